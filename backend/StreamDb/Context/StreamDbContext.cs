@@ -1,57 +1,180 @@
-﻿using Microsoft.EntityFrameworkCore;
-using StreamDb.Models;
-
-namespace StreamDb.Context;
-
-public class StreamDbContext : DbContext
-{
-    public StreamDbContext(DbContextOptions<StreamDbContext> options) : base(options)
-    {
-    }
-    
-    public DbSet<User> Users => Set<User>();
-    public DbSet<Streams> Streams => Set<Streams>();
-    public DbSet<Comments> Comments => Set<Comments>();
-    
-    protected override void OnModelCreating(ModelBuilder modelBuilder)
-    {
-        base.OnModelCreating(modelBuilder);
-
-        // Configure default value for CreatedAt and UpdatedAt
-        foreach (var entityType in modelBuilder.Model.GetEntityTypes())
-        {
-            if (typeof(IHasTimestamps).IsAssignableFrom(entityType.ClrType))
-            {
-                modelBuilder.Entity(entityType.ClrType)
-                    .Property("CreatedAt")
-                    .HasDefaultValueSql("CURRENT_TIMESTAMP");
-
-                modelBuilder.Entity(entityType.ClrType)
-                    .Property("UpdatedAt")
-                    .HasDefaultValueSql("CURRENT_TIMESTAMP");
-            }
-        }
-    }
-    
-    public override Task<int> SaveChangesAsync(CancellationToken cancellationToken = default)
-    {
-        var entries = ChangeTracker
-            .Entries()
-            .Where(e => e.Entity is IHasTimestamps && 
-                        (e.State == EntityState.Added || e.State == EntityState.Modified));
-
-        foreach (var entityEntry in entries)
-        {
-            var entity = (IHasTimestamps)entityEntry.Entity;
-
-            if (entityEntry.State == EntityState.Added)
-            {
-                entity.CreatedAt = DateTime.UtcNow;
-            }
-
-            entity.UpdatedAt = DateTime.UtcNow;
-        }
-
-        return base.SaveChangesAsync(cancellationToken);
-    }
-}
+﻿using Microsoft.EntityFrameworkCore;
+using StreamDb.Models;
+
+namespace StreamDb.Context;
+
+public class StreamDbContext : DbContext
+{
+    public StreamDbContext(DbContextOptions<StreamDbContext> options) : base(options)
+    {
+    }
+    
+    public DbSet<User> Users => Set<User>();
+    public DbSet<Streams> Streams => Set<Streams>();
+    public DbSet<Comments> Comments => Set<Comments>();
+    public DbSet<Collaborators> Collaborators => Set<Collaborators>();
+    public DbSet<RestreamDestinations> RestreamDestinations => Set<RestreamDestinations>();
+    public DbSet<TelemetrySamples> TelemetrySamples => Set<TelemetrySamples>();
+    public DbSet<RenditionLadders> RenditionLadders => Set<RenditionLadders>();
+    public DbSet<Clips> Clips => Set<Clips>();
+    public DbSet<Broadcasts> Broadcasts => Set<Broadcasts>();
+    public DbSet<AuditEntries> AuditEntries => Set<AuditEntries>();
+    public DbSet<ViewerSessions> ViewerSessions => Set<ViewerSessions>();
+    public DbSet<AnalyticsRollups> AnalyticsRollups => Set<AnalyticsRollups>();
+    
+    protected override void OnModelCreating(ModelBuilder modelBuilder)
+    {
+        base.OnModelCreating(modelBuilder);
+
+        // Collaborators reference two users, and a user holds at most one
+        // role per stream or channel of an owner
+        modelBuilder.Entity<Collaborators>(collaborator =>
+        {
+            collaborator.HasOne(c => c.Owner)
+                .WithMany()
+                .HasForeignKey(c => c.OwnerId)
+                .OnDelete(DeleteBehavior.Cascade);
+            collaborator.HasOne(c => c.User)
+                .WithMany()
+                .HasForeignKey(c => c.UserId)
+                .OnDelete(DeleteBehavior.Cascade);
+            collaborator.HasOne(c => c.Stream)
+                .WithMany()
+                .HasForeignKey(c => c.StreamId)
+                .OnDelete(DeleteBehavior.Cascade);
+            collaborator.HasIndex(c => new { c.OwnerId, c.StreamId, c.UserId })
+                .IsUnique()
+                .AreNullsDistinct(false);
+        });
+
+        modelBuilder.Entity<RestreamDestinations>(destination =>
+        {
+            destination.HasOne(d => d.Owner)
+                .WithMany()
+                .HasForeignKey(d => d.OwnerId)
+                .OnDelete(DeleteBehavior.Cascade);
+            destination.HasOne(d => d.Stream)
+                .WithMany()
+                .HasForeignKey(d => d.StreamId)
+                .OnDelete(DeleteBehavior.Cascade);
+        });
+
+        // Samples are listed per stream in the order they were reported, and
+        // expire across streams
+        modelBuilder.Entity<TelemetrySamples>(sample =>
+        {
+            sample.HasOne(s => s.Stream)
+                .WithMany()
+                .HasForeignKey(s => s.StreamId)
+                .OnDelete(DeleteBehavior.Cascade);
+            sample.HasIndex(s => new { s.StreamId, s.ReportedAt });
+            sample.HasIndex(s => s.ReportedAt);
+        });
+
+        // A stream has at most one ladder
+        modelBuilder.Entity<RenditionLadders>(ladder =>
+        {
+            ladder.HasOne(l => l.Stream)
+                .WithMany()
+                .HasForeignKey(l => l.StreamId)
+                .OnDelete(DeleteBehavior.Cascade);
+            ladder.HasIndex(l => l.StreamId)
+                .IsUnique();
+        });
+
+        // Clips are looked up by their public id and listed per stream or
+        // creator
+        modelBuilder.Entity<Clips>(clip =>
+        {
+            clip.HasOne(c => c.Stream)
+                .WithMany()
+                .HasForeignKey(c => c.StreamId)
+                .OnDelete(DeleteBehavior.Cascade);
+            clip.HasIndex(c => c.PublicId)
+                .IsUnique();
+            clip.HasIndex(c => c.StreamId);
+            clip.HasIndex(c => c.CreatorId);
+        });
+
+        // Only the last broadcast of a stream is kept
+        modelBuilder.Entity<Broadcasts>(broadcast =>
+        {
+            broadcast.HasOne(b => b.Stream)
+                .WithMany()
+                .HasForeignKey(b => b.StreamId)
+                .OnDelete(DeleteBehavior.Cascade);
+            broadcast.HasIndex(b => b.StreamId)
+                .IsUnique();
+        });
+
+        // The audit log is read per service, by target or actor, newest
+        // first
+        modelBuilder.Entity<AuditEntries>(entry =>
+        {
+            entry.HasIndex(e => new { e.Service, e.TargetType, e.TargetId });
+            entry.HasIndex(e => new { e.Service, e.ActorId });
+            entry.HasIndex(e => e.RecordedAt);
+        });
+
+        // Sessions are looked up by their public id and listed per stream in
+        // the order they were joined, and a stream has at most one rollup
+        modelBuilder.Entity<ViewerSessions>(session =>
+        {
+            session.HasOne(s => s.Stream)
+                .WithMany()
+                .HasForeignKey(s => s.StreamId)
+                .OnDelete(DeleteBehavior.Cascade);
+            session.HasIndex(s => s.PublicId)
+                .IsUnique();
+            session.HasIndex(s => new { s.StreamId, s.JoinedAt });
+            session.HasIndex(s => s.LeftAt);
+        });
+
+        modelBuilder.Entity<AnalyticsRollups>(rollup =>
+        {
+            rollup.HasOne(r => r.Stream)
+                .WithMany()
+                .HasForeignKey(r => r.StreamId)
+                .OnDelete(DeleteBehavior.Cascade);
+            rollup.HasIndex(r => r.StreamId)
+                .IsUnique();
+        });
+
+        // Configure default value for CreatedAt and UpdatedAt
+        foreach (var entityType in modelBuilder.Model.GetEntityTypes())
+        {
+            if (typeof(IHasTimestamps).IsAssignableFrom(entityType.ClrType))
+            {
+                modelBuilder.Entity(entityType.ClrType)
+                    .Property("CreatedAt")
+                    .HasDefaultValueSql("CURRENT_TIMESTAMP");
+
+                modelBuilder.Entity(entityType.ClrType)
+                    .Property("UpdatedAt")
+                    .HasDefaultValueSql("CURRENT_TIMESTAMP");
+            }
+        }
+    }
+    
+    public override Task<int> SaveChangesAsync(CancellationToken cancellationToken = default)
+    {
+        var entries = ChangeTracker
+            .Entries()
+            .Where(e => e.Entity is IHasTimestamps && 
+                        (e.State == EntityState.Added || e.State == EntityState.Modified));
+
+        foreach (var entityEntry in entries)
+        {
+            var entity = (IHasTimestamps)entityEntry.Entity;
+
+            if (entityEntry.State == EntityState.Added)
+            {
+                entity.CreatedAt = DateTime.UtcNow;
+            }
+
+            entity.UpdatedAt = DateTime.UtcNow;
+        }
+
+        return base.SaveChangesAsync(cancellationToken);
+    }
+}
@@ -0,0 +1,61 @@
+using System;
+using Microsoft.EntityFrameworkCore.Migrations;
+using Npgsql.EntityFrameworkCore.PostgreSQL.Metadata;
+
+#nullable disable
+
+namespace StreamDb.Migrations
+{
+    /// <inheritdoc />
+    public partial class Add_telemetry_samples : Migration
+    {
+        /// <inheritdoc />
+        protected override void Up(MigrationBuilder migrationBuilder)
+        {
+            migrationBuilder.CreateTable(
+                name: "TelemetrySamples",
+                columns: table => new
+                {
+                    Id = table.Column<int>(type: "integer", nullable: false)
+                        .Annotation("Npgsql:ValueGenerationStrategy", NpgsqlValueGenerationStrategy.IdentityByDefaultColumn),
+                    stream_id = table.Column<int>(type: "integer", nullable: false),
+                    reported_at = table.Column<DateTime>(type: "timestamp with time zone", nullable: false),
+                    bitrate_kbps = table.Column<int>(type: "integer", nullable: false),
+                    framerate = table.Column<double>(type: "double precision", nullable: false),
+                    dropped_frames = table.Column<int>(type: "integer", nullable: false),
+                    keyframe_interval_seconds = table.Column<double>(type: "double precision", nullable: false),
+                    audio_level_db = table.Column<double>(type: "double precision", nullable: true),
+                    created_at = table.Column<DateTime>(type: "timestamp with time zone", nullable: false, defaultValueSql: "CURRENT_TIMESTAMP"),
+                    updated_at = table.Column<DateTime>(type: "timestamp with time zone", nullable: false, defaultValueSql: "CURRENT_TIMESTAMP"),
+                    deleted_at = table.Column<DateTime>(type: "timestamp with time zone", nullable: true)
+                },
+                constraints: table =>
+                {
+                    table.PrimaryKey("PK_TelemetrySamples", x => x.Id);
+                    table.ForeignKey(
+                        name: "FK_TelemetrySamples_Streams_stream_id",
+                        column: x => x.stream_id,
+                        principalTable: "Streams",
+                        principalColumn: "Id",
+                        onDelete: ReferentialAction.Cascade);
+                });
+
+            migrationBuilder.CreateIndex(
+                name: "IX_TelemetrySamples_reported_at",
+                table: "TelemetrySamples",
+                column: "reported_at");
+
+            migrationBuilder.CreateIndex(
+                name: "IX_TelemetrySamples_stream_id_reported_at",
+                table: "TelemetrySamples",
+                columns: new[] { "stream_id", "reported_at" });
+        }
+
+        /// <inheritdoc />
+        protected override void Down(MigrationBuilder migrationBuilder)
+        {
+            migrationBuilder.DropTable(
+                name: "TelemetrySamples");
+        }
+    }
+}
@@ -0,0 +1,64 @@
+﻿using System;
+using Microsoft.EntityFrameworkCore.Migrations;
+using Npgsql.EntityFrameworkCore.PostgreSQL.Metadata;
+
+#nullable disable
+
+namespace StreamDb.Migrations
+{
+    /// <inheritdoc />
+    public partial class Add_audit_entries : Migration
+    {
+        /// <inheritdoc />
+        protected override void Up(MigrationBuilder migrationBuilder)
+        {
+            migrationBuilder.CreateTable(
+                name: "AuditEntries",
+                columns: table => new
+                {
+                    Id = table.Column<int>(type: "integer", nullable: false)
+                        .Annotation("Npgsql:ValueGenerationStrategy", NpgsqlValueGenerationStrategy.IdentityByDefaultColumn),
+                    recorded_at = table.Column<DateTime>(type: "timestamp with time zone", nullable: false),
+                    service = table.Column<string>(type: "character varying(50)", maxLength: 50, nullable: false),
+                    method = table.Column<string>(type: "character varying(200)", maxLength: 200, nullable: false),
+                    action = table.Column<string>(type: "character varying(20)", maxLength: 20, nullable: false),
+                    actor_id = table.Column<long>(type: "bigint", nullable: false),
+                    actor_roles = table.Column<string>(type: "character varying(200)", maxLength: 200, nullable: false),
+                    actor_service = table.Column<string>(type: "character varying(50)", maxLength: 50, nullable: false),
+                    target_type = table.Column<string>(type: "character varying(50)", maxLength: 50, nullable: false),
+                    target_id = table.Column<string>(type: "character varying(100)", maxLength: 100, nullable: false),
+                    changes = table.Column<string>(type: "jsonb", nullable: false),
+                    request_id = table.Column<string>(type: "character varying(100)", maxLength: 100, nullable: false),
+                    created_at = table.Column<DateTime>(type: "timestamp with time zone", nullable: false, defaultValueSql: "CURRENT_TIMESTAMP"),
+                    updated_at = table.Column<DateTime>(type: "timestamp with time zone", nullable: false, defaultValueSql: "CURRENT_TIMESTAMP"),
+                    deleted_at = table.Column<DateTime>(type: "timestamp with time zone", nullable: true)
+                },
+                constraints: table =>
+                {
+                    table.PrimaryKey("PK_AuditEntries", x => x.Id);
+                });
+
+            migrationBuilder.CreateIndex(
+                name: "IX_AuditEntries_recorded_at",
+                table: "AuditEntries",
+                column: "recorded_at");
+
+            migrationBuilder.CreateIndex(
+                name: "IX_AuditEntries_service_actor_id",
+                table: "AuditEntries",
+                columns: new[] { "service", "actor_id" });
+
+            migrationBuilder.CreateIndex(
+                name: "IX_AuditEntries_service_target_type_target_id",
+                table: "AuditEntries",
+                columns: new[] { "service", "target_type", "target_id" });
+        }
+
+        /// <inheritdoc />
+        protected override void Down(MigrationBuilder migrationBuilder)
+        {
+            migrationBuilder.DropTable(
+                name: "AuditEntries");
+        }
+    }
+}
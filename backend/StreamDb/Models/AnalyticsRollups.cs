@@ -0,0 +1,24 @@
+using System.ComponentModel.DataAnnotations;
+using System.ComponentModel.DataAnnotations.Schema;
+
+namespace StreamDb.Models;
+
+// The audience figures of an ended stream, computed by stream-service from
+// the viewer sessions of the stream
+public class AnalyticsRollups : BaseEntity
+{
+    [Column("stream_id")]
+    [Required]
+    public int StreamId { get; init; }
+    
+    // Kept as the JSON document stream-service sent
+    [Column("summary", TypeName = "jsonb")]
+    [Required]
+    public string Summary { get; set; } = null!;
+    
+    [Column("computed_at")]
+    [Required]
+    public DateTime ComputedAt { get; set; }
+    
+    public Streams Stream { get; init; }
+}
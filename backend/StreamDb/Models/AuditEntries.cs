@@ -0,0 +1,66 @@
+using System.ComponentModel.DataAnnotations;
+using System.ComponentModel.DataAnnotations.Schema;
+
+namespace StreamDb.Models;
+
+// Entries outlive what they describe, so they reference neither users nor
+// streams
+public class AuditEntries : BaseEntity
+{
+    // When the service recorded the change
+    [Column("recorded_at")]
+    [Required]
+    public DateTime RecordedAt { get; init; }
+    
+    [Column("service")]
+    [Required]
+    [MaxLength(50)]
+    public string Service { get; init; } = null!;
+    
+    // The full gRPC method that made the change
+    [Column("method")]
+    [Required]
+    [MaxLength(200)]
+    public string Method { get; init; } = null!;
+    
+    [Column("action")]
+    [Required]
+    [MaxLength(20)]
+    public string Action { get; init; } = null!;
+    
+    // 0 for services calling on their own
+    [Column("actor_id")]
+    public long ActorId { get; init; }
+    
+    // Separated by commas
+    [Column("actor_roles")]
+    [Required]
+    [MaxLength(200)]
+    public string ActorRoles { get; init; } = null!;
+    
+    [Column("actor_service")]
+    [Required]
+    [MaxLength(50)]
+    public string ActorService { get; init; } = null!;
+    
+    [Column("target_type")]
+    [Required]
+    [MaxLength(50)]
+    public string TargetType { get; init; } = null!;
+    
+    [Column("target_id")]
+    [Required]
+    [MaxLength(100)]
+    public string TargetId { get; init; } = null!;
+    
+    // The fields that changed, as a JSON array of objects with field, before
+    // and after
+    [Column("changes", TypeName = "jsonb")]
+    [Required]
+    public string Changes { get; init; } = null!;
+    
+    [Column("request_id")]
+    [Required]
+    [MaxLength(100)]
+    public string RequestId { get; init; } = null!;
+}
@@ -0,0 +1,23 @@
+using System.ComponentModel.DataAnnotations;
+using System.ComponentModel.DataAnnotations.Schema;
+
+namespace StreamDb.Models;
+
+// The last broadcast of a stream, which the segments of its clips are
+// numbered from
+public class Broadcasts : BaseEntity
+{
+    [Column("stream_id")]
+    [Required]
+    public int StreamId { get; init; }
+    
+    [Column("started_at")]
+    [Required]
+    public DateTime StartedAt { get; set; }
+    
+    // Null while the stream is live
+    [Column("ended_at")]
+    public DateTime? EndedAt { get; set; }
+    
+    public Streams Stream { get; init; }
+}
@@ -0,0 +1,52 @@
+using System.ComponentModel.DataAnnotations;
+using System.ComponentModel.DataAnnotations.Schema;
+
+namespace StreamDb.Models;
+
+public class Clips : BaseEntity
+{
+    // The id stream-service links clips with, hard to guess so that clips
+    // of unlisted streams are only found through their link
+    [Column("public_id")]
+    [Required]
+    [MaxLength(64)]
+    public string PublicId { get; init; } = null!;
+    
+    [Column("stream_id")]
+    [Required]
+    public int StreamId { get; init; }
+    
+    [Column("creator_id")]
+    [Required]
+    public int CreatorId { get; init; }
+    
+    [Column("title")]
+    [Required]
+    [MaxLength(100)]
+    public string Title { get; init; } = null!;
+    
+    // "live" for clips of the DVR window, "recording" for finished streams
+    [Column("source")]
+    [Required]
+    [MaxLength(20)]
+    public string Source { get; init; } = null!;
+    
+    // Counted from the start of the broadcast
+    [Column("first_segment")]
+    public int FirstSegment { get; init; }
+    
+    [Column("segments")]
+    public int Segments { get; init; }
+    
+    [Column("segment_duration_ms")]
+    public int SegmentDurationMs { get; init; }
+    
+    // The ladder of the stream when the clip was cut, separated by slashes,
+    // such as "1280x720p30@2500/854x480p30@1000"
+    [Column("renditions")]
+    [Required]
+    [MaxLength(400)]
+    public string Renditions { get; init; } = null!;
+    
+    public Streams Stream { get; init; }
+}
@@ -0,0 +1,28 @@
+using System.ComponentModel.DataAnnotations;
+using System.ComponentModel.DataAnnotations.Schema;
+
+namespace StreamDb.Models;
+
+public class Collaborators : BaseEntity
+{
+    [Column("owner_id")]
+    [Required]
+    public int OwnerId { get; init; }
+    
+    // Null for the grants on every stream of the owner
+    [Column("stream_id")]
+    public int? StreamId { get; init; }
+    
+    [Column("user_id")]
+    [Required]
+    public int UserId { get; init; }
+    
+    [Column("role")]
+    [Required]
+    [MaxLength(20)]
+    public string Role { get; set; } = null!;
+    
+    public User Owner { get; init; }
+    public Streams? Stream { get; init; }
+    public User User { get; init; }
+}
@@ -0,0 +1,8 @@
+namespace StreamDb.Models;
+
+public enum EStreamVisibility
+{
+    PUBLIC,
+    UNLISTED,
+    PRIVATE,
+}
\ No newline at end of file
@@ -0,0 +1,26 @@
+using System.ComponentModel.DataAnnotations;
+using System.ComponentModel.DataAnnotations.Schema;
+
+namespace StreamDb.Models;
+
+public class RenditionLadders : BaseEntity
+{
+    [Column("stream_id")]
+    [Required]
+    public int StreamId { get; init; }
+    
+    // A preset of stream-service, or "custom" for the ladders of Renditions
+    [Column("preset")]
+    [Required]
+    [MaxLength(100)]
+    public string Preset { get; set; } = null!;
+    
+    // The specs of a custom ladder separated by slashes, such as
+    // "1080p60/720p30@2500", empty for presets
+    [Column("renditions")]
+    [Required]
+    [MaxLength(200)]
+    public string Renditions { get; set; } = null!;
+    
+    public Streams Stream { get; init; }
+}
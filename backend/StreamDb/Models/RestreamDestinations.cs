@@ -0,0 +1,42 @@
+using System.ComponentModel.DataAnnotations;
+using System.ComponentModel.DataAnnotations.Schema;
+
+namespace StreamDb.Models;
+
+public class RestreamDestinations : BaseEntity
+{
+    [Column("owner_id")]
+    [Required]
+    public int OwnerId { get; init; }
+    
+    // Null for the destinations of every stream of the owner
+    [Column("stream_id")]
+    public int? StreamId { get; init; }
+    
+    [Column("name")]
+    [Required]
+    [MaxLength(100)]
+    public string Name { get; set; } = null!;
+    
+    [Column("url")]
+    [Required]
+    [MaxLength(1000)]
+    public string Url { get; set; } = null!;
+    
+    // The stream key encrypted by stream-service, which holds the key
+    [Column("sealed_key")]
+    [Required]
+    [MaxLength(1000)]
+    public string SealedKey { get; set; } = null!;
+    
+    [Column("key_hint")]
+    [Required]
+    [MaxLength(10)]
+    public string KeyHint { get; set; } = null!;
+    
+    [Column("enabled")]
+    public bool Enabled { get; set; }
+    
+    public User Owner { get; init; }
+    public Streams? Stream { get; init; }
+}
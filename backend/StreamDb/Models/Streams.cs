@@ -44,6 +44,8 @@ public class Streams : BaseEntity
     public string Protocol { get; set; } = null!;
 
     [Required] public EStreamStatus Status { get; set; } = EStreamStatus.SCHEDULED;
+
+    [Required] public EStreamVisibility Visibility { get; set; } = EStreamVisibility.PUBLIC;
     
     [Column("user_id")]
     [Required]
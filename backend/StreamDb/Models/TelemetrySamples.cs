@@ -0,0 +1,34 @@
+using System.ComponentModel.DataAnnotations;
+using System.ComponentModel.DataAnnotations.Schema;
+
+namespace StreamDb.Models;
+
+public class TelemetrySamples : BaseEntity
+{
+    [Column("stream_id")]
+    [Required]
+    public int StreamId { get; init; }
+    
+    // When stream-service received the sample
+    [Column("reported_at")]
+    [Required]
+    public DateTime ReportedAt { get; init; }
+    
+    [Column("bitrate_kbps")]
+    public int BitrateKbps { get; init; }
+    
+    [Column("framerate")]
+    public double Framerate { get; init; }
+    
+    [Column("dropped_frames")]
+    public int DroppedFrames { get; init; }
+    
+    [Column("keyframe_interval_seconds")]
+    public double KeyframeIntervalSeconds { get; init; }
+    
+    // Null for streams without audio
+    [Column("audio_level_db")]
+    public double? AudioLevelDb { get; init; }
+    
+    public Streams Stream { get; init; }
+}
@@ -0,0 +1,38 @@
+using System.ComponentModel.DataAnnotations;
+using System.ComponentModel.DataAnnotations.Schema;
+
+namespace StreamDb.Models;
+
+public class ViewerSessions : BaseEntity
+{
+    // The id stream-service gives the session and players end it with
+    [Column("public_id")]
+    [Required]
+    [MaxLength(64)]
+    public string PublicId { get; init; } = null!;
+    
+    [Column("stream_id")]
+    [Required]
+    public int StreamId { get; init; }
+    
+    // The user id of signed-in viewers, or the id the player generated
+    [Column("viewer_id")]
+    [Required]
+    [MaxLength(100)]
+    public string ViewerId { get; init; } = null!;
+    
+    [Column("client_type")]
+    [Required]
+    [MaxLength(20)]
+    public string ClientType { get; init; } = null!;
+    
+    [Column("joined_at")]
+    [Required]
+    public DateTime JoinedAt { get; init; }
+    
+    // Null while the viewer is watching
+    [Column("left_at")]
+    public DateTime? LeftAt { get; set; }
+    
+    public Streams Stream { get; init; }
+}
@@ -36,6 +36,13 @@ app.MapGrpcReflectionService();
 app.MapGrpcService<UserService>();
 app.MapGrpcService<StreamService>();
 app.MapGrpcService<CommentService>();
+app.MapGrpcService<CollaboratorService>();
+app.MapGrpcService<RestreamService>();
+app.MapGrpcService<TelemetryService>();
+app.MapGrpcService<RenditionService>();
+app.MapGrpcService<ClipService>();
+app.MapGrpcService<AuditService>();
+app.MapGrpcService<AnalyticsService>();
 app.MapGet("/",
     () => "Communication with gRPC endpoints must be made through a gRPC client.");
 
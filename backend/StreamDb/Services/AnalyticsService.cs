@@ -0,0 +1,382 @@
+using System.Globalization;
+using Grpc.Core;
+using Microsoft.EntityFrameworkCore;
+using StreamDb.Context;
+using StreamDb.Models;
+using StreamDb.Protos;
+using Google.Protobuf.WellKnownTypes;
+
+namespace StreamDb.Services;
+
+public class AnalyticsService(StreamDbContext context) : Protos.AnalyticsService.AnalyticsServiceBase
+{
+    public override async Task<ViewerSessionResponse> AddViewerSession(AddViewerSessionRequest request, ServerCallContext context1)
+    {
+        var joinedAt = ValidateAddRequest(request);
+
+        var stream = await context.Streams
+            .AsNoTracking()
+            .FirstOrDefaultAsync(s => s.Id == request.StreamId && s.DeletedAt == null);
+
+        if (stream == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Stream not found"));
+        }
+
+        if (await context.ViewerSessions.AnyAsync(s => s.PublicId == request.Id))
+        {
+            throw new RpcException(new Status(StatusCode.AlreadyExists, "Viewer session already exists"));
+        }
+
+        var session = new ViewerSessions
+        {
+            PublicId = request.Id,
+            StreamId = request.StreamId,
+            ViewerId = request.ViewerId,
+            ClientType = request.ClientType,
+            JoinedAt = joinedAt,
+            CreatedAt = DateTime.UtcNow
+        };
+
+        try
+        {
+            // the stream is live again, so its rollup is out of date
+            await context.AnalyticsRollups
+                .Where(r => r.StreamId == request.StreamId)
+                .ExecuteDeleteAsync();
+
+            context.ViewerSessions.Add(session);
+            await context.SaveChangesAsync();
+            return CreateSessionResponse(session);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to add viewer session: {ex.Message}"));
+        }
+    }
+
+    public override async Task<ViewerSessionResponse> GetViewerSession(GetViewerSessionRequest request, ServerCallContext context1)
+    {
+        if (string.IsNullOrWhiteSpace(request.Id))
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Viewer session ID is required"));
+        }
+
+        var session = await context.ViewerSessions
+            .AsNoTracking()
+            .FirstOrDefaultAsync(s => s.PublicId == request.Id);
+
+        if (session == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Viewer session not found"));
+        }
+
+        return CreateSessionResponse(session);
+    }
+
+    public override async Task<ViewerSessionResponse> EndViewerSession(EndViewerSessionRequest request, ServerCallContext context1)
+    {
+        var errors = new List<string>();
+
+        if (string.IsNullOrWhiteSpace(request.Id))
+            errors.Add("Viewer session ID is required");
+
+        if (!TryParseTime(request.LeftAt, out var leftAt))
+            errors.Add("Invalid left at time");
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+
+        var session = await context.ViewerSessions.FirstOrDefaultAsync(s => s.PublicId == request.Id);
+
+        if (session == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Viewer session not found"));
+        }
+
+        if (session.LeftAt != null)
+        {
+            return CreateSessionResponse(session);
+        }
+
+        try
+        {
+            session.LeftAt = leftAt < session.JoinedAt ? session.JoinedAt : leftAt;
+            await context.SaveChangesAsync();
+            return CreateSessionResponse(session);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to end viewer session: {ex.Message}"));
+        }
+    }
+
+    public override async Task<ListViewerSessionsResponse> ListViewerSessions(ListViewerSessionsRequest request, ServerCallContext context1)
+    {
+        if (request.StreamId <= 0)
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid stream ID"));
+        }
+
+        try
+        {
+            var query = context.ViewerSessions
+                .AsNoTracking()
+                .Where(s => s.StreamId == request.StreamId);
+
+            if (!string.IsNullOrEmpty(request.ViewerId))
+                query = query.Where(s => s.ViewerId == request.ViewerId);
+
+            if (request.Open)
+                query = query.Where(s => s.LeftAt == null);
+
+            var sessions = await query
+                .OrderBy(s => s.JoinedAt)
+                .ThenBy(s => s.Id)
+                .ToListAsync();
+
+            return new ListViewerSessionsResponse
+            {
+                Sessions = { sessions.Select(CreateSessionResponse) }
+            };
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to retrieve viewer sessions: {ex.Message}"));
+        }
+    }
+
+    public override async Task<CountOpenViewerSessionsResponse> CountOpenViewerSessions(Empty request, ServerCallContext context1)
+    {
+        try
+        {
+            var count = await context.ViewerSessions.CountAsync(s => s.LeftAt == null);
+            return new CountOpenViewerSessionsResponse { Count = count };
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to count viewer sessions: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> DeleteViewerSessions(DeleteViewerSessionsRequest request, ServerCallContext context1)
+    {
+        if (!TryParseTime(request.RolledUpBefore, out var rolledUpBefore))
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid rolled up before time"));
+        }
+
+        try
+        {
+            await context.ViewerSessions
+                .Where(s => context.AnalyticsRollups.Any(r => r.StreamId == s.StreamId && r.ComputedAt < rolledUpBefore))
+                .ExecuteDeleteAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to delete viewer sessions: {ex.Message}"));
+        }
+    }
+
+    public override async Task<RollupResponse> SetRollup(SetRollupRequest request, ServerCallContext context1)
+    {
+        var (computedAt, closeAt) = ValidateRollupRequest(request);
+
+        // streams are rolled up once deleted too, until they are purged
+        var stream = await context.Streams
+            .AsNoTracking()
+            .FirstOrDefaultAsync(s => s.Id == request.StreamId);
+
+        if (stream == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Stream not found"));
+        }
+
+        try
+        {
+            await context.ViewerSessions
+                .Where(s => s.StreamId == request.StreamId && s.LeftAt == null)
+                .ExecuteUpdateAsync(setters => setters
+                    .SetProperty(s => s.LeftAt, s => s.JoinedAt > closeAt ? s.JoinedAt : closeAt)
+                    .SetProperty(s => s.UpdatedAt, DateTime.UtcNow));
+
+            var rollup = await context.AnalyticsRollups.FirstOrDefaultAsync(r => r.StreamId == request.StreamId);
+
+            if (rollup == null)
+            {
+                rollup = new AnalyticsRollups
+                {
+                    StreamId = request.StreamId,
+                    CreatedAt = DateTime.UtcNow
+                };
+                context.AnalyticsRollups.Add(rollup);
+            }
+
+            rollup.Summary = request.Summary;
+            rollup.ComputedAt = computedAt;
+
+            await context.SaveChangesAsync();
+            return CreateRollupResponse(rollup);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to set rollup: {ex.Message}"));
+        }
+    }
+
+    public override async Task<RollupResponse> GetRollup(GetRollupRequest request, ServerCallContext context1)
+    {
+        if (request.StreamId <= 0)
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid stream ID"));
+        }
+
+        var rollup = await context.AnalyticsRollups
+            .AsNoTracking()
+            .FirstOrDefaultAsync(r => r.StreamId == request.StreamId);
+
+        if (rollup == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Rollup not found"));
+        }
+
+        return CreateRollupResponse(rollup);
+    }
+
+    public override async Task<ListPendingRollupsResponse> ListPendingRollups(Empty request, ServerCallContext context1)
+    {
+        try
+        {
+            var streamIds = await context.ViewerSessions
+                .Where(s => !context.AnalyticsRollups.Any(r => r.StreamId == s.StreamId))
+                .Select(s => s.StreamId)
+                .Distinct()
+                .OrderBy(id => id)
+                .ToListAsync();
+
+            return new ListPendingRollupsResponse
+            {
+                StreamIds = { streamIds }
+            };
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to retrieve pending rollups: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> DeleteStreamAnalytics(DeleteStreamAnalyticsRequest request, ServerCallContext context1)
+    {
+        if (request.StreamId <= 0)
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid stream ID"));
+        }
+
+        try
+        {
+            await context.ViewerSessions
+                .Where(s => s.StreamId == request.StreamId)
+                .ExecuteDeleteAsync();
+            await context.AnalyticsRollups
+                .Where(r => r.StreamId == request.StreamId)
+                .ExecuteDeleteAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to delete stream analytics: {ex.Message}"));
+        }
+    }
+
+    #region Validation Methods
+
+    private static DateTime ValidateAddRequest(AddViewerSessionRequest request)
+    {
+        var errors = new List<string>();
+
+        if (string.IsNullOrWhiteSpace(request.Id))
+            errors.Add("Viewer session ID is required");
+
+        if (request.StreamId <= 0)
+            errors.Add("Invalid stream ID");
+
+        if (string.IsNullOrWhiteSpace(request.ViewerId))
+            errors.Add("Viewer ID is required");
+
+        if (string.IsNullOrWhiteSpace(request.ClientType))
+            errors.Add("Client type is required");
+
+        if (!TryParseTime(request.JoinedAt, out var joinedAt))
+            errors.Add("Invalid joined at time");
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+
+        return joinedAt;
+    }
+
+    private static (DateTime ComputedAt, DateTime CloseAt) ValidateRollupRequest(SetRollupRequest request)
+    {
+        var errors = new List<string>();
+
+        if (request.StreamId <= 0)
+            errors.Add("Invalid stream ID");
+
+        if (string.IsNullOrWhiteSpace(request.Summary))
+            errors.Add("Summary is required");
+
+        if (!TryParseTime(request.ComputedAt, out var computedAt))
+            errors.Add("Invalid computed at time");
+
+        if (!TryParseTime(request.CloseSessionsAt, out var closeAt))
+            errors.Add("Invalid close sessions at time");
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+
+        return (computedAt, closeAt);
+    }
+
+    #endregion
+
+    #region Helper Methods
+
+    private static bool TryParseTime(string value, out DateTime time)
+    {
+        if (DateTime.TryParse(value, CultureInfo.InvariantCulture, DateTimeStyles.AdjustToUniversal | DateTimeStyles.AssumeUniversal, out time))
+            return true;
+
+        time = default;
+        return false;
+    }
+
+    private static ViewerSessionResponse CreateSessionResponse(ViewerSessions session)
+    {
+        var response = new ViewerSessionResponse
+        {
+            Id = session.PublicId,
+            StreamId = session.StreamId,
+            ViewerId = session.ViewerId,
+            ClientType = session.ClientType,
+            JoinedAt = session.JoinedAt.ToString("O")
+        };
+
+        if (session.LeftAt != null)
+            response.LeftAt = session.LeftAt.Value.ToString("O");
+
+        return response;
+    }
+
+    private static RollupResponse CreateRollupResponse(AnalyticsRollups rollup)
+    {
+        return new RollupResponse
+        {
+            StreamId = rollup.StreamId,
+            Summary = rollup.Summary,
+            ComputedAt = rollup.ComputedAt.ToString("O")
+        };
+    }
+
+    #endregion
+}
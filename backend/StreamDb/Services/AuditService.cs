@@ -0,0 +1,206 @@
+using System.Globalization;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using Grpc.Core;
+using Microsoft.EntityFrameworkCore;
+using StreamDb.Context;
+using StreamDb.Models;
+using StreamDb.Protos;
+
+namespace StreamDb.Services;
+
+public class AuditService(StreamDbContext context) : Protos.AuditService.AuditServiceBase
+{
+    private const int DefaultPageSize = 20;
+    private const int MaxPageSize = 100;
+
+    public override async Task<AuditEntry> AppendAuditEntry(AuditEntry request, ServerCallContext context1)
+    {
+        var recordedAt = ValidateAppendRequest(request);
+
+        var entry = new AuditEntries
+        {
+            RecordedAt = recordedAt,
+            Service = request.Service,
+            Method = request.Method,
+            Action = request.Action,
+            ActorId = request.ActorId,
+            ActorRoles = string.Join(',', request.ActorRoles),
+            ActorService = request.ActorService,
+            TargetType = request.TargetType,
+            TargetId = request.TargetId,
+            Changes = JsonSerializer.Serialize(request.Changes.Select(c => new StoredChange(c.Field, c.Before, c.After))),
+            RequestId = request.RequestId,
+            CreatedAt = DateTime.UtcNow
+        };
+
+        try
+        {
+            context.AuditEntries.Add(entry);
+            await context.SaveChangesAsync();
+            return CreateEntryResponse(entry);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to append audit entry: {ex.Message}"));
+        }
+    }
+
+    public override async Task<QueryAuditLogResponse> QueryAuditLog(QueryAuditLogRequest request, ServerCallContext context1)
+    {
+        var errors = new List<string>();
+
+        if (string.IsNullOrWhiteSpace(request.Service))
+            errors.Add("Service is required");
+
+        if (request.PageNumber < 0 || request.PageSize < 0)
+            errors.Add("Page number and size must not be negative");
+
+        DateTime? since = null;
+        if (!string.IsNullOrEmpty(request.Since))
+        {
+            if (TryParseTime(request.Since, out var parsed))
+                since = parsed;
+            else
+                errors.Add("Invalid since time");
+        }
+
+        DateTime? until = null;
+        if (!string.IsNullOrEmpty(request.Until))
+        {
+            if (TryParseTime(request.Until, out var parsed))
+                until = parsed;
+            else
+                errors.Add("Invalid until time");
+        }
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+
+        try
+        {
+            var query = context.AuditEntries
+                .AsNoTracking()
+                .Where(e => e.Service == request.Service);
+
+            if (request.ActorId != 0)
+                query = query.Where(e => e.ActorId == request.ActorId);
+
+            if (!string.IsNullOrEmpty(request.ActorService))
+                query = query.Where(e => e.ActorService == request.ActorService);
+
+            if (!string.IsNullOrEmpty(request.TargetType))
+                query = query.Where(e => e.TargetType == request.TargetType);
+
+            if (!string.IsNullOrEmpty(request.TargetId))
+                query = query.Where(e => e.TargetId == request.TargetId);
+
+            if (since != null)
+                query = query.Where(e => e.RecordedAt >= since);
+
+            if (until != null)
+                query = query.Where(e => e.RecordedAt < until);
+
+            var totalCount = await query.CountAsync();
+
+            var pageSize = request.PageSize <= 0 ? DefaultPageSize : Math.Min(request.PageSize, MaxPageSize);
+            var pageNumber = request.PageNumber <= 0 ? 1 : request.PageNumber;
+
+            var entries = await query
+                .OrderByDescending(e => e.Id)
+                .Skip((pageNumber - 1) * pageSize)
+                .Take(pageSize)
+                .ToListAsync();
+
+            return new QueryAuditLogResponse
+            {
+                Entries = { entries.Select(CreateEntryResponse) },
+                TotalCount = totalCount
+            };
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to retrieve audit entries: {ex.Message}"));
+        }
+    }
+
+    #region Validation Methods
+
+    private static DateTime ValidateAppendRequest(AuditEntry request)
+    {
+        var errors = new List<string>();
+
+        if (!TryParseTime(request.RecordedAt, out var recordedAt))
+            errors.Add("Invalid recorded at time");
+
+        if (string.IsNullOrWhiteSpace(request.Service))
+            errors.Add("Service is required");
+
+        if (string.IsNullOrWhiteSpace(request.Method))
+            errors.Add("Method is required");
+
+        if (string.IsNullOrWhiteSpace(request.Action))
+            errors.Add("Action is required");
+
+        if (string.IsNullOrWhiteSpace(request.TargetType))
+            errors.Add("Target type is required");
+
+        if (request.ActorId < 0)
+            errors.Add("Invalid actor ID");
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+
+        return recordedAt;
+    }
+
+    #endregion
+
+    #region Helper Methods
+
+    private record StoredChange(
+        [property: JsonPropertyName("field")] string Field,
+        [property: JsonPropertyName("before")] string Before,
+        [property: JsonPropertyName("after")] string After);
+
+    private static bool TryParseTime(string value, out DateTime time)
+    {
+        if (DateTime.TryParse(value, CultureInfo.InvariantCulture, DateTimeStyles.AdjustToUniversal | DateTimeStyles.AssumeUniversal, out time))
+            return true;
+
+        time = default;
+        return false;
+    }
+
+    private static AuditEntry CreateEntryResponse(AuditEntries entry)
+    {
+        var response = new AuditEntry
+        {
+            Id = entry.Id,
+            RecordedAt = entry.RecordedAt.ToString("O"),
+            Service = entry.Service,
+            Method = entry.Method,
+            Action = entry.Action,
+            ActorId = entry.ActorId,
+            ActorService = entry.ActorService,
+            TargetType = entry.TargetType,
+            TargetId = entry.TargetId,
+            RequestId = entry.RequestId
+        };
+
+        if (entry.ActorRoles.Length > 0)
+            response.ActorRoles.AddRange(entry.ActorRoles.Split(','));
+
+        var changes = JsonSerializer.Deserialize<List<StoredChange>>(entry.Changes) ?? [];
+        response.Changes.AddRange(changes.Select(c => new AuditChange
+        {
+            Field = c.Field,
+            Before = c.Before,
+            After = c.After
+        }));
+
+        return response;
+    }
+
+    #endregion
+}
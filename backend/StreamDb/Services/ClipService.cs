@@ -0,0 +1,335 @@
+using Grpc.Core;
+using Microsoft.EntityFrameworkCore;
+using StreamDb.Context;
+using StreamDb.Models;
+using StreamDb.Protos;
+using Google.Protobuf.WellKnownTypes;
+
+namespace StreamDb.Services;
+
+public class ClipService(StreamDbContext context) : Protos.ClipService.ClipServiceBase
+{
+    public override async Task<ClipResponse> CreateClip(CreateClipRequest request, ServerCallContext context1)
+    {
+        ValidateCreateRequest(request);
+
+        var stream = await context.Streams
+            .AsNoTracking()
+            .FirstOrDefaultAsync(s => s.Id == request.StreamId && s.DeletedAt == null);
+
+        if (stream == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Stream not found"));
+        }
+
+        if (await context.Clips.AnyAsync(c => c.PublicId == request.Id))
+        {
+            throw new RpcException(new Status(StatusCode.AlreadyExists, "Clip already exists"));
+        }
+
+        var clip = new Clips
+        {
+            PublicId = request.Id,
+            StreamId = request.StreamId,
+            CreatorId = request.CreatorId,
+            Title = request.Title.Trim(),
+            Source = request.Source,
+            FirstSegment = request.FirstSegment,
+            Segments = request.Segments,
+            SegmentDurationMs = request.SegmentDurationMs,
+            Renditions = string.Join('/', request.Renditions.Select(r => r.Trim())),
+            CreatedAt = DateTime.UtcNow
+        };
+
+        try
+        {
+            context.Clips.Add(clip);
+            await context.SaveChangesAsync();
+            return CreateClipResponse(clip);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to create clip: {ex.Message}"));
+        }
+    }
+
+    public override async Task<ClipResponse> GetClip(GetClipRequest request, ServerCallContext context1)
+    {
+        if (string.IsNullOrWhiteSpace(request.Id))
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Clip ID is required"));
+        }
+
+        var clip = await context.Clips
+            .AsNoTracking()
+            .FirstOrDefaultAsync(c => c.PublicId == request.Id);
+
+        if (clip == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Clip not found"));
+        }
+
+        return CreateClipResponse(clip);
+    }
+
+    public override async Task<ListClipsResponse> ListClips(ListClipsRequest request, ServerCallContext context1)
+    {
+        var errors = new List<string>();
+
+        if (request.StreamId < 0)
+            errors.Add("Invalid stream ID");
+
+        if (request.CreatorId < 0)
+            errors.Add("Invalid creator ID");
+
+        if (request.StreamId == 0 && request.CreatorId == 0)
+            errors.Add("Stream ID or creator ID is required");
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+
+        try
+        {
+            var query = context.Clips.AsNoTracking();
+
+            if (request.StreamId > 0)
+                query = query.Where(c => c.StreamId == request.StreamId);
+
+            if (request.CreatorId > 0)
+                query = query.Where(c => c.CreatorId == request.CreatorId);
+
+            var clips = await query
+                .OrderByDescending(c => c.CreatedAt)
+                .ThenByDescending(c => c.FirstSegment)
+                .ToListAsync();
+
+            return new ListClipsResponse
+            {
+                Clips = { clips.Select(CreateClipResponse) }
+            };
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to retrieve clips: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> DeleteClip(DeleteClipRequest request, ServerCallContext context1)
+    {
+        if (string.IsNullOrWhiteSpace(request.Id))
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Clip ID is required"));
+        }
+
+        var clip = await context.Clips.FirstOrDefaultAsync(c => c.PublicId == request.Id);
+
+        if (clip == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Clip not found"));
+        }
+
+        try
+        {
+            context.Clips.Remove(clip);
+            await context.SaveChangesAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to delete clip: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> DeleteStreamClips(DeleteStreamClipsRequest request, ServerCallContext context1)
+    {
+        if (request.StreamId <= 0)
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid stream ID"));
+        }
+
+        try
+        {
+            await context.Clips
+                .Where(c => c.StreamId == request.StreamId)
+                .ExecuteDeleteAsync();
+            await context.Broadcasts
+                .Where(b => b.StreamId == request.StreamId)
+                .ExecuteDeleteAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to delete clips: {ex.Message}"));
+        }
+    }
+
+    // Starts a new broadcast unless the stream is already live, so that
+    // repeated calls keep the timeline of the running broadcast
+    public override async Task<BroadcastResponse> StartBroadcast(BroadcastRequest request, ServerCallContext context1)
+    {
+        ValidateStreamId(request.StreamId);
+
+        var stream = await context.Streams
+            .AsNoTracking()
+            .FirstOrDefaultAsync(s => s.Id == request.StreamId && s.DeletedAt == null);
+
+        if (stream == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Stream not found"));
+        }
+
+        var broadcast = await context.Broadcasts
+            .FirstOrDefaultAsync(b => b.StreamId == request.StreamId);
+
+        if (broadcast != null && broadcast.EndedAt == null)
+        {
+            return CreateBroadcastResponse(broadcast);
+        }
+
+        if (broadcast == null)
+        {
+            broadcast = new Broadcasts
+            {
+                StreamId = request.StreamId,
+                CreatedAt = DateTime.UtcNow
+            };
+            context.Broadcasts.Add(broadcast);
+        }
+
+        broadcast.StartedAt = DateTime.UtcNow;
+        broadcast.EndedAt = null;
+
+        try
+        {
+            await context.SaveChangesAsync();
+            return CreateBroadcastResponse(broadcast);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to start broadcast: {ex.Message}"));
+        }
+    }
+
+    // Ends the running broadcast. Broadcasts that already ended are returned
+    // as they are.
+    public override async Task<BroadcastResponse> EndBroadcast(BroadcastRequest request, ServerCallContext context1)
+    {
+        ValidateStreamId(request.StreamId);
+
+        var broadcast = await context.Broadcasts
+            .FirstOrDefaultAsync(b => b.StreamId == request.StreamId);
+
+        if (broadcast == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Broadcast not found"));
+        }
+
+        if (broadcast.EndedAt != null)
+        {
+            return CreateBroadcastResponse(broadcast);
+        }
+
+        broadcast.EndedAt = DateTime.UtcNow;
+
+        try
+        {
+            await context.SaveChangesAsync();
+            return CreateBroadcastResponse(broadcast);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to end broadcast: {ex.Message}"));
+        }
+    }
+
+    public override async Task<BroadcastResponse> GetBroadcast(BroadcastRequest request, ServerCallContext context1)
+    {
+        ValidateStreamId(request.StreamId);
+
+        var broadcast = await context.Broadcasts
+            .AsNoTracking()
+            .FirstOrDefaultAsync(b => b.StreamId == request.StreamId);
+
+        if (broadcast == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Broadcast not found"));
+        }
+
+        return CreateBroadcastResponse(broadcast);
+    }
+
+    #region Validation Methods
+
+    private static void ValidateCreateRequest(CreateClipRequest request)
+    {
+        var errors = new List<string>();
+
+        if (string.IsNullOrWhiteSpace(request.Id))
+            errors.Add("Clip ID is required");
+
+        if (request.StreamId <= 0)
+            errors.Add("Invalid stream ID");
+
+        if (request.CreatorId <= 0)
+            errors.Add("Invalid creator ID");
+
+        if (string.IsNullOrWhiteSpace(request.Source))
+            errors.Add("Source is required");
+
+        if (request.FirstSegment < 0)
+            errors.Add("First segment must not be negative");
+
+        if (request.Segments <= 0)
+            errors.Add("Segments must be positive");
+
+        if (request.SegmentDurationMs <= 0)
+            errors.Add("Segment duration must be positive");
+
+        if (request.Renditions.Count == 0)
+            errors.Add("Renditions are required");
+        else if (request.Renditions.Any(string.IsNullOrWhiteSpace))
+            errors.Add("Renditions must not be blank");
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+    }
+
+    private static void ValidateStreamId(int streamId)
+    {
+        if (streamId <= 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid stream ID"));
+    }
+
+    #endregion
+
+    #region Helper Methods
+
+    private static ClipResponse CreateClipResponse(Clips clip)
+    {
+        return new ClipResponse
+        {
+            Id = clip.PublicId,
+            StreamId = clip.StreamId,
+            CreatorId = clip.CreatorId,
+            Title = clip.Title,
+            Source = clip.Source,
+            FirstSegment = clip.FirstSegment,
+            Segments = clip.Segments,
+            SegmentDurationMs = clip.SegmentDurationMs,
+            Renditions = { clip.Renditions.Split('/') },
+            CreatedAt = clip.CreatedAt.ToString("O")
+        };
+    }
+
+    private static BroadcastResponse CreateBroadcastResponse(Broadcasts broadcast)
+    {
+        return new BroadcastResponse
+        {
+            StreamId = broadcast.StreamId,
+            StartedAt = broadcast.StartedAt.ToString("O"),
+            EndedAt = broadcast.EndedAt?.ToString("O") ?? ""
+        };
+    }
+
+    #endregion
+}
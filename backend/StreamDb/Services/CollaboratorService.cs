@@ -0,0 +1,194 @@
+using Grpc.Core;
+using Microsoft.EntityFrameworkCore;
+using StreamDb.Context;
+using StreamDb.Models;
+using StreamDb.Protos;
+using Google.Protobuf.WellKnownTypes;
+
+namespace StreamDb.Services;
+
+public class CollaboratorService(StreamDbContext context) : Protos.CollaboratorService.CollaboratorServiceBase
+{
+    public override async Task<CollaboratorResponse> SetCollaborator(SetCollaboratorRequest request, ServerCallContext context1)
+    {
+        ValidateSetRequest(request);
+
+        await ValidateRelationships(request.OwnerId, request.StreamId, request.UserId);
+
+        var streamId = StreamIdOrNull(request.StreamId);
+        var collaborator = await context.Collaborators
+            .FirstOrDefaultAsync(c => c.OwnerId == request.OwnerId && c.StreamId == streamId && c.UserId == request.UserId);
+
+        if (collaborator == null)
+        {
+            collaborator = new Collaborators
+            {
+                OwnerId = request.OwnerId,
+                StreamId = streamId,
+                UserId = request.UserId,
+                CreatedAt = DateTime.UtcNow
+            };
+            context.Collaborators.Add(collaborator);
+        }
+
+        collaborator.Role = request.Role.Trim();
+
+        try
+        {
+            await context.SaveChangesAsync();
+            return CreateCollaboratorResponse(collaborator);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to set collaborator: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> DeleteCollaborator(DeleteCollaboratorRequest request, ServerCallContext context1)
+    {
+        var streamId = StreamIdOrNull(request.StreamId);
+        var collaborator = await context.Collaborators
+            .FirstOrDefaultAsync(c => c.OwnerId == request.OwnerId && c.StreamId == streamId && c.UserId == request.UserId);
+
+        if (collaborator == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Collaborator not found"));
+        }
+
+        try
+        {
+            context.Collaborators.Remove(collaborator);
+            await context.SaveChangesAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to delete collaborator: {ex.Message}"));
+        }
+    }
+
+    public override async Task<ListCollaboratorsResponse> ListCollaborators(ListCollaboratorsRequest request, ServerCallContext context1)
+    {
+        if (request.OwnerId <= 0)
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid owner ID"));
+        }
+
+        try
+        {
+            var streamId = StreamIdOrNull(request.StreamId);
+            var query = context.Collaborators
+                .AsNoTracking()
+                .Where(c => c.OwnerId == request.OwnerId && (c.StreamId == null || c.StreamId == streamId));
+
+            if (request.UserId > 0)
+                query = query.Where(c => c.UserId == request.UserId);
+
+            // Channel grants come first
+            var collaborators = await query
+                .OrderBy(c => c.StreamId ?? 0)
+                .ThenBy(c => c.UserId)
+                .ToListAsync();
+
+            return new ListCollaboratorsResponse
+            {
+                Collaborators = { collaborators.Select(CreateCollaboratorResponse) }
+            };
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to retrieve collaborators: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> DeleteStreamCollaborators(DeleteStreamCollaboratorsRequest request, ServerCallContext context1)
+    {
+        if (request.StreamId <= 0)
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid stream ID"));
+        }
+
+        try
+        {
+            await context.Collaborators
+                .Where(c => c.StreamId == request.StreamId)
+                .ExecuteDeleteAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to delete collaborators: {ex.Message}"));
+        }
+    }
+
+    #region Validation Methods
+
+    private static void ValidateSetRequest(SetCollaboratorRequest request)
+    {
+        var errors = new List<string>();
+
+        if (request.OwnerId <= 0)
+            errors.Add("Invalid owner ID");
+
+        if (request.UserId <= 0)
+            errors.Add("Invalid user ID");
+
+        if (request.StreamId < 0)
+            errors.Add("Invalid stream ID");
+
+        if (request.UserId == request.OwnerId)
+            errors.Add("The owner cannot be a collaborator");
+
+        if (string.IsNullOrWhiteSpace(request.Role))
+            errors.Add("Role is required");
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+    }
+
+    private async Task ValidateRelationships(int ownerId, int streamId, int userId)
+    {
+        var users = await context.Users
+            .AsNoTracking()
+            .Where(u => (u.Id == ownerId || u.Id == userId) && u.DeletedAt == null)
+            .CountAsync();
+
+        if (users != 2)
+            throw new RpcException(new Status(StatusCode.NotFound, "User not found"));
+
+        if (streamId == 0)
+            return;
+
+        var stream = await context.Streams
+            .AsNoTracking()
+            .FirstOrDefaultAsync(s => s.Id == streamId && s.UserId == ownerId && s.DeletedAt == null);
+
+        if (stream == null)
+            throw new RpcException(new Status(StatusCode.NotFound, "Stream not found"));
+    }
+
+    #endregion
+
+    #region Helper Methods
+
+    // Channel grants are stored without a stream
+    private static int? StreamIdOrNull(int streamId)
+    {
+        return streamId > 0 ? streamId : null;
+    }
+
+    private static CollaboratorResponse CreateCollaboratorResponse(Collaborators collaborator)
+    {
+        return new CollaboratorResponse
+        {
+            Id = collaborator.Id,
+            OwnerId = collaborator.OwnerId,
+            StreamId = collaborator.StreamId ?? 0,
+            UserId = collaborator.UserId,
+            Role = collaborator.Role,
+            CreatedAt = collaborator.CreatedAt.ToString("O")
+        };
+    }
+
+    #endregion
+}
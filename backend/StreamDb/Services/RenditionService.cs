@@ -0,0 +1,138 @@
+using Grpc.Core;
+using Microsoft.EntityFrameworkCore;
+using StreamDb.Context;
+using StreamDb.Models;
+using StreamDb.Protos;
+using Google.Protobuf.WellKnownTypes;
+
+namespace StreamDb.Services;
+
+public class RenditionService(StreamDbContext context) : Protos.RenditionService.RenditionServiceBase
+{
+    public override async Task<LadderResponse> SetLadder(SetLadderRequest request, ServerCallContext context1)
+    {
+        ValidateSetRequest(request);
+
+        var stream = await context.Streams
+            .AsNoTracking()
+            .FirstOrDefaultAsync(s => s.Id == request.StreamId && s.DeletedAt == null);
+
+        if (stream == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Stream not found"));
+        }
+
+        var ladder = await context.RenditionLadders
+            .FirstOrDefaultAsync(l => l.StreamId == request.StreamId);
+
+        if (ladder == null)
+        {
+            ladder = new RenditionLadders
+            {
+                StreamId = request.StreamId,
+                CreatedAt = DateTime.UtcNow
+            };
+            context.RenditionLadders.Add(ladder);
+        }
+
+        ladder.Preset = request.Preset.Trim();
+        ladder.Renditions = string.Join('/', request.Renditions.Select(r => r.Trim()));
+
+        try
+        {
+            await context.SaveChangesAsync();
+            return CreateLadderResponse(ladder);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to set ladder: {ex.Message}"));
+        }
+    }
+
+    public override async Task<GetLaddersResponse> GetLadders(GetLaddersRequest request, ServerCallContext context1)
+    {
+        if (request.StreamIds.Any(id => id <= 0))
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid stream ID"));
+        }
+
+        try
+        {
+            var streamIds = request.StreamIds.Distinct().ToList();
+            var ladders = await context.RenditionLadders
+                .AsNoTracking()
+                .Where(l => streamIds.Contains(l.StreamId))
+                .OrderBy(l => l.StreamId)
+                .ToListAsync();
+
+            return new GetLaddersResponse
+            {
+                Ladders = { ladders.Select(CreateLadderResponse) }
+            };
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to retrieve ladders: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> DeleteLadder(DeleteLadderRequest request, ServerCallContext context1)
+    {
+        if (request.StreamId <= 0)
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid stream ID"));
+        }
+
+        try
+        {
+            await context.RenditionLadders
+                .Where(l => l.StreamId == request.StreamId)
+                .ExecuteDeleteAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to delete ladder: {ex.Message}"));
+        }
+    }
+
+    #region Validation Methods
+
+    private static void ValidateSetRequest(SetLadderRequest request)
+    {
+        var errors = new List<string>();
+
+        if (request.StreamId <= 0)
+            errors.Add("Invalid stream ID");
+
+        if (string.IsNullOrWhiteSpace(request.Preset))
+            errors.Add("Preset is required");
+
+        if (request.Renditions.Any(string.IsNullOrWhiteSpace))
+            errors.Add("Renditions must not be blank");
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+    }
+
+    #endregion
+
+    #region Helper Methods
+
+    private static LadderResponse CreateLadderResponse(RenditionLadders ladder)
+    {
+        var response = new LadderResponse
+        {
+            StreamId = ladder.StreamId,
+            Preset = ladder.Preset,
+            UpdatedAt = ladder.UpdatedAt.ToString("O")
+        };
+
+        if (ladder.Renditions.Length > 0)
+            response.Renditions.AddRange(ladder.Renditions.Split('/'));
+
+        return response;
+    }
+
+    #endregion
+}
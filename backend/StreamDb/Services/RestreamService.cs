@@ -0,0 +1,242 @@
+using Grpc.Core;
+using Microsoft.EntityFrameworkCore;
+using StreamDb.Context;
+using StreamDb.Models;
+using StreamDb.Protos;
+using Google.Protobuf.WellKnownTypes;
+
+namespace StreamDb.Services;
+
+public class RestreamService(StreamDbContext context) : Protos.RestreamService.RestreamServiceBase
+{
+    public override async Task<DestinationResponse> CreateDestination(CreateDestinationRequest request, ServerCallContext context1)
+    {
+        ValidateCreateRequest(request);
+
+        await ValidateRelationships(request.OwnerId, request.StreamId);
+
+        var destination = new RestreamDestinations
+        {
+            OwnerId = request.OwnerId,
+            StreamId = request.StreamId > 0 ? request.StreamId : null,
+            Name = request.Name.Trim(),
+            Url = request.Url.Trim(),
+            SealedKey = request.SealedKey,
+            KeyHint = request.KeyHint,
+            Enabled = request.Enabled,
+            CreatedAt = DateTime.UtcNow
+        };
+
+        try
+        {
+            context.RestreamDestinations.Add(destination);
+            await context.SaveChangesAsync();
+            return CreateDestinationResponse(destination);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to create destination: {ex.Message}"));
+        }
+    }
+
+    public override async Task<DestinationResponse> GetDestination(GetDestinationRequest request, ServerCallContext context1)
+    {
+        var destination = await context.RestreamDestinations
+            .AsNoTracking()
+            .FirstOrDefaultAsync(d => d.Id == request.Id);
+
+        if (destination == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Destination not found"));
+        }
+
+        return CreateDestinationResponse(destination);
+    }
+
+    public override async Task<DestinationResponse> UpdateDestination(UpdateDestinationRequest request, ServerCallContext context1)
+    {
+        ValidateUpdateRequest(request);
+
+        var destination = await context.RestreamDestinations
+            .FirstOrDefaultAsync(d => d.Id == request.Id);
+
+        if (destination == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Destination not found"));
+        }
+
+        destination.Name = request.Name.Trim();
+        destination.Url = request.Url.Trim();
+        destination.SealedKey = request.SealedKey;
+        destination.KeyHint = request.KeyHint;
+        destination.Enabled = request.Enabled;
+
+        try
+        {
+            await context.SaveChangesAsync();
+            return CreateDestinationResponse(destination);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to update destination: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> DeleteDestination(DeleteDestinationRequest request, ServerCallContext context1)
+    {
+        var destination = await context.RestreamDestinations
+            .FirstOrDefaultAsync(d => d.Id == request.Id);
+
+        if (destination == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Destination not found"));
+        }
+
+        try
+        {
+            context.RestreamDestinations.Remove(destination);
+            await context.SaveChangesAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to delete destination: {ex.Message}"));
+        }
+    }
+
+    public override async Task<ListDestinationsResponse> ListDestinations(ListDestinationsRequest request, ServerCallContext context1)
+    {
+        if (request.OwnerId <= 0)
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid owner ID"));
+        }
+
+        try
+        {
+            var query = context.RestreamDestinations
+                .AsNoTracking()
+                .Where(d => d.OwnerId == request.OwnerId);
+
+            if (request.StreamId > 0)
+                query = query.Where(d => d.StreamId == null || d.StreamId == request.StreamId);
+
+            var destinations = await query
+                .OrderBy(d => d.Id)
+                .ToListAsync();
+
+            return new ListDestinationsResponse
+            {
+                Destinations = { destinations.Select(CreateDestinationResponse) }
+            };
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to retrieve destinations: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> DeleteStreamDestinations(DeleteStreamDestinationsRequest request, ServerCallContext context1)
+    {
+        if (request.StreamId <= 0)
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid stream ID"));
+        }
+
+        try
+        {
+            await context.RestreamDestinations
+                .Where(d => d.StreamId == request.StreamId)
+                .ExecuteDeleteAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to delete destinations: {ex.Message}"));
+        }
+    }
+
+    #region Validation Methods
+
+    private static void ValidateCreateRequest(CreateDestinationRequest request)
+    {
+        var errors = new List<string>();
+
+        if (request.OwnerId <= 0)
+            errors.Add("Invalid owner ID");
+
+        if (request.StreamId < 0)
+            errors.Add("Invalid stream ID");
+
+        ValidateFields(errors, request.Name, request.Url, request.SealedKey);
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+    }
+
+    private static void ValidateUpdateRequest(UpdateDestinationRequest request)
+    {
+        var errors = new List<string>();
+
+        if (request.Id <= 0)
+            errors.Add("Invalid destination ID");
+
+        ValidateFields(errors, request.Name, request.Url, request.SealedKey);
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+    }
+
+    private static void ValidateFields(List<string> errors, string name, string url, string sealedKey)
+    {
+        if (string.IsNullOrWhiteSpace(name))
+            errors.Add("Name is required");
+
+        if (string.IsNullOrWhiteSpace(url))
+            errors.Add("URL is required");
+
+        if (string.IsNullOrEmpty(sealedKey))
+            errors.Add("Sealed key is required");
+    }
+
+    private async Task ValidateRelationships(int ownerId, int streamId)
+    {
+        var owner = await context.Users
+            .AsNoTracking()
+            .FirstOrDefaultAsync(u => u.Id == ownerId && u.DeletedAt == null);
+
+        if (owner == null)
+            throw new RpcException(new Status(StatusCode.NotFound, "User not found"));
+
+        if (streamId == 0)
+            return;
+
+        var stream = await context.Streams
+            .AsNoTracking()
+            .FirstOrDefaultAsync(s => s.Id == streamId && s.UserId == ownerId && s.DeletedAt == null);
+
+        if (stream == null)
+            throw new RpcException(new Status(StatusCode.NotFound, "Stream not found"));
+    }
+
+    #endregion
+
+    #region Helper Methods
+
+    private static DestinationResponse CreateDestinationResponse(RestreamDestinations destination)
+    {
+        return new DestinationResponse
+        {
+            Id = destination.Id,
+            OwnerId = destination.OwnerId,
+            StreamId = destination.StreamId ?? 0,
+            Name = destination.Name,
+            Url = destination.Url,
+            SealedKey = destination.SealedKey,
+            KeyHint = destination.KeyHint,
+            Enabled = destination.Enabled,
+            CreatedAt = destination.CreatedAt.ToString("O")
+        };
+    }
+
+    #endregion
+}
@@ -32,6 +32,7 @@ public class StreamService(StreamDbContext context) : Protos.StreamService.Strea
             Codec = request.Codec?.Trim()!,
             Protocol = request.Protocol?.Trim()!,
             Status = ConvertStreamStatus(request.Status),
+            Visibility = ConvertStreamVisibility(request.Visibility),
             UserId = (int)request.UserId,
             ViewCount = 0,
             CreatedAt = DateTime.UtcNow
@@ -168,6 +169,116 @@ public override async Task<ListStreamsResponse> ListStreams(ListStreamsRequest r
     }
 
 
+    public override async Task<ListStreamsResponse> ListDeletedStreams(ListDeletedStreamsRequest request, ServerCallContext context1)
+    {
+        var query = context.Streams
+            .AsNoTracking()
+            .Where(s => s.DeletedAt != null);
+
+        if (request.UserId > 0)
+            query = query.Where(s => s.UserId == request.UserId);
+
+        if (!string.IsNullOrWhiteSpace(request.DeletedBefore))
+        {
+            var deletedBefore = ParseTimestamp(request.DeletedBefore);
+            query = query.Where(s => s.DeletedAt < deletedBefore);
+        }
+
+        try
+        {
+            var totalItems = await query.CountAsync();
+
+            var pageSize = request.PageSize <= 0 ? MaxPageSize : Math.Min(request.PageSize, MaxPageSize);
+            var pageNumber = request.PageNumber <= 0 ? 1 : request.PageNumber;
+            var totalPages = (int)Math.Ceiling(totalItems / (double)pageSize);
+
+            if (totalPages > 0 && pageNumber > totalPages)
+            {
+                pageNumber = totalPages;
+            }
+
+            var streams = await query
+                .OrderByDescending(s => s.DeletedAt)
+                .ThenByDescending(s => s.Id)
+                .Skip((pageNumber - 1) * pageSize)
+                .Take(pageSize)
+                .ToListAsync();
+
+            return new ListStreamsResponse
+            {
+                Streams = { streams.Select(CreateStreamResponse) },
+                MetaData = new PaginationMetadata
+                {
+                    TotalItems = totalItems,
+                    TotalPages = totalPages,
+                    CurrentPage = pageNumber,
+                    PageSize = pageSize
+                }
+            };
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to retrieve deleted streams: {ex.Message}"));
+        }
+    }
+
+    public override async Task<StreamResponse> RestoreStream(RestoreStreamRequest request, ServerCallContext context1)
+    {
+        var stream = await context.Streams
+            .FirstOrDefaultAsync(s => s.Id == request.Id);
+
+        if (stream is not { DeletedAt: not null } || (request.UserId > 0 && stream.UserId != request.UserId))
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Deleted stream not found"));
+        }
+
+        var ownerExists = await context.Users
+            .AnyAsync(u => u.Id == stream.UserId && u.DeletedAt == null);
+
+        if (!ownerExists)
+            throw new RpcException(new Status(StatusCode.FailedPrecondition,
+                "Cannot restore a stream of a deleted user."));
+
+        try
+        {
+            stream.DeletedAt = null;
+            await context.SaveChangesAsync();
+            return CreateStreamResponse(stream);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to restore stream: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> PurgeStream(PurgeStreamRequest request, ServerCallContext context1)
+    {
+        var stream = await context.Streams
+            .FirstOrDefaultAsync(s => s.Id == request.Id);
+
+        if (stream == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Stream not found"));
+        }
+
+        if (stream.DeletedAt == null)
+            throw new RpcException(new Status(StatusCode.FailedPrecondition,
+                "Cannot purge a stream that is not deleted. Please delete the stream first."));
+
+        try
+        {
+            // Comments are removed by the cascade on their foreign key
+            context.Streams.Remove(stream);
+            await context.SaveChangesAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to purge stream: {ex.Message}"));
+        }
+    }
+
+
     private static void ValidateCreateRequest(CreateStreamRequest request)
     {
         var errors = new List<string>();
@@ -256,13 +367,8 @@ public override async Task<ListStreamsResponse> ListStreams(ListStreamsRequest r
             var startTime = ParseTimestamp(request.StartTime);
             var endTime = ParseTimestamp(request.EndTime);
 
-            var now = DateTime.UtcNow;
-            
-            if (startTime <= now)
-            {
-                errors.Add("Start time must be in the future");
-            }
-
+            // Streams are updated while and after they are broadcast, so
+            // only the order of the times is checked
             if (startTime >= endTime)
             {
                 errors.Add("Start time must be before end time");
@@ -326,7 +432,9 @@ public override async Task<ListStreamsResponse> ListStreams(ListStreamsRequest r
             ViewCount = stream.ViewCount,
             Protocol = stream.Protocol,
             Status = (StreamStatus)stream.Status,
-            UserId = stream.UserId
+            UserId = stream.UserId,
+            Visibility = (StreamVisibility)stream.Visibility,
+            DeletedAt = stream.DeletedAt?.ToString(TimeFormat) ?? ""
         };
     }
 
@@ -362,6 +470,9 @@ public override async Task<ListStreamsResponse> ListStreams(ListStreamsRequest r
         if (!string.IsNullOrWhiteSpace(request.Status.ToString()))
             stream.Status = ConvertStreamStatus(request.Status);
 
+        if (request.HasVisibility)
+            stream.Visibility = ConvertStreamVisibility(request.Visibility);
+
         if (request.ViewCount >= 0)
             stream.ViewCount = request.ViewCount;
     }
@@ -379,6 +490,9 @@ private static IQueryable<Streams> ApplyFilters(IQueryable<Streams> query, Strea
         if (filter.UserId > 0)
             query = query.Where(s => s.UserId == filter.UserId);
 
+        if (filter.IdAfter > 0)
+            query = query.Where(s => s.Id > filter.IdAfter);
+
         if (filter.MinViewCount > 0)
             query = query.Where(s => s.ViewCount >= filter.MinViewCount);
 
@@ -394,6 +508,15 @@ private static IQueryable<Streams> ApplyFilters(IQueryable<Streams> query, Strea
             query = query.Where(s => statuses.Contains(s.Status));
         }
 
+        if (filter.Visibility.Count > 0)
+        {
+            var visibilities = filter.Visibility
+                .Select(ConvertStreamVisibility)
+                .Distinct()
+                .ToList();
+            query = query.Where(s => visibilities.Contains(s.Visibility));
+        }
+
         if (!string.IsNullOrWhiteSpace(filter.Codec))
             query = query.Where(s => s.Codec == filter.Codec);
 
@@ -423,4 +546,9 @@ private static IQueryable<Streams> ApplyFilters(IQueryable<Streams> query, Strea
     {
         return (EStreamStatus)status;
     }
+
+    private static EStreamVisibility ConvertStreamVisibility(StreamVisibility visibility)
+    {
+        return (EStreamVisibility)visibility;
+    }
 }
\ No newline at end of file
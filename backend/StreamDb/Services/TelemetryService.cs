@@ -0,0 +1,177 @@
+using System.Globalization;
+using Grpc.Core;
+using Microsoft.EntityFrameworkCore;
+using StreamDb.Context;
+using StreamDb.Models;
+using StreamDb.Protos;
+using Google.Protobuf.WellKnownTypes;
+
+namespace StreamDb.Services;
+
+public class TelemetryService(StreamDbContext context) : Protos.TelemetryService.TelemetryServiceBase
+{
+    public override async Task<SampleResponse> AddSample(AddSampleRequest request, ServerCallContext context1)
+    {
+        var reportedAt = ValidateAddRequest(request);
+
+        var stream = await context.Streams
+            .AsNoTracking()
+            .FirstOrDefaultAsync(s => s.Id == request.StreamId && s.DeletedAt == null);
+
+        if (stream == null)
+        {
+            throw new RpcException(new Status(StatusCode.NotFound, "Stream not found"));
+        }
+
+        var sample = new TelemetrySamples
+        {
+            StreamId = request.StreamId,
+            ReportedAt = reportedAt,
+            BitrateKbps = request.BitrateKbps,
+            Framerate = request.Framerate,
+            DroppedFrames = request.DroppedFrames,
+            KeyframeIntervalSeconds = request.KeyframeIntervalSeconds,
+            AudioLevelDb = request.HasAudioLevelDb ? request.AudioLevelDb : null,
+            CreatedAt = DateTime.UtcNow
+        };
+
+        try
+        {
+            context.TelemetrySamples.Add(sample);
+            await context.SaveChangesAsync();
+            return CreateSampleResponse(sample);
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to add sample: {ex.Message}"));
+        }
+    }
+
+    public override async Task<ListSamplesResponse> ListSamples(ListSamplesRequest request, ServerCallContext context1)
+    {
+        var errors = new List<string>();
+
+        if (request.StreamId <= 0)
+            errors.Add("Invalid stream ID");
+
+        if (request.Limit < 0)
+            errors.Add("Limit must not be negative");
+
+        DateTime? reportedAfter = null;
+        if (!string.IsNullOrEmpty(request.ReportedAfter))
+        {
+            if (TryParseTime(request.ReportedAfter, out var parsed))
+                reportedAfter = parsed;
+            else
+                errors.Add("Invalid reported after time");
+        }
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+
+        try
+        {
+            var query = context.TelemetrySamples
+                .AsNoTracking()
+                .Where(s => s.StreamId == request.StreamId);
+
+            if (reportedAfter != null)
+                query = query.Where(s => s.ReportedAt > reportedAfter);
+
+            // the newest samples are kept when the list is cut short
+            query = query
+                .OrderByDescending(s => s.ReportedAt)
+                .ThenByDescending(s => s.Id);
+
+            if (request.Limit > 0)
+                query = query.Take(request.Limit);
+
+            var samples = await query.ToListAsync();
+            samples.Reverse();
+
+            return new ListSamplesResponse
+            {
+                Samples = { samples.Select(CreateSampleResponse) }
+            };
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to retrieve samples: {ex.Message}"));
+        }
+    }
+
+    public override async Task<Empty> DeleteSamples(DeleteSamplesRequest request, ServerCallContext context1)
+    {
+        if (!TryParseTime(request.ReportedBefore, out var reportedBefore))
+        {
+            throw new RpcException(new Status(StatusCode.InvalidArgument, "Invalid reported before time"));
+        }
+
+        try
+        {
+            await context.TelemetrySamples
+                .Where(s => s.ReportedAt < reportedBefore)
+                .ExecuteDeleteAsync();
+            return new Empty();
+        }
+        catch (Exception ex)
+        {
+            throw new RpcException(new Status(StatusCode.Internal, $"Failed to delete samples: {ex.Message}"));
+        }
+    }
+
+    #region Validation Methods
+
+    private static DateTime ValidateAddRequest(AddSampleRequest request)
+    {
+        var errors = new List<string>();
+
+        if (request.StreamId <= 0)
+            errors.Add("Invalid stream ID");
+
+        if (!TryParseTime(request.ReportedAt, out var reportedAt))
+            errors.Add("Invalid reported at time");
+
+        if (request.BitrateKbps < 0 || request.Framerate < 0 || request.DroppedFrames < 0 || request.KeyframeIntervalSeconds < 0)
+            errors.Add("Telemetry values must not be negative");
+
+        if (errors.Count > 0)
+            throw new RpcException(new Status(StatusCode.InvalidArgument, string.Join(", ", errors)));
+
+        return reportedAt;
+    }
+
+    #endregion
+
+    #region Helper Methods
+
+    private static bool TryParseTime(string value, out DateTime time)
+    {
+        if (DateTime.TryParse(value, CultureInfo.InvariantCulture, DateTimeStyles.AdjustToUniversal | DateTimeStyles.AssumeUniversal, out time))
+            return true;
+
+        time = default;
+        return false;
+    }
+
+    private static SampleResponse CreateSampleResponse(TelemetrySamples sample)
+    {
+        var response = new SampleResponse
+        {
+            Id = sample.Id,
+            StreamId = sample.StreamId,
+            ReportedAt = sample.ReportedAt.ToString("O"),
+            BitrateKbps = sample.BitrateKbps,
+            Framerate = sample.Framerate,
+            DroppedFrames = sample.DroppedFrames,
+            KeyframeIntervalSeconds = sample.KeyframeIntervalSeconds
+        };
+
+        if (sample.AudioLevelDb != null)
+            response.AudioLevelDb = sample.AudioLevelDb.Value;
+
+        return response;
+    }
+
+    #endregion
+}
@@ -0,0 +1,287 @@
+// Package app assembles the API gateway from its configuration. It is shared
+// by main and the integration tests, which run the gateway in-process.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/dial"
+	"github.com/clementus360/platform/health"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/metrics"
+	"github.com/clementus360/platform/tlsconfig"
+	"github.com/clementus360/platform/tracing"
+	streampb "github.com/clementus360/stream-service/proto"
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"google.golang.org/grpc"
+
+	"github.com/clementus360/api-gateway/config"
+	"github.com/clementus360/api-gateway/edge"
+	"github.com/clementus360/api-gateway/rest"
+)
+
+// Option customizes an App
+type Option func(*options)
+
+type options struct {
+	logger      *slog.Logger
+	dialOptions []grpc.DialOption
+}
+
+// WithLogger replaces the logger built from the configuration
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithDialOptions adds options to the connections to the services
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// App is an API gateway connected to the services it routes to
+type App struct {
+	tlsManager  *tlsconfig.Manager
+	checker     *health.Checker
+	sessions    *edge.Sessions
+	limiter     *edge.RateLimiter
+	handler     http.Handler
+	streamConn  *grpc.ClientConn
+	commentConn *grpc.ClientConn
+	userConn    *grpc.ClientConn
+}
+
+// New connects to the services and builds the REST routes. Nothing is
+// served until the handler is given to a server.
+func New(ctx context.Context, cfg *config.Config, opts ...Option) (*App, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	logger := o.logger
+	if logger == nil {
+		logger = logging.New("api-gateway", cfg.Log.Level, cfg.Log.Format)
+	}
+	ctx = logging.WithLogger(ctx, logger)
+
+	// register the Prometheus collectors exposed on /metrics
+	gatewayMetrics := metrics.New("api_gateway")
+
+	// load the certificates of the connections to the services
+	tlsManager, err := tlsconfig.New(ctx, "api-gateway", tlsconfig.Config{
+		Enabled:           cfg.TLS.Enabled,
+		CertFile:          cfg.TLS.CertFile,
+		KeyFile:           cfg.TLS.KeyFile,
+		CAFile:            cfg.TLS.CAFile,
+		ClientAuth:        cfg.TLS.ClientAuth,
+		AllowedIdentities: cfg.TLS.AllowedIdentities,
+		ReloadInterval:    cfg.TLS.ReloadInterval,
+		DevMode:           cfg.TLS.DevMode,
+		DevDir:            cfg.TLS.DevDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize TLS: %w", err)
+	}
+
+	// the Backend API URL is only overridden to talk to a fake identity
+	// provider
+	var clerkOptions []clerk.ClerkOption
+	if cfg.Clerk.APIURL != "" {
+		clerkOptions = append(clerkOptions, clerk.WithBaseURL(cfg.Clerk.APIURL))
+	}
+	clerkClient, err := clerk.NewClient(cfg.Clerk.SecretKey, clerkOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Clerk client: %w", err)
+	}
+
+	// sign outgoing calls with the shared service key, on behalf of the user
+	// of the session if any
+	authenticator := auth.New("api-gateway", auth.Config{
+		SigningKey:     cfg.Auth.SigningKey,
+		Enforce:        cfg.Auth.Enforce,
+		AllowedCallers: cfg.Auth.AllowedCallers,
+		TrustedCallers: cfg.Auth.TrustedCallers,
+		TokenTTL:       cfg.Auth.TokenTTL,
+	})
+
+	// every outgoing connection shares the same deadlines, retries and
+	// circuit breaker settings
+	methodTimeouts, _ := cfg.Client.MethodTimeoutMap()
+	dialer := dial.NewDialer(dial.Config{
+		Timeout:        cfg.Client.Timeout,
+		MethodTimeouts: methodTimeouts,
+		Retry: dial.RetryConfig{
+			MaxAttempts:    cfg.Client.RetryMaxAttempts,
+			InitialBackoff: cfg.Client.RetryInitialBackoff,
+			MaxBackoff:     cfg.Client.RetryMaxBackoff,
+		},
+		Breaker: dial.BreakerConfig{
+			Failures:    cfg.Client.BreakerFailures,
+			OpenTimeout: cfg.Client.BreakerOpenTimeout,
+		},
+		KeepaliveTime:     cfg.Client.KeepaliveTime,
+		KeepaliveTimeout:  cfg.Client.KeepaliveTimeout,
+		MaxConnectBackoff: cfg.Client.MaxConnectBackoff,
+		DialOptions:       o.dialOptions,
+	}, tlsManager, authenticator, gatewayMetrics)
+
+	a := &App{tlsManager: tlsManager}
+
+	a.streamConn, err = dialer.Dial(dial.Target{
+		Name:       "stream_service",
+		Audience:   "stream-service",
+		Address:    cfg.Services.StreamService,
+		Service:    streampb.StreamService_ServiceDesc.ServiceName,
+		Idempotent: []string{"GetStream", "ListStreams", "ListDeletedStreams", "GetStreamAnalytics", "GetClip", "ListClips", "ListCollaborators", "ListRestreamDestinations"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to stream service: %w", err)
+	}
+	a.commentConn, err = dialer.Dial(dial.Target{
+		Name:       "comment_service",
+		Audience:   "comment-service",
+		Address:    cfg.Services.CommentService,
+		Service:    commentpb.CommentService_ServiceDesc.ServiceName,
+		Idempotent: []string{"GetComment", "ListComments"},
+	})
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to connect to comment service: %w", err)
+	}
+	a.userConn, err = dialer.Dial(dial.Target{
+		Name:       "user_service",
+		Audience:   "user-service",
+		Address:    cfg.Services.UserService,
+		Service:    userpb.UserService_ServiceDesc.ServiceName,
+		Idempotent: []string{"GetUser", "GetUserByClerkID", "SyncUserWithClerk"},
+	})
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to connect to user service: %w", err)
+	}
+
+	streams := streampb.NewStreamServiceClient(a.streamConn)
+	comments := commentpb.NewCommentServiceClient(a.commentConn)
+	users := userpb.NewUserServiceClient(a.userConn)
+
+	a.sessions = edge.NewSessions(clerkClient, users, cfg.Clerk.IdentityTTL)
+
+	// probe the services for readiness
+	a.checker = health.NewChecker(2 * time.Second)
+	a.checker.AddProbe("stream_service", health.ConnProbe(a.streamConn))
+	a.checker.AddProbe("comment_service", health.ConnProbe(a.commentConn))
+	a.checker.AddProbe("user_service", health.ConnProbe(a.userConn))
+
+	api := http.NewServeMux()
+	api.HandleFunc("GET /v1/streams", rest.ListStreams(streams))
+	api.HandleFunc("POST /v1/streams", rest.CreateStream(streams))
+	api.HandleFunc("GET /v1/streams/{id}", rest.GetStream(streams))
+	api.HandleFunc("PATCH /v1/streams/{id}", rest.UpdateStream(streams))
+	api.HandleFunc("DELETE /v1/streams/{id}", rest.DeleteStream(streams))
+	api.HandleFunc("GET /v1/streams/export", rest.ExportStreams(streams))
+	api.HandleFunc("GET /v1/streams/deleted", rest.ListDeletedStreams(streams))
+	api.HandleFunc("POST /v1/streams/{id}/restore", rest.RestoreStream(streams))
+	api.HandleFunc("POST /v1/streams/{id}/playback-token", rest.IssuePlaybackToken(streams))
+	api.HandleFunc("POST /v1/streams/{id}/viewer-sessions", rest.StartViewerSession(streams))
+	api.HandleFunc("DELETE /v1/viewer-sessions/{id}", rest.EndViewerSession(streams))
+	api.HandleFunc("GET /v1/streams/{id}/analytics", rest.GetStreamAnalytics(streams))
+	api.HandleFunc("GET /v1/streams/{id}/clips", rest.ListStreamClips(streams))
+	api.HandleFunc("POST /v1/streams/{id}/clips", rest.CreateClip(streams))
+	api.HandleFunc("GET /v1/clips/{id}", rest.GetClip(streams))
+	api.HandleFunc("DELETE /v1/clips/{id}", rest.DeleteClip(streams))
+	api.HandleFunc("GET /v1/streams/{id}/collaborators", rest.ListCollaborators(streams, false))
+	api.HandleFunc("POST /v1/streams/{id}/collaborators", rest.AddCollaborator(streams, false))
+	api.HandleFunc("DELETE /v1/streams/{id}/collaborators/{user_id}", rest.RemoveCollaborator(streams, false))
+	api.HandleFunc("GET /v1/streams/{id}/restreams", rest.ListRestreamDestinations(streams, false))
+	api.HandleFunc("POST /v1/streams/{id}/restreams", rest.AddRestreamDestination(streams, false))
+	api.HandleFunc("PATCH /v1/restreams/{id}", rest.UpdateRestreamDestination(streams))
+	api.HandleFunc("DELETE /v1/restreams/{id}", rest.RemoveRestreamDestination(streams))
+	api.HandleFunc("GET /v1/streams/{id}/comments", rest.ListStreamComments(streams, comments))
+	api.HandleFunc("POST /v1/streams/{id}/comments", rest.CreateComment(comments))
+	api.HandleFunc("GET /v1/comments/{id}", rest.GetComment(streams, comments))
+	api.HandleFunc("PATCH /v1/comments/{id}", rest.UpdateComment(comments))
+	api.HandleFunc("DELETE /v1/comments/{id}", rest.DeleteComment(comments))
+	api.HandleFunc("GET /v1/users/me", rest.CurrentUser(users))
+	api.HandleFunc("POST /v1/users/me/sync", rest.SyncUser(users, a.sessions))
+	api.HandleFunc("GET /v1/users/{id}", rest.GetUser(users))
+	api.HandleFunc("PATCH /v1/users/{id}", rest.UpdateUser(users))
+	api.HandleFunc("DELETE /v1/users/{id}", rest.DeleteUser(users, a.sessions))
+	api.HandleFunc("GET /v1/users/{id}/clips", rest.ListUserClips(streams))
+	api.HandleFunc("GET /v1/users/{id}/collaborators", rest.ListCollaborators(streams, true))
+	api.HandleFunc("POST /v1/users/{id}/collaborators", rest.AddCollaborator(streams, true))
+	api.HandleFunc("DELETE /v1/users/{id}/collaborators/{user_id}", rest.RemoveCollaborator(streams, true))
+	api.HandleFunc("GET /v1/users/{id}/restreams", rest.ListRestreamDestinations(streams, true))
+	api.HandleFunc("POST /v1/users/{id}/restreams", rest.AddRestreamDestination(streams, true))
+
+	// The metrics wrap the API mux itself, as the middlewares around it pass
+	// on copies of the request that the matched route is recorded on.
+	// Sessions are resolved before rate limiting so that users are limited
+	// rather than the addresses they share.
+	handler := gatewayMetrics.Middleware(api)
+	if cfg.RateLimit.RequestsPerSecond > 0 {
+		a.limiter = edge.NewRateLimiter(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst, cfg.RateLimit.TrustForwardedFor)
+		handler = a.limiter.Middleware(handler)
+	}
+	handler = a.sessions.Middleware(handler)
+	handler = edge.LimitBody(cfg.Server.MaxBodySize, handler)
+	handler = edge.CORS(edge.CORSConfig{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	}, handler)
+
+	router := http.NewServeMux()
+	router.Handle("/v1/", handler)
+	router.Handle("GET /metrics", gatewayMetrics.Handler())
+	router.HandleFunc("GET /healthz", a.checker.LivenessHandler())
+	router.HandleFunc("GET /readyz", a.checker.ReadinessHandler())
+	a.handler = tracing.Middleware(logging.Middleware(logger, router))
+
+	return a, nil
+}
+
+// Handler serves the REST routes together with the metrics and health
+// endpoints
+func (a *App) Handler() http.Handler {
+	return a.handler
+}
+
+// Start runs the loops that keep the readiness status and certificates up to
+// date, and that prune the session and rate limit caches, in the background
+// until ctx is cancelled
+func (a *App) Start(ctx context.Context) {
+	go a.checker.Run(ctx, 10*time.Second)
+	go a.tlsManager.Watch(ctx)
+	go a.sessions.Run(ctx, time.Minute)
+	if a.limiter != nil {
+		go a.limiter.Run(ctx, time.Minute)
+	}
+}
+
+// Drain reports not ready so that load balancers stop sending traffic
+// before the server stops
+func (a *App) Drain() {
+	a.checker.Shutdown()
+}
+
+// Close closes the connections to the services
+func (a *App) Close() error {
+	var errs []error
+	for _, conn := range []*grpc.ClientConn{a.streamConn, a.commentConn, a.userConn} {
+		if conn != nil {
+			errs = append(errs, conn.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,131 @@
+// Package config holds the settings of the API gateway. They are loaded like
+// those of the services, with the loader and sections of the platform module.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	platformconfig "github.com/clementus360/platform/config"
+)
+
+// Config is the complete api-gateway configuration
+type Config struct {
+	Server    ServerConfig                 `yaml:"server"`
+	Services  ServicesConfig               `yaml:"services"`
+	Log       platformconfig.LogConfig     `yaml:"log"`
+	Tracing   platformconfig.TracingConfig `yaml:"tracing"`
+	TLS       platformconfig.TLSConfig     `yaml:"tls"`
+	Auth      platformconfig.AuthConfig    `yaml:"auth"`
+	Client    platformconfig.ClientConfig  `yaml:"client"`
+	Clerk     ClerkConfig                  `yaml:"clerk"`
+	CORS      CORSConfig                   `yaml:"cors"`
+	RateLimit RateLimitConfig              `yaml:"rate_limit"`
+
+	// PrintConfig dumps the effective configuration and exits
+	PrintConfig bool `yaml:"-" flag:"print-config"`
+}
+
+type ServerConfig struct {
+	Port        string        `yaml:"port" env:"PORT" flag:"port" default:"8000" required:"true"`
+	DrainPeriod time.Duration `yaml:"drain_period" env:"SHUTDOWN_DRAIN_PERIOD" flag:"drain-period" default:"5s"`
+	// MaxBodySize is the largest request body accepted, in bytes
+	MaxBodySize int64 `yaml:"max_body_size" env:"MAX_BODY_SIZE" flag:"max-body-size" default:"1048576"`
+}
+
+// ServicesConfig holds the gRPC addresses of the services behind the gateway
+type ServicesConfig struct {
+	StreamService  string `yaml:"stream_service" env:"STREAM_SERVICE_ADDRESS" flag:"stream-service-address" default:"localhost:8082" required:"true"`
+	CommentService string `yaml:"comment_service" env:"COMMENT_SERVICE_ADDRESS" flag:"comment-service-address" default:"localhost:50053" required:"true"`
+	UserService    string `yaml:"user_service" env:"USER_SERVICE_ADDRESS" flag:"user-service-address" default:"localhost:50052" required:"true"`
+}
+
+// ClerkConfig configures the verification of Clerk session tokens
+type ClerkConfig struct {
+	SecretKey string `yaml:"secret_key" env:"CLERK_SECRET_KEY" required:"true" secret:"true"`
+	// APIURL overrides the Clerk Backend API, e.g. to point at a fake
+	// identity provider in tests
+	APIURL string `yaml:"api_url" env:"CLERK_API_URL"`
+	// IdentityTTL is how long the platform user and roles of a Clerk user
+	// are cached before they are looked up again
+	IdentityTTL time.Duration `yaml:"identity_ttl" env:"CLERK_IDENTITY_TTL" default:"30s"`
+}
+
+// CORSConfig lists the browser origins allowed to call the gateway
+type CORSConfig struct {
+	// AllowedOrigins such as https://app.example.com, or * for any origin.
+	// Cross-origin requests are refused when empty.
+	AllowedOrigins   []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" flag:"cors-allowed-origins"`
+	AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" default:"true"`
+	MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE" default:"10m"`
+}
+
+// RateLimitConfig bounds the requests of each user, or of each client
+// address for anonymous requests
+type RateLimitConfig struct {
+	// RequestsPerSecond refills the allowance, 0 disables rate limiting
+	RequestsPerSecond float64 `yaml:"requests_per_second" env:"RATE_LIMIT_RPS" flag:"rate-limit-rps" default:"10"`
+	// Burst is the number of requests allowed at once
+	Burst int `yaml:"burst" env:"RATE_LIMIT_BURST" flag:"rate-limit-burst" default:"20"`
+	// TrustForwardedFor takes the client address from X-Forwarded-For. Only
+	// enable it behind a proxy that sets the header.
+	TrustForwardedFor bool `yaml:"trust_forwarded_for" env:"RATE_LIMIT_TRUST_FORWARDED_FOR" default:"false"`
+}
+
+// LoadConfig reads the configuration from defaults, an optional YAML file,
+// the environment and the command-line arguments
+func LoadConfig(args []string) (*Config, error) {
+	cfg := &Config{}
+	if err := platformconfig.Load("api-gateway", cfg, args); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// Print writes the effective configuration as YAML with secrets masked
+func Print(w io.Writer, cfg *Config) error {
+	return platformconfig.Print(w, cfg)
+}
+
+// Validate checks values that cannot be expressed with struct tags
+func (c *Config) Validate() error {
+	var errs []error
+
+	if n, err := strconv.Atoi(c.Server.Port); err != nil || n < 1 || n > 65535 {
+		errs = append(errs, fmt.Errorf("PORT must be a port number between 1 and 65535, got %q", c.Server.Port))
+	}
+	if c.Server.MaxBodySize < 1 {
+		errs = append(errs, fmt.Errorf("MAX_BODY_SIZE must be positive, got %d", c.Server.MaxBodySize))
+	}
+
+	errs = append(errs, c.Tracing.Validate()...)
+	errs = append(errs, c.TLS.Validate()...)
+	errs = append(errs, c.Auth.Validate()...)
+	errs = append(errs, c.Client.Validate()...)
+
+	if c.Clerk.IdentityTTL < 0 {
+		errs = append(errs, fmt.Errorf("CLERK_IDENTITY_TTL must not be negative, got %s", c.Clerk.IdentityTTL))
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin != "*" && !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			errs = append(errs, fmt.Errorf("CORS_ALLOWED_ORIGINS must hold origins such as https://app.example.com or *, got %q", origin))
+		}
+	}
+	if c.CORS.MaxAge < 0 {
+		errs = append(errs, fmt.Errorf("CORS_MAX_AGE must not be negative, got %s", c.CORS.MaxAge))
+	}
+
+	if c.RateLimit.RequestsPerSecond < 0 {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_RPS must not be negative, got %v", c.RateLimit.RequestsPerSecond))
+	}
+	if c.RateLimit.RequestsPerSecond > 0 && c.RateLimit.Burst < 1 {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_BURST must be positive, got %d", c.RateLimit.Burst))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,61 @@
+package edge
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// CORSConfig lists the origins allowed to call the gateway from a browser
+type CORSConfig struct {
+	// AllowedOrigins may hold * to allow any origin
+	AllowedOrigins   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// CORS answers preflight requests and allows the configured origins to read
+// the responses. Requests from other origins are still served, as browsers
+// enforce the policy by hiding the response.
+func CORS(cfg CORSConfig, next http.Handler) http.Handler {
+	allowAny := slices.Contains(cfg.AllowedOrigins, "*")
+	maxAge := strconv.Itoa(int(cfg.MaxAge / time.Second))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := w.Header()
+		header.Add("Vary", "Origin")
+		allowed := allowAny || slices.Contains(cfg.AllowedOrigins, origin)
+		if allowed {
+			// the origin is echoed rather than answering *, which browsers
+			// reject for requests with credentials
+			header.Set("Access-Control-Allow-Origin", origin)
+			if cfg.AllowCredentials {
+				header.Set("Access-Control-Allow-Credentials", "true")
+			}
+			header.Set("Access-Control-Expose-Headers", "Retry-After")
+		}
+
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// preflight requests end here, without the headers when the origin
+		// is not allowed
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+		if allowed {
+			header.Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE")
+			header.Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			header.Set("Access-Control-Max-Age", maxAge)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
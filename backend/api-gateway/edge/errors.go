@@ -0,0 +1,22 @@
+// Package edge holds the middleware applied to every request before it is
+// routed to a service: CORS, body size limits, Clerk sessions and rate
+// limits.
+package edge
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// WriteError answers with the structured error response used by the REST
+// APIs of the platform
+func WriteError(w http.ResponseWriter, statusCode int, message, details string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "error",
+		"code":    statusCode,
+		"message": message,
+		"details": details,
+	})
+}
@@ -0,0 +1,21 @@
+package edge
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// LimitBody refuses requests announcing a body larger than maxBytes and
+// stops reading bodies at maxBytes, so that handlers fail with an
+// *http.MaxBytesError instead of buffering them
+func LimitBody(maxBytes int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxBytes {
+			WriteError(w, http.StatusRequestEntityTooLarge, "Request body too large",
+				fmt.Sprintf("request bodies are limited to %d bytes", maxBytes))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		next.ServeHTTP(w, r)
+	})
+}
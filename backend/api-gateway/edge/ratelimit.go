@@ -0,0 +1,132 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter allows each client a burst of requests refilled at a steady
+// rate. Signed-in users are limited per user, other requests per client
+// address.
+type RateLimiter struct {
+	rate              float64
+	burst             float64
+	trustForwardedFor bool
+	now               func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter allows burst requests at once, refilled at perSecond. When
+// trustForwardedFor is set the client address is read from the first entry
+// of X-Forwarded-For.
+func NewRateLimiter(perSecond float64, burst int, trustForwardedFor bool) *RateLimiter {
+	return &RateLimiter{
+		rate:              perSecond,
+		burst:             float64(burst),
+		trustForwardedFor: trustForwardedFor,
+		now:               time.Now,
+		buckets:           make(map[string]*bucket),
+	}
+}
+
+// Middleware answers 429 with a Retry-After header to clients over their
+// limit. It must run after Sessions.Middleware to limit users rather than
+// addresses.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if wait, ok := l.allow(l.key(r)); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			WriteError(w, http.StatusTooManyRequests, "Too many requests",
+				fmt.Sprintf("retry in %s", wait.Round(time.Millisecond)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Run forgets the clients whose allowance is full again until ctx is
+// cancelled, so that the buckets of past clients do not pile up
+func (l *RateLimiter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.sweep()
+		}
+	}
+}
+
+// allow takes a token from the bucket of key, or reports how long until one
+// is available
+func (l *RateLimiter) allow(key string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / l.rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+func (l *RateLimiter) sweep() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *RateLimiter) key(r *http.Request) string {
+	if session, ok := SessionFromContext(r.Context()); ok {
+		if user, ok := session.User(); ok {
+			return "user:" + strconv.FormatInt(user.ID, 10)
+		}
+		return "clerk:" + session.ClerkID
+	}
+	return "addr:" + l.clientAddress(r)
+}
+
+func (l *RateLimiter) clientAddress(r *http.Request) string {
+	if l.trustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
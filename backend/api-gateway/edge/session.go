@@ -0,0 +1,223 @@
+package edge
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sessionCookie is the cookie Clerk's frontend SDKs keep the session token in
+const sessionCookie = "__session"
+
+// Session is the Clerk session a request was made with
+type Session struct {
+	ClerkID string
+	// UserID is the platform user of the Clerk user, 0 until they are synced
+	UserID int64
+	Roles  []string
+}
+
+// User returns the platform user of the session, if the Clerk user has
+// been synced
+func (s Session) User() (auth.User, bool) {
+	if s.UserID == 0 {
+		return auth.User{}, false
+	}
+	return auth.User{ID: s.UserID, Roles: s.Roles}, true
+}
+
+type sessionKey struct{}
+
+// SessionFromContext returns the Clerk session of the current request
+func SessionFromContext(ctx context.Context) (Session, bool) {
+	session, ok := ctx.Value(sessionKey{}).(Session)
+	return session, ok
+}
+
+// Sessions verifies the Clerk session tokens of requests and maps Clerk
+// users to platform users. The mapping and the roles are cached for a short
+// while, so that a session costs one token verification per request.
+type Sessions struct {
+	clerk clerk.Client
+	users userpb.UserServiceClient
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu         sync.Mutex
+	identities map[string]identity
+}
+
+type identity struct {
+	userID  int64
+	roles   []string
+	expires time.Time
+}
+
+// NewSessions verifies tokens with clerkClient and resolves platform users
+// through users, caching them for ttl
+func NewSessions(clerkClient clerk.Client, users userpb.UserServiceClient, ttl time.Duration) *Sessions {
+	return &Sessions{
+		clerk:      clerkClient,
+		users:      users,
+		ttl:        ttl,
+		now:        time.Now,
+		identities: make(map[string]identity),
+	}
+}
+
+// Middleware authenticates requests carrying a Clerk session token in the
+// Authorization header or the __session cookie. The platform user of the
+// session is attached with auth.WithUser, so that the calls made for the
+// request are made on their behalf. Requests without a token go on
+// anonymously, those with an invalid token are refused.
+func (s *Sessions) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := sessionToken(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if token == "" {
+			WriteError(w, http.StatusUnauthorized, "Invalid session token", "expected a Bearer token")
+			return
+		}
+
+		ctx := r.Context()
+		claims, err := s.clerk.VerifyToken(token)
+		if err != nil {
+			WriteError(w, http.StatusUnauthorized, "Invalid session token", err.Error())
+			return
+		}
+
+		session, err := s.resolve(ctx, claims.Subject)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to resolve session user", "clerk_id", claims.Subject, "error", err)
+			if status.Code(err) == codes.Unavailable {
+				WriteError(w, http.StatusServiceUnavailable, "Failed to resolve session user", "user service unavailable")
+			} else {
+				WriteError(w, http.StatusUnauthorized, "Failed to resolve session user", "user not authenticated")
+			}
+			return
+		}
+
+		ctx = context.WithValue(ctx, sessionKey{}, session)
+		if user, ok := session.User(); ok {
+			ctx = auth.WithUser(ctx, user)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Forget drops the cached identity of a Clerk user, e.g. once they are
+// synced or deleted
+func (s *Sessions) Forget(clerkID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.identities, clerkID)
+}
+
+// Run drops expired identities until ctx is cancelled
+func (s *Sessions) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep()
+		}
+	}
+}
+
+// resolve returns the session of a Clerk user, reading their roles from
+// Clerk and their platform id from user-service on a cache miss
+func (s *Sessions) resolve(ctx context.Context, clerkID string) (Session, error) {
+	s.mu.Lock()
+	cached, ok := s.identities[clerkID]
+	s.mu.Unlock()
+	if ok && s.now().Before(cached.expires) {
+		return Session{ClerkID: clerkID, UserID: cached.userID, Roles: cached.roles}, nil
+	}
+
+	clerkUser, err := s.clerk.Users().Read(clerkID)
+	if err != nil {
+		return Session{}, err
+	}
+
+	// the lookup is a trusted call: the session has no platform user yet
+	var userID int64
+	user, err := s.users.GetUserByClerkID(ctx, &userpb.GetUserByClerkIDRequest{ClerkId: clerkID})
+	switch {
+	case err == nil:
+		userID = int64(user.User.Id)
+	case status.Code(err) != codes.NotFound:
+		return Session{}, err
+	}
+
+	id := identity{userID: userID, roles: roles(clerkUser), expires: s.now().Add(s.ttl)}
+	s.mu.Lock()
+	s.identities[clerkID] = id
+	s.mu.Unlock()
+
+	return Session{ClerkID: clerkID, UserID: id.userID, Roles: id.roles}, nil
+}
+
+func (s *Sessions) sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for clerkID, id := range s.identities {
+		if !now.Before(id.expires) {
+			delete(s.identities, clerkID)
+		}
+	}
+}
+
+// sessionToken returns the token of the Authorization header, or else of
+// the session cookie. It returns an empty token for malformed headers and
+// false when the request carries none.
+func sessionToken(r *http.Request) (string, bool) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			return "", true
+		}
+		return strings.TrimSpace(token), true
+	}
+	if cookie, err := r.Cookie(sessionCookie); err == nil && cookie.Value != "" {
+		return cookie.Value, true
+	}
+	return "", false
+}
+
+// roles reads the "roles" entry of the public metadata of a Clerk user, like
+// user-service does
+func roles(user *clerk.User) []string {
+	metadata, ok := user.PublicMetadata.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	values, ok := metadata["roles"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var roles []string
+	for _, v := range values {
+		if role, ok := v.(string); ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/tracing"
+
+	"github.com/clementus360/api-gateway/app"
+	"github.com/clementus360/api-gateway/config"
+)
+
+func main() {
+	// load the configuration from defaults, config file, environment and flags
+	cfg, err := config.LoadConfig(os.Args[1:])
+	if cfg.PrintConfig {
+		config.Print(os.Stdout, cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
+	if cfg.PrintConfig {
+		return
+	}
+
+	PORT := cfg.Server.Port
+
+	// time given to load balancers to notice the gateway is draining
+	drainPeriod := cfg.Server.DrainPeriod
+
+	// Define the structured logger shared by every request
+	logger := logging.New("api-gateway", cfg.Log.Level, cfg.Log.Format)
+	slog.SetDefault(logger)
+
+	ctx := logging.WithLogger(context.Background(), logger)
+
+	// set up tracing before any connection is opened so that every client
+	// picks up the global tracer provider
+	shutdownTracing, err := tracing.Setup(ctx, "api-gateway", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.OTLPInsecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logger.Error("Failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
+
+	// connect to the services behind the gateway
+	gateway, err := app.New(ctx, cfg, app.WithLogger(logger))
+	if err != nil {
+		logger.Error("Failed to initialize API gateway", "error", err)
+		os.Exit(1)
+	}
+
+	// run the background loops until shutdown
+	backgroundCtx, stopBackground := context.WithCancel(ctx)
+	defer stopBackground()
+	gateway.Start(backgroundCtx)
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%s", PORT),
+		Handler: gateway.Handler(),
+	}
+
+	go func() {
+		logger.Info("Server running", "port", PORT)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("Failed to serve HTTP", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	// report not ready first so load balancers drain before we stop
+	logger.Info("Shutting down server...", "drain_period", drainPeriod.String())
+	gateway.Drain()
+	time.Sleep(drainPeriod)
+
+	if err := server.Shutdown(context.Background()); err != nil {
+		logger.Error("Server forced to shutdown", "error", err)
+	}
+	stopBackground()
+
+	gateway.Close()
+	logger.Info("Server exiting")
+}
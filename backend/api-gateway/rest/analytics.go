@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/clementus360/platform/logging"
+	streampb "github.com/clementus360/stream-service/proto"
+)
+
+// IssuePlaybackToken returns a token for watching a stream the viewer may
+// see
+func IssuePlaybackToken(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		id, ok := pathID(w, r, "stream")
+		if !ok {
+			return
+		}
+
+		token, err := streams.IssuePlaybackToken(r.Context(), &streampb.IssuePlaybackTokenRequest{StreamId: id})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to issue playback token")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, token)
+	}
+}
+
+// StartViewerSession records a viewer joining a live stream. Signed-in
+// viewers are identified by their user, anonymous ones by the viewer_id the
+// player generated.
+func StartViewerSession(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		id, ok := pathID(w, r, "stream")
+		if !ok {
+			return
+		}
+
+		var req streampb.StartViewerSessionRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		req.StreamId = id
+
+		session, err := streams.StartViewerSession(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to start viewer session")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, session)
+	}
+}
+
+// EndViewerSession records a viewer leaving a stream and returns the ended
+// session
+func EndViewerSession(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		session, err := streams.EndViewerSession(r.Context(), &streampb.EndViewerSessionRequest{SessionId: r.PathValue("id")})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to end viewer session")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, session)
+	}
+}
+
+// GetStreamAnalytics returns the audience figures of a stream to its owner's
+// team
+func GetStreamAnalytics(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		id, ok := pathID(w, r, "stream")
+		if !ok {
+			return
+		}
+
+		analytics, err := streams.GetStreamAnalytics(r.Context(), &streampb.GetStreamAnalyticsRequest{StreamId: id})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to get stream analytics")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, analytics)
+	}
+}
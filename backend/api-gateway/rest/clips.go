@@ -0,0 +1,109 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/clementus360/platform/logging"
+	streampb "github.com/clementus360/stream-service/proto"
+)
+
+// CreateClip cuts a clip of the signed-in user out of a stream
+func CreateClip(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		id, ok := pathID(w, r, "stream")
+		if !ok {
+			return
+		}
+
+		var req streampb.CreateClipRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		req.StreamId = id
+
+		clip, err := streams.CreateClip(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to create clip")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, clip)
+	}
+}
+
+// ListStreamClips lists the clips of a stream, newest first
+func ListStreamClips(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return listClips(streams, "stream", func(req *streampb.ListClipsRequest, id int32) {
+		req.StreamId = id
+	})
+}
+
+// ListUserClips lists the clips a user created, newest first. Clips of
+// private streams are left out for viewers outside the owner's team.
+func ListUserClips(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return listClips(streams, "user", func(req *streampb.ListClipsRequest, id int32) {
+		req.CreatorId = id
+	})
+}
+
+// listClips lists the clips of the resource given by the {id} segment,
+// paged by the page and page_size query parameters
+func listClips(streams streampb.StreamServiceClient, resource string, filter func(req *streampb.ListClipsRequest, id int32)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		id, ok := pathID(w, r, resource)
+		if !ok {
+			return
+		}
+
+		req := &streampb.ListClipsRequest{}
+		if !queryInt32(w, r, map[string]*int32{"page": &req.PageNumber, "page_size": &req.PageSize}) {
+			return
+		}
+		filter(req, id)
+
+		resp, err := streams.ListClips(r.Context(), req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list clips")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
+
+// GetClip returns a clip of a stream the viewer may see
+func GetClip(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		clip, err := streams.GetClip(r.Context(), &streampb.GetClipRequest{Id: r.PathValue("id")})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to get clip")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, clip)
+	}
+}
+
+// DeleteClip deletes a clip
+func DeleteClip(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+
+		if _, err := streams.DeleteClip(r.Context(), &streampb.DeleteClipRequest{Id: r.PathValue("id")}); err != nil {
+			writeStatusError(w, logger, err, "Failed to delete clip")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/clementus360/platform/logging"
+	streampb "github.com/clementus360/stream-service/proto"
+)
+
+// AddCollaborator grants a user a role on a stream, or with channel on
+// every stream of a user. Only the owner and admins manage grants.
+func AddCollaborator(streams streampb.StreamServiceClient, channel bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		ownerID, streamID, ok := scopeID(w, r, channel)
+		if !ok {
+			return
+		}
+
+		var req streampb.AddCollaboratorRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		req.OwnerId, req.StreamId = ownerID, streamID
+
+		collaborator, err := streams.AddCollaborator(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to add collaborator")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, collaborator)
+	}
+}
+
+// RemoveCollaborator revokes the role of the user given by the {user_id}
+// segment
+func RemoveCollaborator(streams streampb.StreamServiceClient, channel bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		ownerID, streamID, ok := scopeID(w, r, channel)
+		if !ok {
+			return
+		}
+		userID, ok := pathSegmentID(w, r, "user_id", "user")
+		if !ok {
+			return
+		}
+
+		req := &streampb.RemoveCollaboratorRequest{OwnerId: ownerID, StreamId: streamID, UserId: userID}
+		if _, err := streams.RemoveCollaborator(r.Context(), req); err != nil {
+			writeStatusError(w, logger, err, "Failed to remove collaborator")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ListCollaborators lists the grants on a stream and on its channel, or on a
+// channel
+func ListCollaborators(streams streampb.StreamServiceClient, channel bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		ownerID, streamID, ok := scopeID(w, r, channel)
+		if !ok {
+			return
+		}
+
+		resp, err := streams.ListCollaborators(r.Context(), &streampb.ListCollaboratorsRequest{OwnerId: ownerID, StreamId: streamID})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list collaborators")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
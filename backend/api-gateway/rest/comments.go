@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"net/http"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"github.com/clementus360/platform/logging"
+	streampb "github.com/clementus360/stream-service/proto"
+)
+
+// ListStreamComments lists the comments of a stream the viewer may see,
+// oldest first
+func ListStreamComments(streams streampb.StreamServiceClient, comments commentpb.CommentServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logging.FromContext(ctx)
+		id, ok := pathID(w, r, "stream")
+		if !ok {
+			return
+		}
+		var page, pageSize int32
+		if !queryInt32(w, r, map[string]*int32{"page": &page, "page_size": &pageSize}) {
+			return
+		}
+
+		if _, err := getStream(ctx, streams, id); err != nil {
+			writeStatusError(w, logger, err, "Failed to list comments")
+			return
+		}
+
+		req := &commentpb.ListCommentsRequest{StreamId: &id}
+		if page > 0 {
+			req.Page = &page
+		}
+		if pageSize > 0 {
+			req.PageSize = &pageSize
+		}
+		resp, err := comments.ListComments(ctx, req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list comments")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
+
+// CreateComment posts a comment of the signed-in user on a stream
+func CreateComment(comments commentpb.CommentServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		user, ok := requireUser(w, r)
+		if !ok {
+			return
+		}
+		id, ok := pathID(w, r, "stream")
+		if !ok {
+			return
+		}
+
+		var req commentpb.CreateCommentRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		req.StreamId = id
+		req.UserId = int32(user.ID)
+
+		resp, err := comments.CreateComment(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to create comment")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, resp.Comment)
+	}
+}
+
+// GetComment returns a comment on a stream the viewer may see
+func GetComment(streams streampb.StreamServiceClient, comments commentpb.CommentServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logging.FromContext(ctx)
+		id, ok := pathID(w, r, "comment")
+		if !ok {
+			return
+		}
+
+		resp, err := comments.GetComment(ctx, &commentpb.GetCommentRequest{Id: id})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to retrieve comment")
+			return
+		}
+		if _, err := getStream(ctx, streams, resp.Comment.StreamId); err != nil {
+			writeStatusError(w, logger, err, "Failed to retrieve comment")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp.Comment)
+	}
+}
+
+// UpdateComment replaces the content of a comment
+func UpdateComment(comments commentpb.CommentServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		id, ok := pathID(w, r, "comment")
+		if !ok {
+			return
+		}
+
+		var req commentpb.UpdateCommentRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		req.Id = id
+
+		resp, err := comments.UpdateComment(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to update comment")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp.Comment)
+	}
+}
+
+// DeleteComment deletes a comment
+func DeleteComment(comments commentpb.CommentServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		id, ok := pathID(w, r, "comment")
+		if !ok {
+			return
+		}
+
+		if _, err := comments.DeleteComment(r.Context(), &commentpb.DeleteCommentRequest{Id: id}); err != nil {
+			writeStatusError(w, logger, err, "Failed to delete comment")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/clementus360/api-gateway/edge"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/export"
+	streampb "github.com/clementus360/stream-service/proto"
+)
+
+// ExportStreams streams the streams matching the ListStreams query
+// parameters that the viewer may see as CSV or JSON Lines, chosen by the
+// format query parameter. Stream keys are never exported.
+func ExportStreams(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logging.FromContext(ctx)
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = export.FormatCSV
+		}
+		if !export.ValidFormat(format) {
+			edge.WriteError(w, http.StatusBadRequest, "Invalid format query parameter", "expected csv or jsonl")
+			return
+		}
+		req, ok := listStreamsRequest(w, r)
+		if !ok {
+			return
+		}
+
+		list := func(ctx context.Context, req *streampb.ListStreamsRequest) (*streampb.ListStreamsResponse, error) {
+			return streams.ListStreams(ctx, req)
+		}
+		exported, err := export.Start(ctx, list, format, req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to export streams")
+			return
+		}
+		rows, err := exported.Serve(ctx, w)
+		if err != nil {
+			logger.Error("Stream export interrupted", "rows", rows, "error", err)
+			return
+		}
+		logger.Info("Exported streams", "format", format, "rows", rows)
+	}
+}
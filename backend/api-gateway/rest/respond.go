@@ -0,0 +1,176 @@
+// Package rest serves the versioned REST routes of the gateway. Each route
+// is translated to calls of the gRPC APIs of the services, made on behalf of
+// the user of the session.
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/clementus360/platform/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/clementus360/api-gateway/edge"
+)
+
+// writeStatusError answers with the structured error response matching the
+// gRPC status of err. Internal errors are reported without their details,
+// which stay in the logs.
+func writeStatusError(w http.ResponseWriter, logger *slog.Logger, err error, message string) {
+	logger.Error(message, "error", err)
+
+	errStatus, ok := status.FromError(err)
+	if !ok {
+		edge.WriteError(w, http.StatusInternalServerError, message, "internal error")
+		return
+	}
+
+	statusCode := http.StatusInternalServerError
+	switch errStatus.Code() {
+	case codes.InvalidArgument:
+		statusCode = http.StatusBadRequest
+	case codes.NotFound:
+		statusCode = http.StatusNotFound
+	case codes.AlreadyExists:
+		statusCode = http.StatusConflict
+	case codes.FailedPrecondition:
+		statusCode = http.StatusConflict
+	case codes.PermissionDenied:
+		statusCode = http.StatusForbidden
+	case codes.Unauthenticated:
+		statusCode = http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		statusCode = http.StatusTooManyRequests
+	case codes.Unavailable:
+		statusCode = http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		statusCode = http.StatusGatewayTimeout
+	}
+
+	details := errStatus.Message()
+	if statusCode == http.StatusInternalServerError {
+		details = "internal error"
+	}
+	edge.WriteError(w, statusCode, message, details)
+}
+
+// Messages are read and written with their proto field names, such as
+// user_id, and timestamps as RFC 3339 strings
+var (
+	unmarshalOptions = protojson.UnmarshalOptions{}
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true}
+)
+
+// decodeBody reads the JSON request body into m. Bodies must be sent as
+// application/json, which browsers cannot do across origins without a CORS
+// preflight. It answers the error and returns false when the body cannot be
+// read or parsed.
+func decodeBody(w http.ResponseWriter, r *http.Request, m proto.Message) bool {
+	defer r.Body.Close()
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		edge.WriteError(w, http.StatusUnsupportedMediaType, "Unsupported content type", "expected application/json")
+		return false
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			edge.WriteError(w, http.StatusRequestEntityTooLarge, "Request body too large",
+				fmt.Sprintf("request bodies are limited to %d bytes", maxBytesErr.Limit))
+			return false
+		}
+		edge.WriteError(w, http.StatusBadRequest, "Failed to read request body", err.Error())
+		return false
+	}
+	if err := unmarshalOptions.Unmarshal(body, m); err != nil {
+		edge.WriteError(w, http.StatusBadRequest, "Invalid request format", err.Error())
+		return false
+	}
+	return true
+}
+
+// writeJSON answers with status and m encoded as JSON
+func writeJSON(w http.ResponseWriter, logger *slog.Logger, status int, m proto.Message) {
+	body, err := marshalOptions.Marshal(m)
+	if err != nil {
+		logger.Error("Failed to encode response", "error", err)
+		edge.WriteError(w, http.StatusInternalServerError, "Failed to encode response", "internal error")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// pathID parses the {id} segment of the route
+func pathID(w http.ResponseWriter, r *http.Request, resource string) (int32, bool) {
+	return pathSegmentID(w, r, "id", resource)
+}
+
+// pathSegmentID parses the segment of the route holding the id of resource
+func pathSegmentID(w http.ResponseWriter, r *http.Request, segment, resource string) (int32, bool) {
+	id, err := strconv.ParseInt(r.PathValue(segment), 10, 32)
+	if err != nil || id < 1 {
+		edge.WriteError(w, http.StatusBadRequest, "Invalid "+resource+" id", fmt.Sprintf("%q is not a %s id", r.PathValue(segment), resource))
+		return 0, false
+	}
+	return int32(id), true
+}
+
+// scopeID parses the {id} segment of the routes that serve either a stream
+// or, with channel, every stream of a user
+func scopeID(w http.ResponseWriter, r *http.Request, channel bool) (ownerID, streamID int32, ok bool) {
+	if channel {
+		ownerID, ok = pathID(w, r, "user")
+	} else {
+		streamID, ok = pathID(w, r, "stream")
+	}
+	return ownerID, streamID, ok
+}
+
+// queryInt32 parses the positive integer query parameters of names into
+// their fields, leaving the fields of missing parameters untouched
+func queryInt32(w http.ResponseWriter, r *http.Request, fields map[string]*int32) bool {
+	query := r.URL.Query()
+	for name, field := range fields {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || parsed < 1 {
+			edge.WriteError(w, http.StatusBadRequest, "Invalid "+name+" query parameter", "expected a positive integer")
+			return false
+		}
+		*field = int32(parsed)
+	}
+	return true
+}
+
+// requireUser returns the platform user of the session, answering 401 to
+// anonymous requests and 403 to Clerk users who have not been synced yet.
+// Calls without a user are trusted by the services, so every route
+// changing data must go through it.
+func requireUser(w http.ResponseWriter, r *http.Request) (auth.User, bool) {
+	session, ok := edge.SessionFromContext(r.Context())
+	if !ok {
+		edge.WriteError(w, http.StatusUnauthorized, "Authentication required", "sign in with a Clerk session token")
+		return auth.User{}, false
+	}
+	user, ok := session.User()
+	if !ok {
+		edge.WriteError(w, http.StatusForbidden, "User not synced", "call POST /v1/users/me/sync first")
+		return auth.User{}, false
+	}
+	return user, true
+}
@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/clementus360/platform/logging"
+	streampb "github.com/clementus360/stream-service/proto"
+)
+
+// AddRestreamDestination registers a destination a stream is relayed to, or
+// with channel every stream of a user. Only the owner and admins manage
+// destinations.
+func AddRestreamDestination(streams streampb.StreamServiceClient, channel bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		ownerID, streamID, ok := scopeID(w, r, channel)
+		if !ok {
+			return
+		}
+
+		var req streampb.AddRestreamDestinationRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		req.OwnerId, req.StreamId = ownerID, streamID
+
+		destination, err := streams.AddRestreamDestination(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to add restream destination")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, destination)
+	}
+}
+
+// ListRestreamDestinations lists the destinations of a stream, or with
+// channel of a user, with the status of their connection
+func ListRestreamDestinations(streams streampb.StreamServiceClient, channel bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		ownerID, streamID, ok := scopeID(w, r, channel)
+		if !ok {
+			return
+		}
+
+		resp, err := streams.ListRestreamDestinations(r.Context(), &streampb.ListRestreamDestinationsRequest{OwnerId: ownerID, StreamId: streamID})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list restream destinations")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
+
+// UpdateRestreamDestination changes the fields present in the body, such as
+// enabled to pause or resume a destination
+func UpdateRestreamDestination(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		id, ok := pathID(w, r, "restream destination")
+		if !ok {
+			return
+		}
+
+		var req streampb.UpdateRestreamDestinationRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		req.Id = id
+
+		destination, err := streams.UpdateRestreamDestination(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to update restream destination")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, destination)
+	}
+}
+
+// RemoveRestreamDestination deletes a destination and stops relaying to it
+func RemoveRestreamDestination(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		id, ok := pathID(w, r, "restream destination")
+		if !ok {
+			return
+		}
+
+		if _, err := streams.RemoveRestreamDestination(r.Context(), &streampb.RemoveRestreamDestinationRequest{Id: id}); err != nil {
+			writeStatusError(w, logger, err, "Failed to remove restream destination")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,179 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/models"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListStreams lists streams, filtered by the user_id, status, visibility,
+// title_contains and description_contains query parameters. stream-service
+// only lists public streams to anonymous viewers.
+func ListStreams(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logging.FromContext(ctx)
+
+		req, ok := listStreamsRequest(w, r)
+		if !ok {
+			return
+		}
+
+		resp, err := streams.ListStreams(ctx, req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list streams")
+			return
+		}
+		for _, stream := range resp.Streams {
+			redactStream(ctx, stream)
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
+
+// listStreamsRequest parses the query parameters of the stream listings
+func listStreamsRequest(w http.ResponseWriter, r *http.Request) (*streampb.ListStreamsRequest, bool) {
+	query := r.URL.Query()
+	req := &streampb.ListStreamsRequest{
+		SortBy:    query.Get("sort_by"),
+		Ascending: query.Get("ascending") == "true",
+		Filter: &streampb.StreamFilter{
+			TitleContains:       query.Get("title_contains"),
+			DescriptionContains: query.Get("description_contains"),
+			Status:              query["status"],
+			Visibility:          query["visibility"],
+		},
+	}
+	ok := queryInt32(w, r, map[string]*int32{
+		"page":      &req.PageNumber,
+		"page_size": &req.PageSize,
+		"user_id":   &req.Filter.UserId,
+	})
+	return req, ok
+}
+
+// CreateStream creates a stream for the signed-in user, or for the user_id
+// of the body when admins create it for someone else
+func CreateStream(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		user, ok := requireUser(w, r)
+		if !ok {
+			return
+		}
+
+		var req streampb.CreateStreamRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		if req.UserId == 0 {
+			req.UserId = user.ID
+		}
+
+		stream, err := streams.CreateStream(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to create stream")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, stream)
+	}
+}
+
+// GetStream returns a stream. Stream keys are only shown to the owner.
+func GetStream(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logging.FromContext(ctx)
+		id, ok := pathID(w, r, "stream")
+		if !ok {
+			return
+		}
+
+		stream, err := getStream(ctx, streams, id)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to retrieve stream")
+			return
+		}
+		redactStream(ctx, stream)
+
+		writeJSON(w, logger, http.StatusOK, stream)
+	}
+}
+
+// UpdateStream changes the fields set in the body
+func UpdateStream(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		id, ok := pathID(w, r, "stream")
+		if !ok {
+			return
+		}
+
+		var req streampb.UpdateStreamRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		req.Id = id
+
+		stream, err := streams.UpdateStream(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to update stream")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, stream)
+	}
+}
+
+// DeleteStream moves a stream to the trash
+func DeleteStream(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		id, ok := pathID(w, r, "stream")
+		if !ok {
+			return
+		}
+
+		if _, err := streams.DeleteStream(r.Context(), &streampb.DeleteStreamRequest{Id: id}); err != nil {
+			writeStatusError(w, logger, err, "Failed to delete stream")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// getStream fetches a stream the viewer may see. stream-service applies the
+// visibility rules to users, while calls without one are trusted, so
+// private streams are hidden from anonymous viewers here.
+func getStream(ctx context.Context, streams streampb.StreamServiceClient, id int32) (*streampb.StreamResponse, error) {
+	stream, err := streams.GetStream(ctx, &streampb.GetStreamRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := auth.UserFromContext(ctx); !ok && stream.Visibility == models.VisibilityPrivate {
+		return nil, status.Errorf(codes.NotFound, "stream %d not found", id)
+	}
+	return stream, nil
+}
+
+// redactStream hides the stream key from viewers other than the owner
+func redactStream(ctx context.Context, stream *streampb.StreamResponse) {
+	if user, ok := auth.UserFromContext(ctx); !ok || !user.CanAccess(int64(stream.UserId)) {
+		stream.StreamKey = ""
+	}
+}
@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/clementus360/platform/logging"
+	streampb "github.com/clementus360/stream-service/proto"
+)
+
+// ListDeletedStreams lists the streams in the trash of the signed-in user,
+// most recently deleted first. Admins list anyone's with the user_id query
+// parameter. deleted_before only lists streams deleted before that time.
+func ListDeletedStreams(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+
+		req := &streampb.ListDeletedStreamsRequest{DeletedBefore: r.URL.Query().Get("deleted_before")}
+		if !queryInt32(w, r, map[string]*int32{
+			"page":      &req.PageNumber,
+			"page_size": &req.PageSize,
+			"user_id":   &req.UserId,
+		}) {
+			return
+		}
+
+		resp, err := streams.ListDeletedStreams(r.Context(), req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list deleted streams")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
+
+// RestoreStream takes a stream out of the trash
+func RestoreStream(streams streampb.StreamServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		id, ok := pathID(w, r, "stream")
+		if !ok {
+			return
+		}
+
+		stream, err := streams.RestoreStream(r.Context(), &streampb.RestoreStreamRequest{Id: id})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to restore stream")
+			return
+		}
+		redactStream(r.Context(), stream)
+
+		writeJSON(w, logger, http.StatusOK, stream)
+	}
+}
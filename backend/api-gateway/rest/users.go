@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+
+	"github.com/clementus360/api-gateway/edge"
+)
+
+// CurrentUser returns the platform user of the session
+func CurrentUser(users userpb.UserServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		user, ok := requireUser(w, r)
+		if !ok {
+			return
+		}
+
+		resp, err := users.GetUser(r.Context(), &userpb.GetUserRequest{Id: int32(user.ID)})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to retrieve user")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp.User)
+	}
+}
+
+// SyncUser creates or refreshes the platform user of the Clerk session from
+// its Clerk profile. It is the first call of a new user.
+func SyncUser(users userpb.UserServiceClient, sessions *edge.Sessions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		session, ok := edge.SessionFromContext(r.Context())
+		if !ok {
+			edge.WriteError(w, http.StatusUnauthorized, "Authentication required", "sign in with a Clerk session token")
+			return
+		}
+
+		resp, err := users.SyncUserWithClerk(r.Context(), &userpb.SyncUserWithClerkRequest{ClerkId: session.ClerkID})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to sync user")
+			return
+		}
+		// the next request of the session picks up the platform user
+		sessions.Forget(session.ClerkID)
+
+		writeJSON(w, logger, http.StatusOK, resp.User)
+	}
+}
+
+// GetUser returns the public profile of a user. The email and Clerk id are
+// only shown to the user themselves.
+func GetUser(users userpb.UserServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logging.FromContext(ctx)
+		id, ok := pathID(w, r, "user")
+		if !ok {
+			return
+		}
+
+		resp, err := users.GetUser(ctx, &userpb.GetUserRequest{Id: id})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to retrieve user")
+			return
+		}
+		redactUser(ctx, resp.User)
+
+		writeJSON(w, logger, http.StatusOK, resp.User)
+	}
+}
+
+// UpdateUser changes the profile fields set in the body
+func UpdateUser(users userpb.UserServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		if _, ok := requireUser(w, r); !ok {
+			return
+		}
+		id, ok := pathID(w, r, "user")
+		if !ok {
+			return
+		}
+
+		var req userpb.UpdateUserRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		req.Id = id
+
+		resp, err := users.UpdateUser(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to update user")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp.User)
+	}
+}
+
+// DeleteUser deletes a user
+func DeleteUser(users userpb.UserServiceClient, sessions *edge.Sessions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		user, ok := requireUser(w, r)
+		if !ok {
+			return
+		}
+		id, ok := pathID(w, r, "user")
+		if !ok {
+			return
+		}
+
+		if _, err := users.DeleteUser(r.Context(), &userpb.DeleteUserRequest{Id: id}); err != nil {
+			writeStatusError(w, logger, err, "Failed to delete user")
+			return
+		}
+		// sessions of other deleted users expire with the identity cache
+		if user.ID == int64(id) {
+			session, _ := edge.SessionFromContext(r.Context())
+			sessions.Forget(session.ClerkID)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// redactUser hides the contact details of a user from other viewers
+func redactUser(ctx context.Context, user *userpb.User) {
+	if viewer, ok := auth.UserFromContext(ctx); !ok || !viewer.CanAccess(int64(user.Id)) {
+		user.Email = ""
+		user.ClerkId = ""
+	}
+}
@@ -0,0 +1,318 @@
+// Package app assembles the comment service from its configuration. It is
+// shared by cmd/server and the integration tests, which run the service
+// in-process.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/Josy-coder/comment-service/internal/clients"
+	"github.com/Josy-coder/comment-service/internal/config"
+	"github.com/Josy-coder/comment-service/internal/hub"
+	"github.com/Josy-coder/comment-service/internal/metrics"
+	"github.com/Josy-coder/comment-service/internal/ports"
+	"github.com/Josy-coder/comment-service/internal/service"
+	pb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"github.com/clementus360/platform/audit"
+	"github.com/clementus360/platform/auth"
+	platformconfig "github.com/clementus360/platform/config"
+	"github.com/clementus360/platform/dial"
+	"github.com/clementus360/platform/health"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/tlsconfig"
+	"github.com/clementus360/platform/tracing"
+	"github.com/clementus360/streamdb-api/streamdb"
+)
+
+// LoadConfig reads the configuration from defaults, config file, environment
+// and flags
+func LoadConfig(args []string) (*config.Config, error) {
+	return config.LoadConfig(args)
+}
+
+// Option customizes an App
+type Option func(*options)
+
+type options struct {
+	logger      *slog.Logger
+	dialOptions []grpc.DialOption
+}
+
+// WithLogger replaces the logger built from the configuration
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithDialOptions adds options to the connections to other services
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// App is a comment service connected to its dependencies
+type App struct {
+	tlsManager   *tlsconfig.Manager
+	checker      *health.Checker
+	grpcServer   *grpc.Server
+	handler      http.Handler
+	dbClient     *clients.DBServiceClient
+	userClient   *clients.UserServiceClient
+	streamClient *clients.StreamServiceClient
+	auditConn    *grpc.ClientConn
+	hub          *hub.Hub
+	redisBroker  *hub.RedisBroker
+}
+
+// New connects to the dependencies and registers the comment service on a
+// gRPC server. Nothing is served until the server is handed a listener.
+func New(ctx context.Context, cfg *config.Config, opts ...Option) (*App, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	logger := o.logger
+	if logger == nil {
+		logger = logging.New("comment-service", cfg.Log.Level, cfg.Log.Format)
+	}
+
+	// Load the certificates shared by the gRPC server and clients
+	tlsManager, err := tlsconfig.New(ctx, "comment-service", tlsconfig.Config{
+		Enabled:           cfg.TLS.Enabled,
+		CertFile:          cfg.TLS.CertFile,
+		KeyFile:           cfg.TLS.KeyFile,
+		CAFile:            cfg.TLS.CAFile,
+		ClientAuth:        cfg.TLS.ClientAuth,
+		AllowedIdentities: cfg.TLS.AllowedIdentities,
+		ReloadInterval:    cfg.TLS.ReloadInterval,
+		DevMode:           cfg.TLS.DevMode,
+		DevDir:            cfg.TLS.DevDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize TLS: %w", err)
+	}
+
+	// Sign outgoing calls and verify incoming ones with the shared service key
+	authenticator := auth.New("comment-service", auth.Config{
+		SigningKey:     cfg.Auth.SigningKey,
+		Enforce:        cfg.Auth.Enforce,
+		AllowedCallers: cfg.Auth.AllowedCallers,
+		TrustedCallers: cfg.Auth.TrustedCallers,
+		TokenTTL:       cfg.Auth.TokenTTL,
+	})
+
+	// Register the Prometheus collectors exposed on /metrics
+	serviceMetrics := metrics.New("comment_service")
+
+	// Every outgoing connection shares the same deadlines, retries and
+	// circuit breaker settings
+	methodTimeouts, _ := cfg.Client.MethodTimeoutMap()
+	dialer := dial.NewDialer(dial.Config{
+		Timeout:        cfg.Client.Timeout,
+		MethodTimeouts: methodTimeouts,
+		Retry: dial.RetryConfig{
+			MaxAttempts:    cfg.Client.RetryMaxAttempts,
+			InitialBackoff: cfg.Client.RetryInitialBackoff,
+			MaxBackoff:     cfg.Client.RetryMaxBackoff,
+		},
+		Breaker: dial.BreakerConfig{
+			Failures:    cfg.Client.BreakerFailures,
+			OpenTimeout: cfg.Client.BreakerOpenTimeout,
+		},
+		KeepaliveTime:     cfg.Client.KeepaliveTime,
+		KeepaliveTimeout:  cfg.Client.KeepaliveTimeout,
+		MaxConnectBackoff: cfg.Client.MaxConnectBackoff,
+		DialOptions:       o.dialOptions,
+	}, tlsManager, authenticator, serviceMetrics.Metrics)
+
+	a := &App{tlsManager: tlsManager}
+
+	// Initialize service clients
+	a.dbClient, err = clients.NewDBServiceClient(cfg.Services.DBServiceURL, dialer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database service client: %w", err)
+	}
+
+	a.userClient, err = clients.NewUserServiceClient(cfg.Services.UserServiceURL, dialer)
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to create user service client: %w", err)
+	}
+
+	a.streamClient, err = clients.NewStreamServiceClient(cfg.Services.StreamServiceURL, dialer)
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to create stream service client: %w", err)
+	}
+
+	// Changes to comments are fanned out to the subscribers of every replica
+	// through the broker
+	var broker hub.Broker = hub.NewMemoryBroker()
+	if cfg.Hub.Broker == "redis" {
+		a.redisBroker, err = hub.NewRedisBroker(cfg.Hub.RedisURL, cfg.Hub.RedisChannel)
+		if err != nil {
+			a.Close()
+			return nil, err
+		}
+		broker = a.redisBroker
+	}
+	a.hub = hub.New(broker, cfg.Hub.BufferSize)
+
+	commentService := service.NewCommentService(a.dbClient, a.userClient, a.streamClient, a.hub)
+
+	// Mutations are recorded in the audit log StreamDb keeps for every
+	// service
+	a.auditConn, err = dialer.Dial(dial.Target{
+		Name:       "audit_log",
+		Audience:   "database-service",
+		Address:    cfg.Audit.Address,
+		Service:    streamdb.WireName(streamdb.AuditService_ServiceDesc.ServiceName),
+		Idempotent: []string{"QueryAuditLog"},
+	})
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to connect to the audit log: %w", err)
+	}
+	auditLog := audit.NewLog("comment-service", streamdb.NewAuditServiceClient(streamdb.WireConn(a.auditConn)))
+	auditRecorder := audit.NewRecorder(auditLog, ports.AuditedMethods(commentService))
+
+	a.grpcServer = grpc.NewServer(
+		tlsManager.ServerOption(),
+		tracing.ServerOption(),
+		// Accept the keepalive pings of other services' clients
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             platformconfig.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(logger),
+			serviceMetrics.UnaryServerInterceptor(),
+			authenticator.UnaryServerInterceptor(),
+			auditRecorder.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			logging.StreamServerInterceptor(logger),
+			serviceMetrics.StreamServerInterceptor(),
+			authenticator.StreamServerInterceptor(),
+		),
+	)
+	grpcService := ports.NewGRPCServer(commentService, serviceMetrics, auditLog)
+	pb.RegisterCommentServiceServer(a.grpcServer, grpcService)
+
+	// Probe dependencies for readiness and expose grpc.health.v1
+	a.checker = health.NewChecker(2*time.Second, pb.CommentService_ServiceDesc.ServiceName)
+	a.checker.AddProbe("database_service", health.ConnProbe(a.dbClient.Conn()))
+	a.checker.AddProbe("user_service", health.ConnProbe(a.userClient.Conn()))
+	a.checker.AddProbe("stream_service", health.ConnProbe(a.streamClient.Conn()))
+	a.checker.AddProbe("audit_log", health.ConnProbe(a.auditConn))
+	if a.redisBroker != nil {
+		a.checker.AddProbe("redis", a.redisBroker.Ping)
+	}
+	a.checker.Register(a.grpcServer)
+
+	// Enable reflection for development purposes
+	if cfg.Server.Env == "development" {
+		reflection.Register(a.grpcServer)
+	}
+
+	// Operational endpoints
+	router := http.NewServeMux()
+	router.Handle("GET /metrics", serviceMetrics.Handler())
+	router.HandleFunc("GET /healthz", a.checker.LivenessHandler())
+	router.HandleFunc("GET /readyz", a.checker.ReadinessHandler())
+
+	// Signed-in users join the chat of a stream over WebSocket
+	if cfg.Chat.Enabled {
+		// The Backend API URL is only overridden to talk to a fake identity
+		// provider
+		var clerkOptions []clerk.ClerkOption
+		if cfg.Chat.ClerkAPIURL != "" {
+			clerkOptions = append(clerkOptions, clerk.WithBaseURL(cfg.Chat.ClerkAPIURL))
+		}
+		clerkClient, err := clerk.NewClient(cfg.Chat.ClerkSecretKey, clerkOptions...)
+		if err != nil {
+			a.Close()
+			return nil, fmt.Errorf("failed to create Clerk client: %w", err)
+		}
+		chat := ports.NewChatHandler(commentService, grpcService, auditRecorder.UnaryServerInterceptor(),
+			clients.NewAuthClient(clerkClient, a.userClient), serviceMetrics, ports.ChatConfig{
+				AllowedOrigins: cfg.Chat.AllowedOrigins,
+				SendBuffer:     cfg.Chat.SendBuffer,
+				PingInterval:   cfg.Chat.PingInterval,
+				WriteTimeout:   cfg.Chat.WriteTimeout,
+			})
+		router.Handle("GET /chat/streams/{stream_id}", chat)
+	}
+	a.handler = tracing.Middleware(logging.Middleware(logger, serviceMetrics.Middleware(router)))
+
+	return a, nil
+}
+
+// GRPCServer returns the server the comment service is registered on
+func (a *App) GRPCServer() *grpc.Server {
+	return a.grpcServer
+}
+
+// Handler serves the metrics and health endpoints, and the chat
+func (a *App) Handler() http.Handler {
+	return a.handler
+}
+
+// Start runs the loops that keep the readiness status and certificates up to
+// date, and that receive the changes to comments from the broker, in the
+// background until ctx is cancelled
+func (a *App) Start(ctx context.Context) {
+	go a.checker.Run(ctx, 10*time.Second)
+	go a.tlsManager.Watch(ctx)
+	go a.hub.Run(ctx)
+}
+
+// Drain reports NOT_SERVING so that load balancers stop sending traffic
+// before the server stops
+func (a *App) Drain() {
+	a.checker.Shutdown()
+}
+
+// Close ends the subscriptions to comments, stops the gRPC server
+// gracefully and closes the connections to the dependencies and the audit
+// log
+func (a *App) Close() error {
+	// Subscriptions never end on their own, so GracefulStop would wait for
+	// them forever
+	if a.hub != nil {
+		a.hub.Close()
+	}
+	if a.grpcServer != nil {
+		a.grpcServer.GracefulStop()
+	}
+
+	var errs []error
+	if a.dbClient != nil {
+		errs = append(errs, a.dbClient.Close())
+	}
+	if a.userClient != nil {
+		errs = append(errs, a.userClient.Close())
+	}
+	if a.streamClient != nil {
+		errs = append(errs, a.streamClient.Close())
+	}
+	if a.auditConn != nil {
+		errs = append(errs, a.auditConn.Close())
+	}
+	if a.redisBroker != nil {
+		errs = append(errs, a.redisBroker.Close())
+	}
+	return errors.Join(errs...)
+}
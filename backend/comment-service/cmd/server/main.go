@@ -1,63 +1,83 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
-
-	"github.com/Josy-coder/comment-service/internal/clients"
-	"github.com/Josy-coder/comment-service/internal/config"
-	"github.com/Josy-coder/comment-service/internal/ports"
-	"github.com/Josy-coder/comment-service/internal/service"
-	pb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"github.com/Josy-coder/comment-service/app"
+	"github.com/clementus360/platform/config"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/tracing"
 )
 
 func main() {
-	// Load configuration
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+	// Load configuration from defaults, config file, environment and flags
+	cfg, err := app.LoadConfig(os.Args[1:])
+	if cfg.PrintConfig {
+		config.Print(os.Stdout, cfg)
 	}
-
-	// Initialize service clients
-	dbClient, err := clients.NewDBServiceClient(cfg.Services.DBServiceURL)
 	if err != nil {
-		log.Fatalf("Failed to create database service client: %v", err)
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		os.Exit(2)
 	}
+	if cfg.PrintConfig {
+		return
+	}
+
+	logger := logging.New("comment-service", cfg.Log.Level, cfg.Log.Format)
+	slog.SetDefault(logger)
 
-	userClient, err := clients.NewUserServiceClient(cfg.Services.UserServiceURL)
+	// Set up tracing before any client is created so every connection is
+	// instrumented
+	shutdownTracing, err := tracing.Setup(context.Background(), "comment-service", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.OTLPInsecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
 	if err != nil {
-		log.Fatalf("Failed to create user service client: %v", err)
+		logger.Error("Failed to initialize tracing", "error", err)
+		os.Exit(1)
 	}
+	defer shutdownTracing(context.Background())
 
-	streamClient, err := clients.NewStreamServiceClient(cfg.Services.StreamServiceURL)
+	// Connect to the dependencies and register the comment service
+	commentApp, err := app.New(context.Background(), cfg, app.WithLogger(logger))
 	if err != nil {
-		log.Fatalf("Failed to create stream service client: %v", err)
+		logger.Error("Failed to initialize comment service", "error", err)
+		os.Exit(1)
 	}
 
-	// Initialize comment service
-	commentService := service.NewCommentService(dbClient, userClient, streamClient)
-
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
-	commentServer := ports.NewGRPCServer(commentService)
-	pb.RegisterCommentServiceServer(grpcServer, commentServer)
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	commentApp.Start(backgroundCtx)
 
-	// Enable reflection for development purposes
-	if cfg.Server.Env == "development" {
-		reflection.Register(grpcServer)
+	// Start HTTP server for operational endpoints
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler: commentApp.Handler(),
 	}
 
+	go func() {
+		logger.Info("Starting HTTP server", "port", cfg.Server.Port)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("Failed to serve HTTP", "error", err)
+			os.Exit(1)
+		}
+	}()
+
 	// Start gRPC server
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.GRPCPort))
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		logger.Error("Failed to listen", "error", err)
+		os.Exit(1)
 	}
 
 	// Handle graceful shutdown
@@ -65,12 +85,23 @@ func main() {
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 		<-sigChan
-		log.Println("Received shutdown signal")
-		grpcServer.GracefulStop()
+		logger.Info("Received shutdown signal", "drain_period", cfg.Server.DrainPeriod.String())
+
+		// Report NOT_SERVING first so load balancers drain before we stop
+		commentApp.Drain()
+		time.Sleep(cfg.Server.DrainPeriod)
+
+		if err := httpServer.Shutdown(context.Background()); err != nil {
+			logger.Error("Failed to shut down HTTP server", "error", err)
+		}
+		if err := commentApp.Close(); err != nil {
+			logger.Error("Failed to close service clients", "error", err)
+		}
 	}()
 
-	log.Printf("Starting gRPC server on port %d", cfg.Server.GRPCPort)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	logger.Info("Starting gRPC server", "port", cfg.Server.GRPCPort)
+	if err := commentApp.GRPCServer().Serve(lis); err != nil {
+		logger.Error("Failed to serve", "error", err)
+		os.Exit(1)
 	}
 }
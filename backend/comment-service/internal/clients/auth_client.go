@@ -0,0 +1,95 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+
+	"github.com/clementus360/platform/auth"
+)
+
+var (
+	ErrNoToken          = errors.New("no auth token provided")
+	ErrInvalidToken     = errors.New("invalid auth token")
+	ErrNotAuthenticated = errors.New("user not authenticated")
+)
+
+// sessionCookie is the cookie Clerk's frontend SDKs keep the session token in
+const sessionCookie = "__session"
+
+// AuthClient authenticates end users connecting over HTTP with a Clerk
+// session token
+type AuthClient struct {
+	clerkClient clerk.Client
+	userClient  *UserServiceClient
+}
+
+func NewAuthClient(clerkClient clerk.Client, userClient *UserServiceClient) *AuthClient {
+	return &AuthClient{
+		clerkClient: clerkClient,
+		userClient:  userClient,
+	}
+}
+
+// Authenticate verifies the session token of r and returns the platform
+// user of its Clerk user, with the roles stored in the "roles" entry of
+// their public metadata. Users who have not been synced to the platform yet
+// get domain.ErrUserNotFound.
+func (c *AuthClient) Authenticate(r *http.Request) (auth.User, error) {
+	token, err := extractToken(r)
+	if err != nil {
+		return auth.User{}, err
+	}
+
+	claims, err := c.clerkClient.VerifyToken(token)
+	if err != nil {
+		return auth.User{}, ErrInvalidToken
+	}
+	clerkUser, err := c.clerkClient.Users().Read(claims.Subject)
+	if err != nil {
+		return auth.User{}, ErrNotAuthenticated
+	}
+
+	id, err := c.userClient.GetUserByClerkID(r.Context(), clerkUser.ID)
+	if err != nil {
+		return auth.User{}, err
+	}
+	return auth.User{ID: int64(id), Roles: roles(clerkUser)}, nil
+}
+
+// extractToken reads the Bearer token of the Authorization header, or the
+// session cookie that browsers send when opening a WebSocket
+func extractToken(r *http.Request) (string, error) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			return "", ErrInvalidToken
+		}
+		return token, nil
+	}
+	if cookie, err := r.Cookie(sessionCookie); err == nil && cookie.Value != "" {
+		return cookie.Value, nil
+	}
+	return "", ErrNoToken
+}
+
+func roles(user *clerk.User) []string {
+	metadata, ok := user.PublicMetadata.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	values, ok := metadata["roles"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var roles []string
+	for _, v := range values {
+		if role, ok := v.(string); ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
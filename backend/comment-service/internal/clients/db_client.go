@@ -6,8 +6,10 @@ import (
 
 	"github.com/Josy-coder/comment-service/internal/domain"
 	pb "github.com/Josy-coder/db-service/proto/db/v1"
+	"github.com/clementus360/platform/dial"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -16,8 +18,14 @@ type DBServiceClient struct {
 	conn   *grpc.ClientConn
 }
 
-func NewDBServiceClient(address string) (*DBServiceClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewDBServiceClient(address string, dialer *dial.Dialer) (*DBServiceClient, error) {
+	conn, err := dialer.Dial(dial.Target{
+		Name:       "database_service",
+		Audience:   "database-service",
+		Address:    address,
+		Service:    pb.DatabaseService_ServiceDesc.ServiceName,
+		Idempotent: []string{"GetComment", "ListComments"},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database service: %w", err)
 	}
@@ -33,11 +41,22 @@ func (c *DBServiceClient) Close() error {
 	return c.conn.Close()
 }
 
+// Conn exposes the underlying connection for health probes
+func (c *DBServiceClient) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
 func (c *DBServiceClient) CreateComment(ctx context.Context, comment *domain.Comment) error {
-	_, err := c.client.CreateComment(ctx, &pb.CreateCommentRequest{
+	resp, err := c.client.CreateComment(ctx, &pb.CreateCommentRequest{
 		Comment: toProtoComment(comment),
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	// The database assigns the id
+	comment.ID = resp.Comment.GetId()
+	return nil
 }
 
 func (c *DBServiceClient) GetComment(ctx context.Context, id int32) (*domain.Comment, error) {
@@ -45,7 +64,7 @@ func (c *DBServiceClient) GetComment(ctx context.Context, id int32) (*domain.Com
 		Id: id,
 	})
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return toDomainComment(resp.Comment), nil
@@ -55,13 +74,21 @@ func (c *DBServiceClient) UpdateComment(ctx context.Context, comment *domain.Com
 	_, err := c.client.UpdateComment(ctx, &pb.UpdateCommentRequest{
 		Comment: toProtoComment(comment),
 	})
-	return err
+	return mapError(err)
 }
 
 func (c *DBServiceClient) DeleteComment(ctx context.Context, id int32) error {
 	_, err := c.client.DeleteComment(ctx, &pb.DeleteCommentRequest{
 		Id: id,
 	})
+	return mapError(err)
+}
+
+// mapError translates the status of a missing comment into the domain error
+func mapError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return domain.ErrCommentNotFound
+	}
 	return err
 }
 
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 
-	pb "github.com/Josy-coder/stream-service/proto/stream/v1"
+	"github.com/Josy-coder/comment-service/internal/domain"
+	"github.com/clementus360/platform/dial"
+	pb "github.com/clementus360/stream-service/proto"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type StreamServiceClient struct {
@@ -14,8 +17,14 @@ type StreamServiceClient struct {
 	conn   *grpc.ClientConn
 }
 
-func NewStreamServiceClient(address string) (*StreamServiceClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewStreamServiceClient(address string, dialer *dial.Dialer) (*StreamServiceClient, error) {
+	conn, err := dialer.Dial(dial.Target{
+		Name:       "stream_service",
+		Audience:   "stream-service",
+		Address:    address,
+		Service:    pb.StreamService_ServiceDesc.ServiceName,
+		Idempotent: []string{"GetStream"},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to stream service: %w", err)
 	}
@@ -31,9 +40,17 @@ func (c *StreamServiceClient) Close() error {
 	return c.conn.Close()
 }
 
+// Conn exposes the underlying connection for health probes
+func (c *StreamServiceClient) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
 func (c *StreamServiceClient) GetStream(ctx context.Context, streamID int32) error {
 	_, err := c.client.GetStream(ctx, &pb.GetStreamRequest{
 		Id: streamID,
 	})
+	if status.Code(err) == codes.NotFound {
+		return domain.ErrStreamNotFound
+	}
 	return err
 }
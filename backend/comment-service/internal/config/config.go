@@ -1,46 +1,137 @@
 package config
 
 import (
-	"github.com/joho/godotenv"
-	"os"
-	"strconv"
+	"errors"
+	"fmt"
+	"time"
+
+	platformconfig "github.com/clementus360/platform/config"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Services ServicesConfig
+	Server   ServerConfig                 `yaml:"server"`
+	Services ServicesConfig               `yaml:"services"`
+	Log      platformconfig.LogConfig     `yaml:"log"`
+	Tracing  platformconfig.TracingConfig `yaml:"tracing"`
+	TLS      platformconfig.TLSConfig     `yaml:"tls"`
+	Auth     platformconfig.AuthConfig    `yaml:"auth"`
+	Client   platformconfig.ClientConfig  `yaml:"client"`
+	Audit    AuditConfig                  `yaml:"audit"`
+	Hub      HubConfig                    `yaml:"hub"`
+	Chat     ChatConfig                   `yaml:"chat"`
+
+	// PrintConfig dumps the effective configuration and exits
+	PrintConfig bool `yaml:"-" flag:"print-config"`
 }
 
 type ServerConfig struct {
-	Port     int
-	GRPCPort int
-	Env      string
+	Port        int           `yaml:"port" env:"SERVER_PORT" flag:"port" default:"9091" required:"true"`
+	GRPCPort    int           `yaml:"grpc_port" env:"SERVER_GRPC_PORT" flag:"grpc-port" default:"50053" required:"true"`
+	Env         string        `yaml:"env" env:"SERVER_ENV" flag:"env" default:"production"`
+	DrainPeriod time.Duration `yaml:"drain_period" env:"SERVER_DRAIN_PERIOD" flag:"drain-period" default:"5s"`
 }
 
 type ServicesConfig struct {
-	DBServiceURL     string
-	UserServiceURL   string
-	StreamServiceURL string
+	DBServiceURL     string `yaml:"db_service_url" env:"DB_SERVICE_URL" flag:"db-service-url" required:"true"`
+	UserServiceURL   string `yaml:"user_service_url" env:"USER_SERVICE_URL" flag:"user-service-url" required:"true"`
+	StreamServiceURL string `yaml:"stream_service_url" env:"STREAM_SERVICE_URL" flag:"stream-service-url" required:"true"`
+}
+
+// AuditConfig controls where the audit log of mutations is kept
+type AuditConfig struct {
+	// Address of StreamDb, which keeps the audit log of every service
+	Address string `yaml:"address" env:"AUDIT_DB_ADDRESS" flag:"audit-db-address" required:"true"`
+}
+
+// HubConfig controls the live subscriptions to comments
+type HubConfig struct {
+	// Broker shares the changes to comments between replicas: memory keeps
+	// them within the process, redis publishes them on a Redis channel
+	Broker       string `yaml:"broker" env:"HUB_BROKER" flag:"hub-broker" default:"memory"`
+	RedisURL     string `yaml:"redis_url" env:"HUB_REDIS_URL" flag:"hub-redis-url" secret:"true"`
+	RedisChannel string `yaml:"redis_channel" env:"HUB_REDIS_CHANNEL" default:"comment-service:events"`
+	// BufferSize is the number of events a subscriber may fall behind
+	// before it is disconnected
+	BufferSize int `yaml:"buffer_size" env:"HUB_BUFFER_SIZE" flag:"hub-buffer-size" default:"64"`
+}
+
+// ChatConfig controls the WebSocket chat served on the HTTP port
+type ChatConfig struct {
+	Enabled bool `yaml:"enabled" env:"CHAT_ENABLED" flag:"chat" default:"false"`
+	// ClerkSecretKey verifies the session tokens of the end users joining
+	// the chat
+	ClerkSecretKey string `yaml:"clerk_secret_key" env:"CLERK_SECRET_KEY" secret:"true"`
+	// ClerkAPIURL overrides the Clerk Backend API URL, only for tests
+	ClerkAPIURL string `yaml:"clerk_api_url" env:"CLERK_API_URL"`
+	// AllowedOrigins are the host patterns of the pages allowed to connect
+	// from a browser besides the host of the service itself
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CHAT_ALLOWED_ORIGINS" flag:"chat-allowed-origins"`
+	// SendBuffer is the number of messages queued for a connection before it
+	// is closed as too slow
+	SendBuffer   int           `yaml:"send_buffer" env:"CHAT_SEND_BUFFER" flag:"chat-send-buffer" default:"32"`
+	PingInterval time.Duration `yaml:"ping_interval" env:"CHAT_PING_INTERVAL" default:"30s"`
+	// WriteTimeout bounds the time a client takes to accept a message or
+	// answer a ping
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"CHAT_WRITE_TIMEOUT" default:"10s"`
+}
+
+// LoadConfig reads the configuration from defaults, an optional YAML file,
+// the environment and the command-line arguments
+func LoadConfig(args []string) (*Config, error) {
+	cfg := &Config{}
+	if err := platformconfig.Load("comment-service", cfg, args); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
 }
 
-func LoadConfig() (*Config, error) {
-	if err := godotenv.Load(); err != nil {
-		return nil, err
+// Validate checks values that cannot be expressed with struct tags
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := validatePort("SERVER_PORT", c.Server.Port); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validatePort("SERVER_GRPC_PORT", c.Server.GRPCPort); err != nil {
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, c.Tracing.Validate()...)
+	errs = append(errs, c.TLS.Validate()...)
+	errs = append(errs, c.Auth.Validate()...)
+	errs = append(errs, c.Client.Validate()...)
+
+	switch c.Hub.Broker {
+	case "memory":
+	case "redis":
+		if c.Hub.RedisURL == "" {
+			errs = append(errs, errors.New("HUB_REDIS_URL is required when HUB_BROKER is redis"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("HUB_BROKER must be memory or redis, got %q", c.Hub.Broker))
+	}
+	if c.Hub.BufferSize < 1 {
+		errs = append(errs, fmt.Errorf("HUB_BUFFER_SIZE must be positive, got %d", c.Hub.BufferSize))
 	}
 
-	grpcPort, _ := strconv.Atoi(os.Getenv("SERVER_GRPC_PORT"))
-	port, _ := strconv.Atoi(os.Getenv("SERVER_PORT"))
-
-	return &Config{
-		Server: ServerConfig{
-			Port:     port,
-			GRPCPort: grpcPort,
-			Env:      os.Getenv("SERVER_ENV"),
-		},
-		Services: ServicesConfig{
-			DBServiceURL:     os.Getenv("DB_SERVICE_URL"),
-			UserServiceURL:   os.Getenv("USER_SERVICE_URL"),
-			StreamServiceURL: os.Getenv("STREAM_SERVICE_URL"),
-		},
-	}, nil
+	if c.Chat.Enabled {
+		if c.Chat.ClerkSecretKey == "" {
+			errs = append(errs, errors.New("CLERK_SECRET_KEY is required when CHAT_ENABLED is set"))
+		}
+		if c.Chat.SendBuffer < 1 {
+			errs = append(errs, fmt.Errorf("CHAT_SEND_BUFFER must be positive, got %d", c.Chat.SendBuffer))
+		}
+		if c.Chat.PingInterval <= 0 || c.Chat.WriteTimeout <= 0 {
+			errs = append(errs, errors.New("CHAT_PING_INTERVAL and CHAT_WRITE_TIMEOUT must be positive"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validatePort(name string, port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("%s must be a port number between 1 and 65535, got %d", name, port)
+	}
+	return nil
 }
@@ -8,8 +8,12 @@ import (
 
 var (
 	ErrCommentNotFound = errors.New("comment not found")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrStreamNotFound  = errors.New("stream not found")
 	ErrCommentTooLong  = errors.New("comment too long")
 	ErrEmptyComment    = errors.New("empty comment")
+	ErrForbidden       = errors.New("not allowed to act on behalf of another user")
+	ErrUnauthenticated = errors.New("the call is not made on behalf of a user")
 )
 
 const MaxCommentLength = 1000
@@ -24,6 +28,21 @@ type Comment struct {
 	DeletedAt *time.Time
 }
 
+// Types of CommentEvent
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+// CommentEvent is a change to a comment, sent to the subscribers of the
+// stream it belongs to. Deleted comments are sent as they were before the
+// deletion.
+type CommentEvent struct {
+	Type    string
+	Comment Comment
+}
+
 type CommentFilter struct {
 	UserID   *int32
 	StreamID *int32
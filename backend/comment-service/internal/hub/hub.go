@@ -0,0 +1,207 @@
+// Package hub fans the changes to comments out to the live subscribers of
+// each stream. Events go through a Broker, which shares them between the
+// replicas of the service.
+package hub
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Josy-coder/comment-service/internal/domain"
+	"github.com/clementus360/platform/logging"
+)
+
+var (
+	// ErrSlowConsumer ends the subscriptions that do not keep up with the
+	// events of their stream
+	ErrSlowConsumer = errors.New("subscriber too slow, events were dropped")
+	// ErrClosed ends the subscriptions when the hub shuts down
+	ErrClosed = errors.New("comment hub is shutting down")
+)
+
+// publishTimeout bounds the time a mutation waits for the broker
+const publishTimeout = 2 * time.Second
+
+// Broker carries events between the replicas of the service
+type Broker interface {
+	// Publish sends an event to every replica, including this one
+	Publish(ctx context.Context, event domain.CommentEvent) error
+	// Subscribe calls deliver with the events published by every replica
+	// until ctx is cancelled or the subscription fails
+	Subscribe(ctx context.Context, deliver func(domain.CommentEvent)) error
+}
+
+// Hub dispatches the events received from its broker to the subscribers of
+// their stream
+type Hub struct {
+	broker Broker
+	buffer int
+
+	mu          sync.Mutex
+	subscribers map[int32]map[*Subscription]struct{}
+	closed      bool
+}
+
+// New returns a hub receiving events from broker. Each subscriber may fall
+// buffer events behind before it is disconnected.
+func New(broker Broker, buffer int) *Hub {
+	return &Hub{
+		broker:      broker,
+		buffer:      buffer,
+		subscribers: make(map[int32]map[*Subscription]struct{}),
+	}
+}
+
+// Publish sends event to the subscribers of its stream on every replica.
+// Failures are logged rather than returned, as the change they announce has
+// already been made.
+func (h *Hub) Publish(ctx context.Context, event domain.CommentEvent) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), publishTimeout)
+	defer cancel()
+	if err := h.broker.Publish(ctx, event); err != nil {
+		logging.FromContext(ctx).Error("Failed to publish comment event",
+			"type", event.Type, "comment_id", event.Comment.ID, "error", err)
+	}
+}
+
+// Subscribe returns a subscription to the events of streamID. It must be
+// closed once the subscriber is gone.
+func (h *Hub) Subscribe(streamID int32) *Subscription {
+	sub := &Subscription{
+		hub:      h,
+		streamID: streamID,
+		events:   make(chan domain.CommentEvent, h.buffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		sub.end(ErrClosed)
+		return sub
+	}
+	if h.subscribers[streamID] == nil {
+		h.subscribers[streamID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[streamID][sub] = struct{}{}
+	return sub
+}
+
+// Subscribers returns the number of open subscriptions
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := 0
+	for _, subs := range h.subscribers {
+		n += len(subs)
+	}
+	return n
+}
+
+// Run receives the events of the broker until ctx is cancelled, subscribing
+// again with a backoff when the broker fails. Events published while the
+// broker is unreachable are lost.
+func (h *Hub) Run(ctx context.Context) {
+	backoff := 100 * time.Millisecond
+	for {
+		start := time.Now()
+		err := h.broker.Subscribe(ctx, h.dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(start) > time.Minute {
+			backoff = 100 * time.Millisecond
+		}
+		slog.Default().Error("Comment event subscription failed", "error", err, "retry_in", backoff.String())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, 10*time.Second)
+	}
+}
+
+// Close ends every subscription with ErrClosed and refuses new ones, so
+// that streaming calls return before the server stops
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for streamID, subs := range h.subscribers {
+		for sub := range subs {
+			sub.end(ErrClosed)
+		}
+		delete(h.subscribers, streamID)
+	}
+}
+
+// dispatch queues event for the subscribers of its stream, disconnecting
+// those whose queue is full rather than blocking the others
+func (h *Hub) dispatch(event domain.CommentEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	subs := h.subscribers[event.Comment.StreamID]
+	for sub := range subs {
+		select {
+		case sub.events <- event:
+		default:
+			sub.end(ErrSlowConsumer)
+			delete(subs, sub)
+		}
+	}
+	if len(subs) == 0 {
+		delete(h.subscribers, event.Comment.StreamID)
+	}
+}
+
+func (h *Hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if subs, ok := h.subscribers[sub.streamID]; ok {
+		delete(subs, sub)
+		if len(subs) == 0 {
+			delete(h.subscribers, sub.streamID)
+		}
+	}
+}
+
+// Subscription receives the events of one stream
+type Subscription struct {
+	hub      *Hub
+	streamID int32
+	events   chan domain.CommentEvent
+
+	// err is written under the lock of the hub before events is closed
+	err  error
+	once sync.Once
+}
+
+// Events delivers the events of the stream in the order they were
+// received. It is closed when the subscription ends, after which Err tells
+// why.
+func (s *Subscription) Events() <-chan domain.CommentEvent {
+	return s.events
+}
+
+// Err returns ErrSlowConsumer or ErrClosed once Events is closed by the hub
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Close stops the subscription
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// end closes the events of a subscription still registered with the hub.
+// The hub lock must be held.
+func (s *Subscription) end(err error) {
+	s.once.Do(func() {
+		s.err = err
+		close(s.events)
+	})
+}
@@ -0,0 +1,44 @@
+package hub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Josy-coder/comment-service/internal/domain"
+)
+
+// MemoryBroker delivers events within the process. It suits a single
+// replica, as the subscribers of other replicas never see the events.
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	delivers map[*func(domain.CommentEvent)]struct{}
+}
+
+// NewMemoryBroker returns a broker without subscribers
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{delivers: make(map[*func(domain.CommentEvent)]struct{})}
+}
+
+// Publish delivers event to the current subscribers before returning
+func (b *MemoryBroker) Publish(ctx context.Context, event domain.CommentEvent) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for deliver := range b.delivers {
+		(*deliver)(event)
+	}
+	return nil
+}
+
+// Subscribe delivers the published events until ctx is cancelled
+func (b *MemoryBroker) Subscribe(ctx context.Context, deliver func(domain.CommentEvent)) error {
+	b.mu.Lock()
+	b.delivers[&deliver] = struct{}{}
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.delivers, &deliver)
+	b.mu.Unlock()
+	return ctx.Err()
+}
@@ -0,0 +1,69 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Josy-coder/comment-service/internal/domain"
+	"github.com/clementus360/platform/logging"
+)
+
+// RedisBroker shares events between replicas through a Redis Pub/Sub
+// channel. Redis does not keep the messages, so a replica that is
+// disconnected misses the events published meanwhile.
+type RedisBroker struct {
+	client  *redis.Client
+	channel string
+}
+
+// NewRedisBroker connects to the Redis server of url, such as
+// redis://:password@localhost:6379/0, and publishes on channel
+func NewRedisBroker(url, channel string) (*RedisBroker, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	return &RedisBroker{client: redis.NewClient(opts), channel: channel}, nil
+}
+
+// Publish sends event on the channel
+func (b *RedisBroker) Publish(ctx context.Context, event domain.CommentEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.client.Publish(ctx, b.channel, payload).Err()
+}
+
+// Subscribe delivers the events of the channel until ctx is cancelled or
+// the connection fails
+func (b *RedisBroker) Subscribe(ctx context.Context, deliver func(domain.CommentEvent)) error {
+	pubsub := b.client.Subscribe(ctx, b.channel)
+	defer pubsub.Close()
+
+	for {
+		msg, err := pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			return err
+		}
+		var event domain.CommentEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			logging.FromContext(ctx).Warn("Ignoring invalid comment event", "channel", b.channel, "error", err)
+			continue
+		}
+		deliver(event)
+	}
+}
+
+// Ping checks that the Redis server is reachable, for readiness probes
+func (b *RedisBroker) Ping(ctx context.Context) error {
+	return b.client.Ping(ctx).Err()
+}
+
+// Close closes the connections to Redis
+func (b *RedisBroker) Close() error {
+	return b.client.Close()
+}
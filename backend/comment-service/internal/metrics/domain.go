@@ -0,0 +1,68 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// domain holds the comment-specific business metrics
+type domain struct {
+	commentsCreated    prometheus.Counter
+	subscribers        prometheus.Gauge
+	subscribersDropped prometheus.Counter
+	chatConnections    prometheus.Gauge
+}
+
+func newDomain(namespace string, registry *prometheus.Registry) domain {
+	d := domain{
+		commentsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "comments_created_total",
+			Help:      "Total comments created. Use rate() for comments per second.",
+		}),
+		subscribers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "comment_subscribers",
+			Help:      "Open SubscribeComments calls.",
+		}),
+		subscribersDropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "comment_subscribers_dropped_total",
+			Help:      "SubscribeComments calls and chat connections ended because the subscriber fell behind.",
+		}),
+		chatConnections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "chat_connections",
+			Help:      "Open WebSocket chat connections.",
+		}),
+	}
+	registry.MustRegister(d.commentsCreated, d.subscribers, d.subscribersDropped, d.chatConnections)
+	return d
+}
+
+// CommentCreated counts a successfully created comment
+func (m *Metrics) CommentCreated() {
+	m.commentsCreated.Inc()
+}
+
+// SubscriberJoined counts an open subscription to comments
+func (m *Metrics) SubscriberJoined() {
+	m.subscribers.Inc()
+}
+
+// SubscriberLeft counts a subscription that ended
+func (m *Metrics) SubscriberLeft() {
+	m.subscribers.Dec()
+}
+
+// SubscriberDropped counts a subscriber disconnected for falling behind
+func (m *Metrics) SubscriberDropped() {
+	m.subscribersDropped.Inc()
+}
+
+// ChatJoined counts an open chat connection
+func (m *Metrics) ChatJoined() {
+	m.chatConnections.Inc()
+}
+
+// ChatLeft counts a chat connection that ended
+func (m *Metrics) ChatLeft() {
+	m.chatConnections.Dec()
+}
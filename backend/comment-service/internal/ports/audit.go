@@ -0,0 +1,97 @@
+package ports
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Josy-coder/comment-service/internal/service"
+	pb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"github.com/clementus360/platform/audit"
+)
+
+const auditTargetComment = "comment"
+
+// AuditedMethods describes how the mutations of the comment service are
+// audited
+func AuditedMethods(svc *service.CommentService) map[string]audit.Method {
+	before := func(ctx context.Context, id int32) proto.Message {
+		comment, err := svc.GetComment(ctx, id)
+		if err != nil {
+			return nil
+		}
+		return toProtoComment(comment)
+	}
+	after := func(resp any) proto.Message { return resp.(*pb.CommentResponse).Comment }
+	commentID := func(req, resp any) string {
+		return strconv.Itoa(int(resp.(*pb.CommentResponse).Comment.GetId()))
+	}
+
+	return map[string]audit.Method{
+		pb.CommentService_CreateComment_FullMethodName: {
+			Action: audit.ActionCreate, TargetType: auditTargetComment,
+			Target: commentID, After: after,
+		},
+		pb.CommentService_UpdateComment_FullMethodName: {
+			Action: audit.ActionUpdate, TargetType: auditTargetComment,
+			Target: commentID, After: after,
+			Before: func(ctx context.Context, req any) proto.Message {
+				return before(ctx, req.(*pb.UpdateCommentRequest).Id)
+			},
+		},
+		pb.CommentService_DeleteComment_FullMethodName: {
+			Action: audit.ActionDelete, TargetType: auditTargetComment,
+			Target: func(req, resp any) string { return strconv.Itoa(int(req.(*pb.DeleteCommentRequest).Id)) },
+			Before: func(ctx context.Context, req any) proto.Message {
+				return before(ctx, req.(*pb.DeleteCommentRequest).Id)
+			},
+		},
+	}
+}
+
+func (s *GRPCServer) QueryAuditLog(ctx context.Context, req *pb.QueryAuditLogRequest) (*pb.QueryAuditLogResponse, error) {
+	page, err := audit.Serve(ctx, s.audit, audit.Query{
+		ActorID:      req.GetActorId(),
+		ActorService: req.GetActorService(),
+		TargetType:   req.GetTargetType(),
+		TargetID:     req.GetTargetId(),
+		Since:        audit.Timestamp(req.Since),
+		Until:        audit.Timestamp(req.Until),
+		Page:         int(req.GetPage()),
+		PageSize:     int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.QueryAuditLogResponse{TotalCount: int32(page.Total)}
+	for _, entry := range page.Entries {
+		resp.Entries = append(resp.Entries, toProtoAuditEntry(entry))
+	}
+	return resp, nil
+}
+
+func toProtoAuditEntry(entry audit.Entry) *pb.AuditEntry {
+	protoEntry := &pb.AuditEntry{
+		Id:           entry.ID,
+		Time:         timestamppb.New(entry.Time),
+		Service:      entry.Service,
+		Method:       entry.Method,
+		Action:       entry.Action,
+		ActorId:      entry.ActorID,
+		ActorRoles:   entry.ActorRoles,
+		ActorService: entry.ActorService,
+		TargetType:   entry.TargetType,
+		TargetId:     entry.TargetID,
+		RequestId:    entry.RequestID,
+	}
+	for _, change := range entry.Changes {
+		protoEntry.Changes = append(protoEntry.Changes, &pb.AuditChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return protoEntry
+}
@@ -0,0 +1,386 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Josy-coder/comment-service/internal/clients"
+	"github.com/Josy-coder/comment-service/internal/domain"
+	"github.com/Josy-coder/comment-service/internal/hub"
+	"github.com/Josy-coder/comment-service/internal/metrics"
+	"github.com/Josy-coder/comment-service/internal/service"
+	pb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+)
+
+// maxChatMessageSize bounds the messages read from clients, which hold at
+// most a comment
+const maxChatMessageSize = 4 * domain.MaxCommentLength
+
+// Close codes of the chat besides those of RFC 6455
+const (
+	// closeTooSlow ends connections that do not keep up with the chat
+	closeTooSlow websocket.StatusCode = 4008
+)
+
+// Types of the messages of the chat
+const (
+	chatSend  = "send"
+	chatSent  = "sent"
+	chatError = "error"
+)
+
+// clientMessage is a message sent by a client
+type clientMessage struct {
+	Type    string `json:"type"`
+	Ref     string `json:"ref,omitempty"`
+	Content string `json:"content"`
+}
+
+// serverMessage is a message sent to a client: a comment event, the reply
+// to a send or an error
+type serverMessage struct {
+	Type     string       `json:"type"`
+	Ref      string       `json:"ref,omitempty"`
+	Backfill bool         `json:"backfill,omitempty"`
+	Comment  *chatComment `json:"comment,omitempty"`
+	Code     string       `json:"code,omitempty"`
+	Message  string       `json:"message,omitempty"`
+}
+
+type chatComment struct {
+	ID        int32     `json:"id"`
+	Content   string    `json:"content"`
+	UserID    int32     `json:"user_id"`
+	StreamID  int32     `json:"stream_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ChatAuthenticator identifies the end user opening a chat connection
+type ChatAuthenticator interface {
+	Authenticate(r *http.Request) (auth.User, error)
+}
+
+// ChatConfig tunes the connections of the chat
+type ChatConfig struct {
+	AllowedOrigins []string
+	SendBuffer     int
+	PingInterval   time.Duration
+	WriteTimeout   time.Duration
+}
+
+// ChatHandler serves the live chat of streams over WebSocket. Clients
+// receive the changes to the comments of the stream and post comments
+// through the gRPC server, so that they are audited like gRPC calls.
+type ChatHandler struct {
+	svc           *service.CommentService
+	server        pb.CommentServiceServer
+	audit         grpc.UnaryServerInterceptor
+	authenticator ChatAuthenticator
+	metrics       *metrics.Metrics
+	cfg           ChatConfig
+}
+
+func NewChatHandler(svc *service.CommentService, server pb.CommentServiceServer, audit grpc.UnaryServerInterceptor, authenticator ChatAuthenticator, m *metrics.Metrics, cfg ChatConfig) *ChatHandler {
+	return &ChatHandler{
+		svc:           svc,
+		server:        server,
+		audit:         audit,
+		authenticator: authenticator,
+		metrics:       m,
+		cfg:           cfg,
+	}
+}
+
+// ServeHTTP joins the chat of the stream_id path value. The connection is
+// refused with an HTTP status until the user and the stream are checked.
+func (h *ChatHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context())
+
+	streamID, err := strconv.ParseInt(r.PathValue("stream_id"), 10, 32)
+	if err != nil || streamID < 1 {
+		http.Error(w, "invalid stream id", http.StatusBadRequest)
+		return
+	}
+	var backfill int64
+	if raw := r.URL.Query().Get("backfill"); raw != "" {
+		backfill, err = strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid backfill", http.StatusBadRequest)
+			return
+		}
+	}
+
+	user, err := h.authenticator.Authenticate(r)
+	switch {
+	case err == nil:
+	case errors.Is(err, clients.ErrNoToken), errors.Is(err, clients.ErrInvalidToken), errors.Is(err, clients.ErrNotAuthenticated):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case errors.Is(err, domain.ErrUserNotFound):
+		http.Error(w, "user is not synced", http.StatusForbidden)
+		return
+	default:
+		logger.Error("Failed to authenticate chat user", "error", err)
+		http.Error(w, "authentication unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	// The user is checked against the stream like a gRPC caller would be
+	ctx := auth.WithUser(auth.WithCaller(r.Context(), auth.Caller{}), user)
+	sub, comments, err := h.svc.SubscribeComments(ctx, int32(streamID), int32(backfill))
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrInvalidBackfill):
+		http.Error(w, "backfill must be between 0 and "+strconv.Itoa(service.MaxBackfill), http.StatusBadRequest)
+		return
+	case errors.Is(err, domain.ErrStreamNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	default:
+		logger.Error("Failed to subscribe to comments", "stream_id", streamID, "error", err)
+		http.Error(w, "failed to join chat", http.StatusInternalServerError)
+		return
+	}
+	defer sub.Close()
+
+	conn, err := websocket.Accept(hijacker(w), r, &websocket.AcceptOptions{OriginPatterns: h.cfg.AllowedOrigins})
+	if err != nil {
+		// Accept has replied with the reason
+		return
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(maxChatMessageSize)
+
+	h.metrics.ChatJoined()
+	defer h.metrics.ChatLeft()
+
+	c := &chatConn{
+		handler: h,
+		conn:    conn,
+		userID:  int32(user.ID),
+		stream:  int32(streamID),
+		outbox:  make(chan serverMessage, h.cfg.SendBuffer),
+	}
+	c.run(ctx, sub, comments)
+}
+
+// chatConn is an open connection to the chat of a stream. Messages for the
+// client are queued in outbox and written by a single goroutine.
+type chatConn struct {
+	handler *ChatHandler
+	conn    *websocket.Conn
+	userID  int32
+	stream  int32
+	outbox  chan serverMessage
+
+	closeOnce sync.Once
+}
+
+// run serves the connection until it is closed
+func (c *chatConn) run(ctx context.Context, sub *hub.Subscription, backfill []*domain.Comment) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The backfill is written before anything is queued, so that it does
+	// not count against the send buffer
+	var lastID int32
+	for _, comment := range backfill {
+		msg := serverMessage{Type: domain.EventCreated, Backfill: true, Comment: toChatComment(comment)}
+		if err := c.write(ctx, msg); err != nil {
+			return
+		}
+		lastID = comment.ID
+	}
+
+	go func() {
+		defer cancel()
+		c.read(ctx)
+	}()
+	go func() {
+		defer cancel()
+		c.keepalive(ctx)
+	}()
+	go func() {
+		defer cancel()
+		c.writeLoop(ctx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				if errors.Is(sub.Err(), hub.ErrSlowConsumer) {
+					c.handler.metrics.SubscriberDropped()
+					c.close(closeTooSlow, "too slow")
+				} else {
+					c.close(websocket.StatusGoingAway, "server shutting down")
+				}
+				return
+			}
+			// Comments created while the backfill was read are both in the
+			// backfill and in the events
+			if event.Type == domain.EventCreated && event.Comment.ID <= lastID {
+				continue
+			}
+			if !c.queue(serverMessage{Type: event.Type, Comment: toChatComment(&event.Comment)}) {
+				return
+			}
+		}
+	}
+}
+
+// read handles the messages of the client until the connection fails
+func (c *chatConn) read(ctx context.Context) {
+	for {
+		var msg clientMessage
+		// Read closes the connection itself on messages that are not JSON
+		if err := wsjson.Read(ctx, c.conn, &msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case chatSend:
+			if !c.queue(c.send(ctx, msg)) {
+				return
+			}
+		default:
+			if !c.queue(serverMessage{Type: chatError, Ref: msg.Ref, Code: code.Code_INVALID_ARGUMENT.String(), Message: "unknown message type " + strconv.Quote(msg.Type)}) {
+				return
+			}
+		}
+	}
+}
+
+// send posts the comment of msg as the user of the connection and returns
+// the reply
+func (c *chatConn) send(ctx context.Context, msg clientMessage) serverMessage {
+	req := &pb.CreateCommentRequest{Content: msg.Content, UserId: c.userID, StreamId: c.stream}
+	info := &grpc.UnaryServerInfo{Server: c.handler.server, FullMethod: pb.CommentService_CreateComment_FullMethodName}
+	resp, err := c.handler.audit(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return c.handler.server.CreateComment(ctx, req.(*pb.CreateCommentRequest))
+	})
+	if err != nil {
+		st := status.Convert(err)
+		message := st.Message()
+		if st.Code() == codes.Internal || st.Code() == codes.Unknown {
+			logging.FromContext(ctx).Error("Failed to post chat message", "stream_id", c.stream, "error", err)
+			message = "internal error"
+		}
+		return serverMessage{Type: chatError, Ref: msg.Ref, Code: code.Code(st.Code()).String(), Message: message}
+	}
+
+	comment := resp.(*pb.CommentResponse).Comment
+	return serverMessage{Type: chatSent, Ref: msg.Ref, Comment: &chatComment{
+		ID:        comment.Id,
+		Content:   comment.Content,
+		UserID:    comment.UserId,
+		StreamID:  comment.StreamId,
+		CreatedAt: comment.CreatedAt.AsTime(),
+		UpdatedAt: comment.UpdatedAt.AsTime(),
+	}}
+}
+
+// queue adds msg to the outbox, closing the connection when it is full
+func (c *chatConn) queue(msg serverMessage) bool {
+	select {
+	case c.outbox <- msg:
+		return true
+	default:
+		c.handler.metrics.SubscriberDropped()
+		c.close(closeTooSlow, "too slow")
+		return false
+	}
+}
+
+// writeLoop writes the queued messages until ctx is cancelled
+func (c *chatConn) writeLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-c.outbox:
+			if err := c.write(ctx, msg); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *chatConn) write(ctx context.Context, msg serverMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, c.handler.cfg.WriteTimeout)
+	defer cancel()
+	return wsjson.Write(ctx, c.conn, msg)
+}
+
+// keepalive pings the client, closing the connection when a pong does not
+// come back in time
+func (c *chatConn) keepalive(ctx context.Context) {
+	ticker := time.NewTicker(c.handler.cfg.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pingCtx, cancel := context.WithTimeout(ctx, c.handler.cfg.WriteTimeout)
+			err := c.conn.Ping(pingCtx)
+			cancel()
+			if err != nil {
+				if ctx.Err() == nil {
+					c.close(websocket.StatusPolicyViolation, "ping timeout")
+				}
+				return
+			}
+		}
+	}
+}
+
+// close ends the connection with status before ctx is cancelled, which
+// would close it without a reason. The first reason wins.
+func (c *chatConn) close(status websocket.StatusCode, reason string) {
+	c.closeOnce.Do(func() {
+		c.conn.Close(status, reason)
+	})
+}
+
+func toChatComment(c *domain.Comment) *chatComment {
+	return &chatComment{
+		ID:        c.ID,
+		Content:   c.Content,
+		UserID:    c.UserID,
+		StreamID:  c.StreamID,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// hijacker returns the writer of w that can take over the connection.
+// Middlewares recording the status of responses wrap it.
+func hijacker(w http.ResponseWriter) http.ResponseWriter {
+	for {
+		if _, ok := w.(http.Hijacker); ok {
+			return w
+		}
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return w
+		}
+		w = u.Unwrap()
+	}
+}
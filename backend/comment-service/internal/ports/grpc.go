@@ -4,39 +4,59 @@ import (
 	"context"
 	"errors"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/Josy-coder/comment-service/internal/domain"
+	"github.com/Josy-coder/comment-service/internal/hub"
+	"github.com/Josy-coder/comment-service/internal/metrics"
 	"github.com/Josy-coder/comment-service/internal/service"
 	pb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"github.com/clementus360/platform/audit"
 )
 
 type GRPCServer struct {
 	pb.UnimplementedCommentServiceServer
-	svc service.Service
+	svc     *service.CommentService
+	metrics *metrics.Metrics
+	audit   *audit.Log
 }
 
-func NewGRPCServer(repo domain.CommentRepository) pb.CommentServiceServer {
+func NewGRPCServer(svc *service.CommentService, m *metrics.Metrics, auditLog *audit.Log) pb.CommentServiceServer {
 	return &GRPCServer{
-		svc: service.NewCommentService(repo),
+		svc:     svc,
+		metrics: m,
+		audit:   auditLog,
 	}
 }
 
-func (s *GRPCServer) CreateComment(_ context.Context, req *pb.CreateCommentRequest) (*pb.CommentResponse, error) {
-	comment, err := s.svc.CreateComment(req.Content, req.UserId, req.StreamId)
+func (s *GRPCServer) CreateComment(ctx context.Context, req *pb.CreateCommentRequest) (*pb.CommentResponse, error) {
+	comment, err := s.svc.CreateComment(ctx, req.Content, req.UserId, req.StreamId)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		switch {
+		case errors.Is(err, domain.ErrEmptyComment), errors.Is(err, domain.ErrCommentTooLong):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrStreamNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, domain.ErrForbidden):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, domain.ErrUnauthenticated):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 	}
+	s.metrics.CommentCreated()
 
 	return &pb.CommentResponse{
 		Comment: toProtoComment(comment),
 	}, nil
 }
 
-func (s *GRPCServer) GetComment(_ context.Context, req *pb.GetCommentRequest) (*pb.CommentResponse, error) {
-	comment, err := s.svc.GetComment(req.Id)
+func (s *GRPCServer) GetComment(ctx context.Context, req *pb.GetCommentRequest) (*pb.CommentResponse, error) {
+	comment, err := s.svc.GetComment(ctx, req.Id)
 	if err != nil {
 		if errors.Is(err, domain.ErrCommentNotFound) {
 			return nil, status.Error(codes.NotFound, "comment not found")
@@ -49,12 +69,21 @@ func (s *GRPCServer) GetComment(_ context.Context, req *pb.GetCommentRequest) (*
 	}, nil
 }
 
-func (s *GRPCServer) UpdateComment(_ context.Context, req *pb.UpdateCommentRequest) (*pb.CommentResponse, error) {
-	comment, err := s.svc.UpdateComment(req.Id, req.Content)
+func (s *GRPCServer) UpdateComment(ctx context.Context, req *pb.UpdateCommentRequest) (*pb.CommentResponse, error) {
+	comment, err := s.svc.UpdateComment(ctx, req.Id, req.Content)
 	if err != nil {
+		if errors.Is(err, domain.ErrEmptyComment) || errors.Is(err, domain.ErrCommentTooLong) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		if errors.Is(err, domain.ErrCommentNotFound) {
 			return nil, status.Error(codes.NotFound, "comment not found")
 		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -63,19 +92,25 @@ func (s *GRPCServer) UpdateComment(_ context.Context, req *pb.UpdateCommentReque
 	}, nil
 }
 
-func (s *GRPCServer) DeleteComment(_ context.Context, req *pb.DeleteCommentRequest) (*emptypb.Empty, error) {
-	err := s.svc.DeleteComment(req.Id)
+func (s *GRPCServer) DeleteComment(ctx context.Context, req *pb.DeleteCommentRequest) (*emptypb.Empty, error) {
+	err := s.svc.DeleteComment(ctx, req.Id)
 	if err != nil {
 		if errors.Is(err, domain.ErrCommentNotFound) {
 			return nil, status.Error(codes.NotFound, "comment not found")
 		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
-func (s *GRPCServer) ListComments(_ context.Context, req *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
+func (s *GRPCServer) ListComments(ctx context.Context, req *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
 	filter := domain.CommentFilter{
 		UserID:   req.UserId,
 		StreamID: req.StreamId,
@@ -83,8 +118,11 @@ func (s *GRPCServer) ListComments(_ context.Context, req *pb.ListCommentsRequest
 		PageSize: req.GetPageSize(),
 	}
 
-	comments, total, err := s.svc.ListComments(filter)
+	comments, total, err := s.svc.ListComments(ctx, filter)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidPage) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -99,6 +137,62 @@ func (s *GRPCServer) ListComments(_ context.Context, req *pb.ListCommentsRequest
 	}, nil
 }
 
+func (s *GRPCServer) SubscribeComments(req *pb.SubscribeCommentsRequest, stream pb.CommentService_SubscribeCommentsServer) error {
+	ctx := stream.Context()
+	sub, backfill, err := s.svc.SubscribeComments(ctx, req.StreamId, req.Backfill)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidBackfill):
+			return status.Errorf(codes.InvalidArgument, "backfill must be between 0 and %d", service.MaxBackfill)
+		case errors.Is(err, domain.ErrStreamNotFound):
+			return status.Error(codes.NotFound, err.Error())
+		default:
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+	defer sub.Close()
+	s.metrics.SubscriberJoined()
+	defer s.metrics.SubscriberLeft()
+
+	// The headers tell callers that no change made from now on will be
+	// missed, even when there is no backfill to send
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	// Comments created while the backfill was read are both in the backfill
+	// and in the events
+	var lastID int32
+	for _, comment := range backfill {
+		event := &pb.CommentEvent{Type: domain.EventCreated, Comment: toProtoComment(comment), Backfill: true}
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+		lastID = comment.ID
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event, ok := <-sub.Events():
+			if !ok {
+				if errors.Is(sub.Err(), hub.ErrSlowConsumer) {
+					s.metrics.SubscriberDropped()
+					return status.Error(codes.ResourceExhausted, sub.Err().Error())
+				}
+				return status.Error(codes.Unavailable, sub.Err().Error())
+			}
+			if event.Type == domain.EventCreated && event.Comment.ID <= lastID {
+				continue
+			}
+			if err := stream.Send(&pb.CommentEvent{Type: event.Type, Comment: toProtoComment(&event.Comment)}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func toProtoComment(c *domain.Comment) *pb.Comment {
 	if c == nil {
 		return nil
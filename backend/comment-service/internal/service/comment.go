@@ -1,21 +1,31 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/Josy-coder/comment-service/internal/domain"
+	"github.com/Josy-coder/comment-service/internal/hub"
+	"github.com/clementus360/platform/auth"
 )
 
 var (
 	ErrInvalidPage     = errors.New("invalid page number")
 	ErrInvalidPageSize = errors.New("invalid page size")
+	ErrInvalidBackfill = errors.New("invalid backfill")
 )
 
 const (
 	DefaultPageSize = 10
 	MaxPageSize     = 100
+	// MaxBackfill is the most comments sent to a new subscriber
+	MaxBackfill = MaxPageSize
+
+	// dbPageSize is the largest page the database service returns
+	dbPageSize = 10
 )
 
 type DBClient interface {
@@ -38,13 +48,15 @@ type CommentService struct {
 	dbClient     DBClient
 	userClient   UserClient
 	streamClient StreamClient
+	hub          *hub.Hub
 }
 
-func NewCommentService(dbClient DBClient, userClient UserClient, streamClient StreamClient) *CommentService {
+func NewCommentService(dbClient DBClient, userClient UserClient, streamClient StreamClient, commentHub *hub.Hub) *CommentService {
 	return &CommentService{
 		dbClient:     dbClient,
 		userClient:   userClient,
 		streamClient: streamClient,
+		hub:          commentHub,
 	}
 }
 
@@ -53,6 +65,10 @@ func (s *CommentService) CreateComment(ctx context.Context, content string, user
 		return nil, err
 	}
 
+	if err := authorize(ctx, userID); err != nil {
+		return nil, err
+	}
+
 	// Validate user exists
 	if err := s.userClient.GetUser(ctx, userID); err != nil {
 		return nil, err
@@ -74,6 +90,7 @@ func (s *CommentService) CreateComment(ctx context.Context, content string, user
 	if err := s.dbClient.CreateComment(ctx, comment); err != nil {
 		return nil, err
 	}
+	s.hub.Publish(ctx, domain.CommentEvent{Type: domain.EventCreated, Comment: *comment})
 
 	return comment, nil
 }
@@ -92,18 +109,38 @@ func (s *CommentService) UpdateComment(ctx context.Context, id int32, content st
 		return nil, err
 	}
 
+	if err := authorize(ctx, comment.UserID); err != nil {
+		return nil, err
+	}
+
 	comment.Content = content
 	comment.UpdatedAt = time.Now()
 
 	if err := s.dbClient.UpdateComment(ctx, comment); err != nil {
 		return nil, err
 	}
+	s.hub.Publish(ctx, domain.CommentEvent{Type: domain.EventUpdated, Comment: *comment})
 
 	return comment, nil
 }
 
 func (s *CommentService) DeleteComment(ctx context.Context, id int32) error {
-	return s.dbClient.DeleteComment(ctx, id)
+	comment, err := s.dbClient.GetComment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Moderators take down the comments of anyone
+	if err := authorizeError(auth.AuthorizeModeration(ctx, int64(comment.UserID))); err != nil {
+		return err
+	}
+
+	if err := s.dbClient.DeleteComment(ctx, id); err != nil {
+		return err
+	}
+	s.hub.Publish(ctx, domain.CommentEvent{Type: domain.EventDeleted, Comment: *comment})
+
+	return nil
 }
 
 func (s *CommentService) ListComments(ctx context.Context, filter domain.CommentFilter) ([]*domain.Comment, int32, error) {
@@ -114,7 +151,83 @@ func (s *CommentService) ListComments(ctx context.Context, filter domain.Comment
 	return s.dbClient.ListComments(ctx, filter)
 }
 
+// SubscribeComments subscribes to the changes to the comments of a stream
+// and returns its latest backfill comments, oldest first. The subscription
+// starts before the comments are read so that none is missed in between;
+// the subscriber skips the created events of the comments it already has.
+func (s *CommentService) SubscribeComments(ctx context.Context, streamID, backfill int32) (*hub.Subscription, []*domain.Comment, error) {
+	if backfill < 0 || backfill > MaxBackfill {
+		return nil, nil, ErrInvalidBackfill
+	}
+
+	// End users may only follow the streams they can see
+	if err := s.streamClient.GetStream(ctx, streamID); err != nil {
+		return nil, nil, err
+	}
+
+	sub := s.hub.Subscribe(streamID)
+	comments, err := s.latestComments(ctx, streamID, backfill)
+	if err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+	return sub, comments, nil
+}
+
+// latestComments returns the last n comments of a stream, oldest first.
+// The database service does not order the comments it lists, so every page
+// is read and sorted by creation time, keeping the latest n as it goes.
+func (s *CommentService) latestComments(ctx context.Context, streamID, n int32) ([]*domain.Comment, error) {
+	if n == 0 {
+		return nil, nil
+	}
+
+	filter := domain.CommentFilter{StreamID: &streamID, Page: 1, PageSize: dbPageSize}
+	var comments []*domain.Comment
+	for {
+		page, total, err := s.dbClient.ListComments(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, page...)
+		slices.SortStableFunc(comments, func(a, b *domain.Comment) int {
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.ID, b.ID)
+		})
+		comments = comments[max(len(comments)-int(n), 0):]
+
+		if len(page) == 0 || filter.Page*dbPageSize >= total {
+			return comments, nil
+		}
+		filter.Page++
+	}
+}
+
+// authorize checks that the end user behind the call may act on comments of
+// ownerID. Calls without a user, such as cleanups after a user is deleted,
+// are only allowed for the services listed in AUTH_TRUSTED_CALLERS.
+func authorize(ctx context.Context, ownerID int32) error {
+	return authorizeError(auth.Authorize(ctx, int64(ownerID)))
+}
+
+// authorizeError maps the errors of the auth package to those of the domain
+func authorizeError(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrNoUser):
+		return domain.ErrUnauthenticated
+	case err != nil:
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
 func validateFilter(filter *domain.CommentFilter) error {
+	// The page is optional in the API and defaults to the first one
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
 	if filter.Page < 1 {
 		return ErrInvalidPage
 	}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v5.29.3
+// - protoc             v5.29.2
 // source: proto/comment/v1/comment.proto
 
 package commentv1
@@ -20,11 +20,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CommentService_CreateComment_FullMethodName = "/comment.v1.CommentService/CreateComment"
-	CommentService_GetComment_FullMethodName    = "/comment.v1.CommentService/GetComment"
-	CommentService_UpdateComment_FullMethodName = "/comment.v1.CommentService/UpdateComment"
-	CommentService_DeleteComment_FullMethodName = "/comment.v1.CommentService/DeleteComment"
-	CommentService_ListComments_FullMethodName  = "/comment.v1.CommentService/ListComments"
+	CommentService_CreateComment_FullMethodName     = "/comment.v1.CommentService/CreateComment"
+	CommentService_GetComment_FullMethodName        = "/comment.v1.CommentService/GetComment"
+	CommentService_UpdateComment_FullMethodName     = "/comment.v1.CommentService/UpdateComment"
+	CommentService_DeleteComment_FullMethodName     = "/comment.v1.CommentService/DeleteComment"
+	CommentService_ListComments_FullMethodName      = "/comment.v1.CommentService/ListComments"
+	CommentService_SubscribeComments_FullMethodName = "/comment.v1.CommentService/SubscribeComments"
+	CommentService_QueryAuditLog_FullMethodName     = "/comment.v1.CommentService/QueryAuditLog"
 )
 
 // CommentServiceClient is the client API for CommentService service.
@@ -36,6 +38,10 @@ type CommentServiceClient interface {
 	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error)
 	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	// Sends the changes to the comments of a stream as they happen, until the
+	// client cancels the call
+	SubscribeComments(ctx context.Context, in *SubscribeCommentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommentEvent], error)
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
 }
 
 type commentServiceClient struct {
@@ -96,6 +102,35 @@ func (c *commentServiceClient) ListComments(ctx context.Context, in *ListComment
 	return out, nil
 }
 
+func (c *commentServiceClient) SubscribeComments(ctx context.Context, in *SubscribeCommentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommentEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CommentService_ServiceDesc.Streams[0], CommentService_SubscribeComments_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeCommentsRequest, CommentEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CommentService_SubscribeCommentsClient = grpc.ServerStreamingClient[CommentEvent]
+
+func (c *commentServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, CommentService_QueryAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommentServiceServer is the server API for CommentService service.
 // All implementations must embed UnimplementedCommentServiceServer
 // for forward compatibility.
@@ -105,6 +140,10 @@ type CommentServiceServer interface {
 	UpdateComment(context.Context, *UpdateCommentRequest) (*CommentResponse, error)
 	DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error)
 	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	// Sends the changes to the comments of a stream as they happen, until the
+	// client cancels the call
+	SubscribeComments(*SubscribeCommentsRequest, grpc.ServerStreamingServer[CommentEvent]) error
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
 	mustEmbedUnimplementedCommentServiceServer()
 }
 
@@ -130,6 +169,12 @@ func (UnimplementedCommentServiceServer) DeleteComment(context.Context, *DeleteC
 func (UnimplementedCommentServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
 }
+func (UnimplementedCommentServiceServer) SubscribeComments(*SubscribeCommentsRequest, grpc.ServerStreamingServer[CommentEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeComments not implemented")
+}
+func (UnimplementedCommentServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
 func (UnimplementedCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {}
 func (UnimplementedCommentServiceServer) testEmbeddedByValue()                        {}
 
@@ -241,6 +286,35 @@ func _CommentService_ListComments_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentService_SubscribeComments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeCommentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommentServiceServer).SubscribeComments(m, &grpc.GenericServerStream[SubscribeCommentsRequest, CommentEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CommentService_SubscribeCommentsServer = grpc.ServerStreamingServer[CommentEvent]
+
+func _CommentService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).QueryAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_QueryAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommentService_ServiceDesc is the grpc.ServiceDesc for CommentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -268,7 +342,17 @@ var CommentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListComments",
 			Handler:    _CommentService_ListComments_Handler,
 		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _CommentService_QueryAuditLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeComments",
+			Handler:       _CommentService_SubscribeComments_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/comment/v1/comment.proto",
 }
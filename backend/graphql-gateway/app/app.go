@@ -0,0 +1,223 @@
+// Package app assembles the GraphQL gateway from its configuration. It is
+// shared by main and the integration tests, which run the gateway
+// in-process.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/dial"
+	"github.com/clementus360/platform/health"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/metrics"
+	"github.com/clementus360/platform/tlsconfig"
+	"github.com/clementus360/platform/tracing"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc"
+
+	"github.com/clementus360/graphql-gateway/config"
+	"github.com/clementus360/graphql-gateway/graph"
+	"github.com/clementus360/graphql-gateway/transport"
+)
+
+// Option customizes an App
+type Option func(*options)
+
+type options struct {
+	logger      *slog.Logger
+	dialOptions []grpc.DialOption
+}
+
+// WithLogger replaces the logger built from the configuration
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithDialOptions adds options to the connections to the services
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// App is a GraphQL gateway connected to the services it aggregates
+type App struct {
+	tlsManager  *tlsconfig.Manager
+	checker     *health.Checker
+	handler     http.Handler
+	streamConn  *grpc.ClientConn
+	commentConn *grpc.ClientConn
+	userConn    *grpc.ClientConn
+}
+
+// New connects to the services and builds the GraphQL endpoint. Nothing is
+// served until the handler is given to a server.
+func New(ctx context.Context, cfg *config.Config, opts ...Option) (*App, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	logger := o.logger
+	if logger == nil {
+		logger = logging.New("graphql-gateway", cfg.Log.Level, cfg.Log.Format)
+	}
+	ctx = logging.WithLogger(ctx, logger)
+
+	// register the Prometheus collectors exposed on /metrics
+	gatewayMetrics := metrics.New("graphql_gateway")
+
+	// load the certificates of the connections to the services
+	tlsManager, err := tlsconfig.New(ctx, "graphql-gateway", tlsconfig.Config{
+		Enabled:           cfg.TLS.Enabled,
+		CertFile:          cfg.TLS.CertFile,
+		KeyFile:           cfg.TLS.KeyFile,
+		CAFile:            cfg.TLS.CAFile,
+		ClientAuth:        cfg.TLS.ClientAuth,
+		AllowedIdentities: cfg.TLS.AllowedIdentities,
+		ReloadInterval:    cfg.TLS.ReloadInterval,
+		DevMode:           cfg.TLS.DevMode,
+		DevDir:            cfg.TLS.DevDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize TLS: %w", err)
+	}
+
+	// sign outgoing calls with the shared service key, on behalf of the end
+	// user attached to the request if any
+	authenticator := auth.New("graphql-gateway", auth.Config{
+		SigningKey:     cfg.Auth.SigningKey,
+		Enforce:        cfg.Auth.Enforce,
+		AllowedCallers: cfg.Auth.AllowedCallers,
+		TrustedCallers: cfg.Auth.TrustedCallers,
+		TokenTTL:       cfg.Auth.TokenTTL,
+	})
+
+	// every outgoing connection shares the same deadlines, retries and
+	// circuit breaker settings
+	methodTimeouts, _ := cfg.Client.MethodTimeoutMap()
+	dialer := dial.NewDialer(dial.Config{
+		Timeout:        cfg.Client.Timeout,
+		MethodTimeouts: methodTimeouts,
+		Retry: dial.RetryConfig{
+			MaxAttempts:    cfg.Client.RetryMaxAttempts,
+			InitialBackoff: cfg.Client.RetryInitialBackoff,
+			MaxBackoff:     cfg.Client.RetryMaxBackoff,
+		},
+		Breaker: dial.BreakerConfig{
+			Failures:    cfg.Client.BreakerFailures,
+			OpenTimeout: cfg.Client.BreakerOpenTimeout,
+		},
+		KeepaliveTime:     cfg.Client.KeepaliveTime,
+		KeepaliveTimeout:  cfg.Client.KeepaliveTimeout,
+		MaxConnectBackoff: cfg.Client.MaxConnectBackoff,
+		DialOptions:       o.dialOptions,
+	}, tlsManager, authenticator, gatewayMetrics)
+
+	a := &App{tlsManager: tlsManager}
+
+	// the gateway only reads, so every call it makes may be retried
+	a.streamConn, err = dialer.Dial(dial.Target{
+		Name:       "stream_service",
+		Audience:   "stream-service",
+		Address:    cfg.Services.StreamService,
+		Service:    streampb.StreamService_ServiceDesc.ServiceName,
+		Idempotent: []string{"GetStream", "ListStreams"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to stream service: %w", err)
+	}
+	a.commentConn, err = dialer.Dial(dial.Target{
+		Name:       "comment_service",
+		Audience:   "comment-service",
+		Address:    cfg.Services.CommentService,
+		Service:    commentpb.CommentService_ServiceDesc.ServiceName,
+		Idempotent: []string{"GetComment", "ListComments"},
+		Streaming:  dial.StreamingMethods(commentpb.CommentService_ServiceDesc),
+	})
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to connect to comment service: %w", err)
+	}
+	a.userConn, err = dialer.Dial(dial.Target{
+		Name:       "user_service",
+		Audience:   "user-service",
+		Address:    cfg.Services.UserService,
+		Service:    userpb.UserService_ServiceDesc.ServiceName,
+		Idempotent: []string{"GetUser"},
+	})
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to connect to user service: %w", err)
+	}
+
+	schema, err := graph.NewSchema(graph.Services{
+		Streams:  streampb.NewStreamServiceClient(a.streamConn),
+		Comments: commentpb.NewCommentServiceClient(a.commentConn),
+		Users:    userpb.NewUserServiceClient(a.userConn),
+	}, graph.Config{
+		MaxDepth:     cfg.GraphQL.MaxDepth,
+		Parallelism:  cfg.GraphQL.MaxParallelism,
+		BatchWait:    cfg.GraphQL.BatchWait,
+		MaxBatch:     cfg.GraphQL.MaxBatch,
+		PollInterval: cfg.GraphQL.PollInterval,
+	})
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("invalid GraphQL schema: %w", err)
+	}
+
+	// probe the services for readiness
+	a.checker = health.NewChecker(2 * time.Second)
+	a.checker.AddProbe("stream_service", health.ConnProbe(a.streamConn))
+	a.checker.AddProbe("comment_service", health.ConnProbe(a.commentConn))
+	a.checker.AddProbe("user_service", health.ConnProbe(a.userConn))
+
+	router := http.NewServeMux()
+	router.Handle("/graphql", transport.NewHandler(schema, cfg.GraphQL.Playground))
+	router.Handle("GET /metrics", gatewayMetrics.Handler())
+	router.HandleFunc("GET /healthz", a.checker.LivenessHandler())
+	router.HandleFunc("GET /readyz", a.checker.ReadinessHandler())
+	a.handler = tracing.Middleware(logging.Middleware(logger, gatewayMetrics.Middleware(router)))
+
+	return a, nil
+}
+
+// Handler serves the GraphQL endpoint together with the metrics and health
+// endpoints
+func (a *App) Handler() http.Handler {
+	return a.handler
+}
+
+// Start runs the loops that keep the readiness status and certificates up to
+// date in the background until ctx is cancelled
+func (a *App) Start(ctx context.Context) {
+	go a.checker.Run(ctx, 10*time.Second)
+	go a.tlsManager.Watch(ctx)
+}
+
+// Drain reports not ready so that load balancers stop sending traffic
+// before the server stops
+func (a *App) Drain() {
+	a.checker.Shutdown()
+}
+
+// Close closes the connections to the services
+func (a *App) Close() error {
+	var errs []error
+	for _, conn := range []*grpc.ClientConn{a.streamConn, a.commentConn, a.userConn} {
+		if conn != nil {
+			errs = append(errs, conn.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
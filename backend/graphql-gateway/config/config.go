@@ -0,0 +1,106 @@
+// Package config holds the settings of the GraphQL gateway. They are loaded
+// like those of the services, with the loader and sections of the platform
+// module.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	platformconfig "github.com/clementus360/platform/config"
+)
+
+// Config is the complete graphql-gateway configuration
+type Config struct {
+	Server   ServerConfig                 `yaml:"server"`
+	Services ServicesConfig               `yaml:"services"`
+	Log      platformconfig.LogConfig     `yaml:"log"`
+	Tracing  platformconfig.TracingConfig `yaml:"tracing"`
+	TLS      platformconfig.TLSConfig     `yaml:"tls"`
+	Auth     platformconfig.AuthConfig    `yaml:"auth"`
+	Client   platformconfig.ClientConfig  `yaml:"client"`
+	GraphQL  GraphQLConfig                `yaml:"graphql"`
+
+	// PrintConfig dumps the effective configuration and exits
+	PrintConfig bool `yaml:"-" flag:"print-config"`
+}
+
+type ServerConfig struct {
+	Port        string        `yaml:"port" env:"PORT" flag:"port" default:"8090" required:"true"`
+	DrainPeriod time.Duration `yaml:"drain_period" env:"SHUTDOWN_DRAIN_PERIOD" flag:"drain-period" default:"5s"`
+}
+
+// ServicesConfig holds the gRPC addresses of the services behind the gateway
+type ServicesConfig struct {
+	StreamService  string `yaml:"stream_service" env:"STREAM_SERVICE_ADDRESS" flag:"stream-service-address" default:"localhost:8082" required:"true"`
+	CommentService string `yaml:"comment_service" env:"COMMENT_SERVICE_ADDRESS" flag:"comment-service-address" default:"localhost:50053" required:"true"`
+	UserService    string `yaml:"user_service" env:"USER_SERVICE_ADDRESS" flag:"user-service-address" default:"localhost:50052" required:"true"`
+}
+
+// GraphQLConfig bounds the cost of queries and tunes batching and
+// subscriptions
+type GraphQLConfig struct {
+	// MaxDepth rejects queries nesting fields deeper, such as
+	// stream.owner.streams.comments.author...
+	MaxDepth int `yaml:"max_depth" env:"GRAPHQL_MAX_DEPTH" default:"8"`
+	// MaxParallelism is the number of fields resolved at once per request
+	MaxParallelism int `yaml:"max_parallelism" env:"GRAPHQL_MAX_PARALLELISM" default:"10"`
+	// BatchWait is how long loaders collect keys before calling a service
+	BatchWait time.Duration `yaml:"batch_wait" env:"GRAPHQL_BATCH_WAIT" default:"2ms"`
+	// MaxBatch caps the keys a loader fetches together, 0 leaves it unbounded
+	MaxBatch int `yaml:"max_batch" env:"GRAPHQL_MAX_BATCH" default:"100"`
+	// PollInterval is how often StreamStatusChanged checks the stream for changes
+	PollInterval time.Duration `yaml:"poll_interval" env:"GRAPHQL_POLL_INTERVAL" default:"1s"`
+	// Playground serves GraphiQL on GET /graphql
+	Playground bool `yaml:"playground" env:"GRAPHQL_PLAYGROUND" flag:"playground" default:"false"`
+}
+
+// LoadConfig reads the configuration from defaults, an optional YAML file,
+// the environment and the command-line arguments
+func LoadConfig(args []string) (*Config, error) {
+	cfg := &Config{}
+	if err := platformconfig.Load("graphql-gateway", cfg, args); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// Print writes the effective configuration as YAML with secrets masked
+func Print(w io.Writer, cfg *Config) error {
+	return platformconfig.Print(w, cfg)
+}
+
+// Validate checks values that cannot be expressed with struct tags
+func (c *Config) Validate() error {
+	var errs []error
+
+	if n, err := strconv.Atoi(c.Server.Port); err != nil || n < 1 || n > 65535 {
+		errs = append(errs, fmt.Errorf("PORT must be a port number between 1 and 65535, got %q", c.Server.Port))
+	}
+
+	errs = append(errs, c.Tracing.Validate()...)
+	errs = append(errs, c.TLS.Validate()...)
+	errs = append(errs, c.Auth.Validate()...)
+	errs = append(errs, c.Client.Validate()...)
+
+	if c.GraphQL.MaxDepth < 1 {
+		errs = append(errs, fmt.Errorf("GRAPHQL_MAX_DEPTH must be positive, got %d", c.GraphQL.MaxDepth))
+	}
+	if c.GraphQL.MaxParallelism < 1 {
+		errs = append(errs, fmt.Errorf("GRAPHQL_MAX_PARALLELISM must be positive, got %d", c.GraphQL.MaxParallelism))
+	}
+	if c.GraphQL.BatchWait < 0 || c.GraphQL.BatchWait > time.Second {
+		errs = append(errs, fmt.Errorf("GRAPHQL_BATCH_WAIT must be between 0 and 1s, got %s", c.GraphQL.BatchWait))
+	}
+	if c.GraphQL.MaxBatch < 0 {
+		errs = append(errs, fmt.Errorf("GRAPHQL_MAX_BATCH must not be negative, got %d", c.GraphQL.MaxBatch))
+	}
+	if c.GraphQL.PollInterval < 100*time.Millisecond {
+		errs = append(errs, fmt.Errorf("GRAPHQL_POLL_INTERVAL must be at least 100ms, got %s", c.GraphQL.PollInterval))
+	}
+
+	return errors.Join(errs...)
+}
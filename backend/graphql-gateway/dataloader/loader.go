@@ -0,0 +1,173 @@
+// Package dataloader coalesces the lookups made while resolving a GraphQL
+// request. Keys requested within a short window are fetched together and
+// every key is fetched at most once per loader, so listing fifty comments by
+// five authors costs five user lookups made at once instead of fifty made
+// one after the other.
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc fetches the values of keys. Keys missing from the result have no
+// value; an error fails every key of the batch.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader batches and caches the lookups of one request. It must not outlive
+// the request, whose context it fetches with.
+type Loader[K comparable, V any] struct {
+	ctx      context.Context
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending []K
+	timer   *time.Timer
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// New returns a loader that fetches the keys requested within wait of the
+// first one with fetch, at most maxBatch at a time. A maxBatch of 0 leaves
+// batches unbounded.
+func New[K comparable, V any](ctx context.Context, fetch BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		ctx:      ctx,
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		results:  make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value of key, the zero value when it has none. It waits
+// for the batch key is added to, or returns early when ctx is done.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	r := l.enqueue(key)
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// Prime caches the value of key, for values already fetched by other means
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.results[key]; ok {
+		return
+	}
+	r := &result[V]{done: make(chan struct{}), value: value}
+	close(r.done)
+	l.results[key] = r
+}
+
+func (l *Loader[K, V]) enqueue(key K) *result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if r, ok := l.results[key]; ok {
+		return r
+	}
+	r := &result[V]{done: make(chan struct{})}
+	l.results[key] = r
+	l.pending = append(l.pending, key)
+
+	switch {
+	case l.maxBatch > 0 && len(l.pending) >= l.maxBatch:
+		l.dispatchLocked()
+	case l.timer == nil:
+		l.timer = time.AfterFunc(l.wait, l.dispatch)
+	}
+	return r
+}
+
+func (l *Loader[K, V]) dispatch() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dispatchLocked()
+}
+
+// dispatchLocked fetches the pending keys in the background
+func (l *Loader[K, V]) dispatchLocked() {
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+	if len(l.pending) == 0 {
+		return
+	}
+	keys := l.pending
+	l.pending = nil
+	results := make([]*result[V], len(keys))
+	for i, key := range keys {
+		results[i] = l.results[key]
+	}
+
+	go func() {
+		values, err := l.fetch(l.ctx, keys)
+		for i, key := range keys {
+			if err != nil {
+				results[i].err = err
+			} else {
+				results[i].value = values[key]
+			}
+			close(results[i].done)
+		}
+	}()
+}
+
+// Each adapts a lookup of a single key to a BatchFunc for services without a
+// multi-get. The keys of a batch are looked up concurrently, at most
+// parallelism at once. A lookup reporting found false leaves its key out.
+func Each[K comparable, V any](parallelism int, get func(ctx context.Context, key K) (value V, found bool, err error)) BatchFunc[K, V] {
+	return func(ctx context.Context, keys []K) (map[K]V, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			mu       sync.Mutex
+			values   = make(map[K]V, len(keys))
+			firstErr error
+			wg       sync.WaitGroup
+			slots    = make(chan struct{}, max(parallelism, 1))
+		)
+		for _, key := range keys {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-slots }()
+
+				value, found, err := get(ctx, key)
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err != nil && firstErr == nil:
+					firstErr = err
+					cancel()
+				case err == nil && found:
+					values[key] = value
+				}
+			}()
+		}
+		wg.Wait()
+
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		return values, nil
+	}
+}
@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error is a failed call to a service, reported with its gRPC code in the
+// extensions of the GraphQL error
+type Error struct {
+	Code    codes.Code
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions implements the extensions of GraphQL errors
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code.String()}
+}
+
+// serviceError converts the errors of gRPC calls. Internal details stay in
+// the logs of the services.
+func serviceError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return &Error{Code: codes.Canceled, Message: "request cancelled"}
+	}
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.InvalidArgument, codes.NotFound, codes.PermissionDenied, codes.Unauthenticated, codes.FailedPrecondition, codes.ResourceExhausted:
+		return &Error{Code: st.Code(), Message: st.Message()}
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return &Error{Code: st.Code(), Message: "service unavailable"}
+	default:
+		return &Error{Code: codes.Internal, Message: "internal error"}
+	}
+}
@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/stream-service/models"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/clementus360/graphql-gateway/dataloader"
+)
+
+// streamPageKey identifies a page of a stream listing
+type streamPageKey struct {
+	userID        int32
+	status        string
+	titleContains string
+	first, page   int32
+}
+
+// commentPageKey identifies a page of the comments of a stream
+type commentPageKey struct {
+	streamID    int32
+	first, page int32
+}
+
+// loaders make every call to the services of one request or subscription
+// event. None of the services has a multi-get, so the keys of a batch are
+// looked up concurrently: the gain is in fetching each key once and in not
+// waiting for one lookup before starting the next.
+type loaders struct {
+	streams      *dataloader.Loader[int32, *streampb.StreamResponse]
+	users        *dataloader.Loader[int32, *userpb.User]
+	comments     *dataloader.Loader[int32, *commentpb.Comment]
+	streamPages  *dataloader.Loader[streamPageKey, *streampb.ListStreamsResponse]
+	commentPages *dataloader.Loader[commentPageKey, *commentpb.ListCommentsResponse]
+}
+
+func (r *Resolver) newLoaders(ctx context.Context) *loaders {
+	wait, parallelism := r.cfg.BatchWait, r.cfg.Parallelism
+	services := r.services
+
+	return &loaders{
+		streams: dataloader.New(ctx, dataloader.Each(parallelism, func(ctx context.Context, id int32) (*streampb.StreamResponse, bool, error) {
+			stream, err := services.Streams.GetStream(ctx, &streampb.GetStreamRequest{Id: id})
+			if err != nil {
+				return nil, false, notFoundIsMissing(err)
+			}
+			return stream, visible(ctx, stream), nil
+		}), wait, r.cfg.MaxBatch),
+
+		users: dataloader.New(ctx, dataloader.Each(parallelism, func(ctx context.Context, id int32) (*userpb.User, bool, error) {
+			resp, err := services.Users.GetUser(ctx, &userpb.GetUserRequest{Id: id})
+			if err != nil {
+				return nil, false, notFoundIsMissing(err)
+			}
+			return resp.User, resp.User != nil, nil
+		}), wait, r.cfg.MaxBatch),
+
+		comments: dataloader.New(ctx, dataloader.Each(parallelism, func(ctx context.Context, id int32) (*commentpb.Comment, bool, error) {
+			resp, err := services.Comments.GetComment(ctx, &commentpb.GetCommentRequest{Id: id})
+			if err != nil {
+				return nil, false, notFoundIsMissing(err)
+			}
+			return resp.Comment, resp.Comment != nil, nil
+		}), wait, r.cfg.MaxBatch),
+
+		streamPages: dataloader.New(ctx, dataloader.Each(parallelism, func(ctx context.Context, key streamPageKey) (*streampb.ListStreamsResponse, bool, error) {
+			filter := &streampb.StreamFilter{
+				UserId:        key.userID,
+				TitleContains: key.titleContains,
+			}
+			if key.status != "" {
+				filter.Status = strings.Split(key.status, ",")
+			}
+			resp, err := services.Streams.ListStreams(ctx, &streampb.ListStreamsRequest{
+				PageSize:   key.first,
+				PageNumber: key.page,
+				Filter:     filter,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			return resp, true, nil
+		}), wait, r.cfg.MaxBatch),
+
+		commentPages: dataloader.New(ctx, dataloader.Each(parallelism, func(ctx context.Context, key commentPageKey) (*commentpb.ListCommentsResponse, bool, error) {
+			resp, err := services.Comments.ListComments(ctx, &commentpb.ListCommentsRequest{
+				StreamId: &key.streamID,
+				PageSize: &key.first,
+				Page:     &key.page,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			return resp, true, nil
+		}), wait, r.cfg.MaxBatch),
+	}
+}
+
+// visible reports whether the viewer of ctx may see stream. Private streams
+// are hidden from anonymous viewers; for signed-in viewers stream-service has
+// already checked access.
+func visible(ctx context.Context, stream *streampb.StreamResponse) bool {
+	if _, ok := auth.UserFromContext(ctx); ok {
+		return true
+	}
+	return stream.Visibility != models.VisibilityPrivate
+}
+
+// notFoundIsMissing turns NotFound errors into missing values
+func notFoundIsMissing(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
+
+type loadersKey struct{}
+
+// withLoaders attaches the loaders of a request to ctx
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// loadersFrom returns the loaders of the request of ctx
+func loadersFrom(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersKey{}).(*loaders)
+	return l
+}
@@ -0,0 +1,270 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/stream-service/models"
+	streampb "github.com/clementus360/stream-service/proto"
+	graphql "github.com/graph-gophers/graphql-go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxPageSize caps the first argument of connections, like the services do
+const maxPageSize = 100
+
+// Resolver resolves the Query and Subscription root types
+type Resolver struct {
+	services Services
+	cfg      Config
+}
+
+// loaders returns the loaders of the request of ctx. Operations run outside
+// Exec, such as queries sent over a subscription transport, get their own.
+func (r *Resolver) loaders(ctx context.Context) *loaders {
+	if l := loadersFrom(ctx); l != nil {
+		return l
+	}
+	return r.newLoaders(ctx)
+}
+
+func (r *Resolver) Stream(ctx context.Context, args struct{ ID graphql.ID }) (*streamResolver, error) {
+	id, err := parseID("stream", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	return loadStream(ctx, r.loaders(ctx), id)
+}
+
+type streamsArgs struct {
+	UserID        *graphql.ID
+	Status        *[]string
+	TitleContains *string
+	First         int32
+	Page          int32
+}
+
+func (r *Resolver) Streams(ctx context.Context, args streamsArgs) (*streamConnectionResolver, error) {
+	if err := checkPage(args.First, args.Page); err != nil {
+		return nil, err
+	}
+	key := streamPageKey{first: args.First, page: args.Page}
+	if args.UserID != nil {
+		id, err := parseID("user", *args.UserID)
+		if err != nil {
+			return nil, err
+		}
+		key.userID = id
+	}
+	if args.Status != nil {
+		key.status = strings.Join(*args.Status, ",")
+	}
+	if args.TitleContains != nil {
+		key.titleContains = *args.TitleContains
+	}
+	return loadStreamPage(ctx, r.loaders(ctx), key)
+}
+
+func (r *Resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	id, err := parseID("user", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	return loadUser(ctx, r.loaders(ctx), id)
+}
+
+func (r *Resolver) Comment(ctx context.Context, args struct{ ID graphql.ID }) (*commentResolver, error) {
+	id, err := parseID("comment", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	l := r.loaders(ctx)
+	comment, err := l.comments.Load(ctx, id)
+	if err != nil || comment == nil {
+		return nil, serviceError(err)
+	}
+	// Comments of streams the viewer may not see are hidden with them
+	stream, err := l.streams.Load(ctx, comment.StreamId)
+	if err != nil || stream == nil {
+		return nil, serviceError(err)
+	}
+	return &commentResolver{l: l, c: comment}, nil
+}
+
+type streamResolver struct {
+	l *loaders
+	s *streampb.StreamResponse
+}
+
+func loadStream(ctx context.Context, l *loaders, id int32) (*streamResolver, error) {
+	stream, err := l.streams.Load(ctx, id)
+	if err != nil || stream == nil {
+		return nil, serviceError(err)
+	}
+	return &streamResolver{l: l, s: stream}, nil
+}
+
+func (r *streamResolver) ID() graphql.ID           { return formatID(r.s.Id) }
+func (r *streamResolver) Title() string            { return r.s.Title }
+func (r *streamResolver) Description() string      { return r.s.Description }
+func (r *streamResolver) StartTime() *graphql.Time { return parseStreamTime(r.s.StartTime) }
+func (r *streamResolver) EndTime() *graphql.Time   { return parseStreamTime(r.s.EndTime) }
+func (r *streamResolver) Status() string           { return r.s.Status }
+func (r *streamResolver) Visibility() string       { return r.s.Visibility }
+func (r *streamResolver) ViewCount() int32         { return r.s.ViewCount }
+func (r *streamResolver) Resolution() string       { return r.s.Resolution }
+func (r *streamResolver) Bitrate() string          { return r.s.Bitrate }
+func (r *streamResolver) Framerate() string        { return r.s.Framerate }
+func (r *streamResolver) Codec() string            { return r.s.Codec }
+func (r *streamResolver) Protocol() string         { return r.s.Protocol }
+func (r *streamResolver) RenditionPreset() string  { return r.s.RenditionPreset }
+
+func (r *streamResolver) Owner(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.l, r.s.UserId)
+}
+
+type pageArgs struct {
+	First int32
+	Page  int32
+}
+
+func (r *streamResolver) Comments(ctx context.Context, args pageArgs) (*commentConnectionResolver, error) {
+	if err := checkPage(args.First, args.Page); err != nil {
+		return nil, err
+	}
+	resp, err := r.l.commentPages.Load(ctx, commentPageKey{streamID: r.s.Id, first: args.First, page: args.Page})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	nodes := make([]*commentResolver, len(resp.Comments))
+	for i, comment := range resp.Comments {
+		r.l.comments.Prime(comment.Id, comment)
+		nodes[i] = &commentResolver{l: r.l, c: comment}
+	}
+	return &commentConnectionResolver{nodes: nodes, totalCount: resp.TotalCount}, nil
+}
+
+type userResolver struct {
+	l *loaders
+	u *userpb.User
+}
+
+func loadUser(ctx context.Context, l *loaders, id int32) (*userResolver, error) {
+	user, err := l.users.Load(ctx, id)
+	if err != nil || user == nil {
+		return nil, serviceError(err)
+	}
+	return &userResolver{l: l, u: user}, nil
+}
+
+func (r *userResolver) ID() graphql.ID          { return formatID(r.u.Id) }
+func (r *userResolver) Username() string        { return r.u.Username }
+func (r *userResolver) FirstName() string       { return r.u.FirstName }
+func (r *userResolver) LastName() string        { return r.u.LastName }
+func (r *userResolver) ProfileImageURL() string { return r.u.ProfileImageUrl }
+func (r *userResolver) CreatedAt() *graphql.Time {
+	return timestampTime(r.u.CreatedAt)
+}
+
+func (r *userResolver) Streams(ctx context.Context, args pageArgs) (*streamConnectionResolver, error) {
+	if err := checkPage(args.First, args.Page); err != nil {
+		return nil, err
+	}
+	return loadStreamPage(ctx, r.l, streamPageKey{userID: r.u.Id, first: args.First, page: args.Page})
+}
+
+type commentResolver struct {
+	l *loaders
+	c *commentpb.Comment
+}
+
+func (r *commentResolver) ID() graphql.ID           { return formatID(r.c.Id) }
+func (r *commentResolver) Content() string          { return r.c.Content }
+func (r *commentResolver) CreatedAt() *graphql.Time { return timestampTime(r.c.CreatedAt) }
+func (r *commentResolver) UpdatedAt() *graphql.Time { return timestampTime(r.c.UpdatedAt) }
+
+func (r *commentResolver) Author(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.l, r.c.UserId)
+}
+
+func (r *commentResolver) Stream(ctx context.Context) (*streamResolver, error) {
+	return loadStream(ctx, r.l, r.c.StreamId)
+}
+
+type streamConnectionResolver struct {
+	nodes      []*streamResolver
+	totalCount int32
+}
+
+func loadStreamPage(ctx context.Context, l *loaders, key streamPageKey) (*streamConnectionResolver, error) {
+	resp, err := l.streamPages.Load(ctx, key)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	nodes := make([]*streamResolver, len(resp.Streams))
+	for i, stream := range resp.Streams {
+		l.streams.Prime(stream.Id, stream)
+		nodes[i] = &streamResolver{l: l, s: stream}
+	}
+	var total int32
+	if resp.MetaData != nil {
+		total = resp.MetaData.TotalItems
+	}
+	return &streamConnectionResolver{nodes: nodes, totalCount: total}, nil
+}
+
+func (r *streamConnectionResolver) Nodes() []*streamResolver { return r.nodes }
+func (r *streamConnectionResolver) TotalCount() int32        { return r.totalCount }
+
+type commentConnectionResolver struct {
+	nodes      []*commentResolver
+	totalCount int32
+}
+
+func (r *commentConnectionResolver) Nodes() []*commentResolver { return r.nodes }
+func (r *commentConnectionResolver) TotalCount() int32         { return r.totalCount }
+
+// checkPage validates the arguments of a connection
+func checkPage(first, page int32) error {
+	if first < 1 || first > maxPageSize {
+		return fmt.Errorf("first must be between 1 and %d, got %d", maxPageSize, first)
+	}
+	if page < 1 {
+		return fmt.Errorf("page must be positive, got %d", page)
+	}
+	return nil
+}
+
+func parseID(kind string, id graphql.ID) (int32, error) {
+	n, err := strconv.ParseInt(string(id), 10, 32)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid %s id %q", kind, id)
+	}
+	return int32(n), nil
+}
+
+func formatID(id int32) graphql.ID {
+	return graphql.ID(strconv.FormatInt(int64(id), 10))
+}
+
+// parseStreamTime converts the times of stream-service, which are empty
+// when unset
+func parseStreamTime(value string) *graphql.Time {
+	t, err := time.Parse(models.TimeFormat, value)
+	if err != nil {
+		return nil
+	}
+	return &graphql.Time{Time: t}
+}
+
+func timestampTime(ts *timestamppb.Timestamp) *graphql.Time {
+	if ts == nil {
+		return nil
+	}
+	return &graphql.Time{Time: ts.AsTime()}
+}
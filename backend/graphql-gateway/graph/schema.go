@@ -0,0 +1,70 @@
+// Package graph resolves the GraphQL schema of the gateway with the gRPC
+// clients of stream-service, user-service and comment-service.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"time"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	streampb "github.com/clementus360/stream-service/proto"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Services are the clients of the services behind the gateway
+type Services struct {
+	Streams  streampb.StreamServiceClient
+	Comments commentpb.CommentServiceClient
+	Users    userpb.UserServiceClient
+}
+
+// Config bounds the cost of queries and tunes batching and subscriptions
+type Config struct {
+	// MaxDepth rejects queries nesting fields deeper
+	MaxDepth int
+	// Parallelism is the number of fields resolved, and of keys of a batch
+	// looked up, at once
+	Parallelism int
+	// BatchWait is how long loaders collect keys before calling a service
+	BatchWait time.Duration
+	// MaxBatch caps the keys fetched together, 0 leaves batches unbounded
+	MaxBatch int
+	// PollInterval is how often StreamStatusChanged checks the stream for changes
+	PollInterval time.Duration
+}
+
+// Schema executes queries and subscriptions
+type Schema struct {
+	schema   *graphql.Schema
+	resolver *Resolver
+}
+
+// NewSchema parses the schema and binds it to resolvers calling services
+func NewSchema(services Services, cfg Config) (*Schema, error) {
+	resolver := &Resolver{services: services, cfg: cfg}
+	schema, err := graphql.ParseSchema(schemaSDL, resolver,
+		graphql.MaxDepth(cfg.MaxDepth),
+		graphql.MaxParallelism(cfg.Parallelism),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: schema, resolver: resolver}, nil
+}
+
+// Exec runs a query with loaders shared by all of its fields
+func (s *Schema) Exec(ctx context.Context, query, operationName string, variables map[string]interface{}) *graphql.Response {
+	ctx = withLoaders(ctx, s.resolver.newLoaders(ctx))
+	return s.schema.Exec(ctx, query, operationName, variables)
+}
+
+// Subscribe starts a subscription. Its channel yields a *graphql.Response
+// per event and is closed when the subscription ends or ctx is done.
+func (s *Schema) Subscribe(ctx context.Context, query, operationName string, variables map[string]interface{}) (<-chan interface{}, error) {
+	return s.schema.Subscribe(ctx, query, operationName, variables)
+}
@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	streampb "github.com/clementus360/stream-service/proto"
+	graphql "github.com/graph-gophers/graphql-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/clementus360/platform/logging"
+)
+
+// CommentAdded follows the comments of the stream through comment-service
+// and sends those posted after the subscription started. Each event is
+// resolved with fresh loaders, so authors and streams are as current as the
+// comment.
+func (r *Resolver) CommentAdded(ctx context.Context, args struct{ StreamID graphql.ID }) (<-chan *commentResolver, error) {
+	streamID, err := parseID("stream", args.StreamID)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := r.pollStream(ctx, streamID)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	if stream == nil {
+		return nil, &Error{Code: codes.NotFound, Message: "stream not found"}
+	}
+
+	sub, err := r.services.Comments.SubscribeComments(ctx, &commentpb.SubscribeCommentsRequest{StreamId: streamID})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	// comment-service sends the headers once the subscription is in place,
+	// or ends the call when the stream is gone
+	if header, _ := sub.Header(); header == nil {
+		_, err := sub.Recv()
+		return nil, serviceError(err)
+	}
+
+	events := make(chan *commentResolver)
+	go func() {
+		defer close(events)
+		for {
+			event, err := sub.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					logging.FromContext(ctx).Warn("Comment subscription ended", "stream_id", streamID, "error", err)
+				}
+				return
+			}
+			if event.Type != "created" {
+				continue
+			}
+
+			l := r.newLoaders(ctx)
+			l.comments.Prime(event.Comment.Id, event.Comment)
+			select {
+			case events <- &commentResolver{l: l, c: event.Comment}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// StreamStatusChanged polls the stream and sends it each time its status
+// changes. The subscription ends when the stream is deleted or hidden from
+// the viewer.
+func (r *Resolver) StreamStatusChanged(ctx context.Context, args struct{ StreamID graphql.ID }) (<-chan *streamResolver, error) {
+	streamID, err := parseID("stream", args.StreamID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.pollStream(ctx, streamID)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	if current == nil {
+		return nil, &Error{Code: codes.NotFound, Message: "stream not found"}
+	}
+
+	events := make(chan *streamResolver)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(r.cfg.PollInterval)
+		defer ticker.Stop()
+
+		lastStatus := current.Status
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			stream, err := r.pollStream(ctx, streamID)
+			if err != nil {
+				if ctx.Err() == nil {
+					logging.FromContext(ctx).Warn("Failed to poll stream", "stream_id", streamID, "error", err)
+				}
+				continue
+			}
+			if stream == nil {
+				return
+			}
+			if stream.Status == lastStatus {
+				continue
+			}
+			lastStatus = stream.Status
+
+			l := r.newLoaders(ctx)
+			l.streams.Prime(stream.Id, stream)
+			select {
+			case events <- &streamResolver{l: l, s: stream}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// pollStream returns the stream, or nil when it is gone or hidden from the
+// viewer
+func (r *Resolver) pollStream(ctx context.Context, id int32) (*streampb.StreamResponse, error) {
+	stream, err := r.services.Streams.GetStream(ctx, &streampb.GetStreamRequest{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, stream) {
+		return nil, nil
+	}
+	return stream, nil
+}
@@ -0,0 +1,134 @@
+// Package transport serves GraphQL operations over HTTP and subscriptions
+// over WebSocket with the graphql-transport-ws protocol.
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/clementus360/platform/logging"
+)
+
+// maxBodySize bounds the JSON body of a request
+const maxBodySize = 1 << 20
+
+// Executor runs GraphQL operations
+type Executor interface {
+	Exec(ctx context.Context, query, operationName string, variables map[string]interface{}) *graphql.Response
+	Subscribe(ctx context.Context, query, operationName string, variables map[string]interface{}) (<-chan interface{}, error)
+}
+
+// Request is the body of a GraphQL request, and the payload of a subscribe
+// message
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves /graphql. POST runs queries, GET runs queries from the URL
+// or upgrades to a WebSocket for subscriptions.
+type Handler struct {
+	exec       Executor
+	playground bool
+}
+
+// NewHandler returns a handler running operations with exec. With
+// playground set, browsers opening the endpoint get GraphiQL.
+func NewHandler(exec Executor, playground bool) *Handler {
+	return &Handler{exec: exec, playground: playground}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.servePost(w, r)
+	case http.MethodGet:
+		switch {
+		case isWebSocket(r):
+			h.serveWebSocket(w, r)
+		case h.playground && r.URL.Query().Get("query") == "" && strings.Contains(r.Header.Get("Accept"), "text/html"):
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(playgroundPage))
+		default:
+			h.serveGet(w, r)
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (h *Handler) servePost(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	h.execute(w, r, req)
+}
+
+func (h *Handler) serveGet(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	req := Request{Query: q.Get("query"), OperationName: q.Get("operationName")}
+	if variables := q.Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid variables")
+			return
+		}
+	}
+	h.execute(w, r, req)
+}
+
+func (h *Handler) execute(w http.ResponseWriter, r *http.Request, req Request) {
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+	resp := h.exec.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+	if len(resp.Errors) > 0 {
+		logging.FromContext(r.Context()).Debug("GraphQL operation failed", "operation", req.OperationName, "errors", len(resp.Errors))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError reports a request that could not be run as a GraphQL response
+// with a single error
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
+
+const playgroundPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>GraphiQL</title>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css" />
+</head>
+<body style="margin: 0;">
+  <div id="graphiql" style="height: 100vh;"></div>
+  <script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+  <script>
+    const url = new URL(window.location.href);
+    const fetcher = GraphiQL.createFetcher({
+      url: url.pathname,
+      subscriptionUrl: url.href.replace(/^http/, 'ws'),
+    });
+    ReactDOM.createRoot(document.getElementById('graphiql')).render(React.createElement(GraphiQL, { fetcher }));
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,223 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/clementus360/platform/logging"
+)
+
+// protocol is the WebSocket subprotocol of subscriptions, see
+// https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+const protocol = "graphql-transport-ws"
+
+// initTimeout is how long a client has to send connection_init
+const initTimeout = 10 * time.Second
+
+// Close codes of the protocol
+const (
+	closeBadRequest      websocket.StatusCode = 4400
+	closeUnauthorized    websocket.StatusCode = 4401
+	closeInitTimeout     websocket.StatusCode = 4408
+	closeDuplicateID     websocket.StatusCode = 4409
+	closeTooManyInitRqst websocket.StatusCode = 4429
+)
+
+// message is a message of the protocol in either direction
+type message struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+func isWebSocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+func (h *Handler) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(hijacker(w), r, &websocket.AcceptOptions{Subprotocols: []string{protocol}})
+	if err != nil {
+		// Accept has replied with the reason
+		return
+	}
+	defer conn.CloseNow()
+
+	if conn.Subprotocol() != protocol {
+		conn.Close(websocket.StatusPolicyViolation, "subprotocol "+protocol+" is required")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	s := &session{
+		conn:       conn,
+		exec:       h.exec,
+		operations: make(map[string]*operation),
+	}
+	s.run(ctx)
+}
+
+// hijacker returns the writer of w that can take over the connection.
+// Middlewares recording the status of responses wrap it.
+func hijacker(w http.ResponseWriter) http.ResponseWriter {
+	for {
+		if _, ok := w.(http.Hijacker); ok {
+			return w
+		}
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return w
+		}
+		w = u.Unwrap()
+	}
+}
+
+// session is a WebSocket connection running subscriptions
+type session struct {
+	conn  *websocket.Conn
+	exec  Executor
+	acked atomic.Bool
+
+	mu         sync.Mutex
+	operations map[string]*operation
+}
+
+// operation is a running subscription
+type operation struct {
+	cancel context.CancelFunc
+}
+
+// run reads messages until the connection is closed
+func (s *session) run(ctx context.Context) {
+	initTimer := time.AfterFunc(initTimeout, func() {
+		if !s.acked.Load() {
+			s.conn.Close(closeInitTimeout, "Connection initialisation timeout")
+		}
+	})
+	defer initTimer.Stop()
+
+	for {
+		var msg message
+		if err := wsjson.Read(ctx, s.conn, &msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case "connection_init":
+			if s.acked.Swap(true) {
+				s.conn.Close(closeTooManyInitRqst, "Too many initialisation requests")
+				return
+			}
+			initTimer.Stop()
+			s.write(ctx, message{Type: "connection_ack"})
+		case "ping":
+			s.write(ctx, message{Type: "pong"})
+		case "pong":
+		case "subscribe":
+			if !s.acked.Load() {
+				s.conn.Close(closeUnauthorized, "Unauthorized")
+				return
+			}
+			var req Request
+			if msg.ID == "" || json.Unmarshal(msg.Payload, &req) != nil || req.Query == "" {
+				s.conn.Close(closeBadRequest, "Invalid subscribe message")
+				return
+			}
+			if !s.start(ctx, msg.ID, req) {
+				s.conn.Close(closeDuplicateID, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+				return
+			}
+		case "complete":
+			s.stop(msg.ID)
+		default:
+			s.conn.Close(closeBadRequest, fmt.Sprintf("Invalid message type %q", msg.Type))
+			return
+		}
+	}
+}
+
+// start runs the operation id in the background. It reports false when an
+// operation with the same id is running.
+func (s *session) start(ctx context.Context, id string, req Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.operations[id]; ok {
+		return false
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	op := &operation{cancel: cancel}
+	s.operations[id] = op
+
+	go func() {
+		defer s.finish(id, op)
+
+		events, err := s.exec.Subscribe(ctx, req.Query, req.OperationName, req.Variables)
+		if err != nil {
+			s.write(ctx, message{ID: id, Type: "error", Payload: marshal([]map[string]string{{"message": err.Error()}})})
+			return
+		}
+		for event := range events {
+			// The events are drained even once the operation is stopped,
+			// which is what ends the subscription
+			if ctx.Err() != nil {
+				continue
+			}
+			resp := event.(*graphql.Response)
+			if resp.Data == nil && len(resp.Errors) > 0 {
+				s.write(ctx, message{ID: id, Type: "error", Payload: marshal(resp.Errors)})
+				cancel()
+				continue
+			}
+			s.write(ctx, message{ID: id, Type: "next", Payload: marshal(resp)})
+		}
+		if ctx.Err() == nil {
+			s.write(ctx, message{ID: id, Type: "complete"})
+		}
+	}()
+	return true
+}
+
+// stop ends the operation id, if it is running
+func (s *session) stop(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if op, ok := s.operations[id]; ok {
+		op.cancel()
+		delete(s.operations, id)
+	}
+}
+
+// finish forgets op once it has ended. Its id may already be reused by a
+// newer operation, which is left alone.
+func (s *session) finish(id string, op *operation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op.cancel()
+	if s.operations[id] == op {
+		delete(s.operations, id)
+	}
+}
+
+func (s *session) write(ctx context.Context, msg message) {
+	if err := wsjson.Write(ctx, s.conn, msg); err != nil && ctx.Err() == nil {
+		logging.FromContext(ctx).Debug("Failed to write to subscriber", "type", msg.Type, "error", err)
+	}
+}
+
+func marshal(v interface{}) json.RawMessage {
+	data, _ := json.Marshal(v)
+	return data
+}
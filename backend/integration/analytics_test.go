@@ -0,0 +1,168 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestStreamAnalytics(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	viewer := harness.AsUser(harness.Context(t), bob.Id)
+
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+
+	// Only live streams can be watched
+	_, err := h.Streams.StartViewerSession(viewer, &streampb.StartViewerSessionRequest{StreamId: stream.Id})
+	requireCode(t, err, codes.FailedPrecondition)
+
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: stream.Id, Status: "ONLINE"}); err != nil {
+		t.Fatalf("UpdateStream to ONLINE: %v", err)
+	}
+
+	// Signed-in viewers are identified by their user id and cannot pose as
+	// someone else
+	_, err = h.Streams.StartViewerSession(viewer, &streampb.StartViewerSessionRequest{StreamId: stream.Id, ViewerId: "1000"})
+	requireCode(t, err, codes.PermissionDenied)
+
+	bobSession, err := h.Streams.StartViewerSession(viewer, &streampb.StartViewerSessionRequest{StreamId: stream.Id, ClientType: "Web"})
+	if err != nil {
+		t.Fatalf("StartViewerSession(bob): %v", err)
+	}
+	if bobSession.ClientType != "web" {
+		t.Errorf("client type is %q, want web", bobSession.ClientType)
+	}
+
+	// The distribution server reports anonymous viewers without an end user
+	var anonymous []*streampb.ViewerSession
+	for _, client := range []string{"tv", "smart-fridge"} {
+		session, err := h.Streams.StartViewerSession(harness.Context(t), &streampb.StartViewerSessionRequest{
+			StreamId:   stream.Id,
+			ViewerId:   "anonymous-" + client,
+			ClientType: client,
+		})
+		if err != nil {
+			t.Fatalf("StartViewerSession(%s): %v", client, err)
+		}
+		anonymous = append(anonymous, session)
+	}
+
+	_, err = h.Streams.EndViewerSession(owner, &streampb.EndViewerSessionRequest{SessionId: bobSession.Id})
+	requireCode(t, err, codes.PermissionDenied)
+	ended, err := h.Streams.EndViewerSession(viewer, &streampb.EndViewerSessionRequest{SessionId: bobSession.Id})
+	if err != nil {
+		t.Fatalf("EndViewerSession(bob): %v", err)
+	}
+	if ended.LeftAt == "" {
+		t.Error("ended session has no leave time")
+	}
+
+	// Figures are computed on the fly while the stream is live
+	live, err := h.Streams.GetStreamAnalytics(owner, &streampb.GetStreamAnalyticsRequest{StreamId: stream.Id})
+	if err != nil {
+		t.Fatalf("GetStreamAnalytics while live: %v", err)
+	}
+	if live.Final {
+		t.Error("analytics of a live stream are final")
+	}
+	if live.UniqueViewers != 3 || live.PeakConcurrentViewers != 3 || live.TotalSessions != 3 {
+		t.Errorf("got %d unique viewers, peak %d and %d sessions, want 3, 3 and 3",
+			live.UniqueViewers, live.PeakConcurrentViewers, live.TotalSessions)
+	}
+	if live.ViewersByClient["web"] != 1 || live.ViewersByClient["tv"] != 1 || live.ViewersByClient["other"] != 1 {
+		t.Errorf("viewers by client are %v, want one web, tv and other viewer", live.ViewersByClient)
+	}
+	if len(live.Concurrency) == 0 {
+		t.Error("live analytics have no concurrency series")
+	}
+
+	_, err = h.Streams.GetStreamAnalytics(viewer, &streampb.GetStreamAnalyticsRequest{StreamId: stream.Id})
+	requireCode(t, err, codes.PermissionDenied)
+
+	// Ending the stream rolls up the analytics in the background and closes
+	// the sessions still open
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: stream.Id, Status: "COMPLETE"}); err != nil {
+		t.Fatalf("UpdateStream to COMPLETE: %v", err)
+	}
+	var rollup *streampb.StreamAnalytics
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		rollup, err = h.Streams.GetStreamAnalytics(owner, &streampb.GetStreamAnalyticsRequest{StreamId: stream.Id})
+		if err != nil {
+			t.Fatalf("GetStreamAnalytics after the stream ended: %v", err)
+		}
+		if rollup.Final {
+			break
+		}
+	}
+	if !rollup.Final {
+		t.Fatal("analytics were not rolled up after the stream ended")
+	}
+	if rollup.UniqueViewers != 3 || rollup.PeakConcurrentViewers != 3 {
+		t.Errorf("rollup has %d unique viewers and peak %d, want 3 and 3", rollup.UniqueViewers, rollup.PeakConcurrentViewers)
+	}
+
+	closed, err := h.Streams.EndViewerSession(harness.Context(t), &streampb.EndViewerSessionRequest{SessionId: anonymous[0].Id})
+	if err != nil {
+		t.Fatalf("EndViewerSession after the rollup: %v", err)
+	}
+	if closed.LeftAt == "" {
+		t.Error("session left open by the viewer was not closed by the rollup")
+	}
+}
+
+func TestViewerSessionLimits(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	viewer := harness.AsUser(harness.Context(t), bob.Id)
+
+	// Sessions of private streams are opened for the owner's team only
+	private := createStream(t, h, alice.Id, "ONLINE")
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: private.Id, Visibility: "PRIVATE"}); err != nil {
+		t.Fatalf("UpdateStream to PRIVATE: %v", err)
+	}
+	_, err := h.Streams.StartViewerSession(viewer, &streampb.StartViewerSessionRequest{StreamId: private.Id})
+	requireCode(t, err, codes.NotFound)
+	_, err = h.Streams.StartViewerSession(harness.Context(t), &streampb.StartViewerSessionRequest{StreamId: private.Id, ViewerId: "anonymous"})
+	requireCode(t, err, codes.NotFound)
+	if _, err := h.Streams.StartViewerSession(owner, &streampb.StartViewerSessionRequest{StreamId: private.Id}); err != nil {
+		t.Fatalf("StartViewerSession on a private stream as its owner: %v", err)
+	}
+
+	// A viewer keeps a bounded number of sessions open on a stream
+	stream := createStream(t, h, alice.Id, "ONLINE")
+	var first *streampb.ViewerSession
+	for i := range 5 {
+		session, err := h.Streams.StartViewerSession(viewer, &streampb.StartViewerSessionRequest{StreamId: stream.Id})
+		if err != nil {
+			t.Fatalf("StartViewerSession %d: %v", i, err)
+		}
+		if first == nil {
+			first = session
+		}
+	}
+	_, err = h.Streams.StartViewerSession(viewer, &streampb.StartViewerSessionRequest{StreamId: stream.Id})
+	requireCode(t, err, codes.ResourceExhausted)
+
+	// others are not held back by the sessions of bob
+	if _, err := h.Streams.StartViewerSession(owner, &streampb.StartViewerSessionRequest{StreamId: stream.Id}); err != nil {
+		t.Fatalf("StartViewerSession as another viewer: %v", err)
+	}
+
+	if _, err := h.Streams.EndViewerSession(viewer, &streampb.EndViewerSessionRequest{SessionId: first.Id}); err != nil {
+		t.Fatalf("EndViewerSession: %v", err)
+	}
+	if _, err := h.Streams.StartViewerSession(viewer, &streampb.StartViewerSessionRequest{StreamId: stream.Id}); err != nil {
+		t.Fatalf("StartViewerSession after ending one: %v", err)
+	}
+}
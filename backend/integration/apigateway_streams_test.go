@@ -0,0 +1,167 @@
+package integration
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	streampb "github.com/clementus360/stream-service/proto"
+
+	"github.com/clementus360/integration/harness"
+)
+
+// TestAPIGatewayStreamRoutes covers the routes of the gateway over the
+// features of stream-service beyond streams themselves
+func TestAPIGatewayStreamRoutes(t *testing.T) {
+	h := harness.Start(t)
+	gateway := httptest.NewServer(h.APIGateway(t))
+	t.Cleanup(gateway.Close)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	h.Clerk.AddUser(harness.ClerkUser{ID: "user_alice", Email: "alice@example.com", Username: "alice"})
+	h.Clerk.AddUser(harness.ClerkUser{ID: "user_bob", Email: "bob@example.com", Username: "bob"})
+	aliceToken := h.Clerk.SessionToken(t, "user_alice")
+	bobToken := h.Clerk.SessionToken(t, "user_bob")
+
+	live := createStream(t, h, alice.Id, "ONLINE")
+	private := createStream(t, h, alice.Id, "COMPLETE")
+	if _, err := h.Streams.UpdateStream(harness.AsUser(harness.Context(t), alice.Id), &streampb.UpdateStreamRequest{Id: private.Id, Visibility: "PRIVATE"}); err != nil {
+		t.Fatalf("UpdateStream(PRIVATE): %v", err)
+	}
+
+	// Exports hold the streams the viewer may see
+	exported := func(token string) int {
+		t.Helper()
+		resp := call(t, gateway.URL, http.MethodGet, fmt.Sprintf("/v1/streams/export?format=jsonl&user_id=%d", alice.Id), token, "")
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Fatalf("export returned %s with %q", resp.Status, resp.Header.Get("Content-Type"))
+		}
+		lines := 0
+		for scanner := bufio.NewScanner(resp.Body); scanner.Scan(); {
+			lines++
+		}
+		return lines
+	}
+	if got := exported(""); got != 1 {
+		t.Errorf("anonymous export has %d streams, want the public one", got)
+	}
+	if got := exported(aliceToken); got != 2 {
+		t.Errorf("export of the owner has %d streams, want 2", got)
+	}
+	expectStatus(t, call(t, gateway.URL, http.MethodGet, "/v1/streams/export?format=xml", "", ""), http.StatusBadRequest)
+
+	// Anonymous viewers get playback tokens and sessions for public streams
+	var token struct {
+		Token string `json:"token"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodPost, fmt.Sprintf("/v1/streams/%d/playback-token", live.Id), "", ""), http.StatusCreated, &token)
+	if token.Token == "" {
+		t.Error("playback token is empty")
+	}
+	expectStatus(t, call(t, gateway.URL, http.MethodPost, fmt.Sprintf("/v1/streams/%d/playback-token", private.Id), "", ""), http.StatusNotFound)
+	// players fetch the playlists from stream-service with the token alone
+	playlist := httptest.NewRecorder()
+	h.StreamsHTTP.ServeHTTP(playlist, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/api/stream/master.m3u8?stream_id=%d&token=%s", live.Id, token.Token), nil))
+	if playlist.Code != http.StatusOK {
+		t.Errorf("master playlist answered %d: %s", playlist.Code, playlist.Body)
+	}
+
+	var session struct {
+		ID     string `json:"id"`
+		LeftAt string `json:"left_at"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodPost, fmt.Sprintf("/v1/streams/%d/viewer-sessions", live.Id), "",
+		`{"viewer_id": "player-1", "client_type": "web"}`), http.StatusCreated, &session)
+	decodeResponse(t, call(t, gateway.URL, http.MethodDelete, "/v1/viewer-sessions/"+session.ID, "", ""), http.StatusOK, &session)
+	if session.LeftAt == "" {
+		t.Error("ended viewer session has no left_at")
+	}
+
+	// Analytics are kept to the owner's team
+	var analytics struct {
+		TotalSessions int `json:"total_sessions"`
+	}
+	analyticsPath := fmt.Sprintf("/v1/streams/%d/analytics", live.Id)
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, analyticsPath, aliceToken, ""), http.StatusOK, &analytics)
+	if analytics.TotalSessions != 1 {
+		t.Errorf("analytics count %d sessions, want 1", analytics.TotalSessions)
+	}
+	expectStatus(t, call(t, gateway.URL, http.MethodGet, analyticsPath, bobToken, ""), http.StatusForbidden)
+	expectStatus(t, call(t, gateway.URL, http.MethodGet, analyticsPath, "", ""), http.StatusUnauthorized)
+
+	// Owners manage the team of their streams
+	collaboratorsPath := fmt.Sprintf("/v1/streams/%d/collaborators", live.Id)
+	expectStatus(t, call(t, gateway.URL, http.MethodPost, collaboratorsPath, bobToken, fmt.Sprintf(`{"user_id": %d, "role": "editor"}`, bob.Id)), http.StatusForbidden)
+	expectStatus(t, call(t, gateway.URL, http.MethodPost, collaboratorsPath, aliceToken, fmt.Sprintf(`{"user_id": %d, "role": "editor"}`, bob.Id)), http.StatusCreated)
+	var team struct {
+		Collaborators []struct {
+			UserID int32 `json:"user_id"`
+		} `json:"collaborators"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, collaboratorsPath, bobToken, ""), http.StatusOK, &team)
+	if len(team.Collaborators) != 1 || team.Collaborators[0].UserID != bob.Id {
+		t.Errorf("collaborators are %+v, want bob", team.Collaborators)
+	}
+	expectStatus(t, call(t, gateway.URL, http.MethodDelete, fmt.Sprintf("%s/%d", collaboratorsPath, bob.Id), aliceToken, ""), http.StatusNoContent)
+
+	// and the destinations their channel is relayed to
+	var destination struct {
+		ID   int32  `json:"id"`
+		Name string `json:"name"`
+	}
+	restreamsPath := fmt.Sprintf("/v1/users/%d/restreams", alice.Id)
+	decodeResponse(t, call(t, gateway.URL, http.MethodPost, restreamsPath, aliceToken,
+		`{"name": "Twitch", "url": "rtmp://127.0.0.1:1/app", "key": "live_secret_key", "enabled": false}`), http.StatusCreated, &destination)
+	decodeResponse(t, call(t, gateway.URL, http.MethodPatch, fmt.Sprintf("/v1/restreams/%d", destination.ID), aliceToken, `{"name": "Twitch EU"}`), http.StatusOK, &destination)
+	if destination.Name != "Twitch EU" {
+		t.Errorf("renamed destination is named %q", destination.Name)
+	}
+	var destinations struct {
+		Destinations []struct {
+			KeyHint string `json:"key_hint"`
+		} `json:"destinations"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, restreamsPath, aliceToken, ""), http.StatusOK, &destinations)
+	if len(destinations.Destinations) != 1 || destinations.Destinations[0].KeyHint != "…_key" {
+		t.Errorf("destinations are %+v, want the one added with a key hint", destinations.Destinations)
+	}
+	expectStatus(t, call(t, gateway.URL, http.MethodGet, restreamsPath, bobToken, ""), http.StatusForbidden)
+	expectStatus(t, call(t, gateway.URL, http.MethodDelete, fmt.Sprintf("/v1/restreams/%d", destination.ID), bobToken, ""), http.StatusForbidden)
+	expectStatus(t, call(t, gateway.URL, http.MethodDelete, fmt.Sprintf("/v1/restreams/%d", destination.ID), aliceToken, ""), http.StatusNoContent)
+
+	// Clips are listed for anyone
+	var clips struct {
+		Clips []any `json:"clips"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, fmt.Sprintf("/v1/users/%d/clips", alice.Id), "", ""), http.StatusOK, &clips)
+	if len(clips.Clips) != 0 {
+		t.Errorf("alice has clips %v", clips.Clips)
+	}
+	expectStatus(t, call(t, gateway.URL, http.MethodGet, "/v1/clips/missing", "", ""), http.StatusNotFound)
+	expectStatus(t, call(t, gateway.URL, http.MethodPost, fmt.Sprintf("/v1/streams/%d/clips", live.Id), "", `{"duration_seconds": 10}`), http.StatusUnauthorized)
+
+	// Deleted streams wait in the trash of their owner
+	expectStatus(t, call(t, gateway.URL, http.MethodDelete, fmt.Sprintf("/v1/streams/%d", private.Id), aliceToken, ""), http.StatusNoContent)
+	var trash struct {
+		Streams []struct {
+			ID int32 `json:"id"`
+		} `json:"streams"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, "/v1/streams/deleted", aliceToken, ""), http.StatusOK, &trash)
+	if len(trash.Streams) != 1 || trash.Streams[0].ID != private.Id {
+		t.Errorf("trash of alice is %+v, want stream %d", trash.Streams, private.Id)
+	}
+	trash.Streams = nil
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, "/v1/streams/deleted", bobToken, ""), http.StatusOK, &trash)
+	if len(trash.Streams) != 0 {
+		t.Errorf("trash of bob is %+v, want it empty", trash.Streams)
+	}
+	restorePath := fmt.Sprintf("/v1/streams/%d/restore", private.Id)
+	if resp := call(t, gateway.URL, http.MethodPost, restorePath, bobToken, ""); resp.StatusCode == http.StatusOK {
+		t.Error("bob restored a stream of alice")
+	}
+	expectStatus(t, call(t, gateway.URL, http.MethodPost, restorePath, aliceToken, ""), http.StatusOK)
+}
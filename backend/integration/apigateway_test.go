@@ -0,0 +1,214 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestAPIGateway(t *testing.T) {
+	h := harness.Start(t)
+	gateway := httptest.NewServer(h.APIGateway(t,
+		"--cors-allowed-origins", "https://app.example.com",
+		"--max-body-size", "1024",
+	))
+	t.Cleanup(gateway.Close)
+
+	alice := createUser(t, h, "alice")
+	h.Clerk.AddUser(harness.ClerkUser{ID: "user_alice", Email: "alice@example.com", Username: "alice"})
+	h.Clerk.AddUser(harness.ClerkUser{ID: "user_carol", Email: "carol@example.com", Username: "carol"})
+	aliceToken := h.Clerk.SessionToken(t, "user_alice")
+	carolToken := h.Clerk.SessionToken(t, "user_carol")
+
+	// Streams are created for the user of the session, who alone sees the
+	// stream key
+	var stream struct {
+		ID        int32  `json:"id"`
+		UserID    int32  `json:"user_id"`
+		StreamKey string `json:"stream_key"`
+	}
+	start := time.Now().UTC().Add(time.Hour)
+	resp := call(t, gateway.URL, http.MethodPost, "/v1/streams", aliceToken, fmt.Sprintf(
+		`{"title": "Launch", "start_time": %q, "end_time": %q, "resolution": "1920x1080", "bitrate": 6000, "framerate": 30, "codec": "h264", "protocol": "rtmp", "status": "SCHEDULED"}`,
+		start.Format(timeFormat), start.Add(time.Hour).Format(timeFormat)))
+	decodeResponse(t, resp, http.StatusCreated, &stream)
+	if stream.UserID != alice.Id || stream.StreamKey == "" {
+		t.Errorf("created stream is %+v, want a stream of alice with a key", stream)
+	}
+	private := createStream(t, h, alice.Id, "SCHEDULED")
+	resp = call(t, gateway.URL, http.MethodPatch, fmt.Sprintf("/v1/streams/%d", private.Id), aliceToken, `{"visibility": "PRIVATE"}`)
+	decodeResponse(t, resp, http.StatusOK, nil)
+
+	var public struct {
+		StreamKey string `json:"stream_key"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, fmt.Sprintf("/v1/streams/%d", stream.ID), "", ""), http.StatusOK, &public)
+	if public.StreamKey != "" {
+		t.Error("anonymous viewer got the stream key")
+	}
+
+	// Anonymous viewers only see public streams and their comments
+	var listing struct {
+		Streams []struct {
+			ID int32 `json:"id"`
+		} `json:"streams"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, fmt.Sprintf("/v1/streams?user_id=%d", alice.Id), "", ""), http.StatusOK, &listing)
+	if len(listing.Streams) != 1 || listing.Streams[0].ID != stream.ID {
+		t.Errorf("anonymous listing of alice's streams is %+v, want only her public stream", listing.Streams)
+	}
+	for _, path := range []string{"/v1/streams/%d", "/v1/streams/%d/comments"} {
+		expectStatus(t, call(t, gateway.URL, http.MethodGet, fmt.Sprintf(path, private.Id), "", ""), http.StatusNotFound)
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, fmt.Sprintf("/v1/streams/%d", private.Id), aliceToken, ""), http.StatusOK, nil)
+
+	// Changes need a session, and a session of a synced user
+	expectStatus(t, call(t, gateway.URL, http.MethodPost, fmt.Sprintf("/v1/streams/%d/comments", stream.ID), "", `{"content": "hi"}`), http.StatusUnauthorized)
+	expectStatus(t, call(t, gateway.URL, http.MethodPost, fmt.Sprintf("/v1/streams/%d/comments", stream.ID), "not-a-token", `{"content": "hi"}`), http.StatusUnauthorized)
+	expectStatus(t, call(t, gateway.URL, http.MethodPost, fmt.Sprintf("/v1/streams/%d/comments", stream.ID), carolToken, `{"content": "hi"}`), http.StatusForbidden)
+
+	// Syncing makes the Clerk user a platform user at once
+	var carol struct {
+		ID       int32  `json:"id"`
+		Username string `json:"username"`
+		Email    string `json:"email"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodPost, "/v1/users/me/sync", carolToken, ""), http.StatusOK, &carol)
+	if carol.Username != "carol" {
+		t.Errorf("synced user is %+v, want carol", carol)
+	}
+	var comment struct {
+		UserID   int32 `json:"user_id"`
+		StreamID int32 `json:"stream_id"`
+	}
+	resp = call(t, gateway.URL, http.MethodPost, fmt.Sprintf("/v1/streams/%d/comments", stream.ID), carolToken, `{"content": "hi", "user_id": 1}`)
+	decodeResponse(t, resp, http.StatusCreated, &comment)
+	if comment.UserID != carol.ID || comment.StreamID != stream.ID {
+		t.Errorf("comment is %+v, want a comment of carol on stream %d", comment, stream.ID)
+	}
+	var comments struct {
+		TotalCount int `json:"total_count"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, fmt.Sprintf("/v1/streams/%d/comments", stream.ID), "", ""), http.StatusOK, &comments)
+	if comments.TotalCount != 1 {
+		t.Errorf("stream has %d comments, want 1", comments.TotalCount)
+	}
+
+	// The services apply their access rules to the user of the session
+	expectStatus(t, call(t, gateway.URL, http.MethodPatch, fmt.Sprintf("/v1/streams/%d", stream.ID), carolToken, `{"title": "Mine"}`), http.StatusForbidden)
+	expectStatus(t, call(t, gateway.URL, http.MethodGet, fmt.Sprintf("/v1/streams/%d", private.Id), carolToken, ""), http.StatusNotFound)
+
+	// Contact details are only shown to the user themselves, who may also
+	// sign in with the session cookie of Clerk's frontend SDKs
+	var profile struct {
+		Email string `json:"email"`
+	}
+	decodeResponse(t, call(t, gateway.URL, http.MethodGet, fmt.Sprintf("/v1/users/%d", carol.ID), aliceToken, ""), http.StatusOK, &profile)
+	if profile.Email != "" {
+		t.Errorf("alice got the email %q of carol", profile.Email)
+	}
+	req, _ := http.NewRequest(http.MethodGet, gateway.URL+"/v1/users/me", nil)
+	req.AddCookie(&http.Cookie{Name: "__session", Value: carolToken})
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /v1/users/me: %v", err)
+	}
+	decodeResponse(t, resp, http.StatusOK, &profile)
+	if profile.Email != "carol@example.com" {
+		t.Errorf("carol got her email as %q", profile.Email)
+	}
+
+	// Bodies must be JSON and small
+	req, _ = http.NewRequest(http.MethodPost, gateway.URL+"/v1/streams", strings.NewReader("title=Launch"))
+	req.Header.Set("Authorization", "Bearer "+aliceToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if resp, err = http.DefaultClient.Do(req); err != nil {
+		t.Fatalf("POST /v1/streams: %v", err)
+	}
+	expectStatus(t, resp, http.StatusUnsupportedMediaType)
+	large := fmt.Sprintf(`{"title": %q}`, strings.Repeat("a", 2048))
+	expectStatus(t, call(t, gateway.URL, http.MethodPost, "/v1/streams", aliceToken, large), http.StatusRequestEntityTooLarge)
+
+	// Browsers may only call from the allowed origins
+	for origin, allowed := range map[string]bool{"https://app.example.com": true, "https://evil.example.com": false} {
+		req, _ := http.NewRequest(http.MethodOptions, gateway.URL+"/v1/streams", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("OPTIONS /v1/streams: %v", err)
+		}
+		resp.Body.Close()
+		if got := resp.Header.Get("Access-Control-Allow-Origin") == origin; resp.StatusCode != http.StatusNoContent || got != allowed {
+			t.Errorf("preflight from %s returned %s with Access-Control-Allow-Origin %q", origin, resp.Status, resp.Header.Get("Access-Control-Allow-Origin"))
+		}
+	}
+
+	// Clients over their limit are asked to retry later
+	limited := httptest.NewServer(h.APIGateway(t, "--rate-limit-rps", "1", "--rate-limit-burst", "2"))
+	t.Cleanup(limited.Close)
+	for i := range 2 {
+		if resp := call(t, limited.URL, http.MethodGet, "/v1/streams", "", ""); resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d within the burst returned %s", i+1, resp.Status)
+		}
+	}
+	resp = call(t, limited.URL, http.MethodGet, "/v1/streams", "", "")
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Errorf("request over the limit returned %s with Retry-After %q", resp.Status, resp.Header.Get("Retry-After"))
+	}
+	// users have their own allowance
+	expectStatus(t, call(t, limited.URL, http.MethodGet, "/v1/users/me", aliceToken, ""), http.StatusOK)
+}
+
+// call sends a request to the gateway, with a JSON body and a session token
+// when they are not empty. The body of the response is read by the caller.
+func call(t *testing.T, url, method, path, token, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// decodeResponse checks the status of resp and decodes its body into out
+// unless it is nil
+func decodeResponse(t *testing.T, resp *http.Response, want int, out any) {
+	t.Helper()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != want {
+		t.Fatalf("%s %s returned %s: %s, want %d", resp.Request.Method, resp.Request.URL.Path, resp.Status, bytes.TrimSpace(body), want)
+	}
+	if out == nil {
+		return
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		t.Fatalf("%s %s returned invalid JSON %s: %v", resp.Request.Method, resp.Request.URL.Path, body, err)
+	}
+}
+
+func expectStatus(t *testing.T, resp *http.Response, want int) {
+	t.Helper()
+	decodeResponse(t, resp, want, nil)
+}
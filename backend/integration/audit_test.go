@@ -0,0 +1,206 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/models"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestAuditLog(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	admin := harness.AsUser(harness.Context(t), bob.Id, auth.RoleAdmin)
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+
+	stream := createStream(t, h, alice.Id, "COMPLETE")
+	comment := createComment(t, h, alice.Id, stream.Id, "First!")
+	// Times in stream-service queries have a precision of a second
+	since := time.Now().UTC().Truncate(time.Second).Add(time.Second)
+	time.Sleep(time.Until(since))
+
+	// Updates record the fields that changed and the request id
+	if _, err := h.Streams.UpdateStream(withRequestID(owner, "req-rename"), &streampb.UpdateStreamRequest{
+		Id:    stream.Id,
+		Title: "Renamed stream",
+	}); err != nil {
+		t.Fatalf("UpdateStream: %v", err)
+	}
+	streams, err := h.Streams.QueryAuditLog(admin, &streampb.QueryAuditLogRequest{
+		TargetType: "stream",
+		TargetId:   fmt.Sprint(stream.Id),
+		Since:      since.Format(models.TimeFormat),
+	})
+	if err != nil {
+		t.Fatalf("stream-service QueryAuditLog: %v", err)
+	}
+	if len(streams.Entries) != 1 {
+		t.Fatalf("stream-service has %d entries for stream %d since the rename, want 1", len(streams.Entries), stream.Id)
+	}
+	update := streams.Entries[0]
+	if update.Action != "update" || update.ActorId != int64(alice.Id) || update.ActorService != "gateway" || update.RequestId != "req-rename" {
+		t.Errorf("update entry is %v", update)
+	}
+	want := &streampb.AuditChange{Field: "title", Before: fmt.Sprintf("%q", stream.Title), After: `"Renamed stream"`}
+	if len(update.Changes) != 1 || update.Changes[0].String() != want.String() {
+		t.Errorf("update changed %v, want only %v", update.Changes, want)
+	}
+
+	// Changes made through the REST API are recorded the same way
+	req := httptest.NewRequest(http.MethodPatch, "/v1/api/stream", strings.NewReader(fmt.Sprintf(`{"id": %d, "title": "Renamed over REST"}`, stream.Id))).WithContext(owner)
+	req.Header.Set(logging.RequestIDHeader, "req-rest-rename")
+	if rec := h.StreamsREST(t, req); rec.Code != http.StatusOK {
+		t.Fatalf("PATCH /v1/api/stream answered %d: %s", rec.Code, rec.Body)
+	}
+	streams, err = h.Streams.QueryAuditLog(admin, &streampb.QueryAuditLogRequest{
+		TargetType: "stream",
+		TargetId:   fmt.Sprint(stream.Id),
+		Since:      since.Format(models.TimeFormat),
+	})
+	if err != nil {
+		t.Fatalf("stream-service QueryAuditLog: %v", err)
+	}
+	if len(streams.Entries) != 2 {
+		t.Fatalf("stream-service has %d entries for stream %d since the rename, want 2", len(streams.Entries), stream.Id)
+	}
+	rest := streams.Entries[0]
+	if rest.Action != "update" || rest.Method != streampb.StreamService_UpdateStream_FullMethodName || rest.ActorId != int64(alice.Id) || rest.RequestId != "req-rest-rename" {
+		t.Errorf("REST update entry is %v", rest)
+	}
+	want = &streampb.AuditChange{Field: "title", Before: `"Renamed stream"`, After: `"Renamed over REST"`}
+	if len(rest.Changes) != 1 || rest.Changes[0].String() != want.String() {
+		t.Errorf("REST update changed %v, want only %v", rest.Changes, want)
+	}
+
+	// Secrets are reported as changed without their values
+	created, err := h.Streams.QueryAuditLog(admin, &streampb.QueryAuditLogRequest{
+		TargetType: "stream",
+		TargetId:   fmt.Sprint(stream.Id),
+		Until:      since.Format(models.TimeFormat),
+		ActorId:    int64(alice.Id),
+	})
+	if err != nil {
+		t.Fatalf("stream-service QueryAuditLog: %v", err)
+	}
+	if len(created.Entries) == 0 || created.Entries[len(created.Entries)-1].Action != "create" {
+		t.Fatalf("stream-service has no create entry for stream %d: %v", stream.Id, created.Entries)
+	}
+	redacted := false
+	for _, change := range created.Entries[len(created.Entries)-1].Changes {
+		if change.Field == "stream_key" {
+			redacted = change.Before == "" && change.After == `"[REDACTED]"`
+		}
+	}
+	if !redacted {
+		t.Errorf("stream key was not recorded as redacted: %v", created.Entries[len(created.Entries)-1].Changes)
+	}
+
+	// Failed calls are not recorded
+	_, err = h.Comments.UpdateComment(harness.AsUser(harness.Context(t), bob.Id), &commentpb.UpdateCommentRequest{Id: comment.Id, Content: "Hijacked"})
+	requireCode(t, err, codes.PermissionDenied)
+	if _, err := h.Comments.UpdateComment(owner, &commentpb.UpdateCommentRequest{Id: comment.Id, Content: "First, edited"}); err != nil {
+		t.Fatalf("UpdateComment: %v", err)
+	}
+	comments, err := h.Comments.QueryAuditLog(admin, &commentpb.QueryAuditLogRequest{
+		TargetType: ptr("comment"),
+		Since:      timestamppb.New(since),
+	})
+	if err != nil {
+		t.Fatalf("comment-service QueryAuditLog: %v", err)
+	}
+	if comments.TotalCount != 1 || comments.Entries[0].Action != "update" || comments.Entries[0].ActorId != int64(alice.Id) {
+		t.Fatalf("comment-service has %v since the edit, want one update by user %d", comments.Entries, alice.Id)
+	}
+	if !hasCommentChange(comments.Entries[0], "content", `"First!"`, `"First, edited"`) {
+		t.Errorf("comment update changed %v", comments.Entries[0].Changes)
+	}
+
+	// A deletion is recorded by every service it reaches, under the request
+	// id of the original call
+	if _, err := h.Users.DeleteUser(withRequestID(owner, "req-delete-alice"), &userpb.DeleteUserRequest{Id: alice.Id}); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	users, err := h.Users.QueryAuditLog(admin, &userpb.QueryAuditLogRequest{
+		TargetType: "user",
+		TargetId:   fmt.Sprint(alice.Id),
+	})
+	if err != nil {
+		t.Fatalf("user-service QueryAuditLog: %v", err)
+	}
+	if users.TotalCount != 2 || users.Entries[0].Action != "delete" || users.Entries[1].Action != "create" {
+		t.Fatalf("user-service has %v for user %d, want a delete after a create", users.Entries, alice.Id)
+	}
+	if deleted := users.Entries[0]; deleted.RequestId != "req-delete-alice" || deleted.ActorId != int64(alice.Id) || len(deleted.Changes) == 0 {
+		t.Errorf("user delete entry is %v", deleted)
+	}
+
+	streams, err = h.Streams.QueryAuditLog(admin, &streampb.QueryAuditLogRequest{ActorService: "user-service"})
+	if err != nil {
+		t.Fatalf("stream-service QueryAuditLog: %v", err)
+	}
+	if len(streams.Entries) != 1 || streams.Entries[0].Action != "delete" || streams.Entries[0].TargetId != fmt.Sprint(stream.Id) {
+		t.Fatalf("stream-service has %v from user-service, want the deletion of stream %d", streams.Entries, stream.Id)
+	}
+	if streams.Entries[0].RequestId != "req-delete-alice" || streams.Entries[0].ActorId != int64(alice.Id) {
+		t.Errorf("cascaded stream delete entry is %v", streams.Entries[0])
+	}
+	comments, err = h.Comments.QueryAuditLog(admin, &commentpb.QueryAuditLogRequest{ActorService: ptr("user-service")})
+	if err != nil {
+		t.Fatalf("comment-service QueryAuditLog: %v", err)
+	}
+	if comments.TotalCount != 1 || comments.Entries[0].TargetId != fmt.Sprint(comment.Id) || comments.Entries[0].RequestId != "req-delete-alice" {
+		t.Errorf("comment-service has %v from user-service, want the deletion of comment %d", comments.Entries, comment.Id)
+	}
+
+	// Only admins and trusted services read the log
+	viewer := harness.AsUser(harness.Context(t), bob.Id, auth.RoleModerator)
+	_, err = h.Streams.QueryAuditLog(viewer, &streampb.QueryAuditLogRequest{})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Comments.QueryAuditLog(viewer, &commentpb.QueryAuditLogRequest{})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Users.QueryAuditLog(viewer, &userpb.QueryAuditLogRequest{})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Users.QueryAuditLog(harness.Context(t), &userpb.QueryAuditLogRequest{})
+	requireCode(t, err, codes.Unauthenticated)
+	_, err = h.Streams.QueryAuditLog(harness.Context(t), &streampb.QueryAuditLogRequest{})
+	requireCode(t, err, codes.Unauthenticated)
+	_, err = h.Comments.QueryAuditLog(harness.Context(t), &commentpb.QueryAuditLogRequest{})
+	requireCode(t, err, codes.Unauthenticated)
+	platformctl := userpb.NewUserServiceClient(h.ServiceConn(t, "platformctl", "user-service"))
+	if _, err := platformctl.QueryAuditLog(harness.Context(t), &userpb.QueryAuditLogRequest{}); err != nil {
+		t.Errorf("QueryAuditLog as a trusted service: %v", err)
+	}
+	_, err = h.Streams.QueryAuditLog(admin, &streampb.QueryAuditLogRequest{Since: "yesterday"})
+	requireCode(t, err, codes.InvalidArgument)
+}
+
+// withRequestID sets the request id the services log and audit the call with
+func withRequestID(ctx context.Context, id string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "x-request-id", id)
+}
+
+func hasCommentChange(entry *commentpb.AuditEntry, field, before, after string) bool {
+	for _, change := range entry.Changes {
+		if change.Field == field {
+			return change.Before == before && change.After == after
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package integration
+
+import (
+	"fmt"
+	"testing"
+
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestUserDeletionCascade(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+
+	// More streams and comments than fit in one page of the database
+	// service, so that the cleanup has to page through them
+	for range 11 {
+		createStream(t, h, alice.Id, "COMPLETE")
+	}
+	aliceStream := createStream(t, h, alice.Id, "SCHEDULED")
+	bobStream := createStream(t, h, bob.Id, "SCHEDULED")
+	for i := range 12 {
+		createComment(t, h, alice.Id, bobStream.Id, fmt.Sprintf("Comment %d", i))
+	}
+	bobComment := createComment(t, h, bob.Id, aliceStream.Id, "See you there")
+
+	_, err := h.Users.DeleteUser(harness.AsUser(harness.Context(t), bob.Id), &userpb.DeleteUserRequest{Id: alice.Id})
+	requireCode(t, err, codes.PermissionDenied)
+
+	aliceCtx := harness.AsUser(harness.Context(t), alice.Id)
+	if _, err := h.Users.DeleteUser(aliceCtx, &userpb.DeleteUserRequest{Id: alice.Id}); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	_, err = h.Users.GetUser(harness.Context(t), &userpb.GetUserRequest{Id: alice.Id})
+	requireCode(t, err, codes.NotFound)
+
+	for _, stream := range h.StreamDB.Streams() {
+		if stream.UserID == alice.Id {
+			t.Errorf("stream %d of the deleted user was kept", stream.ID)
+		}
+	}
+	var kept []int32
+	for _, comment := range h.StreamDB.Comments() {
+		if comment.UserID == alice.Id {
+			t.Errorf("comment %d of the deleted user was kept", comment.ID)
+		}
+		kept = append(kept, comment.ID)
+	}
+
+	// Content of other users is left alone
+	if streams := h.StreamDB.Streams(); len(streams) != 1 || streams[0].ID != bobStream.Id {
+		t.Errorf("remaining streams are %v, want only stream %d", streams, bobStream.Id)
+	}
+	if len(kept) != 1 || kept[0] != bobComment.Id {
+		t.Errorf("remaining comments are %v, want only comment %d", kept, bobComment.Id)
+	}
+	if !h.StreamDB.HasUser(bob.Id) {
+		t.Error("another user was deleted")
+	}
+}
+
+func TestUserDeletionWithLiveStream(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	stream := createStream(t, h, alice.Id, "ONLINE")
+	createComment(t, h, alice.Id, stream.Id, "Going live")
+
+	// A live stream blocks the deletion before anything is removed
+	aliceCtx := harness.AsUser(harness.Context(t), alice.Id)
+	_, err := h.Users.DeleteUser(aliceCtx, &userpb.DeleteUserRequest{Id: alice.Id})
+	requireCode(t, err, codes.FailedPrecondition)
+
+	if !h.StreamDB.HasUser(alice.Id) {
+		t.Error("user was deleted despite the live stream")
+	}
+	if len(h.StreamDB.Streams()) != 1 {
+		t.Error("live stream was deleted")
+	}
+	if len(h.StreamDB.Comments()) != 1 {
+		t.Error("comments were deleted despite the live stream")
+	}
+}
@@ -0,0 +1,208 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"github.com/clementus360/platform/auth"
+	streampb "github.com/clementus360/stream-service/proto"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/clementus360/integration/harness"
+)
+
+// chatMessage is a message of the chat protocol in either direction
+type chatMessage struct {
+	Type     string `json:"type"`
+	Ref      string `json:"ref,omitempty"`
+	Content  string `json:"content,omitempty"`
+	Backfill bool   `json:"backfill,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Comment  *struct {
+		ID       int32  `json:"id"`
+		Content  string `json:"content"`
+		UserID   int32  `json:"user_id"`
+		StreamID int32  `json:"stream_id"`
+	} `json:"comment,omitempty"`
+}
+
+func TestChat(t *testing.T) {
+	h := harness.Start(t)
+	server := httptest.NewServer(h.CommentsHTTP)
+	t.Cleanup(server.Close)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	h.Clerk.AddUser(harness.ClerkUser{ID: "user_alice", Email: "alice@example.com", Username: "alice"})
+	h.Clerk.AddUser(harness.ClerkUser{ID: "user_bob", Email: "bob@example.com", Username: "bob"})
+	// carol signed in but was never synced to the platform
+	h.Clerk.AddUser(harness.ClerkUser{ID: "user_carol", Email: "carol@example.com", Username: "carol"})
+	aliceToken := h.Clerk.SessionToken(t, "user_alice")
+	bobToken := h.Clerk.SessionToken(t, "user_bob")
+
+	stream := createStream(t, h, alice.Id, "ONLINE")
+	private := createStream(t, h, alice.Id, "ONLINE")
+	if _, err := h.Streams.UpdateStream(harness.AsUser(harness.Context(t), alice.Id), &streampb.UpdateStreamRequest{Id: private.Id, Visibility: "PRIVATE"}); err != nil {
+		t.Fatalf("UpdateStream: %v", err)
+	}
+	for i := range 3 {
+		createComment(t, h, alice.Id, stream.Id, fmt.Sprintf("Welcome %d", i))
+	}
+
+	// Connections are refused before the upgrade
+	for _, c := range []struct {
+		name   string
+		path   string
+		header http.Header
+		status int
+	}{
+		{"anonymous", fmt.Sprintf("/chat/streams/%d", stream.Id), nil, http.StatusUnauthorized},
+		{"invalid token", fmt.Sprintf("/chat/streams/%d", stream.Id), bearer("not-a-token"), http.StatusUnauthorized},
+		{"unsynced user", fmt.Sprintf("/chat/streams/%d", stream.Id), bearer(h.Clerk.SessionToken(t, "user_carol")), http.StatusForbidden},
+		{"private stream", fmt.Sprintf("/chat/streams/%d", private.Id), bearer(bobToken), http.StatusNotFound},
+		{"unknown stream", fmt.Sprintf("/chat/streams/%d", stream.Id+100), bearer(bobToken), http.StatusNotFound},
+		{"invalid stream id", "/chat/streams/abc", bearer(bobToken), http.StatusBadRequest},
+		{"backfill too large", fmt.Sprintf("/chat/streams/%d?backfill=101", stream.Id), bearer(bobToken), http.StatusBadRequest},
+	} {
+		_, resp, err := websocket.Dial(harness.Context(t), chatURL(server, c.path), &websocket.DialOptions{HTTPHeader: c.header})
+		if err == nil {
+			t.Fatalf("%s: joined the chat", c.name)
+		}
+		if resp == nil || resp.StatusCode != c.status {
+			t.Errorf("%s: got %v, want status %d", c.name, err, c.status)
+		}
+	}
+
+	// The owner joins the private chat with the session cookie of browsers
+	aliceChat := dialChat(t, server, fmt.Sprintf("/chat/streams/%d", private.Id), http.Header{"Cookie": {"__session=" + aliceToken}})
+	aliceChat.CloseNow()
+
+	since := time.Now()
+	bobChat := dialChat(t, server, fmt.Sprintf("/chat/streams/%d?backfill=2", stream.Id), bearer(bobToken))
+	for i := 1; i < 3; i++ {
+		msg := readChat(t, bobChat)
+		if msg.Type != "created" || !msg.Backfill || msg.Comment.Content != fmt.Sprintf("Welcome %d", i) {
+			t.Fatalf("backfill message %d is %+v", i, msg)
+		}
+	}
+	aliceChat = dialChat(t, server, fmt.Sprintf("/chat/streams/%d", stream.Id), bearer(aliceToken))
+
+	// A message is acknowledged to its sender and sent to everyone in the
+	// chat, including the sender
+	writeChat(t, bobChat, chatMessage{Type: "send", Ref: "m1", Content: "Hello from the chat"})
+	var sent, created *chatMessage
+	for range 2 {
+		msg := readChat(t, bobChat)
+		switch msg.Type {
+		case "sent":
+			sent = &msg
+		case "created":
+			created = &msg
+		default:
+			t.Fatalf("unexpected message %+v", msg)
+		}
+	}
+	if sent == nil || created == nil || sent.Ref != "m1" || sent.Comment.ID != created.Comment.ID {
+		t.Fatalf("got ack %+v and event %+v, want both for m1", sent, created)
+	}
+	if sent.Comment.UserID != bob.Id || sent.Comment.StreamID != stream.Id || sent.Comment.Content != "Hello from the chat" {
+		t.Errorf("posted comment is %+v", sent.Comment)
+	}
+	commentID := sent.Comment.ID
+	if msg := readChat(t, aliceChat); msg.Type != "created" || msg.Comment.ID != commentID {
+		t.Errorf("alice got %+v, want the created comment %d", msg, commentID)
+	}
+
+	// Edits and deletions made through the API reach the chat too
+	bobCtx := harness.AsUser(harness.Context(t), bob.Id)
+	if _, err := h.Comments.UpdateComment(bobCtx, &commentpb.UpdateCommentRequest{Id: commentID, Content: "Hello, edited"}); err != nil {
+		t.Fatalf("UpdateComment: %v", err)
+	}
+	if _, err := h.Comments.DeleteComment(bobCtx, &commentpb.DeleteCommentRequest{Id: commentID}); err != nil {
+		t.Fatalf("DeleteComment: %v", err)
+	}
+	for _, conn := range []*websocket.Conn{aliceChat, bobChat} {
+		for _, want := range []string{"updated", "deleted"} {
+			if msg := readChat(t, conn); msg.Type != want || msg.Comment.ID != commentID || msg.Comment.Content != "Hello, edited" {
+				t.Errorf("got %+v, want comment %d %s", msg, commentID, want)
+			}
+		}
+	}
+
+	// Invalid messages are answered with an error and keep the connection
+	writeChat(t, bobChat, chatMessage{Type: "send", Ref: "m2", Content: ""})
+	if msg := readChat(t, bobChat); msg.Type != "error" || msg.Ref != "m2" || msg.Code != "INVALID_ARGUMENT" {
+		t.Errorf("empty message got %+v, want an INVALID_ARGUMENT error", msg)
+	}
+	writeChat(t, bobChat, chatMessage{Type: "shout", Ref: "m3"})
+	if msg := readChat(t, bobChat); msg.Type != "error" || msg.Ref != "m3" || msg.Code != "INVALID_ARGUMENT" {
+		t.Errorf("unknown message type got %+v, want an INVALID_ARGUMENT error", msg)
+	}
+
+	// Messages are audited like comments posted through the API
+	admin := harness.AsUser(harness.Context(t), alice.Id, auth.RoleAdmin)
+	entries, err := h.Comments.QueryAuditLog(admin, &commentpb.QueryAuditLogRequest{
+		TargetType: ptr("comment"),
+		TargetId:   ptr(fmt.Sprint(commentID)),
+		Since:      timestamppb.New(since),
+	})
+	if err != nil {
+		t.Fatalf("QueryAuditLog: %v", err)
+	}
+	// entries are newest first
+	if n := len(entries.Entries); n == 0 || entries.Entries[n-1].Action != "create" || entries.Entries[n-1].ActorId != int64(bob.Id) {
+		t.Errorf("audit log has %v, want the creation of comment %d by user %d", entries.Entries, commentID, bob.Id)
+	}
+
+	bobChat.Close(websocket.StatusNormalClosure, "")
+	aliceChat.Close(websocket.StatusNormalClosure, "")
+}
+
+func bearer(token string) http.Header {
+	return http.Header{"Authorization": {"Bearer " + token}}
+}
+
+func chatURL(server *httptest.Server, path string) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http") + path
+}
+
+// dialChat joins a chat, which is left when the test ends
+func dialChat(t *testing.T, server *httptest.Server, path string, header http.Header) *websocket.Conn {
+	t.Helper()
+
+	conn, _, err := websocket.Dial(harness.Context(t), chatURL(server, path), &websocket.DialOptions{HTTPHeader: header})
+	if err != nil {
+		t.Fatalf("joining %s: %v", path, err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+func writeChat(t *testing.T, conn *websocket.Conn, msg chatMessage) {
+	t.Helper()
+
+	if err := wsjson.Write(harness.Context(t), conn, msg); err != nil {
+		t.Fatalf("writing chat message: %v", err)
+	}
+}
+
+func readChat(t *testing.T, conn *websocket.Conn) chatMessage {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(harness.Context(t), 5*time.Second)
+	defer cancel()
+	var msg chatMessage
+	if err := wsjson.Read(ctx, conn, &msg); err != nil {
+		t.Fatalf("reading chat message: %v", err)
+	}
+	return msg
+}
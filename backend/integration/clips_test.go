@@ -0,0 +1,242 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestClips(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	carol := createUser(t, h, "carol")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	viewer := harness.AsUser(harness.Context(t), bob.Id)
+	stranger := harness.AsUser(harness.Context(t), carol.Id)
+
+	// A finished broadcast of half an hour, recorded from its start time
+	now := time.Now().UTC()
+	recorded := createBroadcast(t, h, alice.Id, "COMPLETE", now.Add(-time.Hour), now.Add(-30*time.Minute))
+
+	// Clips are widened to whole 2s segments: 60.5s to 90.5s becomes
+	// segments 30 to 45
+	clip, err := h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{
+		StreamId:           recorded.Id,
+		StartOffsetSeconds: 60.5,
+		DurationSeconds:    30,
+		Title:              "The best part",
+	})
+	if err != nil {
+		t.Fatalf("CreateClip: %v", err)
+	}
+	if clip.CreatorId != bob.Id || clip.Source != "recording" || clip.StartOffsetSeconds != 60 || clip.DurationSeconds != 32 {
+		t.Errorf("clip by %d from %s covers %vs for %vs, want bob's recording clip at 60s for 32s",
+			clip.CreatorId, clip.Source, clip.StartOffsetSeconds, clip.DurationSeconds)
+	}
+	if renditionNames(clip.Renditions) != "1080p30 720p30 480p30 360p30" {
+		t.Errorf("clip renditions are %s", renditionNames(clip.Renditions))
+	}
+
+	// Negative offsets count back from the end of the recording
+	last, err := h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{StreamId: recorded.Id, StartOffsetSeconds: -30, DurationSeconds: 30})
+	if err != nil {
+		t.Fatalf("CreateClip(last 30s): %v", err)
+	}
+	if last.StartOffsetSeconds != 1770 || last.DurationSeconds != 30 {
+		t.Errorf("last 30s clip covers %vs for %vs, want 1770s for 30s", last.StartOffsetSeconds, last.DurationSeconds)
+	}
+
+	_, err = h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{StreamId: recorded.Id, StartOffsetSeconds: 1790, DurationSeconds: 30})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{StreamId: recorded.Id, DurationSeconds: 120})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{StreamId: recorded.Id, DurationSeconds: 30, CreatorId: carol.Id})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Streams.CreateClip(harness.Context(t), &streampb.CreateClipRequest{StreamId: recorded.Id, DurationSeconds: 30, CreatorId: carol.Id})
+	requireCode(t, err, codes.Unauthenticated)
+	rec := trashRequest(t, h, harness.Context(t), http.MethodPost, "/v1/api/clip", fmt.Sprintf(`{"stream_id": %d, "duration_seconds": 30}`, recorded.Id))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous REST clip returned %d, want 401", rec.Code)
+	}
+	_, err = h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{StreamId: recorded.Id, DurationSeconds: 30, Title: strings.Repeat("x", 101)})
+	requireCode(t, err, codes.InvalidArgument)
+
+	// Clips are kept by StreamDb with the ladder they were cut with
+	stored := h.StreamDB.Clips()
+	if len(stored) != 2 || stored[0].PublicID != clip.Id || stored[0].CreatorID != bob.Id || stored[0].FirstSegment != 30 ||
+		stored[0].Renditions != "1920x1080p30@4500/1280x720p30@2500/854x480p30@1000/640x360p30@700" {
+		t.Errorf("StreamDb holds the clips %+v", stored)
+	}
+
+	scheduled := createStream(t, h, alice.Id, "SCHEDULED")
+	_, err = h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{StreamId: scheduled.Id, DurationSeconds: 30})
+	requireCode(t, err, codes.FailedPrecondition)
+
+	// Live streams are clipped from the DVR window, up to the live edge
+	live := createBroadcast(t, h, alice.Id, "ONLINE", now.Add(-10*time.Minute), now.Add(time.Hour))
+	liveClip, err := h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{StreamId: live.Id, StartOffsetSeconds: -30, DurationSeconds: 30})
+	if err != nil {
+		t.Fatalf("CreateClip(live): %v", err)
+	}
+	if liveClip.Source != "live" {
+		t.Errorf("live clip source is %q", liveClip.Source)
+	}
+	_, err = h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{StreamId: live.Id, StartOffsetSeconds: 590, DurationSeconds: 30})
+	requireCode(t, err, codes.InvalidArgument)
+
+	// A stream that has just gone live has nothing to clip yet
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: scheduled.Id, Status: "ONLINE"}); err != nil {
+		t.Fatalf("UpdateStream(ONLINE): %v", err)
+	}
+	_, err = h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{StreamId: scheduled.Id, StartOffsetSeconds: -10, DurationSeconds: 10})
+	requireCode(t, err, codes.InvalidArgument)
+
+	// Clip playlists are played with a playback token of the stream
+	_, err = h.Streams.GetClipPlaylist(viewer, &streampb.GetClipPlaylistRequest{Id: clip.Id})
+	requireCode(t, err, codes.Unauthenticated)
+	token, err := h.Streams.IssuePlaybackToken(viewer, &streampb.IssuePlaybackTokenRequest{StreamId: recorded.Id})
+	if err != nil {
+		t.Fatalf("IssuePlaybackToken: %v", err)
+	}
+	master, err := h.Streams.GetClipPlaylist(viewer, &streampb.GetClipPlaylistRequest{Id: clip.Id, Token: token.Token})
+	if err != nil {
+		t.Fatalf("GetClipPlaylist(master): %v", err)
+	}
+	want := fmt.Sprintf("/v1/api/clip/playlist.m3u8?id=%s&rendition=720p30&token=%s\n", clip.Id, token.Token)
+	if !strings.Contains(master.Content, want) {
+		t.Errorf("clip master playlist lacks %q:\n%s", want, master.Content)
+	}
+	media, err := h.Streams.GetClipPlaylist(viewer, &streampb.GetClipPlaylistRequest{Id: clip.Id, Rendition: "720p30", Token: token.Token})
+	if err != nil {
+		t.Fatalf("GetClipPlaylist(720p30): %v", err)
+	}
+	for _, want := range []string{
+		"#EXT-X-MEDIA-SEQUENCE:30\n",
+		"#EXT-X-PLAYLIST-TYPE:VOD\n",
+		fmt.Sprintf("#EXTINF:2.000,\n/hls/%d/720p30/30.ts?token=%s\n", recorded.Id, token.Token),
+		fmt.Sprintf("/hls/%d/720p30/45.ts?token=", recorded.Id),
+		"#EXT-X-ENDLIST\n",
+	} {
+		if !strings.Contains(media.Content, want) {
+			t.Errorf("clip media playlist lacks %q:\n%s", want, media.Content)
+		}
+	}
+	if n := strings.Count(media.Content, "#EXTINF"); n != 16 {
+		t.Errorf("clip media playlist lists %d segments, want 16", n)
+	}
+	_, err = h.Streams.GetClipPlaylist(viewer, &streampb.GetClipPlaylistRequest{Id: clip.Id, Rendition: "2160p30", Token: token.Token})
+	requireCode(t, err, codes.NotFound)
+	otherToken, err := h.Streams.IssuePlaybackToken(viewer, &streampb.IssuePlaybackTokenRequest{StreamId: live.Id})
+	if err != nil {
+		t.Fatalf("IssuePlaybackToken(live): %v", err)
+	}
+	_, err = h.Streams.GetClipPlaylist(viewer, &streampb.GetClipPlaylistRequest{Id: clip.Id, Token: otherToken.Token})
+	requireCode(t, err, codes.PermissionDenied)
+
+	// Clips of private streams are kept to the owner's team
+	private := createBroadcast(t, h, alice.Id, "COMPLETE", now.Add(-time.Hour), now.Add(-30*time.Minute))
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: private.Id, Visibility: "PRIVATE"}); err != nil {
+		t.Fatalf("UpdateStream(PRIVATE): %v", err)
+	}
+	_, err = h.Streams.CreateClip(viewer, &streampb.CreateClipRequest{StreamId: private.Id, DurationSeconds: 30})
+	requireCode(t, err, codes.NotFound)
+	secret, err := h.Streams.CreateClip(owner, &streampb.CreateClipRequest{StreamId: private.Id, DurationSeconds: 30})
+	if err != nil {
+		t.Fatalf("CreateClip(private) as owner: %v", err)
+	}
+	_, err = h.Streams.GetClip(viewer, &streampb.GetClipRequest{Id: secret.Id})
+	requireCode(t, err, codes.NotFound)
+
+	// Listings are scoped by stream or by creator, newest first
+	_, err = h.Streams.ListClips(viewer, &streampb.ListClipsRequest{})
+	requireCode(t, err, codes.InvalidArgument)
+	byStream, err := h.Streams.ListClips(viewer, &streampb.ListClipsRequest{StreamId: recorded.Id})
+	if err != nil {
+		t.Fatalf("ListClips(stream): %v", err)
+	}
+	if len(byStream.Clips) != 2 || byStream.Clips[0].Id != last.Id || byStream.MetaData.TotalItems != 2 {
+		t.Errorf("stream lists %d clips, want the 2 of it newest first", len(byStream.Clips))
+	}
+	byCreator, err := h.Streams.ListClips(viewer, &streampb.ListClipsRequest{CreatorId: bob.Id, PageSize: 2, PageNumber: 2})
+	if err != nil {
+		t.Fatalf("ListClips(creator): %v", err)
+	}
+	if len(byCreator.Clips) != 1 || byCreator.Clips[0].Id != clip.Id || byCreator.MetaData.TotalPages != 2 {
+		t.Errorf("second page of bob's clips has %d clips of %d pages, want his first clip", len(byCreator.Clips), byCreator.MetaData.TotalPages)
+	}
+	hidden, err := h.Streams.ListClips(viewer, &streampb.ListClipsRequest{CreatorId: alice.Id})
+	if err != nil {
+		t.Fatalf("ListClips(alice) as viewer: %v", err)
+	}
+	if len(hidden.Clips) != 0 {
+		t.Errorf("viewer lists %d clips of a private stream", len(hidden.Clips))
+	}
+	own, err := h.Streams.ListClips(owner, &streampb.ListClipsRequest{CreatorId: alice.Id})
+	if err != nil {
+		t.Fatalf("ListClips(alice) as owner: %v", err)
+	}
+	if len(own.Clips) != 1 || own.Clips[0].Id != secret.Id {
+		t.Errorf("owner lists %d of her clips, want the private one", len(own.Clips))
+	}
+
+	// Clips are deleted by their creator or the moderators of the stream
+	_, err = h.Streams.DeleteClip(stranger, &streampb.DeleteClipRequest{Id: clip.Id})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Streams.DeleteClip(harness.Context(t), &streampb.DeleteClipRequest{Id: clip.Id})
+	requireCode(t, err, codes.Unauthenticated)
+	rec = trashRequest(t, h, harness.Context(t), http.MethodDelete, "/v1/api/clip", fmt.Sprintf(`{"id": %q}`, clip.Id))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous REST delete returned %d, want 401", rec.Code)
+	}
+	if _, err := h.Streams.DeleteClip(owner, &streampb.DeleteClipRequest{Id: clip.Id}); err != nil {
+		t.Fatalf("DeleteClip as stream owner: %v", err)
+	}
+	if _, err := h.Streams.DeleteClip(viewer, &streampb.DeleteClipRequest{Id: last.Id}); err != nil {
+		t.Fatalf("DeleteClip as creator: %v", err)
+	}
+	_, err = h.Streams.GetClip(viewer, &streampb.GetClipRequest{Id: clip.Id})
+	requireCode(t, err, codes.NotFound)
+
+	// Deleting a stream drops its clips
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: live.Id, Status: "COMPLETE"}); err != nil {
+		t.Fatalf("UpdateStream(COMPLETE): %v", err)
+	}
+	if _, err := h.Streams.DeleteStream(owner, &streampb.DeleteStreamRequest{Id: live.Id}); err != nil {
+		t.Fatalf("DeleteStream: %v", err)
+	}
+	_, err = h.Streams.GetClip(viewer, &streampb.GetClipRequest{Id: liveClip.Id})
+	requireCode(t, err, codes.NotFound)
+}
+
+// createBroadcast creates a stream of userID broadcast from start until end.
+// Streams are created with a schedule in the future, so the stream is moved
+// to its broadcast afterwards.
+func createBroadcast(t *testing.T, h *harness.Harness, userID int32, status string, start, end time.Time) *streampb.StreamResponse {
+	t.Helper()
+
+	stream, err := h.Streams.CreateStream(harness.AsUser(harness.Context(t), userID), &streampb.CreateStreamRequest{
+		Title:      fmt.Sprintf("Broadcast of user %d", userID),
+		StartTime:  time.Now().UTC().Add(time.Hour).Format(timeFormat),
+		EndTime:    time.Now().UTC().Add(2 * time.Hour).Format(timeFormat),
+		Resolution: "1920x1080",
+		Bitrate:    6000,
+		Framerate:  30,
+		Status:     status,
+		UserId:     int64(userID),
+	})
+	if err != nil {
+		t.Fatalf("CreateStream(user %d): %v", userID, err)
+	}
+	h.StreamDB.Reschedule(stream.Id, start, end)
+	stream.StartTime, stream.EndTime = start.Format(timeFormat), end.Format(timeFormat)
+	return stream
+}
@@ -0,0 +1,168 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestStreamCollaborators(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	carol := createUser(t, h, "carol")
+	dave := createUser(t, h, "dave")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	editor := harness.AsUser(harness.Context(t), bob.Id)
+	coHost := harness.AsUser(harness.Context(t), carol.Id)
+	stranger := harness.AsUser(harness.Context(t), dave.Id)
+
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+
+	// Without a role nobody but the owner touches the stream
+	_, err := h.Streams.UpdateStream(editor, &streampb.UpdateStreamRequest{Id: stream.Id, Title: "Hijacked"})
+	requireCode(t, err, codes.PermissionDenied)
+
+	// Bob edits this stream, Carol co-hosts the whole channel
+	if _, err := h.Streams.AddCollaborator(owner, &streampb.AddCollaboratorRequest{StreamId: stream.Id, UserId: bob.Id, Role: "editor"}); err != nil {
+		t.Fatalf("AddCollaborator(bob): %v", err)
+	}
+	if _, err := h.Streams.AddCollaborator(owner, &streampb.AddCollaboratorRequest{OwnerId: alice.Id, UserId: carol.Id, Role: "co-host"}); err != nil {
+		t.Fatalf("AddCollaborator(carol): %v", err)
+	}
+
+	_, err = h.Streams.AddCollaborator(owner, &streampb.AddCollaboratorRequest{StreamId: stream.Id, UserId: dave.Id, Role: "producer"})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = h.Streams.AddCollaborator(owner, &streampb.AddCollaboratorRequest{StreamId: stream.Id, UserId: alice.Id, Role: "editor"})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = h.Streams.AddCollaborator(editor, &streampb.AddCollaboratorRequest{StreamId: stream.Id, UserId: dave.Id, Role: "editor"})
+	requireCode(t, err, codes.PermissionDenied)
+
+	// Calls without a user are refused, the gateway is not a trusted caller
+	anonymous := harness.Context(t)
+	_, err = h.Streams.AddCollaborator(anonymous, &streampb.AddCollaboratorRequest{StreamId: stream.Id, UserId: dave.Id, Role: "editor"})
+	requireCode(t, err, codes.Unauthenticated)
+	_, err = h.Streams.ListCollaborators(anonymous, &streampb.ListCollaboratorsRequest{StreamId: stream.Id})
+	requireCode(t, err, codes.Unauthenticated)
+	_, err = h.Streams.RemoveCollaborator(anonymous, &streampb.RemoveCollaboratorRequest{OwnerId: alice.Id, UserId: carol.Id})
+	requireCode(t, err, codes.Unauthenticated)
+
+	// Grants are kept by the database service
+	if grants := h.StreamDB.Collaborators(); len(grants) != 2 {
+		t.Errorf("database holds %d grants, want the editor and the co-host", len(grants))
+	}
+
+	// Editors change the details but not the status
+	edited, err := h.Streams.UpdateStream(editor, &streampb.UpdateStreamRequest{Id: stream.Id, Title: "Edited by the producer"})
+	if err != nil {
+		t.Fatalf("UpdateStream as editor: %v", err)
+	}
+	if edited.Title != "Edited by the producer" {
+		t.Errorf("title is %q after the edit", edited.Title)
+	}
+	_, err = h.Streams.UpdateStream(editor, &streampb.UpdateStreamRequest{Id: stream.Id, Status: "ONLINE"})
+	requireCode(t, err, codes.PermissionDenied)
+
+	// Channel co-hosts run the broadcast and see its analytics
+	if _, err := h.Streams.UpdateStream(coHost, &streampb.UpdateStreamRequest{Id: stream.Id, Status: "ONLINE"}); err != nil {
+		t.Fatalf("UpdateStream to ONLINE as co-host: %v", err)
+	}
+	if _, err := h.Streams.GetStreamAnalytics(coHost, &streampb.GetStreamAnalyticsRequest{StreamId: stream.Id}); err != nil {
+		t.Fatalf("GetStreamAnalytics as co-host: %v", err)
+	}
+	_, err = h.Streams.GetStreamAnalytics(editor, &streampb.GetStreamAnalyticsRequest{StreamId: stream.Id})
+	requireCode(t, err, codes.PermissionDenied)
+
+	// The team is visible to its members only
+	team, err := h.Streams.ListCollaborators(editor, &streampb.ListCollaboratorsRequest{StreamId: stream.Id})
+	if err != nil {
+		t.Fatalf("ListCollaborators as editor: %v", err)
+	}
+	if len(team.Collaborators) != 2 {
+		t.Fatalf("stream has %d collaborators, want the channel co-host and the editor", len(team.Collaborators))
+	}
+	if c := team.Collaborators[0]; c.UserId != carol.Id || c.StreamId != 0 || c.Role != "co-host" {
+		t.Errorf("first collaborator is %v, want the channel co-host", c)
+	}
+	_, err = h.Streams.ListCollaborators(stranger, &streampb.ListCollaboratorsRequest{StreamId: stream.Id})
+	requireCode(t, err, codes.PermissionDenied)
+
+	// Deleting stays with the owner
+	if _, err := h.Streams.UpdateStream(coHost, &streampb.UpdateStreamRequest{Id: stream.Id, Status: "COMPLETE"}); err != nil {
+		t.Fatalf("UpdateStream to COMPLETE as co-host: %v", err)
+	}
+	_, err = h.Streams.DeleteStream(coHost, &streampb.DeleteStreamRequest{Id: stream.Id})
+	requireCode(t, err, codes.PermissionDenied)
+
+	// Collaborators may step down, after which their role no longer applies
+	if _, err := h.Streams.RemoveCollaborator(editor, &streampb.RemoveCollaboratorRequest{StreamId: stream.Id, UserId: bob.Id}); err != nil {
+		t.Fatalf("RemoveCollaborator(bob) as bob: %v", err)
+	}
+	_, err = h.Streams.UpdateStream(editor, &streampb.UpdateStreamRequest{Id: stream.Id, Title: "Edited again"})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Streams.RemoveCollaborator(owner, &streampb.RemoveCollaboratorRequest{StreamId: stream.Id, UserId: bob.Id})
+	requireCode(t, err, codes.NotFound)
+
+	if _, err := h.Streams.DeleteStream(owner, &streampb.DeleteStreamRequest{Id: stream.Id}); err != nil {
+		t.Fatalf("DeleteStream as owner: %v", err)
+	}
+}
+
+func TestStreamCollaboratorsREST(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	stranger := harness.AsUser(harness.Context(t), bob.Id)
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+	grant := fmt.Sprintf(`{"stream_id": %d, "user_id": %d, "role": "editor"}`, stream.Id, bob.Id)
+
+	// Requests without a service token are refused before reaching the
+	// handlers
+	req := httptest.NewRequest(http.MethodPost, "/v1/api/stream/collaborators", strings.NewReader(grant))
+	resp := httptest.NewRecorder()
+	h.StreamsHTTP.ServeHTTP(resp, req)
+	if resp.Code != http.StatusUnauthorized {
+		t.Errorf("POST /v1/api/stream/collaborators without a token answered %d, want 401", resp.Code)
+	}
+
+	// Signed requests without a user are refused by the handlers
+	if resp := collaboratorsRequest(t, h, harness.Context(t), http.MethodPost, grant); resp.Code != http.StatusUnauthorized {
+		t.Errorf("POST /v1/api/stream/collaborators without a user answered %d, want 401", resp.Code)
+	}
+	if resp := collaboratorsRequest(t, h, stranger, http.MethodPost, grant); resp.Code != http.StatusForbidden {
+		t.Errorf("POST /v1/api/stream/collaborators by another user answered %d, want 403", resp.Code)
+	}
+	if resp := collaboratorsRequest(t, h, owner, http.MethodPost, grant); resp.Code != http.StatusCreated {
+		t.Fatalf("POST /v1/api/stream/collaborators by the owner answered %d: %s", resp.Code, resp.Body)
+	}
+	grants := h.StreamDB.Collaborators()
+	if len(grants) != 1 || grants[0].UserID != bob.Id || grants[0].StreamID != stream.Id || grants[0].Role != "editor" {
+		t.Errorf("database holds grants %+v, want bob as editor of stream %d", grants, stream.Id)
+	}
+
+	if resp := collaboratorsRequest(t, h, owner, http.MethodDelete, fmt.Sprintf(`{"stream_id": %d, "user_id": %d}`, stream.Id, bob.Id)); resp.Code != http.StatusOK {
+		t.Fatalf("DELETE /v1/api/stream/collaborators by the owner answered %d: %s", resp.Code, resp.Body)
+	}
+	if grants := h.StreamDB.Collaborators(); len(grants) != 0 {
+		t.Errorf("database holds grants %+v after the removal", grants)
+	}
+}
+
+// collaboratorsRequest calls the collaborators endpoint of the REST API on
+// behalf of the user of ctx
+func collaboratorsRequest(t *testing.T, h *harness.Harness, ctx context.Context, method, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	return h.StreamsREST(t, httptest.NewRequest(method, "/v1/api/stream/collaborators", strings.NewReader(body)).WithContext(ctx))
+}
@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"github.com/alicebob/miniredis/v2"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestCommentSubscriptions(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	stream := createStream(t, h, alice.Id, "ONLINE")
+	other := createStream(t, h, alice.Id, "ONLINE")
+	bobCtx := harness.AsUser(harness.Context(t), bob.Id)
+
+	for i := range 12 {
+		createComment(t, h, alice.Id, stream.Id, fmt.Sprintf("Welcome %d", i))
+	}
+
+	// The backfill spans two pages of the database service, which lists the
+	// comments in no particular order
+	h.StreamDB.ReverseComments()
+	sub, err := h.Comments.SubscribeComments(bobCtx, &commentpb.SubscribeCommentsRequest{StreamId: stream.Id, Backfill: 5})
+	if err != nil {
+		t.Fatalf("SubscribeComments: %v", err)
+	}
+	for i := 7; i < 12; i++ {
+		event := recvEvent(t, sub)
+		if !event.Backfill || event.Type != "created" || event.Comment.Content != fmt.Sprintf("Welcome %d", i) {
+			t.Fatalf("backfill event %d is %v", i, event)
+		}
+	}
+
+	// Changes are pushed as they are made, and only for the stream followed
+	createComment(t, h, bob.Id, other.Id, "Wrong stream")
+	comment := createComment(t, h, bob.Id, stream.Id, "Hello!")
+	if _, err := h.Comments.UpdateComment(bobCtx, &commentpb.UpdateCommentRequest{Id: comment.Id, Content: "Hello, alice!"}); err != nil {
+		t.Fatalf("UpdateComment: %v", err)
+	}
+	if _, err := h.Comments.DeleteComment(bobCtx, &commentpb.DeleteCommentRequest{Id: comment.Id}); err != nil {
+		t.Fatalf("DeleteComment: %v", err)
+	}
+	for _, want := range []struct {
+		eventType string
+		content   string
+	}{
+		{"created", "Hello!"},
+		{"updated", "Hello, alice!"},
+		{"deleted", "Hello, alice!"},
+	} {
+		event := recvEvent(t, sub)
+		if event.Backfill || event.Type != want.eventType || event.Comment.Id != comment.Id || event.Comment.Content != want.content {
+			t.Errorf("got event %v, want %s %q", event, want.eventType, want.content)
+		}
+	}
+
+	// Subscribers are checked like readers of the stream
+	if _, err := h.Streams.UpdateStream(harness.AsUser(harness.Context(t), alice.Id), &streampb.UpdateStreamRequest{Id: other.Id, Visibility: "PRIVATE"}); err != nil {
+		t.Fatalf("UpdateStream: %v", err)
+	}
+	for _, req := range []struct {
+		streamID int32
+		backfill int32
+		code     codes.Code
+	}{
+		{other.Id, 0, codes.NotFound},
+		{stream.Id + 100, 0, codes.NotFound},
+		{stream.Id, -1, codes.InvalidArgument},
+		{stream.Id, 101, codes.InvalidArgument},
+	} {
+		sub, err := h.Comments.SubscribeComments(bobCtx, &commentpb.SubscribeCommentsRequest{StreamId: req.streamID, Backfill: req.backfill})
+		if err == nil {
+			_, err = sub.Recv()
+		}
+		requireCode(t, err, req.code)
+	}
+}
+
+func TestCommentSubscriptionSlowConsumer(t *testing.T) {
+	h := harness.Start(t)
+	comments := h.CommentReplica(t, "--hub-buffer-size", "1")
+
+	alice := createUser(t, h, "alice")
+	stream := createStream(t, h, alice.Id, "ONLINE")
+	aliceCtx := harness.AsUser(harness.Context(t), alice.Id)
+	createComment(t, h, alice.Id, stream.Id, "First")
+
+	// The backfill tells that the subscription has started
+	sub, err := comments.SubscribeComments(aliceCtx, &commentpb.SubscribeCommentsRequest{StreamId: stream.Id, Backfill: 1})
+	if err != nil {
+		t.Fatalf("SubscribeComments: %v", err)
+	}
+	recvEvent(t, sub)
+
+	// The subscriber stops reading while the flow control windows fill up
+	content := strings.Repeat("a", 1000)
+	const posted = 500
+	for range posted {
+		if _, err := comments.CreateComment(aliceCtx, &commentpb.CreateCommentRequest{Content: content, UserId: alice.Id, StreamId: stream.Id}); err != nil {
+			t.Fatalf("CreateComment: %v", err)
+		}
+	}
+
+	received := 0
+	for {
+		_, err := sub.Recv()
+		if err != nil {
+			requireCode(t, err, codes.ResourceExhausted)
+			break
+		}
+		received++
+	}
+	if received >= posted {
+		t.Errorf("slow subscriber received all %d events before being disconnected", received)
+	}
+}
+
+func TestCommentSubscriptionAcrossReplicas(t *testing.T) {
+	h := harness.Start(t)
+	redis := miniredis.RunT(t)
+	first := h.CommentReplica(t, "--hub-broker", "redis", "--hub-redis-url", "redis://"+redis.Addr())
+	second := h.CommentReplica(t, "--hub-broker", "redis", "--hub-redis-url", "redis://"+redis.Addr())
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	stream := createStream(t, h, alice.Id, "ONLINE")
+	createComment(t, h, alice.Id, stream.Id, "First")
+
+	// Both replicas listen on the channel before anything is published
+	deadline := time.Now().Add(5 * time.Second)
+	for redis.PubSubNumSub("comment-service:events")["comment-service:events"] < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("the replicas did not subscribe to the Redis channel")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	sub, err := first.SubscribeComments(harness.AsUser(harness.Context(t), alice.Id), &commentpb.SubscribeCommentsRequest{StreamId: stream.Id, Backfill: 1})
+	if err != nil {
+		t.Fatalf("SubscribeComments: %v", err)
+	}
+	recvEvent(t, sub)
+
+	bobCtx := harness.AsUser(harness.Context(t), bob.Id)
+	resp, err := second.CreateComment(bobCtx, &commentpb.CreateCommentRequest{Content: "Hi from the other replica", UserId: bob.Id, StreamId: stream.Id})
+	if err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+	event := recvEvent(t, sub)
+	if event.Type != "created" || event.Comment.Id != resp.Comment.Id {
+		t.Errorf("got event %v, want the comment created on the other replica", event)
+	}
+}
+
+// recvEvent returns the next event of a subscription
+func recvEvent(t *testing.T, sub commentpb.CommentService_SubscribeCommentsClient) *commentpb.CommentEvent {
+	t.Helper()
+
+	event, err := sub.Recv()
+	if err != nil {
+		t.Fatalf("receiving comment event: %v", err)
+	}
+	return event
+}
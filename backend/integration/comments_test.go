@@ -0,0 +1,96 @@
+package integration
+
+import (
+	"fmt"
+	"testing"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"github.com/clementus360/platform/auth"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestCommenting(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	stream := createStream(t, h, alice.Id, "ONLINE")
+	aliceCtx := harness.AsUser(harness.Context(t), alice.Id)
+	bobCtx := harness.AsUser(harness.Context(t), bob.Id)
+
+	comment := createComment(t, h, bob.Id, stream.Id, "Great stream!")
+	if comment.Id == 0 {
+		t.Fatal("created comment has no id")
+	}
+
+	got, err := h.Comments.GetComment(aliceCtx, &commentpb.GetCommentRequest{Id: comment.Id})
+	if err != nil {
+		t.Fatalf("GetComment: %v", err)
+	}
+	if got.Comment.Content != "Great stream!" || got.Comment.UserId != bob.Id || got.Comment.StreamId != stream.Id {
+		t.Errorf("GetComment returned %v", got.Comment)
+	}
+
+	// Comments are checked against the other services
+	_, err = h.Comments.CreateComment(bobCtx, &commentpb.CreateCommentRequest{Content: "Hello?", UserId: bob.Id, StreamId: stream.Id + 100})
+	requireCode(t, err, codes.NotFound)
+
+	ghostCtx := harness.AsUser(harness.Context(t), bob.Id+100)
+	_, err = h.Comments.CreateComment(ghostCtx, &commentpb.CreateCommentRequest{Content: "Boo", UserId: bob.Id + 100, StreamId: stream.Id})
+	requireCode(t, err, codes.NotFound)
+
+	_, err = h.Comments.CreateComment(bobCtx, &commentpb.CreateCommentRequest{Content: "I am alice", UserId: alice.Id, StreamId: stream.Id})
+	requireCode(t, err, codes.PermissionDenied)
+
+	_, err = h.Comments.CreateComment(bobCtx, &commentpb.CreateCommentRequest{Content: "", UserId: bob.Id, StreamId: stream.Id})
+	requireCode(t, err, codes.InvalidArgument)
+
+	// Only the author edits a comment
+	_, err = h.Comments.UpdateComment(aliceCtx, &commentpb.UpdateCommentRequest{Id: comment.Id, Content: "Edited by alice"})
+	requireCode(t, err, codes.PermissionDenied)
+
+	updated, err := h.Comments.UpdateComment(bobCtx, &commentpb.UpdateCommentRequest{Id: comment.Id, Content: "Great stream, really!"})
+	if err != nil {
+		t.Fatalf("UpdateComment: %v", err)
+	}
+	if updated.Comment.Content != "Great stream, really!" {
+		t.Errorf("content is %q after update", updated.Comment.Content)
+	}
+
+	var thanks *commentpb.Comment
+	for i := range 3 {
+		thanks = createComment(t, h, alice.Id, stream.Id, fmt.Sprintf("Thanks %d", i))
+	}
+	page, err := h.Comments.ListComments(aliceCtx, &commentpb.ListCommentsRequest{StreamId: ptr(stream.Id), PageSize: ptr(int32(2))})
+	if err != nil {
+		t.Fatalf("ListComments: %v", err)
+	}
+	if page.TotalCount != 4 || len(page.Comments) != 2 {
+		t.Errorf("ListComments returned %d of %d comments, want 2 of 4", len(page.Comments), page.TotalCount)
+	}
+
+	// Moderators take down the comments of others, but neither edit them nor
+	// act on anything else of their authors
+	moderator := createUser(t, h, "carol")
+	moderatorCtx := harness.AsUser(harness.Context(t), moderator.Id, auth.RoleModerator)
+	_, err = h.Comments.UpdateComment(moderatorCtx, &commentpb.UpdateCommentRequest{Id: thanks.Id, Content: "Edited by a moderator"})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Streams.UpdateStream(moderatorCtx, &streampb.UpdateStreamRequest{Id: stream.Id, Title: "Moderated"})
+	requireCode(t, err, codes.PermissionDenied)
+	if _, err := h.Comments.DeleteComment(moderatorCtx, &commentpb.DeleteCommentRequest{Id: thanks.Id}); err != nil {
+		t.Fatalf("DeleteComment as a moderator: %v", err)
+	}
+
+	// The gateways may not change comments without a user
+	_, err = h.Comments.DeleteComment(harness.Context(t), &commentpb.DeleteCommentRequest{Id: comment.Id})
+	requireCode(t, err, codes.Unauthenticated)
+
+	if _, err := h.Comments.DeleteComment(bobCtx, &commentpb.DeleteCommentRequest{Id: comment.Id}); err != nil {
+		t.Fatalf("DeleteComment: %v", err)
+	}
+	_, err = h.Comments.GetComment(bobCtx, &commentpb.GetCommentRequest{Id: comment.Id})
+	requireCode(t, err, codes.NotFound)
+}
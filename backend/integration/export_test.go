@@ -0,0 +1,130 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/clementus360/stream-service/export"
+	streampb "github.com/clementus360/stream-service/proto"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestStreamExport(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+
+	// More streams than fit in a page of the database service, one of them
+	// private
+	var streams []*streampb.StreamResponse
+	for range 12 {
+		streams = append(streams, createStream(t, h, alice.Id, "COMPLETE"))
+	}
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: streams[0].Id, Visibility: "PRIVATE"}); err != nil {
+		t.Fatalf("UpdateStream(PRIVATE): %v", err)
+	}
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: streams[1].Id, Title: "=HYPERLINK(\"http://evil\")"}); err != nil {
+		t.Fatalf("UpdateStream(title): %v", err)
+	}
+	createStream(t, h, bob.Id, "COMPLETE")
+
+	// Exports page by ascending id whatever the sorting asked
+	resp := exportStreams(t, h, fmt.Sprintf("format=csv&user_id=%d&sort_by=title&ascending=false", alice.Id))
+	if resp.Code != http.StatusOK || !strings.HasPrefix(resp.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("CSV export answered %d with %q: %s", resp.Code, resp.Header().Get("Content-Type"), resp.Body)
+	}
+	if disposition := resp.Header().Get("Content-Disposition"); !strings.HasPrefix(disposition, "attachment;") || !strings.Contains(disposition, ".csv") {
+		t.Errorf("CSV export is served with Content-Disposition %q", disposition)
+	}
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV export is not valid CSV: %v", err)
+	}
+	if len(records) != 12 {
+		t.Fatalf("CSV export has %d records, want a header and the 11 public streams of the channel", len(records))
+	}
+	if header := strings.Join(records[0], ","); !strings.HasPrefix(header, "id,title,") || strings.Contains(header, "stream_key") {
+		t.Errorf("CSV export has header %q", header)
+	}
+	if records[1][0] != fmt.Sprint(streams[1].Id) || records[1][1] != "'=HYPERLINK(\"http://evil\")" {
+		t.Errorf("first exported stream is %v, want stream %d with its title escaped", records[1][:2], streams[1].Id)
+	}
+	if records[11][0] != fmt.Sprint(streams[11].Id) {
+		t.Errorf("last exported stream is %s, want %d", records[11][0], streams[11].Id)
+	}
+
+	resp = exportStreams(t, h, "format=jsonl")
+	if resp.Code != http.StatusOK || resp.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("JSON Lines export answered %d with %q: %s", resp.Code, resp.Header().Get("Content-Type"), resp.Body)
+	}
+	ids := make(map[int32]bool)
+	lines := bufio.NewScanner(resp.Body)
+	for lines.Scan() {
+		var row map[string]any
+		if err := json.Unmarshal(lines.Bytes(), &row); err != nil {
+			t.Fatalf("JSON Lines export has invalid line %q: %v", lines.Text(), err)
+		}
+		if _, ok := row["stream_key"]; ok {
+			t.Errorf("JSON Lines export contains the stream key of stream %v", row["id"])
+		}
+		ids[int32(row["id"].(float64))] = true
+	}
+	if len(ids) != 12 || ids[streams[0].Id] {
+		t.Errorf("JSON Lines export has %d streams including the private one: %v, want the 12 public streams", len(ids), ids[streams[0].Id])
+	}
+
+	// The owner exports the hidden streams of their channel too
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/api/streams/export?format=jsonl&user_id=%d", alice.Id), nil).WithContext(owner)
+	resp = h.StreamsREST(t, req)
+	if rows := strings.Count(resp.Body.String(), "\n"); resp.Code != http.StatusOK || rows != 12 {
+		t.Errorf("owner export answered %d with %d rows, want the 12 streams of the channel", resp.Code, rows)
+	}
+	if trailer := resp.Result().Trailer.Get(export.ErrorTrailer); trailer != "" {
+		t.Errorf("complete export has error trailer %q", trailer)
+	}
+
+	// Failures after the first page cut the file short and say so in a
+	// trailer
+	ctx, cancel := context.WithCancel(harness.Context(t))
+	interrupted := &cancelOnFlush{ResponseRecorder: httptest.NewRecorder(), cancel: cancel}
+	h.ServeStreamsREST(t, interrupted, httptest.NewRequest(http.MethodGet, "/v1/api/streams/export?format=jsonl", nil).WithContext(ctx))
+	if rows := strings.Count(interrupted.Body.String(), "\n"); interrupted.Code != http.StatusOK || rows != export.PageSize {
+		t.Errorf("interrupted export answered %d with %d rows, want the first page", interrupted.Code, rows)
+	}
+	if trailer := interrupted.Result().Trailer.Get(export.ErrorTrailer); trailer == "" {
+		t.Error("interrupted export has no error trailer")
+	}
+
+	if resp := exportStreams(t, h, "format=xlsx"); resp.Code != http.StatusBadRequest {
+		t.Errorf("export in an unknown format answered %d, want 400", resp.Code)
+	}
+}
+
+// cancelOnFlush cancels the request once the first page of an export is
+// flushed
+type cancelOnFlush struct {
+	*httptest.ResponseRecorder
+	cancel context.CancelFunc
+}
+
+func (w *cancelOnFlush) Flush() {
+	w.ResponseRecorder.Flush()
+	w.cancel()
+}
+
+// exportStreams calls the export endpoint of the REST API with query
+func exportStreams(t *testing.T, h *harness.Harness, query string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	return h.StreamsREST(t, httptest.NewRequest(http.MethodGet, "/v1/api/streams/export?"+query, nil).WithContext(harness.Context(t)))
+}
@@ -0,0 +1,77 @@
+module github.com/clementus360/integration
+
+go 1.23.5
+
+require (
+	github.com/Josy-coder/comment-service v0.0.0-00010101000000-000000000000
+	github.com/Josy-coder/db-service v0.0.0-00010101000000-000000000000
+	github.com/Josy-coder/user-service v0.0.0-00010101000000-000000000000
+	github.com/alicebob/miniredis/v2 v2.33.0
+	github.com/clementus360/api-gateway v0.0.0-00010101000000-000000000000
+	github.com/clementus360/graphql-gateway v0.0.0-00010101000000-000000000000
+	github.com/clementus360/platform v0.0.0-00010101000000-000000000000
+	github.com/clementus360/platformctl v0.0.0-00010101000000-000000000000
+	github.com/clementus360/stream-service v0.0.0-00010101000000-000000000000
+	github.com/clementus360/streamdb-memory v0.0.0-00010101000000-000000000000
+	github.com/coder/websocket v1.8.12
+	github.com/go-jose/go-jose/v3 v3.0.0
+	google.golang.org/grpc v1.70.0
+	google.golang.org/protobuf v1.36.4
+)
+
+// The services under test are built from this repository
+replace (
+	github.com/Josy-coder/comment-service => ../comment-service
+	github.com/Josy-coder/db-service => ../db-service
+	github.com/Josy-coder/user-service => ../user-service
+	github.com/clementus360/api-gateway => ../api-gateway
+	github.com/clementus360/graphql-gateway => ../graphql-gateway
+	github.com/clementus360/platform => ../platform
+	github.com/clementus360/platformctl => ../platformctl
+	github.com/clementus360/stream-service => ../stream-service
+	github.com/clementus360/streamdb-api => ../streamdb-api
+	github.com/clementus360/streamdb-memory => ../streamdb-memory
+)
+
+require (
+	github.com/alicebob/gopher-json v0.0.0-20200520072559-a9ecdc9d1d3a // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cenkalti/backoff/v4 v4.3.0 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/clementus360/streamdb-api v0.0.0-00010101000000-000000000000 // indirect
+	github.com/clerkinc/clerk-sdk-go v1.49.1 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/felixge/httpsnoop v1.0.4 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/graph-gophers/graphql-go v1.5.0 // indirect
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.25.1 // indirect
+	github.com/joho/godotenv v1.5.1 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_golang v1.20.5 // indirect
+	github.com/prometheus/client_model v0.6.1 // indirect
+	github.com/prometheus/common v0.55.0 // indirect
+	github.com/prometheus/procfs v0.15.1 // indirect
+	github.com/redis/go-redis/v9 v9.7.3 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.59.0 // indirect
+	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.59.0 // indirect
+	go.opentelemetry.io/otel v1.34.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.34.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.34.0 // indirect
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.34.0 // indirect
+	go.opentelemetry.io/otel/metric v1.34.0 // indirect
+	go.opentelemetry.io/otel/sdk v1.34.0 // indirect
+	go.opentelemetry.io/otel/trace v1.34.0 // indirect
+	go.opentelemetry.io/proto/otlp v1.5.0 // indirect
+	golang.org/x/crypto v0.32.0 // indirect
+	golang.org/x/net v0.34.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20250115164207-1a7da9e5054f // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250124145028-65684f501c47 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,276 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	gatewayapp "github.com/clementus360/graphql-gateway/app"
+	streampb "github.com/clementus360/stream-service/proto"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"google.golang.org/grpc"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestGraphQLGateway(t *testing.T) {
+	h := harness.Start(t)
+	calls := &callCounter{counts: make(map[string]int)}
+	gateway := httptest.NewServer(h.GraphQLGateway(t, gatewayapp.WithDialOptions(grpc.WithChainUnaryInterceptor(calls.intercept))))
+	t.Cleanup(gateway.Close)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+	private := createStream(t, h, alice.Id, "SCHEDULED")
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: private.Id, Visibility: "PRIVATE"}); err != nil {
+		t.Fatalf("UpdateStream(%d) to PRIVATE: %v", private.Id, err)
+	}
+	for i := range 6 {
+		createComment(t, h, []int32{alice.Id, bob.Id}[i%2], stream.Id, fmt.Sprintf("comment %d", i))
+	}
+	secret := createComment(t, h, alice.Id, private.Id, "hidden")
+
+	// Relationships are resolved across the services, and the authors of
+	// the six comments are fetched once each, together with the owner
+	var got struct {
+		Stream struct {
+			Title string
+			Owner struct{ Username string }
+			// Comments are oldest first
+			Comments struct {
+				TotalCount int
+				Nodes      []struct {
+					Content string
+					Author  struct{ Username string }
+				}
+			}
+		}
+	}
+	calls.reset()
+	graphQL(t, gateway.URL, `query($id: ID!) {
+		stream(id: $id) {
+			title
+			owner { username }
+			comments(first: 4) { totalCount nodes { content author { username } } }
+		}
+	}`, map[string]any{"id": fmt.Sprint(stream.Id)}, &got)
+	if got.Stream.Title != stream.Title || got.Stream.Owner.Username != "alice" {
+		t.Errorf("stream is %+v", got.Stream)
+	}
+	if got.Stream.Comments.TotalCount != 6 || len(got.Stream.Comments.Nodes) != 4 {
+		t.Fatalf("stream has comments %+v, want the first 4 of 6", got.Stream.Comments)
+	}
+	if c := got.Stream.Comments.Nodes[1]; c.Content != "comment 1" || c.Author.Username != "bob" {
+		t.Errorf("second comment is %+v", c)
+	}
+	if n := calls.count(userpb.UserService_GetUser_FullMethodName); n != 2 {
+		t.Errorf("gateway called GetUser %d times, want once per user", n)
+	}
+
+	// Anonymous viewers only see public streams and their comments
+	var user struct {
+		User struct {
+			Streams struct {
+				TotalCount int
+				Nodes      []struct {
+					ID       string
+					Comments struct{ TotalCount int }
+				}
+			}
+		}
+	}
+	graphQL(t, gateway.URL, `query($id: ID!) {
+		user(id: $id) { streams { totalCount nodes { id comments { totalCount } } } }
+	}`, map[string]any{"id": fmt.Sprint(alice.Id)}, &user)
+	if s := user.User.Streams; s.TotalCount != 1 || len(s.Nodes) != 1 || s.Nodes[0].ID != fmt.Sprint(stream.Id) || s.Nodes[0].Comments.TotalCount != 6 {
+		t.Errorf("streams of alice are %+v, want only her public stream", s)
+	}
+	var hidden struct {
+		Stream  *struct{ ID string }
+		Comment *struct{ ID string }
+	}
+	graphQL(t, gateway.URL, `query($stream: ID!, $comment: ID!) {
+		stream(id: $stream) { id }
+		comment(id: $comment) { id }
+	}`, map[string]any{"stream": fmt.Sprint(private.Id), "comment": fmt.Sprint(secret.Id)}, &hidden)
+	if hidden.Stream != nil || hidden.Comment != nil {
+		t.Errorf("anonymous viewer got the private stream %v and its comment %v", hidden.Stream, hidden.Comment)
+	}
+
+	// Invalid arguments and queries nested too deep are rejected
+	if errs := graphQLErrors(t, gateway.URL, `{ stream(id: "abc") { id } }`); len(errs) != 1 || !strings.Contains(errs[0], "invalid stream id") {
+		t.Errorf("query with an invalid id failed with %q", errs)
+	}
+	deep := `{ stream(id: "1") { owner { streams { nodes { owner { streams { nodes { owner { streams { nodes { id } } } } } } } } } } }`
+	if errs := graphQLErrors(t, gateway.URL, deep); len(errs) == 0 {
+		t.Error("query nested 11 levels deep was accepted")
+	}
+
+	// Subscriptions report new comments and status changes
+	ctx := harness.Context(t)
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(gateway.URL, "http")+"/graphql", &websocket.DialOptions{
+		Subprotocols: []string{"graphql-transport-ws"},
+	})
+	if err != nil {
+		t.Fatalf("failed to open a WebSocket to the gateway: %v", err)
+	}
+	defer conn.CloseNow()
+
+	send(t, conn, wsMessage{Type: "connection_init"})
+	if msg := receive(t, conn); msg.Type != "connection_ack" {
+		t.Fatalf("gateway answered connection_init with %+v", msg)
+	}
+	send(t, conn, wsMessage{ID: "comments", Type: "subscribe", Payload: subscribe(
+		`subscription($id: ID!) { commentAdded(streamId: $id) { content author { username } } }`, stream.Id)})
+	send(t, conn, wsMessage{ID: "status", Type: "subscribe", Payload: subscribe(
+		`subscription($id: ID!) { streamStatusChanged(streamId: $id) { status } }`, stream.Id)})
+	send(t, conn, wsMessage{ID: "private", Type: "subscribe", Payload: subscribe(
+		`subscription($id: ID!) { commentAdded(streamId: $id) { content } }`, private.Id)})
+	if msg := receive(t, conn); msg.ID != "private" || msg.Type != "error" {
+		t.Fatalf("subscription to the private stream got %+v, want an error", msg)
+	}
+	// The subscriptions take note of the current state when they start
+	time.Sleep(300 * time.Millisecond)
+
+	createComment(t, h, bob.Id, stream.Id, "live!")
+	msg := receive(t, conn)
+	if msg.ID != "comments" || msg.Type != "next" || !bytes.Contains(msg.Payload, []byte(`{"content":"live!","author":{"username":"bob"}}`)) {
+		t.Errorf("comment subscription got %s %s %s", msg.ID, msg.Type, msg.Payload)
+	}
+	send(t, conn, wsMessage{ID: "comments", Type: "complete"})
+
+	for _, status := range []string{"ONLINE", "COMPLETE"} {
+		if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: stream.Id, Status: status}); err != nil {
+			t.Fatalf("UpdateStream(%d) to %s: %v", stream.Id, status, err)
+		}
+		msg := receive(t, conn)
+		if msg.ID != "status" || msg.Type != "next" || !bytes.Contains(msg.Payload, []byte(`"status":"`+status+`"`)) {
+			t.Errorf("status subscription got %s %s %s, want %s", msg.ID, msg.Type, msg.Payload, status)
+		}
+	}
+
+	// Deleting the stream ends its subscription
+	if _, err := h.Streams.DeleteStream(owner, &streampb.DeleteStreamRequest{Id: stream.Id}); err != nil {
+		t.Fatalf("DeleteStream(%d): %v", stream.Id, err)
+	}
+	if msg := receive(t, conn); msg.ID != "status" || msg.Type != "complete" {
+		t.Errorf("status subscription got %+v after the deletion, want complete", msg)
+	}
+	conn.Close(websocket.StatusNormalClosure, "")
+}
+
+// callCounter counts the calls the gateway makes to the services
+type callCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (c *callCounter) intercept(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	c.mu.Lock()
+	c.counts[method]++
+	c.mu.Unlock()
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (c *callCounter) count(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[method]
+}
+
+func (c *callCounter) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.counts)
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage
+	Errors []struct{ Message string }
+}
+
+func postGraphQL(t *testing.T, url, query string, variables map[string]any) graphQLResponse {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	resp, err := http.Post(url+"/graphql", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /graphql: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST /graphql returned %s", resp.Status)
+	}
+	var result graphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("POST /graphql returned invalid JSON: %v", err)
+	}
+	return result
+}
+
+// graphQL runs a query that must succeed and decodes its data into out
+func graphQL(t *testing.T, url, query string, variables map[string]any, out any) {
+	t.Helper()
+
+	result := postGraphQL(t, url, query, variables)
+	if len(result.Errors) > 0 {
+		t.Fatalf("query failed: %+v", result.Errors)
+	}
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		t.Fatalf("query returned unexpected data %s: %v", result.Data, err)
+	}
+}
+
+// graphQLErrors runs a query and returns the messages of its errors
+func graphQLErrors(t *testing.T, url, query string) []string {
+	t.Helper()
+
+	var messages []string
+	for _, err := range postGraphQL(t, url, query, nil).Errors {
+		messages = append(messages, err.Message)
+	}
+	return messages
+}
+
+// wsMessage is a message of the graphql-transport-ws protocol
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+func subscribe(query string, streamID int32) json.RawMessage {
+	payload, _ := json.Marshal(map[string]any{"query": query, "variables": map[string]any{"id": fmt.Sprint(streamID)}})
+	return payload
+}
+
+func send(t *testing.T, conn *websocket.Conn, msg wsMessage) {
+	t.Helper()
+	if err := wsjson.Write(harness.Context(t), conn, msg); err != nil {
+		t.Fatalf("failed to send %s: %v", msg.Type, err)
+	}
+}
+
+// receive returns the next message, failing the test after 5 seconds
+func receive(t *testing.T, conn *websocket.Conn) wsMessage {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(harness.Context(t), 5*time.Second)
+	defer cancel()
+	var msg wsMessage
+	if err := wsjson.Read(ctx, conn, &msg); err != nil {
+		t.Fatalf("no message from the gateway: %v", err)
+	}
+	return msg
+}
@@ -0,0 +1,42 @@
+package harness
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	apiapp "github.com/clementus360/api-gateway/app"
+	apiconfig "github.com/clementus360/api-gateway/config"
+)
+
+// APIGateway starts an API gateway in front of the services and returns its
+// HTTP handler. Sessions are verified against the fake Clerk. Rate limiting
+// is off unless args, added after the defaults, turn it on.
+func (h *Harness) APIGateway(t *testing.T, args ...string) http.Handler {
+	t.Helper()
+
+	cfg, err := apiconfig.LoadConfig(append([]string{
+		"--stream-service-address", address(streamService),
+		"--comment-service-address", address(commentService),
+		"--user-service-address", address(userService),
+		"--rate-limit-rps", "0",
+	}, args...))
+	if err != nil {
+		t.Fatalf("invalid api-gateway configuration: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	gateway, err := apiapp.New(ctx, cfg,
+		apiapp.WithLogger(Logger("api-gateway")),
+		apiapp.WithDialOptions(h.dialOption()),
+	)
+	if err != nil {
+		cancel()
+		t.Fatalf("failed to start api-gateway: %v", err)
+	}
+	t.Cleanup(func() { gateway.Close() })
+	gateway.Start(ctx)
+	t.Cleanup(cancel)
+
+	return gateway.Handler()
+}
@@ -0,0 +1,148 @@
+package harness
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+)
+
+const (
+	// clerkIssuer passes the issuer check of the Clerk SDK
+	clerkIssuer = "https://clerk.integration.test"
+	clerkKeyID  = "integration"
+)
+
+// ClerkUser is an account of the fake identity provider
+type ClerkUser struct {
+	ID        string
+	Email     string
+	Username  string
+	FirstName string
+	LastName  string
+	// Roles are stored in the public metadata like in production
+	Roles []string
+}
+
+// FakeClerk serves the parts of the Clerk Backend API used by user-service:
+// the signing keys of session tokens and user lookups
+type FakeClerk struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	signer jose.Signer
+
+	mu    sync.Mutex
+	users map[string]ClerkUser
+}
+
+// NewFakeClerk starts the identity provider, which is stopped when the test
+// ends
+func NewFakeClerk(t testing.TB) *FakeClerk {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate Clerk signing key: %v", err)
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", clerkKeyID),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Clerk token signer: %v", err)
+	}
+
+	c := &FakeClerk{
+		key:    key,
+		signer: signer,
+		users:  make(map[string]ClerkUser),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/jwks", c.jwks)
+	mux.HandleFunc("GET /v1/users/{id}", c.user)
+	c.server = httptest.NewServer(mux)
+	t.Cleanup(c.server.Close)
+
+	return c
+}
+
+// URL is the Backend API base URL to configure user-service with
+func (c *FakeClerk) URL() string {
+	return c.server.URL + "/v1/"
+}
+
+// AddUser registers an account
+func (c *FakeClerk) AddUser(user ClerkUser) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.users[user.ID] = user
+}
+
+// SessionToken signs a session token for the account clerkID
+func (c *FakeClerk) SessionToken(t testing.TB, clerkID string) string {
+	t.Helper()
+
+	now := time.Now()
+	token, err := jwt.Signed(c.signer).Claims(jwt.Claims{
+		Issuer:    clerkIssuer,
+		Subject:   clerkID,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now.Add(-time.Minute)),
+		Expiry:    jwt.NewNumericDate(now.Add(time.Minute)),
+	}).CompactSerialize()
+	if err != nil {
+		t.Fatalf("failed to sign session token: %v", err)
+	}
+	return token
+}
+
+func (c *FakeClerk) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       &c.key.PublicKey,
+		KeyID:     clerkKeyID,
+		Algorithm: string(jose.RS256),
+		Use:       "sig",
+	}}})
+}
+
+func (c *FakeClerk) user(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	user, ok := c.users[r.PathValue("id")]
+	c.mu.Unlock()
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]any{
+			"errors": []map[string]string{{"code": "resource_not_found", "message": "not found"}},
+		})
+		return
+	}
+
+	// The subset of the Clerk user object read by the SDK
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":                       user.ID,
+		"object":                   "user",
+		"username":                 user.Username,
+		"first_name":               user.FirstName,
+		"last_name":                user.LastName,
+		"primary_email_address_id": "email_" + user.ID,
+		"email_addresses": []map[string]any{{
+			"id":            "email_" + user.ID,
+			"object":        "email_address",
+			"email_address": user.Email,
+		}},
+		"public_metadata": map[string]any{"roles": user.Roles},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
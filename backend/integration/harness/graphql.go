@@ -0,0 +1,42 @@
+package harness
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	gatewayapp "github.com/clementus360/graphql-gateway/app"
+	gatewayconfig "github.com/clementus360/graphql-gateway/config"
+)
+
+// GraphQLGateway starts a GraphQL gateway in front of the services and
+// returns its HTTP handler. Stream status subscriptions poll every 100ms.
+func (h *Harness) GraphQLGateway(t *testing.T, opts ...gatewayapp.Option) http.Handler {
+	t.Helper()
+
+	t.Setenv("GRAPHQL_POLL_INTERVAL", "100ms")
+	cfg, err := gatewayconfig.LoadConfig([]string{
+		"--stream-service-address", address(streamService),
+		"--comment-service-address", address(commentService),
+		"--user-service-address", address(userService),
+	})
+	if err != nil {
+		t.Fatalf("invalid graphql-gateway configuration: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts = append([]gatewayapp.Option{
+		gatewayapp.WithLogger(Logger("graphql-gateway")),
+		gatewayapp.WithDialOptions(h.dialOption()),
+	}, opts...)
+	gateway, err := gatewayapp.New(ctx, cfg, opts...)
+	if err != nil {
+		cancel()
+		t.Fatalf("failed to start graphql-gateway: %v", err)
+	}
+	t.Cleanup(func() { gateway.Close() })
+	gateway.Start(ctx)
+	t.Cleanup(cancel)
+
+	return gateway.Handler()
+}
@@ -0,0 +1,355 @@
+// Package harness runs stream-service, comment-service and user-service
+// in-process for end-to-end tests. The services are assembled by their own
+// app packages and talk to each other over in-memory bufconn listeners, with
+// fakes standing in for the users database and Clerk, and streamdb-memory
+// for StreamDb.
+package harness
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	commentapp "github.com/Josy-coder/comment-service/app"
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	userapp "github.com/Josy-coder/user-service/app"
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/auth"
+	streamapp "github.com/clementus360/stream-service/app"
+	streamconfig "github.com/clementus360/stream-service/config"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// SigningKey is the service token key shared by every service
+const SigningKey = "integration-test-signing-key-0123456789"
+
+// PlaybackSigningKey is the key stream-service signs playback tokens with
+const PlaybackSigningKey = "integration-test-playback-key-0123456789"
+
+// RestreamEncryptionKey is the key stream-service encrypts the stream keys of
+// restream destinations with
+const RestreamEncryptionKey = "integration-test-restream-key-0123456789"
+
+// Names of the in-memory listeners, used as the host of each address
+const (
+	databaseService       = "database-service"
+	streamDatabaseService = "stream-database-service"
+	streamService         = "stream-service"
+	commentService        = "comment-service"
+	userService           = "user-service"
+)
+
+// Harness is a running set of services. Tests call them through the clients,
+// which act as an API gateway holding the shared service key.
+type Harness struct {
+	StreamDB *StreamDB
+	Clerk    *FakeClerk
+
+	Users    userpb.UserServiceClient
+	Comments commentpb.CommentServiceClient
+	Streams  streampb.StreamServiceClient
+
+	// StreamsHTTP serves the REST API of stream-service
+	StreamsHTTP http.Handler
+	// CommentsHTTP serves the chat of comment-service
+	CommentsHTTP http.Handler
+
+	// mu guards listeners, which grows when replicas are started
+	mu        sync.RWMutex
+	listeners map[string]*bufconn.Listener
+}
+
+// Start boots the fakes and the three services. Everything is stopped when
+// the test ends. Start sets environment variables read by the configuration
+// loaders, so tests using it cannot run in parallel.
+func Start(t *testing.T) *Harness {
+	t.Helper()
+
+	h := &Harness{
+		StreamDB:  NewStreamDB(),
+		Clerk:     NewFakeClerk(t),
+		listeners: make(map[string]*bufconn.Listener),
+	}
+
+	// Every listener of the services exists before the first call is made
+	for _, name := range []string{databaseService, streamDatabaseService, streamService, commentService, userService} {
+		h.listeners[name] = bufconn.Listen(1 << 20)
+	}
+
+	dbServer := grpc.NewServer()
+	h.StreamDB.RegisterDatabase(dbServer)
+	h.serve(t, databaseService, dbServer)
+
+	streamDBServer := grpc.NewServer()
+	h.StreamDB.Register(streamDBServer)
+	h.serve(t, streamDatabaseService, streamDBServer)
+
+	// Settings without a command-line flag are read from the environment
+	t.Setenv("AUTH_SIGNING_KEY", SigningKey)
+	t.Setenv("PLAYBACK_SIGNING_KEY", PlaybackSigningKey)
+	t.Setenv("RESTREAM_ENCRYPTION_KEY", RestreamEncryptionKey)
+	t.Setenv("RESTREAM_RETRY_BACKOFF", "100ms")
+	t.Setenv("RESTREAM_MAX_RETRY_BACKOFF", "1s")
+	// the RTMP sinks listen on the loopback interface; tests of the refusal
+	// of private hosts set this to false before starting the harness
+	if _, ok := os.LookupEnv("RESTREAM_ALLOW_PRIVATE_HOSTS"); !ok {
+		t.Setenv("RESTREAM_ALLOW_PRIVATE_HOSTS", "true")
+	}
+	// the trash is swept often so that tests see backdated streams purged
+	t.Setenv("PURGE_INTERVAL", "100ms")
+
+	// stream-service restreams by running the test binary, see RunRelay
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatalf("failed to find the test binary: %v", err)
+	}
+	t.Setenv("RESTREAM_FFMPEG_PATH", executable)
+	t.Setenv(relayEnv, "1")
+	t.Setenv("CLERK_SECRET_KEY", "sk_test_integration")
+	t.Setenv("CHAT_ENABLED", "true")
+	t.Setenv("CLERK_API_URL", h.Clerk.URL())
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	streamCfg, err := streamconfig.LoadConfig([]string{
+		"--db-address", address(streamDatabaseService),
+	})
+	if err != nil {
+		t.Fatalf("invalid stream-service configuration: %v", err)
+	}
+	streams, err := streamapp.New(ctx, streamCfg,
+		streamapp.WithLogger(Logger(streamService)),
+		streamapp.WithDialOptions(h.dialOption()),
+	)
+	if err != nil {
+		t.Fatalf("failed to start stream-service: %v", err)
+	}
+	t.Cleanup(streams.Close)
+	streams.Start(ctx)
+	h.serve(t, streamService, streams.GRPCServer())
+	h.StreamsHTTP = streams.Handler()
+
+	commentCfg, err := commentapp.LoadConfig([]string{
+		"--db-service-url", address(databaseService),
+		"--audit-db-address", address(streamDatabaseService),
+		"--user-service-url", address(userService),
+		"--stream-service-url", address(streamService),
+	})
+	if err != nil {
+		t.Fatalf("invalid comment-service configuration: %v", err)
+	}
+	comments, err := commentapp.New(ctx, commentCfg,
+		commentapp.WithLogger(Logger(commentService)),
+		commentapp.WithDialOptions(h.dialOption()),
+	)
+	if err != nil {
+		t.Fatalf("failed to start comment-service: %v", err)
+	}
+	t.Cleanup(func() { comments.Close() })
+	comments.Start(ctx)
+	h.serve(t, commentService, comments.GRPCServer())
+	h.CommentsHTTP = comments.Handler()
+
+	userCfg, err := userapp.LoadConfig([]string{
+		"--db-service-url", address(databaseService),
+		"--audit-db-address", address(streamDatabaseService),
+		"--comment-service-url", address(commentService),
+		"--stream-service-url", address(streamService),
+	})
+	if err != nil {
+		t.Fatalf("invalid user-service configuration: %v", err)
+	}
+	users, err := userapp.New(ctx, userCfg,
+		userapp.WithLogger(Logger(userService)),
+		userapp.WithDialOptions(h.dialOption()),
+	)
+	if err != nil {
+		t.Fatalf("failed to start user-service: %v", err)
+	}
+	t.Cleanup(func() { users.Close() })
+	users.Start(ctx)
+	h.serve(t, userService, users.GRPCServer())
+
+	// cleanups run in reverse, so the background loops stop before the
+	// services are closed
+	t.Cleanup(cancel)
+
+	h.Users = userpb.NewUserServiceClient(h.gatewayConn(t, userService))
+	h.Comments = commentpb.NewCommentServiceClient(h.gatewayConn(t, commentService))
+	h.Streams = streampb.NewStreamServiceClient(h.gatewayConn(t, streamService))
+
+	return h
+}
+
+// AsUser returns a context for calls made on behalf of the end user id, as
+// the gateway does after authenticating them
+func AsUser(ctx context.Context, id int32, roles ...string) context.Context {
+	return auth.WithUser(ctx, auth.User{ID: int64(id), Roles: roles})
+}
+
+// StreamsREST serves req with the REST API of stream-service. Like the
+// gateway, it signs req for the user attached to its context with AsUser.
+func (h *Harness) StreamsREST(t *testing.T, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+
+	resp := httptest.NewRecorder()
+	h.ServeStreamsREST(t, resp, req)
+	return resp
+}
+
+// ServeStreamsREST is StreamsREST writing the response to w
+func (h *Harness) ServeStreamsREST(t *testing.T, w http.ResponseWriter, req *http.Request) {
+	t.Helper()
+
+	if err := gatewayAuthenticator().SignRequest(req, streamService); err != nil {
+		t.Fatalf("failed to sign %s %s: %v", req.Method, req.URL, err)
+	}
+	h.StreamsHTTP.ServeHTTP(w, req)
+}
+
+// WithSessionToken returns a context for calls that present a Clerk session
+// token instead of a service token, as a client calling user-service
+// directly does
+func WithSessionToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// Context returns a context that is cancelled when the test ends or after a
+// timeout, so that a hung call fails the test instead of blocking it
+func Context(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+// serve runs server on the listener name until the test ends
+func (h *Harness) serve(t *testing.T, name string, server *grpc.Server) {
+	h.mu.RLock()
+	lis := h.listeners[name]
+	h.mu.RUnlock()
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+}
+
+// Addresses of the services, for clients built outside the harness that dial
+// them with DialOption
+var (
+	StreamServiceAddress  = address(streamService)
+	CommentServiceAddress = address(commentService)
+	UserServiceAddress    = address(userService)
+)
+
+// DialOption connects clients built outside the harness, such as
+// platformctl, to the services through the listeners
+func (h *Harness) DialOption() grpc.DialOption {
+	return h.dialOption()
+}
+
+// dialOption connects the services to each other through the listeners
+func (h *Harness) dialOption() grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		h.mu.RLock()
+		lis := h.listeners[addr]
+		h.mu.RUnlock()
+		return lis.DialContext(ctx)
+	})
+}
+
+// gatewayConn connects to a service like the API gateway: service tokens are
+// minted for the user attached with AsUser, and calls carrying a session
+// token are sent without one
+func (h *Harness) gatewayConn(t *testing.T, name string, opts ...grpc.DialOption) *grpc.ClientConn {
+	return h.replicaConn(t, name, name, opts...)
+}
+
+// replicaConn connects like gatewayConn to the replica of service served on
+// the listener name
+func (h *Harness) replicaConn(t *testing.T, service, name string, opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+
+	gateway := gatewayAuthenticator()
+	signUnary := gateway.UnaryClientInterceptor(service)
+	signStream := gateway.StreamClientInterceptor(service)
+	conn, err := grpc.NewClient(address(name), append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		h.dialOption(),
+		grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if hasSessionToken(ctx) {
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}
+			return signUnary(ctx, method, req, reply, cc, invoker, opts...)
+		}),
+		grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			if hasSessionToken(ctx) {
+				return streamer(ctx, desc, cc, method, opts...)
+			}
+			return signStream(ctx, desc, cc, method, streamer, opts...)
+		}),
+	}, opts...)...)
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", name, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// ServiceConn connects to service as the service caller does when calling on
+// its own, without an end user
+func (h *Harness) ServiceConn(t *testing.T, caller, service string) *grpc.ClientConn {
+	t.Helper()
+
+	authenticator := auth.New(caller, auth.Config{
+		SigningKey: SigningKey,
+		TokenTTL:   time.Minute,
+	})
+	conn, err := grpc.NewClient(address(service),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		h.dialOption(),
+		grpc.WithUnaryInterceptor(authenticator.UnaryClientInterceptor(service)),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", service, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// gatewayAuthenticator signs calls like the API gateway, which is not a
+// trusted caller
+func gatewayAuthenticator() *auth.Authenticator {
+	return auth.New("gateway", auth.Config{
+		SigningKey: SigningKey,
+		TokenTTL:   time.Minute,
+	})
+}
+
+// hasSessionToken reports whether the call presents a Clerk session token
+func hasSessionToken(ctx context.Context) bool {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	return ok && len(md.Get("authorization")) > 0
+}
+
+// address targets a listener without name resolution
+func address(name string) string {
+	return "passthrough:///" + name
+}
+
+// Logger returns the logger of service, which discards everything unless
+// INTEGRATION_LOG is set
+func Logger(service string) *slog.Logger {
+	if os.Getenv("INTEGRATION_LOG") == "" {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})).With("service", service)
+}
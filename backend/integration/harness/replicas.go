@@ -0,0 +1,59 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	commentapp "github.com/Josy-coder/comment-service/app"
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// flowControlWindow is the fixed flow control window of the clients of
+// replicas. gRPC otherwise grows it, buffering megabytes of a stream the
+// client does not read before the service notices.
+const flowControlWindow = 64 << 10
+
+// CommentReplica starts another comment-service next to the one of the
+// harness, sharing its database and the other services, and returns a
+// client calling it. args are added to the flags, for instance to share
+// comment events through a Redis broker.
+func (h *Harness) CommentReplica(t *testing.T, args ...string) commentpb.CommentServiceClient {
+	t.Helper()
+
+	h.mu.Lock()
+	name := fmt.Sprintf("%s-%d", commentService, len(h.listeners))
+	h.listeners[name] = bufconn.Listen(1 << 20)
+	h.mu.Unlock()
+
+	cfg, err := commentapp.LoadConfig(append([]string{
+		"--db-service-url", address(databaseService),
+		"--audit-db-address", address(streamDatabaseService),
+		"--user-service-url", address(userService),
+		"--stream-service-url", address(streamService),
+	}, args...))
+	if err != nil {
+		t.Fatalf("invalid comment-service configuration: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	replica, err := commentapp.New(ctx, cfg,
+		commentapp.WithLogger(Logger(name)),
+		commentapp.WithDialOptions(h.dialOption()),
+	)
+	if err != nil {
+		cancel()
+		t.Fatalf("failed to start %s: %v", name, err)
+	}
+	t.Cleanup(func() { replica.Close() })
+	replica.Start(ctx)
+	h.serve(t, name, replica.GRPCServer())
+	t.Cleanup(cancel)
+
+	return commentpb.NewCommentServiceClient(h.replicaConn(t, commentService, name,
+		grpc.WithInitialWindowSize(flowControlWindow),
+		grpc.WithInitialConnWindowSize(flowControlWindow),
+	))
+}
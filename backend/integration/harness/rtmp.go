@@ -0,0 +1,167 @@
+package harness
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// RTMP handshake sizes: a version byte followed by 1536 byte chunks
+const (
+	rtmpVersion       = 3
+	rtmpHandshakeSize = 1536
+)
+
+// relayEnv makes the test binary act as the restream relay of stream-service
+const relayEnv = "INTEGRATION_RESTREAM_RELAY"
+
+// RTMPSink is a local RTMP server standing in for the ingest of another
+// platform. It completes the RTMP handshake with publishers and discards
+// what they send.
+type RTMPSink struct {
+	listener net.Listener
+
+	mu     sync.Mutex
+	active int
+	total  int
+}
+
+// NewRTMPSink listens on a local port until the test ends
+func NewRTMPSink(t *testing.T) *RTMPSink {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen for RTMP: %v", err)
+	}
+	s := &RTMPSink{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+	go s.serve()
+	return s
+}
+
+// URL returns the address of an application of the sink, to which
+// publishers append their stream key
+func (s *RTMPSink) URL(app string) string {
+	return fmt.Sprintf("rtmp://%s/%s", s.listener.Addr(), app)
+}
+
+// Active returns the number of publishers connected
+func (s *RTMPSink) Active() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active
+}
+
+// Total returns the number of publishers that completed the handshake
+func (s *RTMPSink) Total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.total
+}
+
+func (s *RTMPSink) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *RTMPSink) handle(conn net.Conn) {
+	defer conn.Close()
+
+	// C0 and C1, answered with S0, S1 and S2 echoing C1, then C2
+	c0c1 := make([]byte, 1+rtmpHandshakeSize)
+	if _, err := io.ReadFull(conn, c0c1); err != nil || c0c1[0] != rtmpVersion {
+		return
+	}
+	s1 := make([]byte, rtmpHandshakeSize)
+	rand.Read(s1[8:])
+	if _, err := conn.Write(append(append([]byte{rtmpVersion}, s1...), c0c1[1:]...)); err != nil {
+		return
+	}
+	if _, err := io.ReadFull(conn, make([]byte, rtmpHandshakeSize)); err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.active++
+	s.total++
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+	}()
+
+	io.Copy(io.Discard, conn)
+}
+
+// RunRelay turns the test binary into a stand-in for ffmpeg when
+// stream-service starts it to restream. TestMain calls it first. The stand-in
+// publishes to the target, the last argument, without reading the source.
+func RunRelay() {
+	if os.Getenv(relayEnv) != "1" {
+		return
+	}
+	if err := relay(os.Args[len(os.Args)-1]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func relay(target string) error {
+	u, err := url.Parse(target)
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("tcp", u.Host, 2*time.Second)
+	if err != nil {
+		return fmt.Errorf("%s: Connection refused", target)
+	}
+	defer conn.Close()
+
+	c1 := make([]byte, rtmpHandshakeSize)
+	rand.Read(c1[8:])
+	if _, err := conn.Write(append([]byte{rtmpVersion}, c1...)); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(conn, make([]byte, 1+2*rtmpHandshakeSize)); err != nil {
+		return fmt.Errorf("%s: handshake failed: %v", target, err)
+	}
+	if _, err := conn.Write(c1); err != nil {
+		return err
+	}
+
+	// ffmpeg reports progress on stdout with -progress pipe:1
+	fmt.Println("progress=continue")
+	for {
+		if _, err := conn.Write(make([]byte, 512)); err != nil {
+			return errors.New("av_interleaved_write_frame(): Broken pipe")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// RefusedRTMPURL returns the address of a local port nothing listens on
+func RefusedRTMPURL(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+	return "rtmp://" + addr + "/live"
+}
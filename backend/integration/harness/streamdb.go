@@ -0,0 +1,190 @@
+package harness
+
+import (
+	"slices"
+	"time"
+
+	dbpb "github.com/Josy-coder/db-service/proto/db/v1"
+	"github.com/clementus360/streamdb-memory/server"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc"
+)
+
+// StreamDB is the in-memory stand-in of StreamDb from streamdb-memory,
+// holding the streams read by stream-service. It also serves the database
+// service of comment-service and user-service on the same users, which
+// StreamDb checks the owners of streams against.
+type StreamDB struct {
+	store *store.Store
+}
+
+// NewStreamDB returns an empty stream database
+func NewStreamDB() *StreamDB {
+	// without a snapshot the store cannot fail to load
+	st, _ := store.New("")
+	return &StreamDB{store: st}
+}
+
+// Register serves the services of StreamDb on server
+func (db *StreamDB) Register(s *grpc.Server) {
+	server.New(db.store).Register(s)
+}
+
+// RegisterDatabase serves the database service of comment-service and
+// user-service on server
+func (db *StreamDB) RegisterDatabase(s *grpc.Server) {
+	dbpb.RegisterDatabaseServiceServer(s, server.New(db.store).Database())
+}
+
+// HasUser reports whether a user exists and was not deleted
+func (db *StreamDB) HasUser(id int32) bool {
+	var found bool
+	db.store.Read(func(d *store.Data) error {
+		user := d.User(id)
+		found = user != nil && !user.Deleted()
+		return nil
+	})
+	return found
+}
+
+// Streams returns the streams that have not been deleted, in creation order
+func (db *StreamDB) Streams() []store.Stream {
+	var streams []store.Stream
+	db.store.Read(func(d *store.Data) error {
+		for _, s := range d.Streams {
+			if s != nil && !s.Deleted() {
+				streams = append(streams, *s)
+			}
+		}
+		return nil
+	})
+	return streams
+}
+
+// Comments returns the comments that have not been deleted, in creation
+// order
+func (db *StreamDB) Comments() []store.Comment {
+	var comments []store.Comment
+	db.store.Read(func(d *store.Data) error {
+		for _, comment := range d.Comments {
+			if comment != nil && !comment.Deleted() {
+				comments = append(comments, *comment)
+			}
+		}
+		return nil
+	})
+	return comments
+}
+
+// Collaborators returns the grants that have not been revoked
+func (db *StreamDB) Collaborators() []store.Collaborator {
+	var collaborators []store.Collaborator
+	db.store.Read(func(d *store.Data) error {
+		for _, c := range d.Collaborators {
+			if c != nil {
+				collaborators = append(collaborators, *c)
+			}
+		}
+		return nil
+	})
+	return collaborators
+}
+
+// RestreamDestinations returns the restream destinations that have not
+// been deleted
+func (db *StreamDB) RestreamDestinations() []store.RestreamDestination {
+	var destinations []store.RestreamDestination
+	db.store.Read(func(d *store.Data) error {
+		for _, destination := range d.Destinations {
+			if destination != nil {
+				destinations = append(destinations, *destination)
+			}
+		}
+		return nil
+	})
+	return destinations
+}
+
+// TelemetrySamples returns the telemetry samples that have not expired, in
+// the order they were stored
+func (db *StreamDB) TelemetrySamples() []store.TelemetrySample {
+	var samples []store.TelemetrySample
+	db.store.Read(func(d *store.Data) error {
+		for _, sample := range d.TelemetrySamples {
+			if sample != nil {
+				samples = append(samples, *sample)
+			}
+		}
+		return nil
+	})
+	return samples
+}
+
+// RenditionLadders returns the ladders chosen for streams
+func (db *StreamDB) RenditionLadders() []store.RenditionLadder {
+	var ladders []store.RenditionLadder
+	db.store.Read(func(d *store.Data) error {
+		for _, ladder := range d.Ladders {
+			if ladder != nil {
+				ladders = append(ladders, *ladder)
+			}
+		}
+		return nil
+	})
+	return ladders
+}
+
+// Clips returns the clips that have not been deleted, in creation order
+func (db *StreamDB) Clips() []store.Clip {
+	var clips []store.Clip
+	db.store.Read(func(d *store.Data) error {
+		for _, clip := range d.Clips {
+			if clip != nil {
+				clips = append(clips, *clip)
+			}
+		}
+		return nil
+	})
+	return clips
+}
+
+// ReverseComments reverses the order comments are stored, and therefore
+// listed, in. The database service does not promise any order.
+func (db *StreamDB) ReverseComments() {
+	db.store.Write(func(d *store.Data) error {
+		slices.Reverse(d.Comments)
+		return nil
+	})
+}
+
+// HasStream reports whether a stream exists, deleted or not
+func (db *StreamDB) HasStream(id int32) bool {
+	var found bool
+	db.store.Read(func(d *store.Data) error {
+		found = d.Stream(id) != nil
+		return nil
+	})
+	return found
+}
+
+// Backdate moves the deletion of a stream in the trash back by age
+func (db *StreamDB) Backdate(id int32, age time.Duration) {
+	db.store.Write(func(d *store.Data) error {
+		if s := d.Stream(id); s != nil && s.Deleted() {
+			deletedAt := s.DeletedAt.Add(-age)
+			s.DeletedAt = &deletedAt
+		}
+		return nil
+	})
+}
+
+// Reschedule moves the schedule of a stream, which can only be created in
+// the future, to start and end
+func (db *StreamDB) Reschedule(id int32, start, end time.Time) {
+	db.store.Write(func(d *store.Data) error {
+		if s := d.Stream(id); s != nil {
+			s.StartTime, s.EndTime = start.UTC().Truncate(time.Second), end.UTC().Truncate(time.Second)
+		}
+		return nil
+	})
+}
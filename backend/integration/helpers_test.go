@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestMain(m *testing.M) {
+	// stream-service runs the test binary as its restream relay
+	harness.RunRelay()
+
+	// The services log a few warnings through the default logger
+	slog.SetDefault(harness.Logger("integration"))
+	os.Exit(m.Run())
+}
+
+// timeFormat is the layout of the times of streams
+const timeFormat = "2006-01-02T15:04:05Z"
+
+// createUser registers a user through user-service
+func createUser(t *testing.T, h *harness.Harness, name string) *userpb.User {
+	t.Helper()
+
+	resp, err := h.Users.CreateUser(harness.Context(t), &userpb.CreateUserRequest{
+		ClerkId:   "user_" + name,
+		Email:     name + "@example.com",
+		Username:  name,
+		FirstName: name,
+		LastName:  "Tester",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser(%s): %v", name, err)
+	}
+	return resp.User
+}
+
+// createStream creates a stream owned by userID through stream-service
+func createStream(t *testing.T, h *harness.Harness, userID int32, status string) *streampb.StreamResponse {
+	t.Helper()
+
+	ctx := harness.AsUser(harness.Context(t), userID)
+	stream, err := h.Streams.CreateStream(ctx, &streampb.CreateStreamRequest{
+		Title:      fmt.Sprintf("Stream of user %d", userID),
+		StartTime:  time.Now().UTC().Add(time.Hour).Format(timeFormat),
+		EndTime:    time.Now().UTC().Add(2 * time.Hour).Format(timeFormat),
+		Resolution: "1920x1080",
+		Bitrate:    6000,
+		Framerate:  30,
+		Codec:      "h264",
+		Protocol:   "rtmp",
+		Status:     status,
+		UserId:     int64(userID),
+	})
+	if err != nil {
+		t.Fatalf("CreateStream(user %d): %v", userID, err)
+	}
+	return stream
+}
+
+// createComment posts a comment as userID through comment-service
+func createComment(t *testing.T, h *harness.Harness, userID, streamID int32, content string) *commentpb.Comment {
+	t.Helper()
+
+	ctx := harness.AsUser(harness.Context(t), userID)
+	resp, err := h.Comments.CreateComment(ctx, &commentpb.CreateCommentRequest{
+		Content:  content,
+		UserId:   userID,
+		StreamId: streamID,
+	})
+	if err != nil {
+		t.Fatalf("CreateComment(user %d, stream %d): %v", userID, streamID, err)
+	}
+	return resp.Comment
+}
+
+// requireCode fails the test unless err carries the status code want
+func requireCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != want {
+		t.Fatalf("got status %s (%v), want %s", got, err, want)
+	}
+}
+
+// ptr returns a pointer to v for optional proto fields
+func ptr[T any](v T) *T {
+	return &v
+}
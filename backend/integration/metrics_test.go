@@ -0,0 +1,69 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/clementus360/integration/harness"
+)
+
+// TestStreamServiceMetrics checks that the REST requests of stream-service
+// are counted under the route they matched
+func TestStreamServiceMetrics(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+	ctx := harness.AsUser(harness.Context(t), alice.Id)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/api/stream", strings.NewReader(fmt.Sprintf(`{"id":%d}`, stream.Id))).WithContext(ctx)
+	if rec := h.StreamsREST(t, req); rec.Code != http.StatusOK {
+		t.Fatalf("GET /v1/api/stream answered %d: %s", rec.Code, rec.Body)
+	}
+
+	rec := httptest.NewRecorder()
+	h.StreamsHTTP.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /metrics answered %d: %s", rec.Code, rec.Body)
+	}
+	if want := `stream_service_http_requests_total{method="GET",route="GET /v1/api/stream",status="200"} 1`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("metrics do not count the request under its route, want %s in:\n%s", want, grepLines(rec.Body.String(), "http_requests_total"))
+	}
+}
+
+func TestAPIGatewayMetrics(t *testing.T) {
+	h := harness.Start(t)
+	gateway := h.APIGateway(t)
+
+	alice := createUser(t, h, "alice")
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+
+	rec := httptest.NewRecorder()
+	gateway.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/streams/%d", stream.Id), nil).WithContext(harness.Context(t)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /v1/streams/{id} answered %d: %s", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	gateway.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /metrics answered %d: %s", rec.Code, rec.Body)
+	}
+	if want := `api_gateway_http_requests_total{method="GET",route="GET /v1/streams/{id}",status="200"} 1`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("metrics do not count the request under its route, want %s in:\n%s", want, grepLines(rec.Body.String(), "http_requests_total"))
+	}
+}
+
+// grepLines returns the lines of text that contain substr
+func grepLines(text, substr string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.Contains(line, substr) {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
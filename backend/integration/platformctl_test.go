@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clementus360/platformctl/cli"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestPlatformctl(t *testing.T) {
+	h := harness.Start(t)
+	ctl := newPlatformctl(t, h)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	live := createStream(t, h, alice.Id, "ONLINE")
+	// the broadcast started before it is ended
+	h.StreamDB.Reschedule(live.Id, time.Now().Add(-10*time.Minute), time.Now().Add(time.Hour))
+	scheduled := createStream(t, h, alice.Id, "SCHEDULED")
+	createStream(t, h, bob.Id, "SCHEDULED")
+	comment := createComment(t, h, bob.Id, live.Id, "first!")
+
+	out := ctl.run(t, 0, "", "streams", "list", "--user", fmt.Sprint(alice.Id), "-o", "json")
+	var list struct {
+		Streams []struct {
+			ID        int32  `json:"id"`
+			StreamKey string `json:"stream_key"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		t.Fatalf("streams list printed invalid JSON: %v\n%s", err, out)
+	}
+	if len(list.Streams) != 2 || list.Streams[0].StreamKey != "" {
+		t.Errorf("streams list of alice printed %+v, want her 2 streams without keys", list.Streams)
+	}
+	out = ctl.run(t, 0, "", "streams", "list", "--status", "online")
+	if !strings.Contains(out, live.Title) || strings.Count(out, "\n") != 3 {
+		t.Errorf("streams list of live streams printed:\n%s", out)
+	}
+	out = ctl.run(t, 0, "", "streams", "get", fmt.Sprint(live.Id))
+	if !strings.Contains(out, "ONLINE") || strings.Contains(out, live.StreamKey) {
+		t.Errorf("streams get printed:\n%s", out)
+	}
+	if out := ctl.run(t, 1, "", "streams", "get", "999"); !strings.Contains(ctl.stderr.String(), codes.NotFound.String()) {
+		t.Errorf("streams get of a missing stream printed %q and %q", out, ctl.stderr.String())
+	}
+	ctl.run(t, 2, "", "streams", "get", "abc")
+
+	// Destructive commands ask first
+	ctl.run(t, 1, "n\n", "streams", "end", fmt.Sprint(live.Id))
+	ctl.run(t, 1, "", "streams", "end", fmt.Sprint(live.Id))
+	if !strings.Contains(ctl.stderr.String(), "--yes") {
+		t.Errorf("streams end without a terminal printed %q, want a hint at --yes", ctl.stderr.String())
+	}
+	if stream := getStream(t, h, live.Id); stream.Status != "ONLINE" {
+		t.Fatalf("declined streams end left the stream %s", stream.Status)
+	}
+	ctl.run(t, 0, "y\n", "streams", "end", fmt.Sprint(live.Id))
+	if stream := getStream(t, h, live.Id); stream.Status != "COMPLETE" || stream.EndTime == "" {
+		t.Errorf("ended stream is %s with end time %q", stream.Status, stream.EndTime)
+	}
+	// Deleted streams go to the trash, which the prompt says
+	ctl.run(t, 1, "n\n", "streams", "delete", fmt.Sprint(scheduled.Id))
+	if prompt := ctl.stderr.String(); !strings.Contains(prompt, "trash") || strings.Contains(prompt, "cannot be undone") {
+		t.Errorf("streams delete asked %q, want it to mention the trash", prompt)
+	}
+	ctl.run(t, 0, "", "streams", "delete", fmt.Sprint(scheduled.Id), "--yes")
+	_, err := h.Streams.GetStream(harness.Context(t), &streampb.GetStreamRequest{Id: scheduled.Id})
+	requireCode(t, err, codes.NotFound)
+
+	out = ctl.run(t, 0, "", "comments", "list", "--stream", fmt.Sprint(live.Id))
+	if !strings.Contains(out, "first!") {
+		t.Errorf("comments list printed:\n%s", out)
+	}
+	ctl.run(t, 0, "", "-y", "comments", "delete", fmt.Sprint(comment.Id))
+	if out := ctl.run(t, 0, "", "comments", "list", "--stream", fmt.Sprint(live.Id)); strings.Contains(out, "first!") {
+		t.Errorf("deleted comment is still listed:\n%s", out)
+	}
+
+	out = ctl.run(t, 0, "", "users", "get", "--clerk-id", "user_bob")
+	if !strings.Contains(out, "bob@example.com") {
+		t.Errorf("users get printed:\n%s", out)
+	}
+	h.Clerk.AddUser(harness.ClerkUser{ID: "user_carol", Email: "carol@example.com", Username: "carol"})
+	out = ctl.run(t, 0, "", "users", "sync", "user_carol", "-o", "json")
+	var carol struct {
+		ID       int32  `json:"id"`
+		Username string `json:"username"`
+	}
+	if err := json.Unmarshal([]byte(out), &carol); err != nil || carol.Username != "carol" {
+		t.Fatalf("users sync printed %q (%v), want carol", out, err)
+	}
+	ctl.run(t, 0, "yes\n", "users", "delete", fmt.Sprint(bob.Id))
+	if out := ctl.run(t, 0, "", "streams", "list", "--user", fmt.Sprint(bob.Id)); strings.Count(out, "\n") != 2 {
+		t.Errorf("streams of the deleted user are still listed:\n%s", out)
+	}
+
+	ctl.run(t, 1, "", "streams", "list", "--profile", "production")
+	out = ctl.run(t, 0, "", "profiles", "list")
+	if !strings.Contains(out, "* ") || !strings.Contains(out, "integration") {
+		t.Errorf("profiles list printed:\n%s", out)
+	}
+}
+
+// platformctl runs the command-line tool against the services of a harness
+type platformctl struct {
+	h      *harness.Harness
+	config string
+	stderr bytes.Buffer
+}
+
+func newPlatformctl(t *testing.T, h *harness.Harness) *platformctl {
+	t.Helper()
+
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(config, []byte(fmt.Sprintf(`current: integration
+profiles:
+  integration:
+    stream_service: %s
+    comment_service: %s
+    user_service: %s
+    signing_key: %s
+  production:
+    stream_service: streams.invalid:443
+`, harness.StreamServiceAddress, harness.CommentServiceAddress, harness.UserServiceAddress, harness.SigningKey)), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write the platformctl configuration: %v", err)
+	}
+	return &platformctl{h: h, config: config}
+}
+
+// run runs platformctl with args, answering prompts with stdin, and returns
+// what it printed on stdout. The test fails unless it exits with code.
+func (p *platformctl) run(t *testing.T, code int, stdin string, args ...string) string {
+	t.Helper()
+
+	var stdout bytes.Buffer
+	p.stderr.Reset()
+	args = append([]string{"--config", p.config, "--timeout", "10s"}, args...)
+	if got := cli.Main(harness.Context(t), args, strings.NewReader(stdin), &stdout, &p.stderr, p.h.DialOption()); got != code {
+		t.Fatalf("platformctl %s exited with %d, want %d\n%s%s", strings.Join(args[4:], " "), got, code, stdout.String(), p.stderr.String())
+	}
+	return stdout.String()
+}
+
+// getStream fetches a stream as a trusted service
+func getStream(t *testing.T, h *harness.Harness, id int32) *streampb.StreamResponse {
+	t.Helper()
+
+	stream, err := h.Streams.GetStream(harness.Context(t), &streampb.GetStreamRequest{Id: id})
+	if err != nil {
+		t.Fatalf("GetStream(%d): %v", id, err)
+	}
+	return stream
+}
@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestRenditionLadders(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	viewer := harness.AsUser(harness.Context(t), bob.Id)
+
+	presets, err := h.Streams.ListRenditionPresets(harness.Context(t), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("ListRenditionPresets: %v", err)
+	}
+	if presets.DefaultPreset != "standard" || len(presets.Presets) != 3 {
+		t.Errorf("got %d presets with default %q, want the 3 built-in ones with standard", len(presets.Presets), presets.DefaultPreset)
+	}
+
+	// createStream sends 1920x1080 at 30 fps and 6000 kbps, which feeds
+	// the whole standard ladder
+	stream := createStream(t, h, alice.Id, "ONLINE")
+	if stream.RenditionPreset != "standard" {
+		t.Errorf("new stream uses preset %q, want standard", stream.RenditionPreset)
+	}
+	if got := renditionNames(stream.Renditions); got != "1080p30 720p30 480p30 360p30" {
+		t.Errorf("standard ladder is %s", got)
+	}
+	if r := stream.Renditions[2]; r.Width != 854 || r.Height != 480 || r.BitrateKbps != 1000 {
+		t.Errorf("480p30 rendition is %dx%d at %d kbps, want 854x480 at 1000 kbps", r.Width, r.Height, r.BitrateKbps)
+	}
+
+	_, err = h.Streams.SetStreamRenditions(viewer, &streampb.SetStreamRenditionsRequest{StreamId: stream.Id, Preset: "mobile"})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Streams.SetStreamRenditions(harness.Context(t), &streampb.SetStreamRenditionsRequest{StreamId: stream.Id, Preset: "mobile"})
+	requireCode(t, err, codes.Unauthenticated)
+	rec := trashRequest(t, h, harness.Context(t), http.MethodPut, "/v1/api/stream/renditions", fmt.Sprintf(`{"stream_id": %d, "preset": "mobile"}`, stream.Id))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous REST change returned %d, want 401", rec.Code)
+	}
+	_, err = h.Streams.SetStreamRenditions(owner, &streampb.SetStreamRenditionsRequest{StreamId: stream.Id, Preset: "cinema"})
+	requireCode(t, err, codes.InvalidArgument)
+
+	// Presets adapt to the source: its 60 fps renditions are left out
+	updated, err := h.Streams.SetStreamRenditions(owner, &streampb.SetStreamRenditionsRequest{StreamId: stream.Id, Preset: "high-motion"})
+	if err != nil {
+		t.Fatalf("SetStreamRenditions(high-motion): %v", err)
+	}
+	if got := renditionNames(updated.Renditions); updated.RenditionPreset != "high-motion" || got != "720p30 480p30" {
+		t.Errorf("high-motion ladder of a 30 fps source is %s %s", updated.RenditionPreset, got)
+	}
+
+	// Custom ladders must stay within the source
+	_, err = h.Streams.SetStreamRenditions(owner, &streampb.SetStreamRenditionsRequest{StreamId: stream.Id, Renditions: []string{"1080p60", "720p30"}})
+	requireCode(t, err, codes.FailedPrecondition)
+	_, err = h.Streams.SetStreamRenditions(owner, &streampb.SetStreamRenditionsRequest{StreamId: stream.Id, Renditions: []string{"720x480"}})
+	requireCode(t, err, codes.InvalidArgument)
+	if _, err := h.Streams.SetStreamRenditions(owner, &streampb.SetStreamRenditionsRequest{StreamId: stream.Id, Renditions: []string{"480p", "720p30@2000"}}); err != nil {
+		t.Fatalf("SetStreamRenditions(custom): %v", err)
+	}
+	got, err := h.Streams.GetStream(viewer, &streampb.GetStreamRequest{Id: stream.Id})
+	if err != nil {
+		t.Fatalf("GetStream: %v", err)
+	}
+	if got.RenditionPreset != "custom" || renditionNames(got.Renditions) != "720p30 480p30" || got.Renditions[0].BitrateKbps != 2000 {
+		t.Errorf("custom ladder is %s %v", got.RenditionPreset, got.Renditions)
+	}
+
+	// The ladder is kept by StreamDb, not by the stream-service instance
+	if ladders := h.StreamDB.RenditionLadders(); len(ladders) != 1 || ladders[0].StreamID != stream.Id || ladders[0].Preset != "custom" {
+		t.Errorf("StreamDb holds the ladders %+v, want the custom one of stream %d", ladders, stream.Id)
+	}
+
+	// The master playlist lists each variant for holders of a playback token
+	_, err = h.Streams.GetMasterPlaylist(viewer, &streampb.GetMasterPlaylistRequest{StreamId: stream.Id})
+	requireCode(t, err, codes.Unauthenticated)
+	token, err := h.Streams.IssuePlaybackToken(viewer, &streampb.IssuePlaybackTokenRequest{StreamId: stream.Id})
+	if err != nil {
+		t.Fatalf("IssuePlaybackToken: %v", err)
+	}
+	playlist, err := h.Streams.GetMasterPlaylist(viewer, &streampb.GetMasterPlaylistRequest{StreamId: stream.Id, Token: token.Token})
+	if err != nil {
+		t.Fatalf("GetMasterPlaylist: %v", err)
+	}
+	for _, want := range []string{
+		"#EXTM3U\n",
+		"#EXT-X-STREAM-INF:BANDWIDTH=2340800,AVERAGE-BANDWIDTH=2128000,RESOLUTION=1280x720,FRAME-RATE=30.000\n",
+		fmt.Sprintf("/hls/%d/720p30/index.m3u8?token=%s\n", stream.Id, token.Token),
+		fmt.Sprintf("/hls/%d/480p30/index.m3u8?token=", stream.Id),
+	} {
+		if !strings.Contains(playlist.Content, want) {
+			t.Errorf("master playlist lacks %q:\n%s", want, playlist.Content)
+		}
+	}
+
+	// Neither a preset nor renditions returns to the default
+	reset, err := h.Streams.SetStreamRenditions(owner, &streampb.SetStreamRenditionsRequest{StreamId: stream.Id})
+	if err != nil {
+		t.Fatalf("SetStreamRenditions(reset): %v", err)
+	}
+	if reset.RenditionPreset != "standard" {
+		t.Errorf("reset stream uses preset %q, want standard", reset.RenditionPreset)
+	}
+	if ladders := h.StreamDB.RenditionLadders(); len(ladders) != 0 {
+		t.Errorf("StreamDb still holds the ladders %+v after a reset", ladders)
+	}
+}
+
+func renditionNames(renditions []*streampb.Rendition) string {
+	names := make([]string, len(renditions))
+	for i, r := range renditions {
+		names[i] = r.Name
+	}
+	return strings.Join(names, " ")
+}
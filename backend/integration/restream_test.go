@@ -0,0 +1,231 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestRestreaming(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	viewer := harness.AsUser(harness.Context(t), bob.Id)
+
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+	sink := harness.NewRTMPSink(t)
+
+	_, err := h.Streams.AddRestreamDestination(harness.Context(t), &streampb.AddRestreamDestinationRequest{StreamId: stream.Id, Url: sink.URL("live")})
+	requireCode(t, err, codes.Unauthenticated)
+	_, err = h.Streams.AddRestreamDestination(viewer, &streampb.AddRestreamDestinationRequest{StreamId: stream.Id, Url: sink.URL("live")})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Streams.AddRestreamDestination(owner, &streampb.AddRestreamDestinationRequest{StreamId: stream.Id, Url: "https://example.com/live"})
+	requireCode(t, err, codes.InvalidArgument)
+
+	// A destination of the stream, one of the channel nobody listens on and
+	// a paused one
+	twitch, err := h.Streams.AddRestreamDestination(owner, &streampb.AddRestreamDestinationRequest{
+		StreamId: stream.Id,
+		Name:     "Twitch",
+		Url:      sink.URL("app"),
+		Key:      "live_123456789_secret",
+	})
+	if err != nil {
+		t.Fatalf("AddRestreamDestination: %v", err)
+	}
+	if twitch.KeyHint != "…cret" || twitch.Status.State != "idle" || !twitch.Enabled {
+		t.Errorf("new destination has key hint %q, state %s and enabled %v", twitch.KeyHint, twitch.Status.State, twitch.Enabled)
+	}
+	// The database only holds the sealed stream key
+	if stored := h.StreamDB.RestreamDestinations(); len(stored) != 1 || stored[0].SealedKey == "" || strings.Contains(stored[0].SealedKey, "secret") || stored[0].KeyHint != "…cret" {
+		t.Errorf("database holds destinations %+v, want one with a sealed key", stored)
+	}
+	broken, err := h.Streams.AddRestreamDestination(owner, &streampb.AddRestreamDestinationRequest{
+		OwnerId: alice.Id,
+		Url:     harness.RefusedRTMPURL(t),
+		Key:     "channel-key-abcdefgh",
+	})
+	if err != nil {
+		t.Fatalf("AddRestreamDestination(channel): %v", err)
+	}
+	paused, err := h.Streams.AddRestreamDestination(owner, &streampb.AddRestreamDestinationRequest{
+		StreamId: stream.Id,
+		Url:      sink.URL("backup"),
+		Key:      "backup-key-0123456789",
+		Enabled:  ptr(false),
+	})
+	if err != nil {
+		t.Fatalf("AddRestreamDestination(paused): %v", err)
+	}
+
+	// Going live relays the stream to the enabled destinations
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: stream.Id, Status: "ONLINE"}); err != nil {
+		t.Fatalf("UpdateStream(ONLINE): %v", err)
+	}
+	states := waitForRestreams(t, h, owner, stream.Id, func(states map[int32]*streampb.RestreamStatus) bool {
+		return states[twitch.Id].State == "live" && states[broken.Id].State == "error" && sink.Active() == 1
+	})
+	if s := states[twitch.Id]; s.StreamId != stream.Id || s.Attempts != 1 {
+		t.Errorf("live destination forwards stream %d after %d attempts, want stream %d after 1", s.StreamId, s.Attempts, stream.Id)
+	}
+	if s := states[broken.Id]; !strings.Contains(s.Error, "Connection refused") || strings.Contains(s.Error, "channel-key-abcdefgh") {
+		t.Errorf("failed destination reports %q, want a refused connection without the stream key", s.Error)
+	}
+	if states[paused.Id].State != "idle" {
+		t.Errorf("paused destination is %s", states[paused.Id].State)
+	}
+
+	// Failed relays are retried
+	waitForRestreams(t, h, owner, stream.Id, func(states map[int32]*streampb.RestreamStatus) bool {
+		return states[broken.Id].Attempts > 1
+	})
+
+	_, err = h.Streams.UpdateRestreamDestination(viewer, &streampb.UpdateRestreamDestinationRequest{Id: paused.Id, Enabled: ptr(true)})
+	requireCode(t, err, codes.PermissionDenied)
+	if _, err := h.Streams.UpdateRestreamDestination(owner, &streampb.UpdateRestreamDestinationRequest{Id: paused.Id, Enabled: ptr(true)}); err != nil {
+		t.Fatalf("UpdateRestreamDestination(enable): %v", err)
+	}
+	waitForRestreams(t, h, owner, stream.Id, func(states map[int32]*streampb.RestreamStatus) bool {
+		return states[paused.Id].State == "live" && sink.Active() == 2
+	})
+
+	// Ending the stream disconnects every destination
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: stream.Id, Status: "COMPLETE"}); err != nil {
+		t.Fatalf("UpdateStream(COMPLETE): %v", err)
+	}
+	waitForRestreams(t, h, owner, stream.Id, func(states map[int32]*streampb.RestreamStatus) bool {
+		for _, s := range states {
+			if s.State != "idle" {
+				return false
+			}
+		}
+		return sink.Active() == 0
+	})
+
+	if _, err := h.Streams.RemoveRestreamDestination(owner, &streampb.RemoveRestreamDestinationRequest{Id: paused.Id}); err != nil {
+		t.Fatalf("RemoveRestreamDestination: %v", err)
+	}
+	_, err = h.Streams.RemoveRestreamDestination(owner, &streampb.RemoveRestreamDestinationRequest{Id: paused.Id})
+	requireCode(t, err, codes.NotFound)
+	list, err := h.Streams.ListRestreamDestinations(owner, &streampb.ListRestreamDestinationsRequest{OwnerId: alice.Id})
+	if err != nil {
+		t.Fatalf("ListRestreamDestinations: %v", err)
+	}
+	if len(list.Destinations) != 2 {
+		t.Errorf("channel has %d destinations, want 2", len(list.Destinations))
+	}
+	_, err = h.Streams.ListRestreamDestinations(viewer, &streampb.ListRestreamDestinationsRequest{OwnerId: alice.Id})
+	requireCode(t, err, codes.PermissionDenied)
+}
+
+func TestRestreamPrivateHosts(t *testing.T) {
+	t.Setenv("RESTREAM_ALLOW_PRIVATE_HOSTS", "false")
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	sink := harness.NewRTMPSink(t)
+
+	// Relays cannot be pointed at the network of stream-service
+	for _, url := range []string{sink.URL("app"), "rtmp://localhost/app", "rtmp://10.0.0.8/app", "rtmps://169.254.169.254/latest", "rtmp://[::1]:1935/app"} {
+		_, err := h.Streams.AddRestreamDestination(owner, &streampb.AddRestreamDestinationRequest{OwnerId: alice.Id, Url: url, Key: "live_123456789_secret"})
+		requireCode(t, err, codes.InvalidArgument)
+	}
+
+	// documentation addresses are public and resolve without DNS
+	destination, err := h.Streams.AddRestreamDestination(owner, &streampb.AddRestreamDestinationRequest{OwnerId: alice.Id, Url: "rtmp://203.0.113.10/app", Key: "live_123456789_secret"})
+	if err != nil {
+		t.Fatalf("AddRestreamDestination(public): %v", err)
+	}
+	_, err = h.Streams.UpdateRestreamDestination(owner, &streampb.UpdateRestreamDestinationRequest{Id: destination.Id, Url: ptr("rtmp://192.168.1.1/app")})
+	requireCode(t, err, codes.InvalidArgument)
+}
+
+func TestRestreamREST(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	stranger := harness.AsUser(harness.Context(t), bob.Id)
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+	sink := harness.NewRTMPSink(t)
+	destination := fmt.Sprintf(`{"stream_id": %d, "url": %q, "key": "live_123456789_secret"}`, stream.Id, sink.URL("app"))
+
+	if resp := restreamsRequest(t, h, harness.Context(t), http.MethodPost, destination); resp.Code != http.StatusUnauthorized {
+		t.Errorf("POST /v1/api/stream/restreams without a user answered %d, want 401", resp.Code)
+	}
+	if resp := restreamsRequest(t, h, stranger, http.MethodPost, destination); resp.Code != http.StatusForbidden {
+		t.Errorf("POST /v1/api/stream/restreams by another user answered %d, want 403", resp.Code)
+	}
+	resp := restreamsRequest(t, h, owner, http.MethodPost, destination)
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("POST /v1/api/stream/restreams by the owner answered %d: %s", resp.Code, resp.Body)
+	}
+	stored := h.StreamDB.RestreamDestinations()
+	if len(stored) != 1 {
+		t.Fatalf("database holds %d destinations, want 1", len(stored))
+	}
+	id := stored[0].ID
+
+	if resp := restreamsRequest(t, h, stranger, http.MethodPatch, fmt.Sprintf(`{"id": %d, "enabled": false}`, id)); resp.Code != http.StatusForbidden {
+		t.Errorf("PATCH /v1/api/stream/restreams by another user answered %d, want 403", resp.Code)
+	}
+	if resp := restreamsRequest(t, h, stranger, http.MethodDelete, fmt.Sprintf(`{"id": %d}`, id)); resp.Code != http.StatusForbidden {
+		t.Errorf("DELETE /v1/api/stream/restreams by another user answered %d, want 403", resp.Code)
+	}
+	if resp := restreamsRequest(t, h, owner, http.MethodPatch, fmt.Sprintf(`{"id": %d, "enabled": false}`, id)); resp.Code != http.StatusOK {
+		t.Fatalf("PATCH /v1/api/stream/restreams by the owner answered %d: %s", resp.Code, resp.Body)
+	}
+	if stored := h.StreamDB.RestreamDestinations(); len(stored) != 1 || stored[0].Enabled {
+		t.Errorf("database holds destinations %+v after pausing", stored)
+	}
+	if resp := restreamsRequest(t, h, owner, http.MethodDelete, fmt.Sprintf(`{"id": %d}`, id)); resp.Code != http.StatusOK {
+		t.Fatalf("DELETE /v1/api/stream/restreams by the owner answered %d: %s", resp.Code, resp.Body)
+	}
+	if stored := h.StreamDB.RestreamDestinations(); len(stored) != 0 {
+		t.Errorf("database holds destinations %+v after the removal", stored)
+	}
+}
+
+// restreamsRequest calls the restreams endpoint of the REST API on behalf of
+// the user of ctx
+func restreamsRequest(t *testing.T, h *harness.Harness, ctx context.Context, method, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	return h.StreamsREST(t, httptest.NewRequest(method, "/v1/api/stream/restreams", strings.NewReader(body)).WithContext(ctx))
+}
+
+// waitForRestreams polls the destinations of a stream until done accepts
+// their statuses
+func waitForRestreams(t *testing.T, h *harness.Harness, ctx context.Context, streamID int32, done func(map[int32]*streampb.RestreamStatus) bool) map[int32]*streampb.RestreamStatus {
+	t.Helper()
+
+	var states map[int32]*streampb.RestreamStatus
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		list, err := h.Streams.ListRestreamDestinations(ctx, &streampb.ListRestreamDestinationsRequest{StreamId: streamID})
+		if err != nil {
+			t.Fatalf("ListRestreamDestinations: %v", err)
+		}
+		states = make(map[int32]*streampb.RestreamStatus)
+		for _, d := range list.Destinations {
+			states[d.Id] = d.Status
+		}
+		if done(states) {
+			return states
+		}
+	}
+	t.Fatalf("restream destinations did not settle: %v", states)
+	return nil
+}
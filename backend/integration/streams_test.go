@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestStreamLifecycle(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	ctx := harness.AsUser(harness.Context(t), alice.Id)
+
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+	if stream.UserId != alice.Id {
+		t.Errorf("stream is owned by user %d, want %d", stream.UserId, alice.Id)
+	}
+	if len(stream.StreamKey) < 10 {
+		t.Errorf("stream key %q was not generated", stream.StreamKey)
+	}
+
+	_, err := h.Streams.CreateStream(ctx, &streampb.CreateStreamRequest{Title: "Not mine", UserId: int64(bob.Id)})
+	requireCode(t, err, codes.PermissionDenied)
+
+	_, err = h.Streams.CreateStream(harness.Context(t), &streampb.CreateStreamRequest{
+		Title:     "Nobody's",
+		StartTime: stream.StartTime,
+		EndTime:   stream.EndTime,
+		Bitrate:   6000,
+		Framerate: 30,
+		UserId:    int64(bob.Id + 100),
+	})
+	requireCode(t, err, codes.NotFound)
+
+	// Go live, refuse to delete while live, then end and delete the stream
+	live, err := h.Streams.UpdateStream(ctx, &streampb.UpdateStreamRequest{Id: stream.Id, Status: "ONLINE"})
+	if err != nil {
+		t.Fatalf("UpdateStream to ONLINE: %v", err)
+	}
+	if live.Status != "ONLINE" {
+		t.Errorf("status is %s, want ONLINE", live.Status)
+	}
+
+	listed, err := h.Streams.ListStreams(ctx, &streampb.ListStreamsRequest{
+		PageNumber: 1,
+		PageSize:   10,
+		Filter:     &streampb.StreamFilter{Status: []string{"ONLINE"}},
+	})
+	if err != nil {
+		t.Fatalf("ListStreams: %v", err)
+	}
+	if len(listed.Streams) != 1 || listed.Streams[0].Id != stream.Id {
+		t.Errorf("live streams are %v, want only stream %d", listed.Streams, stream.Id)
+	}
+
+	_, err = h.Streams.DeleteStream(ctx, &streampb.DeleteStreamRequest{Id: stream.Id})
+	requireCode(t, err, codes.FailedPrecondition)
+
+	if _, err := h.Streams.UpdateStream(ctx, &streampb.UpdateStreamRequest{Id: stream.Id, Status: "COMPLETE"}); err != nil {
+		t.Fatalf("UpdateStream to COMPLETE: %v", err)
+	}
+	if _, err := h.Streams.DeleteStream(ctx, &streampb.DeleteStreamRequest{Id: stream.Id}); err != nil {
+		t.Fatalf("DeleteStream: %v", err)
+	}
+
+	_, err = h.Streams.GetStream(ctx, &streampb.GetStreamRequest{Id: stream.Id})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestStreamREST(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	stranger := harness.AsUser(harness.Context(t), bob.Id)
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+	live := fmt.Sprintf(`{"id": %d, "status": "ONLINE"}`, stream.Id)
+
+	// Update and delete check who asks like the gRPC API does
+	_, err := h.Streams.UpdateStream(harness.Context(t), &streampb.UpdateStreamRequest{Id: stream.Id, Title: "Renamed"})
+	requireCode(t, err, codes.Unauthenticated)
+	if resp := trashRequest(t, h, harness.Context(t), http.MethodPatch, "/v1/api/stream", live); resp.Code != http.StatusUnauthorized {
+		t.Errorf("PATCH /v1/api/stream without a user answered %d, want 401", resp.Code)
+	}
+	if resp := trashRequest(t, h, stranger, http.MethodPatch, "/v1/api/stream", live); resp.Code != http.StatusForbidden {
+		t.Errorf("PATCH /v1/api/stream by another user answered %d, want 403", resp.Code)
+	}
+	if resp := trashRequest(t, h, stranger, http.MethodPost, "/v1/api/stream", fmt.Sprintf(`{"title": "Not mine", "user_id": %d}`, alice.Id)); resp.Code != http.StatusForbidden {
+		t.Errorf("POST /v1/api/stream for another user answered %d, want 403", resp.Code)
+	}
+
+	resp := trashRequest(t, h, owner, http.MethodPatch, "/v1/api/stream", live)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("PATCH /v1/api/stream by the owner answered %d: %s", resp.Code, resp.Body)
+	}
+	var updated streampb.StreamResponse
+	if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+		t.Fatalf("invalid stream: %v", err)
+	}
+	if updated.Status != "ONLINE" {
+		t.Errorf("stream updated over REST is %s, want ONLINE", updated.Status)
+	}
+
+	if resp := trashRequest(t, h, owner, http.MethodPatch, "/v1/api/stream", fmt.Sprintf(`{"id": %d, "status": "COMPLETE"}`, stream.Id)); resp.Code != http.StatusOK {
+		t.Fatalf("PATCH /v1/api/stream to COMPLETE answered %d: %s", resp.Code, resp.Body)
+	}
+	body := fmt.Sprintf(`{"id": %d}`, stream.Id)
+	if resp := trashRequest(t, h, harness.Context(t), http.MethodDelete, "/v1/api/stream", body); resp.Code != http.StatusUnauthorized {
+		t.Errorf("DELETE /v1/api/stream without a user answered %d, want 401", resp.Code)
+	}
+	if resp := trashRequest(t, h, stranger, http.MethodDelete, "/v1/api/stream", body); resp.Code != http.StatusForbidden {
+		t.Errorf("DELETE /v1/api/stream by another user answered %d, want 403", resp.Code)
+	}
+	if resp := trashRequest(t, h, owner, http.MethodDelete, "/v1/api/stream", body); resp.Code != http.StatusOK {
+		t.Fatalf("DELETE /v1/api/stream by the owner answered %d: %s", resp.Code, resp.Body)
+	}
+	if streams := h.StreamDB.Streams(); len(streams) != 0 {
+		t.Errorf("database holds streams %v after the delete", streams)
+	}
+}
@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestStreamHealth(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	viewer := harness.AsUser(harness.Context(t), bob.Id)
+
+	// createStream configures 6000 kbps at 30 fps
+	stream := createStream(t, h, alice.Id, "SCHEDULED")
+
+	health, err := h.Streams.GetStreamHealth(owner, &streampb.GetStreamHealthRequest{StreamId: stream.Id})
+	if err != nil {
+		t.Fatalf("GetStreamHealth before any report: %v", err)
+	}
+	if health.Status != "no_data" {
+		t.Errorf("health without telemetry is %q, want no_data", health.Status)
+	}
+
+	audio := -18.0
+	good := &streampb.StreamTelemetry{
+		StreamId:                stream.Id,
+		BitrateKbps:             5900,
+		Framerate:               30,
+		KeyframeIntervalSeconds: 2,
+		AudioLevelDb:            &audio,
+	}
+
+	// Only live streams report telemetry
+	_, err = h.Streams.ReportStreamTelemetry(owner, good)
+	requireCode(t, err, codes.FailedPrecondition)
+	if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: stream.Id, Status: "ONLINE"}); err != nil {
+		t.Fatalf("UpdateStream to ONLINE: %v", err)
+	}
+
+	// Encoders report on behalf of whoever runs the broadcast, anonymous
+	// reports are refused
+	_, err = h.Streams.ReportStreamTelemetry(harness.Context(t), good)
+	requireCode(t, err, codes.Unauthenticated)
+	rec := trashRequest(t, h, harness.Context(t), http.MethodPost, "/v1/api/stream/telemetry", fmt.Sprintf(`{"stream_id": %d, "bitrate_kbps": 100}`, stream.Id))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous REST report returned %d, want 401", rec.Code)
+	}
+	_, err = h.Streams.GetStreamHealth(harness.Context(t), &streampb.GetStreamHealthRequest{StreamId: stream.Id})
+	requireCode(t, err, codes.Unauthenticated)
+
+	health, err = h.Streams.ReportStreamTelemetry(owner, good)
+	if err != nil {
+		t.Fatalf("ReportStreamTelemetry: %v", err)
+	}
+	if health.Status != "healthy" || len(health.Warnings) != 0 {
+		t.Errorf("health after a good report is %q with warnings %v, want healthy", health.Status, health.Warnings)
+	}
+
+	_, err = h.Streams.ReportStreamTelemetry(viewer, good)
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Streams.ReportStreamTelemetry(owner, &streampb.StreamTelemetry{StreamId: stream.Id, BitrateKbps: -1})
+	requireCode(t, err, codes.InvalidArgument)
+
+	// The encoder struggles: the average bitrate and framerate fall below
+	// the configured ones and keyframes are too far apart
+	bad := &streampb.StreamTelemetry{
+		StreamId:                stream.Id,
+		BitrateKbps:             1200,
+		Framerate:               12,
+		KeyframeIntervalSeconds: 10,
+		AudioLevelDb:            &audio,
+	}
+	degraded, err := h.Streams.ReportStreamTelemetry(owner, bad)
+	if err != nil {
+		t.Fatalf("ReportStreamTelemetry of a degraded stream: %v", err)
+	}
+	if degraded.Status != "degraded" {
+		t.Fatalf("health after a bad report is %q, want degraded", degraded.Status)
+	}
+	kinds := map[string]string{}
+	for _, warning := range degraded.Warnings {
+		kinds[warning.Kind] = warning.Since
+	}
+	for _, kind := range []string{"low_bitrate", "low_framerate", "keyframe_interval"} {
+		if _, ok := kinds[kind]; !ok {
+			t.Errorf("warnings %v lack %s", degraded.Warnings, kind)
+		}
+	}
+	if _, ok := kinds["audio_silent"]; ok {
+		t.Error("audio reported at -18 dBFS was flagged as silent")
+	}
+
+	// The owner reads the same series back
+	health, err = h.Streams.GetStreamHealth(owner, &streampb.GetStreamHealthRequest{StreamId: stream.Id})
+	if err != nil {
+		t.Fatalf("GetStreamHealth: %v", err)
+	}
+	if len(health.Samples) != 2 {
+		t.Errorf("health has %d samples, want 2", len(health.Samples))
+	}
+	if health.AverageBitrateKbps != (5900+1200)/2 {
+		t.Errorf("average bitrate is %d kbps, want %d", health.AverageBitrateKbps, (5900+1200)/2)
+	}
+	for _, warning := range health.Warnings {
+		if warning.Since != kinds[warning.Kind] {
+			t.Errorf("warning %s moved from %s to %s", warning.Kind, kinds[warning.Kind], warning.Since)
+		}
+	}
+	_, err = h.Streams.GetStreamHealth(viewer, &streampb.GetStreamHealthRequest{StreamId: stream.Id})
+	requireCode(t, err, codes.PermissionDenied)
+
+	// The samples are stored by StreamDb, not in the memory of
+	// stream-service
+	samples := h.StreamDB.TelemetrySamples()
+	if len(samples) != 2 || samples[0].StreamID != stream.Id || samples[0].BitrateKbps != 5900 || samples[1].BitrateKbps != 1200 {
+		t.Errorf("StreamDb holds samples %+v, want the two reports", samples)
+	}
+}
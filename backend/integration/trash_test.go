@@ -0,0 +1,261 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clementus360/platform/auth"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestStreamTrash(t *testing.T) {
+	media := t.TempDir()
+	t.Setenv("PURGE_MEDIA_PATHS", filepath.Join(media, "recordings", "{stream_id}")+","+filepath.Join(media, "{stream_id}.jpg"))
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	viewer := harness.AsUser(harness.Context(t), bob.Id)
+	admin := harness.AsUser(harness.Context(t), bob.Id, auth.RoleAdmin)
+	moderator := harness.AsUser(harness.Context(t), bob.Id, auth.RoleModerator)
+
+	kept := createStream(t, h, alice.Id, "COMPLETE")
+	expired := createStream(t, h, alice.Id, "COMPLETE")
+	other := createStream(t, h, bob.Id, "COMPLETE")
+	for _, stream := range []*streampb.StreamResponse{kept, expired} {
+		if _, err := h.Streams.DeleteStream(owner, &streampb.DeleteStreamRequest{Id: stream.Id}); err != nil {
+			t.Fatalf("DeleteStream(%d): %v", stream.Id, err)
+		}
+	}
+	if _, err := h.Streams.DeleteStream(viewer, &streampb.DeleteStreamRequest{Id: other.Id}); err != nil {
+		t.Fatalf("DeleteStream(%d): %v", other.Id, err)
+	}
+
+	// Owners see their own trash, most recently deleted first
+	trash, err := h.Streams.ListDeletedStreams(owner, &streampb.ListDeletedStreamsRequest{})
+	if err != nil {
+		t.Fatalf("ListDeletedStreams: %v", err)
+	}
+	if len(trash.Streams) != 2 || trash.Streams[0].Id != expired.Id || trash.Streams[1].Id != kept.Id {
+		t.Fatalf("trash of the owner is %v, want streams %d and %d", streamIDs(trash.Streams), expired.Id, kept.Id)
+	}
+	if trash.Streams[0].DeletedAt == "" {
+		t.Errorf("deleted stream %d has no deleted_at", expired.Id)
+	}
+	for _, stream := range trash.Streams {
+		if stream.StreamKey != "" {
+			t.Errorf("deleted stream %d is listed with its stream key", stream.Id)
+		}
+	}
+	_, err = h.Streams.ListDeletedStreams(harness.Context(t), &streampb.ListDeletedStreamsRequest{UserId: alice.Id})
+	requireCode(t, err, codes.Unauthenticated)
+	_, err = h.Streams.ListDeletedStreams(viewer, &streampb.ListDeletedStreamsRequest{UserId: alice.Id})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Streams.ListDeletedStreams(moderator, &streampb.ListDeletedStreamsRequest{UserId: alice.Id})
+	requireCode(t, err, codes.PermissionDenied)
+	trash, err = h.Streams.ListDeletedStreams(admin, &streampb.ListDeletedStreamsRequest{})
+	if err != nil {
+		t.Fatalf("ListDeletedStreams(admin): %v", err)
+	}
+	if len(trash.Streams) != 3 {
+		t.Errorf("trash seen by an admin has streams %v, want all 3", streamIDs(trash.Streams))
+	}
+
+	// Only the owner or an admin restores a stream
+	_, err = h.Streams.RestoreStream(harness.Context(t), &streampb.RestoreStreamRequest{Id: kept.Id, UserId: alice.Id})
+	requireCode(t, err, codes.Unauthenticated)
+	_, err = h.Streams.RestoreStream(viewer, &streampb.RestoreStreamRequest{Id: kept.Id})
+	requireCode(t, err, codes.NotFound)
+	_, err = h.Streams.RestoreStream(viewer, &streampb.RestoreStreamRequest{Id: kept.Id, UserId: alice.Id})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Streams.RestoreStream(moderator, &streampb.RestoreStreamRequest{Id: kept.Id, UserId: alice.Id})
+	requireCode(t, err, codes.PermissionDenied)
+	restored, err := h.Streams.RestoreStream(owner, &streampb.RestoreStreamRequest{Id: kept.Id})
+	if err != nil {
+		t.Fatalf("RestoreStream: %v", err)
+	}
+	if restored.Id != kept.Id || restored.DeletedAt != "" {
+		t.Errorf("restored stream %d has deleted_at %q", restored.Id, restored.DeletedAt)
+	}
+	if _, err := h.Streams.GetStream(owner, &streampb.GetStreamRequest{Id: kept.Id}); err != nil {
+		t.Errorf("GetStream after restore: %v", err)
+	}
+	_, err = h.Streams.RestoreStream(owner, &streampb.RestoreStreamRequest{Id: kept.Id})
+	requireCode(t, err, codes.NotFound)
+
+	// Purging on request is left to admins and only applies to the trash
+	_, err = h.Streams.PurgeStream(owner, &streampb.PurgeStreamRequest{Id: expired.Id})
+	requireCode(t, err, codes.PermissionDenied)
+	_, err = h.Streams.PurgeStream(admin, &streampb.PurgeStreamRequest{Id: kept.Id})
+	requireCode(t, err, codes.FailedPrecondition)
+
+	// The REST API lists and restores on behalf of the owner
+	resp := trashRequest(t, h, viewer, http.MethodGet, fmt.Sprintf("/v1/api/streams/deleted?user_id=%d", bob.Id), "")
+	if resp.Code != http.StatusOK {
+		t.Fatalf("GET /v1/api/streams/deleted answered %d: %s", resp.Code, resp.Body)
+	}
+	var listed streampb.ListStreamsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listed); err != nil {
+		t.Fatalf("invalid trash listing: %v", err)
+	}
+	if len(listed.Streams) != 1 || listed.Streams[0].Id != other.Id || listed.Streams[0].StreamKey != "" {
+		t.Errorf("REST trash of bob is %v, want stream %d without its stream key", listed.Streams, other.Id)
+	}
+	resp = trashRequest(t, h, harness.Context(t), http.MethodGet, fmt.Sprintf("/v1/api/streams/deleted?user_id=%d", bob.Id), "")
+	if resp.Code != http.StatusUnauthorized {
+		t.Errorf("GET /v1/api/streams/deleted without a user answered %d, want 401", resp.Code)
+	}
+	resp = trashRequest(t, h, viewer, http.MethodGet, "/v1/api/streams/deleted?page=zero", "")
+	if resp.Code != http.StatusBadRequest {
+		t.Errorf("GET /v1/api/streams/deleted with an invalid page answered %d", resp.Code)
+	}
+	resp = trashRequest(t, h, viewer, http.MethodPost, "/v1/api/stream/restore", fmt.Sprintf(`{"id": %d}`, kept.Id))
+	if resp.Code != http.StatusNotFound {
+		t.Errorf("restoring a stream that is not deleted answered %d: %s", resp.Code, resp.Body)
+	}
+	resp = trashRequest(t, h, viewer, http.MethodPost, "/v1/api/stream/restore", fmt.Sprintf(`{"id": %d}`, other.Id))
+	if resp.Code != http.StatusOK {
+		t.Fatalf("POST /v1/api/stream/restore answered %d: %s", resp.Code, resp.Body)
+	}
+	if _, err := h.Streams.DeleteStream(viewer, &streampb.DeleteStreamRequest{Id: other.Id}); err != nil {
+		t.Fatalf("DeleteStream(%d): %v", other.Id, err)
+	}
+
+	// Streams past the retention period are purged with their media, the
+	// others stay in the trash
+	recordings := filepath.Join(media, "recordings", fmt.Sprint(expired.Id))
+	thumbnail := filepath.Join(media, fmt.Sprintf("%d.jpg", expired.Id))
+	if err := os.MkdirAll(recordings, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{filepath.Join(recordings, "index.m3u8"), thumbnail} {
+		if err := os.WriteFile(file, []byte("media"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h.StreamDB.Backdate(expired.Id, 31*24*time.Hour)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for h.StreamDB.HasStream(expired.Id) {
+		if time.Now().After(deadline) {
+			t.Fatalf("stream %d deleted 31 days ago was not purged", expired.Id)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	for _, path := range []string{recordings, thumbnail} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("media %s of the purged stream still exists: %v", path, err)
+		}
+	}
+	if !h.StreamDB.HasStream(other.Id) {
+		t.Errorf("stream %d deleted just now was purged", other.Id)
+	}
+	_, err = h.Streams.RestoreStream(owner, &streampb.RestoreStreamRequest{Id: expired.Id})
+	requireCode(t, err, codes.NotFound)
+
+	// Admins purge the trash right away
+	if _, err := h.Streams.PurgeStream(admin, &streampb.PurgeStreamRequest{Id: other.Id}); err != nil {
+		t.Fatalf("PurgeStream: %v", err)
+	}
+	if h.StreamDB.HasStream(other.Id) {
+		t.Errorf("stream %d is still stored after PurgeStream", other.Id)
+	}
+}
+
+func TestTrashKeepsStreamData(t *testing.T) {
+	h := harness.Start(t)
+	sink := harness.NewRTMPSink(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	admin := harness.AsUser(harness.Context(t), bob.Id, auth.RoleAdmin)
+
+	now := time.Now().UTC()
+	stream := createBroadcast(t, h, alice.Id, "COMPLETE", now.Add(-time.Hour), now.Add(-30*time.Minute))
+	if _, err := h.Streams.AddCollaborator(owner, &streampb.AddCollaboratorRequest{StreamId: stream.Id, UserId: bob.Id, Role: "editor"}); err != nil {
+		t.Fatalf("AddCollaborator: %v", err)
+	}
+	if _, err := h.Streams.SetStreamRenditions(owner, &streampb.SetStreamRenditionsRequest{StreamId: stream.Id, Preset: "mobile"}); err != nil {
+		t.Fatalf("SetStreamRenditions: %v", err)
+	}
+	clip, err := h.Streams.CreateClip(owner, &streampb.CreateClipRequest{StreamId: stream.Id, DurationSeconds: 30})
+	if err != nil {
+		t.Fatalf("CreateClip: %v", err)
+	}
+	destination, err := h.Streams.AddRestreamDestination(owner, &streampb.AddRestreamDestinationRequest{StreamId: stream.Id, Name: "Twitch", Url: sink.URL("app"), Key: "live_secret"})
+	if err != nil {
+		t.Fatalf("AddRestreamDestination: %v", err)
+	}
+
+	// A deleted stream keeps what goes with it, so a restore brings it back
+	if _, err := h.Streams.DeleteStream(owner, &streampb.DeleteStreamRequest{Id: stream.Id}); err != nil {
+		t.Fatalf("DeleteStream: %v", err)
+	}
+	if _, err := h.Streams.RestoreStream(owner, &streampb.RestoreStreamRequest{Id: stream.Id}); err != nil {
+		t.Fatalf("RestoreStream: %v", err)
+	}
+	collaborators, err := h.Streams.ListCollaborators(owner, &streampb.ListCollaboratorsRequest{StreamId: stream.Id})
+	if err != nil {
+		t.Fatalf("ListCollaborators: %v", err)
+	}
+	if len(collaborators.Collaborators) != 1 || collaborators.Collaborators[0].UserId != bob.Id {
+		t.Errorf("restored stream has collaborators %v, want bob", collaborators.Collaborators)
+	}
+	restored, err := h.Streams.GetStream(owner, &streampb.GetStreamRequest{Id: stream.Id})
+	if err != nil {
+		t.Fatalf("GetStream: %v", err)
+	}
+	if restored.RenditionPreset != "mobile" {
+		t.Errorf("restored stream has preset %q, want mobile", restored.RenditionPreset)
+	}
+	if _, err := h.Streams.GetClip(owner, &streampb.GetClipRequest{Id: clip.Id}); err != nil {
+		t.Errorf("GetClip after restore: %v", err)
+	}
+	destinations, err := h.Streams.ListRestreamDestinations(owner, &streampb.ListRestreamDestinationsRequest{StreamId: stream.Id})
+	if err != nil {
+		t.Fatalf("ListRestreamDestinations: %v", err)
+	}
+	if len(destinations.Destinations) != 1 || destinations.Destinations[0].Id != destination.Id {
+		t.Errorf("restored stream has restream destinations %v, want %d", destinations.Destinations, destination.Id)
+	}
+
+	// Purging the stream removes them for good
+	if _, err := h.Streams.DeleteStream(owner, &streampb.DeleteStreamRequest{Id: stream.Id}); err != nil {
+		t.Fatalf("DeleteStream: %v", err)
+	}
+	if _, err := h.Streams.PurgeStream(admin, &streampb.PurgeStreamRequest{Id: stream.Id}); err != nil {
+		t.Fatalf("PurgeStream: %v", err)
+	}
+	if n := len(h.StreamDB.Collaborators()) + len(h.StreamDB.RenditionLadders()) + len(h.StreamDB.Clips()) + len(h.StreamDB.RestreamDestinations()); n != 0 {
+		t.Errorf("StreamDb holds %d collaborators, ladders, clips and restream destinations of the purged stream", n)
+	}
+}
+
+// trashRequest calls the REST API of stream-service on behalf of the user of
+// ctx
+func trashRequest(t *testing.T, h *harness.Harness, ctx context.Context, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	return h.StreamsREST(t, httptest.NewRequest(method, target, strings.NewReader(body)).WithContext(ctx))
+}
+
+func streamIDs(streams []*streampb.StreamResponse) []int32 {
+	ids := make([]int32, 0, len(streams))
+	for _, stream := range streams {
+		ids = append(ids, stream.Id)
+	}
+	return ids
+}
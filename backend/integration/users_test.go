@@ -0,0 +1,96 @@
+package integration
+
+import (
+	"testing"
+
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestUserCreation(t *testing.T) {
+	h := harness.Start(t)
+	ctx := harness.Context(t)
+
+	alice := createUser(t, h, "alice")
+	if alice.Id == 0 {
+		t.Fatal("created user has no id")
+	}
+
+	got, err := h.Users.GetUser(ctx, &userpb.GetUserRequest{Id: alice.Id})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if got.User.Email != "alice@example.com" || got.User.Username != "alice" {
+		t.Errorf("GetUser returned %s <%s>, want alice <alice@example.com>", got.User.Username, got.User.Email)
+	}
+
+	_, err = h.Users.CreateUser(ctx, &userpb.CreateUserRequest{
+		ClerkId:  "user_alice2",
+		Email:    "alice@example.com",
+		Username: "alice2",
+	})
+	requireCode(t, err, codes.AlreadyExists)
+
+	_, err = h.Users.CreateUser(ctx, &userpb.CreateUserRequest{
+		ClerkId:  "user_alice3",
+		Email:    "alice3@example.com",
+		Username: "alice",
+	})
+	requireCode(t, err, codes.AlreadyExists)
+
+	_, err = h.Users.GetUser(ctx, &userpb.GetUserRequest{Id: alice.Id + 100})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestClerkSignIn(t *testing.T) {
+	h := harness.Start(t)
+	ctx := harness.Context(t)
+
+	alice := createUser(t, h, "alice")
+	h.Clerk.AddUser(harness.ClerkUser{
+		ID:        "user_bob",
+		Email:     "bob@example.com",
+		Username:  "bob",
+		FirstName: "Bob",
+		LastName:  "Builder",
+	})
+	bobCtx := harness.WithSessionToken(ctx, h.Clerk.SessionToken(t, "user_bob"))
+
+	// The first sign-in copies the Clerk account into the platform
+	synced, err := h.Users.SyncUserWithClerk(bobCtx, &userpb.SyncUserWithClerkRequest{ClerkId: "user_bob"})
+	if err != nil {
+		t.Fatalf("SyncUserWithClerk: %v", err)
+	}
+	bob := synced.User
+	if bob.Username != "bob" || bob.Email != "bob@example.com" || bob.FirstName != "Bob" {
+		t.Errorf("synced user is %s <%s> %s, want bob <bob@example.com> Bob", bob.Username, bob.Email, bob.FirstName)
+	}
+
+	byClerkID, err := h.Users.GetUserByClerkID(ctx, &userpb.GetUserByClerkIDRequest{ClerkId: "user_bob"})
+	if err != nil {
+		t.Fatalf("GetUserByClerkID: %v", err)
+	}
+	if byClerkID.User.Id != bob.Id {
+		t.Errorf("GetUserByClerkID returned user %d, want %d", byClerkID.User.Id, bob.Id)
+	}
+
+	// Later calls with the session token act as bob
+	updated, err := h.Users.UpdateUser(bobCtx, &userpb.UpdateUserRequest{Id: bob.Id, Username: "bobby"})
+	if err != nil {
+		t.Fatalf("UpdateUser of own account: %v", err)
+	}
+	if updated.User.Username != "bobby" {
+		t.Errorf("username is %q after update, want bobby", updated.User.Username)
+	}
+
+	_, err = h.Users.UpdateUser(bobCtx, &userpb.UpdateUserRequest{Id: alice.Id, Username: "mallory"})
+	requireCode(t, err, codes.PermissionDenied)
+
+	_, err = h.Users.DeleteUser(bobCtx, &userpb.DeleteUserRequest{Id: alice.Id})
+	requireCode(t, err, codes.PermissionDenied)
+
+	_, err = h.Users.GetUser(harness.WithSessionToken(ctx, "not-a-token"), &userpb.GetUserRequest{Id: bob.Id})
+	requireCode(t, err, codes.Unauthenticated)
+}
@@ -0,0 +1,152 @@
+package integration
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/clementus360/stream-service/playback"
+	streampb "github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+
+	"github.com/clementus360/integration/harness"
+)
+
+func TestStreamVisibility(t *testing.T) {
+	h := harness.Start(t)
+
+	alice := createUser(t, h, "alice")
+	bob := createUser(t, h, "bob")
+	carol := createUser(t, h, "carol")
+	owner := harness.AsUser(harness.Context(t), alice.Id)
+	viewer := harness.AsUser(harness.Context(t), bob.Id)
+	editor := harness.AsUser(harness.Context(t), carol.Id)
+
+	public := createStream(t, h, alice.Id, "ONLINE")
+	if public.Visibility != "PUBLIC" {
+		t.Errorf("stream created without a visibility is %q, want PUBLIC", public.Visibility)
+	}
+	unlisted := createStream(t, h, alice.Id, "ONLINE")
+	private := createStream(t, h, alice.Id, "ONLINE")
+	for id, visibility := range map[int32]string{unlisted.Id: "UNLISTED", private.Id: "PRIVATE"} {
+		if _, err := h.Streams.UpdateStream(owner, &streampb.UpdateStreamRequest{Id: id, Visibility: visibility}); err != nil {
+			t.Fatalf("UpdateStream(%d) to %s: %v", id, visibility, err)
+		}
+	}
+
+	// StreamDb stores the visibility as an enum and keeps the fields the
+	// update left out
+	for _, stream := range h.StreamDB.Streams() {
+		want := map[int32]store.StreamVisibility{public.Id: store.VisibilityPublic, unlisted.Id: store.VisibilityUnlisted, private.Id: store.VisibilityPrivate}[stream.ID]
+		if stream.Visibility != want || stream.Status != store.StatusOnline {
+			t.Errorf("stream %d is stored with visibility %d and status %d, want %d and ONLINE", stream.ID, stream.Visibility, stream.Status, want)
+		}
+	}
+
+	_, err := h.Streams.CreateStream(owner, &streampb.CreateStreamRequest{Title: "Secret", UserId: int64(alice.Id), Visibility: "HIDDEN"})
+	requireCode(t, err, codes.InvalidArgument)
+
+	// Listings and search only show public streams to other viewers, while
+	// owners see their whole channel
+	listed, err := h.Streams.ListStreams(viewer, &streampb.ListStreamsRequest{PageSize: 10, PageNumber: 1, SortBy: "id", Ascending: true})
+	if err != nil {
+		t.Fatalf("ListStreams as viewer: %v", err)
+	}
+	if len(listed.Streams) != 1 || listed.Streams[0].Id != public.Id {
+		t.Errorf("viewer listed %d streams, want only the public one", len(listed.Streams))
+	}
+	listed, err = h.Streams.ListStreams(viewer, &streampb.ListStreamsRequest{
+		PageSize:   10,
+		PageNumber: 1,
+		Filter:     &streampb.StreamFilter{UserId: alice.Id, Visibility: []string{"PRIVATE"}},
+	})
+	if err != nil {
+		t.Fatalf("ListStreams of private streams as viewer: %v", err)
+	}
+	if len(listed.Streams) != 0 {
+		t.Errorf("viewer listed %d private streams of another user", len(listed.Streams))
+	}
+	listed, err = h.Streams.ListStreams(owner, &streampb.ListStreamsRequest{
+		PageSize:   10,
+		PageNumber: 1,
+		Filter:     &streampb.StreamFilter{UserId: alice.Id},
+	})
+	if err != nil {
+		t.Fatalf("ListStreams as owner: %v", err)
+	}
+	if len(listed.Streams) != 3 {
+		t.Errorf("owner listed %d of their streams, want 3", len(listed.Streams))
+	}
+
+	// Calls without a user only list what anonymous viewers see, unless they
+	// come from a trusted service
+	hidden := &streampb.ListStreamsRequest{
+		PageSize:   10,
+		PageNumber: 1,
+		Filter:     &streampb.StreamFilter{UserId: alice.Id, Visibility: []string{"PRIVATE", "UNLISTED"}},
+	}
+	listed, err = h.Streams.ListStreams(harness.Context(t), hidden)
+	if err != nil {
+		t.Fatalf("ListStreams of hidden streams without a user: %v", err)
+	}
+	if len(listed.Streams) != 0 {
+		t.Errorf("gateway listed %d hidden streams without a user", len(listed.Streams))
+	}
+	trusted := streampb.NewStreamServiceClient(h.ServiceConn(t, "platformctl", "stream-service"))
+	listed, err = trusted.ListStreams(harness.Context(t), hidden)
+	if err != nil {
+		t.Fatalf("ListStreams of hidden streams as a trusted service: %v", err)
+	}
+	if len(listed.Streams) != 2 {
+		t.Errorf("trusted service listed %d hidden streams, want 2", len(listed.Streams))
+	}
+
+	// Unlisted streams are reachable by id, private ones only by the team
+	if _, err := h.Streams.GetStream(viewer, &streampb.GetStreamRequest{Id: unlisted.Id}); err != nil {
+		t.Errorf("GetStream of an unlisted stream as viewer: %v", err)
+	}
+	_, err = h.Streams.GetStream(viewer, &streampb.GetStreamRequest{Id: private.Id})
+	requireCode(t, err, codes.NotFound)
+	_, err = h.Streams.GetStream(editor, &streampb.GetStreamRequest{Id: private.Id})
+	requireCode(t, err, codes.NotFound)
+
+	if _, err := h.Streams.AddCollaborator(owner, &streampb.AddCollaboratorRequest{StreamId: private.Id, UserId: carol.Id, Role: "editor"}); err != nil {
+		t.Fatalf("AddCollaborator(carol): %v", err)
+	}
+	if _, err := h.Streams.GetStream(editor, &streampb.GetStreamRequest{Id: private.Id}); err != nil {
+		t.Errorf("GetStream of a private stream as collaborator: %v", err)
+	}
+
+	// Playback tokens are bound to one stream and verified offline with the
+	// shared key
+	verifier := playback.NewSigner(harness.PlaybackSigningKey, time.Hour)
+
+	token, err := h.Streams.IssuePlaybackToken(viewer, &streampb.IssuePlaybackTokenRequest{StreamId: unlisted.Id})
+	if err != nil {
+		t.Fatalf("IssuePlaybackToken(unlisted) as viewer: %v", err)
+	}
+	claims, err := verifier.Verify(token.Token, unlisted.Id)
+	if err != nil {
+		t.Fatalf("Verify(unlisted token): %v", err)
+	}
+	if claims.Subject != strconv.Itoa(int(bob.Id)) {
+		t.Errorf("token subject is %q, want the viewer %d", claims.Subject, bob.Id)
+	}
+	if _, err := verifier.Verify(token.Token, public.Id); err != playback.ErrWrongStream {
+		t.Errorf("Verify of the token for another stream returned %v, want %v", err, playback.ErrWrongStream)
+	}
+	if _, err := playback.NewSigner("another-playback-key-0123456789abcdef", time.Hour).Verify(token.Token, unlisted.Id); err != playback.ErrInvalidToken {
+		t.Errorf("Verify with another key returned %v, want %v", err, playback.ErrInvalidToken)
+	}
+
+	_, err = h.Streams.IssuePlaybackToken(viewer, &streampb.IssuePlaybackTokenRequest{StreamId: private.Id})
+	requireCode(t, err, codes.NotFound)
+	token, err = h.Streams.IssuePlaybackToken(editor, &streampb.IssuePlaybackTokenRequest{StreamId: private.Id})
+	if err != nil {
+		t.Fatalf("IssuePlaybackToken(private) as collaborator: %v", err)
+	}
+	if _, err := verifier.Verify(token.Token, private.Id); err != nil {
+		t.Errorf("Verify(private token): %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+// Package audit records who changed what through the gRPC API. Mutations
+// are appended to a log kept by StreamDb, which is never rewritten and is
+// shared by every service and replica.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/clementus360/streamdb-api/streamdb"
+)
+
+// Page sizes of queries
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Entry is one recorded mutation
+type Entry struct {
+	// ID numbers the entries in the order they were appended
+	ID      int64
+	Time    time.Time
+	Service string
+	// Method is the full gRPC method that made the change
+	Method string
+	Action string
+
+	// ActorID is the end user the call was made on behalf of, 0 for calls
+	// made by a service on its own
+	ActorID    int64
+	ActorRoles []string
+	// ActorService is the service that called, empty when the end user
+	// called directly
+	ActorService string
+
+	TargetType string
+	TargetID   string
+	Changes    []Change
+	RequestID  string
+}
+
+// Change is a field of the target before and after the mutation, as JSON
+// values. Before is empty for created fields and After for removed ones.
+type Change struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// Query filters the entries of a log. Zero fields match everything.
+type Query struct {
+	ActorID      int64
+	ActorService string
+	TargetType   string
+	TargetID     string
+	// Since is inclusive and Until exclusive
+	Since time.Time
+	Until time.Time
+
+	Page     int
+	PageSize int
+}
+
+// Log is the part of the audit log of StreamDb written by one service.
+// Every replica of every service appends to the same log, so entries
+// survive restarts and are found wherever they were recorded.
+type Log struct {
+	service string
+	client  streamdb.AuditServiceClient
+}
+
+// NewLog returns the log of the entries service records, kept by StreamDb
+// and called through client
+func NewLog(service string, client streamdb.AuditServiceClient) *Log {
+	return &Log{service: service, client: client}
+}
+
+// Append adds entry to the log as recorded by the service of the log and
+// returns it with the id StreamDb numbered it with
+func (l *Log) Append(ctx context.Context, entry Entry) (Entry, error) {
+	req := &streamdb.AuditEntry{
+		RecordedAt:   entry.Time.UTC().Format(time.RFC3339Nano),
+		Service:      l.service,
+		Method:       entry.Method,
+		Action:       entry.Action,
+		ActorId:      entry.ActorID,
+		ActorRoles:   entry.ActorRoles,
+		ActorService: entry.ActorService,
+		TargetType:   entry.TargetType,
+		TargetId:     entry.TargetID,
+		RequestId:    entry.RequestID,
+	}
+	for _, change := range entry.Changes {
+		req.Changes = append(req.Changes, &streamdb.AuditChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+
+	resp, err := l.client.AppendAuditEntry(ctx, req)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return entryFromResponse(resp)
+}
+
+// Query returns a page of the entries of the service matching q, newest
+// first, and the number of matching entries
+func (l *Log) Query(ctx context.Context, q Query) ([]Entry, int, error) {
+	req := &streamdb.QueryAuditLogRequest{
+		Service:      l.service,
+		ActorId:      q.ActorID,
+		ActorService: q.ActorService,
+		TargetType:   q.TargetType,
+		TargetId:     q.TargetID,
+		PageNumber:   int32(max(q.Page, 1)),
+		PageSize:     int32(q.pageSize()),
+	}
+	if !q.Since.IsZero() {
+		req.Since = q.Since.UTC().Format(time.RFC3339Nano)
+	}
+	if !q.Until.IsZero() {
+		req.Until = q.Until.UTC().Format(time.RFC3339Nano)
+	}
+
+	resp, err := l.client.QueryAuditLog(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries := make([]Entry, 0, len(resp.Entries))
+	for _, stored := range resp.Entries {
+		entry, err := entryFromResponse(stored)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, int(resp.TotalCount), nil
+}
+
+// entryFromResponse reads an entry returned by StreamDb, whose times are in
+// the round-trip format of .NET
+func entryFromResponse(resp *streamdb.AuditEntry) (Entry, error) {
+	recordedAt, err := time.Parse(time.RFC3339Nano, resp.RecordedAt)
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid time of audit entry %d: %w", resp.Id, err)
+	}
+	entry := Entry{
+		ID:           resp.Id,
+		Time:         recordedAt.UTC(),
+		Service:      resp.Service,
+		Method:       resp.Method,
+		Action:       resp.Action,
+		ActorID:      resp.ActorId,
+		ActorRoles:   resp.ActorRoles,
+		ActorService: resp.ActorService,
+		TargetType:   resp.TargetType,
+		TargetID:     resp.TargetId,
+		RequestID:    resp.RequestId,
+	}
+	for _, change := range resp.Changes {
+		entry.Changes = append(entry.Changes, Change{Field: change.Field, Before: change.Before, After: change.After})
+	}
+	return entry, nil
+}
+
+// pageSize returns the most entries a page of q holds
+func (q Query) pageSize() int {
+	if q.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return min(q.PageSize, MaxPageSize)
+}
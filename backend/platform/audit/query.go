@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/clementus360/platform/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Page is a page of the entries matching a query, newest first
+type Page struct {
+	Entries []Entry
+	// Total is the number of entries matching the query
+	Total int
+	// Number is the page, from 1, and Size the most entries it holds
+	Number int
+	Size   int
+}
+
+// Pages returns the number of pages of the matching entries
+func (p Page) Pages() int {
+	return (p.Total + p.Size - 1) / p.Size
+}
+
+// Serve answers the QueryAuditLog method of a service with the page of the
+// entries of log matching q. The log tells who did what to the resources of
+// anyone, so it is only read by admins and by trusted services calling on
+// their own. Errors are gRPC status errors.
+func Serve(ctx context.Context, log *Log, q Query) (Page, error) {
+	if err := authorizeRead(ctx); err != nil {
+		return Page{}, err
+	}
+
+	entries, total, err := log.Query(ctx, q)
+	if err != nil {
+		return Page{}, err
+	}
+	return Page{Entries: entries, Total: total, Number: max(q.Page, 1), Size: q.pageSize()}, nil
+}
+
+func authorizeRead(ctx context.Context) error {
+	if user, ok := auth.UserFromContext(ctx); ok {
+		if !user.HasRole(auth.RoleAdmin) {
+			return status.Error(codes.PermissionDenied, "only admins can read the audit log")
+		}
+		return nil
+	}
+	if err := auth.Authorize(ctx, 0); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return nil
+}
+
+// ParseTime returns the bound name of a query sent as text in layout. Empty
+// text leaves the bound open.
+func ParseTime(name, value, layout string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s must look like %s", name, layout)
+	}
+	return parsed, nil
+}
+
+// Timestamp returns a bound of a query sent as a protobuf timestamp. A nil
+// timestamp leaves the bound open.
+func Timestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,179 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Actions recorded in entries
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+)
+
+// redactedValue replaces the values of redacted fields in changes
+const redactedValue = `"[REDACTED]"`
+
+// Method describes how calls to one gRPC method are audited
+type Method struct {
+	Action     string
+	TargetType string
+	// Target returns the id of the target. The response is nil when the
+	// call failed.
+	Target func(req, resp any) string
+	// Before returns the target before the call, or nil when it cannot be
+	// read, for example because the call creates it
+	Before func(ctx context.Context, req any) proto.Message
+	// After returns the target after the call from the response, or nil
+	// when the call removes it
+	After func(resp any) proto.Message
+}
+
+// Recorder appends an entry to the log for every successful call to an
+// audited method
+type Recorder struct {
+	log     *Log
+	methods map[string]Method
+	// redacted fields are reported as changed without their values
+	redacted []string
+	now      func() time.Time
+}
+
+// NewRecorder returns a recorder of the calls to methods, keyed by full
+// method name, made to the service of log
+func NewRecorder(log *Log, methods map[string]Method, redacted ...string) *Recorder {
+	return &Recorder{
+		log:      log,
+		methods:  methods,
+		redacted: redacted,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// UnaryServerInterceptor records audited calls. It must run after the
+// authentication interceptor, which resolves the actor.
+func (r *Recorder) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return r.Record(ctx, info.FullMethod, req, handler)
+	}
+}
+
+// Record calls handler with req and records the call as a call to the gRPC
+// method fullMethod. It serves the same methods reached another way, such as
+// through a REST API, whose context must hold the actor like that of a gRPC
+// call.
+func (r *Recorder) Record(ctx context.Context, fullMethod string, req any, handler grpc.UnaryHandler) (any, error) {
+	method, ok := r.methods[fullMethod]
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	var before proto.Message
+	if method.Before != nil {
+		before = method.Before(ctx, req)
+	}
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	var after proto.Message
+	if method.After != nil {
+		after = method.After(resp)
+	}
+
+	entry := Entry{
+		Time:       r.now(),
+		Method:     fullMethod,
+		Action:     method.Action,
+		TargetType: method.TargetType,
+		TargetID:   method.Target(req, resp),
+		Changes:    r.diff(before, after),
+		RequestID:  logging.RequestIDFromContext(ctx),
+	}
+	if user, ok := auth.UserFromContext(ctx); ok {
+		entry.ActorID = user.ID
+		entry.ActorRoles = user.Roles
+	}
+	if caller, ok := auth.CallerFromContext(ctx); ok {
+		entry.ActorService = caller.Service
+	}
+
+	// The change is made, so a failed append is logged rather than
+	// returned to the caller, and it is made even if the caller has gone
+	if _, err := r.log.Append(context.WithoutCancel(ctx), entry); err != nil {
+		logging.FromContext(ctx).Error("Failed to record audit entry", "method", fullMethod, "target_id", entry.TargetID, "error", err)
+	}
+	return resp, nil
+}
+
+// diff returns the top-level fields that differ between before and after
+func (r *Recorder) diff(before, after proto.Message) []Change {
+	beforeFields, afterFields := fields(before), fields(after)
+
+	var names []string
+	for name := range beforeFields {
+		names = append(names, name)
+	}
+	for name := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var changes []Change
+	for _, name := range names {
+		change := Change{Field: name, Before: beforeFields[name], After: afterFields[name]}
+		if change.Before == change.After {
+			continue
+		}
+		if slices.Contains(r.redacted, name) {
+			change.Before, change.After = redact(change.Before), redact(change.After)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// fields returns the populated fields of m, by proto name, as JSON values
+func fields(m proto.Message) map[string]string {
+	values := make(map[string]string)
+	if m == nil {
+		return values
+	}
+	raw, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return values
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return values
+	}
+	// Re-encoding makes the values comparable, protojson output is not
+	// stable
+	for name, value := range decoded {
+		encoded, err := json.Marshal(value)
+		if err == nil {
+			values[name] = string(encoded)
+		}
+	}
+	return values
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redactedValue
+}
@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TokenHeader is the metadata key carrying the service token. It is separate
+// from "authorization" which holds end-user credentials at the edge.
+const TokenHeader = "x-service-token"
+
+// Config controls how calls between services are authenticated
+type Config struct {
+	// SigningKey is shared by every service. Authentication is disabled when
+	// it is empty.
+	SigningKey string
+	// Enforce rejects calls without a valid token. When false such calls are
+	// logged and served, which eases rolling the keys out.
+	Enforce bool
+	// AllowedCallers restricts which services may call this one. An empty
+	// list accepts any service holding the key.
+	AllowedCallers []string
+	// TrustedCallers are the services that may act on any resource without
+	// an end user, such as the cascades of user-service. Calls of other
+	// services must carry a user to change anything.
+	TrustedCallers []string
+	// TokenTTL is the lifetime of minted tokens
+	TokenTTL time.Duration
+}
+
+// Authenticator mints tokens for outgoing calls and verifies incoming ones
+type Authenticator struct {
+	service string
+	cfg     Config
+	key     []byte
+	now     func() time.Time
+}
+
+// New returns the authenticator of service
+func New(service string, cfg Config) *Authenticator {
+	if cfg.SigningKey == "" {
+		slog.Warn("Service authentication is disabled, set a signing key to enable it")
+	}
+	return &Authenticator{
+		service: service,
+		cfg:     cfg,
+		key:     []byte(cfg.SigningKey),
+		now:     time.Now,
+	}
+}
+
+// Enabled reports whether calls are signed and verified
+func (a *Authenticator) Enabled() bool {
+	return len(a.key) > 0
+}
+
+// Token mints a token for a call to audience on behalf of the user in ctx
+func (a *Authenticator) Token(ctx context.Context, audience string) (string, error) {
+	now := a.now()
+	claims := Claims{
+		Issuer:    a.service,
+		Audience:  audience,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(a.cfg.TokenTTL).Unix(),
+	}
+	if user, ok := UserFromContext(ctx); ok {
+		claims.Subject = user.subject()
+		claims.Roles = user.Roles
+	}
+	return sign(a.key, claims)
+}
+
+// Verify checks a token addressed to this service
+func (a *Authenticator) Verify(token string) (*Claims, error) {
+	return verify(a.key, token, a.service, a.now())
+}
+
+// skipAuth lists the infrastructure services that are reachable without a
+// token so probes and tooling keep working
+func skipAuth(method string) bool {
+	return strings.HasPrefix(method, "/grpc.health.v1.") || strings.HasPrefix(method, "/grpc.reflection.")
+}
+
+// authenticate verifies the token of an incoming call and attaches the
+// caller and the end user to the context
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if skipAuth(method) {
+		return ctx, nil
+	}
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TokenHeader); len(values) > 0 {
+			token = values[0]
+		}
+	}
+	return a.authenticateToken(ctx, method, token)
+}
+
+// authenticateToken verifies the token presented for a call or request to
+// target, such as a gRPC method or an HTTP path
+func (a *Authenticator) authenticateToken(ctx context.Context, target, token string) (context.Context, error) {
+	// An earlier interceptor, such as an end-user authenticator at the edge,
+	// already established who is calling
+	if _, ok := CallerFromContext(ctx); ok {
+		return ctx, nil
+	}
+	// Nothing can be verified without a key, so every caller is trusted
+	if !a.Enabled() {
+		return WithCaller(ctx, Caller{Trusted: true}), nil
+	}
+
+	ctx, err := a.verifyToken(ctx, token)
+	if err != nil {
+		if !a.cfg.Enforce {
+			logging.FromContext(ctx).Warn("Accepting unauthenticated call", "method", target, "error", err)
+			return ctx, nil
+		}
+		return ctx, err
+	}
+	return ctx, nil
+}
+
+func (a *Authenticator) verifyToken(ctx context.Context, token string) (context.Context, error) {
+	if token == "" {
+		return ctx, status.Error(codes.Unauthenticated, ErrMissingToken.Error())
+	}
+
+	claims, err := a.Verify(token)
+	if err != nil {
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if len(a.cfg.AllowedCallers) > 0 && !slices.Contains(a.cfg.AllowedCallers, claims.Issuer) {
+		return ctx, status.Errorf(codes.PermissionDenied, "service %q may not call %s", claims.Issuer, a.service)
+	}
+	// With mTLS the token must have been minted by the service holding the
+	// client certificate
+	if peer := tlsconfig.PeerIdentity(ctx); peer != "" && peer != claims.Issuer {
+		return ctx, status.Errorf(codes.PermissionDenied, "token issued by %q presented by %q", claims.Issuer, peer)
+	}
+
+	user, hasUser, err := userFromClaims(claims)
+	if err != nil {
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	ctx = WithCaller(ctx, Caller{Service: claims.Issuer, Trusted: slices.Contains(a.cfg.TrustedCallers, claims.Issuer)})
+	logger := logging.FromContext(ctx).With("caller", claims.Issuer)
+	if hasUser {
+		ctx = WithUser(ctx, user)
+		logger = logger.With("user_id", user.ID)
+	}
+	return logging.WithLogger(ctx, logger), nil
+}
+
+// UnaryServerInterceptor authenticates every unary call
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authStream overrides the context of a server stream
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (a *Authenticator) outgoingContext(ctx context.Context, audience string) (context.Context, error) {
+	if !a.Enabled() {
+		return ctx, nil
+	}
+	token, err := a.Token(ctx, audience)
+	if err != nil {
+		return ctx, status.Errorf(codes.Internal, "failed to sign service token: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(ctx, TokenHeader, token), nil
+}
+
+// UnaryClientInterceptor attaches a token for audience to outgoing calls
+func (a *Authenticator) UnaryClientInterceptor(audience string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := a.outgoingContext(ctx, audience)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor attaches a token for audience to outgoing streams
+func (a *Authenticator) StreamClientInterceptor(audience string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := a.outgoingContext(ctx, audience)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"net/http"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Middleware authenticates HTTP requests like UnaryServerInterceptor
+// authenticates calls, with the service token in the TokenHeader header.
+// Requests to the paths in skip, such as probes and metrics, are served
+// without one.
+func (a *Authenticator) Middleware(next http.Handler, skip ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices.Contains(skip, r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, err := a.authenticateToken(r.Context(), r.Method+" "+r.URL.Path, r.Header.Get(TokenHeader))
+		if err != nil {
+			code := http.StatusUnauthorized
+			if status.Code(err) == codes.PermissionDenied {
+				code = http.StatusForbidden
+			}
+			http.Error(w, status.Convert(err).Message(), code)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// SignRequest attaches a token for a request to audience on behalf of the
+// user in the context of req
+func (a *Authenticator) SignRequest(req *http.Request, audience string) error {
+	if !a.Enabled() {
+		return nil
+	}
+	token, err := a.Token(req.Context(), audience)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(TokenHeader, token)
+	return nil
+}
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerify(t *testing.T) {
+	key := []byte("a key shared by the services")
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	claims := func(change func(*Claims)) Claims {
+		c := Claims{
+			Issuer:    "api-gateway",
+			Audience:  "stream-service",
+			Subject:   "42",
+			IssuedAt:  now.Add(-time.Minute).Unix(),
+			ExpiresAt: now.Add(time.Minute).Unix(),
+		}
+		if change != nil {
+			change(&c)
+		}
+		return c
+	}
+	token := func(key []byte, claims Claims) string {
+		t.Helper()
+		token, err := sign(key, claims)
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return token
+	}
+	valid := token(key, claims(nil))
+	parts := strings.Split(valid, ".")
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"valid", valid, nil},
+		{"expired within the clock skew", token(key, claims(func(c *Claims) { c.ExpiresAt = now.Add(-clockSkew / 2).Unix() })), nil},
+		{"expired", token(key, claims(func(c *Claims) { c.ExpiresAt = now.Add(-2 * clockSkew).Unix() })), ErrExpiredToken},
+		{"issued within the clock skew", token(key, claims(func(c *Claims) { c.IssuedAt = now.Add(clockSkew / 2).Unix() })), nil},
+		{"issued in the future", token(key, claims(func(c *Claims) { c.IssuedAt = now.Add(2 * clockSkew).Unix() })), ErrInvalidToken},
+		{"other audience", token(key, claims(func(c *Claims) { c.Audience = "user-service" })), ErrWrongAudience},
+		{"no issuer", token(key, claims(func(c *Claims) { c.Issuer = "" })), ErrInvalidToken},
+		{"other key", token([]byte("another key"), claims(nil)), ErrInvalidToken},
+		{"payload swapped", parts[0] + "." + strings.Split(token(key, claims(func(c *Claims) { c.Subject = "1" })), ".")[1] + "." + parts[2], ErrInvalidToken},
+		{"other algorithm", "eyJhbGciOiJub25lIn0." + parts[1] + "." + parts[2], ErrInvalidToken},
+		{"not a token", "not-a-token", ErrInvalidToken},
+		{"empty", "", ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verify(key, tt.token, "stream-service", now)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("verify failed with %v, want %v", err, tt.want)
+			}
+			if err == nil && (got.Issuer != "api-gateway" || got.Subject != "42") {
+				t.Errorf("verify returned %+v", got)
+			}
+		})
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	minted := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	gateway := New("api-gateway", Config{SigningKey: "a key shared by the services", TokenTTL: time.Minute})
+	gateway.now = func() time.Time { return minted }
+	token, err := gateway.Token(WithUser(context.Background(), User{ID: 42, Roles: []string{RoleAdmin}}), "stream-service")
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		after time.Duration
+		want  error
+	}{
+		{"fresh", 0, nil},
+		{"at the end of its lifetime", time.Minute, nil},
+		{"within the clock skew", time.Minute + clockSkew, nil},
+		{"after the clock skew", time.Minute + clockSkew + time.Second, ErrExpiredToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams := New("stream-service", Config{SigningKey: "a key shared by the services"})
+			streams.now = func() time.Time { return minted.Add(tt.after) }
+			claims, err := streams.Verify(token)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify failed with %v, want %v", err, tt.want)
+			}
+			if err == nil && (claims.Subject != "42" || len(claims.Roles) != 1 || claims.Roles[0] != RoleAdmin) {
+				t.Errorf("Verify returned %+v, want the user of the call", claims)
+			}
+		})
+	}
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	const key = "a key shared by the services"
+	mint := func(issuer string, user *User) string {
+		t.Helper()
+		ctx := context.Background()
+		if user != nil {
+			ctx = WithUser(ctx, *user)
+		}
+		token, err := New(issuer, Config{SigningKey: key, TokenTTL: time.Minute}).Token(ctx, "stream-service")
+		if err != nil {
+			t.Fatalf("Token: %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		token   string
+		code    codes.Code
+		caller  *Caller
+		userID  int64
+		hasUser bool
+	}{
+		{"trusted caller", Config{Enforce: true, TrustedCallers: []string{"user-service"}}, mint("user-service", nil), codes.OK, &Caller{Service: "user-service", Trusted: true}, 0, false},
+		{"untrusted caller with a user", Config{Enforce: true, TrustedCallers: []string{"user-service"}}, mint("api-gateway", &User{ID: 7}), codes.OK, &Caller{Service: "api-gateway"}, 7, true},
+		{"allowed caller", Config{Enforce: true, AllowedCallers: []string{"api-gateway"}}, mint("api-gateway", nil), codes.OK, &Caller{Service: "api-gateway"}, 0, false},
+		{"caller not allowed", Config{Enforce: true, AllowedCallers: []string{"api-gateway"}}, mint("comment-service", nil), codes.PermissionDenied, nil, 0, false},
+		{"missing token", Config{Enforce: true}, "", codes.Unauthenticated, nil, 0, false},
+		{"invalid token", Config{Enforce: true}, "not-a-token", codes.Unauthenticated, nil, 0, false},
+		{"missing token while rolling out", Config{}, "", codes.OK, nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.SigningKey = key
+			ctx, err := New("stream-service", cfg).authenticateToken(context.Background(), "/stream.StreamService/GetStream", tt.token)
+			if got := status.Code(err); got != tt.code {
+				t.Fatalf("authenticateToken failed with %v, want %v", err, tt.code)
+			}
+
+			caller, ok := CallerFromContext(ctx)
+			switch {
+			case tt.caller == nil && ok:
+				t.Errorf("caller %+v was attached", caller)
+			case tt.caller != nil && (!ok || caller != *tt.caller):
+				t.Errorf("caller is %+v, want %+v", caller, *tt.caller)
+			}
+			user, ok := UserFromContext(ctx)
+			if ok != tt.hasUser || user.ID != tt.userID {
+				t.Errorf("user is %+v (%v), want %d (%v)", user, ok, tt.userID, tt.hasUser)
+			}
+		})
+	}
+}
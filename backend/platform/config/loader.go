@@ -0,0 +1,278 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// The loader fills a configuration struct from four sources, each one
+// overriding the previous:
+//
+//  1. the `default` struct tag
+//  2. a YAML file passed with --config (or CONFIG_FILE)
+//  3. the environment variable named by the `env` tag (a .env file is loaded
+//     first when present)
+//  4. the command-line flag named by the `flag` tag
+//
+// Fields tagged `required:"true"` must end up non-empty and fields tagged
+// `secret:"true"` are masked by Print.
+
+// field is a leaf setting discovered on the configuration struct
+type field struct {
+	path  string
+	value reflect.Value
+	tag   reflect.StructTag
+}
+
+// Load populates cfg, which must be a pointer to a struct, from defaults,
+// the YAML file, the environment and args. It returns every problem found
+// at once so a misconfigured service fails with a complete report.
+func Load(name string, cfg interface{}, args []string) error {
+	root := reflect.ValueOf(cfg)
+	if root.Kind() != reflect.Pointer || root.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: expected a pointer to a struct, got %T", cfg)
+	}
+	fields := collect(root.Elem(), "")
+
+	// Parse the flags first to learn which config file to read, but apply
+	// them last so they take precedence
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML configuration file")
+	flagValues := make(map[string]*rawFlag)
+	for _, f := range fields {
+		if name := f.tag.Get("flag"); name != "" {
+			flagValues[name] = &rawFlag{isBool: f.value.Kind() == reflect.Bool}
+			fs.Var(flagValues[name], name, usage(f))
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, f := range fields {
+		if def, ok := f.tag.Lookup("default"); ok {
+			if err := setValue(f.value, def); err != nil {
+				errs = append(errs, fmt.Errorf("invalid default %q for %s: %w", def, f.path, err))
+			}
+		}
+	}
+
+	if *configFile != "" {
+		data, err := os.ReadFile(*configFile)
+		if err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", *configFile, err)
+		}
+	}
+
+	// A missing .env file is not an error, the environment may be set directly
+	_ = godotenv.Load()
+
+	for _, f := range fields {
+		env := f.tag.Get("env")
+		if env == "" {
+			continue
+		}
+		if raw, ok := os.LookupEnv(env); ok && raw != "" {
+			if err := setValue(f.value, raw); err != nil {
+				errs = append(errs, fmt.Errorf("invalid value %q for %s: %w", raw, env, err))
+			}
+		}
+	}
+
+	for _, f := range fields {
+		fl, ok := flagValues[f.tag.Get("flag")]
+		if !ok || !fl.set {
+			continue
+		}
+		if err := setValue(f.value, fl.value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %q for --%s: %w", fl.value, f.tag.Get("flag"), err))
+		}
+	}
+
+	for _, f := range fields {
+		if f.tag.Get("required") == "true" && f.value.IsZero() {
+			errs = append(errs, fmt.Errorf("%s is required (%s)", f.path, sources(f)))
+		}
+	}
+
+	if v, ok := cfg.(interface{ Validate() error }); ok && len(errs) == 0 {
+		if err := v.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Print writes the effective configuration as YAML with secrets masked
+func Print(w io.Writer, cfg interface{}) error {
+	out, err := yaml.Marshal(toMap(reflect.Indirect(reflect.ValueOf(cfg))))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// rawFlag records the text of a command-line flag so it can be applied after
+// the other sources
+type rawFlag struct {
+	value  string
+	set    bool
+	isBool bool
+}
+
+func (f *rawFlag) String() string { return f.value }
+
+func (f *rawFlag) Set(s string) error {
+	f.value = s
+	f.set = true
+	return nil
+}
+
+func (f *rawFlag) IsBoolFlag() bool { return f.isBool }
+
+// collect walks the struct and returns its leaf fields
+func collect(v reflect.Value, prefix string) []field {
+	var fields []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		path := yamlName(sf)
+		if path == "-" {
+			path = strings.ToLower(sf.Name)
+		}
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct {
+			fields = append(fields, collect(fv, path)...)
+			continue
+		}
+		fields = append(fields, field{path: path, value: fv, tag: sf.Tag})
+	}
+	return fields
+}
+
+func toMap(v reflect.Value) map[string]interface{} {
+	out := make(map[string]interface{})
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := yamlName(sf)
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		switch {
+		case fv.Kind() == reflect.Struct:
+			out[name] = toMap(fv)
+		case sf.Tag.Get("secret") == "true" && !fv.IsZero():
+			out[name] = "********"
+		case fv.Type() == reflect.TypeOf(time.Duration(0)):
+			out[name] = fv.Interface().(time.Duration).String()
+		default:
+			out[name] = fv.Interface()
+		}
+	}
+	return out
+}
+
+func yamlName(sf reflect.StructField) string {
+	name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+	if name == "" {
+		name = strings.ToLower(sf.Name)
+	}
+	return name
+}
+
+func usage(f field) string {
+	u := f.path
+	if env := f.tag.Get("env"); env != "" {
+		u += ", env " + env
+	}
+	if def := f.tag.Get("default"); def != "" {
+		u += ", default " + def
+	}
+	return u
+}
+
+func sources(f field) string {
+	var s []string
+	if env := f.tag.Get("env"); env != "" {
+		s = append(s, "env "+env)
+	}
+	if fl := f.tag.Get("flag"); fl != "" {
+		s = append(s, "flag --"+fl)
+	}
+	s = append(s, "yaml "+f.path)
+	return "set " + strings.Join(s, ", ")
+}
+
+// setValue parses raw into the field according to its type
+func setValue(v reflect.Value, raw string) error {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return errors.New("expected a duration such as 5s")
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("expected an integer")
+		}
+		v.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.New("expected true or false")
+		}
+		v.SetBool(b)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return errors.New("expected a number")
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
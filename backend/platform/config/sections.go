@@ -0,0 +1,164 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The sections below are shared by the configuration of every service and
+// gateway, so that logging, tracing, transport security, authentication and
+// outgoing connections are set up the same way everywhere.
+
+type LogConfig struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL" flag:"log-level" default:"info"`
+	Format string `yaml:"format" env:"LOG_FORMAT" flag:"log-format" default:"json"`
+}
+
+type TracingConfig struct {
+	Exporter     string  `yaml:"exporter" env:"OTEL_TRACES_EXPORTER" flag:"trace-exporter" default:"none"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" default:"localhost:4317"`
+	OTLPInsecure bool    `yaml:"otlp_insecure" env:"OTEL_EXPORTER_OTLP_INSECURE" default:"true"`
+	SampleRatio  float64 `yaml:"sample_ratio" env:"OTEL_TRACES_SAMPLER_ARG" default:"1"`
+}
+
+type TLSConfig struct {
+	Enabled           bool          `yaml:"enabled" env:"TLS_ENABLED" flag:"tls" default:"false"`
+	CertFile          string        `yaml:"cert_file" env:"TLS_CERT_FILE" flag:"tls-cert"`
+	KeyFile           string        `yaml:"key_file" env:"TLS_KEY_FILE" flag:"tls-key"`
+	CAFile            string        `yaml:"ca_file" env:"TLS_CA_FILE" flag:"tls-ca"`
+	ClientAuth        bool          `yaml:"client_auth" env:"TLS_CLIENT_AUTH" flag:"tls-client-auth" default:"false"`
+	AllowedIdentities []string      `yaml:"allowed_identities" env:"TLS_ALLOWED_IDENTITIES" flag:"tls-allowed-identities"`
+	ReloadInterval    time.Duration `yaml:"reload_interval" env:"TLS_RELOAD_INTERVAL" default:"30s"`
+	DevMode           bool          `yaml:"dev_mode" env:"TLS_DEV_MODE" flag:"tls-dev" default:"false"`
+	DevDir            string        `yaml:"dev_dir" env:"TLS_DEV_DIR" default:".certs"`
+}
+
+type AuthConfig struct {
+	SigningKey     string        `yaml:"signing_key" env:"AUTH_SIGNING_KEY" secret:"true"`
+	Enforce        bool          `yaml:"enforce" env:"AUTH_ENFORCE" flag:"auth-enforce" default:"true"`
+	AllowedCallers []string      `yaml:"allowed_callers" env:"AUTH_ALLOWED_CALLERS" flag:"auth-allowed-callers"`
+	TrustedCallers []string      `yaml:"trusted_callers" env:"AUTH_TRUSTED_CALLERS" flag:"auth-trusted-callers" default:"platformctl,user-service,stream-service"`
+	TokenTTL       time.Duration `yaml:"token_ttl" env:"AUTH_TOKEN_TTL" default:"1m"`
+}
+
+// ClientConfig tunes the gRPC connections to other services
+type ClientConfig struct {
+	Timeout             time.Duration `yaml:"timeout" env:"CLIENT_TIMEOUT" flag:"client-timeout" default:"5s"`
+	MethodTimeouts      []string      `yaml:"method_timeouts" env:"CLIENT_METHOD_TIMEOUTS"`
+	RetryMaxAttempts    int           `yaml:"retry_max_attempts" env:"CLIENT_RETRY_MAX_ATTEMPTS" default:"3"`
+	RetryInitialBackoff time.Duration `yaml:"retry_initial_backoff" env:"CLIENT_RETRY_INITIAL_BACKOFF" default:"100ms"`
+	RetryMaxBackoff     time.Duration `yaml:"retry_max_backoff" env:"CLIENT_RETRY_MAX_BACKOFF" default:"1s"`
+	BreakerFailures     int           `yaml:"breaker_failures" env:"CLIENT_BREAKER_FAILURES" default:"5"`
+	BreakerOpenTimeout  time.Duration `yaml:"breaker_open_timeout" env:"CLIENT_BREAKER_OPEN_TIMEOUT" default:"10s"`
+	KeepaliveTime       time.Duration `yaml:"keepalive_time" env:"CLIENT_KEEPALIVE_TIME" default:"30s"`
+	KeepaliveTimeout    time.Duration `yaml:"keepalive_timeout" env:"CLIENT_KEEPALIVE_TIMEOUT" default:"10s"`
+	MaxConnectBackoff   time.Duration `yaml:"max_connect_backoff" env:"CLIENT_MAX_CONNECT_BACKOFF" default:"20s"`
+}
+
+// KeepaliveMinTime is the shortest ping interval the gRPC server accepts
+// from clients; CLIENT_KEEPALIVE_TIME may not be lower
+const KeepaliveMinTime = 10 * time.Second
+
+// MethodTimeoutMap parses CLIENT_METHOD_TIMEOUTS entries such as
+// "ListStreams=10s"
+func (c ClientConfig) MethodTimeoutMap() (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(c.MethodTimeouts))
+	for _, entry := range c.MethodTimeouts {
+		method, raw, ok := strings.Cut(entry, "=")
+		if !ok || method == "" {
+			return nil, fmt.Errorf("CLIENT_METHOD_TIMEOUTS entries must look like Method=duration, got %q", entry)
+		}
+		timeout, err := time.ParseDuration(raw)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("CLIENT_METHOD_TIMEOUTS has an invalid duration for %s: %q", method, raw)
+		}
+		timeouts[method] = timeout
+	}
+	return timeouts, nil
+}
+
+// Validate checks the tracing settings
+func (t TracingConfig) Validate() []error {
+	var errs []error
+	switch t.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		errs = append(errs, fmt.Errorf("OTEL_TRACES_EXPORTER must be one of none, stdout or otlp, got %q", t.Exporter))
+	}
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG must be between 0 and 1, got %v", t.SampleRatio))
+	}
+	return errs
+}
+
+// Validate checks that the files needed by the TLS settings are given
+func (t TLSConfig) Validate() []error {
+	var errs []error
+	if t.DevMode {
+		return nil
+	}
+	if t.Enabled && (t.CertFile == "" || t.KeyFile == "") {
+		errs = append(errs, errors.New("TLS_CERT_FILE and TLS_KEY_FILE are required when TLS_ENABLED is set"))
+	}
+	if t.ClientAuth && !t.Enabled {
+		errs = append(errs, errors.New("TLS_CLIENT_AUTH requires TLS_ENABLED"))
+	}
+	if t.ClientAuth && t.CAFile == "" {
+		errs = append(errs, errors.New("TLS_CA_FILE is required when TLS_CLIENT_AUTH is set"))
+	}
+	if len(t.AllowedIdentities) > 0 && !t.ClientAuth {
+		errs = append(errs, errors.New("TLS_ALLOWED_IDENTITIES requires TLS_CLIENT_AUTH"))
+	}
+	return errs
+}
+
+// Validate checks the signing key and lifetime of service tokens
+func (a AuthConfig) Validate() []error {
+	var errs []error
+	if a.Enforce && a.SigningKey == "" {
+		errs = append(errs, errors.New("AUTH_SIGNING_KEY is required when AUTH_ENFORCE is set"))
+	}
+	if a.SigningKey != "" && len(a.SigningKey) < 32 {
+		errs = append(errs, errors.New("AUTH_SIGNING_KEY must be at least 32 characters long"))
+	}
+	if a.TokenTTL <= 0 {
+		errs = append(errs, fmt.Errorf("AUTH_TOKEN_TTL must be positive, got %s", a.TokenTTL))
+	}
+	return errs
+}
+
+// Validate checks the deadlines, retries, circuit breaker and keepalive
+// settings of outgoing connections
+func (c ClientConfig) Validate() []error {
+	var errs []error
+	if c.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("CLIENT_TIMEOUT must be positive, got %s", c.Timeout))
+	}
+	if _, err := c.MethodTimeoutMap(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.RetryMaxAttempts < 1 || c.RetryMaxAttempts > 5 {
+		errs = append(errs, fmt.Errorf("CLIENT_RETRY_MAX_ATTEMPTS must be between 1 and 5, got %d", c.RetryMaxAttempts))
+	}
+	if c.RetryInitialBackoff <= 0 || c.RetryMaxBackoff < c.RetryInitialBackoff {
+		errs = append(errs, errors.New("CLIENT_RETRY_INITIAL_BACKOFF must be positive and not above CLIENT_RETRY_MAX_BACKOFF"))
+	}
+	if c.BreakerFailures < 0 {
+		errs = append(errs, fmt.Errorf("CLIENT_BREAKER_FAILURES must not be negative, got %d", c.BreakerFailures))
+	}
+	if c.BreakerFailures > 0 && c.BreakerOpenTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("CLIENT_BREAKER_OPEN_TIMEOUT must be positive, got %s", c.BreakerOpenTimeout))
+	}
+	if c.KeepaliveTime < KeepaliveMinTime {
+		errs = append(errs, fmt.Errorf("CLIENT_KEEPALIVE_TIME must be at least %s, got %s", KeepaliveMinTime, c.KeepaliveTime))
+	}
+	if c.KeepaliveTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("CLIENT_KEEPALIVE_TIMEOUT must be positive, got %s", c.KeepaliveTimeout))
+	}
+	if c.MaxConnectBackoff < time.Second {
+		errs = append(errs, fmt.Errorf("CLIENT_MAX_CONNECT_BACKOFF must be at least 1s, got %s", c.MaxConnectBackoff))
+	}
+	return errs
+}
@@ -0,0 +1,177 @@
+package dial
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// State is the state of a circuit breaker
+type State int
+
+const (
+	// StateClosed lets every call through
+	StateClosed State = iota
+	// StateHalfOpen lets a single trial call through after the open period
+	StateHalfOpen
+	// StateOpen fails every call fast
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "open"
+	}
+}
+
+// BreakerConfig controls when a dependency is considered down
+type BreakerConfig struct {
+	// Failures is the number of consecutive failed calls that opens the
+	// breaker. Zero disables the breaker.
+	Failures int
+	// OpenTimeout is how long the breaker fails fast before letting a trial
+	// call through
+	OpenTimeout time.Duration
+}
+
+// Breaker is a consecutive-failure circuit breaker shared by every call to a
+// dependency. Only failures that point at an unhealthy dependency count;
+// errors such as NotFound or InvalidArgument are answers, not outages.
+type Breaker struct {
+	name          string
+	cfg           BreakerConfig
+	onStateChange func(name string, from, to State)
+	now           func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewBreaker returns a closed breaker for the dependency name
+func NewBreaker(name string, cfg BreakerConfig, onStateChange func(name string, from, to State)) *Breaker {
+	if onStateChange == nil {
+		onStateChange = func(string, State, State) {}
+	}
+	return &Breaker{name: name, cfg: cfg, onStateChange: onStateChange, now: time.Now}
+}
+
+// State returns the current state of the breaker
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow reports whether a call may proceed. A call allowed in the half-open
+// state is the trial whose outcome decides the next state.
+func (b *Breaker) allow() bool {
+	if b.cfg.Failures <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return false
+		}
+		b.setState(StateHalfOpen)
+		b.trial = true
+		return true
+	case StateHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the outcome of an allowed call
+func (b *Breaker) record(err error) {
+	if b.cfg.Failures <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isFailure(err) {
+		b.failures = 0
+		b.trial = false
+		if b.state != StateClosed {
+			b.setState(StateClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.cfg.Failures {
+		b.trial = false
+		b.openedAt = b.now()
+		if b.state != StateOpen {
+			b.setState(StateOpen)
+		}
+	}
+}
+
+func (b *Breaker) setState(to State) {
+	from := b.state
+	b.state = to
+	b.onStateChange(b.name, from, to)
+}
+
+func isFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *Breaker) openError() error {
+	return status.Errorf(codes.Unavailable, "circuit breaker open for %s", b.name)
+}
+
+// UnaryClientInterceptor fails calls fast with Unavailable while the breaker
+// is open
+func (b *Breaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow() {
+			return b.openError()
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(err)
+		return err
+	}
+}
+
+// StreamClientInterceptor guards the creation of streams. Only failures to
+// open a stream are counted.
+func (b *Breaker) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if !b.allow() {
+			return nil, b.openError()
+		}
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		b.record(err)
+		return stream, err
+	}
+}
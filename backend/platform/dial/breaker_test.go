@@ -0,0 +1,117 @@
+package dial
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreaker(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	notFound := status.Error(codes.NotFound, "stream not found")
+
+	// a step either asks to make a call, records the outcome of the last
+	// allowed call or lets time pass
+	type step struct {
+		wait    time.Duration
+		call    bool
+		allowed bool
+		err     error
+		record  bool
+	}
+	call := func(allowed bool) step { return step{call: true, allowed: allowed} }
+	record := func(err error) step { return step{record: true, err: err} }
+	wait := func(d time.Duration) step { return step{wait: d} }
+
+	tests := []struct {
+		name        string
+		cfg         BreakerConfig
+		steps       []step
+		state       State
+		transitions string
+	}{
+		{
+			"stays closed below the threshold",
+			BreakerConfig{Failures: 3, OpenTimeout: time.Minute},
+			[]step{call(true), record(unavailable), call(true), record(unavailable), call(true)},
+			StateClosed, "[]",
+		},
+		{
+			"answers are not failures",
+			BreakerConfig{Failures: 2, OpenTimeout: time.Minute},
+			[]step{call(true), record(notFound), call(true), record(notFound), call(true), record(nil)},
+			StateClosed, "[]",
+		},
+		{
+			"a success resets the count",
+			BreakerConfig{Failures: 2, OpenTimeout: time.Minute},
+			[]step{call(true), record(unavailable), call(true), record(nil), call(true), record(unavailable), call(true)},
+			StateClosed, "[]",
+		},
+		{
+			"opens after consecutive failures",
+			BreakerConfig{Failures: 2, OpenTimeout: time.Minute},
+			[]step{call(true), record(unavailable), call(true), record(status.Error(codes.DeadlineExceeded, "timeout")), call(false), wait(time.Minute - time.Second), call(false)},
+			StateOpen, "[closed->open]",
+		},
+		{
+			"lets a single trial through once the timeout passed",
+			BreakerConfig{Failures: 1, OpenTimeout: time.Minute},
+			[]step{call(true), record(unavailable), wait(time.Minute), call(true), call(false)},
+			StateHalfOpen, "[closed->open open->half-open]",
+		},
+		{
+			"closes after a successful trial",
+			BreakerConfig{Failures: 1, OpenTimeout: time.Minute},
+			[]step{call(true), record(unavailable), wait(time.Minute), call(true), record(notFound), call(true)},
+			StateClosed, "[closed->open open->half-open half-open->closed]",
+		},
+		{
+			"reopens after a failed trial",
+			BreakerConfig{Failures: 3, OpenTimeout: time.Minute},
+			[]step{call(true), record(unavailable), call(true), record(unavailable), call(true), record(unavailable), wait(time.Minute), call(true), record(unavailable), call(false), wait(time.Minute), call(true)},
+			StateHalfOpen, "[closed->open open->half-open half-open->open open->half-open]",
+		},
+		{
+			"disabled",
+			BreakerConfig{},
+			[]step{call(true), record(unavailable), call(true), record(unavailable), call(true)},
+			StateClosed, "[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var transitions []string
+			b := NewBreaker("stream-service", tt.cfg, func(name string, from, to State) {
+				if name != "stream-service" {
+					t.Errorf("state change reported for %q", name)
+				}
+				transitions = append(transitions, from.String()+"->"+to.String())
+			})
+			now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+			b.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				switch {
+				case s.wait > 0:
+					now = now.Add(s.wait)
+				case s.call:
+					if got := b.allow(); got != s.allowed {
+						t.Fatalf("step %d: allow returned %v in state %v, want %v", i, got, b.State(), s.allowed)
+					}
+				case s.record:
+					b.record(s.err)
+				}
+			}
+			if got := b.State(); got != tt.state {
+				t.Errorf("state is %v, want %v", got, tt.state)
+			}
+			if got := fmt.Sprint(transitions); got != tt.transitions {
+				t.Errorf("transitions are %s, want %s", got, tt.transitions)
+			}
+		})
+	}
+}
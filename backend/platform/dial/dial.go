@@ -0,0 +1,251 @@
+package dial
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/metrics"
+	"github.com/clementus360/platform/tlsconfig"
+	"github.com/clementus360/platform/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Config tunes every connection made by a Dialer
+type Config struct {
+	// Timeout is the deadline applied to calls that do not set a shorter one
+	Timeout time.Duration
+	// MethodTimeouts overrides Timeout for individual methods, keyed by the
+	// method name (for example "ListStreams")
+	MethodTimeouts map[string]time.Duration
+	// Retry applies to the idempotent methods of each target
+	Retry RetryConfig
+	// Breaker is created once per target
+	Breaker BreakerConfig
+	// KeepaliveTime is the idle time after which the connection is pinged and
+	// KeepaliveTimeout how long to wait for the ping acknowledgement
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// MaxConnectBackoff caps the delay between reconnection attempts
+	MaxConnectBackoff time.Duration
+	// DialOptions are appended to the options of every connection, for
+	// example a context dialer that connects to an in-memory listener
+	DialOptions []grpc.DialOption
+}
+
+// RetryConfig is the gRPC retry policy of idempotent methods
+type RetryConfig struct {
+	// MaxAttempts includes the original call; 1 disables retries. gRPC caps
+	// it at 5.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Target describes a dependency to connect to
+type Target struct {
+	// Name labels the metrics and the circuit breaker, such as
+	// "database_service"
+	Name string
+	// Audience is the service the tokens of outgoing calls are minted for
+	Audience string
+	// Address is the host:port of the dependency
+	Address string
+	// Service is the fully qualified gRPC service name, such as
+	// "stream.StreamService"
+	Service string
+	// Idempotent lists the methods that are safe to retry
+	Idempotent []string
+	// Streaming lists the streaming methods, such as those returned by
+	// StreamingMethods. They last as long as the caller needs them, so they
+	// do not get the default Timeout.
+	Streaming []string
+}
+
+// StreamingMethods lists the streaming methods of a service
+func StreamingMethods(desc grpc.ServiceDesc) []string {
+	methods := make([]string, 0, len(desc.Streams))
+	for _, stream := range desc.Streams {
+		methods = append(methods, stream.StreamName)
+	}
+	return methods
+}
+
+// Dialer builds client connections that share the transport security,
+// authentication, observability and resilience settings of a service
+type Dialer struct {
+	cfg           Config
+	tls           *tlsconfig.Manager
+	authenticator *auth.Authenticator
+	metrics       *metrics.Metrics
+}
+
+// NewDialer returns a Dialer for the given settings
+func NewDialer(cfg Config, tlsManager *tlsconfig.Manager, authenticator *auth.Authenticator, m *metrics.Metrics) *Dialer {
+	return &Dialer{
+		cfg:           cfg,
+		tls:           tlsManager,
+		authenticator: authenticator,
+		metrics:       m,
+	}
+}
+
+// Dial creates a connection to target. Like grpc.NewClient it does not wait
+// for the connection to be established.
+func (d *Dialer) Dial(target Target) (*grpc.ClientConn, error) {
+	serviceConfig, err := d.serviceConfig(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build service config for %s: %w", target.Name, err)
+	}
+
+	breaker := NewBreaker(target.Name, d.cfg.Breaker, d.breakerStateChanged)
+	d.metrics.SetBreakerState(target.Name, int(StateClosed))
+
+	opts := []grpc.DialOption{
+		d.tls.DialOption(),
+		tracing.DialOption(),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                d.cfg.KeepaliveTime,
+			Timeout:             d.cfg.KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  time.Second,
+				Multiplier: 1.6,
+				Jitter:     0.2,
+				MaxDelay:   d.cfg.MaxConnectBackoff,
+			},
+			MinConnectTimeout: 5 * time.Second,
+		}),
+		// The breaker sees the outcome after retries, so a call that
+		// succeeded on a second attempt does not count as a failure
+		grpc.WithChainUnaryInterceptor(
+			logging.UnaryClientInterceptor(),
+			d.metrics.UnaryClientInterceptor(target.Name),
+			breaker.UnaryClientInterceptor(),
+			d.authenticator.UnaryClientInterceptor(target.Audience),
+		),
+		grpc.WithChainStreamInterceptor(
+			logging.StreamClientInterceptor(),
+			breaker.StreamClientInterceptor(),
+			d.authenticator.StreamClientInterceptor(target.Audience),
+		),
+	}
+	opts = append(opts, d.cfg.DialOptions...)
+
+	return grpc.NewClient(target.Address, opts...)
+}
+
+func (d *Dialer) breakerStateChanged(name string, from, to State) {
+	d.metrics.SetBreakerState(name, int(to))
+	level := slog.LevelInfo
+	if to == StateOpen {
+		level = slog.LevelWarn
+	}
+	slog.Log(context.Background(), level, "Circuit breaker state changed", "dependency", name, "from", from.String(), "to", to.String())
+}
+
+// The types below mirror the JSON service config understood by grpc-go,
+// see https://github.com/grpc/grpc/blob/master/doc/service_config.md
+
+type serviceConfig struct {
+	MethodConfig []methodConfig `json:"methodConfig"`
+}
+
+type methodName struct {
+	Service string `json:"service,omitempty"`
+	Method  string `json:"method,omitempty"`
+}
+
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	Timeout     string       `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+// serviceConfig renders the deadlines and retry policy of target. Each
+// method gets at most one entry: methods with their own timeout, a retry
+// policy or that stream are listed by name and everything else falls back to
+// the default entry.
+func (d *Dialer) serviceConfig(target Target) (string, error) {
+	methods := make(map[string]*methodConfig)
+	entry := func(method string) *methodConfig {
+		if mc, ok := methods[method]; ok {
+			return mc
+		}
+		mc := &methodConfig{
+			Name:    []methodName{{Service: target.Service, Method: method}},
+			Timeout: seconds(d.cfg.Timeout),
+		}
+		methods[method] = mc
+		return mc
+	}
+
+	if target.Service != "" {
+		// Streams only end at the deadline of their context, unless they are
+		// given a timeout of their own below
+		for _, method := range target.Streaming {
+			entry(method).Timeout = ""
+		}
+		for method, timeout := range d.cfg.MethodTimeouts {
+			entry(method).Timeout = seconds(timeout)
+		}
+		if d.cfg.Retry.MaxAttempts > 1 {
+			for _, method := range target.Idempotent {
+				entry(method).RetryPolicy = &retryPolicy{
+					MaxAttempts:          d.cfg.Retry.MaxAttempts,
+					InitialBackoff:       seconds(d.cfg.Retry.InitialBackoff),
+					MaxBackoff:           seconds(d.cfg.Retry.MaxBackoff),
+					BackoffMultiplier:    2,
+					RetryableStatusCodes: []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"},
+				}
+			}
+		}
+	}
+
+	// An entry without a name is the default for every method
+	cfg := serviceConfig{MethodConfig: []methodConfig{{
+		Name:    []methodName{{}},
+		Timeout: seconds(d.cfg.Timeout),
+	}}}
+	names := make([]string, 0, len(methods))
+	for method := range methods {
+		names = append(names, method)
+	}
+	sort.Strings(names)
+	for _, method := range names {
+		cfg.MethodConfig = append(cfg.MethodConfig, *methods[method])
+	}
+
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// seconds formats a duration the way the service config expects, e.g.
+// "1.5s". Zero means no timeout and is left out.
+func seconds(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%gs", d.Seconds())
+}
@@ -0,0 +1,189 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Probe checks a single dependency and returns an error when it is unusable
+type Probe func(ctx context.Context) error
+
+type namedProbe struct {
+	name  string
+	probe Probe
+}
+
+// Checker runs dependency probes and reports the result through the
+// grpc.health.v1 service and the /healthz and /readyz HTTP endpoints
+type Checker struct {
+	timeout  time.Duration
+	services []string
+	probes   []namedProbe
+
+	grpcHealth   *grpchealth.Server
+	shuttingDown atomic.Bool
+}
+
+// NewChecker creates a checker whose probes are each bounded by timeout.
+// services are the fully qualified gRPC service names reported alongside the
+// overall ("") status.
+func NewChecker(timeout time.Duration, services ...string) *Checker {
+	return &Checker{
+		timeout:    timeout,
+		services:   services,
+		grpcHealth: grpchealth.NewServer(),
+	}
+}
+
+// AddProbe registers a dependency probe
+func (c *Checker) AddProbe(name string, probe Probe) {
+	c.probes = append(c.probes, namedProbe{name: name, probe: probe})
+}
+
+// Register exposes the grpc.health.v1 service on the server
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.grpcHealth)
+}
+
+// Check runs every probe concurrently and returns the failures keyed by
+// probe name. It reports ready only when all probes pass and the service is
+// not shutting down.
+func (c *Checker) Check(ctx context.Context) (bool, map[string]string) {
+	results := make(map[string]string, len(c.probes))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, p := range c.probes {
+		wg.Add(1)
+		go func(p namedProbe) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			result := "ok"
+			if err := p.probe(probeCtx); err != nil {
+				result = err.Error()
+			}
+			mu.Lock()
+			results[p.name] = result
+			mu.Unlock()
+		}(p)
+	}
+	wg.Wait()
+
+	ready := !c.shuttingDown.Load()
+	for _, result := range results {
+		if result != "ok" {
+			ready = false
+		}
+	}
+	return ready, results
+}
+
+// Run keeps the gRPC serving status in sync with the probes until ctx is
+// cancelled
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ready, results := c.Check(ctx)
+		if !c.shuttingDown.Load() {
+			c.setStatus(ready)
+		}
+		if !ready {
+			slog.Warn("Readiness check failed", "probes", results)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Checker) setStatus(ready bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if ready {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	c.grpcHealth.SetServingStatus("", status)
+	for _, service := range c.services {
+		c.grpcHealth.SetServingStatus(service, status)
+	}
+}
+
+// Shutdown flips every status to NOT_SERVING so load balancers stop sending
+// new traffic while in-flight requests drain
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+	c.grpcHealth.Shutdown()
+}
+
+// LivenessHandler reports whether the process is up. It never probes
+// dependencies so a failing dependency does not get the service restarted.
+func (c *Checker) LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status": "ok",
+		})
+	}
+}
+
+// ReadinessHandler probes every dependency and answers 503 when one of them
+// fails or the service is shutting down
+func (c *Checker) ReadinessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ready, results := c.Check(r.Context())
+
+		statusCode := http.StatusOK
+		status := "ok"
+		if !ready {
+			statusCode = http.StatusServiceUnavailable
+			status = "unavailable"
+		}
+		if c.shuttingDown.Load() {
+			status = "shutting_down"
+		}
+
+		writeJSON(w, statusCode, map[string]interface{}{
+			"status": status,
+			"checks": results,
+		})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// ConnProbe reports whether a gRPC client connection can reach its target,
+// triggering a connection attempt when the channel is idle
+func ConnProbe(conn *grpc.ClientConn) Probe {
+	return func(ctx context.Context) error {
+		conn.Connect()
+		for {
+			state := conn.GetState()
+			if state == connectivity.Ready {
+				return nil
+			}
+			if !conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("connection %s: %w", state, ctx.Err())
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// incomingContext resolves the request id from the incoming metadata and
+// attaches it, together with a request-scoped logger, to the context
+func incomingContext(ctx context.Context, logger *slog.Logger) (context.Context, *slog.Logger) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = NewRequestID()
+	}
+
+	reqLogger := withTrace(ctx, logger.With("request_id", requestID))
+	ctx = WithRequestID(ctx, requestID)
+	ctx = WithLogger(ctx, reqLogger)
+	return ctx, reqLogger
+}
+
+func logCall(ctx context.Context, logger *slog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+	var level slog.Level
+	switch code {
+	case codes.OK, codes.NotFound, codes.InvalidArgument, codes.AlreadyExists:
+		level = slog.LevelInfo
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+
+	attrs := []any{
+		"method", method,
+		"code", code.String(),
+		"latency_ms", time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	logger.Log(ctx, level, "grpc request", attrs...)
+}
+
+// UnaryServerInterceptor attaches a request id and logger to every unary call
+// and logs its outcome
+func UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, reqLogger := incomingContext(ctx, logger)
+		resp, err := handler(ctx, req)
+		logCall(ctx, reqLogger, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// wrappedStream overrides the context of a server stream
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor
+func StreamServerInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, reqLogger := incomingContext(ss.Context(), logger)
+		err := handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, reqLogger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// outgoingContext forwards the request id to downstream services
+func outgoingContext(ctx context.Context) context.Context {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return metadata.AppendToOutgoingContext(ctx, RequestIDHeader, requestID)
+	}
+	return ctx
+}
+
+// UnaryClientInterceptor propagates the request id on outgoing unary calls
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor propagates the request id on outgoing streams
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys lists attribute keys whose values must never reach the logs
+var sensitiveKeys = []string{
+	"stream_key",
+	"streamkey",
+	"password",
+	"secret",
+	"token",
+	"authorization",
+	"api_key",
+}
+
+// IsSensitive reports whether an attribute key holds a secret
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact masks a secret, keeping the last four characters so operators can
+// still tell two values apart
+func Redact(value string) string {
+	if len(value) <= 4 {
+		return redacted
+	}
+	return redacted + value[len(value)-4:]
+}
+
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if IsSensitive(a.Key) {
+		return slog.String(a.Key, Redact(a.Value.String()))
+	}
+	return a
+}
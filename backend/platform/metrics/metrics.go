@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics holds the Prometheus registry of a service together with the
+// request/error/duration (RED) collectors shared by every transport
+type Metrics struct {
+	namespace string
+	registry  *prometheus.Registry
+
+	grpcRequests *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	clientRequests *prometheus.CounterVec
+	clientDuration *prometheus.HistogramVec
+	breakerState   *prometheus.GaugeVec
+}
+
+// New creates the collectors under the given namespace (e.g. "stream_service")
+func New(namespace string) *Metrics {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	m := &Metrics{
+		namespace: namespace,
+		registry:  registry,
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_server_requests_total",
+			Help:      "Total gRPC requests handled, by method and status code.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_server_request_duration_seconds",
+			Help:      "Duration of gRPC requests handled, by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Total HTTP requests handled, by route, method and status.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests handled, by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		clientRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_client_requests_total",
+			Help:      "Total outbound gRPC calls, by dependency, method and status code.",
+		}, []string{"dependency", "method", "code"}),
+		clientDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_client_request_duration_seconds",
+			Help:      "Duration of outbound gRPC calls, by dependency and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"dependency", "method"}),
+		breakerState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "grpc_client_circuit_breaker_state",
+			Help:      "State of the circuit breaker of each dependency: 0 closed, 1 half-open, 2 open.",
+		}, []string{"dependency"}),
+	}
+
+	registry.MustRegister(
+		m.grpcRequests, m.grpcDuration,
+		m.httpRequests, m.httpDuration,
+		m.clientRequests, m.clientDuration, m.breakerState,
+	)
+
+	return m
+}
+
+// Namespace is the namespace of the collectors, which services also put
+// their business metrics under
+func (m *Metrics) Namespace() string {
+	return m.namespace
+}
+
+// Registry is where services register their business metrics
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// UnaryServerInterceptor records RED metrics for unary gRPC calls
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observeServer(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records RED metrics for streaming gRPC calls
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observeServer(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (m *Metrics) observeServer(method string, start time.Time, err error) {
+	m.grpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	m.grpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// UnaryClientInterceptor records metrics for outbound calls to a dependency
+func (m *Metrics) UnaryClientInterceptor(dependency string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		m.clientRequests.WithLabelValues(dependency, method, status.Code(err).String()).Inc()
+		m.clientDuration.WithLabelValues(dependency, method).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// SetBreakerState records the circuit breaker state of a dependency
+func (m *Metrics) SetBreakerState(dependency string, state int) {
+	m.breakerState.WithLabelValues(dependency).Set(float64(state))
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Middleware records RED metrics for HTTP requests. It must wrap the
+// ServeMux directly so the matched route pattern can be used as a label
+// instead of the raw path.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		m.httpRequests.WithLabelValues(route, r.Method, strconv.Itoa(rec.status)).Inc()
+		m.httpDuration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+	})
+}
@@ -0,0 +1,178 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Development certificates are written to a shared directory so services
+// started from the same checkout trust each other. The CA is created once
+// and reused; service certificates are renewed when they are close to
+// expiry or were signed by another CA.
+const (
+	devCAName       = "stream-platform development CA"
+	devCAValidity   = 365 * 24 * time.Hour
+	devCertValidity = 30 * 24 * time.Hour
+	devRenewBefore  = 7 * 24 * time.Hour
+)
+
+type devFiles struct {
+	cert, key, ca string
+}
+
+func ensureDevCertificates(dir, service string) (devFiles, error) {
+	if dir == "" {
+		return devFiles{}, errors.New("a directory for development certificates is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return devFiles{}, err
+	}
+
+	files := devFiles{
+		ca:   filepath.Join(dir, "ca.pem"),
+		cert: filepath.Join(dir, service+".pem"),
+		key:  filepath.Join(dir, service+"-key.pem"),
+	}
+	caKeyFile := filepath.Join(dir, "ca-key.pem")
+
+	ca, err := tls.LoadX509KeyPair(files.ca, caKeyFile)
+	if err != nil {
+		if ca, err = createDevCA(files.ca, caKeyFile); err != nil {
+			return devFiles{}, err
+		}
+	}
+	caCert, err := x509.ParseCertificate(ca.Certificate[0])
+	if err != nil {
+		return devFiles{}, err
+	}
+
+	if existing, err := tls.LoadX509KeyPair(files.cert, files.key); err == nil {
+		leaf, err := x509.ParseCertificate(existing.Certificate[0])
+		if err == nil && time.Until(leaf.NotAfter) > devRenewBefore && leaf.CheckSignatureFrom(caCert) == nil {
+			return files, nil
+		}
+	}
+
+	if err := createDevCertificate(files.cert, files.key, service, caCert, ca.PrivateKey); err != nil {
+		return devFiles{}, err
+	}
+	return files, nil
+}
+
+func createDevCA(certFile, keyFile string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: devCAName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(devCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	if err := writePEM(certFile, keyFile, der, key); err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.LoadX509KeyPair(certFile, keyFile)
+}
+
+func createDevCertificate(certFile, keyFile, service string, ca *x509.Certificate, caKey any) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return err
+	}
+
+	// The certificate names the service as its identity and covers the
+	// addresses services are usually reached at in development
+	dnsNames := []string{service, "localhost"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		dnsNames = append(dnsNames, hostname)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: service},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(devCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     dnsNames,
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	return writePEM(certFile, keyFile, der, key)
+}
+
+func writePEM(certFile, keyFile string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	// Write the key before the certificate so a reader never pairs a new
+	// certificate with an old key
+	if err := writeFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		return err
+	}
+	return writeFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644)
+}
+
+// writeFile replaces path atomically so watchers never read a partial file
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func newSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
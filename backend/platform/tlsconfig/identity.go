@@ -0,0 +1,40 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/x509"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Identities lists the names a certificate identifies its owner by: the
+// subject common name followed by any URI SANs (such as SPIFFE ids)
+func Identities(cert *x509.Certificate) []string {
+	var ids []string
+	if cert.Subject.CommonName != "" {
+		ids = append(ids, cert.Subject.CommonName)
+	}
+	for _, uri := range cert.URIs {
+		ids = append(ids, uri.String())
+	}
+	return ids
+}
+
+// PeerIdentity returns the identity of the client certificate of the current
+// gRPC call, or an empty string when the call was not made over mTLS
+func PeerIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	ids := Identities(info.State.VerifiedChains[0][0])
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[0]
+}
@@ -0,0 +1,110 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// store keeps the current certificate and CA pool and swaps them when the
+// files change
+type store struct {
+	certFile, keyFile, caFile string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+	modTime time.Time
+}
+
+func newStore(certFile, keyFile, caFile string) (*store, error) {
+	s := &store{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// current returns the certificate and CA pool to use for a new handshake
+func (s *store) current() (*tls.Certificate, *x509.CertPool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cert, s.pool
+}
+
+// reload loads the files again when any of them was modified since the last
+// load
+func (s *store) reload() (bool, error) {
+	modTime, err := s.latestModTime()
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	unchanged := !modTime.After(s.modTime)
+	s.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	if err := s.load(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *store) load() error {
+	modTime, err := s.latestModTime()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.certFile, s.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate %s: %w", s.certFile, err)
+	}
+
+	// A nil pool makes verification fall back to the system roots
+	var pool *x509.CertPool
+	if s.caFile != "" {
+		data, err := os.ReadFile(s.caFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificates found in CA bundle %s", s.caFile)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cert = &cert
+	s.pool = pool
+	s.modTime = modTime
+	return nil
+}
+
+func (s *store) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{s.certFile, s.keyFile, s.caFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	if latest.IsZero() {
+		return latest, errors.New("no certificate files configured")
+	}
+	return latest, nil
+}
@@ -0,0 +1,53 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"log/slog"
+	"net/url"
+	"testing"
+)
+
+func TestVerifyClient(t *testing.T) {
+	certificate := func(commonName string, uris ...string) *x509.Certificate {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+		for _, uri := range uris {
+			u, err := url.Parse(uri)
+			if err != nil {
+				t.Fatalf("url.Parse(%q): %v", uri, err)
+			}
+			cert.URIs = append(cert.URIs, u)
+		}
+		return cert
+	}
+
+	tests := []struct {
+		name    string
+		allowed []string
+		peer    []*x509.Certificate
+		wantErr bool
+	}{
+		{"no allow-list", nil, []*x509.Certificate{certificate("comment-service")}, false},
+		{"no allow-list or certificate", nil, nil, false},
+		{"allowed common name", []string{"api-gateway", "comment-service"}, []*x509.Certificate{certificate("comment-service")}, false},
+		{"allowed uri", []string{"spiffe://platform/api-gateway"}, []*x509.Certificate{certificate("gateway", "spiffe://platform/api-gateway")}, false},
+		{"identity not allowed", []string{"api-gateway"}, []*x509.Certificate{certificate("comment-service", "spiffe://platform/comment-service")}, true},
+		{"only the leaf counts", []string{"platform-ca"}, []*x509.Certificate{certificate("comment-service"), certificate("platform-ca")}, true},
+		{"no common name", []string{""}, []*x509.Certificate{certificate("")}, true},
+		{"no certificate", []string{"api-gateway"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manager{
+				cfg:    Config{ClientAuth: true, AllowedIdentities: tt.allowed},
+				logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+			err := m.verifyClient(tls.ConnectionState{PeerCertificates: tt.peer})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyClient returned %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
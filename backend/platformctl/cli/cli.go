@@ -0,0 +1,300 @@
+// Package cli implements platformctl, the command-line tool support engineers
+// use to inspect and repair streams, users and comments through the gRPC APIs
+// of the services.
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Exit codes
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// usageError reports invalid arguments; the usage of the command is printed
+// after it
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...any) error {
+	return usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// command is a subcommand such as "streams list"
+type command struct {
+	name string
+	// args describes the positional arguments in the usage
+	args    string
+	summary string
+	// setup registers the flags of the command and returns the function that
+	// runs it with the positional arguments
+	setup func(fs *flag.FlagSet) func(ctx context.Context, args []string) error
+}
+
+// group is a resource and its commands
+type group struct {
+	name     string
+	summary  string
+	commands []command
+}
+
+// app is one invocation of platformctl
+type app struct {
+	stdin          *bufio.Reader
+	stdout, stderr io.Writer
+	dialOptions    []grpc.DialOption
+
+	configPath  string
+	profileName string
+	output      string
+	timeout     time.Duration
+	yes         bool
+
+	clients *clients
+	out     *printer
+}
+
+// Main runs platformctl with args, the command line without the program
+// name, and returns the exit code. dialOptions are added to the connections
+// to the services.
+func Main(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, dialOptions ...grpc.DialOption) int {
+	a := &app{
+		stdin:       bufio.NewReader(stdin),
+		stdout:      stdout,
+		stderr:      stderr,
+		dialOptions: dialOptions,
+	}
+	return a.run(ctx, args)
+}
+
+func (a *app) groups() []group {
+	return []group{
+		{name: "streams", summary: "Inspect and manage streams", commands: a.streamCommands()},
+		{name: "users", summary: "Inspect and manage users", commands: a.userCommands()},
+		{name: "comments", summary: "Inspect and moderate comments", commands: a.commentCommands()},
+		{name: "profiles", summary: "Show the configured environments", commands: a.profileCommands()},
+	}
+}
+
+// globalFlags are accepted before the group and after the command
+func (a *app) globalFlags(fs *flag.FlagSet) {
+	fs.StringVar(&a.configPath, "config", a.configPath, "configuration file (default $"+configEnv+" or "+defaultConfigPath()+")")
+	fs.StringVar(&a.profileName, "profile", a.profileName, "profile of the environment to use (default $"+profileEnv+" or the current profile)")
+	fs.StringVar(&a.output, "o", a.output, "output format: table or json")
+	fs.StringVar(&a.output, "output", a.output, "output format: table or json")
+	fs.DurationVar(&a.timeout, "timeout", a.timeout, "timeout of the whole command")
+	fs.BoolVar(&a.yes, "y", a.yes, "do not ask before destructive actions")
+	fs.BoolVar(&a.yes, "yes", a.yes, "do not ask before destructive actions")
+}
+
+func (a *app) run(ctx context.Context, args []string) int {
+	a.output = formatTable
+	a.timeout = 30 * time.Second
+
+	fs := flag.NewFlagSet("platformctl", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	a.globalFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			a.usage(a.stdout)
+			return exitOK
+		}
+		fmt.Fprintf(a.stderr, "platformctl: %v\n", err)
+		a.usage(a.stderr)
+		return exitUsage
+	}
+	args = fs.Args()
+
+	if len(args) == 0 || args[0] == "help" {
+		a.usage(a.stderr)
+		return exitUsage
+	}
+	g, ok := a.group(args[0])
+	if !ok {
+		fmt.Fprintf(a.stderr, "platformctl: unknown command %q\n", args[0])
+		a.usage(a.stderr)
+		return exitUsage
+	}
+	if len(args) == 1 || args[1] == "help" {
+		a.groupUsage(a.stderr, g)
+		return exitUsage
+	}
+	cmd, ok := g.command(args[1])
+	if !ok {
+		fmt.Fprintf(a.stderr, "platformctl: unknown command %q\n", g.name+" "+args[1])
+		a.groupUsage(a.stderr, g)
+		return exitUsage
+	}
+
+	name := "platformctl " + g.name + " " + cmd.name
+	fs = flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	a.globalFlags(fs)
+	runCommand := cmd.setup(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [flags] %s\n\n%s\n\nFlags:\n", name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		return exitUsage
+	}
+	if a.output != formatTable && a.output != formatJSON {
+		fmt.Fprintf(a.stderr, "platformctl: unknown output format %q, use table or json\n", a.output)
+		return exitUsage
+	}
+	a.out = &printer{w: a.stdout, format: a.output}
+
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+	defer func() {
+		if a.clients != nil {
+			a.clients.close()
+		}
+	}()
+
+	if err := runCommand(ctx, positional); err != nil {
+		if errors.As(err, new(usageError)) {
+			fmt.Fprintf(a.stderr, "platformctl: %v\n", err)
+			fs.Usage()
+			return exitUsage
+		}
+		fmt.Fprintf(a.stderr, "platformctl: %s\n", describe(err))
+		return exitError
+	}
+	return exitOK
+}
+
+// parseInterspersed parses flags placed anywhere among the positional
+// arguments, which the flag package stops at, and returns the positional ones
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// services returns the clients of the selected profile
+func (a *app) services() (*clients, error) {
+	if a.clients != nil {
+		return a.clients, nil
+	}
+	cfg, err := a.config()
+	if err != nil {
+		return nil, err
+	}
+	_, profile, err := cfg.profile(a.profileName)
+	if err != nil {
+		return nil, err
+	}
+	a.clients = &clients{profile: profile, dialOptions: a.dialOptions}
+	return a.clients, nil
+}
+
+func (a *app) config() (*Config, error) {
+	path, explicit := a.configPath, a.configPath != ""
+	if !explicit {
+		path = defaultConfigPath()
+	}
+	return loadConfig(path, explicit)
+}
+
+// describe turns gRPC errors into "Code: message"
+func describe(err error) string {
+	if st, ok := status.FromError(err); ok {
+		return fmt.Sprintf("%s: %s", st.Code(), st.Message())
+	}
+	return err.Error()
+}
+
+// parseID parses the single positional argument of commands acting on one
+// resource
+func parseID(args []string, resource string) (int32, error) {
+	if len(args) != 1 {
+		return 0, usagef("expected the id of one %s", resource)
+	}
+	id, err := strconv.ParseInt(args[0], 10, 32)
+	if err != nil || id <= 0 {
+		return 0, usagef("invalid %s id %q", resource, args[0])
+	}
+	return int32(id), nil
+}
+
+// noArgs rejects positional arguments given to commands that take none
+func noArgs(args []string) error {
+	if len(args) > 0 {
+		return usagef("unexpected argument %q", args[0])
+	}
+	return nil
+}
+
+func (a *app) group(name string) (group, bool) {
+	for _, g := range a.groups() {
+		if g.name == name {
+			return g, true
+		}
+	}
+	return group{}, false
+}
+
+func (g group) command(name string) (command, bool) {
+	for _, cmd := range g.commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func (a *app) usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: platformctl [flags] <group> <command> [arguments]")
+	fmt.Fprintln(w)
+	for _, g := range a.groups() {
+		fmt.Fprintf(w, "  %-10s %s\n", g.name, g.summary)
+		for _, cmd := range g.commands {
+			fmt.Fprintf(w, "    %-30s %s\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.summary)
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run platformctl <group> <command> -h for the flags of a command.")
+}
+
+func (a *app) groupUsage(w io.Writer, g group) {
+	fmt.Fprintf(w, "Usage: platformctl %s <command> [arguments]\n\n%s:\n", g.name, g.summary)
+	for _, cmd := range g.commands {
+		fmt.Fprintf(w, "  %-30s %s\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.summary)
+	}
+}
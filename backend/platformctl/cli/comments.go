@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+)
+
+func (a *app) commentCommands() []command {
+	return []command{
+		{name: "list", summary: "List comments of a stream or a user", setup: a.listComments},
+		{name: "delete", args: "<id>", summary: "Delete a comment", setup: a.deleteComment},
+	}
+}
+
+func (a *app) listComments(fs *flag.FlagSet) func(context.Context, []string) error {
+	streamID := fs.Int("stream", 0, "only comments on this stream id")
+	userID := fs.Int("user", 0, "only comments of this user id")
+	page := fs.Int("page", 1, "page to show")
+	pageSize := fs.Int("page-size", 10, "comments per page")
+
+	return func(ctx context.Context, args []string) error {
+		if err := noArgs(args); err != nil {
+			return err
+		}
+		comments, err := a.commentClient()
+		if err != nil {
+			return err
+		}
+
+		req := &commentpb.ListCommentsRequest{Page: ptr(int32(*page)), PageSize: ptr(int32(*pageSize))}
+		if *streamID != 0 {
+			req.StreamId = ptr(int32(*streamID))
+		}
+		if *userID != 0 {
+			req.UserId = ptr(int32(*userID))
+		}
+		resp, err := comments.ListComments(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		t := table{header: []string{"ID", "STREAM", "USER", "CREATED", "CONTENT"}}
+		for _, comment := range resp.Comments {
+			t.rows = append(t.rows, []string{
+				strconv.Itoa(int(comment.Id)), strconv.Itoa(int(comment.StreamId)), strconv.Itoa(int(comment.UserId)),
+				timestamp(comment.CreatedAt), truncate(comment.Content, 60),
+			})
+		}
+		t.footer = fmt.Sprintf("Page %d, %d comments", *page, resp.TotalCount)
+		return a.out.print(resp, t)
+	}
+}
+
+func (a *app) deleteComment(fs *flag.FlagSet) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		id, err := parseID(args, "comment")
+		if err != nil {
+			return err
+		}
+		comments, err := a.commentClient()
+		if err != nil {
+			return err
+		}
+		resp, err := comments.GetComment(ctx, &commentpb.GetCommentRequest{Id: id})
+		if err != nil {
+			return err
+		}
+		comment := resp.Comment
+		if err := a.confirm("Delete comment %d of user %d on stream %d: %q?", id, comment.UserId, comment.StreamId, truncate(comment.Content, 60)); err != nil {
+			return err
+		}
+
+		if _, err := comments.DeleteComment(ctx, &commentpb.DeleteCommentRequest{Id: id}); err != nil {
+			return err
+		}
+		a.out.message("Deleted comment %d", id)
+		return nil
+	}
+}
+
+func (a *app) commentClient() (commentpb.CommentServiceClient, error) {
+	services, err := a.services()
+	if err != nil {
+		return nil, err
+	}
+	return services.comments()
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errNotConfirmed is returned when a destructive action was declined
+var errNotConfirmed = errors.New("aborted")
+
+// confirm asks before a destructive action, unless --yes was given. Anything
+// but an explicit yes declines, including a closed or non-interactive stdin.
+func (a *app) confirm(format string, args ...any) error {
+	if a.yes {
+		return nil
+	}
+
+	fmt.Fprintf(a.stderr, format+" [y/N] ", args...)
+	answer, err := a.stdin.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(a.stderr)
+		return fmt.Errorf("%w: no confirmation given, pass --yes to run without a prompt", errNotConfirmed)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errNotConfirmed
+	}
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	commentpb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/auth"
+	streampb "github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Issuer is the name platformctl signs its service tokens with. Services
+// restricting their callers must allow it.
+const Issuer = "platformctl"
+
+// Service names, which are the audiences of service tokens
+const (
+	streamService  = "stream-service"
+	commentService = "comment-service"
+	userService    = "user-service"
+)
+
+// tokenTTL is the lifetime of the tokens minted for each call
+const tokenTTL = time.Minute
+
+// clients connects to the services of a profile on first use
+type clients struct {
+	profile     Profile
+	dialOptions []grpc.DialOption
+	conns       map[string]*grpc.ClientConn
+}
+
+func (c *clients) streams() (streampb.StreamServiceClient, error) {
+	conn, err := c.dial(streamService, c.profile.StreamService)
+	if err != nil {
+		return nil, err
+	}
+	return streampb.NewStreamServiceClient(conn), nil
+}
+
+func (c *clients) comments() (commentpb.CommentServiceClient, error) {
+	conn, err := c.dial(commentService, c.profile.CommentService)
+	if err != nil {
+		return nil, err
+	}
+	return commentpb.NewCommentServiceClient(conn), nil
+}
+
+func (c *clients) users() (userpb.UserServiceClient, error) {
+	conn, err := c.dial(userService, c.profile.UserService)
+	if err != nil {
+		return nil, err
+	}
+	return userpb.NewUserServiceClient(conn), nil
+}
+
+// dial connects to service at address, once. Calls carry a service token
+// without an end user, so the services trust them like calls from another
+// service.
+func (c *clients) dial(service, address string) (*grpc.ClientConn, error) {
+	if conn, ok := c.conns[service]; ok {
+		return conn, nil
+	}
+	if address == "" {
+		return nil, fmt.Errorf("the profile has no address for %s", service)
+	}
+
+	creds, err := c.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, c.dialOptions...)
+	if c.profile.SigningKey != "" {
+		signer := auth.New(Issuer, auth.Config{SigningKey: c.profile.SigningKey, TokenTTL: tokenTTL})
+		opts = append(opts, grpc.WithUnaryInterceptor(signer.UnaryClientInterceptor(service)))
+	}
+
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", service, err)
+	}
+	if c.conns == nil {
+		c.conns = make(map[string]*grpc.ClientConn)
+	}
+	c.conns[service] = conn
+	return conn, nil
+}
+
+func (c *clients) transportCredentials() (credentials.TransportCredentials, error) {
+	cfg := c.profile.TLS
+	if !cfg.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", cfg.CAFile)
+		}
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func (c *clients) close() error {
+	var errs []error
+	for _, conn := range c.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
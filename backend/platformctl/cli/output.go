@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Output formats
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// printer writes results in the chosen format
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// table describes how a result is shown as a table
+type table struct {
+	header []string
+	rows   [][]string
+	// footer is printed under the table, such as the page being shown
+	footer string
+}
+
+// print writes msg as JSON, or as t in the table format
+func (p *printer) print(msg proto.Message, t table) error {
+	if p.format == formatJSON {
+		data, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", UseProtoNames: true}.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, string(data))
+		return err
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if t.footer != "" {
+		fmt.Fprintln(p.w, t.footer)
+	}
+	return nil
+}
+
+// message writes a confirmation of an action that returns nothing. It is
+// skipped in the JSON format, whose output is meant for other programs.
+func (p *printer) message(format string, args ...any) {
+	if p.format == formatTable {
+		fmt.Fprintf(p.w, format+"\n", args...)
+	}
+}
+
+// field shows an empty value as a dash so that table columns stay aligned
+func field(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// truncate shortens text to n characters for table cells
+func truncate(text string, n int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+func timestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables overriding the configuration file
+const (
+	configEnv     = "PLATFORMCTL_CONFIG"
+	profileEnv    = "PLATFORMCTL_PROFILE"
+	signingKeyEnv = "PLATFORMCTL_SIGNING_KEY"
+)
+
+// localProfile is used when there is no configuration file. It targets the
+// default ports of services running on this machine.
+var localProfile = Profile{
+	StreamService:  "localhost:8082",
+	CommentService: "localhost:50053",
+	UserService:    "localhost:50052",
+}
+
+// Config is the configuration file, holding a profile per environment
+type Config struct {
+	// Current is the profile used when none is selected
+	Current  string             `yaml:"current"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// Profile is how to reach the services of one environment
+type Profile struct {
+	StreamService  string `yaml:"stream_service"`
+	CommentService string `yaml:"comment_service"`
+	UserService    string `yaml:"user_service"`
+
+	// SigningKey is the key the services share to sign service tokens.
+	// SigningKeyEnv names an environment variable holding it instead, which
+	// keeps the key out of the file.
+	SigningKey    string `yaml:"signing_key"`
+	SigningKeyEnv string `yaml:"signing_key_env"`
+
+	TLS TLSConfig `yaml:"tls"`
+}
+
+// TLSConfig enables TLS towards the services. With mTLS, the client
+// certificate must identify platformctl, the issuer of its service tokens.
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	CAFile   string `yaml:"ca_file"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+// defaultConfigPath returns where the configuration file is looked for
+func defaultConfigPath() string {
+	if path := os.Getenv(configEnv); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "platformctl", "config.yaml")
+}
+
+// loadConfig reads the configuration file at path. A missing file at the
+// default path yields the local profile.
+func loadConfig(path string, explicit bool) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return &Config{Current: "local", Profiles: map[string]Profile{"local": localProfile}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration %s: %w", path, err)
+	}
+	if len(cfg.Profiles) == 0 {
+		return nil, fmt.Errorf("configuration %s has no profiles", path)
+	}
+	return &cfg, nil
+}
+
+// profile returns the profile name, or the current one when name is empty,
+// with the signing key resolved
+func (c *Config) profile(name string) (string, Profile, error) {
+	if name == "" {
+		name = os.Getenv(profileEnv)
+	}
+	if name == "" {
+		name = c.Current
+	}
+	if name == "" && len(c.Profiles) == 1 {
+		for only := range c.Profiles {
+			name = only
+		}
+	}
+	if name == "" {
+		return "", Profile{}, fmt.Errorf("no profile selected, choose one of %s with --profile", strings.Join(c.names(), ", "))
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return "", Profile{}, fmt.Errorf("unknown profile %q, choose one of %s", name, strings.Join(c.names(), ", "))
+	}
+	if profile.SigningKeyEnv != "" {
+		profile.SigningKey = os.Getenv(profile.SigningKeyEnv)
+	}
+	if key := os.Getenv(signingKeyEnv); key != "" {
+		profile.SigningKey = key
+	}
+	profile.TLS.CAFile = expandHome(profile.TLS.CAFile)
+	profile.TLS.CertFile = expandHome(profile.TLS.CertFile)
+	profile.TLS.KeyFile = expandHome(profile.TLS.KeyFile)
+	return name, profile, nil
+}
+
+// expandHome resolves paths starting with ~/ against the home directory
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
+
+// names returns the profile names in order
+func (c *Config) names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"flag"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func (a *app) profileCommands() []command {
+	return []command{
+		{name: "list", summary: "List the profiles of the configuration file", setup: a.listProfiles},
+	}
+}
+
+func (a *app) listProfiles(fs *flag.FlagSet) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		if err := noArgs(args); err != nil {
+			return err
+		}
+		cfg, err := a.config()
+		if err != nil {
+			return err
+		}
+		selected, _, err := cfg.profile(a.profileName)
+		if err != nil {
+			selected = ""
+		}
+
+		t := table{header: []string{"", "PROFILE", "STREAMS", "COMMENTS", "USERS", "TLS", "SIGNED"}}
+		var profiles []any
+		for _, name := range cfg.names() {
+			_, profile, err := cfg.profile(name)
+			if err != nil {
+				return err
+			}
+			marker := ""
+			if name == selected {
+				marker = "*"
+			}
+			t.rows = append(t.rows, []string{
+				marker, name, field(profile.StreamService), field(profile.CommentService), field(profile.UserService),
+				yesNo(profile.TLS.Enabled), yesNo(profile.SigningKey != ""),
+			})
+			profiles = append(profiles, map[string]any{
+				"name":            name,
+				"selected":        name == selected,
+				"stream_service":  profile.StreamService,
+				"comment_service": profile.CommentService,
+				"user_service":    profile.UserService,
+				"tls":             profile.TLS.Enabled,
+				"signed":          profile.SigningKey != "",
+			})
+		}
+
+		// Signing keys are never printed
+		list, err := structpb.NewList(profiles)
+		if err != nil {
+			return err
+		}
+		return a.out.print(list, t)
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
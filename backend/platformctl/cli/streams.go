@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clementus360/stream-service/models"
+	streampb "github.com/clementus360/stream-service/proto"
+)
+
+func (a *app) streamCommands() []command {
+	return []command{
+		{name: "list", summary: "List streams matching filters", setup: a.listStreams},
+		{name: "get", args: "<id>", summary: "Show a stream", setup: a.getStream},
+		{name: "end", args: "<id>", summary: "End a stream, marking it COMPLETE", setup: a.endStream},
+		{name: "delete", args: "<id>", summary: "Delete a stream", setup: a.deleteStream},
+	}
+}
+
+func (a *app) listStreams(fs *flag.FlagSet) func(context.Context, []string) error {
+	userID := fs.Int("user", 0, "only streams of this user id")
+	statuses := fs.String("status", "", "only streams with these statuses, comma separated")
+	visibility := fs.String("visibility", "", "only streams with these visibilities, comma separated")
+	title := fs.String("title", "", "only streams whose title contains this text")
+	page := fs.Int("page", 1, "page to show")
+	pageSize := fs.Int("page-size", 10, "streams per page")
+	sortBy := fs.String("sort", "", "field to sort by, such as start_time or view_count")
+	ascending := fs.Bool("asc", false, "sort in ascending order")
+
+	return func(ctx context.Context, args []string) error {
+		if err := noArgs(args); err != nil {
+			return err
+		}
+		services, err := a.services()
+		if err != nil {
+			return err
+		}
+		streams, err := services.streams()
+		if err != nil {
+			return err
+		}
+
+		resp, err := streams.ListStreams(ctx, &streampb.ListStreamsRequest{
+			PageNumber: int32(*page),
+			PageSize:   int32(*pageSize),
+			SortBy:     *sortBy,
+			Ascending:  *ascending,
+			Filter: &streampb.StreamFilter{
+				UserId:        int32(*userID),
+				Status:        splitList(*statuses),
+				Visibility:    splitList(*visibility),
+				TitleContains: *title,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		t := table{header: []string{"ID", "TITLE", "USER", "STATUS", "VISIBILITY", "START", "END", "VIEWS"}}
+		for _, stream := range resp.Streams {
+			stream.StreamKey = ""
+			t.rows = append(t.rows, []string{
+				strconv.Itoa(int(stream.Id)), truncate(stream.Title, 40), strconv.Itoa(int(stream.UserId)),
+				stream.Status, stream.Visibility, field(stream.StartTime), field(stream.EndTime), strconv.Itoa(int(stream.ViewCount)),
+			})
+		}
+		if meta := resp.MetaData; meta != nil {
+			t.footer = fmt.Sprintf("Page %d of %d, %d streams", meta.CurrentPage, meta.TotalPages, meta.TotalItems)
+		}
+		return a.out.print(resp, t)
+	}
+}
+
+func (a *app) getStream(fs *flag.FlagSet) func(context.Context, []string) error {
+	showKey := fs.Bool("show-key", false, "include the stream key")
+
+	return func(ctx context.Context, args []string) error {
+		id, err := parseID(args, "stream")
+		if err != nil {
+			return err
+		}
+		stream, err := a.fetchStream(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !*showKey {
+			stream.StreamKey = ""
+		}
+
+		t := table{header: []string{"FIELD", "VALUE"}, rows: [][]string{
+			{"id", strconv.Itoa(int(stream.Id))},
+			{"title", stream.Title},
+			{"description", field(truncate(stream.Description, 80))},
+			{"user_id", strconv.Itoa(int(stream.UserId))},
+			{"status", stream.Status},
+			{"visibility", stream.Visibility},
+			{"start_time", field(stream.StartTime)},
+			{"end_time", field(stream.EndTime)},
+			{"view_count", strconv.Itoa(int(stream.ViewCount))},
+			{"resolution", field(stream.Resolution)},
+			{"bitrate", field(stream.Bitrate)},
+			{"framerate", field(stream.Framerate)},
+			{"codec", field(stream.Codec)},
+			{"protocol", field(stream.Protocol)},
+			{"rendition_preset", field(stream.RenditionPreset)},
+		}}
+		if *showKey {
+			t.rows = append(t.rows, []string{"stream_key", field(stream.StreamKey)})
+		}
+		return a.out.print(stream, t)
+	}
+}
+
+func (a *app) endStream(fs *flag.FlagSet) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		id, err := parseID(args, "stream")
+		if err != nil {
+			return err
+		}
+		stream, err := a.fetchStream(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stream.Status == models.StatusComplete {
+			return fmt.Errorf("stream %d has already ended", id)
+		}
+		if err := a.confirm("End %s stream %d %q of user %d?", stream.Status, id, stream.Title, stream.UserId); err != nil {
+			return err
+		}
+
+		streams, err := a.clients.streams()
+		if err != nil {
+			return err
+		}
+		ended, err := streams.UpdateStream(ctx, &streampb.UpdateStreamRequest{
+			Id:      id,
+			Status:  models.StatusComplete,
+			EndTime: time.Now().UTC().Format(models.TimeFormat),
+		})
+		if err != nil {
+			return err
+		}
+		ended.StreamKey = ""
+		if a.out.format == formatJSON {
+			return a.out.print(ended, table{})
+		}
+		a.out.message("Ended stream %d", id)
+		return nil
+	}
+}
+
+func (a *app) deleteStream(fs *flag.FlagSet) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		id, err := parseID(args, "stream")
+		if err != nil {
+			return err
+		}
+		stream, err := a.fetchStream(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := a.confirm("Delete %s stream %d %q of user %d? It moves to the trash and can be restored until the retention period ends.", stream.Status, id, stream.Title, stream.UserId); err != nil {
+			return err
+		}
+
+		streams, err := a.clients.streams()
+		if err != nil {
+			return err
+		}
+		if _, err := streams.DeleteStream(ctx, &streampb.DeleteStreamRequest{Id: id}); err != nil {
+			return err
+		}
+		a.out.message("Moved stream %d to the trash", id)
+		return nil
+	}
+}
+
+// fetchStream gets a stream, to act on it or to show what is about to change
+func (a *app) fetchStream(ctx context.Context, id int32) (*streampb.StreamResponse, error) {
+	services, err := a.services()
+	if err != nil {
+		return nil, err
+	}
+	streams, err := services.streams()
+	if err != nil {
+		return nil, err
+	}
+	return streams.GetStream(ctx, &streampb.GetStreamRequest{Id: id})
+}
+
+// splitList splits a comma separated list of enum values, such as statuses
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, strings.ToUpper(item))
+		}
+	}
+	return items
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	userpb "github.com/Josy-coder/user-service/proto/user/v1"
+)
+
+func (a *app) userCommands() []command {
+	return []command{
+		{name: "list", summary: "List users", setup: a.listUsers},
+		{name: "get", args: "<id>", summary: "Show a user, by id or with --clerk-id", setup: a.getUser},
+		{name: "sync", args: "<clerk-id>", summary: "Refresh a user from their Clerk account, creating them if needed", setup: a.syncUser},
+		{name: "delete", args: "<id>", summary: "Delete a user with their streams and comments", setup: a.deleteUser},
+	}
+}
+
+func (a *app) listUsers(fs *flag.FlagSet) func(context.Context, []string) error {
+	page := fs.Int("page", 1, "page to show")
+	pageSize := fs.Int("page-size", 10, "users per page")
+
+	return func(ctx context.Context, args []string) error {
+		if err := noArgs(args); err != nil {
+			return err
+		}
+		users, err := a.userClient()
+		if err != nil {
+			return err
+		}
+
+		resp, err := users.ListUsers(ctx, &userpb.ListUsersRequest{Page: int32(*page), PageSize: int32(*pageSize)})
+		if err != nil {
+			return err
+		}
+
+		t := table{header: []string{"ID", "USERNAME", "EMAIL", "CLERK ID", "CREATED"}}
+		for _, user := range resp.Users {
+			t.rows = append(t.rows, []string{
+				strconv.Itoa(int(user.Id)), user.Username, user.Email, field(user.ClerkId), timestamp(user.CreatedAt),
+			})
+		}
+		t.footer = fmt.Sprintf("Page %d, %d users", *page, resp.TotalCount)
+		return a.out.print(resp, t)
+	}
+}
+
+func (a *app) getUser(fs *flag.FlagSet) func(context.Context, []string) error {
+	clerkID := fs.String("clerk-id", "", "look the user up by their Clerk id instead")
+
+	return func(ctx context.Context, args []string) error {
+		users, err := a.userClient()
+		if err != nil {
+			return err
+		}
+
+		var resp *userpb.UserResponse
+		if *clerkID != "" {
+			if err := noArgs(args); err != nil {
+				return err
+			}
+			resp, err = users.GetUserByClerkID(ctx, &userpb.GetUserByClerkIDRequest{ClerkId: *clerkID})
+		} else {
+			var id int32
+			if id, err = parseID(args, "user"); err != nil {
+				return err
+			}
+			resp, err = users.GetUser(ctx, &userpb.GetUserRequest{Id: id})
+		}
+		if err != nil {
+			return err
+		}
+		return a.out.print(resp.User, userTable(resp.User))
+	}
+}
+
+func (a *app) syncUser(fs *flag.FlagSet) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		if len(args) != 1 || args[0] == "" {
+			return usagef("expected one Clerk user id")
+		}
+		users, err := a.userClient()
+		if err != nil {
+			return err
+		}
+
+		resp, err := users.SyncUserWithClerk(ctx, &userpb.SyncUserWithClerkRequest{ClerkId: args[0]})
+		if err != nil {
+			return err
+		}
+		return a.out.print(resp.User, userTable(resp.User))
+	}
+}
+
+func (a *app) deleteUser(fs *flag.FlagSet) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		id, err := parseID(args, "user")
+		if err != nil {
+			return err
+		}
+		users, err := a.userClient()
+		if err != nil {
+			return err
+		}
+		resp, err := users.GetUser(ctx, &userpb.GetUserRequest{Id: id})
+		if err != nil {
+			return err
+		}
+		if err := a.confirm("Delete user %d %q <%s> with all their streams and comments? This cannot be undone.", id, resp.User.Username, resp.User.Email); err != nil {
+			return err
+		}
+
+		if _, err := users.DeleteUser(ctx, &userpb.DeleteUserRequest{Id: id}); err != nil {
+			return err
+		}
+		a.out.message("Deleted user %d", id)
+		return nil
+	}
+}
+
+func (a *app) userClient() (userpb.UserServiceClient, error) {
+	services, err := a.services()
+	if err != nil {
+		return nil, err
+	}
+	return services.users()
+}
+
+func userTable(user *userpb.User) table {
+	return table{header: []string{"FIELD", "VALUE"}, rows: [][]string{
+		{"id", strconv.Itoa(int(user.Id))},
+		{"username", user.Username},
+		{"email", user.Email},
+		{"name", field(user.FirstName + " " + user.LastName)},
+		{"clerk_id", field(user.ClerkId)},
+		{"created_at", timestamp(user.CreatedAt)},
+		{"updated_at", timestamp(user.UpdatedAt)},
+		{"last_login", timestamp(user.LastLogin)},
+	}}
+}
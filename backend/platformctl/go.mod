@@ -0,0 +1,32 @@
+module github.com/clementus360/platformctl
+
+go 1.23.5
+
+require (
+	github.com/Josy-coder/comment-service v0.0.0-00010101000000-000000000000
+	github.com/Josy-coder/user-service v0.0.0-00010101000000-000000000000
+	github.com/clementus360/platform v0.0.0-00010101000000-000000000000
+	github.com/clementus360/stream-service v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.70.0
+	google.golang.org/protobuf v1.36.4
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+// The service APIs are built from this repository
+replace (
+	github.com/Josy-coder/comment-service => ../comment-service
+	github.com/Josy-coder/user-service => ../user-service
+	github.com/clementus360/platform => ../platform
+	github.com/clementus360/stream-service => ../stream-service
+	github.com/clementus360/streamdb-api => ../streamdb-api
+)
+
+require (
+	github.com/clementus360/streamdb-api v0.0.0-00010101000000-000000000000 // indirect
+	go.opentelemetry.io/otel v1.34.0 // indirect
+	go.opentelemetry.io/otel/trace v1.34.0 // indirect
+	golang.org/x/net v0.34.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250124145028-65684f501c47 // indirect
+)
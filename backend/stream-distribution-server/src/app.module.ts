@@ -1,9 +1,10 @@
 import { Module } from '@nestjs/common';
 
+import { PlaybackTokenService } from './playback/playback-token.service';
 import { SignalingGateway } from './signaling/signaling.gateway';
 import { WebrtcService } from './webrtc/webrtc.service';
 
 @Module({
-  providers: [SignalingGateway, WebrtcService],
+  providers: [SignalingGateway, WebrtcService, PlaybackTokenService],
 })
 export class AppModule {}
@@ -0,0 +1,78 @@
+import { Injectable, Logger } from '@nestjs/common';
+import { createHmac, timingSafeEqual } from 'crypto';
+
+// Playback tokens are HS256 JWTs issued by stream-service and bound to a
+// single stream. They are verified offline with the key shared with
+// stream-service (PLAYBACK_SIGNING_KEY).
+
+interface PlaybackClaims {
+  iss: string;
+  aud: string;
+  sub?: string;
+  sid: number;
+  iat: number;
+  exp: number;
+}
+
+// Tolerate small clock differences between hosts
+const CLOCK_SKEW_SECONDS = 30;
+
+const TOKEN_HEADER = Buffer.from('{"alg":"HS256","typ":"JWT"}').toString('base64url');
+
+@Injectable()
+export class PlaybackTokenService {
+  private readonly logger = new Logger(PlaybackTokenService.name);
+  private readonly key = process.env.PLAYBACK_SIGNING_KEY ?? '';
+
+  constructor() {
+    if (!this.key) {
+      this.logger.warn('Playback tokens are not verified, set PLAYBACK_SIGNING_KEY to require them');
+    }
+  }
+
+  // Without a key every viewer may consume every stream, as before tokens
+  // were introduced
+  get enabled(): boolean {
+    return this.key.length > 0;
+  }
+
+  // Returns the reason token does not grant access to streamId, or null when
+  // it does
+  verify(token: string | undefined, streamId: string): string | null {
+    if (!this.enabled) {
+      return null;
+    }
+    if (!token) {
+      return 'missing playback token';
+    }
+
+    const parts = token.split('.');
+    if (parts.length !== 3 || parts[0] !== TOKEN_HEADER) {
+      return 'invalid playback token';
+    }
+    const expected = Buffer.from(
+      createHmac('sha256', this.key).update(`${parts[0]}.${parts[1]}`).digest('base64url'),
+    );
+    const actual = Buffer.from(parts[2]);
+    if (actual.length !== expected.length || !timingSafeEqual(actual, expected)) {
+      return 'invalid playback token';
+    }
+
+    let claims: PlaybackClaims;
+    try {
+      claims = JSON.parse(Buffer.from(parts[1], 'base64url').toString('utf8'));
+    } catch {
+      return 'invalid playback token';
+    }
+    if (claims.iss !== 'stream-service' || claims.aud !== 'playback') {
+      return 'invalid playback token';
+    }
+    if (Date.now() / 1000 > claims.exp + CLOCK_SKEW_SECONDS) {
+      return 'playback token expired';
+    }
+    if (String(claims.sid) !== streamId) {
+      return 'playback token issued for another stream';
+    }
+    return null;
+  }
+}
@@ -11,6 +11,7 @@ import {
 import { Server, Socket } from 'socket.io';
 import { Logger, Injectable } from '@nestjs/common';
 import { WebrtcService } from 'src/webrtc/webrtc.service';
+import { PlaybackTokenService } from 'src/playback/playback-token.service';
 import * as wrtc from 'wrtc';
 
 interface JoinRoomDto {
@@ -53,7 +54,10 @@ export class SignalingGateway
   private readonly logger = new Logger(SignalingGateway.name);
   private rooms = new Map<string, Set<string>>();
 
-  constructor(private readonly webrtcService: WebrtcService) {}
+  constructor(
+    private readonly webrtcService: WebrtcService,
+    private readonly playbackTokens: PlaybackTokenService,
+  ) {}
 
   @WebSocketServer()
   server: Server;
@@ -174,8 +178,15 @@ export class SignalingGateway
   @SubscribeMessage('createConsumer')
   async handleCreateConsumer(
     @ConnectedSocket() client: Socket,
-    @MessageBody() data: { streamId: string },
+    @MessageBody() data: { streamId: string, token?: string },
   ): Promise<{ offer: RTCSessionDescriptionInit | null } | { error: string }> {
+    // Viewers present the playback token issued by stream-service
+    const denied = this.playbackTokens.verify(data.token, String(data.streamId));
+    if (denied) {
+      this.logger.warn(`Refused consumer ${client.id} for stream ${data.streamId}: ${denied}`);
+      return { error: denied };
+    }
+
     try {
       const offer = await this.webrtcService.createConsumerPeerConnection(
         data.streamId,
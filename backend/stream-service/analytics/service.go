@@ -0,0 +1,317 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	// ErrSessionNotFound is returned for unknown or expired session ids
+	ErrSessionNotFound = errors.New("viewer session not found")
+	// ErrStreamNotLive is returned when joining a stream that is not online
+	ErrStreamNotLive = errors.New("stream is not live")
+	// ErrViewerRequired is returned when a session has no viewer id
+	ErrViewerRequired = errors.New("viewer id is required")
+	// ErrTooManySessions is returned when a viewer already has the most
+	// sessions allowed open on a stream
+	ErrTooManySessions = errors.New("too many open viewer sessions")
+)
+
+// StreamLookup returns a stream from the database service
+type StreamLookup func(ctx context.Context, id int32) (*proto.StreamResponse, error)
+
+// Config tunes the background rollups
+type Config struct {
+	// RollupInterval is how often streams with sessions are checked for
+	// having ended
+	RollupInterval time.Duration
+	// SessionRetention is how long the raw sessions of an ended stream are
+	// kept after its rollup
+	SessionRetention time.Duration
+	// MaxSessionsPerViewer bounds the sessions a viewer keeps open on one
+	// stream
+	MaxSessionsPerViewer int
+}
+
+// Service records viewer sessions and summarizes them. Sessions and rollups
+// are stored by the database service, so they survive restarts and are
+// shared between replicas.
+type Service struct {
+	cfg     Config
+	client  streamdb.AnalyticsServiceClient
+	streams StreamLookup
+	now     func() time.Time
+	// ended receives the ids of streams that just ended so they are rolled
+	// up without waiting for the next sweep
+	ended chan int32
+}
+
+// NewService returns a service storing sessions through client and checking
+// the status of streams with lookup
+func NewService(cfg Config, client streamdb.AnalyticsServiceClient, lookup StreamLookup) *Service {
+	return &Service{
+		cfg:     cfg,
+		client:  client,
+		streams: lookup,
+		now:     func() time.Time { return time.Now().UTC() },
+		ended:   make(chan int32, 64),
+	}
+}
+
+// Join opens a session for viewerID on a live stream
+func (s *Service) Join(ctx context.Context, stream *proto.StreamResponse, viewerID, clientType string) (Session, error) {
+	if viewerID == "" {
+		return Session{}, ErrViewerRequired
+	}
+	if stream.Status != models.StatusOnline {
+		return Session{}, ErrStreamNotLive
+	}
+
+	open, err := s.client.ListViewerSessions(ctx, &streamdb.ListViewerSessionsRequest{StreamId: stream.Id, ViewerId: viewerID, Open: true})
+	if err != nil {
+		return Session{}, err
+	}
+	if len(open.Sessions) >= s.cfg.MaxSessionsPerViewer {
+		return Session{}, ErrTooManySessions
+	}
+
+	resp, err := s.client.AddViewerSession(ctx, &streamdb.AddViewerSessionRequest{
+		Id:         newSessionID(),
+		StreamId:   stream.Id,
+		ViewerId:   viewerID,
+		ClientType: normalizeClientType(clientType),
+		JoinedAt:   s.now().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return Session{}, err
+	}
+	return toSession(resp)
+}
+
+// Leave closes a session. Leaving a closed session is a no-op so that
+// players may retry.
+func (s *Service) Leave(ctx context.Context, sessionID string) (Session, error) {
+	resp, err := s.client.EndViewerSession(ctx, &streamdb.EndViewerSessionRequest{Id: sessionID, LeftAt: s.now().Format(time.RFC3339Nano)})
+	if err != nil {
+		return Session{}, sessionError(err)
+	}
+	return toSession(resp)
+}
+
+// Session returns an open or recently closed session
+func (s *Service) Session(ctx context.Context, sessionID string) (Session, error) {
+	if sessionID == "" {
+		return Session{}, ErrSessionNotFound
+	}
+	resp, err := s.client.GetViewerSession(ctx, &streamdb.GetViewerSessionRequest{Id: sessionID})
+	if err != nil {
+		return Session{}, sessionError(err)
+	}
+	return toSession(resp)
+}
+
+// Summary returns the rollup of an ended stream, or the figures so far of a
+// stream that has not been rolled up yet
+func (s *Service) Summary(ctx context.Context, streamID int32) (Summary, error) {
+	rollup, err := s.client.GetRollup(ctx, &streamdb.GetRollupRequest{StreamId: streamID})
+	switch {
+	case err == nil:
+		var summary Summary
+		if err := json.Unmarshal([]byte(rollup.Summary), &summary); err != nil {
+			return Summary{}, fmt.Errorf("invalid rollup of stream %d: %w", streamID, err)
+		}
+		return summary, nil
+	case status.Code(err) != codes.NotFound:
+		return Summary{}, err
+	}
+
+	sessions, err := s.sessions(ctx, streamID)
+	if err != nil {
+		return Summary{}, err
+	}
+	return Summarize(streamID, sessions, s.now()), nil
+}
+
+// OpenSessions counts the viewers currently watching any stream
+func (s *Service) OpenSessions(ctx context.Context) (int, error) {
+	resp, err := s.client.CountOpenViewerSessions(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.Count), nil
+}
+
+// StreamEnded schedules the rollup of a stream that went offline. It never
+// blocks; the periodic sweep catches up when the queue is full.
+func (s *Service) StreamEnded(streamID int32) {
+	select {
+	case s.ended <- streamID:
+	default:
+	}
+}
+
+// RemoveStream drops the sessions and rollup of a purged stream
+func (s *Service) RemoveStream(ctx context.Context, streamID int32) error {
+	_, err := s.client.DeleteStreamAnalytics(ctx, &streamdb.DeleteStreamAnalyticsRequest{StreamId: streamID})
+	return err
+}
+
+// Run rolls up ended streams and expires old sessions until ctx is cancelled
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.RollupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-s.ended:
+			if err := s.rollup(ctx, id); err != nil && ctx.Err() == nil {
+				slog.Warn("Failed to roll up stream analytics", "stream_id", id, "error", err)
+			}
+		case <-ticker.C:
+			if err := s.sweep(ctx); err != nil && ctx.Err() == nil {
+				slog.Error("Failed to sweep stream analytics", "error", err)
+			}
+		}
+	}
+}
+
+// sweep rolls up every stream that is no longer live and drops the sessions
+// of rollups older than the retention period
+func (s *Service) sweep(ctx context.Context) error {
+	pending, err := s.client.ListPendingRollups(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	for _, id := range pending.StreamIds {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.rollup(ctx, id); err != nil {
+			slog.Warn("Failed to roll up stream analytics", "stream_id", id, "error", err)
+		}
+	}
+
+	_, err = s.client.DeleteViewerSessions(ctx, &streamdb.DeleteViewerSessionsRequest{
+		RolledUpBefore: s.now().Add(-s.cfg.SessionRetention).Format(time.RFC3339Nano),
+	})
+	return err
+}
+
+// rollup stores the final summary of a stream once it is no longer live.
+// Sessions left open are closed at the end time of the stream.
+func (s *Service) rollup(ctx context.Context, streamID int32) error {
+	lookupCtx, cancel := context.WithTimeout(ctx, s.cfg.RollupInterval)
+	stream, err := s.streams(lookupCtx, streamID)
+	cancel()
+
+	end := s.now()
+	switch {
+	case status.Code(err) == codes.NotFound:
+		// deleted streams are rolled up as of now
+	case err != nil:
+		return err
+	case stream.Status == models.StatusOnline:
+		return nil
+	default:
+		if endTime, err := time.Parse(models.TimeFormat, stream.EndTime); err == nil && endTime.Before(end) {
+			end = endTime
+		}
+	}
+
+	sessions, err := s.sessions(ctx, streamID)
+	if err != nil || len(sessions) == 0 {
+		return err
+	}
+
+	// an end time before the last viewer joined is the scheduled one, not
+	// when the broadcast actually stopped
+	for _, session := range sessions {
+		if session.JoinedAt.After(end) {
+			end = s.now()
+			break
+		}
+	}
+	summary := Summarize(streamID, sessions, end)
+	summary.Final = true
+	summary.ComputedAt = s.now()
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.SetRollup(ctx, &streamdb.SetRollupRequest{
+		StreamId:        streamID,
+		Summary:         string(data),
+		ComputedAt:      summary.ComputedAt.Format(time.RFC3339Nano),
+		CloseSessionsAt: end.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Rolled up stream analytics", "stream_id", streamID,
+		"unique_viewers", summary.UniqueViewers, "peak_concurrent_viewers", summary.PeakConcurrentViewers)
+	return nil
+}
+
+// sessions loads the sessions of a stream, oldest first
+func (s *Service) sessions(ctx context.Context, streamID int32) ([]*Session, error) {
+	resp, err := s.client.ListViewerSessions(ctx, &streamdb.ListViewerSessionsRequest{StreamId: streamID})
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*Session, 0, len(resp.Sessions))
+	for _, item := range resp.Sessions {
+		session, err := toSession(item)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+	return sessions, nil
+}
+
+// sessionError maps a missing session to ErrSessionNotFound
+func sessionError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return ErrSessionNotFound
+	}
+	return err
+}
+
+func toSession(resp *streamdb.ViewerSessionResponse) (Session, error) {
+	joinedAt, err := time.Parse(time.RFC3339Nano, resp.JoinedAt)
+	if err != nil {
+		return Session{}, fmt.Errorf("invalid join time %q of session %s: %w", resp.JoinedAt, resp.Id, err)
+	}
+	session := Session{
+		ID:         resp.Id,
+		StreamID:   resp.StreamId,
+		ViewerID:   resp.ViewerId,
+		ClientType: resp.ClientType,
+		JoinedAt:   joinedAt.UTC(),
+	}
+	if resp.LeftAt != "" {
+		leftAt, err := time.Parse(time.RFC3339Nano, resp.LeftAt)
+		if err != nil {
+			return Session{}, fmt.Errorf("invalid leave time %q of session %s: %w", resp.LeftAt, resp.Id, err)
+		}
+		leftAt = leftAt.UTC()
+		session.LeftAt = &leftAt
+	}
+	return session, nil
+}
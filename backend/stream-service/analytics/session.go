@@ -0,0 +1,69 @@
+// Package analytics records the viewer sessions of streams and summarizes
+// them into audience figures. Sessions are stored by the database service;
+// once a stream has ended its summary is rolled up in the background and the
+// raw sessions are dropped after a retention period.
+package analytics
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Client types reported by players. Anything else is counted as
+// ClientOther.
+const (
+	ClientWeb     = "web"
+	ClientMobile  = "mobile"
+	ClientDesktop = "desktop"
+	ClientTV      = "tv"
+	ClientOther   = "other"
+)
+
+// Session is the time a viewer spent watching a stream
+type Session struct {
+	ID         string
+	StreamID   int32
+	ViewerID   string
+	ClientType string
+	JoinedAt   time.Time
+	// LeftAt is nil while the viewer is watching
+	LeftAt *time.Time
+}
+
+// Open reports whether the viewer is still watching
+func (s *Session) Open() bool {
+	return s.LeftAt == nil
+}
+
+// end returns when the session ended, or until for open sessions
+func (s *Session) end(until time.Time) time.Time {
+	end := until
+	if s.LeftAt != nil {
+		end = *s.LeftAt
+	}
+	if end.Before(s.JoinedAt) {
+		return s.JoinedAt
+	}
+	return end
+}
+
+// normalizeClientType maps the client type reported by a player to one of
+// the known client types
+func normalizeClientType(clientType string) string {
+	switch clientType = strings.ToLower(strings.TrimSpace(clientType)); clientType {
+	case ClientWeb, ClientMobile, ClientDesktop, ClientTV:
+		return clientType
+	default:
+		return ClientOther
+	}
+}
+
+func newSessionID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic("failed to generate session id")
+	}
+	return hex.EncodeToString(id)
+}
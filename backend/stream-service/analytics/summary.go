@@ -0,0 +1,109 @@
+package analytics
+
+import (
+	"slices"
+	"time"
+)
+
+// Summary holds the audience figures of a stream. Rollups are stored as
+// its JSON encoding.
+type Summary struct {
+	StreamID int32 `json:"stream_id"`
+	// UniqueViewers counts distinct viewer ids
+	UniqueViewers int `json:"unique_viewers"`
+	// PeakConcurrentViewers is the largest number of sessions open at once,
+	// first reached at PeakAt
+	PeakConcurrentViewers int           `json:"peak_concurrent_viewers"`
+	PeakAt                time.Time     `json:"peak_at"`
+	TotalWatchTime        time.Duration `json:"total_watch_time"`
+	// AverageWatchTime is the watch time per unique viewer
+	AverageWatchTime time.Duration `json:"average_watch_time"`
+	Sessions         int           `json:"sessions"`
+	// ViewersByClient counts the unique viewers of every client type
+	ViewersByClient map[string]int `json:"viewers_by_client"`
+	// Concurrency holds the peak number of open sessions of every minute
+	// from the first join to the last leave
+	Concurrency []Point `json:"concurrency"`
+	// Final is set on rollups of ended streams, which no longer change
+	Final      bool      `json:"final"`
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+// Point is the concurrency of one minute
+type Point struct {
+	Minute  time.Time `json:"minute"`
+	Viewers int       `json:"viewers"`
+}
+
+// event is a session opening (+1) or closing (-1)
+type event struct {
+	at    time.Time
+	delta int
+}
+
+// Summarize computes the summary of the sessions of a stream. Sessions that
+// are still open are counted as watching until the given time.
+func Summarize(streamID int32, sessions []*Session, until time.Time) Summary {
+	summary := Summary{
+		StreamID:        streamID,
+		Sessions:        len(sessions),
+		ViewersByClient: make(map[string]int),
+		ComputedAt:      until,
+	}
+	if len(sessions) == 0 {
+		return summary
+	}
+
+	viewers := make(map[string]bool)
+	clientViewers := make(map[[2]string]bool)
+	events := make([]event, 0, 2*len(sessions))
+	for _, s := range sessions {
+		end := s.end(until)
+		summary.TotalWatchTime += end.Sub(s.JoinedAt)
+
+		viewers[s.ViewerID] = true
+		if key := [2]string{s.ClientType, s.ViewerID}; !clientViewers[key] {
+			clientViewers[key] = true
+			summary.ViewersByClient[s.ClientType]++
+		}
+
+		// sessions that ended as soon as they started never overlap others
+		if end.After(s.JoinedAt) {
+			events = append(events, event{s.JoinedAt, 1}, event{end, -1})
+		}
+	}
+	summary.UniqueViewers = len(viewers)
+	summary.AverageWatchTime = summary.TotalWatchTime / time.Duration(summary.UniqueViewers)
+
+	if len(events) == 0 {
+		return summary
+	}
+
+	// a viewer leaving at the instant another one joins does not add up to
+	// two concurrent sessions
+	slices.SortFunc(events, func(a, b event) int {
+		if c := a.at.Compare(b.at); c != 0 {
+			return c
+		}
+		return a.delta - b.delta
+	})
+
+	first := events[0].at.Truncate(time.Minute)
+	last := events[len(events)-1].at.Truncate(time.Minute)
+	current, i := 0, 0
+	for minute := first; !minute.After(last); minute = minute.Add(time.Minute) {
+		next := minute.Add(time.Minute)
+		peak := current
+		for ; i < len(events) && events[i].at.Before(next); i++ {
+			current += events[i].delta
+			peak = max(peak, current)
+			if current > summary.PeakConcurrentViewers {
+				summary.PeakConcurrentViewers = current
+				summary.PeakAt = events[i].at
+			}
+		}
+		summary.Concurrency = append(summary.Concurrency, Point{Minute: minute, Viewers: peak})
+	}
+
+	return summary
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/clementus360/platform/logging"
+	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/proto"
+)
+
+// StartViewerSession records a viewer joining a live stream. Players call it
+// when playback starts and keep the returned session id to end it.
+func StartViewerSession(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.StartViewerSessionRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		session, err := streamService.StartViewerSession(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to start viewer session")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, session)
+	}
+}
+
+// EndViewerSession records a viewer leaving a stream
+func EndViewerSession(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.EndViewerSessionRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		session, err := streamService.EndViewerSession(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to end viewer session")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, session)
+	}
+}
+
+// GetStreamAnalytics returns the audience figures of the stream given by the
+// stream_id query parameter
+func GetStreamAnalytics(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		streamID, err := strconv.Atoi(r.URL.Query().Get("stream_id"))
+		if err != nil || streamID < 1 {
+			http.Error(w, "A valid stream_id query parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := streamService.GetStreamAnalytics(r.Context(), &proto.GetStreamAnalyticsRequest{StreamId: int32(streamID)})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to get stream analytics")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	grpcclient "github.com/clementus360/stream-service/grpc"
+)
+
+// audited calls the gRPC method fullMethod of the stream service through
+// call, recording it in the audit log as the interceptor does for calls
+// over gRPC
+func audited[Req, Resp any](r *http.Request, streamService *grpcclient.StreamServiceServer, fullMethod string, req *Req, call func(context.Context, *Req) (*Resp, error)) (*Resp, error) {
+	resp, err := streamService.Recorder.Record(r.Context(), fullMethod, req, func(ctx context.Context, req any) (any, error) {
+		return call(ctx, req.(*Req))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*Resp), nil
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/clementus360/platform/logging"
+	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/playback"
+	"github.com/clementus360/stream-service/proto"
+)
+
+// CreateClip cuts a clip out of a stream for the calling user. Private
+// streams are only clipped through the gRPC API.
+func CreateClip(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.CreateClipRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		if !publicStream(w, r, streamService, req.StreamId, "Failed to create clip") {
+			return
+		}
+
+		clip, err := audited(r, streamService, proto.StreamService_CreateClip_FullMethodName, &req, streamService.CreateClip)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to create clip")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, clip)
+	}
+}
+
+// GetClip returns the clip given by the id query parameter
+func GetClip(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		clip, err := streamService.GetClip(r.Context(), &proto.GetClipRequest{Id: r.URL.Query().Get("id")})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to get clip")
+			return
+		}
+		if !publicStream(w, r, streamService, clip.StreamId, "Failed to get clip") {
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, clip)
+	}
+}
+
+// ListClips lists the clips of the stream_id or creator_id query parameter,
+// newest first. Clips of private streams are left out.
+func ListClips(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		query := r.URL.Query()
+
+		var req proto.ListClipsRequest
+		for name, field := range map[string]*int32{
+			"stream_id":  &req.StreamId,
+			"creator_id": &req.CreatorId,
+			"page":       &req.PageNumber,
+			"page_size":  &req.PageSize,
+		} {
+			value := query.Get(name)
+			if value == "" {
+				continue
+			}
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 {
+				http.Error(w, "Invalid "+name+" query parameter", http.StatusBadRequest)
+				return
+			}
+			*field = int32(parsed)
+		}
+
+		resp, err := streamService.ListPublicClips(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list clips")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
+
+// DeleteClip deletes a clip
+func DeleteClip(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.DeleteClipRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		if _, err := audited(r, streamService, proto.StreamService_DeleteClip_FullMethodName, &req, streamService.DeleteClip); err != nil {
+			writeStatusError(w, logger, err, "Failed to delete clip")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, map[string]interface{}{
+			"status":  "success",
+			"message": "Clip deleted successfully",
+		})
+	}
+}
+
+// GetClipPlaylist serves the HLS playlists of the clip given by the id query
+// parameter: the master playlist, or with media the media playlist of the
+// rendition query parameter. The playback token of the stream is read from
+// the token query parameter or the Authorization header.
+func GetClipPlaylist(streamService *grpcclient.StreamServiceServer, media bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		query := r.URL.Query()
+
+		req := proto.GetClipPlaylistRequest{
+			Id:    query.Get("id"),
+			Token: playback.TokenFromRequest(r),
+		}
+		if media {
+			req.Rendition = query.Get("rendition")
+			if req.Rendition == "" {
+				http.Error(w, "A rendition query parameter is required", http.StatusBadRequest)
+				return
+			}
+		}
+
+		playlist, err := streamService.GetClipPlaylist(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to get clip playlist")
+			return
+		}
+
+		writePlaylist(w, logger, playlist.Content)
+	}
+}
+
+// publicStream answers 404 and returns false unless the stream exists and is
+// not private
+func publicStream(w http.ResponseWriter, r *http.Request, streamService *grpcclient.StreamServiceServer, streamID int32, message string) bool {
+	logger := logging.FromContext(r.Context())
+
+	stream, err := streamService.GetStream(r.Context(), &proto.GetStreamRequest{Id: streamID})
+	if err != nil {
+		writeStatusError(w, logger, err, message)
+		return false
+	}
+	if stream.Visibility == models.VisibilityPrivate {
+		http.Error(w, "Stream not found", http.StatusNotFound)
+		return false
+	}
+	return true
+}
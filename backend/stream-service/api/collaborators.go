@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/clementus360/platform/logging"
+	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/proto"
+)
+
+// AddCollaborator grants a user a role on a stream, or on the owner's whole
+// channel when stream_id is omitted
+func AddCollaborator(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.AddCollaboratorRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		collaborator, err := audited(r, streamService, proto.StreamService_AddCollaborator_FullMethodName, &req, streamService.AddCollaborator)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to add collaborator")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, collaborator)
+	}
+}
+
+// RemoveCollaborator revokes the role of a user on a stream or channel
+func RemoveCollaborator(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.RemoveCollaboratorRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		if _, err := audited(r, streamService, proto.StreamService_RemoveCollaborator_FullMethodName, &req, streamService.RemoveCollaborator); err != nil {
+			writeStatusError(w, logger, err, "Failed to remove collaborator")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, map[string]interface{}{
+			"status":  "success",
+			"message": "Collaborator removed successfully",
+		})
+	}
+}
+
+// ListCollaborators lists the collaborators of the stream given by the
+// stream_id query parameter, or of the channel given by owner_id
+func ListCollaborators(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		req := &proto.ListCollaboratorsRequest{}
+		query := r.URL.Query()
+		if id := query.Get("stream_id"); id != "" {
+			parsedID, err := strconv.Atoi(id)
+			if err != nil {
+				http.Error(w, "Invalid stream_id parameter", http.StatusBadRequest)
+				return
+			}
+			req.StreamId = int32(parsedID)
+		}
+		if id := query.Get("owner_id"); id != "" {
+			parsedID, err := strconv.Atoi(id)
+			if err != nil {
+				http.Error(w, "Invalid owner_id parameter", http.StatusBadRequest)
+				return
+			}
+			req.OwnerId = int32(parsedID)
+		}
+
+		resp, err := streamService.ListCollaborators(r.Context(), req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list collaborators")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
@@ -5,22 +5,21 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/clementus360/platform/logging"
 	grpcclient "github.com/clementus360/stream-service/grpc"
 	"github.com/clementus360/stream-service/proto"
-	"github.com/clementus360/stream-service/utils"
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func CreateStream(grpcClient *grpcclient.Client) http.HandlerFunc {
+func CreateStream(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		logger := logrus.New()
+		logger := logging.FromContext(r.Context())
 
 		// Read and parse the request body with a limit to prevent large payload attacks
 		body, err := io.ReadAll(io.LimitReader(r.Body, 10<<20)) // Limit body size to 10MB
 		if err != nil {
-			logger.Errorf("Failed to read request body: %v", err)
+			logger.Error("Failed to read request body", "error", err)
 			http.Error(w, "Failed to read request body", http.StatusBadRequest)
 			return
 		}
@@ -29,27 +28,14 @@ func CreateStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 		// Unmarshal the JSON into a CreateStreamRequest message
 		var req proto.CreateStreamRequest
 		if err := json.Unmarshal(body, &req); err != nil {
-			logger.Errorf("Invalid request format: %v", err)
+			logger.Error("Invalid request format", "error", err)
 			http.Error(w, "Invalid request format", http.StatusBadRequest)
 			return
 		}
 
-		// Generate stream key only if not provided
-		if req.StreamKey == "" {
-			req.StreamKey = utils.GenerateStreamKey()
-		} else {
-			// Optionally validate the stream key format
-			if len(req.StreamKey) < 10 {
-				http.Error(w, "Invalid stream key", http.StatusBadRequest)
-				return
-			}
-		}
-
-		// Ensure that only authorized users can create streams (optional)
-		// You can use a middleware to validate user authentication here
-
-		// Call gRPC to create the stream
-		streamResponse, err := grpcClient.Client.CreateStream(r.Context(), &req)
+		// The stream service generates the stream key, checks the
+		// visibility and who the stream is created for
+		streamResponse, err := audited(r, streamService, proto.StreamService_CreateStream_FullMethodName, &req, streamService.CreateStream)
 		if err != nil {
 			// Parse the gRPC error to extract detailed error information
 			if errStatus, ok := status.FromError(err); ok {
@@ -78,7 +64,7 @@ func CreateStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 					"details": errorMsg,
 				}
 
-				logger.Errorf("Failed to create stream via grpc: %v", errorMsg)
+				logger.Error("Failed to create stream via grpc", "error", errorMsg)
 
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(statusCode)
@@ -87,7 +73,7 @@ func CreateStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 			}
 
 			// Fallback error handling
-			logger.Errorf("Failed to create stream via grpc: %v", err)
+			logger.Error("Failed to create stream via grpc", "error", err)
 			http.Error(w, "Failed to create stream", http.StatusInternalServerError)
 			return
 		}
@@ -96,10 +82,10 @@ func CreateStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		if err := json.NewEncoder(w).Encode(streamResponse); err != nil {
-			logger.Errorf("Failed to encode response: %v", err)
+			logger.Error("Failed to encode response", "error", err)
 			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		}
 
-		logger.Infof("Created stream: %v", streamResponse)
+		logger.Info("Created stream", "stream_id", streamResponse.Id)
 	}
 }
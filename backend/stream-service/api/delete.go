@@ -5,21 +5,21 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/clementus360/platform/logging"
 	grpcclient "github.com/clementus360/stream-service/grpc"
 	"github.com/clementus360/stream-service/proto"
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func DeleteStream(grpcClient *grpcclient.Client) http.HandlerFunc {
+func DeleteStream(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		logger := logrus.New()
+		logger := logging.FromContext(r.Context())
 
 		// Read and parse the request body with a limit to prevent large payload attacks
 		body, err := io.ReadAll(io.LimitReader(r.Body, 10<<20))
 		if err != nil {
-			logger.Errorf("Failed to read request body: %v", err)
+			logger.Error("Failed to read request body", "error", err)
 			http.Error(w, "Failed to read request body", http.StatusBadRequest)
 			return
 		}
@@ -28,16 +28,14 @@ func DeleteStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 		// Unmarshal the JSON into a GetStreamRequest message
 		var req proto.DeleteStreamRequest
 		if err := json.Unmarshal(body, &req); err != nil {
-			logger.Errorf("Invalid request format: %v", err)
+			logger.Error("Invalid request format", "error", err)
 			http.Error(w, "Invalid request format", http.StatusBadRequest)
 			return
 		}
 
-		// Ensure that only authorized users can delete streams (optional)
-		// You can use a middleware to validate user authentication here
-
-		// Call gRPC to get the stream info
-		streamResponse, err := grpcClient.Client.DeleteStream(r.Context(), &req)
+		// The stream service checks that the user owns the stream and drops
+		// what goes with it
+		_, err = audited(r, streamService, proto.StreamService_DeleteStream_FullMethodName, &req, streamService.DeleteStream)
 		if err != nil {
 			// Parse the gRPC error to extract detailed error information
 			if errStatus, ok := status.FromError(err); ok {
@@ -66,7 +64,7 @@ func DeleteStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 					"details": errorMsg,
 				}
 
-				logger.Errorf("Failed to delete stream via grpc: %v", err)
+				logger.Error("Failed to delete stream via grpc", "error", err)
 
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(statusCode)
@@ -75,7 +73,7 @@ func DeleteStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 			}
 
 			// Fallback error handling
-			logger.Errorf("Failed to delete stream: %v", err)
+			logger.Error("Failed to delete stream", "error", err)
 			http.Error(w, "Failed to delete stream", http.StatusInternalServerError)
 			return
 		}
@@ -88,11 +86,10 @@ func DeleteStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 			"message": "Stream deleted successfully",
 		}
 		if err := json.NewEncoder(w).Encode(successResponse); err != nil {
-			logger.Errorf("Failed to encode response: %v", err)
+			logger.Error("Failed to encode response", "error", err)
 			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		}
 
-		logger.Infof("Deleted stream: %v", streamResponse)
-
+		logger.Info("Deleted stream", "stream_id", req.Id)
 	}
 }
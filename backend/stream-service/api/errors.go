@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// writeStatusError answers with the structured error response matching the
+// gRPC status of err
+func writeStatusError(w http.ResponseWriter, logger *slog.Logger, err error, message string) {
+	errStatus, ok := status.FromError(err)
+	if !ok {
+		logger.Error(message, "error", err)
+		http.Error(w, message, http.StatusInternalServerError)
+		return
+	}
+
+	statusCode := http.StatusInternalServerError
+	switch errStatus.Code() {
+	case codes.InvalidArgument:
+		statusCode = http.StatusBadRequest
+	case codes.NotFound:
+		statusCode = http.StatusNotFound
+	case codes.AlreadyExists:
+		statusCode = http.StatusConflict
+	case codes.FailedPrecondition:
+		statusCode = http.StatusConflict
+	case codes.PermissionDenied:
+		statusCode = http.StatusForbidden
+	case codes.Unauthenticated:
+		statusCode = http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		statusCode = http.StatusTooManyRequests
+	case codes.Unavailable:
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	logger.Error(message, "error", err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "error",
+		"code":    statusCode,
+		"message": message,
+		"details": errStatus.Message(),
+	})
+}
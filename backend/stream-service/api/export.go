@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/export"
+	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/proto"
+)
+
+// ExportStreams streams the streams matching the ListStreams query
+// parameters that the caller may list as CSV or JSON Lines, chosen by the
+// format query parameter
+func ExportStreams(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		query := r.URL.Query()
+
+		format := query.Get("format")
+		if format == "" {
+			format = export.FormatCSV
+		}
+		if !export.ValidFormat(format) {
+			http.Error(w, "format must be csv or jsonl", http.StatusBadRequest)
+			return
+		}
+
+		// ListStreams keeps hidden streams to those who may list them
+		req := &proto.ListStreamsRequest{Filter: streamFilter(query, logger)}
+
+		streams, err := export.Start(r.Context(), streamService.ListStreams, format, req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to export streams")
+			return
+		}
+		rows, err := streams.Serve(r.Context(), w)
+		if err != nil {
+			// The status is already sent; the trailer tells the client the
+			// file is truncated
+			logger.Error("Stream export interrupted", "rows", rows, "error", err)
+			return
+		}
+		logger.Info("Exported streams", "format", format, "rows", rows)
+	}
+}
@@ -2,20 +2,23 @@ package api
 
 import (
 	"encoding/json"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/clementus360/platform/logging"
 	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/models"
 	"github.com/clementus360/stream-service/proto"
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func ListStream(grpcClient *grpcclient.Client) http.HandlerFunc {
+func ListStream(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		logger := logrus.New()
+		logger := logging.FromContext(r.Context())
 
 		// Parse the query parameters
 		query := r.URL.Query()
@@ -29,7 +32,7 @@ func ListStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 			if parsedPage, err := strconv.Atoi(p); err == nil && parsedPage > 0 {
 				pageNumber = int32(parsedPage)
 			} else {
-				logger.Warnf("Invalid page parameter: %v", p)
+				logger.Warn("Invalid page parameter", "value", p)
 			}
 		}
 
@@ -38,66 +41,14 @@ func ListStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 			if parsedPageSize, err := strconv.Atoi(ps); err == nil && parsedPageSize > 0 {
 				pageSize = int32(parsedPageSize)
 			} else {
-				logger.Warnf("Invalid page_size parameter: %v", ps)
+				logger.Warn("Invalid page_size parameter", "value", ps)
 			}
 		}
 
-		// Initialize filter
-		filter := &proto.StreamFilter{}
+		filter := streamFilter(query, logger)
 
-		// Parse text filters
-		if title := query.Get("title_contains"); title != "" {
-			filter.TitleContains = title
-		}
-		if desc := query.Get("description_contains"); desc != "" {
-			filter.DescriptionContains = desc
-		}
-		if codec := query.Get("codec"); codec != "" {
-			filter.Codec = codec
-		}
-		if protocol := query.Get("protocol"); protocol != "" {
-			filter.Protocol = protocol
-		}
-
-		// Parse user_id
-		if id := query.Get("user_id"); id != "" {
-			if parsedID, err := strconv.Atoi(id); err == nil {
-				filter.UserId = int32(parsedID)
-			} else {
-				logger.Warnf("Invalid user_id parameter: %v", id)
-			}
-		}
-
-		// Parse view count filters
-		if minViews := query.Get("min_view_count"); minViews != "" {
-			if parsedViews, err := strconv.Atoi(minViews); err == nil {
-				filter.MinViewCount = int32(parsedViews)
-			}
-		}
-		if maxViews := query.Get("max_view_count"); maxViews != "" {
-			if parsedViews, err := strconv.Atoi(maxViews); err == nil {
-				filter.MaxViewCount = int32(parsedViews)
-			}
-		}
-
-		// Parse time filters
-		if startTime := query.Get("start_time"); startTime != "" {
-			filter.StartTime = startTime
-		}
-		if endTime := query.Get("end_time"); endTime != "" {
-			filter.EndTime = endTime
-		}
-		if endTimeAfter := query.Get("end_time_after"); endTimeAfter != "" {
-			filter.EndTimeAfter = endTimeAfter
-		}
-		if endTimeBefore := query.Get("end_time_before"); endTimeBefore != "" {
-			filter.EndTimeBefore = endTimeBefore
-		}
-
-		// Parse status filters (can be multiple)
-		if statuses := query["status"]; len(statuses) > 0 {
-			filter.Status = statuses
-		}
+		// Only public streams are listed and searched over REST
+		filter.Visibility = []string{models.VisibilityPublic}
 
 		// Create the request
 		req := &proto.ListStreamsRequest{
@@ -109,7 +60,7 @@ func ListStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 		}
 
 		// Call gRPC to list streams
-		streamResponse, err := grpcClient.Client.ListStreams(r.Context(), req)
+		streamResponse, err := streamService.ListStreams(r.Context(), req)
 		if err != nil {
 			// Parse the gRPC error to extract detailed error information
 			if errStatus, ok := status.FromError(err); ok {
@@ -147,7 +98,7 @@ func ListStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 					}
 				}
 
-				logger.Errorf("Failed to list streams via grpc: %v", err)
+				logger.Error("Failed to list streams via grpc", "error", err)
 
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(statusCode)
@@ -156,7 +107,7 @@ func ListStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 			}
 
 			// Fallback error handling
-			logger.Errorf("Failed to list streams: %v", err)
+			logger.Error("Failed to list streams", "error", err)
 			http.Error(w, "Failed to list streams", http.StatusInternalServerError)
 			return
 		}
@@ -165,14 +116,76 @@ func ListStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		if err := json.NewEncoder(w).Encode(streamResponse); err != nil {
-			logger.Errorf("Failed to encode response: %v", err)
+			logger.Error("Failed to encode response", "error", err)
 			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		}
 
-		logger.Infof("Listed streams: %v", streamResponse)
+		logger.Info("Listed streams", "count", len(streamResponse.Streams))
 	}
 }
 
+// streamFilter reads the StreamFilter query parameters shared by the listing
+// and the export of streams
+func streamFilter(query url.Values, logger *slog.Logger) *proto.StreamFilter {
+	filter := &proto.StreamFilter{}
+
+	// Parse text filters
+	if title := query.Get("title_contains"); title != "" {
+		filter.TitleContains = title
+	}
+	if desc := query.Get("description_contains"); desc != "" {
+		filter.DescriptionContains = desc
+	}
+	if codec := query.Get("codec"); codec != "" {
+		filter.Codec = codec
+	}
+	if protocol := query.Get("protocol"); protocol != "" {
+		filter.Protocol = protocol
+	}
+
+	// Parse user_id
+	if id := query.Get("user_id"); id != "" {
+		if parsedID, err := strconv.Atoi(id); err == nil {
+			filter.UserId = int32(parsedID)
+		} else {
+			logger.Warn("Invalid user_id parameter", "value", id)
+		}
+	}
+
+	// Parse view count filters
+	if minViews := query.Get("min_view_count"); minViews != "" {
+		if parsedViews, err := strconv.Atoi(minViews); err == nil {
+			filter.MinViewCount = int32(parsedViews)
+		}
+	}
+	if maxViews := query.Get("max_view_count"); maxViews != "" {
+		if parsedViews, err := strconv.Atoi(maxViews); err == nil {
+			filter.MaxViewCount = int32(parsedViews)
+		}
+	}
+
+	// Parse time filters
+	if startTime := query.Get("start_time"); startTime != "" {
+		filter.StartTime = startTime
+	}
+	if endTime := query.Get("end_time"); endTime != "" {
+		filter.EndTime = endTime
+	}
+	if endTimeAfter := query.Get("end_time_after"); endTimeAfter != "" {
+		filter.EndTimeAfter = endTimeAfter
+	}
+	if endTimeBefore := query.Get("end_time_before"); endTimeBefore != "" {
+		filter.EndTimeBefore = endTimeBefore
+	}
+
+	// Parse status filters (can be multiple)
+	if statuses := query["status"]; len(statuses) > 0 {
+		filter.Status = statuses
+	}
+
+	return filter
+}
+
 // Helper function to parse validation errors from error message
 func parseValidationErrors(errorMsg string) []string {
 	// Simple parsing: split by commas and periods
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/clementus360/platform/logging"
+	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+)
+
+// IssuePlaybackToken returns a signed token for watching a stream. Callers of
+// the REST API are anonymous viewers, so tokens for private streams are only
+// issued through the authenticated gRPC API.
+func IssuePlaybackToken(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.IssuePlaybackTokenRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		stream, err := streamService.GetStream(r.Context(), &proto.GetStreamRequest{Id: req.StreamId})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to issue playback token")
+			return
+		}
+		if stream.Visibility == models.VisibilityPrivate {
+			http.Error(w, "Stream not found", http.StatusNotFound)
+			return
+		}
+
+		token, err := streamService.IssuePlaybackToken(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to issue playback token")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, token)
+	}
+}
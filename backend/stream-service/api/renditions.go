@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/clementus360/platform/logging"
+	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/playback"
+	"github.com/clementus360/stream-service/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListRenditionPresets returns the rendition ladders streams can choose from
+func ListRenditionPresets(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		resp, err := streamService.ListRenditionPresets(r.Context(), &emptypb.Empty{})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list rendition presets")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
+
+// SetStreamRenditions chooses the rendition ladder of a stream
+func SetStreamRenditions(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.SetStreamRenditionsRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		stream, err := audited(r, streamService, proto.StreamService_SetStreamRenditions_FullMethodName, &req, streamService.SetStreamRenditions)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to set stream renditions")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, stream)
+	}
+}
+
+// GetMasterPlaylist serves the HLS master playlist of the live stream given
+// by the stream_id query parameter. The playback token is read from the
+// token query parameter or the Authorization header.
+func GetMasterPlaylist(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		streamID, err := strconv.Atoi(r.URL.Query().Get("stream_id"))
+		if err != nil || streamID < 1 {
+			http.Error(w, "A valid stream_id query parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		playlist, err := streamService.GetMasterPlaylist(r.Context(), &proto.GetMasterPlaylistRequest{
+			StreamId: int32(streamID),
+			Token:    playback.TokenFromRequest(r),
+		})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to get master playlist")
+			return
+		}
+
+		writePlaylist(w, logger, playlist.Content)
+	}
+}
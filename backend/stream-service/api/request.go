@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// decodeBody reads the JSON request body into v with a limit to prevent
+// large payload attacks. It answers 400 and returns false when the body
+// cannot be read or parsed.
+func decodeBody(w http.ResponseWriter, r *http.Request, logger *slog.Logger, v any) bool {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		logger.Error("Failed to read request body", "error", err)
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return false
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		logger.Error("Invalid request format", "error", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// writeJSON answers with status and v encoded as JSON
+func writeJSON(w http.ResponseWriter, logger *slog.Logger, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Error("Failed to encode response", "error", err)
+	}
+}
+
+// writePlaylist writes an HLS playlist. Playlists are not cached, as they
+// stop working once the playback token in their addresses expires.
+func writePlaylist(w http.ResponseWriter, logger *slog.Logger, content string) {
+	w.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(content)); err != nil {
+		logger.Error("Failed to write playlist", "error", err)
+	}
+}
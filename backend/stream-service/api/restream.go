@@ -0,0 +1,110 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/clementus360/platform/logging"
+	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/proto"
+)
+
+// AddRestreamDestination registers a destination a stream is relayed to, or
+// every stream of the owner's channel when stream_id is omitted
+func AddRestreamDestination(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.AddRestreamDestinationRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		destination, err := audited(r, streamService, proto.StreamService_AddRestreamDestination_FullMethodName, &req, streamService.AddRestreamDestination)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to add restream destination")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusCreated, destination)
+	}
+}
+
+// UpdateRestreamDestination changes the fields present in the body, such as
+// enabled to pause or resume a destination
+func UpdateRestreamDestination(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.UpdateRestreamDestinationRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		destination, err := audited(r, streamService, proto.StreamService_UpdateRestreamDestination_FullMethodName, &req, streamService.UpdateRestreamDestination)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to update restream destination")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, destination)
+	}
+}
+
+// RemoveRestreamDestination deletes a destination and stops relaying to it
+func RemoveRestreamDestination(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.RemoveRestreamDestinationRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		if _, err := audited(r, streamService, proto.StreamService_RemoveRestreamDestination_FullMethodName, &req, streamService.RemoveRestreamDestination); err != nil {
+			writeStatusError(w, logger, err, "Failed to remove restream destination")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, map[string]interface{}{
+			"status":  "success",
+			"message": "Restream destination removed successfully",
+		})
+	}
+}
+
+// ListRestreamDestinations lists the destinations of the stream given by the
+// stream_id query parameter, or of the channel given by owner_id, with the
+// status of their connection
+func ListRestreamDestinations(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		req := &proto.ListRestreamDestinationsRequest{}
+		query := r.URL.Query()
+		if id := query.Get("stream_id"); id != "" {
+			parsedID, err := strconv.Atoi(id)
+			if err != nil {
+				http.Error(w, "Invalid stream_id parameter", http.StatusBadRequest)
+				return
+			}
+			req.StreamId = int32(parsedID)
+		}
+		if id := query.Get("owner_id"); id != "" {
+			parsedID, err := strconv.Atoi(id)
+			if err != nil {
+				http.Error(w, "Invalid owner_id parameter", http.StatusBadRequest)
+				return
+			}
+			req.OwnerId = int32(parsedID)
+		}
+
+		resp, err := streamService.ListRestreamDestinations(r.Context(), req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list restream destinations")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
@@ -2,13 +2,13 @@ package api
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 
+	"github.com/clementus360/platform/logging"
 	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/models"
 	"github.com/clementus360/stream-service/proto"
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -29,14 +29,14 @@ type StreamResponse struct {
 	UserID      int32  `json:"user_id"`
 }
 
-func RetrieveStream(grpcClient *grpcclient.Client) http.HandlerFunc {
+func RetrieveStream(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		logger := logrus.New()
+		logger := logging.FromContext(r.Context())
 
 		// Read and parse the request body with a limit to prevent large payload attacks
 		body, err := io.ReadAll(io.LimitReader(r.Body, 10<<20))
 		if err != nil {
-			logger.Errorf("Failed to read request body: %v", err)
+			logger.Error("Failed to read request body", "error", err)
 			http.Error(w, "Failed to read request body", http.StatusBadRequest)
 			return
 		}
@@ -45,7 +45,7 @@ func RetrieveStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 		// Unmarshal the JSON into a GetStreamRequest message
 		var req proto.GetStreamRequest
 		if err := json.Unmarshal(body, &req); err != nil {
-			logger.Errorf("Invalid request format: %v", err)
+			logger.Error("Invalid request format", "error", err)
 			http.Error(w, "Invalid request format", http.StatusBadRequest)
 			return
 		}
@@ -54,7 +54,7 @@ func RetrieveStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 		// You can use a middleware to validate user authentication here
 
 		// Call gRPC to get the stream info
-		streamResponse, err := grpcClient.Client.GetStream(r.Context(), &req)
+		streamResponse, err := streamService.GetStream(r.Context(), &req)
 		if err != nil {
 			// Parse the gRPC error to extract detailed error information
 			if errStatus, ok := status.FromError(err); ok {
@@ -81,7 +81,7 @@ func RetrieveStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 					"details": errorMsg,
 				}
 
-				logger.Errorf("Failed to get stream info via gRPC: %v", err)
+				logger.Error("Failed to get stream info via gRPC", "error", err)
 
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(statusCode)
@@ -90,21 +90,26 @@ func RetrieveStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 			}
 
 			// Fallback error handling if not a standard gRPC status error
-			logger.Errorf("Failed to get stream info: %v", err)
+			logger.Error("Failed to get stream info", "error", err)
 			http.Error(w, "Failed to get stream info", http.StatusInternalServerError)
 			return
 		}
 
-		fmt.Println(streamResponse)
+		// Private streams are only shown to the owner's team, through the
+		// authenticated gRPC API
+		if streamResponse.Visibility == models.VisibilityPrivate {
+			http.Error(w, "Stream not found", http.StatusNotFound)
+			return
+		}
 
 		// Respond with the created stream as JSON
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		if err := json.NewEncoder(w).Encode(streamResponse); err != nil {
-			logger.Errorf("Failed to encode response: %v", err)
+			logger.Error("Failed to encode response", "error", err)
 			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		}
 
-		logger.Infof("Retrieved stream info: %v", streamResponse)
+		logger.Info("Retrieved stream info", "stream_id", streamResponse.Id)
 	}
 }
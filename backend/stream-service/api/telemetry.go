@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/clementus360/platform/logging"
+	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/proto"
+)
+
+// ReportStreamTelemetry records a telemetry report of the encoder of a live
+// stream. Encoders report every few seconds while broadcasting.
+func ReportStreamTelemetry(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.StreamTelemetry
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		health, err := streamService.ReportStreamTelemetry(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to report stream telemetry")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusAccepted, health)
+	}
+}
+
+// GetStreamHealth returns the recent telemetry and health warnings of the
+// stream given by the stream_id query parameter
+func GetStreamHealth(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		streamID, err := strconv.Atoi(r.URL.Query().Get("stream_id"))
+		if err != nil || streamID < 1 {
+			http.Error(w, "A valid stream_id query parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		health, err := streamService.GetStreamHealth(r.Context(), &proto.GetStreamHealthRequest{StreamId: int32(streamID)})
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to get stream health")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, health)
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/clementus360/platform/logging"
+	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/proto"
+)
+
+// ListDeletedStreams lists the streams in the trash, most recently deleted
+// first, optionally only those of the user_id query parameter or deleted
+// before deleted_before
+func ListDeletedStreams(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		query := r.URL.Query()
+
+		req := proto.ListDeletedStreamsRequest{DeletedBefore: query.Get("deleted_before")}
+		for name, field := range map[string]*int32{
+			"user_id":   &req.UserId,
+			"page":      &req.PageNumber,
+			"page_size": &req.PageSize,
+		} {
+			value := query.Get(name)
+			if value == "" {
+				continue
+			}
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 {
+				http.Error(w, "Invalid "+name+" query parameter", http.StatusBadRequest)
+				return
+			}
+			*field = int32(parsed)
+		}
+
+		resp, err := streamService.ListDeletedStreams(r.Context(), &req)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to list deleted streams")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, resp)
+	}
+}
+
+// RestoreStream takes a stream out of the trash
+func RestoreStream(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+
+		var req proto.RestoreStreamRequest
+		if !decodeBody(w, r, logger, &req) {
+			return
+		}
+
+		stream, err := audited(r, streamService, proto.StreamService_RestoreStream_FullMethodName, &req, streamService.RestoreStream)
+		if err != nil {
+			writeStatusError(w, logger, err, "Failed to restore stream")
+			return
+		}
+
+		writeJSON(w, logger, http.StatusOK, stream)
+	}
+}
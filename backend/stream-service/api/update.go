@@ -5,20 +5,18 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/clementus360/platform/logging"
 	grpcclient "github.com/clementus360/stream-service/grpc"
 	"github.com/clementus360/stream-service/proto"
-	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
-func UpdateStream(grpcClient *grpcclient.Client) http.HandlerFunc {
+func UpdateStream(streamService *grpcclient.StreamServiceServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		logger := logrus.New()
+		logger := logging.FromContext(r.Context())
 		// Read and parse the request body with a limit to prevent large payload attacks
 		body, err := io.ReadAll(io.LimitReader(r.Body, 10<<20))
 		if err != nil {
-			logger.Errorf("Failed to read request body: %v", err)
+			logger.Error("Failed to read request body", "error", err)
 			http.Error(w, "Failed to read request body", http.StatusBadRequest)
 			return
 		}
@@ -27,51 +25,16 @@ func UpdateStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 		// Unmarshal the JSON into a UpdateStreamRequest message
 		var req proto.UpdateStreamRequest
 		if err := json.Unmarshal(body, &req); err != nil {
-			logger.Errorf("Invalid request format: %v", err)
+			logger.Error("Invalid request format", "error", err)
 			http.Error(w, "Invalid request format", http.StatusBadRequest)
 			return
 		}
 
-		// Call gRPC to update the stream info
-		streamResponse, err := grpcClient.Client.UpdateStream(r.Context(), &req)
+		// The stream service checks the visibility and what the user may
+		// change, and starts or stops what follows the status
+		streamResponse, err := audited(r, streamService, proto.StreamService_UpdateStream_FullMethodName, &req, streamService.UpdateStream)
 		if err != nil {
-			// Parse the gRPC error to extract validation errors
-			if errStatus, ok := status.FromError(err); ok {
-				// Check if it's an InvalidArgument error
-				if errStatus.Code() == codes.InvalidArgument {
-					errorMsg := errStatus.Message()
-					// Create a structured error response
-					errorResponse := map[string]interface{}{
-						"status":  "error",
-						"code":    http.StatusBadRequest,
-						"message": "Validation failed",
-						"details": errorMsg,
-					}
-					w.Header().Set("Content-Type", "application/json")
-					w.WriteHeader(http.StatusBadRequest)
-					json.NewEncoder(w).Encode(errorResponse)
-					return
-				} else if errStatus.Code() == codes.NotFound {
-					errMsg := errStatus.Message()
-
-					errorResponse := map[string]interface{}{
-						"status":  "error",
-						"code":    http.StatusNotFound,
-						"message": "Stream not found",
-						"details": errMsg,
-					}
-
-					logger.Errorf("Failed to update stream info: %v", err)
-
-					w.Header().Set("Content-Type", "application/json")
-					w.WriteHeader(http.StatusNotFound)
-					json.NewEncoder(w).Encode(errorResponse)
-					return
-				}
-			}
-			// If not a validation error, return generic error
-			logger.Errorf("Failed to update stream info: %v", err)
-			http.Error(w, "Failed to update stream info", http.StatusInternalServerError)
+			writeStatusError(w, logger, err, "Failed to update stream info")
 			return
 		}
 
@@ -79,7 +42,7 @@ func UpdateStream(grpcClient *grpcclient.Client) http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK) // Changed from StatusFound to StatusOK
 		if err := json.NewEncoder(w).Encode(streamResponse); err != nil {
-			logger.Errorf("Failed to encode response: %v", err)
+			logger.Error("Failed to encode response", "error", err)
 			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		}
 	}
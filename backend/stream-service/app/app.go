@@ -0,0 +1,361 @@
+// Package app assembles the stream service from its configuration. It is
+// shared by main and the integration tests, which run the service in-process.
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clementus360/platform/audit"
+	"github.com/clementus360/platform/auth"
+	platformconfig "github.com/clementus360/platform/config"
+	"github.com/clementus360/platform/dial"
+	"github.com/clementus360/platform/health"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/tlsconfig"
+	"github.com/clementus360/platform/tracing"
+	"github.com/clementus360/stream-service/analytics"
+	"github.com/clementus360/stream-service/api"
+	"github.com/clementus360/stream-service/clips"
+	"github.com/clementus360/stream-service/collaborators"
+	"github.com/clementus360/stream-service/config"
+	grpcclient "github.com/clementus360/stream-service/grpc"
+	"github.com/clementus360/stream-service/metrics"
+	"github.com/clementus360/stream-service/playback"
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/stream-service/purge"
+	"github.com/clementus360/stream-service/renditions"
+	"github.com/clementus360/stream-service/restream"
+	"github.com/clementus360/stream-service/telemetry"
+	"github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+)
+
+// Option customizes an App
+type Option func(*options)
+
+type options struct {
+	logger      *slog.Logger
+	dialOptions []grpc.DialOption
+}
+
+// WithLogger replaces the logger built from the configuration
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithDialOptions adds options to the connection to the database service
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// App is a stream service connected to the database service
+type App struct {
+	tlsManager *tlsconfig.Manager
+	metrics    *metrics.Metrics
+	checker    *health.Checker
+	grpcClient *grpcclient.Client
+	analytics  *analytics.Service
+	telemetry  *telemetry.Service
+	restream   *restream.Service
+	purge      *purge.Service
+	grpcServer *grpc.Server
+	handler    http.Handler
+}
+
+// New connects to the database service, registers the stream service on a
+// gRPC server and builds the REST API. Nothing is served until the server is
+// handed a listener.
+func New(ctx context.Context, cfg *config.Config, opts ...Option) (*App, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	logger := o.logger
+	if logger == nil {
+		logger = logging.New("stream-service", cfg.Log.Level, cfg.Log.Format)
+	}
+	ctx = logging.WithLogger(ctx, logger)
+
+	// register the Prometheus collectors exposed on /metrics
+	serviceMetrics := metrics.New("stream_service")
+
+	// load the certificates shared by the gRPC server and client
+	tlsManager, err := tlsconfig.New(ctx, "stream-service", tlsconfig.Config{
+		Enabled:           cfg.TLS.Enabled,
+		CertFile:          cfg.TLS.CertFile,
+		KeyFile:           cfg.TLS.KeyFile,
+		CAFile:            cfg.TLS.CAFile,
+		ClientAuth:        cfg.TLS.ClientAuth,
+		AllowedIdentities: cfg.TLS.AllowedIdentities,
+		ReloadInterval:    cfg.TLS.ReloadInterval,
+		DevMode:           cfg.TLS.DevMode,
+		DevDir:            cfg.TLS.DevDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize TLS: %w", err)
+	}
+
+	// sign outgoing calls and verify incoming ones with the shared service key
+	authenticator := auth.New("stream-service", auth.Config{
+		SigningKey:     cfg.Auth.SigningKey,
+		Enforce:        cfg.Auth.Enforce,
+		AllowedCallers: cfg.Auth.AllowedCallers,
+		TrustedCallers: cfg.Auth.TrustedCallers,
+		TokenTTL:       cfg.Auth.TokenTTL,
+	})
+
+	// every outgoing connection shares the same deadlines, retries and
+	// circuit breaker settings
+	methodTimeouts, _ := cfg.Client.MethodTimeoutMap()
+	dialer := dial.NewDialer(dial.Config{
+		Timeout:        cfg.Client.Timeout,
+		MethodTimeouts: methodTimeouts,
+		Retry: dial.RetryConfig{
+			MaxAttempts:    cfg.Client.RetryMaxAttempts,
+			InitialBackoff: cfg.Client.RetryInitialBackoff,
+			MaxBackoff:     cfg.Client.RetryMaxBackoff,
+		},
+		Breaker: dial.BreakerConfig{
+			Failures:    cfg.Client.BreakerFailures,
+			OpenTimeout: cfg.Client.BreakerOpenTimeout,
+		},
+		KeepaliveTime:     cfg.Client.KeepaliveTime,
+		KeepaliveTimeout:  cfg.Client.KeepaliveTimeout,
+		MaxConnectBackoff: cfg.Client.MaxConnectBackoff,
+		DialOptions:       o.dialOptions,
+	}, tlsManager, authenticator, serviceMetrics.Metrics)
+
+	// start a grpc client with context to handle grpc connections
+	grpcClient, err := grpcclient.NewClient(ctx, cfg.Database.Address, dialer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize gRPC client: %w", err)
+	}
+
+	// viewer sessions are rolled up once the stream is no longer live
+	analyticsService := analytics.NewService(analytics.Config{
+		RollupInterval:       cfg.Analytics.RollupInterval,
+		SessionRetention:     cfg.Analytics.SessionRetention,
+		MaxSessionsPerViewer: cfg.Analytics.MaxSessionsPerViewer,
+	}, streamdb.NewAnalyticsServiceClient(streamdb.WireConn(grpcClient.Conn)), func(ctx context.Context, id int32) (*proto.StreamResponse, error) {
+		return grpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: id})
+	})
+
+	// encoder telemetry raises warnings when a live stream degrades
+	telemetryService := telemetry.NewService(telemetry.Config{
+		Retention: cfg.Telemetry.Retention,
+		Thresholds: telemetry.Thresholds{
+			Window:              cfg.Telemetry.Window,
+			StaleAfter:          cfg.Telemetry.StaleAfter,
+			BitrateTolerance:    cfg.Telemetry.BitrateTolerance,
+			FramerateTolerance:  cfg.Telemetry.FramerateTolerance,
+			MaxDroppedFrames:    cfg.Telemetry.MaxDroppedFrames,
+			MaxKeyframeInterval: cfg.Telemetry.MaxKeyframeInterval,
+			SilenceLevel:        cfg.Telemetry.SilenceLevel,
+		},
+	}, streamdb.NewTelemetryServiceClient(streamdb.WireConn(grpcClient.Conn)), func(streamID int32, warning telemetry.Warning) {
+		logger.Warn("Stream health degraded", "stream_id", streamID, "kind", warning.Kind, "message", warning.Message)
+		serviceMetrics.StreamHealthWarning(warning.Kind)
+	})
+
+	// rendition ladders come from the built-in and configured presets
+	presets, _ := cfg.Renditions.PresetMap()
+	ladders, err := renditions.NewLadders(presets, cfg.Renditions.DefaultPreset, streamdb.NewRenditionServiceClient(streamdb.WireConn(grpcClient.Conn)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid rendition presets: %w", err)
+	}
+
+	// live streams are relayed by ffmpeg to the destinations of their owners
+	cipher, err := restream.NewCipher(cfg.Restream.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid restream encryption key: %w", err)
+	}
+	restreamService := restream.NewService(restream.Config{
+		SourceURL:         cfg.Restream.SourceURL,
+		RetryBackoff:      cfg.Restream.RetryBackoff,
+		MaxRetryBackoff:   cfg.Restream.MaxRetryBackoff,
+		AllowPrivateHosts: cfg.Restream.AllowPrivateHosts,
+	}, streamdb.NewRestreamServiceClient(streamdb.WireConn(grpcClient.Conn)), cipher, restream.FFmpeg{Path: cfg.Restream.FFmpegPath}, func(destination restream.Destination, status restream.Status) {
+		if status.State == restream.StateError {
+			logger.Warn("Restream failed", "destination_id", destination.ID, "stream_id", status.StreamID, "attempts", status.Attempts, "error", status.Error)
+			return
+		}
+		logger.Info("Restream state changed", "destination_id", destination.ID, "stream_id", status.StreamID, "state", status.State)
+	})
+
+	collaboratorStore := collaborators.NewStore(streamdb.NewCollaboratorServiceClient(streamdb.WireConn(grpcClient.Conn)))
+	clipStore := clips.NewStore(clips.Config{
+		SegmentDuration: cfg.Clips.SegmentDuration,
+		MinDuration:     cfg.Clips.MinDuration,
+		MaxDuration:     cfg.Clips.MaxDuration,
+		DVRWindow:       cfg.Clips.DVRWindow,
+	}, streamdb.NewClipServiceClient(streamdb.WireConn(grpcClient.Conn)))
+
+	// deleted streams, their media and what goes with them are removed for
+	// good after the retention period
+	purgeService := purge.NewService(purge.Config{
+		Retention:  cfg.Purge.Retention,
+		Interval:   cfg.Purge.Interval,
+		MediaPaths: cfg.Purge.MediaPaths,
+	}, grpcClient.Client,
+		collaboratorStore.RemoveStream,
+		ladders.Remove,
+		clipStore.RemoveStream,
+		restreamService.RemoveStream,
+		analyticsService.RemoveStream,
+	)
+
+	// mutations are recorded in the audit log the database service keeps
+	// for every service
+	auditLog := audit.NewLog("stream-service", streamdb.NewAuditServiceClient(streamdb.WireConn(grpcClient.Conn)))
+
+	streamService := &grpcclient.StreamServiceServer{
+		GrpcClient:    *grpcClient,
+		Metrics:       serviceMetrics,
+		Analytics:     analyticsService,
+		Collaborators: collaboratorStore,
+		// tokens for watching streams, verified offline by the distribution
+		// server with the same key
+		Playback:   playback.NewSigner(cfg.Playback.SigningKey, cfg.Playback.TokenTTL),
+		Telemetry:  telemetryService,
+		Renditions: ladders,
+		Clips:      clipStore,
+		Restream:   restreamService,
+		Purge:      purgeService,
+		Audit:      auditLog,
+		HLSBaseURL: cfg.Playback.HLSBaseURL,
+	}
+
+	streamService.Recorder = audit.NewRecorder(auditLog, streamService.AuditedMethods(), "stream_key")
+
+	// define route handlers
+	router := http.NewServeMux()
+	router.HandleFunc("POST /v1/api/stream", api.CreateStream(streamService))
+	router.HandleFunc("GET /v1/api/stream", api.RetrieveStream(streamService))
+	router.HandleFunc("PATCH /v1/api/stream", api.UpdateStream(streamService))
+	router.HandleFunc("DELETE /v1/api/stream", api.DeleteStream(streamService))
+	router.HandleFunc("GET /v1/api/streams", api.ListStream(streamService))
+	router.HandleFunc("GET /v1/api/streams/export", api.ExportStreams(streamService))
+	router.HandleFunc("GET /v1/api/streams/deleted", api.ListDeletedStreams(streamService))
+	router.HandleFunc("POST /v1/api/stream/restore", api.RestoreStream(streamService))
+	router.HandleFunc("POST /v1/api/stream/session", api.StartViewerSession(streamService))
+	router.HandleFunc("PATCH /v1/api/stream/session", api.EndViewerSession(streamService))
+	router.HandleFunc("GET /v1/api/stream/analytics", api.GetStreamAnalytics(streamService))
+	router.HandleFunc("POST /v1/api/stream/collaborators", api.AddCollaborator(streamService))
+	router.HandleFunc("DELETE /v1/api/stream/collaborators", api.RemoveCollaborator(streamService))
+	router.HandleFunc("GET /v1/api/stream/collaborators", api.ListCollaborators(streamService))
+	router.HandleFunc("POST /v1/api/stream/playback-token", api.IssuePlaybackToken(streamService))
+	router.HandleFunc("POST /v1/api/stream/telemetry", api.ReportStreamTelemetry(streamService))
+	router.HandleFunc("GET /v1/api/stream/health", api.GetStreamHealth(streamService))
+	router.HandleFunc("GET /v1/api/stream/renditions/presets", api.ListRenditionPresets(streamService))
+	router.HandleFunc("PUT /v1/api/stream/renditions", api.SetStreamRenditions(streamService))
+	router.HandleFunc("GET /v1/api/stream/master.m3u8", api.GetMasterPlaylist(streamService))
+	router.HandleFunc("POST /v1/api/clip", api.CreateClip(streamService))
+	router.HandleFunc("GET /v1/api/clip", api.GetClip(streamService))
+	router.HandleFunc("DELETE /v1/api/clip", api.DeleteClip(streamService))
+	router.HandleFunc("GET /v1/api/clips", api.ListClips(streamService))
+	router.HandleFunc("GET /v1/api/clip/master.m3u8", api.GetClipPlaylist(streamService, false))
+	router.HandleFunc("GET /v1/api/clip/playlist.m3u8", api.GetClipPlaylist(streamService, true))
+	router.HandleFunc("POST /v1/api/stream/restreams", api.AddRestreamDestination(streamService))
+	router.HandleFunc("PATCH /v1/api/stream/restreams", api.UpdateRestreamDestination(streamService))
+	router.HandleFunc("DELETE /v1/api/stream/restreams", api.RemoveRestreamDestination(streamService))
+	router.HandleFunc("GET /v1/api/stream/restreams", api.ListRestreamDestinations(streamService))
+	router.Handle("GET /metrics", serviceMetrics.Handler())
+
+	// probe the database service for readiness
+	checker := health.NewChecker(2*time.Second, proto.StreamService_ServiceDesc.ServiceName)
+	checker.AddProbe("database_service", health.ConnProbe(grpcClient.Conn))
+	router.HandleFunc("GET /healthz", checker.LivenessHandler())
+	router.HandleFunc("GET /readyz", checker.ReadinessHandler())
+
+	grpcServer := grpc.NewServer(
+		tlsManager.ServerOption(),
+		tracing.ServerOption(),
+		// accept the keepalive pings of other services' clients
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             platformconfig.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(logger),
+			serviceMetrics.UnaryServerInterceptor(),
+			authenticator.UnaryServerInterceptor(),
+			streamService.Recorder.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			logging.StreamServerInterceptor(logger),
+			serviceMetrics.StreamServerInterceptor(),
+			authenticator.StreamServerInterceptor(),
+		),
+	)
+
+	reflection.Register(grpcServer)
+	checker.Register(grpcServer)
+
+	proto.RegisterStreamServiceServer(grpcServer, streamService)
+
+	return &App{
+		tlsManager: tlsManager,
+		metrics:    serviceMetrics,
+		checker:    checker,
+		grpcClient: grpcClient,
+		analytics:  analyticsService,
+		telemetry:  telemetryService,
+		restream:   restreamService,
+		purge:      purgeService,
+		grpcServer: grpcServer,
+		// the REST API authenticates requests like the gRPC API, with the
+		// service token of the API gateway, except for the playlists, which
+		// players fetch with a playback token. The metrics wrap the router
+		// itself, as the authenticator passes on a copy of the request that
+		// the matched route is recorded on.
+		handler: tracing.Middleware(logging.Middleware(logger,
+			authenticator.Middleware(serviceMetrics.Middleware(router), "/metrics", "/healthz", "/readyz",
+				"/v1/api/stream/master.m3u8", "/v1/api/clip/master.m3u8", "/v1/api/clip/playlist.m3u8"),
+		)),
+	}, nil
+}
+
+// GRPCServer returns the server the stream service is registered on
+func (a *App) GRPCServer() *grpc.Server {
+	return a.grpcServer
+}
+
+// Handler serves the REST API together with the metrics and health endpoints
+func (a *App) Handler() http.Handler {
+	return a.handler
+}
+
+// Start runs the background loops until ctx is cancelled
+func (a *App) Start(ctx context.Context) {
+	go a.metrics.TrackLiveStreams(ctx, 30*time.Second, a.grpcClient.CountLiveStreams)
+	go a.metrics.TrackViewerSessions(ctx, 30*time.Second, a.analytics.OpenSessions)
+	go a.checker.Run(ctx, 10*time.Second)
+	go a.analytics.Run(ctx)
+	go a.telemetry.Run(ctx)
+	go a.purge.Run(ctx)
+	go a.tlsManager.Watch(ctx)
+}
+
+// Drain reports NOT_SERVING so that load balancers stop sending traffic
+// before the server stops
+func (a *App) Drain() {
+	a.checker.Shutdown()
+}
+
+// Close stops the gRPC server gracefully, stops restreaming and closes the
+// connection to the database service
+func (a *App) Close() {
+	a.grpcServer.GracefulStop()
+	a.restream.Close()
+	a.grpcClient.Close()
+}
@@ -0,0 +1,91 @@
+// Package clips cuts short clips out of broadcasts. A clip is a range of the
+// HLS segments a stream was packaged into, taken from the DVR window of a
+// live stream or from the recording of a finished one, and replayed with its
+// own playlists. Clips and the broadcasts they are cut from are stored by the
+// database service; their segments must be kept by the packager.
+package clips
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/clementus360/stream-service/renditions"
+)
+
+// Sources of clips
+const (
+	SourceLive      = "live"
+	SourceRecording = "recording"
+)
+
+var (
+	// ErrClipNotFound is returned for unknown clip ids
+	ErrClipNotFound = errors.New("clip not found")
+	// ErrNotRecorded is returned for streams that were never broadcast
+	ErrNotRecorded = errors.New("stream has no recording or DVR window to clip from")
+	// ErrInvalidDuration is returned for clips that are too short or long
+	ErrInvalidDuration = errors.New("invalid clip duration")
+	// ErrOutOfRange is returned for clips outside the recorded range
+	ErrOutOfRange = errors.New("clip is outside the recorded range")
+	// ErrInvalidTitle is returned for titles that are too long
+	ErrInvalidTitle = errors.New("invalid clip title")
+)
+
+// Config tunes how clips are cut
+type Config struct {
+	// SegmentDuration is the duration of the segments of the packager.
+	// Clips start and end on segment boundaries.
+	SegmentDuration time.Duration
+	MinDuration     time.Duration
+	MaxDuration     time.Duration
+	// DVRWindow is how far back live streams can be clipped
+	DVRWindow time.Duration
+}
+
+// Clip is a range of a broadcast
+type Clip struct {
+	ID        string
+	StreamID  int32
+	CreatorID int32
+	Title     string
+	Source    string
+	// FirstSegment is the sequence number of the first segment, counted
+	// from the start of the broadcast
+	FirstSegment int
+	Segments     int
+	// SegmentDuration is the segment duration the clip was cut with
+	SegmentDuration time.Duration
+	// Renditions is the ladder of the stream when the clip was cut
+	Renditions []renditions.Rendition
+	CreatedAt  time.Time
+}
+
+// StartOffset is where the clip starts in the broadcast
+func (c Clip) StartOffset() time.Duration {
+	return time.Duration(c.FirstSegment) * c.SegmentDuration
+}
+
+// Duration is the length of the clip
+func (c Clip) Duration() time.Duration {
+	return time.Duration(c.Segments) * c.SegmentDuration
+}
+
+// HasRendition reports whether the clip was cut with the rendition name
+func (c Clip) HasRendition(name string) bool {
+	for _, r := range c.Renditions {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// newClipID returns a random id, which is hard to guess so that clips of
+// unlisted streams are only found through their link
+func newClipID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
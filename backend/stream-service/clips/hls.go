@@ -0,0 +1,27 @@
+package clips
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MediaPlaylist renders the HLS media playlist of one rendition of a clip.
+// uri returns the address of the segment with a sequence number.
+func MediaPlaylist(clip Clip, uri func(sequence int) string) string {
+	seconds := clip.SegmentDuration.Seconds()
+
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-VERSION:3\n")
+	fmt.Fprintf(&b, "#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(seconds)))
+	fmt.Fprintf(&b, "#EXT-X-MEDIA-SEQUENCE:%d\n", clip.FirstSegment)
+	b.WriteString("#EXT-X-PLAYLIST-TYPE:VOD\n")
+	for sequence := clip.FirstSegment; sequence < clip.FirstSegment+clip.Segments; sequence++ {
+		fmt.Fprintf(&b, "#EXTINF:%.3f,\n", seconds)
+		b.WriteString(uri(sequence))
+		b.WriteByte('\n')
+	}
+	b.WriteString("#EXT-X-ENDLIST\n")
+	return b.String()
+}
@@ -0,0 +1,264 @@
+package clips
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/stream-service/renditions"
+	"github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// storedTimeFormat parses the times of broadcasts and clips, which the
+// database service returns in the round-trip format of .NET
+const storedTimeFormat = time.RFC3339Nano
+
+// maxTitleLength is the length of the title column of clips
+const maxTitleLength = 100
+
+// broadcast is the time range of the last broadcast of a stream
+type broadcast struct {
+	start time.Time
+	// end is zero while the stream is live
+	end time.Time
+}
+
+// Filter selects clips. At least one field must be set.
+type Filter struct {
+	StreamID  int32
+	CreatorID int32
+}
+
+// Store keeps clips and the broadcasts they are cut from in the database
+// service
+type Store struct {
+	cfg    Config
+	now    func() time.Time
+	client streamdb.ClipServiceClient
+}
+
+// NewStore returns a store calling the database service through client
+func NewStore(cfg Config, client streamdb.ClipServiceClient) *Store {
+	return &Store{
+		cfg:    cfg,
+		now:    func() time.Time { return time.Now().UTC() },
+		client: client,
+	}
+}
+
+// StreamLive records that a stream went live. Segments are numbered from
+// the start of each broadcast, so going live again starts a new timeline.
+func (s *Store) StreamLive(ctx context.Context, streamID int32) error {
+	_, err := s.client.StartBroadcast(ctx, &streamdb.BroadcastRequest{StreamId: streamID})
+	return err
+}
+
+// StreamEnded records that the broadcast of a stream ended. Streams that
+// were never seen live have no broadcast to end.
+func (s *Store) StreamEnded(ctx context.Context, streamID int32) error {
+	_, err := s.client.EndBroadcast(ctx, &streamdb.BroadcastRequest{StreamId: streamID})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
+
+// Create cuts a clip of duration from stream, starting at offset into the
+// broadcast. A negative offset counts back from the end of what can be
+// clipped, so -30s on a live stream is the last thirty seconds. The clip is
+// widened to whole segments.
+func (s *Store) Create(ctx context.Context, stream *proto.StreamResponse, creatorID int32, title string, offset, duration time.Duration, ladder []renditions.Rendition) (Clip, error) {
+	if duration < s.cfg.MinDuration || duration > s.cfg.MaxDuration {
+		return Clip{}, fmt.Errorf("%w: clips last between %s and %s", ErrInvalidDuration, s.cfg.MinDuration, s.cfg.MaxDuration)
+	}
+	title = strings.TrimSpace(title)
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return Clip{}, fmt.Errorf("%w: titles have at most %d characters", ErrInvalidTitle, maxTitleLength)
+	}
+
+	from, to, source, err := s.clippable(ctx, stream)
+	if err != nil {
+		return Clip{}, err
+	}
+	if offset < 0 {
+		offset += to
+	}
+	if offset < from || offset+duration > to {
+		return Clip{}, fmt.Errorf("%w: %s to %s into the broadcast can be clipped", ErrOutOfRange, from.Round(time.Second), to.Round(time.Second))
+	}
+
+	segment := s.cfg.SegmentDuration
+	first := int(offset / segment)
+	last := int((offset + duration + segment - 1) / segment)
+
+	req := &streamdb.CreateClipRequest{
+		Id:                newClipID(),
+		StreamId:          stream.Id,
+		CreatorId:         creatorID,
+		Title:             title,
+		Source:            source,
+		FirstSegment:      int32(first),
+		Segments:          int32(last - first),
+		SegmentDurationMs: int32(segment.Milliseconds()),
+	}
+	for _, r := range ladder {
+		req.Renditions = append(req.Renditions, renditionSpec(r))
+	}
+	resp, err := s.client.CreateClip(ctx, req)
+	if err != nil {
+		return Clip{}, err
+	}
+	return fromClip(resp)
+}
+
+// clippable returns the range of the broadcast of stream that can be
+// clipped, as offsets from its start. Broadcasts recorded when the stream
+// went live are preferred to the times stored with the stream.
+func (s *Store) clippable(ctx context.Context, stream *proto.StreamResponse) (from, to time.Duration, source string, err error) {
+	b, err := s.broadcast(ctx, stream.Id)
+	if status.Code(err) == codes.NotFound {
+		b.start, _ = time.Parse(models.TimeFormat, stream.StartTime)
+		b.end, _ = time.Parse(models.TimeFormat, stream.EndTime)
+	} else if err != nil {
+		return 0, 0, "", err
+	}
+	if b.start.IsZero() {
+		return 0, 0, "", ErrNotRecorded
+	}
+
+	switch stream.Status {
+	case models.StatusOnline:
+		// the segment being written cannot be clipped yet
+		to = s.now().Sub(b.start).Truncate(s.cfg.SegmentDuration)
+		return max(0, to-s.cfg.DVRWindow), to, SourceLive, nil
+	case models.StatusComplete, models.StatusOffline:
+		if b.end.IsZero() || !b.end.After(b.start) {
+			return 0, 0, "", ErrNotRecorded
+		}
+		return 0, b.end.Sub(b.start), SourceRecording, nil
+	}
+	return 0, 0, "", ErrNotRecorded
+}
+
+// broadcast returns the last broadcast of a stream, failing with NotFound
+// when it was never seen live
+func (s *Store) broadcast(ctx context.Context, streamID int32) (broadcast, error) {
+	resp, err := s.client.GetBroadcast(ctx, &streamdb.BroadcastRequest{StreamId: streamID})
+	if err != nil {
+		return broadcast{}, err
+	}
+
+	var b broadcast
+	if b.start, err = time.Parse(storedTimeFormat, resp.StartedAt); err != nil {
+		return broadcast{}, fmt.Errorf("broadcast of stream %d has an invalid start: %w", streamID, err)
+	}
+	if resp.EndedAt != "" {
+		if b.end, err = time.Parse(storedTimeFormat, resp.EndedAt); err != nil {
+			return broadcast{}, fmt.Errorf("broadcast of stream %d has an invalid end: %w", streamID, err)
+		}
+	}
+	return b, nil
+}
+
+// Get returns a clip by id
+func (s *Store) Get(ctx context.Context, id string) (Clip, error) {
+	resp, err := s.client.GetClip(ctx, &streamdb.GetClipRequest{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return Clip{}, ErrClipNotFound
+	}
+	if err != nil {
+		return Clip{}, err
+	}
+	return fromClip(resp)
+}
+
+// List returns the clips matching filter, newest first
+func (s *Store) List(ctx context.Context, filter Filter) ([]Clip, error) {
+	resp, err := s.client.ListClips(ctx, &streamdb.ListClipsRequest{StreamId: filter.StreamID, CreatorId: filter.CreatorID})
+	if err != nil {
+		return nil, err
+	}
+
+	clips := make([]Clip, 0, len(resp.Clips))
+	for _, stored := range resp.Clips {
+		clip, err := fromClip(stored)
+		if err != nil {
+			return nil, err
+		}
+		clips = append(clips, clip)
+	}
+	return clips, nil
+}
+
+// Delete removes a clip
+func (s *Store) Delete(ctx context.Context, id string) error {
+	_, err := s.client.DeleteClip(ctx, &streamdb.DeleteClipRequest{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return ErrClipNotFound
+	}
+	return err
+}
+
+// RemoveStream drops the clips and broadcast of a purged stream
+func (s *Store) RemoveStream(ctx context.Context, streamID int32) error {
+	_, err := s.client.DeleteStreamClips(ctx, &streamdb.DeleteStreamClipsRequest{StreamId: streamID})
+	return err
+}
+
+// renditionSpec writes a rendition of a clip as its spec prefixed with its
+// width, such as "1280x720p30@2500", since widths depend on the source the
+// clip was cut from
+func renditionSpec(r renditions.Rendition) string {
+	return fmt.Sprintf("%dx%s", r.Width, r.Spec())
+}
+
+// parseRenditionSpec reads a rendition written by renditionSpec
+func parseRenditionSpec(spec string) (renditions.Rendition, error) {
+	width, rest, ok := strings.Cut(spec, "x")
+	if !ok {
+		return renditions.Rendition{}, fmt.Errorf("%w %q: want <width>x<spec>", renditions.ErrInvalidRendition, spec)
+	}
+	r, err := renditions.ParseRendition(rest)
+	if err != nil {
+		return renditions.Rendition{}, err
+	}
+	if r.Width, err = strconv.Atoi(width); err != nil {
+		return renditions.Rendition{}, fmt.Errorf("%w %q: invalid width", renditions.ErrInvalidRendition, spec)
+	}
+	return r, nil
+}
+
+// fromClip reads a clip stored by the database service
+func fromClip(resp *streamdb.ClipResponse) (Clip, error) {
+	createdAt, err := time.Parse(storedTimeFormat, resp.CreatedAt)
+	if err != nil {
+		return Clip{}, fmt.Errorf("clip %s has an invalid creation time: %w", resp.Id, err)
+	}
+
+	clip := Clip{
+		ID:              resp.Id,
+		StreamID:        resp.StreamId,
+		CreatorID:       resp.CreatorId,
+		Title:           resp.Title,
+		Source:          resp.Source,
+		FirstSegment:    int(resp.FirstSegment),
+		Segments:        int(resp.Segments),
+		SegmentDuration: time.Duration(resp.SegmentDurationMs) * time.Millisecond,
+		CreatedAt:       createdAt,
+	}
+	for _, spec := range resp.Renditions {
+		r, err := parseRenditionSpec(spec)
+		if err != nil {
+			return Clip{}, fmt.Errorf("clip %s: %w", resp.Id, err)
+		}
+		clip.Renditions = append(clip.Renditions, r)
+	}
+	return clip, nil
+}
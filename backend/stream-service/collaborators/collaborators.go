@@ -0,0 +1,183 @@
+// Package collaborators keeps the roles streamers grant other users on their
+// streams. A grant applies to a single stream or, when its stream id is 0, to
+// every stream of the owner's channel. Grants are kept by the database
+// service.
+package collaborators
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Role is what a collaborator may do on the streams they were granted
+type Role string
+
+const (
+	// RoleCoHost runs the broadcast: edits the stream, takes it live or
+	// ends it and sees its analytics
+	RoleCoHost Role = "co-host"
+	// RoleEditor edits the title, description and schedule of the stream
+	RoleEditor Role = "editor"
+	// RoleModerator moderates the chat and has no say on the stream itself
+	RoleModerator Role = "moderator"
+)
+
+// Permission is an action on a stream that owners may delegate
+type Permission int
+
+const (
+	// PermissionEdit allows changing the details and schedule of a stream
+	PermissionEdit Permission = iota
+	// PermissionChangeStatus allows taking a stream live and ending it
+	PermissionChangeStatus
+	// PermissionViewAnalytics allows reading the audience figures
+	PermissionViewAnalytics
+	// PermissionModerate allows moderating the chat of a stream
+	PermissionModerate
+	// PermissionView allows watching private streams
+	PermissionView
+)
+
+var permissions = map[Role][]Permission{
+	RoleCoHost:    {PermissionEdit, PermissionChangeStatus, PermissionViewAnalytics, PermissionModerate, PermissionView},
+	RoleEditor:    {PermissionEdit, PermissionView},
+	RoleModerator: {PermissionModerate, PermissionView},
+}
+
+// Valid reports whether r is a known role
+func (r Role) Valid() bool {
+	_, ok := permissions[r]
+	return ok
+}
+
+// Can reports whether the role grants permission
+func (r Role) Can(permission Permission) bool {
+	return slices.Contains(permissions[r], permission)
+}
+
+var (
+	// ErrInvalidRole is returned for roles other than the known ones
+	ErrInvalidRole = errors.New("role must be one of co-host, editor or moderator")
+	// ErrOwner is returned when owners try to make themselves collaborators
+	ErrOwner = errors.New("the owner cannot be a collaborator")
+	// ErrNotFound is returned when removing a grant that does not exist
+	ErrNotFound = errors.New("collaborator not found")
+)
+
+// Collaborator is a role granted by an owner to a user
+type Collaborator struct {
+	OwnerID int32
+	// StreamID is 0 for grants on the whole channel
+	StreamID  int32
+	UserID    int32
+	Role      Role
+	CreatedAt time.Time
+}
+
+// Store reads and writes the grants of every owner in the database service
+type Store struct {
+	client streamdb.CollaboratorServiceClient
+}
+
+// NewStore returns a store calling the database service through client
+func NewStore(client streamdb.CollaboratorServiceClient) *Store {
+	return &Store{client: client}
+}
+
+// Add grants role to a user, replacing the role they held on the same stream
+// or channel
+func (s *Store) Add(ctx context.Context, ownerID, streamID, userID int32, role Role) (Collaborator, error) {
+	if !role.Valid() {
+		return Collaborator{}, ErrInvalidRole
+	}
+	if userID == ownerID {
+		return Collaborator{}, ErrOwner
+	}
+
+	resp, err := s.client.SetCollaborator(ctx, &streamdb.SetCollaboratorRequest{
+		OwnerId:  ownerID,
+		StreamId: streamID,
+		UserId:   userID,
+		Role:     string(role),
+	})
+	if err != nil {
+		return Collaborator{}, err
+	}
+	return fromResponse(resp), nil
+}
+
+// Remove revokes the grant of a user on a stream or channel
+func (s *Store) Remove(ctx context.Context, ownerID, streamID, userID int32) error {
+	_, err := s.client.DeleteCollaborator(ctx, &streamdb.DeleteCollaboratorRequest{
+		OwnerId:  ownerID,
+		StreamId: streamID,
+		UserId:   userID,
+	})
+	if status.Code(err) == codes.NotFound {
+		return ErrNotFound
+	}
+	return err
+}
+
+// RemoveStream revokes every grant on a stream, which is done when it is
+// purged
+func (s *Store) RemoveStream(ctx context.Context, streamID int32) error {
+	if streamID == 0 {
+		return nil
+	}
+	_, err := s.client.DeleteStreamCollaborators(ctx, &streamdb.DeleteStreamCollaboratorsRequest{StreamId: streamID})
+	return err
+}
+
+// List returns the grants of an owner that apply to a stream, including the
+// channel grants, or only the channel grants when streamID is 0. Grants are
+// sorted by stream and user.
+func (s *Store) List(ctx context.Context, ownerID, streamID int32) ([]Collaborator, error) {
+	return s.list(ctx, &streamdb.ListCollaboratorsRequest{OwnerId: ownerID, StreamId: streamID})
+}
+
+// Can reports whether userID was granted permission on a stream of ownerID,
+// either on the stream itself or on the channel
+func (s *Store) Can(ctx context.Context, ownerID, streamID, userID int32, permission Permission) (bool, error) {
+	grants, err := s.list(ctx, &streamdb.ListCollaboratorsRequest{OwnerId: ownerID, StreamId: streamID, UserId: userID})
+	if err != nil {
+		return false, err
+	}
+	for _, collaborator := range grants {
+		if collaborator.Role.Can(permission) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *Store) list(ctx context.Context, req *streamdb.ListCollaboratorsRequest) ([]Collaborator, error) {
+	resp, err := s.client.ListCollaborators(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Collaborator, 0, len(resp.Collaborators))
+	for _, collaborator := range resp.Collaborators {
+		list = append(list, fromResponse(collaborator))
+	}
+	return list, nil
+}
+
+// fromResponse converts a grant of the database service, which returns
+// creation times in the round-trip format of .NET
+func fromResponse(resp *streamdb.CollaboratorResponse) Collaborator {
+	createdAt, _ := time.Parse(time.RFC3339Nano, resp.CreatedAt)
+	return Collaborator{
+		OwnerID:   resp.OwnerId,
+		StreamID:  resp.StreamId,
+		UserID:    resp.UserId,
+		Role:      Role(resp.Role),
+		CreatedAt: createdAt.UTC(),
+	}
+}
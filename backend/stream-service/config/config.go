@@ -0,0 +1,247 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	platformconfig "github.com/clementus360/platform/config"
+)
+
+// Config is the complete stream-service configuration
+type Config struct {
+	Server     ServerConfig                 `yaml:"server"`
+	Database   DatabaseConfig               `yaml:"database"`
+	Log        platformconfig.LogConfig     `yaml:"log"`
+	Tracing    platformconfig.TracingConfig `yaml:"tracing"`
+	TLS        platformconfig.TLSConfig     `yaml:"tls"`
+	Auth       platformconfig.AuthConfig    `yaml:"auth"`
+	Client     platformconfig.ClientConfig  `yaml:"client"`
+	Analytics  AnalyticsConfig              `yaml:"analytics"`
+	Playback   PlaybackConfig               `yaml:"playback"`
+	Telemetry  TelemetryConfig              `yaml:"telemetry"`
+	Renditions RenditionsConfig             `yaml:"renditions"`
+	Clips      ClipsConfig                  `yaml:"clips"`
+	Restream   RestreamConfig               `yaml:"restream"`
+	Purge      PurgeConfig                  `yaml:"purge"`
+
+	// PrintConfig dumps the effective configuration and exits
+	PrintConfig bool `yaml:"-" flag:"print-config"`
+}
+
+type ServerConfig struct {
+	Port        string        `yaml:"port" env:"PORT" flag:"port" default:"8080" required:"true"`
+	GRPCPort    string        `yaml:"grpc_port" env:"gRPC_PORT" flag:"grpc-port" default:"8082" required:"true"`
+	DrainPeriod time.Duration `yaml:"drain_period" env:"SHUTDOWN_DRAIN_PERIOD" flag:"drain-period" default:"5s"`
+}
+
+type DatabaseConfig struct {
+	Address string `yaml:"address" env:"DB_SERVICE_ADDRESS" flag:"db-address" default:"localhost:8080" required:"true"`
+}
+
+// AnalyticsConfig controls the rollups of viewer sessions
+type AnalyticsConfig struct {
+	RollupInterval   time.Duration `yaml:"rollup_interval" env:"ANALYTICS_ROLLUP_INTERVAL" default:"1m"`
+	SessionRetention time.Duration `yaml:"session_retention" env:"ANALYTICS_SESSION_RETENTION" default:"24h"`
+	// MaxSessionsPerViewer bounds the sessions a viewer keeps open on one
+	// stream
+	MaxSessionsPerViewer int `yaml:"max_sessions_per_viewer" env:"ANALYTICS_MAX_SESSIONS_PER_VIEWER" default:"5"`
+}
+
+// PlaybackConfig controls the tokens viewers present to play streams. The
+// signing key is shared with the distribution server.
+type PlaybackConfig struct {
+	SigningKey string        `yaml:"signing_key" env:"PLAYBACK_SIGNING_KEY" secret:"true"`
+	TokenTTL   time.Duration `yaml:"token_ttl" env:"PLAYBACK_TOKEN_TTL" default:"1h"`
+	// HLSBaseURL is where the media playlists of the renditions are served
+	HLSBaseURL string `yaml:"hls_base_url" env:"HLS_BASE_URL" default:"/hls"`
+}
+
+// TelemetryConfig controls how long encoder telemetry is kept and when a
+// live stream is reported as degraded
+type TelemetryConfig struct {
+	Retention           time.Duration `yaml:"retention" env:"TELEMETRY_RETENTION" default:"5m"`
+	Window              time.Duration `yaml:"window" env:"TELEMETRY_WINDOW" default:"30s"`
+	StaleAfter          time.Duration `yaml:"stale_after" env:"TELEMETRY_STALE_AFTER" default:"15s"`
+	BitrateTolerance    float64       `yaml:"bitrate_tolerance" env:"TELEMETRY_BITRATE_TOLERANCE" default:"0.8"`
+	FramerateTolerance  float64       `yaml:"framerate_tolerance" env:"TELEMETRY_FRAMERATE_TOLERANCE" default:"0.9"`
+	MaxDroppedFrames    float64       `yaml:"max_dropped_frames" env:"TELEMETRY_MAX_DROPPED_FRAMES" default:"0.02"`
+	MaxKeyframeInterval time.Duration `yaml:"max_keyframe_interval" env:"TELEMETRY_MAX_KEYFRAME_INTERVAL" default:"4s"`
+	SilenceLevel        float64       `yaml:"silence_level" env:"TELEMETRY_SILENCE_LEVEL" default:"-60"`
+}
+
+// RenditionsConfig defines the adaptive bitrate ladders streams can use
+type RenditionsConfig struct {
+	// Presets are entries such as "sports=1080p60/720p60/480p30", added to
+	// or replacing the built-in presets
+	Presets       []string `yaml:"presets" env:"RENDITION_PRESETS"`
+	DefaultPreset string   `yaml:"default_preset" env:"RENDITION_DEFAULT_PRESET" default:"standard"`
+}
+
+// ClipsConfig controls how clips are cut from broadcasts
+type ClipsConfig struct {
+	// SegmentDuration must match the segment duration of the packager
+	SegmentDuration time.Duration `yaml:"segment_duration" env:"CLIPS_SEGMENT_DURATION" default:"2s"`
+	MinDuration     time.Duration `yaml:"min_duration" env:"CLIPS_MIN_DURATION" default:"5s"`
+	MaxDuration     time.Duration `yaml:"max_duration" env:"CLIPS_MAX_DURATION" default:"60s"`
+	// DVRWindow is how far back the packager keeps the segments of live
+	// streams
+	DVRWindow time.Duration `yaml:"dvr_window" env:"CLIPS_DVR_WINDOW" default:"2h"`
+}
+
+// RestreamConfig controls the relays of live streams to other platforms.
+// Restreaming is disabled without an encryption key.
+type RestreamConfig struct {
+	// EncryptionKey encrypts the stream keys of destinations
+	EncryptionKey string `yaml:"encryption_key" env:"RESTREAM_ENCRYPTION_KEY" secret:"true"`
+	// SourceURL is where the ingested feed of a stream is read, with
+	// {stream_key} and {stream_id} replaced
+	SourceURL       string        `yaml:"source_url" env:"RESTREAM_SOURCE_URL" default:"rtmp://localhost:1935/live/{stream_key}"`
+	FFmpegPath      string        `yaml:"ffmpeg_path" env:"RESTREAM_FFMPEG_PATH" default:"ffmpeg"`
+	RetryBackoff    time.Duration `yaml:"retry_backoff" env:"RESTREAM_RETRY_BACKOFF" default:"2s"`
+	MaxRetryBackoff time.Duration `yaml:"max_retry_backoff" env:"RESTREAM_MAX_RETRY_BACKOFF" default:"1m"`
+	// AllowPrivateHosts accepts destinations in private networks, which
+	// are refused so that relays cannot reach internal services
+	AllowPrivateHosts bool `yaml:"allow_private_hosts" env:"RESTREAM_ALLOW_PRIVATE_HOSTS" default:"false"`
+}
+
+// PurgeConfig controls how long deleted streams can be restored before they
+// and their media are removed for good
+type PurgeConfig struct {
+	// Retention of deleted streams; 0 disables the scheduled purge
+	Retention time.Duration `yaml:"retention" env:"PURGE_RETENTION" default:"720h"`
+	Interval  time.Duration `yaml:"interval" env:"PURGE_INTERVAL" default:"1h"`
+	// MediaPaths are the recordings and thumbnails of a stream, each with
+	// {stream_id} replaced before it is removed
+	MediaPaths []string `yaml:"media_paths" env:"PURGE_MEDIA_PATHS"`
+}
+
+// PresetMap parses RENDITION_PRESETS entries into ladders by preset name
+func (c RenditionsConfig) PresetMap() (map[string]string, error) {
+	presets := make(map[string]string, len(c.Presets))
+	for _, entry := range c.Presets {
+		name, ladder, ok := strings.Cut(entry, "=")
+		if !ok || name == "" || ladder == "" {
+			return nil, fmt.Errorf("RENDITION_PRESETS entries must look like name=1080p60/720p30/480p, got %q", entry)
+		}
+		presets[name] = ladder
+	}
+	return presets, nil
+}
+
+// LoadConfig reads the configuration from defaults, an optional YAML file,
+// the environment and the command-line arguments
+func LoadConfig(args []string) (*Config, error) {
+	cfg := &Config{}
+	if err := platformconfig.Load("stream-service", cfg, args); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// Validate checks values that cannot be expressed with struct tags
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := validatePort("PORT", c.Server.Port); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validatePort("gRPC_PORT", c.Server.GRPCPort); err != nil {
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, c.Tracing.Validate()...)
+	errs = append(errs, c.TLS.Validate()...)
+	errs = append(errs, c.Auth.Validate()...)
+	errs = append(errs, c.Client.Validate()...)
+
+	if c.Analytics.RollupInterval < time.Second {
+		errs = append(errs, fmt.Errorf("ANALYTICS_ROLLUP_INTERVAL must be at least 1s, got %s", c.Analytics.RollupInterval))
+	}
+	if c.Analytics.SessionRetention < 0 {
+		errs = append(errs, fmt.Errorf("ANALYTICS_SESSION_RETENTION must not be negative, got %s", c.Analytics.SessionRetention))
+	}
+	if c.Analytics.MaxSessionsPerViewer < 1 {
+		errs = append(errs, fmt.Errorf("ANALYTICS_MAX_SESSIONS_PER_VIEWER must be at least 1, got %d", c.Analytics.MaxSessionsPerViewer))
+	}
+
+	if c.Playback.SigningKey != "" && len(c.Playback.SigningKey) < 32 {
+		errs = append(errs, errors.New("PLAYBACK_SIGNING_KEY must be at least 32 characters long"))
+	}
+	if c.Playback.TokenTTL < time.Minute {
+		errs = append(errs, fmt.Errorf("PLAYBACK_TOKEN_TTL must be at least 1m, got %s", c.Playback.TokenTTL))
+	}
+
+	errs = append(errs, validateTelemetry(c.Telemetry)...)
+
+	if _, err := c.Renditions.PresetMap(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.Clips.SegmentDuration < 100*time.Millisecond {
+		errs = append(errs, fmt.Errorf("CLIPS_SEGMENT_DURATION must be at least 100ms, got %s", c.Clips.SegmentDuration))
+	}
+	if c.Clips.MinDuration <= 0 || c.Clips.MaxDuration < c.Clips.MinDuration {
+		errs = append(errs, errors.New("CLIPS_MIN_DURATION must be positive and not above CLIPS_MAX_DURATION"))
+	}
+	if c.Clips.DVRWindow < c.Clips.MaxDuration {
+		errs = append(errs, fmt.Errorf("CLIPS_DVR_WINDOW must be at least CLIPS_MAX_DURATION, got %s", c.Clips.DVRWindow))
+	}
+
+	if c.Restream.EncryptionKey != "" && len(c.Restream.EncryptionKey) < 32 {
+		errs = append(errs, errors.New("RESTREAM_ENCRYPTION_KEY must be at least 32 characters long"))
+	}
+	if c.Restream.RetryBackoff <= 0 || c.Restream.MaxRetryBackoff < c.Restream.RetryBackoff {
+		errs = append(errs, errors.New("RESTREAM_RETRY_BACKOFF must be positive and not above RESTREAM_MAX_RETRY_BACKOFF"))
+	}
+
+	if c.Purge.Retention < 0 {
+		errs = append(errs, fmt.Errorf("PURGE_RETENTION must not be negative, got %s", c.Purge.Retention))
+	}
+	if c.Purge.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("PURGE_INTERVAL must be positive, got %s", c.Purge.Interval))
+	}
+	for _, path := range c.Purge.MediaPaths {
+		// Without the placeholder every stream would share, and lose, the path
+		if !strings.Contains(path, "{stream_id}") {
+			errs = append(errs, fmt.Errorf("PURGE_MEDIA_PATHS entries must contain {stream_id}, got %q", path))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateTelemetry(t TelemetryConfig) []error {
+	var errs []error
+	if t.Window <= 0 || t.Retention < t.Window {
+		errs = append(errs, errors.New("TELEMETRY_WINDOW must be positive and not above TELEMETRY_RETENTION"))
+	}
+	if t.StaleAfter < time.Second {
+		errs = append(errs, fmt.Errorf("TELEMETRY_STALE_AFTER must be at least 1s, got %s", t.StaleAfter))
+	}
+	if t.BitrateTolerance < 0 || t.BitrateTolerance > 1 {
+		errs = append(errs, fmt.Errorf("TELEMETRY_BITRATE_TOLERANCE must be between 0 and 1, got %v", t.BitrateTolerance))
+	}
+	if t.FramerateTolerance < 0 || t.FramerateTolerance > 1 {
+		errs = append(errs, fmt.Errorf("TELEMETRY_FRAMERATE_TOLERANCE must be between 0 and 1, got %v", t.FramerateTolerance))
+	}
+	if t.MaxDroppedFrames < 0 || t.MaxDroppedFrames > 1 {
+		errs = append(errs, fmt.Errorf("TELEMETRY_MAX_DROPPED_FRAMES must be between 0 and 1, got %v", t.MaxDroppedFrames))
+	}
+	if t.MaxKeyframeInterval <= 0 {
+		errs = append(errs, fmt.Errorf("TELEMETRY_MAX_KEYFRAME_INTERVAL must be positive, got %s", t.MaxKeyframeInterval))
+	}
+	if t.SilenceLevel > 0 {
+		errs = append(errs, fmt.Errorf("TELEMETRY_SILENCE_LEVEL is in dBFS and must not be positive, got %v", t.SilenceLevel))
+	}
+	return errs
+}
+
+func validatePort(name, port string) error {
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("%s must be a port number between 1 and 65535, got %q", name, port)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Package export writes listings of streams as CSV or JSON Lines files. It
+// is shared by the REST API of stream-service and the API gateway, which
+// list the streams through the gRPC API.
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clementus360/stream-service/proto"
+)
+
+// The formats streams are exported in
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// PageSize is the page size asked of ListStreams, as the database service
+// returns at most 10 streams a page
+const PageSize = 10
+
+// ErrorTrailer is the trailer set when an export fails after the status was
+// sent. Clients must check it to tell a complete file from a truncated one.
+const ErrorTrailer = "X-Export-Error"
+
+// Lister fetches a page of the streams matching req
+type Lister func(ctx context.Context, req *proto.ListStreamsRequest) (*proto.ListStreamsResponse, error)
+
+// ValidFormat reports whether streams can be exported in format
+func ValidFormat(format string) bool {
+	return format == FormatCSV || format == FormatJSONL
+}
+
+// columns are the fields of exported streams. Stream keys are never
+// exported.
+var columns = []string{
+	"id", "title", "description", "user_id", "status", "visibility",
+	"start_time", "end_time", "view_count", "resolution", "bitrate",
+	"framerate", "codec", "protocol", "rendition_preset",
+}
+
+// row is an exported stream in JSON Lines
+type row struct {
+	ID              int32  `json:"id"`
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+	UserID          int32  `json:"user_id"`
+	Status          string `json:"status"`
+	Visibility      string `json:"visibility"`
+	StartTime       string `json:"start_time"`
+	EndTime         string `json:"end_time"`
+	ViewCount       int32  `json:"view_count"`
+	Resolution      string `json:"resolution"`
+	Bitrate         string `json:"bitrate"`
+	Framerate       string `json:"framerate"`
+	Codec           string `json:"codec"`
+	Protocol        string `json:"protocol"`
+	RenditionPreset string `json:"rendition_preset"`
+}
+
+func newRow(stream *proto.StreamResponse) row {
+	return row{
+		ID:              stream.Id,
+		Title:           stream.Title,
+		Description:     stream.Description,
+		UserID:          stream.UserId,
+		Status:          stream.Status,
+		Visibility:      stream.Visibility,
+		StartTime:       stream.StartTime,
+		EndTime:         stream.EndTime,
+		ViewCount:       stream.ViewCount,
+		Resolution:      stream.Resolution,
+		Bitrate:         stream.Bitrate,
+		Framerate:       stream.Framerate,
+		Codec:           stream.Codec,
+		Protocol:        stream.Protocol,
+		RenditionPreset: stream.RenditionPreset,
+	}
+}
+
+// record returns the CSV fields of the row in the order of columns
+func (row row) record() []string {
+	return []string{
+		strconv.Itoa(int(row.ID)), spreadsheetSafe(row.Title), spreadsheetSafe(row.Description),
+		strconv.Itoa(int(row.UserID)), row.Status, row.Visibility,
+		row.StartTime, row.EndTime, strconv.Itoa(int(row.ViewCount)), row.Resolution, row.Bitrate,
+		row.Framerate, row.Codec, row.Protocol, row.RenditionPreset,
+	}
+}
+
+// spreadsheetSafe keeps spreadsheets from evaluating text typed by users as
+// formulas
+func spreadsheetSafe(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// writer writes exported streams in one format
+type writer interface {
+	Write(row row) error
+	// Flush sends what was written so far to the client
+	Flush() error
+}
+
+type csvExport struct {
+	w *csv.Writer
+}
+
+func (e *csvExport) Write(row row) error {
+	return e.w.Write(row.record())
+}
+
+func (e *csvExport) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonlExport struct {
+	enc *json.Encoder
+}
+
+func (e *jsonlExport) Write(row row) error {
+	return e.enc.Encode(row)
+}
+
+func (e *jsonlExport) Flush() error {
+	return nil
+}
+
+// Export is an export whose first page has been fetched
+type Export struct {
+	list   Lister
+	req    *proto.ListStreamsRequest
+	page   *proto.ListStreamsResponse
+	format string
+}
+
+// Start fetches the first page of the streams matching req, which is done
+// before anything is written so that its errors still get a status code.
+// format must be valid. Streams are exported by ascending id, paging after
+// the last id exported, so that streams created or deleted meanwhile do
+// not shift the pages; the sorting of req is ignored.
+func Start(ctx context.Context, list Lister, format string, req *proto.ListStreamsRequest) (*Export, error) {
+	if req.Filter == nil {
+		req.Filter = &proto.StreamFilter{}
+	}
+	req.PageSize = PageSize
+	req.PageNumber = 1
+	req.SortBy = "id"
+	req.Ascending = true
+	req.Filter.IdAfter = 0
+	page, err := list(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &Export{list: list, req: req, page: page, format: format}, nil
+}
+
+// Serve answers with the exported streams as an attachment. Pages are
+// fetched one at a time and written as they arrive, so exports of any size
+// use little memory. It returns how many rows were written. Once the status
+// is sent, errors cut the file short and set ErrorTrailer.
+func (e *Export) Serve(ctx context.Context, w http.ResponseWriter) (int, error) {
+	filename := fmt.Sprintf("streams-%s.%s", time.Now().UTC().Format("20060102-150405"), e.format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Trailer", ErrorTrailer)
+	var export writer
+	if e.format == FormatCSV {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		csvWriter := csv.NewWriter(w)
+		csvWriter.Write(columns)
+		export = &csvExport{w: csvWriter}
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		export = &jsonlExport{enc: json.NewEncoder(w)}
+	}
+	w.WriteHeader(http.StatusOK)
+
+	rows, err := e.pages(ctx, export, http.NewResponseController(w))
+	if err != nil {
+		w.Header().Set(ErrorTrailer, fmt.Sprintf("export interrupted after %d rows", rows))
+	}
+	return rows, err
+}
+
+// pages writes the rows of the first page and of the pages after it,
+// flushing after each one, and returns how many rows were written
+func (e *Export) pages(ctx context.Context, export writer, rc *http.ResponseController) (int, error) {
+	rows, page := 0, e.page
+	for {
+		for _, stream := range page.Streams {
+			if err := export.Write(newRow(stream)); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+		if err := export.Flush(); err != nil {
+			return rows, err
+		}
+		if err := rc.Flush(); err != nil && err != http.ErrNotSupported {
+			return rows, err
+		}
+
+		// The total counts the streams after the cursor, this page included
+		meta := page.MetaData
+		if len(page.Streams) == 0 || meta == nil || int(meta.TotalItems) <= len(page.Streams) {
+			return rows, nil
+		}
+		e.req.Filter.IdAfter = page.Streams[len(page.Streams)-1].Id
+
+		var err error
+		if page, err = e.list(ctx, e.req); err != nil {
+			return rows, err
+		}
+	}
+}
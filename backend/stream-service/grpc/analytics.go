@@ -0,0 +1,152 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/analytics"
+	"github.com/clementus360/stream-service/collaborators"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Implement the StartViewerSession method for gRPC
+func (s *StreamServiceServer) StartViewerSession(ctx context.Context, req *proto.StartViewerSessionRequest) (*proto.ViewerSession, error) {
+	logger := logging.FromContext(ctx)
+
+	// Signed-in viewers are identified by their user id, anonymous ones by
+	// the id the player generated
+	if user, ok := auth.UserFromContext(ctx); ok {
+		userID := strconv.FormatInt(user.ID, 10)
+		if req.ViewerId != "" && req.ViewerId != userID {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot start a session for another viewer")
+		}
+		req.ViewerId = userID
+	}
+
+	stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: req.StreamId})
+	if err != nil {
+		logger.Error("Failed to get stream info via gRPC", "error", err)
+		return nil, err
+	}
+
+	// Sessions are opened by those allowed to play the stream
+	if !s.canView(ctx, stream) {
+		return nil, status.Errorf(codes.NotFound, "Stream not found")
+	}
+
+	session, err := s.Analytics.Join(ctx, stream, req.ViewerId, req.ClientType)
+	if err != nil {
+		logger.Error("Failed to start viewer session", "stream_id", req.StreamId, "error", err)
+		return nil, analyticsError(err)
+	}
+
+	return viewerSessionResponse(session), nil
+}
+
+// Implement the EndViewerSession method for gRPC
+func (s *StreamServiceServer) EndViewerSession(ctx context.Context, req *proto.EndViewerSessionRequest) (*proto.ViewerSession, error) {
+	logger := logging.FromContext(ctx)
+
+	session, err := s.Analytics.Session(ctx, req.SessionId)
+	if err != nil {
+		return nil, analyticsError(err)
+	}
+	if user, ok := auth.UserFromContext(ctx); ok && session.ViewerID != strconv.FormatInt(user.ID, 10) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot end the session of another viewer")
+	}
+
+	session, err = s.Analytics.Leave(ctx, req.SessionId)
+	if err != nil {
+		logger.Error("Failed to end viewer session", "session_id", req.SessionId, "error", err)
+		return nil, analyticsError(err)
+	}
+
+	return viewerSessionResponse(session), nil
+}
+
+// Implement the GetStreamAnalytics method for gRPC
+func (s *StreamServiceServer) GetStreamAnalytics(ctx context.Context, req *proto.GetStreamAnalyticsRequest) (*proto.StreamAnalytics, error) {
+	logger := logging.FromContext(ctx)
+
+	stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: req.StreamId})
+	if err != nil {
+		logger.Error("Failed to get stream info via gRPC", "error", err)
+		return nil, err
+	}
+
+	// Audience figures are only shown to the streamer and their co-hosts
+	if !s.authorizeStream(ctx, stream, collaborators.PermissionViewAnalytics) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot view the analytics of another user's stream")
+	}
+
+	summary, err := s.Analytics.Summary(ctx, req.StreamId)
+	if err != nil {
+		logger.Error("Failed to get stream analytics", "stream_id", req.StreamId, "error", err)
+		return nil, err
+	}
+
+	return streamAnalyticsResponse(summary), nil
+}
+
+// analyticsError maps the errors of the analytics service to gRPC statuses.
+// Errors of the database service already carry one.
+func analyticsError(err error) error {
+	switch {
+	case errors.Is(err, analytics.ErrSessionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, analytics.ErrStreamNotLive):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, analytics.ErrViewerRequired):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, analytics.ErrTooManySessions):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return err
+}
+
+func viewerSessionResponse(session analytics.Session) *proto.ViewerSession {
+	resp := &proto.ViewerSession{
+		Id:         session.ID,
+		StreamId:   session.StreamID,
+		ViewerId:   session.ViewerID,
+		ClientType: session.ClientType,
+		JoinedAt:   session.JoinedAt.Format(models.TimeFormat),
+	}
+	if session.LeftAt != nil {
+		resp.LeftAt = session.LeftAt.Format(models.TimeFormat)
+	}
+	return resp
+}
+
+func streamAnalyticsResponse(summary analytics.Summary) *proto.StreamAnalytics {
+	resp := &proto.StreamAnalytics{
+		StreamId:                summary.StreamID,
+		UniqueViewers:           int32(summary.UniqueViewers),
+		PeakConcurrentViewers:   int32(summary.PeakConcurrentViewers),
+		AverageWatchTimeSeconds: int64(summary.AverageWatchTime.Seconds()),
+		TotalWatchTimeSeconds:   int64(summary.TotalWatchTime.Seconds()),
+		TotalSessions:           int32(summary.Sessions),
+		ViewersByClient:         make(map[string]int32, len(summary.ViewersByClient)),
+		Final:                   summary.Final,
+		ComputedAt:              summary.ComputedAt.Format(models.TimeFormat),
+	}
+	if !summary.PeakAt.IsZero() {
+		resp.PeakAt = summary.PeakAt.Format(models.TimeFormat)
+	}
+	for client, viewers := range summary.ViewersByClient {
+		resp.ViewersByClient[client] = int32(viewers)
+	}
+	for _, point := range summary.Concurrency {
+		resp.Concurrency = append(resp.Concurrency, &proto.ConcurrencyPoint{
+			Minute:  point.Minute.Format(models.TimeFormat),
+			Viewers: int32(point.Viewers),
+		})
+	}
+	return resp
+}
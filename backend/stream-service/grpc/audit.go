@@ -0,0 +1,203 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/clementus360/platform/audit"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// Target types of the audit entries of stream-service
+const (
+	auditTargetStream       = "stream"
+	auditTargetCollaborator = "collaborator"
+	auditTargetClip         = "clip"
+	auditTargetDestination  = "restream_destination"
+)
+
+// AuditedMethods describes how the mutations of the stream service are
+// audited. Viewer sessions, telemetry and playback tokens are traffic rather
+// than changes and are left out.
+func (s *StreamServiceServer) AuditedMethods() map[string]audit.Method {
+	streamBefore := func(id func(req any) int32) func(context.Context, any) protobuf.Message {
+		return func(ctx context.Context, req any) protobuf.Message {
+			stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: id(req)})
+			if err != nil {
+				return nil
+			}
+			if err := s.withRenditions(ctx, stream); err != nil {
+				return nil
+			}
+			return stream
+		}
+	}
+	streamAfter := func(resp any) protobuf.Message { return resp.(*proto.StreamResponse) }
+	streamID := func(req, resp any) string { return strconv.Itoa(int(resp.(*proto.StreamResponse).Id)) }
+
+	return map[string]audit.Method{
+		proto.StreamService_CreateStream_FullMethodName: {
+			Action: audit.ActionCreate, TargetType: auditTargetStream,
+			Target: streamID, After: streamAfter,
+		},
+		proto.StreamService_UpdateStream_FullMethodName: {
+			Action: audit.ActionUpdate, TargetType: auditTargetStream,
+			Target: streamID, After: streamAfter,
+			Before: streamBefore(func(req any) int32 { return req.(*proto.UpdateStreamRequest).Id }),
+		},
+		proto.StreamService_DeleteStream_FullMethodName: {
+			Action: audit.ActionDelete, TargetType: auditTargetStream,
+			Target: func(req, resp any) string { return strconv.Itoa(int(req.(*proto.DeleteStreamRequest).Id)) },
+			Before: streamBefore(func(req any) int32 { return req.(*proto.DeleteStreamRequest).Id }),
+		},
+		proto.StreamService_RestoreStream_FullMethodName: {
+			Action: audit.ActionRestore, TargetType: auditTargetStream,
+			Target: streamID, After: streamAfter,
+		},
+		proto.StreamService_PurgeStream_FullMethodName: {
+			Action: audit.ActionPurge, TargetType: auditTargetStream,
+			Target: func(req, resp any) string { return strconv.Itoa(int(req.(*proto.PurgeStreamRequest).Id)) },
+		},
+		proto.StreamService_SetStreamRenditions_FullMethodName: {
+			Action: audit.ActionUpdate, TargetType: auditTargetStream,
+			Target: streamID, After: streamAfter,
+			Before: streamBefore(func(req any) int32 { return req.(*proto.SetStreamRenditionsRequest).StreamId }),
+		},
+		proto.StreamService_AddCollaborator_FullMethodName: {
+			Action: audit.ActionCreate, TargetType: auditTargetCollaborator,
+			Target: func(req, resp any) string {
+				collaborator := resp.(*proto.Collaborator)
+				return collaboratorTarget(collaborator.OwnerId, collaborator.StreamId, collaborator.UserId)
+			},
+			After: func(resp any) protobuf.Message { return resp.(*proto.Collaborator) },
+		},
+		proto.StreamService_RemoveCollaborator_FullMethodName: {
+			Action: audit.ActionDelete, TargetType: auditTargetCollaborator,
+			Target: func(req, resp any) string {
+				r := req.(*proto.RemoveCollaboratorRequest)
+				return collaboratorTarget(r.OwnerId, r.StreamId, r.UserId)
+			},
+		},
+		proto.StreamService_CreateClip_FullMethodName: {
+			Action: audit.ActionCreate, TargetType: auditTargetClip,
+			Target: func(req, resp any) string { return resp.(*proto.Clip).Id },
+			After:  func(resp any) protobuf.Message { return resp.(*proto.Clip) },
+		},
+		proto.StreamService_DeleteClip_FullMethodName: {
+			Action: audit.ActionDelete, TargetType: auditTargetClip,
+			Target: func(req, resp any) string { return req.(*proto.DeleteClipRequest).Id },
+			Before: func(ctx context.Context, req any) protobuf.Message {
+				clip, err := s.Clips.Get(ctx, req.(*proto.DeleteClipRequest).Id)
+				if err != nil {
+					return nil
+				}
+				return clipResponse(clip)
+			},
+		},
+		proto.StreamService_AddRestreamDestination_FullMethodName: {
+			Action: audit.ActionCreate, TargetType: auditTargetDestination,
+			Target: func(req, resp any) string { return strconv.Itoa(int(resp.(*proto.RestreamDestination).Id)) },
+			After:  destinationAudit,
+		},
+		proto.StreamService_UpdateRestreamDestination_FullMethodName: {
+			Action: audit.ActionUpdate, TargetType: auditTargetDestination,
+			Target: func(req, resp any) string { return strconv.Itoa(int(resp.(*proto.RestreamDestination).Id)) },
+			Before: func(ctx context.Context, req any) protobuf.Message {
+				destination, err := s.Restream.Get(ctx, req.(*proto.UpdateRestreamDestinationRequest).Id)
+				if err != nil {
+					return nil
+				}
+				return destinationAudit(restreamDestinationResponse(destination))
+			},
+			After: destinationAudit,
+		},
+		proto.StreamService_RemoveRestreamDestination_FullMethodName: {
+			Action: audit.ActionDelete, TargetType: auditTargetDestination,
+			Target: func(req, resp any) string {
+				return strconv.Itoa(int(req.(*proto.RemoveRestreamDestinationRequest).Id))
+			},
+			Before: func(ctx context.Context, req any) protobuf.Message {
+				destination, err := s.Restream.Get(ctx, req.(*proto.RemoveRestreamDestinationRequest).Id)
+				if err != nil {
+					return nil
+				}
+				return destinationAudit(restreamDestinationResponse(destination))
+			},
+		},
+	}
+}
+
+// collaboratorTarget identifies a grant on a stream or on a channel
+func collaboratorTarget(ownerID, streamID, userID int32) string {
+	if streamID == 0 {
+		return fmt.Sprintf("channel/%d/user/%d", ownerID, userID)
+	}
+	return fmt.Sprintf("stream/%d/user/%d", streamID, userID)
+}
+
+// destinationAudit leaves the connection status out of audited
+// destinations, as it changes on its own
+func destinationAudit(resp any) protobuf.Message {
+	destination := protobuf.Clone(resp.(*proto.RestreamDestination)).(*proto.RestreamDestination)
+	destination.Status = nil
+	return destination
+}
+
+// Implement the QueryAuditLog method for gRPC
+func (s *StreamServiceServer) QueryAuditLog(ctx context.Context, req *proto.QueryAuditLogRequest) (*proto.QueryAuditLogResponse, error) {
+	query := audit.Query{
+		ActorID:      req.ActorId,
+		ActorService: req.ActorService,
+		TargetType:   req.TargetType,
+		TargetID:     req.TargetId,
+		Page:         int(req.PageNumber),
+		PageSize:     int(req.PageSize),
+	}
+	var err error
+	if query.Since, err = audit.ParseTime("since", req.Since, models.TimeFormat); err != nil {
+		return nil, err
+	}
+	if query.Until, err = audit.ParseTime("until", req.Until, models.TimeFormat); err != nil {
+		return nil, err
+	}
+
+	page, err := audit.Serve(ctx, s.Audit, query)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.QueryAuditLogResponse{
+		MetaData: &proto.PaginationMetadata{
+			TotalItems:  int32(page.Total),
+			TotalPages:  int32(page.Pages()),
+			CurrentPage: int32(page.Number),
+			PageSize:    int32(page.Size),
+		},
+	}
+	for _, entry := range page.Entries {
+		resp.Entries = append(resp.Entries, auditEntryResponse(entry))
+	}
+	return resp, nil
+}
+
+func auditEntryResponse(entry audit.Entry) *proto.AuditEntry {
+	resp := &proto.AuditEntry{
+		Id:           entry.ID,
+		Time:         entry.Time.Format(models.TimeFormat),
+		Service:      entry.Service,
+		Method:       entry.Method,
+		Action:       entry.Action,
+		ActorId:      entry.ActorID,
+		ActorRoles:   entry.ActorRoles,
+		ActorService: entry.ActorService,
+		TargetType:   entry.TargetType,
+		TargetId:     entry.TargetID,
+		RequestId:    entry.RequestID,
+	}
+	for _, change := range entry.Changes {
+		resp.Changes = append(resp.Changes, &proto.AuditChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+	return resp
+}
@@ -3,42 +3,42 @@ package grpcclient
 import (
 	"context"
 	"fmt"
-	"os"
+	"log/slog"
 
+	"github.com/clementus360/platform/dial"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/models"
 	"github.com/clementus360/stream-service/proto"
-	"github.com/sirupsen/logrus"
+	"github.com/clementus360/streamdb-api/streamdb"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Client struct {
 	Conn   *grpc.ClientConn
-	Client proto.StreamServiceClient
+	Client Database
 }
 
-func NewClient(ctx context.Context) (*Client, error) {
-	logger := logrus.New()
-
-	// Get the database service address from environment variable
-	dbAddress := os.Getenv("DB_SERVICE_ADDRESS")
-	if dbAddress == "" {
-		dbAddress = "localhost:8080" // Default address
-	}
+func NewClient(ctx context.Context, dbAddress string, dialer *dial.Dialer) (*Client, error) {
+	logger := logging.FromContext(ctx)
 
 	// Create a connection to the server
-	conn, err := grpc.NewClient(
-		dbAddress,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	conn, err := dialer.Dial(dial.Target{
+		Name:       "database_service",
+		Audience:   "database-service",
+		Address:    dbAddress,
+		Service:    streamdb.WireName(streamdb.StreamService_ServiceDesc.ServiceName),
+		Idempotent: []string{"GetStream", "ListStreams"},
+	})
 	if err != nil {
-		logger.WithError(err).Error("Failed to connect to database service")
+		logger.Error("Failed to connect to database service", "error", err)
 		return nil, fmt.Errorf("failed to connect to the database service: %v", err)
 	}
 
-	// Ensure graceful shutdown by deferring closing the connection
-	client := proto.NewStreamServiceClient(conn)
+	// The database service has its own messages for streams, which the
+	// client translates to and from those of stream-service
+	client := NewDatabase(streamdb.NewStreamServiceClient(streamdb.WireConn(conn)))
 
-	fmt.Println("Connected to database service at", dbAddress)
+	logger.Info("Connected to database service", "address", dbAddress)
 
 	return &Client{
 		Conn:   conn,
@@ -49,6 +49,19 @@ func NewClient(ctx context.Context) (*Client, error) {
 // Graceful shutdown to close the gRPC connection properly
 func (c *Client) Close() {
 	if err := c.Conn.Close(); err != nil {
-		logrus.WithError(err).Error("Failed to close gRPC connection")
+		slog.Error("Failed to close gRPC connection", "error", err)
+	}
+}
+
+// CountLiveStreams returns the number of streams currently online
+func (c *Client) CountLiveStreams(ctx context.Context) (int, error) {
+	resp, err := c.Client.ListStreams(ctx, &proto.ListStreamsRequest{
+		PageSize:   1,
+		PageNumber: 1,
+		Filter:     &proto.StreamFilter{Status: []string{models.StatusOnline}},
+	})
+	if err != nil {
+		return 0, err
 	}
+	return int(resp.GetMetaData().GetTotalItems()), nil
 }
@@ -0,0 +1,279 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/clips"
+	"github.com/clementus360/stream-service/collaborators"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/stream-service/renditions"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// clipPlaylistPath is where the REST API serves the playlists of clips
+const clipPlaylistPath = "/v1/api/clip"
+
+// Implement the CreateClip method for gRPC
+func (s *StreamServiceServer) CreateClip(ctx context.Context, req *proto.CreateClipRequest) (*proto.Clip, error) {
+	logger := logging.FromContext(ctx)
+
+	// Viewers clip for themselves, trusted services on behalf of anyone
+	if err := authenticated(ctx); err != nil {
+		return nil, err
+	}
+	creatorID := req.CreatorId
+	if user, ok := auth.UserFromContext(ctx); ok {
+		if creatorID == 0 {
+			creatorID = int32(user.ID)
+		} else if !user.CanAccess(int64(creatorID)) {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot create a clip for another user")
+		}
+	} else if creatorID == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "creator_id is required")
+	}
+
+	stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: req.StreamId})
+	if err != nil {
+		logger.Error("Failed to get stream info via gRPC", "error", err)
+		return nil, err
+	}
+	if !s.canView(ctx, stream) {
+		return nil, status.Errorf(codes.NotFound, "Stream not found")
+	}
+
+	ladder, err := s.Renditions.Ladder(ctx, stream.Id, renditions.SourceOf(stream))
+	if err != nil {
+		logger.Error("Failed to get stream renditions via gRPC", "stream_id", stream.Id, "error", err)
+		return nil, err
+	}
+	if len(ladder.Renditions) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "no rendition fits the source of this stream")
+	}
+
+	clip, err := s.Clips.Create(ctx, stream, creatorID, req.Title, seconds(req.StartOffsetSeconds), seconds(req.DurationSeconds), ladder.Renditions)
+	if err != nil {
+		return nil, clipsError(err)
+	}
+	logger.Info("Created clip", "clip_id", clip.ID, "stream_id", stream.Id, "creator_id", creatorID, "source", clip.Source)
+
+	return clipResponse(clip), nil
+}
+
+// Implement the GetClip method for gRPC
+func (s *StreamServiceServer) GetClip(ctx context.Context, req *proto.GetClipRequest) (*proto.Clip, error) {
+	clip, _, err := s.viewableClip(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return clipResponse(clip), nil
+}
+
+// Implement the ListClips method for gRPC
+func (s *StreamServiceServer) ListClips(ctx context.Context, req *proto.ListClipsRequest) (*proto.ListClipsResponse, error) {
+	// Clips of private streams are left out for viewers outside the team
+	return s.listClips(ctx, req, func(stream *proto.StreamResponse) bool {
+		return s.canView(ctx, stream)
+	})
+}
+
+// ListPublicClips lists clips like ListClips, leaving out the clips of
+// private streams whoever calls. It serves anonymous callers such as those of
+// the REST API.
+func (s *StreamServiceServer) ListPublicClips(ctx context.Context, req *proto.ListClipsRequest) (*proto.ListClipsResponse, error) {
+	return s.listClips(ctx, req, func(stream *proto.StreamResponse) bool {
+		return stream.Visibility != models.VisibilityPrivate
+	})
+}
+
+// listClips lists a page of the clips whose stream passes viewable. Clips of
+// streams deleted since are left out.
+func (s *StreamServiceServer) listClips(ctx context.Context, req *proto.ListClipsRequest, viewable func(*proto.StreamResponse) bool) (*proto.ListClipsResponse, error) {
+	logger := logging.FromContext(ctx)
+
+	if req.StreamId == 0 && req.CreatorId == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "stream_id or creator_id is required")
+	}
+	pageSize, pageNumber := req.PageSize, req.PageNumber
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 10
+	}
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+
+	stored, err := s.Clips.List(ctx, clips.Filter{StreamID: req.StreamId, CreatorID: req.CreatorId})
+	if err != nil {
+		logger.Error("Failed to list clips via gRPC", "error", err)
+		return nil, err
+	}
+
+	visible := make(map[int32]bool)
+	var list []clips.Clip
+	for _, clip := range stored {
+		ok, seen := visible[clip.StreamID]
+		if !seen {
+			stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: clip.StreamID})
+			if err != nil && status.Code(err) != codes.NotFound {
+				logger.Error("Failed to get stream info via gRPC", "error", err)
+				return nil, err
+			}
+			ok = err == nil && viewable(stream)
+			visible[clip.StreamID] = ok
+		}
+		if ok {
+			list = append(list, clip)
+		}
+	}
+
+	total := int32(len(list))
+	resp := &proto.ListClipsResponse{
+		MetaData: &proto.PaginationMetadata{
+			TotalItems:  total,
+			TotalPages:  (total + pageSize - 1) / pageSize,
+			CurrentPage: pageNumber,
+			PageSize:    pageSize,
+		},
+	}
+	start := min(int((pageNumber-1)*pageSize), len(list))
+	end := min(start+int(pageSize), len(list))
+	for _, clip := range list[start:end] {
+		resp.Clips = append(resp.Clips, clipResponse(clip))
+	}
+	return resp, nil
+}
+
+// Implement the DeleteClip method for gRPC
+func (s *StreamServiceServer) DeleteClip(ctx context.Context, req *proto.DeleteClipRequest) (*emptypb.Empty, error) {
+	logger := logging.FromContext(ctx)
+
+	if err := authenticated(ctx); err != nil {
+		return nil, err
+	}
+	clip, stream, err := s.viewableClip(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clips are taken down by their creator or by whoever moderates the
+	// stream, which trusted services calling without a user may do
+	user, ok := auth.UserFromContext(ctx)
+	if !(ok && user.CanAccess(int64(clip.CreatorID))) && !s.authorizeStream(ctx, stream, collaborators.PermissionModerate) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot delete another user's clip")
+	}
+
+	if err := s.Clips.Delete(ctx, clip.ID); err != nil {
+		return nil, clipsError(err)
+	}
+	logger.Info("Deleted clip", "clip_id", clip.ID, "stream_id", clip.StreamID)
+
+	return &emptypb.Empty{}, nil
+}
+
+// Implement the GetClipPlaylist method for gRPC
+func (s *StreamServiceServer) GetClipPlaylist(ctx context.Context, req *proto.GetClipPlaylistRequest) (*proto.ClipPlaylist, error) {
+	clip, err := s.Clips.Get(ctx, req.Id)
+	if err != nil {
+		if !errors.Is(err, clips.ErrClipNotFound) {
+			logging.FromContext(ctx).Error("Failed to get clip via gRPC", "clip_id", req.Id, "error", err)
+		}
+		return nil, clipsError(err)
+	}
+
+	// Clips are watched with a playback token of their stream, like the
+	// stream itself
+	if _, err := s.Playback.Verify(req.Token, clip.StreamID); err != nil {
+		return nil, playbackError(err)
+	}
+
+	query := func(values url.Values) string {
+		if req.Token != "" {
+			values.Set("token", req.Token)
+		}
+		return values.Encode()
+	}
+
+	var content string
+	if req.Rendition == "" {
+		content = renditions.MasterPlaylist(renditions.Ladder{Renditions: clip.Renditions}, func(r renditions.Rendition) string {
+			return clipPlaylistPath + "/playlist.m3u8?" + query(url.Values{"id": {clip.ID}, "rendition": {r.Name}})
+		})
+	} else {
+		if !clip.HasRendition(req.Rendition) {
+			return nil, status.Errorf(codes.NotFound, "clip has no rendition %s", req.Rendition)
+		}
+		suffix := ""
+		if q := query(url.Values{}); q != "" {
+			suffix = "?" + q
+		}
+		content = clips.MediaPlaylist(clip, func(sequence int) string {
+			return fmt.Sprintf("%s/%d/%s/%d.ts%s", strings.TrimSuffix(s.HLSBaseURL, "/"), clip.StreamID, req.Rendition, sequence, suffix)
+		})
+	}
+	return &proto.ClipPlaylist{Id: clip.ID, Content: content}, nil
+}
+
+// viewableClip returns a clip and its stream, as long as the end user of the
+// call may watch the stream
+func (s *StreamServiceServer) viewableClip(ctx context.Context, id string) (clips.Clip, *proto.StreamResponse, error) {
+	logger := logging.FromContext(ctx)
+
+	clip, err := s.Clips.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, clips.ErrClipNotFound) {
+			logger.Error("Failed to get clip via gRPC", "clip_id", id, "error", err)
+		}
+		return clips.Clip{}, nil, clipsError(err)
+	}
+	stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: clip.StreamID})
+	if err != nil {
+		logger.Error("Failed to get stream info via gRPC", "error", err)
+		return clips.Clip{}, nil, err
+	}
+	if !s.canView(ctx, stream) {
+		return clips.Clip{}, nil, status.Error(codes.NotFound, clips.ErrClipNotFound.Error())
+	}
+	return clip, stream, nil
+}
+
+// clipsError maps the errors of the clips store to gRPC statuses
+func clipsError(err error) error {
+	switch {
+	case errors.Is(err, clips.ErrClipNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, clips.ErrInvalidDuration), errors.Is(err, clips.ErrOutOfRange), errors.Is(err, clips.ErrInvalidTitle):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, clips.ErrNotRecorded):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+// seconds converts seconds sent over the API to a duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func clipResponse(clip clips.Clip) *proto.Clip {
+	return &proto.Clip{
+		Id:                 clip.ID,
+		StreamId:           clip.StreamID,
+		CreatorId:          clip.CreatorID,
+		Title:              clip.Title,
+		StartOffsetSeconds: clip.StartOffset().Seconds(),
+		DurationSeconds:    clip.Duration().Seconds(),
+		Source:             clip.Source,
+		Renditions:         renditionsResponse(clip.Renditions),
+		CreatedAt:          clip.CreatedAt.Format(models.TimeFormat),
+		PlaylistUrl:        clipPlaylistPath + "/master.m3u8?id=" + clip.ID,
+	}
+}
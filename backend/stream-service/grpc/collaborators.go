@@ -0,0 +1,181 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/collaborators"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Implement the AddCollaborator method for gRPC
+func (s *StreamServiceServer) AddCollaborator(ctx context.Context, req *proto.AddCollaboratorRequest) (*proto.Collaborator, error) {
+	logger := logging.FromContext(ctx)
+
+	if req.UserId <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "user_id is required")
+	}
+	ownerID, err := s.collaboratorsOwner(ctx, req.OwnerId, req.StreamId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only owners hand out roles on their streams
+	if err := authorizeOwner(ctx, ownerID, "cannot add collaborators to another user's streams"); err != nil {
+		return nil, err
+	}
+
+	collaborator, err := s.Collaborators.Add(ctx, ownerID, req.StreamId, req.UserId, collaborators.Role(req.Role))
+	if err != nil {
+		return nil, collaboratorsError(err)
+	}
+	logger.Info("Added collaborator", "owner_id", ownerID, "stream_id", req.StreamId, "user_id", req.UserId, "role", req.Role)
+
+	return collaboratorResponse(collaborator), nil
+}
+
+// Implement the RemoveCollaborator method for gRPC
+func (s *StreamServiceServer) RemoveCollaborator(ctx context.Context, req *proto.RemoveCollaboratorRequest) (*emptypb.Empty, error) {
+	logger := logging.FromContext(ctx)
+
+	ownerID, err := s.collaboratorsOwner(ctx, req.OwnerId, req.StreamId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collaborators may step down by themselves
+	if user, ok := auth.UserFromContext(ctx); !ok || user.ID != int64(req.UserId) {
+		if err := authorizeOwner(ctx, ownerID, "cannot remove collaborators from another user's streams"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.Collaborators.Remove(ctx, ownerID, req.StreamId, req.UserId); err != nil {
+		return nil, collaboratorsError(err)
+	}
+	logger.Info("Removed collaborator", "owner_id", ownerID, "stream_id", req.StreamId, "user_id", req.UserId)
+
+	return &emptypb.Empty{}, nil
+}
+
+// Implement the ListCollaborators method for gRPC
+func (s *StreamServiceServer) ListCollaborators(ctx context.Context, req *proto.ListCollaboratorsRequest) (*proto.ListCollaboratorsResponse, error) {
+	ownerID, err := s.collaboratorsOwner(ctx, req.OwnerId, req.StreamId)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.Collaborators.List(ctx, ownerID, req.StreamId)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list collaborators via gRPC", "error", err)
+		return nil, err
+	}
+
+	// The team of a stream is visible to the owner and its members
+	if err := authorizeOwner(ctx, ownerID, "cannot list the collaborators of another user's streams"); err != nil {
+		user, ok := auth.UserFromContext(ctx)
+		if !ok || !slices.ContainsFunc(list, func(c collaborators.Collaborator) bool { return int64(c.UserID) == user.ID }) {
+			return nil, err
+		}
+	}
+
+	resp := &proto.ListCollaboratorsResponse{}
+	for _, collaborator := range list {
+		resp.Collaborators = append(resp.Collaborators, collaboratorResponse(collaborator))
+	}
+	return resp, nil
+}
+
+// collaboratorsOwner returns the owner of the grants addressed by a request:
+// the owner of the stream, or ownerID for channel grants
+func (s *StreamServiceServer) collaboratorsOwner(ctx context.Context, ownerID, streamID int32) (int32, error) {
+	if streamID == 0 {
+		if ownerID <= 0 {
+			return 0, status.Errorf(codes.InvalidArgument, "stream_id or owner_id is required")
+		}
+		return ownerID, nil
+	}
+
+	stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: streamID})
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get stream info via gRPC", "error", err)
+		return 0, err
+	}
+	if ownerID != 0 && ownerID != stream.UserId {
+		return 0, status.Errorf(codes.InvalidArgument, "stream %d is not owned by user %d", streamID, ownerID)
+	}
+	return stream.UserId, nil
+}
+
+// authorizeOwner checks that the call is made by the owner of a resource or
+// an admin, or by a trusted service on nobody's behalf
+func authorizeOwner(ctx context.Context, ownerID int32, denied string) error {
+	switch err := auth.Authorize(ctx, int64(ownerID)); {
+	case errors.Is(err, auth.ErrNoUser):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case err != nil:
+		return status.Error(codes.PermissionDenied, denied)
+	}
+	return nil
+}
+
+// authenticated checks that the call is made on behalf of a user, or by a
+// trusted service on nobody's behalf
+func authenticated(ctx context.Context) error {
+	if _, ok := auth.UserFromContext(ctx); ok {
+		return nil
+	}
+	if err := auth.Authorize(ctx, 0); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return nil
+}
+
+// authorizeStream checks that the end user of the call may act on stream.
+// Owners and admins always may; collaborators when their role
+// grants permission. Calls without a user are only allowed from trusted
+// services, and grants that cannot be read deny access.
+func (s *StreamServiceServer) authorizeStream(ctx context.Context, stream *proto.StreamResponse, permission collaborators.Permission) bool {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return auth.Authorize(ctx, 0) == nil
+	}
+	if user.CanAccess(int64(stream.UserId)) {
+		return true
+	}
+	can, err := s.Collaborators.Can(ctx, stream.UserId, stream.Id, int32(user.ID), permission)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to read collaborators via gRPC", "error", err)
+		return false
+	}
+	return can
+}
+
+// collaboratorsError maps the errors of the collaborators store to gRPC
+// statuses
+func collaboratorsError(err error) error {
+	switch {
+	case errors.Is(err, collaborators.ErrInvalidRole), errors.Is(err, collaborators.ErrOwner):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, collaborators.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+func collaboratorResponse(collaborator collaborators.Collaborator) *proto.Collaborator {
+	return &proto.Collaborator{
+		OwnerId:   collaborator.OwnerID,
+		StreamId:  collaborator.StreamID,
+		UserId:    collaborator.UserID,
+		Role:      string(collaborator.Role),
+		CreatedAt: collaborator.CreatedAt.Format(models.TimeFormat),
+	}
+}
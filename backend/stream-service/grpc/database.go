@@ -0,0 +1,278 @@
+package grpcclient
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Database is the part of the StreamService API kept by the database
+// service. Its messages are those of stream-service, which has strings
+// where the database service has enums for the status and visibility.
+type Database interface {
+	CreateStream(ctx context.Context, req *proto.CreateStreamRequest) (*proto.StreamResponse, error)
+	GetStream(ctx context.Context, req *proto.GetStreamRequest) (*proto.StreamResponse, error)
+	UpdateStream(ctx context.Context, req *proto.UpdateStreamRequest) (*proto.StreamResponse, error)
+	DeleteStream(ctx context.Context, req *proto.DeleteStreamRequest) (*emptypb.Empty, error)
+	ListStreams(ctx context.Context, req *proto.ListStreamsRequest) (*proto.ListStreamsResponse, error)
+	ListDeletedStreams(ctx context.Context, req *proto.ListDeletedStreamsRequest) (*proto.ListStreamsResponse, error)
+	RestoreStream(ctx context.Context, req *proto.RestoreStreamRequest) (*proto.StreamResponse, error)
+	PurgeStream(ctx context.Context, req *proto.PurgeStreamRequest) (*emptypb.Empty, error)
+}
+
+// NewDatabase returns a Database calling the database service through client
+func NewDatabase(client streamdb.StreamServiceClient) Database {
+	return &database{client: client}
+}
+
+// database translates the messages of stream-service to those of the
+// database service and back
+type database struct {
+	client streamdb.StreamServiceClient
+}
+
+// CreateStream schedules streams created without a status, where the
+// database service would take them live
+func (d *database) CreateStream(ctx context.Context, req *proto.CreateStreamRequest) (*proto.StreamResponse, error) {
+	streamStatus, err := toStatus(req.Status, models.StatusScheduled)
+	if err != nil {
+		return nil, err
+	}
+	visibility, err := toVisibility(req.Visibility, models.VisibilityPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.client.CreateStream(ctx, &streamdb.CreateStreamRequest{
+		Title:       req.Title,
+		Description: req.Description,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		StreamKey:   req.StreamKey,
+		Resolution:  req.Resolution,
+		Bitrate:     req.Bitrate,
+		Framerate:   req.Framerate,
+		Codec:       req.Codec,
+		Protocol:    req.Protocol,
+		Status:      streamStatus,
+		UserId:      req.UserId,
+		Visibility:  visibility,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromStream(resp), nil
+}
+
+func (d *database) GetStream(ctx context.Context, req *proto.GetStreamRequest) (*proto.StreamResponse, error) {
+	resp, err := d.client.GetStream(ctx, &streamdb.GetStreamRequest{Id: req.Id})
+	if err != nil {
+		return nil, err
+	}
+	return fromStream(resp), nil
+}
+
+// UpdateStream keeps the stored value of the fields left empty in req. The
+// database service overwrites every field but the visibility, so the
+// request is completed with the stream as it is stored.
+func (d *database) UpdateStream(ctx context.Context, req *proto.UpdateStreamRequest) (*proto.StreamResponse, error) {
+	stored, err := d.client.GetStream(ctx, &streamdb.GetStreamRequest{Id: req.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	streamStatus, err := toStatus(req.Status, stored.Status.String())
+	if err != nil {
+		return nil, err
+	}
+	update := &streamdb.UpdateStreamRequest{
+		Id:          req.Id,
+		Title:       orStored(req.Title, stored.Title),
+		Description: orStored(req.Description, stored.Description),
+		StartTime:   orStored(req.StartTime, stored.StartTime),
+		EndTime:     orStored(req.EndTime, stored.EndTime),
+		Resolution:  orStored(req.Resolution, stored.Resolution),
+		Bitrate:     orStoredNumber(req.Bitrate, stored.Bitrate),
+		Framerate:   orStoredNumber(req.Framerate, stored.Framerate),
+		Codec:       orStored(req.Codec, stored.Codec),
+		ViewCount:   stored.ViewCount,
+		Protocol:    orStored(req.Protocol, stored.Protocol),
+		Status:      streamStatus,
+	}
+	if req.ViewCount != 0 {
+		update.ViewCount = req.ViewCount
+	}
+	if req.Visibility != "" {
+		visibility, err := toVisibility(req.Visibility, "")
+		if err != nil {
+			return nil, err
+		}
+		update.Visibility = &visibility
+	}
+
+	resp, err := d.client.UpdateStream(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+	return fromStream(resp), nil
+}
+
+func (d *database) DeleteStream(ctx context.Context, req *proto.DeleteStreamRequest) (*emptypb.Empty, error) {
+	return d.client.DeleteStream(ctx, &streamdb.DeleteStreamRequest{Id: req.Id})
+}
+
+func (d *database) ListStreams(ctx context.Context, req *proto.ListStreamsRequest) (*proto.ListStreamsResponse, error) {
+	list := &streamdb.ListStreamsRequest{
+		PageSize:   req.PageSize,
+		PageNumber: req.PageNumber,
+		SortBy:     req.SortBy,
+		Ascending:  req.Ascending,
+	}
+	if f := req.Filter; f != nil {
+		list.Filter = &streamdb.StreamFilter{
+			TitleContains:       f.TitleContains,
+			DescriptionContains: f.DescriptionContains,
+			UserId:              f.UserId,
+			MinViewCount:        f.MinViewCount,
+			MaxViewCount:        f.MaxViewCount,
+			StartTime:           f.StartTime,
+			EndTime:             f.EndTime,
+			EndTimeAfter:        f.EndTimeAfter,
+			EndTimeBefore:       f.EndTimeBefore,
+			Codec:               f.Codec,
+			Protocol:            f.Protocol,
+			IdAfter:             f.IdAfter,
+		}
+		for _, s := range f.Status {
+			streamStatus, err := toStatus(s, "")
+			if err != nil {
+				return nil, err
+			}
+			list.Filter.Status = append(list.Filter.Status, streamStatus)
+		}
+		for _, v := range f.Visibility {
+			visibility, err := toVisibility(v, "")
+			if err != nil {
+				return nil, err
+			}
+			list.Filter.Visibility = append(list.Filter.Visibility, visibility)
+		}
+	}
+
+	resp, err := d.client.ListStreams(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	return fromStreams(resp), nil
+}
+
+func (d *database) ListDeletedStreams(ctx context.Context, req *proto.ListDeletedStreamsRequest) (*proto.ListStreamsResponse, error) {
+	resp, err := d.client.ListDeletedStreams(ctx, &streamdb.ListDeletedStreamsRequest{
+		PageSize:      req.PageSize,
+		PageNumber:    req.PageNumber,
+		UserId:        req.UserId,
+		DeletedBefore: req.DeletedBefore,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromStreams(resp), nil
+}
+
+func (d *database) RestoreStream(ctx context.Context, req *proto.RestoreStreamRequest) (*proto.StreamResponse, error) {
+	resp, err := d.client.RestoreStream(ctx, &streamdb.RestoreStreamRequest{Id: req.Id, UserId: req.UserId})
+	if err != nil {
+		return nil, err
+	}
+	return fromStream(resp), nil
+}
+
+func (d *database) PurgeStream(ctx context.Context, req *proto.PurgeStreamRequest) (*emptypb.Empty, error) {
+	return d.client.PurgeStream(ctx, &streamdb.PurgeStreamRequest{Id: req.Id})
+}
+
+// toStatus returns the enum of a status, or of fallback when it is empty.
+// The enum values carry the names of the statuses.
+func toStatus(value, fallback string) (streamdb.StreamStatus, error) {
+	if value == "" {
+		value = fallback
+	}
+	streamStatus, ok := streamdb.StreamStatus_value[value]
+	if !ok {
+		return 0, status.Errorf(codes.InvalidArgument, "status must be one of ONLINE, OFFLINE, COMPLETE or SCHEDULED")
+	}
+	return streamdb.StreamStatus(streamStatus), nil
+}
+
+// toVisibility returns the enum of a visibility, or of fallback when it is
+// empty
+func toVisibility(value, fallback string) (streamdb.StreamVisibility, error) {
+	if value == "" {
+		value = fallback
+	}
+	visibility, ok := streamdb.StreamVisibility_value[value]
+	if !ok {
+		return 0, status.Errorf(codes.InvalidArgument, "visibility must be one of PUBLIC, UNLISTED or PRIVATE")
+	}
+	return streamdb.StreamVisibility(visibility), nil
+}
+
+func orStored(value, stored string) string {
+	if value == "" {
+		return stored
+	}
+	return value
+}
+
+// orStoredNumber returns value, or the stored number when value is not set.
+// The database service returns bitrates and framerates as strings.
+func orStoredNumber(value int32, stored string) int32 {
+	if value != 0 {
+		return value
+	}
+	n, _ := strconv.Atoi(stored)
+	return int32(n)
+}
+
+func fromStream(s *streamdb.StreamResponse) *proto.StreamResponse {
+	return &proto.StreamResponse{
+		Id:          s.Id,
+		Title:       s.Title,
+		Description: s.Description,
+		StartTime:   s.StartTime,
+		EndTime:     s.EndTime,
+		StreamKey:   s.StreamKey,
+		Resolution:  s.Resolution,
+		Bitrate:     s.Bitrate,
+		Framerate:   s.Framerate,
+		Codec:       s.Codec,
+		ViewCount:   s.ViewCount,
+		Protocol:    s.Protocol,
+		Status:      s.Status.String(),
+		UserId:      s.UserId,
+		Visibility:  s.Visibility.String(),
+		DeletedAt:   s.DeletedAt,
+	}
+}
+
+func fromStreams(resp *streamdb.ListStreamsResponse) *proto.ListStreamsResponse {
+	list := &proto.ListStreamsResponse{}
+	if meta := resp.MetaData; meta != nil {
+		list.MetaData = &proto.PaginationMetadata{
+			TotalItems:  meta.TotalItems,
+			TotalPages:  meta.TotalPages,
+			CurrentPage: meta.CurrentPage,
+			PageSize:    meta.PageSize,
+		}
+	}
+	for _, s := range resp.Streams {
+		list.Streams = append(list.Streams, fromStream(s))
+	}
+	return list
+}
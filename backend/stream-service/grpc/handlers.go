@@ -3,9 +3,21 @@ package grpcclient
 import (
 	"context"
 
+	"github.com/clementus360/platform/audit"
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/analytics"
+	"github.com/clementus360/stream-service/clips"
+	"github.com/clementus360/stream-service/collaborators"
+	"github.com/clementus360/stream-service/metrics"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/playback"
 	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/stream-service/purge"
+	"github.com/clementus360/stream-service/renditions"
+	"github.com/clementus360/stream-service/restream"
+	"github.com/clementus360/stream-service/telemetry"
 	"github.com/clementus360/stream-service/utils"
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -14,12 +26,32 @@ import (
 // Define the streamServiceServer struct
 type StreamServiceServer struct {
 	proto.UnimplementedStreamServiceServer
-	GrpcClient Client
+	GrpcClient    Client
+	Metrics       *metrics.Metrics
+	Analytics     *analytics.Service
+	Collaborators *collaborators.Store
+	Playback      *playback.Signer
+	Telemetry     *telemetry.Service
+	Renditions    *renditions.Ladders
+	Clips         *clips.Store
+	Restream      *restream.Service
+	Purge         *purge.Service
+	Audit         *audit.Log
+	// Recorder records the changes made through the REST API, which the
+	// audit interceptor of the gRPC server does not see
+	Recorder *audit.Recorder
+	// HLSBaseURL prefixes the media playlists listed in master playlists
+	HLSBaseURL string
 }
 
 // Implement the CreateStream method for gRPC
 func (s *StreamServiceServer) CreateStream(ctx context.Context, req *proto.CreateStreamRequest) (*proto.StreamResponse, error) {
-	logger := logrus.New()
+	logger := logging.FromContext(ctx)
+
+	// Users may only create streams for themselves
+	if user, ok := auth.UserFromContext(ctx); ok && !user.CanAccess(req.UserId) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot create a stream for another user")
+	}
 
 	// Generate stream key only if not provided
 	if req.StreamKey == "" {
@@ -31,38 +63,76 @@ func (s *StreamServiceServer) CreateStream(ctx context.Context, req *proto.Creat
 		}
 	}
 
+	// Streams are public unless told otherwise
+	if req.Visibility == "" {
+		req.Visibility = models.VisibilityPublic
+	} else if !models.ValidVisibility(req.Visibility) {
+		return nil, status.Errorf(codes.InvalidArgument, "visibility must be one of PUBLIC, UNLISTED or PRIVATE")
+	}
+
 	// Call gRPC to create the stream
 	streamResponse, err := s.GrpcClient.Client.CreateStream(ctx, req)
 	if err != nil {
-		logger.Errorf("Failed to create stream via gRPC: %v", err)
+		logger.Error("Failed to create stream via gRPC", "error", err)
 		return nil, err
 	}
+	s.Metrics.StreamCreated()
 
+	if err := s.withRenditions(ctx, streamResponse); err != nil {
+		return nil, err
+	}
 	return streamResponse, nil
 }
 
 // Implement the DeleteStream method for gRPC
 func (s *StreamServiceServer) DeleteStream(ctx context.Context, req *proto.DeleteStreamRequest) (*emptypb.Empty, error) {
-	logger := logrus.New()
+	logger := logging.FromContext(ctx)
+
+	// Deleting is not delegated to collaborators
+	if user, ok := auth.UserFromContext(ctx); ok {
+		stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: req.Id})
+		if err != nil {
+			logger.Error("Failed to get stream info via gRPC", "error", err)
+			return nil, err
+		}
+		if !user.CanAccess(int64(stream.UserId)) {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot delete another user's stream")
+		}
+	} else if err := auth.Authorize(ctx, 0); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
 
 	// Call gRPC to delete the stream
 	_, err := s.GrpcClient.Client.DeleteStream(ctx, req)
 	if err != nil {
-		logger.Errorf("Failed to delete stream via gRPC: %v", err)
+		logger.Error("Failed to delete stream via gRPC", "error", err)
 		return nil, err
 	}
+	// The collaborators, ladder, clips and restream destinations stay for a
+	// restore and go when the stream is purged. Relays stop right away.
+	s.Restream.StreamEnded(req.Id)
 
 	return &emptypb.Empty{}, nil
 }
 
 // Implement the ListStreams method for gRPC
 func (s *StreamServiceServer) ListStreams(ctx context.Context, req *proto.ListStreamsRequest) (*proto.ListStreamsResponse, error) {
-	logger := logrus.New()
+	logger := logging.FromContext(ctx)
+
+	// Unlisted and private streams are left out of listings and search
+	if !restrictListing(ctx, req) {
+		return &proto.ListStreamsResponse{
+			MetaData: &proto.PaginationMetadata{CurrentPage: 1, PageSize: req.PageSize},
+		}, nil
+	}
 
 	// Call gRPC to list streams
 	streamResponse, err := s.GrpcClient.Client.ListStreams(ctx, req)
 	if err != nil {
-		logger.Errorf("Failed to list streams via gRPC: %v", err)
+		logger.Error("Failed to list streams via gRPC", "error", err)
+		return nil, err
+	}
+	if err := s.withRenditions(ctx, streamResponse.Streams...); err != nil {
 		return nil, err
 	}
 
@@ -71,29 +141,88 @@ func (s *StreamServiceServer) ListStreams(ctx context.Context, req *proto.ListSt
 
 // Implement the GetStream method for gRPC
 func (s *StreamServiceServer) GetStream(ctx context.Context, req *proto.GetStreamRequest) (*proto.StreamResponse, error) {
-	logger := logrus.New()
+	logger := logging.FromContext(ctx)
 
 	// Call gRPC to get the stream info
 	streamResponse, err := s.GrpcClient.Client.GetStream(ctx, req)
 	if err != nil {
-		logger.Errorf("Failed to get stream info via gRPC: %v", err)
+		logger.Error("Failed to get stream info via gRPC", "error", err)
 		return nil, err
 	}
 
+	// Private streams do not exist for viewers outside the owner's team
+	if !s.canView(ctx, streamResponse) {
+		return nil, status.Errorf(codes.NotFound, "Stream not found")
+	}
+
+	if err := s.withRenditions(ctx, streamResponse); err != nil {
+		return nil, err
+	}
 	return streamResponse, nil
 }
 
 // Implement the UpdateStream method for gRPC
 func (s *StreamServiceServer) UpdateStream(ctx context.Context, req *proto.UpdateStreamRequest) (*proto.StreamResponse, error) {
-	logger := logrus.New()
+	logger := logging.FromContext(ctx)
+
+	if req.Visibility != "" && !models.ValidVisibility(req.Visibility) {
+		return nil, status.Errorf(codes.InvalidArgument, "visibility must be one of PUBLIC, UNLISTED or PRIVATE")
+	}
+
+	// Owners may let collaborators edit the stream or take it live
+	if _, ok := auth.UserFromContext(ctx); ok {
+		stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: req.Id})
+		if err != nil {
+			logger.Error("Failed to get stream info via gRPC", "error", err)
+			return nil, err
+		}
+		if req.Status != "" && req.Status != stream.Status && !s.authorizeStream(ctx, stream, collaborators.PermissionChangeStatus) {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot change the status of this stream")
+		}
+		if updatesDetails(req) && !s.authorizeStream(ctx, stream, collaborators.PermissionEdit) {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot edit this stream")
+		}
+	} else if err := auth.Authorize(ctx, 0); err != nil {
+		// only trusted services change streams on nobody's behalf
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
 
 	// Call gRPC to update the stream info
 	streamResponse, err := s.GrpcClient.Client.UpdateStream(ctx, req)
 
 	if err != nil {
-		logger.Errorf("Failed to update stream info via gRPC: %v", err)
+		logger.Error("Failed to update stream info via gRPC", "error", err)
 		return nil, err
 	}
 
+	// Start restreaming as soon as a stream goes live and roll up its
+	// audience as soon as it ends
+	switch streamResponse.Status {
+	case models.StatusOnline:
+		if err := s.Clips.StreamLive(ctx, streamResponse.Id); err != nil {
+			logger.Error("Failed to record the broadcast of the stream via gRPC", "stream_id", streamResponse.Id, "error", err)
+		}
+		if err := s.Restream.StreamLive(ctx, streamResponse); err != nil {
+			logger.Error("Failed to start restreaming the stream", "stream_id", streamResponse.Id, "error", err)
+		}
+	case models.StatusComplete, models.StatusOffline:
+		s.Analytics.StreamEnded(streamResponse.Id)
+		s.Telemetry.StreamEnded(streamResponse.Id)
+		if err := s.Clips.StreamEnded(ctx, streamResponse.Id); err != nil {
+			logger.Error("Failed to end the broadcast of the stream via gRPC", "stream_id", streamResponse.Id, "error", err)
+		}
+		s.Restream.StreamEnded(streamResponse.Id)
+	}
+
+	if err := s.withRenditions(ctx, streamResponse); err != nil {
+		return nil, err
+	}
 	return streamResponse, nil
 }
+
+// updatesDetails reports whether req changes anything but the status
+func updatesDetails(req *proto.UpdateStreamRequest) bool {
+	return req.Title != "" || req.Description != "" || req.StartTime != "" || req.EndTime != "" ||
+		req.Resolution != "" || req.Bitrate != 0 || req.Framerate != 0 || req.Codec != "" ||
+		req.ViewCount != 0 || req.Protocol != "" || req.Visibility != ""
+}
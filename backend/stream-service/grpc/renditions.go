@@ -0,0 +1,154 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/collaborators"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/playback"
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/stream-service/renditions"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Implement the ListRenditionPresets method for gRPC
+func (s *StreamServiceServer) ListRenditionPresets(ctx context.Context, req *emptypb.Empty) (*proto.ListRenditionPresetsResponse, error) {
+	resp := &proto.ListRenditionPresetsResponse{DefaultPreset: s.Renditions.DefaultPreset()}
+	for _, preset := range s.Renditions.Presets() {
+		resp.Presets = append(resp.Presets, &proto.RenditionPreset{
+			Name:       preset.Name,
+			Renditions: renditionsResponse(preset.Renditions),
+		})
+	}
+	return resp, nil
+}
+
+// Implement the SetStreamRenditions method for gRPC
+func (s *StreamServiceServer) SetStreamRenditions(ctx context.Context, req *proto.SetStreamRenditionsRequest) (*proto.StreamResponse, error) {
+	logger := logging.FromContext(ctx)
+
+	if err := authenticated(ctx); err != nil {
+		return nil, err
+	}
+
+	stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: req.StreamId})
+	if err != nil {
+		logger.Error("Failed to get stream info via gRPC", "error", err)
+		return nil, err
+	}
+
+	// The ladder is part of the encoding settings of the stream
+	if !s.authorizeStream(ctx, stream, collaborators.PermissionEdit) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot edit this stream")
+	}
+
+	ladder, err := s.Renditions.Set(ctx, stream.Id, renditions.SourceOf(stream), req.Preset, req.Renditions)
+	if err != nil {
+		return nil, renditionsError(err)
+	}
+	logger.Info("Set stream renditions", "stream_id", stream.Id, "preset", ladder.Preset, "renditions", len(ladder.Renditions))
+
+	stream.RenditionPreset = ladder.Preset
+	stream.Renditions = renditionsResponse(ladder.Renditions)
+	return stream, nil
+}
+
+// Implement the GetMasterPlaylist method for gRPC
+func (s *StreamServiceServer) GetMasterPlaylist(ctx context.Context, req *proto.GetMasterPlaylistRequest) (*proto.MasterPlaylist, error) {
+	logger := logging.FromContext(ctx)
+
+	stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: req.StreamId})
+	if err != nil {
+		logger.Error("Failed to get stream info via gRPC", "error", err)
+		return nil, err
+	}
+
+	// Players present the playback token they were issued, which already
+	// decided whether the viewer may watch the stream
+	if _, err := s.Playback.Verify(req.Token, stream.Id); err != nil {
+		return nil, playbackError(err)
+	}
+
+	if stream.Status != models.StatusOnline {
+		return nil, status.Errorf(codes.FailedPrecondition, "stream is not live")
+	}
+	ladder, err := s.Renditions.Ladder(ctx, stream.Id, renditions.SourceOf(stream))
+	if err != nil {
+		logger.Error("Failed to get stream renditions via gRPC", "stream_id", stream.Id, "error", err)
+		return nil, err
+	}
+	if len(ladder.Renditions) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "no rendition fits the source of this stream")
+	}
+
+	content := renditions.MasterPlaylist(ladder, func(r renditions.Rendition) string {
+		uri := fmt.Sprintf("%s/%d/%s/index.m3u8", strings.TrimSuffix(s.HLSBaseURL, "/"), stream.Id, r.Name)
+		if req.Token != "" {
+			uri += "?token=" + url.QueryEscape(req.Token)
+		}
+		return uri
+	})
+	return &proto.MasterPlaylist{StreamId: stream.Id, Content: content}, nil
+}
+
+// withRenditions fills in the ladders of streams returned by the database
+// service, which are read in a single call
+func (s *StreamServiceServer) withRenditions(ctx context.Context, streams ...*proto.StreamResponse) error {
+	ladders, err := s.Renditions.LaddersOf(ctx, streams)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get stream renditions via gRPC", "error", err)
+		return err
+	}
+	for _, stream := range streams {
+		ladder := ladders[stream.Id]
+		stream.RenditionPreset = ladder.Preset
+		stream.Renditions = renditionsResponse(ladder.Renditions)
+	}
+	return nil
+}
+
+// renditionsError maps the errors of the rendition ladders to gRPC statuses
+func renditionsError(err error) error {
+	switch {
+	case errors.Is(err, renditions.ErrInvalidRendition), errors.Is(err, renditions.ErrUnknownPreset):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, renditions.ErrExceedsSource):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+// playbackError maps the errors of verifying a playback token to gRPC
+// statuses
+func playbackError(err error) error {
+	switch {
+	case errors.Is(err, playback.ErrDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, playback.ErrMissingToken), errors.Is(err, playback.ErrInvalidToken), errors.Is(err, playback.ErrExpiredToken):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, playback.ErrWrongStream):
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return err
+}
+
+func renditionsResponse(list []renditions.Rendition) []*proto.Rendition {
+	resp := make([]*proto.Rendition, 0, len(list))
+	for _, r := range list {
+		resp = append(resp, &proto.Rendition{
+			Name:        r.Name,
+			Width:       int32(r.Width),
+			Height:      int32(r.Height),
+			Framerate:   int32(r.Framerate),
+			BitrateKbps: int32(r.Bitrate),
+		})
+	}
+	return resp
+}
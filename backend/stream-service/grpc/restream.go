@@ -0,0 +1,142 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/stream-service/restream"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Implement the AddRestreamDestination method for gRPC
+func (s *StreamServiceServer) AddRestreamDestination(ctx context.Context, req *proto.AddRestreamDestinationRequest) (*proto.RestreamDestination, error) {
+	logger := logging.FromContext(ctx)
+
+	ownerID, err := s.collaboratorsOwner(ctx, req.OwnerId, req.StreamId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Destinations hold the keys of the owner's accounts elsewhere, so they
+	// are not delegated to collaborators
+	if err := authorizeOwner(ctx, ownerID, "cannot restream another user's streams"); err != nil {
+		return nil, err
+	}
+
+	enabled := req.Enabled == nil || *req.Enabled
+	destination, err := s.Restream.Add(ctx, ownerID, req.StreamId, req.Name, req.Url, req.Key, enabled)
+	if err != nil {
+		return nil, restreamError(err)
+	}
+	logger.Info("Added restream destination", "destination_id", destination.ID, "owner_id", ownerID, "stream_id", req.StreamId, "name", destination.Name)
+
+	return restreamDestinationResponse(destination), nil
+}
+
+// Implement the UpdateRestreamDestination method for gRPC
+func (s *StreamServiceServer) UpdateRestreamDestination(ctx context.Context, req *proto.UpdateRestreamDestinationRequest) (*proto.RestreamDestination, error) {
+	logger := logging.FromContext(ctx)
+
+	if err := s.authorizeDestination(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	destination, err := s.Restream.Update(ctx, req.Id, restream.Changes{
+		Name:    req.Name,
+		URL:     req.Url,
+		Key:     req.Key,
+		Enabled: req.Enabled,
+	})
+	if err != nil {
+		return nil, restreamError(err)
+	}
+	logger.Info("Updated restream destination", "destination_id", destination.ID, "enabled", destination.Enabled)
+
+	return restreamDestinationResponse(destination), nil
+}
+
+// Implement the RemoveRestreamDestination method for gRPC
+func (s *StreamServiceServer) RemoveRestreamDestination(ctx context.Context, req *proto.RemoveRestreamDestinationRequest) (*emptypb.Empty, error) {
+	logger := logging.FromContext(ctx)
+
+	if err := s.authorizeDestination(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	if err := s.Restream.Remove(ctx, req.Id); err != nil {
+		return nil, restreamError(err)
+	}
+	logger.Info("Removed restream destination", "destination_id", req.Id)
+
+	return &emptypb.Empty{}, nil
+}
+
+// Implement the ListRestreamDestinations method for gRPC
+func (s *StreamServiceServer) ListRestreamDestinations(ctx context.Context, req *proto.ListRestreamDestinationsRequest) (*proto.ListRestreamDestinationsResponse, error) {
+	ownerID, err := s.collaboratorsOwner(ctx, req.OwnerId, req.StreamId)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeOwner(ctx, ownerID, "cannot list another user's restream destinations"); err != nil {
+		return nil, err
+	}
+
+	destinations, err := s.Restream.List(ctx, ownerID, req.StreamId)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list restream destinations via gRPC", "error", err)
+		return nil, restreamError(err)
+	}
+	resp := &proto.ListRestreamDestinationsResponse{}
+	for _, destination := range destinations {
+		resp.Destinations = append(resp.Destinations, restreamDestinationResponse(destination))
+	}
+	return resp, nil
+}
+
+// authorizeDestination checks that the call is made by the owner of the
+// destination
+func (s *StreamServiceServer) authorizeDestination(ctx context.Context, id int32) error {
+	destination, err := s.Restream.Get(ctx, id)
+	if err != nil {
+		return restreamError(err)
+	}
+	return authorizeOwner(ctx, destination.OwnerID, "cannot change another user's restream destinations")
+}
+
+// restreamError maps the errors of the restream service to gRPC statuses
+func restreamError(err error) error {
+	switch {
+	case errors.Is(err, restream.ErrDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, restream.ErrInvalidDestination):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, restream.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+func restreamDestinationResponse(destination restream.Destination) *proto.RestreamDestination {
+	return &proto.RestreamDestination{
+		Id:        destination.ID,
+		OwnerId:   destination.OwnerID,
+		StreamId:  destination.StreamID,
+		Name:      destination.Name,
+		Url:       destination.URL,
+		KeyHint:   destination.KeyHint,
+		Enabled:   destination.Enabled,
+		CreatedAt: destination.CreatedAt.Format(models.TimeFormat),
+		Status: &proto.RestreamStatus{
+			State:    destination.Status.State,
+			StreamId: destination.Status.StreamID,
+			Error:    destination.Status.Error,
+			Since:    destination.Status.Since.Format(models.TimeFormat),
+			Attempts: int32(destination.Status.Attempts),
+		},
+	}
+}
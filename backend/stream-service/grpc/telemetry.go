@@ -0,0 +1,120 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/collaborators"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/stream-service/telemetry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Implement the ReportStreamTelemetry method for gRPC
+func (s *StreamServiceServer) ReportStreamTelemetry(ctx context.Context, req *proto.StreamTelemetry) (*proto.StreamHealth, error) {
+	logger := logging.FromContext(ctx)
+
+	if err := authenticated(ctx); err != nil {
+		return nil, err
+	}
+
+	stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: req.StreamId})
+	if err != nil {
+		logger.Error("Failed to get stream info via gRPC", "error", err)
+		return nil, err
+	}
+
+	// Encoders report as the ingest service or on behalf of whoever runs the
+	// broadcast
+	if !s.authorizeStream(ctx, stream, collaborators.PermissionChangeStatus) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot report telemetry for this stream")
+	}
+
+	health, err := s.Telemetry.Report(ctx, stream, telemetry.Sample{
+		Bitrate:          int(req.BitrateKbps),
+		Framerate:        req.Framerate,
+		DroppedFrames:    int(req.DroppedFrames),
+		KeyframeInterval: time.Duration(req.KeyframeIntervalSeconds * float64(time.Second)),
+		AudioLevel:       req.AudioLevelDb,
+	})
+	if err != nil {
+		logger.Error("Failed to report stream telemetry", "stream_id", stream.Id, "error", err)
+		return nil, telemetryError(err)
+	}
+
+	return streamHealthResponse(health), nil
+}
+
+// Implement the GetStreamHealth method for gRPC
+func (s *StreamServiceServer) GetStreamHealth(ctx context.Context, req *proto.GetStreamHealthRequest) (*proto.StreamHealth, error) {
+	logger := logging.FromContext(ctx)
+
+	if err := authenticated(ctx); err != nil {
+		return nil, err
+	}
+
+	stream, err := s.GrpcClient.Client.GetStream(ctx, &proto.GetStreamRequest{Id: req.StreamId})
+	if err != nil {
+		logger.Error("Failed to get stream info via gRPC", "error", err)
+		return nil, err
+	}
+
+	if !s.authorizeStream(ctx, stream, collaborators.PermissionChangeStatus) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot view the health of this stream")
+	}
+
+	health, err := s.Telemetry.Health(ctx, stream)
+	if err != nil {
+		logger.Error("Failed to get stream health", "stream_id", stream.Id, "error", err)
+		return nil, err
+	}
+
+	return streamHealthResponse(health), nil
+}
+
+// telemetryError maps the errors of the telemetry service to gRPC statuses.
+// Errors of the database service already carry one.
+func telemetryError(err error) error {
+	switch {
+	case errors.Is(err, telemetry.ErrStreamNotLive):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, telemetry.ErrInvalidSample):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func streamHealthResponse(health telemetry.Health) *proto.StreamHealth {
+	resp := &proto.StreamHealth{
+		StreamId:           health.StreamID,
+		Status:             health.Status,
+		AverageBitrateKbps: int32(health.AverageBitrate),
+		AverageFramerate:   health.AverageFramerate,
+		DroppedFrameRatio:  health.DroppedFrameRatio,
+	}
+	if !health.LastReportedAt.IsZero() {
+		resp.LastReportedAt = health.LastReportedAt.Format(models.TimeFormat)
+	}
+	for _, warning := range health.Warnings {
+		resp.Warnings = append(resp.Warnings, &proto.HealthWarning{
+			Kind:    warning.Kind,
+			Message: warning.Message,
+			Since:   warning.Since.Format(models.TimeFormat),
+		})
+	}
+	for _, sample := range health.Samples {
+		resp.Samples = append(resp.Samples, &proto.TelemetrySample{
+			ReportedAt:              sample.ReportedAt.Format(models.TimeFormat),
+			BitrateKbps:             int32(sample.Bitrate),
+			Framerate:               sample.Framerate,
+			DroppedFrames:           int32(sample.DroppedFrames),
+			KeyframeIntervalSeconds: sample.KeyframeInterval.Seconds(),
+			AudioLevelDb:            sample.AudioLevel,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,84 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/clementus360/platform/auth"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Implement the ListDeletedStreams method for gRPC
+func (s *StreamServiceServer) ListDeletedStreams(ctx context.Context, req *proto.ListDeletedStreamsRequest) (*proto.ListStreamsResponse, error) {
+	logger := logging.FromContext(ctx)
+
+	// Owners see their own trash and admins anyone's
+	if user, ok := auth.UserFromContext(ctx); ok && req.UserId == 0 && !user.HasRole(auth.RoleAdmin) {
+		req.UserId = int32(user.ID)
+	}
+	if err := authorizeOwner(ctx, req.UserId, "cannot list another user's deleted streams"); err != nil {
+		return nil, err
+	}
+
+	// Call gRPC to list the deleted streams
+	streamResponse, err := s.GrpcClient.Client.ListDeletedStreams(ctx, req)
+	if err != nil {
+		logger.Error("Failed to list deleted streams via gRPC", "error", err)
+		return nil, err
+	}
+
+	// The trash is browsed, not streamed to, so it leaves out stream keys
+	for _, stream := range streamResponse.Streams {
+		stream.StreamKey = ""
+	}
+
+	return streamResponse, nil
+}
+
+// Implement the RestoreStream method for gRPC
+func (s *StreamServiceServer) RestoreStream(ctx context.Context, req *proto.RestoreStreamRequest) (*proto.StreamResponse, error) {
+	logger := logging.FromContext(ctx)
+
+	// Deleted streams cannot be looked up, so owners may only restore a
+	// stream of their own and admins anyone's
+	if user, ok := auth.UserFromContext(ctx); ok && req.UserId == 0 && !user.HasRole(auth.RoleAdmin) {
+		req.UserId = int32(user.ID)
+	}
+	if err := authorizeOwner(ctx, req.UserId, "cannot restore another user's stream"); err != nil {
+		return nil, err
+	}
+
+	// Call gRPC to restore the stream
+	streamResponse, err := s.GrpcClient.Client.RestoreStream(ctx, req)
+	if err != nil {
+		logger.Error("Failed to restore stream via gRPC", "error", err)
+		return nil, err
+	}
+	logger.Info("Restored stream", "stream_id", streamResponse.Id, "user_id", streamResponse.UserId)
+
+	if err := s.withRenditions(ctx, streamResponse); err != nil {
+		return nil, err
+	}
+	return streamResponse, nil
+}
+
+// Implement the PurgeStream method for gRPC
+func (s *StreamServiceServer) PurgeStream(ctx context.Context, req *proto.PurgeStreamRequest) (*emptypb.Empty, error) {
+	logger := logging.FromContext(ctx)
+
+	// Purging cannot be undone, so owners wait for the retention period
+	if user, ok := auth.UserFromContext(ctx); ok && !user.HasRole(auth.RoleAdmin) {
+		return nil, status.Errorf(codes.PermissionDenied, "only admins can purge streams")
+	}
+
+	if err := s.Purge.Purge(ctx, req.Id); err != nil {
+		logger.Error("Failed to purge stream via gRPC", "error", err)
+		return nil, err
+	}
+	logger.Info("Purged stream", "stream_id", req.Id)
+
+	return &emptypb.Empty{}, nil
+}
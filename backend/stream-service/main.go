@@ -3,100 +3,118 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/clementus360/stream-service/api"
+	platformconfig "github.com/clementus360/platform/config"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/tracing"
+	"github.com/clementus360/stream-service/app"
 	"github.com/clementus360/stream-service/config"
-	grpcclient "github.com/clementus360/stream-service/grpc"
-	"github.com/clementus360/stream-service/proto"
-	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// Define the logger for error and eventt logging
-	logger := logrus.New()
+	// load the configuration from defaults, config file, environment and flags
+	cfg, err := config.LoadConfig(os.Args[1:])
+	if cfg.PrintConfig {
+		platformconfig.Print(os.Stdout, cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
+	if cfg.PrintConfig {
+		return
+	}
 
-	// load environment variables
-	config.LoadEnv()
+	PORT := cfg.Server.Port
+	grpcPORT := cfg.Server.GRPCPort
 
-	// Get the port number from environment variables and set the fallback port
-	PORT := os.Getenv("PORT")
-	if PORT == "" {
-		PORT = "8080"
-	}
+	// time given to load balancers to notice the service is draining
+	drainPeriod := cfg.Server.DrainPeriod
+
+	// Define the structured logger shared by every request
+	logger := logging.New("stream-service", cfg.Log.Level, cfg.Log.Format)
+	slog.SetDefault(logger)
 
-	grpcPORT := os.Getenv("gRPC_PORT")
+	ctx := logging.WithLogger(context.Background(), logger)
 
-	// start a grpc client with context to handle grpc connections
-	ctx := context.Background()
-	grpcClient, err := grpcclient.NewClient(ctx)
+	// set up tracing before any connection is opened so that every client
+	// and server picks up the global tracer provider
+	shutdownTracing, err := tracing.Setup(ctx, "stream-service", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.OTLPInsecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
 	if err != nil {
-		logger.Fatalf("Failed to initialize gRPC client: %v", err)
+		logger.Error("Failed to initialize tracing", "error", err)
+		os.Exit(1)
 	}
-	logger.Info("grpc client initialized successfully")
-	defer grpcClient.Close()
-
-	// define route handlers
-	router := http.NewServeMux()
-	router.HandleFunc("POST /v1/api/stream", api.CreateStream(grpcClient))
-	router.HandleFunc("GET /v1/api/stream", api.RetrieveStream(grpcClient))
-	router.HandleFunc("PATCH /v1/api/stream", api.UpdateStream(grpcClient))
-	router.HandleFunc("DELETE /v1/api/stream", api.DeleteStream(grpcClient))
-	router.HandleFunc("GET /v1/api/streams", api.ListStream(grpcClient))
+	defer shutdownTracing(context.Background())
 
-	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPORT))
+	// connect to the database service and register the stream service
+	streamApp, err := app.New(ctx, cfg, app.WithLogger(logger))
 	if err != nil {
-		logger.Fatalf("Failed to listen: %v", err)
+		logger.Error("Failed to initialize stream service", "error", err)
+		os.Exit(1)
 	}
+	logger.Info("grpc client initialized successfully")
 
-	var opts []grpc.ServerOption
-
-	// create a gRPC server instance
-	grpcServer := grpc.NewServer(opts...)
+	// run the background loops until shutdown
+	backgroundCtx, stopBackground := context.WithCancel(ctx)
+	defer stopBackground()
+	streamApp.Start(backgroundCtx)
 
-	streamService := &grpcclient.StreamServiceServer{
-		GrpcClient: *grpcClient,
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPORT))
+	if err != nil {
+		logger.Error("Failed to listen", "error", err)
+		os.Exit(1)
 	}
-	reflection.Register(grpcServer)
-
-	proto.RegisterStreamServiceServer(grpcServer, streamService)
 
 	// Handle graceful shutdown
 	go func() {
-		logger.Infof("gRPC server is listening on port %s", grpcPORT)
-		if err := grpcServer.Serve(listener); err != nil {
-			logger.Fatalf("Failed to serve: %v", err)
+		logger.Info("gRPC server is listening", "port", grpcPORT)
+		if err := streamApp.GRPCServer().Serve(listener); err != nil {
+			logger.Error("Failed to serve gRPC", "error", err)
+			os.Exit(1)
 		}
 	}()
 
 	// define the server before starting
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%s", PORT),
-		Handler: router,
+		Handler: streamApp.Handler(),
 	}
 
 	// start the server inside a goroutine
 	go func() {
-		logger.Info(fmt.Sprintf("Server running at PORT: %v", PORT))
+		logger.Info("Server running", "port", PORT)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Fatalf("Failed to serve: %v", err)
+			logger.Error("Failed to serve HTTP", "error", err)
+			os.Exit(1)
 		}
 	}()
 
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
 
-	logger.Info("Shutting down server...")
+	// report NOT_SERVING first so load balancers drain before we stop
+	logger.Info("Shutting down server...", "drain_period", drainPeriod.String())
+	streamApp.Drain()
+	time.Sleep(drainPeriod)
+
 	if err := server.Shutdown(context.Background()); err != nil {
-		logger.Fatalf("Server forced to shutdown: %v", err)
+		logger.Error("Server forced to shutdown", "error", err)
+		os.Exit(1)
 	}
 
-	grpcServer.GracefulStop()
+	streamApp.Close()
 	logger.Info("Server exiting")
 }
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// domain holds the stream-specific business metrics
+type domain struct {
+	streamsCreated prometheus.Counter
+	liveStreams    prometheus.Gauge
+	viewerSessions prometheus.Gauge
+	healthWarnings *prometheus.CounterVec
+}
+
+func newDomain(namespace string, registry *prometheus.Registry) domain {
+	d := domain{
+		streamsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "streams_created_total",
+			Help:      "Total streams created.",
+		}),
+		liveStreams: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "live_streams",
+			Help:      "Number of streams currently live.",
+		}),
+		viewerSessions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "viewer_sessions_open",
+			Help:      "Number of viewer sessions currently open.",
+		}),
+		healthWarnings: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "stream_health_warnings_total",
+			Help:      "Total health warnings raised for live streams, by kind.",
+		}, []string{"kind"}),
+	}
+	registry.MustRegister(d.streamsCreated, d.liveStreams, d.viewerSessions, d.healthWarnings)
+	return d
+}
+
+// StreamCreated counts a successfully created stream
+func (m *Metrics) StreamCreated() {
+	m.streamsCreated.Inc()
+}
+
+// StreamHealthWarning counts a health warning raised for a live stream
+func (m *Metrics) StreamHealthWarning(kind string) {
+	m.healthWarnings.WithLabelValues(kind).Inc()
+}
+
+// TrackLiveStreams refreshes the live streams gauge every interval using
+// count until ctx is cancelled
+func (m *Metrics) TrackLiveStreams(ctx context.Context, interval time.Duration, count func(context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		refreshCtx, cancel := context.WithTimeout(ctx, interval)
+		live, err := count(refreshCtx)
+		cancel()
+		if err != nil {
+			slog.Warn("Failed to refresh live streams gauge", "error", err)
+		} else {
+			m.liveStreams.Set(float64(live))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// TrackViewerSessions refreshes the open viewer sessions gauge every
+// interval using count until ctx is cancelled
+func (m *Metrics) TrackViewerSessions(ctx context.Context, interval time.Duration, count func(context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		refreshCtx, cancel := context.WithTimeout(ctx, interval)
+		open, err := count(refreshCtx)
+		cancel()
+		if err != nil {
+			slog.Warn("Failed to refresh viewer sessions gauge", "error", err)
+		} else {
+			m.viewerSessions.Set(float64(open))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
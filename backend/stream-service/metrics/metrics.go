@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	platformmetrics "github.com/clementus360/platform/metrics"
+)
+
+// Metrics adds the stream-specific business metrics to the RED collectors
+// shared by the services
+type Metrics struct {
+	*platformmetrics.Metrics
+
+	domain
+}
+
+// New creates the collectors under the given namespace (e.g. "stream_service")
+func New(namespace string) *Metrics {
+	shared := platformmetrics.New(namespace)
+	return &Metrics{
+		Metrics: shared,
+		domain:  newDomain(namespace, shared.Registry()),
+	}
+}
@@ -1,6 +1,34 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"time"
+)
+
+// Stream statuses, mirroring EStreamStatus in the database service
+const (
+	StatusOnline    = "ONLINE"
+	StatusOffline   = "OFFLINE"
+	StatusComplete  = "COMPLETE"
+	StatusScheduled = "SCHEDULED"
+)
+
+// Stream visibilities, mirroring EStreamVisibility in the database service.
+// Unlisted streams are watchable by anyone with the link but left out of
+// listings; private streams only by the owner's team.
+const (
+	VisibilityPublic   = "PUBLIC"
+	VisibilityUnlisted = "UNLISTED"
+	VisibilityPrivate  = "PRIVATE"
+)
+
+// ValidVisibility reports whether visibility is a known stream visibility
+func ValidVisibility(visibility string) bool {
+	return slices.Contains([]string{VisibilityPublic, VisibilityUnlisted, VisibilityPrivate}, visibility)
+}
+
+// TimeFormat is the layout of the times exchanged with the database service
+const TimeFormat = "2006-01-02T15:04:05Z"
 
 // Stream represents a stream entity in the system.
 type Stream struct {
@@ -19,6 +47,7 @@ type Stream struct {
 	ViewCount   int        `json:"view_count" db:"view_count"`
 	Protocol    string     `json:"protocol" db:"protocol"`
 	Status      string     `json:"status" db:"status"`
+	Visibility  string     `json:"visibility" db:"visibility"`
 	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // Nullable
 	UserID      int64      `json:"user_id" db:"user_id"`
 }
@@ -0,0 +1,153 @@
+// Package playback signs the tokens viewers present to the playback
+// endpoints and the distribution server. Tokens are compact HS256 JWTs bound
+// to a single stream and signed with a key shared with the services serving
+// media, which verify them offline.
+package playback
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	issuer   = "stream-service"
+	audience = "playback"
+)
+
+var (
+	// ErrDisabled is returned when no signing key is configured
+	ErrDisabled     = errors.New("playback tokens are not configured")
+	ErrMissingToken = errors.New("missing playback token")
+	ErrInvalidToken = errors.New("invalid playback token")
+	ErrExpiredToken = errors.New("playback token expired")
+	// ErrWrongStream is returned for valid tokens issued for another stream
+	ErrWrongStream = errors.New("playback token issued for another stream")
+)
+
+// clockSkew tolerates small clock differences between hosts
+const clockSkew = 30 * time.Second
+
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims is the payload of a playback token
+type Claims struct {
+	Issuer   string `json:"iss"`
+	Audience string `json:"aud"`
+	// Subject is the viewer the token was issued to, empty for anonymous
+	// viewers of public and unlisted streams
+	Subject   string `json:"sub,omitempty"`
+	StreamID  int32  `json:"sid"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Signer issues and verifies playback tokens
+type Signer struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewSigner returns a signer for tokens valid for ttl. Without a key the
+// signer is disabled and every call fails with ErrDisabled.
+func NewSigner(key string, ttl time.Duration) *Signer {
+	return &Signer{
+		key: []byte(key),
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// Enabled reports whether a signing key is configured
+func (s *Signer) Enabled() bool {
+	return len(s.key) > 0
+}
+
+// Issue returns a token granting viewer access to a stream, together with
+// its expiry
+func (s *Signer) Issue(streamID int32, viewer string) (string, time.Time, error) {
+	if !s.Enabled() {
+		return "", time.Time{}, ErrDisabled
+	}
+
+	now := s.now()
+	expiresAt := now.Add(s.ttl)
+	payload, err := json.Marshal(Claims{
+		Issuer:    issuer,
+		Audience:  audience,
+		Subject:   viewer,
+		StreamID:  streamID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + s.signature(unsigned), expiresAt, nil
+}
+
+// Verify checks that token was signed with the key of the signer, has not
+// expired and grants access to streamID
+func (s *Signer) Verify(token string, streamID int32) (*Claims, error) {
+	if !s.Enabled() {
+		return nil, ErrDisabled
+	}
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return nil, ErrInvalidToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.signature(parts[0]+"."+parts[1]))) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.Issuer != issuer || claims.Audience != audience {
+		return nil, ErrInvalidToken
+	}
+
+	now := s.now()
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, ErrExpiredToken
+	}
+	if claims.StreamID != streamID {
+		return nil, ErrWrongStream
+	}
+	return &claims, nil
+}
+
+func (s *Signer) signature(unsigned string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// TokenFromRequest returns the playback token of an HTTP request, taken from
+// the token query parameter, which players append to media URLs, or from a
+// bearer Authorization header
+func TokenFromRequest(r *http.Request) string {
+	if token := r.URL.Query().Get("token"); token != "" {
+		return token
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package playback
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	issued := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	signer := NewSigner("a key shared with the media servers", time.Minute)
+	signer.now = func() time.Time { return issued }
+	token, expiresAt, err := signer.Issue(7, "viewer-1")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if !expiresAt.Equal(issued.Add(time.Minute)) {
+		t.Errorf("token expires at %v, want %v", expiresAt, issued.Add(time.Minute))
+	}
+	parts := strings.Split(token, ".")
+	other, _, err := NewSigner("another key", time.Minute).Issue(7, "viewer-1")
+	if err != nil {
+		t.Fatalf("Issue with another key: %v", err)
+	}
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"stream-service","aud":"playback","sub":"viewer-1","sid":8,"exp":9999999999}`))
+
+	tests := []struct {
+		name     string
+		token    string
+		streamID int32
+		after    time.Duration
+		want     error
+	}{
+		{"valid", token, 7, 0, nil},
+		{"at the end of its lifetime", token, 7, time.Minute, nil},
+		{"within the clock skew", token, 7, time.Minute + clockSkew, nil},
+		{"expired", token, 7, time.Minute + clockSkew + time.Second, ErrExpiredToken},
+		{"other stream", token, 8, 0, ErrWrongStream},
+		{"missing", "", 7, 0, ErrMissingToken},
+		{"other key", other, 7, 0, ErrInvalidToken},
+		{"payload swapped", parts[0] + "." + forged + "." + parts[2], 8, 0, ErrInvalidToken},
+		{"signature stripped", parts[0] + "." + parts[1] + ".", 7, 0, ErrInvalidToken},
+		{"other header", base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + "." + parts[2], 7, 0, ErrInvalidToken},
+		{"malformed", "not-a-token", 7, 0, ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer.now = func() time.Time { return issued.Add(tt.after) }
+			claims, err := signer.Verify(tt.token, tt.streamID)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify failed with %v, want %v", err, tt.want)
+			}
+			if err == nil && (claims.Subject != "viewer-1" || claims.StreamID != 7) {
+				t.Errorf("Verify returned %+v", claims)
+			}
+		})
+	}
+}
+
+func TestDisabledSigner(t *testing.T) {
+	signer := NewSigner("", time.Minute)
+	if signer.Enabled() {
+		t.Fatal("signer without a key is enabled")
+	}
+	if _, _, err := signer.Issue(7, "viewer-1"); !errors.Is(err, ErrDisabled) {
+		t.Errorf("Issue failed with %v, want ErrDisabled", err)
+	}
+	if _, err := signer.Verify("a.b.c", 7); !errors.Is(err, ErrDisabled) {
+		t.Errorf("Verify failed with %v, want ErrDisabled", err)
+	}
+}
@@ -20,11 +20,36 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StreamService_CreateStream_FullMethodName = "/stream.StreamService/CreateStream"
-	StreamService_GetStream_FullMethodName    = "/stream.StreamService/GetStream"
-	StreamService_UpdateStream_FullMethodName = "/stream.StreamService/UpdateStream"
-	StreamService_DeleteStream_FullMethodName = "/stream.StreamService/DeleteStream"
-	StreamService_ListStreams_FullMethodName  = "/stream.StreamService/ListStreams"
+	StreamService_CreateStream_FullMethodName              = "/stream.StreamService/CreateStream"
+	StreamService_GetStream_FullMethodName                 = "/stream.StreamService/GetStream"
+	StreamService_UpdateStream_FullMethodName              = "/stream.StreamService/UpdateStream"
+	StreamService_DeleteStream_FullMethodName              = "/stream.StreamService/DeleteStream"
+	StreamService_ListStreams_FullMethodName               = "/stream.StreamService/ListStreams"
+	StreamService_ListDeletedStreams_FullMethodName        = "/stream.StreamService/ListDeletedStreams"
+	StreamService_RestoreStream_FullMethodName             = "/stream.StreamService/RestoreStream"
+	StreamService_PurgeStream_FullMethodName               = "/stream.StreamService/PurgeStream"
+	StreamService_StartViewerSession_FullMethodName        = "/stream.StreamService/StartViewerSession"
+	StreamService_EndViewerSession_FullMethodName          = "/stream.StreamService/EndViewerSession"
+	StreamService_GetStreamAnalytics_FullMethodName        = "/stream.StreamService/GetStreamAnalytics"
+	StreamService_AddCollaborator_FullMethodName           = "/stream.StreamService/AddCollaborator"
+	StreamService_RemoveCollaborator_FullMethodName        = "/stream.StreamService/RemoveCollaborator"
+	StreamService_ListCollaborators_FullMethodName         = "/stream.StreamService/ListCollaborators"
+	StreamService_IssuePlaybackToken_FullMethodName        = "/stream.StreamService/IssuePlaybackToken"
+	StreamService_ReportStreamTelemetry_FullMethodName     = "/stream.StreamService/ReportStreamTelemetry"
+	StreamService_GetStreamHealth_FullMethodName           = "/stream.StreamService/GetStreamHealth"
+	StreamService_ListRenditionPresets_FullMethodName      = "/stream.StreamService/ListRenditionPresets"
+	StreamService_SetStreamRenditions_FullMethodName       = "/stream.StreamService/SetStreamRenditions"
+	StreamService_GetMasterPlaylist_FullMethodName         = "/stream.StreamService/GetMasterPlaylist"
+	StreamService_CreateClip_FullMethodName                = "/stream.StreamService/CreateClip"
+	StreamService_GetClip_FullMethodName                   = "/stream.StreamService/GetClip"
+	StreamService_ListClips_FullMethodName                 = "/stream.StreamService/ListClips"
+	StreamService_DeleteClip_FullMethodName                = "/stream.StreamService/DeleteClip"
+	StreamService_GetClipPlaylist_FullMethodName           = "/stream.StreamService/GetClipPlaylist"
+	StreamService_AddRestreamDestination_FullMethodName    = "/stream.StreamService/AddRestreamDestination"
+	StreamService_UpdateRestreamDestination_FullMethodName = "/stream.StreamService/UpdateRestreamDestination"
+	StreamService_RemoveRestreamDestination_FullMethodName = "/stream.StreamService/RemoveRestreamDestination"
+	StreamService_ListRestreamDestinations_FullMethodName  = "/stream.StreamService/ListRestreamDestinations"
+	StreamService_QueryAuditLog_FullMethodName             = "/stream.StreamService/QueryAuditLog"
 )
 
 // StreamServiceClient is the client API for StreamService service.
@@ -36,6 +61,47 @@ type StreamServiceClient interface {
 	UpdateStream(ctx context.Context, in *UpdateStreamRequest, opts ...grpc.CallOption) (*StreamResponse, error)
 	DeleteStream(ctx context.Context, in *DeleteStreamRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListStreams(ctx context.Context, in *ListStreamsRequest, opts ...grpc.CallOption) (*ListStreamsResponse, error)
+	// Deleted streams stay in the trash of the database service until they
+	// are restored or purged
+	ListDeletedStreams(ctx context.Context, in *ListDeletedStreamsRequest, opts ...grpc.CallOption) (*ListStreamsResponse, error)
+	RestoreStream(ctx context.Context, in *RestoreStreamRequest, opts ...grpc.CallOption) (*StreamResponse, error)
+	PurgeStream(ctx context.Context, in *PurgeStreamRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Viewer sessions and analytics are kept by stream-service itself and
+	// are not forwarded to the database service
+	StartViewerSession(ctx context.Context, in *StartViewerSessionRequest, opts ...grpc.CallOption) (*ViewerSession, error)
+	EndViewerSession(ctx context.Context, in *EndViewerSessionRequest, opts ...grpc.CallOption) (*ViewerSession, error)
+	GetStreamAnalytics(ctx context.Context, in *GetStreamAnalyticsRequest, opts ...grpc.CallOption) (*StreamAnalytics, error)
+	// Collaborators are kept by stream-service itself
+	AddCollaborator(ctx context.Context, in *AddCollaboratorRequest, opts ...grpc.CallOption) (*Collaborator, error)
+	RemoveCollaborator(ctx context.Context, in *RemoveCollaboratorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error)
+	// Playback tokens are signed by stream-service and verified offline by
+	// the playback endpoints and the distribution server
+	IssuePlaybackToken(ctx context.Context, in *IssuePlaybackTokenRequest, opts ...grpc.CallOption) (*PlaybackToken, error)
+	// Encoder telemetry is kept for a few minutes to judge the health of
+	// live streams
+	ReportStreamTelemetry(ctx context.Context, in *StreamTelemetry, opts ...grpc.CallOption) (*StreamHealth, error)
+	GetStreamHealth(ctx context.Context, in *GetStreamHealthRequest, opts ...grpc.CallOption) (*StreamHealth, error)
+	// Adaptive bitrate ladders come from named presets or are set per stream
+	ListRenditionPresets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListRenditionPresetsResponse, error)
+	SetStreamRenditions(ctx context.Context, in *SetStreamRenditionsRequest, opts ...grpc.CallOption) (*StreamResponse, error)
+	GetMasterPlaylist(ctx context.Context, in *GetMasterPlaylistRequest, opts ...grpc.CallOption) (*MasterPlaylist, error)
+	// Clips are ranges of a broadcast cut by viewers and kept by
+	// stream-service; their segments are served by the packager
+	CreateClip(ctx context.Context, in *CreateClipRequest, opts ...grpc.CallOption) (*Clip, error)
+	GetClip(ctx context.Context, in *GetClipRequest, opts ...grpc.CallOption) (*Clip, error)
+	ListClips(ctx context.Context, in *ListClipsRequest, opts ...grpc.CallOption) (*ListClipsResponse, error)
+	DeleteClip(ctx context.Context, in *DeleteClipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetClipPlaylist(ctx context.Context, in *GetClipPlaylistRequest, opts ...grpc.CallOption) (*ClipPlaylist, error)
+	// Restream destinations are kept by stream-service, which relays live
+	// streams to them
+	AddRestreamDestination(ctx context.Context, in *AddRestreamDestinationRequest, opts ...grpc.CallOption) (*RestreamDestination, error)
+	UpdateRestreamDestination(ctx context.Context, in *UpdateRestreamDestinationRequest, opts ...grpc.CallOption) (*RestreamDestination, error)
+	RemoveRestreamDestination(ctx context.Context, in *RemoveRestreamDestinationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListRestreamDestinations(ctx context.Context, in *ListRestreamDestinationsRequest, opts ...grpc.CallOption) (*ListRestreamDestinationsResponse, error)
+	// Every create, update and delete made through this API is recorded in
+	// the audit log of stream-service
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
 }
 
 type streamServiceClient struct {
@@ -96,6 +162,256 @@ func (c *streamServiceClient) ListStreams(ctx context.Context, in *ListStreamsRe
 	return out, nil
 }
 
+func (c *streamServiceClient) ListDeletedStreams(ctx context.Context, in *ListDeletedStreamsRequest, opts ...grpc.CallOption) (*ListStreamsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStreamsResponse)
+	err := c.cc.Invoke(ctx, StreamService_ListDeletedStreams_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) RestoreStream(ctx context.Context, in *RestoreStreamRequest, opts ...grpc.CallOption) (*StreamResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StreamResponse)
+	err := c.cc.Invoke(ctx, StreamService_RestoreStream_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) PurgeStream(ctx context.Context, in *PurgeStreamRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StreamService_PurgeStream_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) StartViewerSession(ctx context.Context, in *StartViewerSessionRequest, opts ...grpc.CallOption) (*ViewerSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ViewerSession)
+	err := c.cc.Invoke(ctx, StreamService_StartViewerSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) EndViewerSession(ctx context.Context, in *EndViewerSessionRequest, opts ...grpc.CallOption) (*ViewerSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ViewerSession)
+	err := c.cc.Invoke(ctx, StreamService_EndViewerSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) GetStreamAnalytics(ctx context.Context, in *GetStreamAnalyticsRequest, opts ...grpc.CallOption) (*StreamAnalytics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StreamAnalytics)
+	err := c.cc.Invoke(ctx, StreamService_GetStreamAnalytics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) AddCollaborator(ctx context.Context, in *AddCollaboratorRequest, opts ...grpc.CallOption) (*Collaborator, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collaborator)
+	err := c.cc.Invoke(ctx, StreamService_AddCollaborator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) RemoveCollaborator(ctx context.Context, in *RemoveCollaboratorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StreamService_RemoveCollaborator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollaboratorsResponse)
+	err := c.cc.Invoke(ctx, StreamService_ListCollaborators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) IssuePlaybackToken(ctx context.Context, in *IssuePlaybackTokenRequest, opts ...grpc.CallOption) (*PlaybackToken, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaybackToken)
+	err := c.cc.Invoke(ctx, StreamService_IssuePlaybackToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) ReportStreamTelemetry(ctx context.Context, in *StreamTelemetry, opts ...grpc.CallOption) (*StreamHealth, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StreamHealth)
+	err := c.cc.Invoke(ctx, StreamService_ReportStreamTelemetry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) GetStreamHealth(ctx context.Context, in *GetStreamHealthRequest, opts ...grpc.CallOption) (*StreamHealth, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StreamHealth)
+	err := c.cc.Invoke(ctx, StreamService_GetStreamHealth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) ListRenditionPresets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListRenditionPresetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRenditionPresetsResponse)
+	err := c.cc.Invoke(ctx, StreamService_ListRenditionPresets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) SetStreamRenditions(ctx context.Context, in *SetStreamRenditionsRequest, opts ...grpc.CallOption) (*StreamResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StreamResponse)
+	err := c.cc.Invoke(ctx, StreamService_SetStreamRenditions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) GetMasterPlaylist(ctx context.Context, in *GetMasterPlaylistRequest, opts ...grpc.CallOption) (*MasterPlaylist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MasterPlaylist)
+	err := c.cc.Invoke(ctx, StreamService_GetMasterPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) CreateClip(ctx context.Context, in *CreateClipRequest, opts ...grpc.CallOption) (*Clip, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Clip)
+	err := c.cc.Invoke(ctx, StreamService_CreateClip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) GetClip(ctx context.Context, in *GetClipRequest, opts ...grpc.CallOption) (*Clip, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Clip)
+	err := c.cc.Invoke(ctx, StreamService_GetClip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) ListClips(ctx context.Context, in *ListClipsRequest, opts ...grpc.CallOption) (*ListClipsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClipsResponse)
+	err := c.cc.Invoke(ctx, StreamService_ListClips_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) DeleteClip(ctx context.Context, in *DeleteClipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StreamService_DeleteClip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) GetClipPlaylist(ctx context.Context, in *GetClipPlaylistRequest, opts ...grpc.CallOption) (*ClipPlaylist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClipPlaylist)
+	err := c.cc.Invoke(ctx, StreamService_GetClipPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) AddRestreamDestination(ctx context.Context, in *AddRestreamDestinationRequest, opts ...grpc.CallOption) (*RestreamDestination, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestreamDestination)
+	err := c.cc.Invoke(ctx, StreamService_AddRestreamDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) UpdateRestreamDestination(ctx context.Context, in *UpdateRestreamDestinationRequest, opts ...grpc.CallOption) (*RestreamDestination, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestreamDestination)
+	err := c.cc.Invoke(ctx, StreamService_UpdateRestreamDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) RemoveRestreamDestination(ctx context.Context, in *RemoveRestreamDestinationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StreamService_RemoveRestreamDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) ListRestreamDestinations(ctx context.Context, in *ListRestreamDestinationsRequest, opts ...grpc.CallOption) (*ListRestreamDestinationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRestreamDestinationsResponse)
+	err := c.cc.Invoke(ctx, StreamService_ListRestreamDestinations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, StreamService_QueryAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StreamServiceServer is the server API for StreamService service.
 // All implementations must embed UnimplementedStreamServiceServer
 // for forward compatibility.
@@ -105,6 +421,47 @@ type StreamServiceServer interface {
 	UpdateStream(context.Context, *UpdateStreamRequest) (*StreamResponse, error)
 	DeleteStream(context.Context, *DeleteStreamRequest) (*emptypb.Empty, error)
 	ListStreams(context.Context, *ListStreamsRequest) (*ListStreamsResponse, error)
+	// Deleted streams stay in the trash of the database service until they
+	// are restored or purged
+	ListDeletedStreams(context.Context, *ListDeletedStreamsRequest) (*ListStreamsResponse, error)
+	RestoreStream(context.Context, *RestoreStreamRequest) (*StreamResponse, error)
+	PurgeStream(context.Context, *PurgeStreamRequest) (*emptypb.Empty, error)
+	// Viewer sessions and analytics are kept by stream-service itself and
+	// are not forwarded to the database service
+	StartViewerSession(context.Context, *StartViewerSessionRequest) (*ViewerSession, error)
+	EndViewerSession(context.Context, *EndViewerSessionRequest) (*ViewerSession, error)
+	GetStreamAnalytics(context.Context, *GetStreamAnalyticsRequest) (*StreamAnalytics, error)
+	// Collaborators are kept by stream-service itself
+	AddCollaborator(context.Context, *AddCollaboratorRequest) (*Collaborator, error)
+	RemoveCollaborator(context.Context, *RemoveCollaboratorRequest) (*emptypb.Empty, error)
+	ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error)
+	// Playback tokens are signed by stream-service and verified offline by
+	// the playback endpoints and the distribution server
+	IssuePlaybackToken(context.Context, *IssuePlaybackTokenRequest) (*PlaybackToken, error)
+	// Encoder telemetry is kept for a few minutes to judge the health of
+	// live streams
+	ReportStreamTelemetry(context.Context, *StreamTelemetry) (*StreamHealth, error)
+	GetStreamHealth(context.Context, *GetStreamHealthRequest) (*StreamHealth, error)
+	// Adaptive bitrate ladders come from named presets or are set per stream
+	ListRenditionPresets(context.Context, *emptypb.Empty) (*ListRenditionPresetsResponse, error)
+	SetStreamRenditions(context.Context, *SetStreamRenditionsRequest) (*StreamResponse, error)
+	GetMasterPlaylist(context.Context, *GetMasterPlaylistRequest) (*MasterPlaylist, error)
+	// Clips are ranges of a broadcast cut by viewers and kept by
+	// stream-service; their segments are served by the packager
+	CreateClip(context.Context, *CreateClipRequest) (*Clip, error)
+	GetClip(context.Context, *GetClipRequest) (*Clip, error)
+	ListClips(context.Context, *ListClipsRequest) (*ListClipsResponse, error)
+	DeleteClip(context.Context, *DeleteClipRequest) (*emptypb.Empty, error)
+	GetClipPlaylist(context.Context, *GetClipPlaylistRequest) (*ClipPlaylist, error)
+	// Restream destinations are kept by stream-service, which relays live
+	// streams to them
+	AddRestreamDestination(context.Context, *AddRestreamDestinationRequest) (*RestreamDestination, error)
+	UpdateRestreamDestination(context.Context, *UpdateRestreamDestinationRequest) (*RestreamDestination, error)
+	RemoveRestreamDestination(context.Context, *RemoveRestreamDestinationRequest) (*emptypb.Empty, error)
+	ListRestreamDestinations(context.Context, *ListRestreamDestinationsRequest) (*ListRestreamDestinationsResponse, error)
+	// Every create, update and delete made through this API is recorded in
+	// the audit log of stream-service
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
 	mustEmbedUnimplementedStreamServiceServer()
 }
 
@@ -130,6 +487,81 @@ func (UnimplementedStreamServiceServer) DeleteStream(context.Context, *DeleteStr
 func (UnimplementedStreamServiceServer) ListStreams(context.Context, *ListStreamsRequest) (*ListStreamsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListStreams not implemented")
 }
+func (UnimplementedStreamServiceServer) ListDeletedStreams(context.Context, *ListDeletedStreamsRequest) (*ListStreamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedStreams not implemented")
+}
+func (UnimplementedStreamServiceServer) RestoreStream(context.Context, *RestoreStreamRequest) (*StreamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreStream not implemented")
+}
+func (UnimplementedStreamServiceServer) PurgeStream(context.Context, *PurgeStreamRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeStream not implemented")
+}
+func (UnimplementedStreamServiceServer) StartViewerSession(context.Context, *StartViewerSessionRequest) (*ViewerSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartViewerSession not implemented")
+}
+func (UnimplementedStreamServiceServer) EndViewerSession(context.Context, *EndViewerSessionRequest) (*ViewerSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndViewerSession not implemented")
+}
+func (UnimplementedStreamServiceServer) GetStreamAnalytics(context.Context, *GetStreamAnalyticsRequest) (*StreamAnalytics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStreamAnalytics not implemented")
+}
+func (UnimplementedStreamServiceServer) AddCollaborator(context.Context, *AddCollaboratorRequest) (*Collaborator, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCollaborator not implemented")
+}
+func (UnimplementedStreamServiceServer) RemoveCollaborator(context.Context, *RemoveCollaboratorRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCollaborator not implemented")
+}
+func (UnimplementedStreamServiceServer) ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollaborators not implemented")
+}
+func (UnimplementedStreamServiceServer) IssuePlaybackToken(context.Context, *IssuePlaybackTokenRequest) (*PlaybackToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssuePlaybackToken not implemented")
+}
+func (UnimplementedStreamServiceServer) ReportStreamTelemetry(context.Context, *StreamTelemetry) (*StreamHealth, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportStreamTelemetry not implemented")
+}
+func (UnimplementedStreamServiceServer) GetStreamHealth(context.Context, *GetStreamHealthRequest) (*StreamHealth, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStreamHealth not implemented")
+}
+func (UnimplementedStreamServiceServer) ListRenditionPresets(context.Context, *emptypb.Empty) (*ListRenditionPresetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRenditionPresets not implemented")
+}
+func (UnimplementedStreamServiceServer) SetStreamRenditions(context.Context, *SetStreamRenditionsRequest) (*StreamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStreamRenditions not implemented")
+}
+func (UnimplementedStreamServiceServer) GetMasterPlaylist(context.Context, *GetMasterPlaylistRequest) (*MasterPlaylist, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMasterPlaylist not implemented")
+}
+func (UnimplementedStreamServiceServer) CreateClip(context.Context, *CreateClipRequest) (*Clip, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateClip not implemented")
+}
+func (UnimplementedStreamServiceServer) GetClip(context.Context, *GetClipRequest) (*Clip, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClip not implemented")
+}
+func (UnimplementedStreamServiceServer) ListClips(context.Context, *ListClipsRequest) (*ListClipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClips not implemented")
+}
+func (UnimplementedStreamServiceServer) DeleteClip(context.Context, *DeleteClipRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClip not implemented")
+}
+func (UnimplementedStreamServiceServer) GetClipPlaylist(context.Context, *GetClipPlaylistRequest) (*ClipPlaylist, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClipPlaylist not implemented")
+}
+func (UnimplementedStreamServiceServer) AddRestreamDestination(context.Context, *AddRestreamDestinationRequest) (*RestreamDestination, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRestreamDestination not implemented")
+}
+func (UnimplementedStreamServiceServer) UpdateRestreamDestination(context.Context, *UpdateRestreamDestinationRequest) (*RestreamDestination, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRestreamDestination not implemented")
+}
+func (UnimplementedStreamServiceServer) RemoveRestreamDestination(context.Context, *RemoveRestreamDestinationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRestreamDestination not implemented")
+}
+func (UnimplementedStreamServiceServer) ListRestreamDestinations(context.Context, *ListRestreamDestinationsRequest) (*ListRestreamDestinationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRestreamDestinations not implemented")
+}
+func (UnimplementedStreamServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
 func (UnimplementedStreamServiceServer) mustEmbedUnimplementedStreamServiceServer() {}
 func (UnimplementedStreamServiceServer) testEmbeddedByValue()                       {}
 
@@ -241,32 +673,582 @@ func _StreamService_ListStreams_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
-// StreamService_ServiceDesc is the grpc.ServiceDesc for StreamService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var StreamService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "stream.StreamService",
-	HandlerType: (*StreamServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateStream",
-			Handler:    _StreamService_CreateStream_Handler,
-		},
-		{
-			MethodName: "GetStream",
-			Handler:    _StreamService_GetStream_Handler,
-		},
-		{
-			MethodName: "UpdateStream",
-			Handler:    _StreamService_UpdateStream_Handler,
-		},
-		{
-			MethodName: "DeleteStream",
-			Handler:    _StreamService_DeleteStream_Handler,
-		},
-		{
-			MethodName: "ListStreams",
-			Handler:    _StreamService_ListStreams_Handler,
+func _StreamService_ListDeletedStreams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedStreamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).ListDeletedStreams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_ListDeletedStreams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).ListDeletedStreams(ctx, req.(*ListDeletedStreamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_RestoreStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreStreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).RestoreStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_RestoreStream_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).RestoreStream(ctx, req.(*RestoreStreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_PurgeStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeStreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).PurgeStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_PurgeStream_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).PurgeStream(ctx, req.(*PurgeStreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_StartViewerSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartViewerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).StartViewerSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_StartViewerSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).StartViewerSession(ctx, req.(*StartViewerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_EndViewerSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndViewerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).EndViewerSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_EndViewerSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).EndViewerSession(ctx, req.(*EndViewerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_GetStreamAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreamAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).GetStreamAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_GetStreamAnalytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).GetStreamAnalytics(ctx, req.(*GetStreamAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_AddCollaborator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCollaboratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).AddCollaborator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_AddCollaborator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).AddCollaborator(ctx, req.(*AddCollaboratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_RemoveCollaborator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCollaboratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).RemoveCollaborator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_RemoveCollaborator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).RemoveCollaborator(ctx, req.(*RemoveCollaboratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_ListCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).ListCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_ListCollaborators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).ListCollaborators(ctx, req.(*ListCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_IssuePlaybackToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssuePlaybackTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).IssuePlaybackToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_IssuePlaybackToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).IssuePlaybackToken(ctx, req.(*IssuePlaybackTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_ReportStreamTelemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StreamTelemetry)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).ReportStreamTelemetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_ReportStreamTelemetry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).ReportStreamTelemetry(ctx, req.(*StreamTelemetry))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_GetStreamHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreamHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).GetStreamHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_GetStreamHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).GetStreamHealth(ctx, req.(*GetStreamHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_ListRenditionPresets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).ListRenditionPresets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_ListRenditionPresets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).ListRenditionPresets(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_SetStreamRenditions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStreamRenditionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).SetStreamRenditions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_SetStreamRenditions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).SetStreamRenditions(ctx, req.(*SetStreamRenditionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_GetMasterPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMasterPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).GetMasterPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_GetMasterPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).GetMasterPlaylist(ctx, req.(*GetMasterPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_CreateClip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateClipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).CreateClip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_CreateClip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).CreateClip(ctx, req.(*CreateClipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_GetClip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).GetClip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_GetClip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).GetClip(ctx, req.(*GetClipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_ListClips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).ListClips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_ListClips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).ListClips(ctx, req.(*ListClipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_DeleteClip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteClipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).DeleteClip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_DeleteClip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).DeleteClip(ctx, req.(*DeleteClipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_GetClipPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClipPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).GetClipPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_GetClipPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).GetClipPlaylist(ctx, req.(*GetClipPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_AddRestreamDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRestreamDestinationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).AddRestreamDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_AddRestreamDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).AddRestreamDestination(ctx, req.(*AddRestreamDestinationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_UpdateRestreamDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRestreamDestinationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).UpdateRestreamDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_UpdateRestreamDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).UpdateRestreamDestination(ctx, req.(*UpdateRestreamDestinationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_RemoveRestreamDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRestreamDestinationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).RemoveRestreamDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_RemoveRestreamDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).RemoveRestreamDestination(ctx, req.(*RemoveRestreamDestinationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_ListRestreamDestinations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRestreamDestinationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).ListRestreamDestinations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_ListRestreamDestinations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).ListRestreamDestinations(ctx, req.(*ListRestreamDestinationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamServiceServer).QueryAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamService_QueryAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StreamService_ServiceDesc is the grpc.ServiceDesc for StreamService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StreamService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stream.StreamService",
+	HandlerType: (*StreamServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateStream",
+			Handler:    _StreamService_CreateStream_Handler,
+		},
+		{
+			MethodName: "GetStream",
+			Handler:    _StreamService_GetStream_Handler,
+		},
+		{
+			MethodName: "UpdateStream",
+			Handler:    _StreamService_UpdateStream_Handler,
+		},
+		{
+			MethodName: "DeleteStream",
+			Handler:    _StreamService_DeleteStream_Handler,
+		},
+		{
+			MethodName: "ListStreams",
+			Handler:    _StreamService_ListStreams_Handler,
+		},
+		{
+			MethodName: "ListDeletedStreams",
+			Handler:    _StreamService_ListDeletedStreams_Handler,
+		},
+		{
+			MethodName: "RestoreStream",
+			Handler:    _StreamService_RestoreStream_Handler,
+		},
+		{
+			MethodName: "PurgeStream",
+			Handler:    _StreamService_PurgeStream_Handler,
+		},
+		{
+			MethodName: "StartViewerSession",
+			Handler:    _StreamService_StartViewerSession_Handler,
+		},
+		{
+			MethodName: "EndViewerSession",
+			Handler:    _StreamService_EndViewerSession_Handler,
+		},
+		{
+			MethodName: "GetStreamAnalytics",
+			Handler:    _StreamService_GetStreamAnalytics_Handler,
+		},
+		{
+			MethodName: "AddCollaborator",
+			Handler:    _StreamService_AddCollaborator_Handler,
+		},
+		{
+			MethodName: "RemoveCollaborator",
+			Handler:    _StreamService_RemoveCollaborator_Handler,
+		},
+		{
+			MethodName: "ListCollaborators",
+			Handler:    _StreamService_ListCollaborators_Handler,
+		},
+		{
+			MethodName: "IssuePlaybackToken",
+			Handler:    _StreamService_IssuePlaybackToken_Handler,
+		},
+		{
+			MethodName: "ReportStreamTelemetry",
+			Handler:    _StreamService_ReportStreamTelemetry_Handler,
+		},
+		{
+			MethodName: "GetStreamHealth",
+			Handler:    _StreamService_GetStreamHealth_Handler,
+		},
+		{
+			MethodName: "ListRenditionPresets",
+			Handler:    _StreamService_ListRenditionPresets_Handler,
+		},
+		{
+			MethodName: "SetStreamRenditions",
+			Handler:    _StreamService_SetStreamRenditions_Handler,
+		},
+		{
+			MethodName: "GetMasterPlaylist",
+			Handler:    _StreamService_GetMasterPlaylist_Handler,
+		},
+		{
+			MethodName: "CreateClip",
+			Handler:    _StreamService_CreateClip_Handler,
+		},
+		{
+			MethodName: "GetClip",
+			Handler:    _StreamService_GetClip_Handler,
+		},
+		{
+			MethodName: "ListClips",
+			Handler:    _StreamService_ListClips_Handler,
+		},
+		{
+			MethodName: "DeleteClip",
+			Handler:    _StreamService_DeleteClip_Handler,
+		},
+		{
+			MethodName: "GetClipPlaylist",
+			Handler:    _StreamService_GetClipPlaylist_Handler,
+		},
+		{
+			MethodName: "AddRestreamDestination",
+			Handler:    _StreamService_AddRestreamDestination_Handler,
+		},
+		{
+			MethodName: "UpdateRestreamDestination",
+			Handler:    _StreamService_UpdateRestreamDestination_Handler,
+		},
+		{
+			MethodName: "RemoveRestreamDestination",
+			Handler:    _StreamService_RemoveRestreamDestination_Handler,
+		},
+		{
+			MethodName: "ListRestreamDestinations",
+			Handler:    _StreamService_ListRestreamDestinations_Handler,
+		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _StreamService_QueryAuditLog_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,150 @@
+// Package purge removes deleted streams for good once they have been in the
+// trash for the retention period, together with their media on disk
+package purge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// StreamIDPlaceholder is replaced by the id of the stream in media paths
+const StreamIDPlaceholder = "{stream_id}"
+
+// Config controls the retention of deleted streams
+type Config struct {
+	// Retention is how long deleted streams can be restored. Zero disables
+	// the scheduled purge; streams are then only purged on request.
+	Retention time.Duration
+	// Interval is how often the trash is checked for expired streams
+	Interval time.Duration
+	// MediaPaths are the files or directories of a stream, such as its
+	// recordings and thumbnails, with StreamIDPlaceholder in each
+	MediaPaths []string
+}
+
+// PurgedFunc is called after a stream was purged, to drop what is kept about
+// it besides the stream, such as its collaborators and clips. These are left
+// alone while the stream is in the trash so that a restore brings them back.
+type PurgedFunc func(ctx context.Context, streamID int32) error
+
+// Client is the part of the database service used to purge streams
+type Client interface {
+	PurgeStream(ctx context.Context, req *proto.PurgeStreamRequest) (*emptypb.Empty, error)
+	ListDeletedStreams(ctx context.Context, req *proto.ListDeletedStreamsRequest) (*proto.ListStreamsResponse, error)
+}
+
+// Service purges deleted streams from the database service and their media
+type Service struct {
+	cfg      Config
+	client   Client
+	onPurged []PurgedFunc
+	now      func() time.Time
+}
+
+// NewService returns a service purging streams through client, then calling
+// onPurged in order
+func NewService(cfg Config, client Client, onPurged ...PurgedFunc) *Service {
+	return &Service{
+		cfg:      cfg,
+		client:   client,
+		onPurged: onPurged,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Purge removes a deleted stream from the database service, then its media
+// and what goes with it. The stream is gone once the database service
+// agrees, so media and data that cannot be removed are logged rather than
+// reported.
+func (s *Service) Purge(ctx context.Context, streamID int32) error {
+	if _, err := s.client.PurgeStream(ctx, &proto.PurgeStreamRequest{Id: streamID}); err != nil {
+		return err
+	}
+	s.removeMedia(ctx, streamID)
+	for _, purged := range s.onPurged {
+		if err := purged(ctx, streamID); err != nil {
+			logging.FromContext(ctx).Warn("Failed to remove data of purged stream", "stream_id", streamID, "error", err)
+		}
+	}
+	return nil
+}
+
+// Run purges expired streams every interval until ctx is cancelled
+func (s *Service) Run(ctx context.Context) {
+	if s.cfg.Retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.Sweep(ctx)
+			if err != nil && ctx.Err() == nil {
+				slog.Error("Failed to purge deleted streams", "purged", purged, "error", err)
+			} else if purged > 0 {
+				slog.Info("Purged deleted streams", "purged", purged)
+			}
+		}
+	}
+}
+
+// Sweep purges every stream deleted more than the retention period ago and
+// returns how many were purged. Purged streams leave the first page, so it
+// is listed again until it only holds streams that failed to purge.
+func (s *Service) Sweep(ctx context.Context) (int, error) {
+	cutoff := s.now().Add(-s.cfg.Retention).Format(models.TimeFormat)
+
+	purged := 0
+	var errs []error
+	for {
+		page, err := s.client.ListDeletedStreams(ctx, &proto.ListDeletedStreamsRequest{
+			PageNumber:    1,
+			DeletedBefore: cutoff,
+		})
+		if err != nil {
+			return purged, errors.Join(append(errs, err)...)
+		}
+
+		failed := 0
+		for _, stream := range page.Streams {
+			if err := s.Purge(ctx, stream.Id); err != nil {
+				errs = append(errs, fmt.Errorf("stream %d: %w", stream.Id, err))
+				failed++
+				continue
+			}
+			purged++
+		}
+		// The same page would come back, so leave the rest to the next sweep
+		if failed == len(page.Streams) {
+			return purged, errors.Join(errs...)
+		}
+	}
+}
+
+// removeMedia deletes the media paths of a stream
+func (s *Service) removeMedia(ctx context.Context, streamID int32) {
+	logger := logging.FromContext(ctx)
+	id := strconv.Itoa(int(streamID))
+	for _, template := range s.cfg.MediaPaths {
+		path := strings.ReplaceAll(template, StreamIDPlaceholder, id)
+		if err := os.RemoveAll(path); err != nil {
+			logger.Warn("Failed to remove media of purged stream", "stream_id", streamID, "path", path, "error", err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package renditions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// audioBitrate is the bitrate in kbps of the audio track muxed into every
+// rendition
+const audioBitrate = 128
+
+// MasterPlaylist renders the HLS master playlist of a ladder. uri returns
+// the address of the media playlist of each rendition.
+func MasterPlaylist(ladder Ladder, uri func(Rendition) string) string {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-INDEPENDENT-SEGMENTS\n")
+	for _, r := range ladder.Renditions {
+		// BANDWIDTH is the peak rate in bits per second; encoders are
+		// allowed to overshoot the target bitrate by a tenth
+		average := (r.Bitrate + audioBitrate) * 1000
+		fmt.Fprintf(&b, "#EXT-X-STREAM-INF:BANDWIDTH=%d,AVERAGE-BANDWIDTH=%d,RESOLUTION=%dx%d,FRAME-RATE=%d.000\n",
+			average*11/10, average, r.Width, r.Height, r.Framerate)
+		b.WriteString(uri(r))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,245 @@
+package renditions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/streamdb-api/streamdb"
+)
+
+var (
+	// ErrUnknownPreset is returned for preset names that are not configured
+	ErrUnknownPreset = errors.New("unknown rendition preset")
+	// ErrExceedsSource is wrapped by the errors of renditions the source of
+	// a stream cannot feed
+	ErrExceedsSource = errors.New("rendition exceeds the source")
+)
+
+// BuiltinPresets are available unless configuration replaces them
+var BuiltinPresets = map[string]string{
+	"standard":    "1080p30/720p30/480p30/360p30",
+	"high-motion": "1080p60/720p60/720p30/480p30",
+	"mobile":      "720p30/480p30/360p30",
+}
+
+// CustomPreset is the preset name reported for streams with their own ladder
+const CustomPreset = "custom"
+
+// Preset is a named ladder
+type Preset struct {
+	Name       string
+	Renditions []Rendition
+}
+
+// Ladder is the renditions a stream is transcoded to
+type Ladder struct {
+	// Preset is the preset the ladder comes from, or CustomPreset
+	Preset     string
+	Renditions []Rendition
+}
+
+// Ladders holds the presets, and the ladders chosen for individual streams
+// through the database service
+type Ladders struct {
+	presets       map[string][]Rendition
+	defaultPreset string
+	// client stores the ladders of streams not using the default preset
+	client streamdb.RenditionServiceClient
+}
+
+// NewLadders parses presets, given as ladders of specs separated by
+// slashes such as "1080p60/720p30/480p", on top of BuiltinPresets.
+// defaultPreset applies to streams without a ladder of their own.
+func NewLadders(presets map[string]string, defaultPreset string, client streamdb.RenditionServiceClient) (*Ladders, error) {
+	l := &Ladders{
+		presets:       make(map[string][]Rendition),
+		defaultPreset: defaultPreset,
+		client:        client,
+	}
+	for _, specs := range []map[string]string{BuiltinPresets, presets} {
+		for name, ladder := range specs {
+			if name == CustomPreset {
+				return nil, fmt.Errorf("preset name %q is reserved", CustomPreset)
+			}
+			renditions, err := ParseLadder(strings.Split(ladder, "/"))
+			if err != nil {
+				return nil, fmt.Errorf("preset %s: %w", name, err)
+			}
+			l.presets[name] = renditions
+		}
+	}
+	if _, ok := l.presets[defaultPreset]; !ok {
+		return nil, fmt.Errorf("%w: default preset %q", ErrUnknownPreset, defaultPreset)
+	}
+	return l, nil
+}
+
+// DefaultPreset returns the name of the preset of streams without a ladder
+// of their own
+func (l *Ladders) DefaultPreset() string {
+	return l.defaultPreset
+}
+
+// Presets returns the configured presets sorted by name
+func (l *Ladders) Presets() []Preset {
+	presets := make([]Preset, 0, len(l.presets))
+	for name, renditions := range l.presets {
+		presets = append(presets, Preset{Name: name, Renditions: slices.Clone(renditions)})
+	}
+	slices.SortFunc(presets, func(a, b Preset) int { return strings.Compare(a.Name, b.Name) })
+	return presets
+}
+
+// Set chooses the ladder of a stream: a preset, or custom renditions given
+// as specs. Every rendition must be within the source. Setting neither
+// returns the stream to the default preset.
+func (l *Ladders) Set(ctx context.Context, streamID int32, source Source, preset string, specs []string) (Ladder, error) {
+	var ladder Ladder
+	switch {
+	case preset != "" && len(specs) > 0:
+		return Ladder{}, fmt.Errorf("%w: set either a preset or renditions", ErrInvalidRendition)
+	case preset != "":
+		renditions, ok := l.presets[preset]
+		if !ok {
+			return Ladder{}, fmt.Errorf("%w %q", ErrUnknownPreset, preset)
+		}
+		ladder = Ladder{Preset: preset, Renditions: renditions}
+	case len(specs) > 0:
+		renditions, err := ParseLadder(specs)
+		if err != nil {
+			return Ladder{}, err
+		}
+		ladder = Ladder{Preset: CustomPreset, Renditions: renditions}
+	default:
+		if err := l.Remove(ctx, streamID); err != nil {
+			return Ladder{}, err
+		}
+		return l.resolve(l.defaultLadder(), source), nil
+	}
+
+	// presets adapt to smaller sources, custom ladders are taken literally
+	if ladder.Preset == CustomPreset {
+		for _, r := range ladder.Renditions {
+			if err := source.check(r); err != nil {
+				return Ladder{}, fmt.Errorf("%w: %v", ErrExceedsSource, err)
+			}
+		}
+	} else if len(fit(ladder.Renditions, source)) == 0 {
+		return Ladder{}, fmt.Errorf("%w: no rendition of preset %s fits the source", ErrExceedsSource, preset)
+	}
+
+	// presets are stored by name so that they follow the configuration
+	req := &streamdb.SetLadderRequest{StreamId: streamID, Preset: ladder.Preset}
+	if ladder.Preset == CustomPreset {
+		for _, r := range ladder.Renditions {
+			req.Renditions = append(req.Renditions, r.Spec())
+		}
+	}
+	if _, err := l.client.SetLadder(ctx, req); err != nil {
+		return Ladder{}, err
+	}
+
+	return l.resolve(ladder, source), nil
+}
+
+// Ladder returns the ladder of a stream resolved against its source: the
+// renditions the source cannot feed are left out and widths follow the
+// aspect ratio of the source. Renditions are sorted from the highest.
+func (l *Ladders) Ladder(ctx context.Context, streamID int32, source Source) (Ladder, error) {
+	resp, err := l.client.GetLadders(ctx, &streamdb.GetLaddersRequest{StreamIds: []int32{streamID}})
+	if err != nil {
+		return Ladder{}, err
+	}
+
+	ladder := l.defaultLadder()
+	if len(resp.Ladders) > 0 {
+		ladder = l.stored(resp.Ladders[0])
+	}
+	return l.resolve(ladder, source), nil
+}
+
+// LaddersOf returns the ladders of streams by id, resolved like Ladder,
+// with a single call to the database service
+func (l *Ladders) LaddersOf(ctx context.Context, streams []*proto.StreamResponse) (map[int32]Ladder, error) {
+	if len(streams) == 0 {
+		return map[int32]Ladder{}, nil
+	}
+
+	ids := make([]int32, len(streams))
+	for i, stream := range streams {
+		ids[i] = stream.Id
+	}
+	resp, err := l.client.GetLadders(ctx, &streamdb.GetLaddersRequest{StreamIds: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make(map[int32]Ladder, len(resp.Ladders))
+	for _, ladder := range resp.Ladders {
+		stored[ladder.StreamId] = l.stored(ladder)
+	}
+	ladders := make(map[int32]Ladder, len(streams))
+	for _, stream := range streams {
+		ladder, ok := stored[stream.Id]
+		if !ok {
+			ladder = l.defaultLadder()
+		}
+		ladders[stream.Id] = l.resolve(ladder, SourceOf(stream))
+	}
+	return ladders, nil
+}
+
+// Remove returns a stream to the default preset, which is done when it is
+// purged
+func (l *Ladders) Remove(ctx context.Context, streamID int32) error {
+	_, err := l.client.DeleteLadder(ctx, &streamdb.DeleteLadderRequest{StreamId: streamID})
+	return err
+}
+
+func (l *Ladders) defaultLadder() Ladder {
+	return Ladder{Preset: l.defaultPreset, Renditions: l.presets[l.defaultPreset]}
+}
+
+// stored reads a ladder kept by the database service. Presets that are no
+// longer configured fall back to the default preset.
+func (l *Ladders) stored(stored *streamdb.LadderResponse) Ladder {
+	if stored.Preset == CustomPreset {
+		// the specs were checked when they were set
+		if renditions, err := ParseLadder(stored.Renditions); err == nil {
+			return Ladder{Preset: CustomPreset, Renditions: renditions}
+		}
+	} else if renditions, ok := l.presets[stored.Preset]; ok {
+		return Ladder{Preset: stored.Preset, Renditions: renditions}
+	}
+	return l.defaultLadder()
+}
+
+// resolve fits a ladder to source and sorts it from the highest rendition
+func (l *Ladders) resolve(ladder Ladder, source Source) Ladder {
+	renditions := fit(ladder.Renditions, source)
+	for i := range renditions {
+		renditions[i].Width = source.width(renditions[i].Height)
+	}
+	slices.SortStableFunc(renditions, func(a, b Rendition) int {
+		if a.Height != b.Height {
+			return b.Height - a.Height
+		}
+		return b.Framerate - a.Framerate
+	})
+	return Ladder{Preset: ladder.Preset, Renditions: renditions}
+}
+
+// fit returns a copy of the renditions source can feed
+func fit(renditions []Rendition, source Source) []Rendition {
+	var fitting []Rendition
+	for _, r := range renditions {
+		if source.check(r) == nil {
+			fitting = append(fitting, r)
+		}
+	}
+	return fitting
+}
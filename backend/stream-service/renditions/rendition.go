@@ -0,0 +1,174 @@
+// Package renditions builds the adaptive bitrate ladders of streams: the
+// renditions a stream is transcoded to, chosen from named presets or set per
+// stream, and checked against the settings of the source. Per-stream ladders
+// are stored by the database service.
+package renditions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/clementus360/stream-service/proto"
+)
+
+// Rendition is one variant of a stream
+type Rendition struct {
+	// Name identifies the rendition, such as "720p30"
+	Name string
+	// Width is derived from the aspect ratio of the source when the ladder
+	// of a stream is resolved
+	Width     int
+	Height    int
+	Framerate int
+	// Bitrate is the video bitrate in kbps
+	Bitrate int
+}
+
+// ErrInvalidRendition is wrapped by the errors of rendition specs that
+// cannot be parsed
+var ErrInvalidRendition = errors.New("invalid rendition")
+
+// specPattern matches rendition specs such as "1080p60", "480p" or
+// "720p30@2500"
+var specPattern = regexp.MustCompile(`^(\d{3,4})p(\d{2,3})?(?:@(\d+))?$`)
+
+// defaultFramerate is used for specs without a framerate
+const defaultFramerate = 30
+
+// defaultBitrates are the video bitrates in kbps of common heights at up to
+// 30 fps. Renditions with a higher framerate get half as much again.
+var defaultBitrates = map[int]int{
+	2160: 14000,
+	1440: 8000,
+	1080: 4500,
+	720:  2500,
+	480:  1000,
+	360:  700,
+	240:  400,
+}
+
+// ParseRendition parses a spec of the form <height>p[<fps>][@<kbps>]. The
+// bitrate may be omitted for the heights of defaultBitrates.
+func ParseRendition(spec string) (Rendition, error) {
+	m := specPattern.FindStringSubmatch(strings.TrimSpace(spec))
+	if m == nil {
+		return Rendition{}, fmt.Errorf("%w %q: want <height>p[<fps>][@<kbps>], such as 720p30@2500", ErrInvalidRendition, spec)
+	}
+
+	r := Rendition{Framerate: defaultFramerate}
+	r.Height, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		r.Framerate, _ = strconv.Atoi(m[2])
+	}
+	if r.Height < 144 || r.Height > 2160 {
+		return Rendition{}, fmt.Errorf("%w %q: height must be between 144 and 2160", ErrInvalidRendition, spec)
+	}
+	if r.Framerate < 1 || r.Framerate > 120 {
+		return Rendition{}, fmt.Errorf("%w %q: framerate must be between 1 and 120", ErrInvalidRendition, spec)
+	}
+
+	if m[3] != "" {
+		r.Bitrate, _ = strconv.Atoi(m[3])
+	} else if bitrate, ok := defaultBitrates[r.Height]; ok {
+		r.Bitrate = bitrate
+		if r.Framerate > defaultFramerate {
+			r.Bitrate += bitrate / 2
+		}
+	} else {
+		return Rendition{}, fmt.Errorf("%w %q: a bitrate is required for this height, such as %sp%d@1500", ErrInvalidRendition, spec, m[1], r.Framerate)
+	}
+	if r.Bitrate < 100 {
+		return Rendition{}, fmt.Errorf("%w %q: bitrate must be at least 100 kbps", ErrInvalidRendition, spec)
+	}
+
+	r.Name = fmt.Sprintf("%dp%d", r.Height, r.Framerate)
+	return r, nil
+}
+
+// Spec returns the spec the rendition parses from, with its bitrate
+func (r Rendition) Spec() string {
+	return fmt.Sprintf("%s@%d", r.Name, r.Bitrate)
+}
+
+// ParseLadder parses the specs of a ladder. Renditions must have distinct
+// names.
+func ParseLadder(specs []string) ([]Rendition, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("%w: a ladder needs at least one rendition", ErrInvalidRendition)
+	}
+	if len(specs) > maxRenditions {
+		return nil, fmt.Errorf("%w: a ladder has at most %d renditions", ErrInvalidRendition, maxRenditions)
+	}
+
+	ladder := make([]Rendition, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		r, err := ParseRendition(spec)
+		if err != nil {
+			return nil, err
+		}
+		if seen[r.Name] {
+			return nil, fmt.Errorf("%w: %s appears twice", ErrInvalidRendition, r.Name)
+		}
+		seen[r.Name] = true
+		ladder = append(ladder, r)
+	}
+	return ladder, nil
+}
+
+// maxRenditions bounds the work of transcoding a single stream
+const maxRenditions = 8
+
+// Source is the encoding of the stream sent by the broadcaster. Zero values
+// are unknown and not checked.
+type Source struct {
+	Width     int
+	Height    int
+	Framerate float64
+	Bitrate   int
+}
+
+// SourceOf reads the source settings of a stream. Resolutions are either
+// <width>x<height> or <height>p.
+func SourceOf(stream *proto.StreamResponse) Source {
+	var source Source
+	resolution := strings.ToLower(strings.TrimSpace(stream.Resolution))
+	if w, h, ok := strings.Cut(resolution, "x"); ok {
+		source.Width, _ = strconv.Atoi(w)
+		source.Height, _ = strconv.Atoi(h)
+	} else if h, ok := strings.CutSuffix(resolution, "p"); ok {
+		source.Height, _ = strconv.Atoi(h)
+	}
+	source.Width, source.Height = max(source.Width, 0), max(source.Height, 0)
+	source.Framerate, _ = strconv.ParseFloat(stream.Framerate, 64)
+	source.Bitrate, _ = strconv.Atoi(stream.Bitrate)
+	return source
+}
+
+// check returns why r cannot be produced from source, or nil
+func (source Source) check(r Rendition) error {
+	switch {
+	case source.Height > 0 && r.Height > source.Height:
+		return fmt.Errorf("%s is taller than the %dp source", r.Name, source.Height)
+	// allow 29.97 fps sources to feed 30 fps renditions
+	case source.Framerate > 0 && float64(r.Framerate) > source.Framerate+0.5:
+		return fmt.Errorf("%s has a higher framerate than the %g fps source", r.Name, source.Framerate)
+	case source.Bitrate > 0 && r.Bitrate > source.Bitrate:
+		return fmt.Errorf("%s needs %d kbps, more than the %d kbps source", r.Name, r.Bitrate, source.Bitrate)
+	}
+	return nil
+}
+
+// width returns the width of a rendition of height with the aspect ratio of
+// source, or 16:9 when it is unknown. Widths are even, as encoders require.
+func (source Source) width(height int) int {
+	w, h := 16, 9
+	if source.Width > 0 && source.Height > 0 {
+		w, h = source.Width, source.Height
+	}
+	width := (height*w + h/2) / h
+	return width + width%2
+}
@@ -0,0 +1,96 @@
+package restream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Relay copies the feed at source to target until ctx is cancelled or
+// either end fails. live is called once media reaches the target.
+type Relay interface {
+	Run(ctx context.Context, source, target string, live func()) error
+}
+
+// FFmpeg relays feeds by running ffmpeg, which copies the media without
+// transcoding it
+type FFmpeg struct {
+	// Path is the ffmpeg binary
+	Path string
+}
+
+// Run runs ffmpeg and reports the stream live once it reports progress
+func (f FFmpeg) Run(ctx context.Context, source, target string, live func()) error {
+	cmd := exec.CommandContext(ctx, f.Path,
+		"-hide_banner", "-nostats", "-loglevel", "error",
+		"-i", source,
+		"-c", "copy", "-f", "flv",
+		"-progress", "pipe:1",
+		target,
+	)
+	// give ffmpeg a moment to close the connection to the target
+	cmd.WaitDelay = 5 * time.Second
+	stderr := &tailBuffer{}
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start ffmpeg: %w", err)
+	}
+
+	var once sync.Once
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), "progress=") {
+			once.Do(live)
+		}
+	}
+	io.Copy(io.Discard, stdout)
+
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if message := stderr.lastLine(); message != "" {
+		return errors.New(message)
+	}
+	if err != nil {
+		return fmt.Errorf("ffmpeg: %w", err)
+	}
+	return errors.New("the feed ended")
+}
+
+// tailBuffer keeps the end of what a process writes
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+// tailSize is enough for the last few lines of an error
+const tailSize = 4096
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > tailSize {
+		b.buf = b.buf[len(b.buf)-tailSize:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) lastLine() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lines := bytes.Split(bytes.TrimSpace(b.buf), []byte("\n"))
+	return strings.TrimSpace(string(lines[len(lines)-1]))
+}
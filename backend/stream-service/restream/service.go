@@ -0,0 +1,602 @@
+// Package restream forwards live streams to other platforms. Owners register
+// RTMP destinations for a single stream or, when its stream id is 0, for
+// every stream of their channel; while a stream is live its feed is relayed
+// to each enabled destination. Destinations are kept in StreamDb with their
+// stream keys encrypted; the state of the relays is kept in memory.
+package restream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// States of the connection to a destination
+const (
+	// StateIdle is a destination with nothing to forward
+	StateIdle = "idle"
+	// StateConnecting is a relay starting or retrying
+	StateConnecting = "connecting"
+	// StateLive is a relay delivering media
+	StateLive = "live"
+	// StateError is a relay that failed and is waiting to retry
+	StateError = "error"
+)
+
+var (
+	// ErrNotFound is returned for unknown destination ids
+	ErrNotFound = errors.New("restream destination not found")
+	// ErrInvalidDestination is returned for addresses that are not RTMP or
+	// that point into a private network
+	ErrInvalidDestination = errors.New("invalid restream destination")
+)
+
+// Status is the connection of a destination
+type Status struct {
+	State string
+	// StreamID is the stream being forwarded, if any
+	StreamID int32
+	// Error is the last failure, with the stream key redacted
+	Error string
+	Since time.Time
+	// Attempts counts the connections made for the current stream
+	Attempts int
+}
+
+// Destination is where a stream is restreamed to
+type Destination struct {
+	ID      int32
+	OwnerID int32
+	// StreamID is 0 for destinations of the whole channel
+	StreamID int32
+	Name     string
+	URL      string
+	// KeyHint is the end of the stream key, for owners to recognise it
+	KeyHint   string
+	Enabled   bool
+	CreatedAt time.Time
+	// Status is idle for destinations without a live stream to forward
+	Status Status
+
+	// sealedKey is the stream key encrypted by the cipher
+	sealedKey string
+}
+
+// Changes updates a destination. Nil fields are left as they are.
+type Changes struct {
+	Name    *string
+	URL     *string
+	Key     *string
+	Enabled *bool
+}
+
+// Config tunes where feeds are read from and how failed relays are retried
+type Config struct {
+	// SourceURL is the address of the ingested feed of a stream, in which
+	// {stream_key} and {stream_id} are replaced
+	SourceURL string
+	// RetryBackoff is the first delay before reconnecting, doubled after
+	// each failure up to MaxRetryBackoff
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	// AllowPrivateHosts lets destinations resolve to loopback, private and
+	// link-local addresses, for relays within a trusted network
+	AllowPrivateHosts bool
+}
+
+// Resolver looks up the addresses of destination hosts. *net.Resolver is
+// one.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// StatusFunc is called when the connection of a destination changes state.
+// It is called with the service locked and must not call back into it.
+type StatusFunc func(destination Destination, status Status)
+
+// liveStream is a stream being broadcast
+type liveStream struct {
+	ownerID int32
+	key     string
+}
+
+// forwarder relays one stream to one destination
+type forwarder struct {
+	streamID int32
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// Service keeps destinations in StreamDb and runs the relays of live
+// streams
+type Service struct {
+	cfg      Config
+	client   streamdb.RestreamServiceClient
+	cipher   *Cipher
+	relay    Relay
+	resolver Resolver
+	onStatus StatusFunc
+	now      func() time.Time
+
+	// ctx is the parent of the relays, cancelled by Close
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu sync.Mutex
+	// destinations are those of the owners of live streams, loaded when a
+	// stream goes live, with the status of their relay
+	destinations map[int32]*Destination
+	forwarders   map[int32]*forwarder
+	live         map[int32]liveStream
+}
+
+// NewService returns a service keeping destinations through client and
+// relaying with relay. A nil cipher disables restreaming. onStatus, if not
+// nil, is told about state changes.
+func NewService(cfg Config, client streamdb.RestreamServiceClient, cipher *Cipher, relay Relay, onStatus StatusFunc) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		cfg:          cfg,
+		client:       client,
+		cipher:       cipher,
+		relay:        relay,
+		resolver:     net.DefaultResolver,
+		onStatus:     onStatus,
+		now:          func() time.Time { return time.Now().UTC() },
+		ctx:          ctx,
+		cancel:       cancel,
+		destinations: make(map[int32]*Destination),
+		forwarders:   make(map[int32]*forwarder),
+		live:         make(map[int32]liveStream),
+	}
+}
+
+// Enabled reports whether destinations can be registered
+func (s *Service) Enabled() bool {
+	return s.cipher != nil
+}
+
+// Add registers a destination of ownerID, for streamID or the whole channel
+// when it is 0. A live stream is forwarded to it right away.
+func (s *Service) Add(ctx context.Context, ownerID, streamID int32, name, address, key string, enabled bool) (Destination, error) {
+	if !s.Enabled() {
+		return Destination{}, ErrDisabled
+	}
+	host, err := s.checkURL(ctx, address)
+	if err != nil {
+		return Destination{}, err
+	}
+	sealed, err := s.cipher.Seal(key)
+	if err != nil {
+		return Destination{}, err
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		name = host
+	}
+
+	resp, err := s.client.CreateDestination(ctx, &streamdb.CreateDestinationRequest{
+		OwnerId:   ownerID,
+		StreamId:  streamID,
+		Name:      name,
+		Url:       address,
+		SealedKey: sealed,
+		KeyHint:   keyHint(key),
+		Enabled:   enabled,
+	})
+	if err != nil {
+		return Destination{}, storeError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.track(fromResponse(resp), false), nil
+}
+
+// Get returns a destination by id
+func (s *Service) Get(ctx context.Context, id int32) (Destination, error) {
+	resp, err := s.client.GetDestination(ctx, &streamdb.GetDestinationRequest{Id: id})
+	if err != nil {
+		return Destination{}, storeError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.withStatus(fromResponse(resp)), nil
+}
+
+// Update changes a destination. Relays follow the change: a new address or
+// key reconnects and disabling stops forwarding.
+func (s *Service) Update(ctx context.Context, id int32, changes Changes) (Destination, error) {
+	if !s.Enabled() {
+		return Destination{}, ErrDisabled
+	}
+	stored, err := s.client.GetDestination(ctx, &streamdb.GetDestinationRequest{Id: id})
+	if err != nil {
+		return Destination{}, storeError(err)
+	}
+
+	// the database replaces every field, so the request starts from the
+	// stored destination
+	update := &streamdb.UpdateDestinationRequest{
+		Id:        id,
+		Name:      stored.Name,
+		Url:       stored.Url,
+		SealedKey: stored.SealedKey,
+		KeyHint:   stored.KeyHint,
+		Enabled:   stored.Enabled,
+	}
+	if changes.Name != nil && strings.TrimSpace(*changes.Name) != "" {
+		update.Name = strings.TrimSpace(*changes.Name)
+	}
+	reconnect := false
+	if changes.URL != nil && *changes.URL != stored.Url {
+		if _, err := s.checkURL(ctx, *changes.URL); err != nil {
+			return Destination{}, err
+		}
+		update.Url = *changes.URL
+		reconnect = true
+	}
+	if changes.Key != nil {
+		if update.SealedKey, err = s.cipher.Seal(*changes.Key); err != nil {
+			return Destination{}, err
+		}
+		update.KeyHint = keyHint(*changes.Key)
+		reconnect = true
+	}
+	if changes.Enabled != nil {
+		update.Enabled = *changes.Enabled
+	}
+
+	resp, err := s.client.UpdateDestination(ctx, update)
+	if err != nil {
+		return Destination{}, storeError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.track(fromResponse(resp), reconnect), nil
+}
+
+// Remove deletes a destination and stops forwarding to it
+func (s *Service) Remove(ctx context.Context, id int32) error {
+	if _, err := s.client.DeleteDestination(ctx, &streamdb.DeleteDestinationRequest{Id: id}); err != nil {
+		return storeError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stop(id)
+	delete(s.destinations, id)
+	return nil
+}
+
+// List returns the destinations of ownerID sorted by id. A stream id limits
+// them to those applying to the stream.
+func (s *Service) List(ctx context.Context, ownerID, streamID int32) ([]Destination, error) {
+	resp, err := s.client.ListDestinations(ctx, &streamdb.ListDestinationsRequest{OwnerId: ownerID, StreamId: streamID})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]Destination, 0, len(resp.Destinations))
+	for _, destination := range resp.Destinations {
+		list = append(list, s.withStatus(fromResponse(destination)))
+	}
+	return list, nil
+}
+
+// StreamLive loads the destinations of a stream that went live and starts
+// forwarding it
+func (s *Service) StreamLive(ctx context.Context, stream *proto.StreamResponse) error {
+	resp, err := s.client.ListDestinations(ctx, &streamdb.ListDestinationsRequest{OwnerId: stream.UserId, StreamId: stream.Id})
+	if err != nil {
+		return storeError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.live[stream.Id] = liveStream{ownerID: stream.UserId, key: stream.StreamKey}
+	for _, destination := range resp.Destinations {
+		if _, ok := s.destinations[destination.Id]; !ok {
+			d := fromResponse(destination)
+			s.destinations[d.ID] = &d
+		}
+	}
+	s.reconcile()
+	return nil
+}
+
+// StreamEnded stops forwarding a stream. Destinations of the channel move
+// on to another of its live streams, if any.
+func (s *Service) StreamEnded(streamID int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.live, streamID)
+	s.reconcile()
+}
+
+// RemoveStream deletes the destinations of a purged stream
+func (s *Service) RemoveStream(ctx context.Context, streamID int32) error {
+	s.mu.Lock()
+	delete(s.live, streamID)
+	for id, d := range s.destinations {
+		if d.StreamID == streamID {
+			s.stop(id)
+			delete(s.destinations, id)
+		}
+	}
+	s.reconcile()
+	s.mu.Unlock()
+
+	if _, err := s.client.DeleteStreamDestinations(ctx, &streamdb.DeleteStreamDestinationsRequest{StreamId: streamID}); err != nil {
+		return storeError(err)
+	}
+	return nil
+}
+
+// Close stops every relay and waits for them to exit
+func (s *Service) Close() {
+	s.cancel()
+	s.mu.Lock()
+	var done []chan struct{}
+	for _, f := range s.forwarders {
+		done = append(done, f.done)
+	}
+	s.mu.Unlock()
+	for _, ch := range done {
+		<-ch
+	}
+}
+
+// track keeps a destination that was stored while its owner is live, so
+// that it is forwarded, and returns it with its status. reconnect restarts
+// its relay. It is called with the service locked.
+func (s *Service) track(d Destination, reconnect bool) Destination {
+	if reconnect {
+		s.stop(d.ID)
+	}
+	if current, ok := s.destinations[d.ID]; ok {
+		d.Status = current.Status
+		*current = d
+	} else if s.ownerLive(d.OwnerID) {
+		s.destinations[d.ID] = &d
+	}
+	s.reconcile()
+	return s.withStatus(d)
+}
+
+// withStatus completes a destination read from the database with the status
+// of its relay. It is called with the service locked.
+func (s *Service) withStatus(d Destination) Destination {
+	if current, ok := s.destinations[d.ID]; ok {
+		d.Status = current.Status
+	}
+	return d
+}
+
+// ownerLive reports whether a stream of ownerID is live. It is called with
+// the service locked.
+func (s *Service) ownerLive(ownerID int32) bool {
+	for _, stream := range s.live {
+		if stream.ownerID == ownerID {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcile starts and stops relays so that every enabled destination
+// forwards one of the live streams it applies to. A destination of the
+// channel keeps the stream it forwards while that stream is live.
+// Destinations of owners that are no longer live are dropped. It is called
+// with the service locked.
+func (s *Service) reconcile() {
+	for id, d := range s.destinations {
+		want := int32(0)
+		if d.Enabled {
+			if f, ok := s.forwarders[id]; ok && s.applies(d, f.streamID) {
+				continue
+			}
+			for streamID := range s.live {
+				if s.applies(d, streamID) && (want == 0 || streamID < want) {
+					want = streamID
+				}
+			}
+		}
+		if f, ok := s.forwarders[id]; !ok || f.streamID != want {
+			s.stop(id)
+			if want != 0 {
+				s.start(d, want)
+			}
+		}
+		if !s.ownerLive(d.OwnerID) {
+			delete(s.destinations, id)
+		}
+	}
+}
+
+// applies reports whether d forwards the stream, which must be live
+func (s *Service) applies(d *Destination, streamID int32) bool {
+	stream, ok := s.live[streamID]
+	return ok && stream.ownerID == d.OwnerID && (d.StreamID == 0 || d.StreamID == streamID)
+}
+
+// start runs a relay of streamID to d. It is called with the service locked.
+func (s *Service) start(d *Destination, streamID int32) {
+	key, err := s.cipher.Open(d.sealedKey)
+	if err != nil {
+		s.setStatus(d, Status{State: StateError, StreamID: streamID, Error: "cannot decrypt the stream key"})
+		return
+	}
+	stream := s.live[streamID]
+	source := strings.NewReplacer(
+		"{stream_key}", url.PathEscape(stream.key),
+		"{stream_id}", strconv.Itoa(int(streamID)),
+	).Replace(s.cfg.SourceURL)
+	target := d.URL
+	if key != "" {
+		target = strings.TrimSuffix(d.URL, "/") + "/" + key
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	f := &forwarder{streamID: streamID, cancel: cancel, done: make(chan struct{})}
+	s.forwarders[d.ID] = f
+	go s.forward(ctx, f, d.ID, d.URL, source, target, key)
+}
+
+// stop cancels the relay of a destination, if any, and marks it idle. It is
+// called with the service locked.
+func (s *Service) stop(id int32) {
+	f, ok := s.forwarders[id]
+	if !ok {
+		return
+	}
+	f.cancel()
+	delete(s.forwarders, id)
+	if d, ok := s.destinations[id]; ok {
+		s.setStatus(d, Status{State: StateIdle})
+	}
+}
+
+// forward runs the relay of f until it is stopped, reconnecting with
+// backoff when it fails. The host of address is checked again before every
+// connection, as what it resolves to may have changed since it was stored.
+func (s *Service) forward(ctx context.Context, f *forwarder, id int32, address, source, target, key string) {
+	defer close(f.done)
+
+	backoff := s.cfg.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		s.update(f, id, Status{State: StateConnecting, StreamID: f.streamID, Attempts: attempt})
+
+		started := s.now()
+		_, err := s.checkURL(ctx, address)
+		if err == nil {
+			err = s.relay.Run(ctx, source, target, func() {
+				s.update(f, id, Status{State: StateLive, StreamID: f.streamID, Attempts: attempt})
+			})
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = errors.New("the relay stopped")
+		}
+		message := err.Error()
+		if key != "" {
+			message = strings.ReplaceAll(message, key, "****")
+		}
+		s.update(f, id, Status{State: StateError, StreamID: f.streamID, Error: message, Attempts: attempt})
+
+		// a relay that held up for a while starts over with short delays
+		if s.now().Sub(started) > s.cfg.MaxRetryBackoff {
+			backoff = s.cfg.RetryBackoff
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, s.cfg.MaxRetryBackoff)
+	}
+}
+
+// update sets the status of a destination as long as f still relays to it
+func (s *Service) update(f *forwarder, id int32, status Status) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.destinations[id]
+	if !ok || s.forwarders[id] != f {
+		return
+	}
+	s.setStatus(d, status)
+}
+
+// setStatus records a status and reports it. It is called with the service
+// locked.
+func (s *Service) setStatus(d *Destination, status Status) {
+	status.Since = s.now()
+	previous := d.Status
+	d.Status = status
+	if s.onStatus != nil && (previous.State != status.State || previous.Error != status.Error) {
+		s.onStatus(*d, status)
+	}
+}
+
+// checkURL checks that address is an RTMP address outside of private
+// networks, unless they are allowed, and returns its host. Every address the
+// host resolves to is checked, so that a public name cannot point a relay at
+// an internal service.
+func (s *Service) checkURL(ctx context.Context, address string) (string, error) {
+	u, err := url.Parse(address)
+	if err != nil || (u.Scheme != "rtmp" && u.Scheme != "rtmps") || u.Host == "" {
+		return "", fmt.Errorf("%w: want an rtmp:// or rtmps:// address, got %q", ErrInvalidDestination, address)
+	}
+	host := u.Hostname()
+	if s.cfg.AllowPrivateHosts {
+		return host, nil
+	}
+
+	addrs, err := s.resolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return "", fmt.Errorf("%w: cannot resolve %q", ErrInvalidDestination, host)
+	}
+	for _, addr := range addrs {
+		if privateIP(addr.IP) {
+			return "", fmt.Errorf("%w: %q resolves to the private address %s", ErrInvalidDestination, host, addr.IP)
+		}
+	}
+	return host, nil
+}
+
+// privateIP reports whether ip cannot be reached from the internet
+func privateIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast()
+}
+
+// storeError maps the statuses of the database to the errors of the service
+func storeError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, status.Convert(err).Message())
+	}
+	return err
+}
+
+func fromResponse(resp *streamdb.DestinationResponse) Destination {
+	createdAt, _ := time.Parse(time.RFC3339Nano, resp.CreatedAt)
+	return Destination{
+		ID:        resp.Id,
+		OwnerID:   resp.OwnerId,
+		StreamID:  resp.StreamId,
+		Name:      resp.Name,
+		URL:       resp.Url,
+		KeyHint:   resp.KeyHint,
+		Enabled:   resp.Enabled,
+		CreatedAt: createdAt,
+		Status:    Status{State: StateIdle, Since: createdAt},
+		sealedKey: resp.SealedKey,
+	}
+}
+
+// keyHint returns the last characters of a stream key long enough to keep
+// the rest secret
+func keyHint(key string) string {
+	if len(key) < 12 {
+		return ""
+	}
+	return "…" + key[len(key)-4:]
+}
@@ -0,0 +1,194 @@
+// Package telemetry keeps the live telemetry encoders report for their
+// streams as a short time series in the database service, and derives
+// health warnings from it when a stream falls below the settings it was
+// configured with. When each warning was first raised is only known to the
+// replica that raised it, and is reset when it restarts.
+package telemetry
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/clementus360/stream-service/proto"
+)
+
+// Health statuses of a stream
+const (
+	StatusHealthy  = "healthy"
+	StatusDegraded = "degraded"
+	StatusNoData   = "no_data"
+)
+
+// Warning kinds
+const (
+	WarningLowBitrate       = "low_bitrate"
+	WarningLowFramerate     = "low_framerate"
+	WarningDroppedFrames    = "dropped_frames"
+	WarningKeyframeInterval = "keyframe_interval"
+	WarningAudioSilent      = "audio_silent"
+	WarningStale            = "stale"
+)
+
+// Sample is one report of an encoder
+type Sample struct {
+	ReportedAt time.Time
+	// Bitrate is the actual output bitrate in kbps
+	Bitrate int
+	// Framerate is the actual output frames per second
+	Framerate float64
+	// DroppedFrames counts the frames dropped since the previous report
+	DroppedFrames int
+	// KeyframeInterval is the time between the last two keyframes
+	KeyframeInterval time.Duration
+	// AudioLevel is the audio level in dBFS, nil for streams without audio
+	AudioLevel *float64
+}
+
+// Warning is a way a stream currently falls short
+type Warning struct {
+	Kind    string
+	Message string
+	// Since is when the warning was first raised
+	Since time.Time
+}
+
+// Health is the state of a stream derived from its recent telemetry
+type Health struct {
+	StreamID int32
+	Status   string
+	Warnings []Warning
+	// Samples are the reports kept for the stream, oldest first
+	Samples []Sample
+	// Figures over the evaluation window
+	AverageBitrate    int
+	AverageFramerate  float64
+	DroppedFrameRatio float64
+	LastReportedAt    time.Time
+}
+
+// Thresholds decide when a stream is degraded
+type Thresholds struct {
+	// Window is the period averages are computed over
+	Window time.Duration
+	// StaleAfter is how long a live stream may go without a report
+	StaleAfter time.Duration
+	// BitrateTolerance and FramerateTolerance are the fractions of the
+	// configured bitrate and framerate a stream must keep
+	BitrateTolerance   float64
+	FramerateTolerance float64
+	// MaxDroppedFrames is the highest acceptable ratio of dropped frames
+	MaxDroppedFrames    float64
+	MaxKeyframeInterval time.Duration
+	// SilenceLevel is the audio level in dBFS at or below which audio counts
+	// as silent
+	SilenceLevel float64
+}
+
+// Settings are the encoding settings a stream was configured with. Zero
+// values are not checked.
+type Settings struct {
+	Bitrate   int
+	Framerate float64
+}
+
+// SettingsOf reads the configured settings of a stream. The database service
+// returns them as strings.
+func SettingsOf(stream *proto.StreamResponse) Settings {
+	bitrate, _ := strconv.Atoi(stream.Bitrate)
+	framerate, _ := strconv.ParseFloat(stream.Framerate, 64)
+	return Settings{Bitrate: max(bitrate, 0), Framerate: max(framerate, 0)}
+}
+
+// evaluate computes the figures and warnings of a series of samples, oldest
+// first, as of now. stale is only checked for live streams.
+func evaluate(samples []Sample, settings Settings, t Thresholds, now time.Time, live bool) (Health, []Warning) {
+	health := Health{Status: StatusNoData}
+	if len(samples) == 0 {
+		return health, nil
+	}
+	last := samples[len(samples)-1]
+	health.LastReportedAt = last.ReportedAt
+
+	// the window ends with the last report so that a stale stream is judged
+	// on what it last sent
+	var window []Sample
+	for i, sample := range samples {
+		if last.ReportedAt.Sub(sample.ReportedAt) <= t.Window {
+			window = samples[i:]
+			break
+		}
+	}
+
+	var bitrate, framerate, expected float64
+	var dropped, silent, withAudio int
+	for i, sample := range window {
+		bitrate += float64(sample.Bitrate)
+		framerate += sample.Framerate
+		if sample.AudioLevel != nil {
+			withAudio++
+			if *sample.AudioLevel <= t.SilenceLevel {
+				silent++
+			}
+		}
+		// frames dropped in the first report were dropped before the window
+		if i == 0 {
+			continue
+		}
+		fps := settings.Framerate
+		if fps == 0 {
+			fps = sample.Framerate
+		}
+		expected += fps * sample.ReportedAt.Sub(window[i-1].ReportedAt).Seconds()
+		dropped += sample.DroppedFrames
+	}
+	health.AverageBitrate = int(bitrate / float64(len(window)))
+	health.AverageFramerate = framerate / float64(len(window))
+	if expected > 0 {
+		health.DroppedFrameRatio = float64(dropped) / expected
+	}
+
+	var warnings []Warning
+	if settings.Bitrate > 0 && float64(health.AverageBitrate) < t.BitrateTolerance*float64(settings.Bitrate) {
+		warnings = append(warnings, Warning{
+			Kind:    WarningLowBitrate,
+			Message: fmt.Sprintf("bitrate is %d kbps, below the configured %d kbps", health.AverageBitrate, settings.Bitrate),
+		})
+	}
+	if settings.Framerate > 0 && health.AverageFramerate < t.FramerateTolerance*settings.Framerate {
+		warnings = append(warnings, Warning{
+			Kind:    WarningLowFramerate,
+			Message: fmt.Sprintf("framerate is %.1f fps, below the configured %.0f fps", health.AverageFramerate, settings.Framerate),
+		})
+	}
+	if health.DroppedFrameRatio > t.MaxDroppedFrames {
+		warnings = append(warnings, Warning{
+			Kind:    WarningDroppedFrames,
+			Message: fmt.Sprintf("%.1f%% of the frames were dropped", 100*health.DroppedFrameRatio),
+		})
+	}
+	if last.KeyframeInterval > t.MaxKeyframeInterval {
+		warnings = append(warnings, Warning{
+			Kind:    WarningKeyframeInterval,
+			Message: fmt.Sprintf("keyframes are %s apart, more than %s", last.KeyframeInterval, t.MaxKeyframeInterval),
+		})
+	}
+	if withAudio > 0 && silent == withAudio {
+		warnings = append(warnings, Warning{
+			Kind:    WarningAudioSilent,
+			Message: fmt.Sprintf("audio has stayed at or below %.0f dBFS", t.SilenceLevel),
+		})
+	}
+	if live && now.Sub(last.ReportedAt) > t.StaleAfter {
+		warnings = append(warnings, Warning{
+			Kind:    WarningStale,
+			Message: fmt.Sprintf("no telemetry received for %s", now.Sub(last.ReportedAt).Truncate(time.Second)),
+		})
+	}
+
+	health.Status = StatusHealthy
+	if len(warnings) > 0 {
+		health.Status = StatusDegraded
+	}
+	return health, warnings
+}
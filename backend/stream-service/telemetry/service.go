@@ -0,0 +1,245 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/clementus360/stream-service/models"
+	"github.com/clementus360/stream-service/proto"
+	"github.com/clementus360/streamdb-api/streamdb"
+)
+
+var (
+	// ErrStreamNotLive is returned when reporting for a stream that is not
+	// online
+	ErrStreamNotLive = errors.New("stream is not live")
+	// ErrInvalidSample is returned for samples with negative values
+	ErrInvalidSample = errors.New("telemetry values must not be negative")
+)
+
+// maxSamples bounds the series of a stream whose encoder reports more often
+// than the retention period calls for
+const maxSamples = 1000
+
+// Config tunes how long telemetry is kept and when warnings are raised
+type Config struct {
+	// Retention is how long samples are kept
+	Retention time.Duration
+	Thresholds
+}
+
+// WarningFunc is called when a stream starts falling short in a new way. It
+// is called with the service locked and must not call back into it.
+type WarningFunc func(streamID int32, warning Warning)
+
+// series is what is known of a stream besides its samples, which are stored
+// by the database service
+type series struct {
+	settings Settings
+	live     bool
+	// last is when the newest sample was reported
+	last time.Time
+	// active holds when each warning currently raised was first raised
+	active map[string]time.Time
+}
+
+// Service records telemetry and evaluates the health of streams
+type Service struct {
+	cfg       Config
+	client    streamdb.TelemetryServiceClient
+	onWarning WarningFunc
+	now       func() time.Time
+
+	mu       sync.Mutex
+	byStream map[int32]*series
+}
+
+// NewService returns a service storing samples through client and calling
+// onWarning, if not nil, for every new warning
+func NewService(cfg Config, client streamdb.TelemetryServiceClient, onWarning WarningFunc) *Service {
+	return &Service{
+		cfg:       cfg,
+		client:    client,
+		onWarning: onWarning,
+		now:       func() time.Time { return time.Now().UTC() },
+		byStream:  make(map[int32]*series),
+	}
+}
+
+// Report records a sample for a live stream, stamped with the time it was
+// received, and returns the health of the stream
+func (s *Service) Report(ctx context.Context, stream *proto.StreamResponse, sample Sample) (Health, error) {
+	if stream.Status != models.StatusOnline {
+		return Health{}, ErrStreamNotLive
+	}
+	if sample.Bitrate < 0 || sample.Framerate < 0 || sample.DroppedFrames < 0 || sample.KeyframeInterval < 0 {
+		return Health{}, ErrInvalidSample
+	}
+
+	sample.ReportedAt = s.now()
+	_, err := s.client.AddSample(ctx, &streamdb.AddSampleRequest{
+		StreamId:                stream.Id,
+		ReportedAt:              sample.ReportedAt.Format(time.RFC3339Nano),
+		BitrateKbps:             int32(sample.Bitrate),
+		Framerate:               sample.Framerate,
+		DroppedFrames:           int32(sample.DroppedFrames),
+		KeyframeIntervalSeconds: sample.KeyframeInterval.Seconds(),
+		AudioLevelDb:            sample.AudioLevel,
+	})
+	if err != nil {
+		return Health{}, err
+	}
+	return s.Health(ctx, stream)
+}
+
+// Health returns the health of a stream as of now
+func (s *Service) Health(ctx context.Context, stream *proto.StreamResponse) (Health, error) {
+	now := s.now()
+	samples, err := s.samples(ctx, stream.Id, now)
+	if err != nil {
+		return Health{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(samples) == 0 {
+		delete(s.byStream, stream.Id)
+		return Health{StreamID: stream.Id, Status: StatusNoData}, nil
+	}
+	data := s.byStream[stream.Id]
+	if data == nil {
+		data = &series{}
+		s.byStream[stream.Id] = data
+	}
+	data.settings = SettingsOf(stream)
+	data.live = stream.Status == models.StatusOnline
+	return s.health(stream.Id, data, samples, now), nil
+}
+
+// StreamEnded stops expecting reports for a stream, so that it is not
+// flagged as stale. Its samples are kept until they expire.
+func (s *Service) StreamEnded(streamID int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if data := s.byStream[streamID]; data != nil {
+		data.live = false
+		delete(data.active, WarningStale)
+	}
+}
+
+// Run raises stale warnings for live streams whose encoder went quiet and
+// deletes expired telemetry until ctx is cancelled
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.StaleAfter)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.sweep(ctx); err != nil && ctx.Err() == nil {
+				slog.Error("Failed to sweep stream telemetry", "error", err)
+			}
+		}
+	}
+}
+
+func (s *Service) sweep(ctx context.Context) error {
+	now := s.now()
+	_, err := s.client.DeleteSamples(ctx, &streamdb.DeleteSamplesRequest{ReportedBefore: now.Add(-s.cfg.Retention).Format(time.RFC3339Nano)})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	var live []int32
+	for id, data := range s.byStream {
+		switch {
+		case data.live:
+			live = append(live, id)
+		case now.Sub(data.last) > s.cfg.Retention:
+			delete(s.byStream, id)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, id := range live {
+		samples, err := s.samples(ctx, id, now)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		if data := s.byStream[id]; data != nil && data.live {
+			if len(samples) == 0 {
+				delete(s.byStream, id)
+			} else {
+				s.health(id, data, samples, now)
+			}
+		}
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// samples loads the samples of a stream reported within the retention
+// period, oldest first
+func (s *Service) samples(ctx context.Context, streamID int32, now time.Time) ([]Sample, error) {
+	resp, err := s.client.ListSamples(ctx, &streamdb.ListSamplesRequest{
+		StreamId:      streamID,
+		ReportedAfter: now.Add(-s.cfg.Retention).Format(time.RFC3339Nano),
+		Limit:         maxSamples,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]Sample, 0, len(resp.Samples))
+	for _, sample := range resp.Samples {
+		reportedAt, err := time.Parse(time.RFC3339Nano, sample.ReportedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid report time %q of stream %d: %w", sample.ReportedAt, streamID, err)
+		}
+		samples = append(samples, Sample{
+			ReportedAt:       reportedAt,
+			Bitrate:          int(sample.BitrateKbps),
+			Framerate:        sample.Framerate,
+			DroppedFrames:    int(sample.DroppedFrames),
+			KeyframeInterval: time.Duration(sample.KeyframeIntervalSeconds * float64(time.Second)),
+			AudioLevel:       sample.AudioLevelDb,
+		})
+	}
+	return samples, nil
+}
+
+// health evaluates the samples of a stream and records which warnings are
+// raised. s.mu must be held.
+func (s *Service) health(streamID int32, data *series, samples []Sample, now time.Time) Health {
+	health, warnings := evaluate(samples, data.settings, s.cfg.Thresholds, now, data.live)
+	health.StreamID = streamID
+	health.Samples = samples
+	data.last = health.LastReportedAt
+
+	active := make(map[string]time.Time, len(warnings))
+	for i := range warnings {
+		since, ok := data.active[warnings[i].Kind]
+		if !ok {
+			since = now
+		}
+		warnings[i].Since = since
+		active[warnings[i].Kind] = since
+		if !ok && s.onWarning != nil {
+			s.onWarning(streamID, warnings[i])
+		}
+	}
+	data.active = active
+	health.Warnings = warnings
+	return health
+}
@@ -0,0 +1,475 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: streamdb/analytics.proto
+
+// Copy of StreamDb/Protos/analytics.proto. The package is prefixed with
+// streamdb so that it can be linked next to the Go services, see wire.go.
+
+package streamdb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_AddViewerSession_FullMethodName        = "/streamdb.analytics.AnalyticsService/AddViewerSession"
+	AnalyticsService_GetViewerSession_FullMethodName        = "/streamdb.analytics.AnalyticsService/GetViewerSession"
+	AnalyticsService_EndViewerSession_FullMethodName        = "/streamdb.analytics.AnalyticsService/EndViewerSession"
+	AnalyticsService_ListViewerSessions_FullMethodName      = "/streamdb.analytics.AnalyticsService/ListViewerSessions"
+	AnalyticsService_CountOpenViewerSessions_FullMethodName = "/streamdb.analytics.AnalyticsService/CountOpenViewerSessions"
+	AnalyticsService_DeleteViewerSessions_FullMethodName    = "/streamdb.analytics.AnalyticsService/DeleteViewerSessions"
+	AnalyticsService_SetRollup_FullMethodName               = "/streamdb.analytics.AnalyticsService/SetRollup"
+	AnalyticsService_GetRollup_FullMethodName               = "/streamdb.analytics.AnalyticsService/GetRollup"
+	AnalyticsService_ListPendingRollups_FullMethodName      = "/streamdb.analytics.AnalyticsService/ListPendingRollups"
+	AnalyticsService_DeleteStreamAnalytics_FullMethodName   = "/streamdb.analytics.AnalyticsService/DeleteStreamAnalytics"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// The viewer sessions of streams and the audience figures stream-service
+// rolls up from them once a stream has ended. stream-service picks the ids
+// of sessions and computes the rollups; they are stored as it sends them.
+type AnalyticsServiceClient interface {
+	AddViewerSession(ctx context.Context, in *AddViewerSessionRequest, opts ...grpc.CallOption) (*ViewerSessionResponse, error)
+	GetViewerSession(ctx context.Context, in *GetViewerSessionRequest, opts ...grpc.CallOption) (*ViewerSessionResponse, error)
+	EndViewerSession(ctx context.Context, in *EndViewerSessionRequest, opts ...grpc.CallOption) (*ViewerSessionResponse, error)
+	ListViewerSessions(ctx context.Context, in *ListViewerSessionsRequest, opts ...grpc.CallOption) (*ListViewerSessionsResponse, error)
+	CountOpenViewerSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CountOpenViewerSessionsResponse, error)
+	DeleteViewerSessions(ctx context.Context, in *DeleteViewerSessionsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetRollup(ctx context.Context, in *SetRollupRequest, opts ...grpc.CallOption) (*RollupResponse, error)
+	GetRollup(ctx context.Context, in *GetRollupRequest, opts ...grpc.CallOption) (*RollupResponse, error)
+	ListPendingRollups(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListPendingRollupsResponse, error)
+	DeleteStreamAnalytics(ctx context.Context, in *DeleteStreamAnalyticsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) AddViewerSession(ctx context.Context, in *AddViewerSessionRequest, opts ...grpc.CallOption) (*ViewerSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ViewerSessionResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_AddViewerSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetViewerSession(ctx context.Context, in *GetViewerSessionRequest, opts ...grpc.CallOption) (*ViewerSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ViewerSessionResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetViewerSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) EndViewerSession(ctx context.Context, in *EndViewerSessionRequest, opts ...grpc.CallOption) (*ViewerSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ViewerSessionResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_EndViewerSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) ListViewerSessions(ctx context.Context, in *ListViewerSessionsRequest, opts ...grpc.CallOption) (*ListViewerSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListViewerSessionsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_ListViewerSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) CountOpenViewerSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CountOpenViewerSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountOpenViewerSessionsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_CountOpenViewerSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) DeleteViewerSessions(ctx context.Context, in *DeleteViewerSessionsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AnalyticsService_DeleteViewerSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) SetRollup(ctx context.Context, in *SetRollupRequest, opts ...grpc.CallOption) (*RollupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollupResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_SetRollup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetRollup(ctx context.Context, in *GetRollupRequest, opts ...grpc.CallOption) (*RollupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollupResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetRollup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) ListPendingRollups(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListPendingRollupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingRollupsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_ListPendingRollups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) DeleteStreamAnalytics(ctx context.Context, in *DeleteStreamAnalyticsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AnalyticsService_DeleteStreamAnalytics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+//
+// The viewer sessions of streams and the audience figures stream-service
+// rolls up from them once a stream has ended. stream-service picks the ids
+// of sessions and computes the rollups; they are stored as it sends them.
+type AnalyticsServiceServer interface {
+	AddViewerSession(context.Context, *AddViewerSessionRequest) (*ViewerSessionResponse, error)
+	GetViewerSession(context.Context, *GetViewerSessionRequest) (*ViewerSessionResponse, error)
+	EndViewerSession(context.Context, *EndViewerSessionRequest) (*ViewerSessionResponse, error)
+	ListViewerSessions(context.Context, *ListViewerSessionsRequest) (*ListViewerSessionsResponse, error)
+	CountOpenViewerSessions(context.Context, *emptypb.Empty) (*CountOpenViewerSessionsResponse, error)
+	DeleteViewerSessions(context.Context, *DeleteViewerSessionsRequest) (*emptypb.Empty, error)
+	SetRollup(context.Context, *SetRollupRequest) (*RollupResponse, error)
+	GetRollup(context.Context, *GetRollupRequest) (*RollupResponse, error)
+	ListPendingRollups(context.Context, *emptypb.Empty) (*ListPendingRollupsResponse, error)
+	DeleteStreamAnalytics(context.Context, *DeleteStreamAnalyticsRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) AddViewerSession(context.Context, *AddViewerSessionRequest) (*ViewerSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddViewerSession not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetViewerSession(context.Context, *GetViewerSessionRequest) (*ViewerSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetViewerSession not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) EndViewerSession(context.Context, *EndViewerSessionRequest) (*ViewerSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndViewerSession not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) ListViewerSessions(context.Context, *ListViewerSessionsRequest) (*ListViewerSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListViewerSessions not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) CountOpenViewerSessions(context.Context, *emptypb.Empty) (*CountOpenViewerSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountOpenViewerSessions not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) DeleteViewerSessions(context.Context, *DeleteViewerSessionsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteViewerSessions not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) SetRollup(context.Context, *SetRollupRequest) (*RollupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRollup not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetRollup(context.Context, *GetRollupRequest) (*RollupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRollup not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) ListPendingRollups(context.Context, *emptypb.Empty) (*ListPendingRollupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingRollups not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) DeleteStreamAnalytics(context.Context, *DeleteStreamAnalyticsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStreamAnalytics not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_AddViewerSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddViewerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).AddViewerSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_AddViewerSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).AddViewerSession(ctx, req.(*AddViewerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetViewerSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetViewerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetViewerSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetViewerSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetViewerSession(ctx, req.(*GetViewerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_EndViewerSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndViewerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).EndViewerSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_EndViewerSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).EndViewerSession(ctx, req.(*EndViewerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_ListViewerSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListViewerSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).ListViewerSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_ListViewerSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).ListViewerSessions(ctx, req.(*ListViewerSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_CountOpenViewerSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).CountOpenViewerSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_CountOpenViewerSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).CountOpenViewerSessions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_DeleteViewerSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteViewerSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).DeleteViewerSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_DeleteViewerSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).DeleteViewerSessions(ctx, req.(*DeleteViewerSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_SetRollup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRollupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).SetRollup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_SetRollup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).SetRollup(ctx, req.(*SetRollupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetRollup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRollupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetRollup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetRollup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetRollup(ctx, req.(*GetRollupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_ListPendingRollups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).ListPendingRollups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_ListPendingRollups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).ListPendingRollups(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_DeleteStreamAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStreamAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).DeleteStreamAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_DeleteStreamAnalytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).DeleteStreamAnalytics(ctx, req.(*DeleteStreamAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "streamdb.analytics.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddViewerSession",
+			Handler:    _AnalyticsService_AddViewerSession_Handler,
+		},
+		{
+			MethodName: "GetViewerSession",
+			Handler:    _AnalyticsService_GetViewerSession_Handler,
+		},
+		{
+			MethodName: "EndViewerSession",
+			Handler:    _AnalyticsService_EndViewerSession_Handler,
+		},
+		{
+			MethodName: "ListViewerSessions",
+			Handler:    _AnalyticsService_ListViewerSessions_Handler,
+		},
+		{
+			MethodName: "CountOpenViewerSessions",
+			Handler:    _AnalyticsService_CountOpenViewerSessions_Handler,
+		},
+		{
+			MethodName: "DeleteViewerSessions",
+			Handler:    _AnalyticsService_DeleteViewerSessions_Handler,
+		},
+		{
+			MethodName: "SetRollup",
+			Handler:    _AnalyticsService_SetRollup_Handler,
+		},
+		{
+			MethodName: "GetRollup",
+			Handler:    _AnalyticsService_GetRollup_Handler,
+		},
+		{
+			MethodName: "ListPendingRollups",
+			Handler:    _AnalyticsService_ListPendingRollups_Handler,
+		},
+		{
+			MethodName: "DeleteStreamAnalytics",
+			Handler:    _AnalyticsService_DeleteStreamAnalytics_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "streamdb/analytics.proto",
+}
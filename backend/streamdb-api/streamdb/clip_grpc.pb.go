@@ -0,0 +1,399 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: streamdb/clip.proto
+
+// Copy of StreamDb/Protos/clip.proto. The package is prefixed with
+// streamdb so that it can be linked next to the Go services, see wire.go.
+
+package streamdb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ClipService_CreateClip_FullMethodName        = "/streamdb.clip.ClipService/CreateClip"
+	ClipService_GetClip_FullMethodName           = "/streamdb.clip.ClipService/GetClip"
+	ClipService_ListClips_FullMethodName         = "/streamdb.clip.ClipService/ListClips"
+	ClipService_DeleteClip_FullMethodName        = "/streamdb.clip.ClipService/DeleteClip"
+	ClipService_DeleteStreamClips_FullMethodName = "/streamdb.clip.ClipService/DeleteStreamClips"
+	ClipService_StartBroadcast_FullMethodName    = "/streamdb.clip.ClipService/StartBroadcast"
+	ClipService_EndBroadcast_FullMethodName      = "/streamdb.clip.ClipService/EndBroadcast"
+	ClipService_GetBroadcast_FullMethodName      = "/streamdb.clip.ClipService/GetBroadcast"
+)
+
+// ClipServiceClient is the client API for ClipService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// The clips cut out of broadcasts, and the broadcasts they are cut from.
+// stream-service picks the ids of clips and the segments they span; the
+// segments themselves are kept by the packager.
+type ClipServiceClient interface {
+	CreateClip(ctx context.Context, in *CreateClipRequest, opts ...grpc.CallOption) (*ClipResponse, error)
+	GetClip(ctx context.Context, in *GetClipRequest, opts ...grpc.CallOption) (*ClipResponse, error)
+	ListClips(ctx context.Context, in *ListClipsRequest, opts ...grpc.CallOption) (*ListClipsResponse, error)
+	DeleteClip(ctx context.Context, in *DeleteClipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteStreamClips(ctx context.Context, in *DeleteStreamClipsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	StartBroadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+	EndBroadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+	GetBroadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+}
+
+type clipServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewClipServiceClient(cc grpc.ClientConnInterface) ClipServiceClient {
+	return &clipServiceClient{cc}
+}
+
+func (c *clipServiceClient) CreateClip(ctx context.Context, in *CreateClipRequest, opts ...grpc.CallOption) (*ClipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClipResponse)
+	err := c.cc.Invoke(ctx, ClipService_CreateClip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clipServiceClient) GetClip(ctx context.Context, in *GetClipRequest, opts ...grpc.CallOption) (*ClipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClipResponse)
+	err := c.cc.Invoke(ctx, ClipService_GetClip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clipServiceClient) ListClips(ctx context.Context, in *ListClipsRequest, opts ...grpc.CallOption) (*ListClipsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClipsResponse)
+	err := c.cc.Invoke(ctx, ClipService_ListClips_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clipServiceClient) DeleteClip(ctx context.Context, in *DeleteClipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ClipService_DeleteClip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clipServiceClient) DeleteStreamClips(ctx context.Context, in *DeleteStreamClipsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ClipService_DeleteStreamClips_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clipServiceClient) StartBroadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastResponse)
+	err := c.cc.Invoke(ctx, ClipService_StartBroadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clipServiceClient) EndBroadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastResponse)
+	err := c.cc.Invoke(ctx, ClipService_EndBroadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clipServiceClient) GetBroadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastResponse)
+	err := c.cc.Invoke(ctx, ClipService_GetBroadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClipServiceServer is the server API for ClipService service.
+// All implementations must embed UnimplementedClipServiceServer
+// for forward compatibility.
+//
+// The clips cut out of broadcasts, and the broadcasts they are cut from.
+// stream-service picks the ids of clips and the segments they span; the
+// segments themselves are kept by the packager.
+type ClipServiceServer interface {
+	CreateClip(context.Context, *CreateClipRequest) (*ClipResponse, error)
+	GetClip(context.Context, *GetClipRequest) (*ClipResponse, error)
+	ListClips(context.Context, *ListClipsRequest) (*ListClipsResponse, error)
+	DeleteClip(context.Context, *DeleteClipRequest) (*emptypb.Empty, error)
+	DeleteStreamClips(context.Context, *DeleteStreamClipsRequest) (*emptypb.Empty, error)
+	StartBroadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error)
+	EndBroadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error)
+	GetBroadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error)
+	mustEmbedUnimplementedClipServiceServer()
+}
+
+// UnimplementedClipServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedClipServiceServer struct{}
+
+func (UnimplementedClipServiceServer) CreateClip(context.Context, *CreateClipRequest) (*ClipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateClip not implemented")
+}
+func (UnimplementedClipServiceServer) GetClip(context.Context, *GetClipRequest) (*ClipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClip not implemented")
+}
+func (UnimplementedClipServiceServer) ListClips(context.Context, *ListClipsRequest) (*ListClipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClips not implemented")
+}
+func (UnimplementedClipServiceServer) DeleteClip(context.Context, *DeleteClipRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClip not implemented")
+}
+func (UnimplementedClipServiceServer) DeleteStreamClips(context.Context, *DeleteStreamClipsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStreamClips not implemented")
+}
+func (UnimplementedClipServiceServer) StartBroadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBroadcast not implemented")
+}
+func (UnimplementedClipServiceServer) EndBroadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndBroadcast not implemented")
+}
+func (UnimplementedClipServiceServer) GetBroadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBroadcast not implemented")
+}
+func (UnimplementedClipServiceServer) mustEmbedUnimplementedClipServiceServer() {}
+func (UnimplementedClipServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeClipServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClipServiceServer will
+// result in compilation errors.
+type UnsafeClipServiceServer interface {
+	mustEmbedUnimplementedClipServiceServer()
+}
+
+func RegisterClipServiceServer(s grpc.ServiceRegistrar, srv ClipServiceServer) {
+	// If the following call pancis, it indicates UnimplementedClipServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ClipService_ServiceDesc, srv)
+}
+
+func _ClipService_CreateClip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateClipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClipServiceServer).CreateClip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClipService_CreateClip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClipServiceServer).CreateClip(ctx, req.(*CreateClipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClipService_GetClip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClipServiceServer).GetClip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClipService_GetClip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClipServiceServer).GetClip(ctx, req.(*GetClipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClipService_ListClips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClipServiceServer).ListClips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClipService_ListClips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClipServiceServer).ListClips(ctx, req.(*ListClipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClipService_DeleteClip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteClipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClipServiceServer).DeleteClip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClipService_DeleteClip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClipServiceServer).DeleteClip(ctx, req.(*DeleteClipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClipService_DeleteStreamClips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStreamClipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClipServiceServer).DeleteStreamClips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClipService_DeleteStreamClips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClipServiceServer).DeleteStreamClips(ctx, req.(*DeleteStreamClipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClipService_StartBroadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClipServiceServer).StartBroadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClipService_StartBroadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClipServiceServer).StartBroadcast(ctx, req.(*BroadcastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClipService_EndBroadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClipServiceServer).EndBroadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClipService_EndBroadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClipServiceServer).EndBroadcast(ctx, req.(*BroadcastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClipService_GetBroadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClipServiceServer).GetBroadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClipService_GetBroadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClipServiceServer).GetBroadcast(ctx, req.(*BroadcastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ClipService_ServiceDesc is the grpc.ServiceDesc for ClipService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ClipService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "streamdb.clip.ClipService",
+	HandlerType: (*ClipServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateClip",
+			Handler:    _ClipService_CreateClip_Handler,
+		},
+		{
+			MethodName: "GetClip",
+			Handler:    _ClipService_GetClip_Handler,
+		},
+		{
+			MethodName: "ListClips",
+			Handler:    _ClipService_ListClips_Handler,
+		},
+		{
+			MethodName: "DeleteClip",
+			Handler:    _ClipService_DeleteClip_Handler,
+		},
+		{
+			MethodName: "DeleteStreamClips",
+			Handler:    _ClipService_DeleteStreamClips_Handler,
+		},
+		{
+			MethodName: "StartBroadcast",
+			Handler:    _ClipService_StartBroadcast_Handler,
+		},
+		{
+			MethodName: "EndBroadcast",
+			Handler:    _ClipService_EndBroadcast_Handler,
+		},
+		{
+			MethodName: "GetBroadcast",
+			Handler:    _ClipService_GetBroadcast_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "streamdb/clip.proto",
+}
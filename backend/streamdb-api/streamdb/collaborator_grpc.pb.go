@@ -0,0 +1,245 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: streamdb/collaborator.proto
+
+// Copy of StreamDb/Protos/collaborator.proto. The package is prefixed with
+// streamdb so that it can be linked next to the Go services, see wire.go.
+
+package streamdb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CollaboratorService_SetCollaborator_FullMethodName           = "/streamdb.collaborator.CollaboratorService/SetCollaborator"
+	CollaboratorService_DeleteCollaborator_FullMethodName        = "/streamdb.collaborator.CollaboratorService/DeleteCollaborator"
+	CollaboratorService_ListCollaborators_FullMethodName         = "/streamdb.collaborator.CollaboratorService/ListCollaborators"
+	CollaboratorService_DeleteStreamCollaborators_FullMethodName = "/streamdb.collaborator.CollaboratorService/DeleteStreamCollaborators"
+)
+
+// CollaboratorServiceClient is the client API for CollaboratorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Roles streamers grant other users on a stream, or on every stream of their
+// channel when stream_id is 0
+type CollaboratorServiceClient interface {
+	SetCollaborator(ctx context.Context, in *SetCollaboratorRequest, opts ...grpc.CallOption) (*CollaboratorResponse, error)
+	DeleteCollaborator(ctx context.Context, in *DeleteCollaboratorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error)
+	DeleteStreamCollaborators(ctx context.Context, in *DeleteStreamCollaboratorsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type collaboratorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCollaboratorServiceClient(cc grpc.ClientConnInterface) CollaboratorServiceClient {
+	return &collaboratorServiceClient{cc}
+}
+
+func (c *collaboratorServiceClient) SetCollaborator(ctx context.Context, in *SetCollaboratorRequest, opts ...grpc.CallOption) (*CollaboratorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollaboratorResponse)
+	err := c.cc.Invoke(ctx, CollaboratorService_SetCollaborator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collaboratorServiceClient) DeleteCollaborator(ctx context.Context, in *DeleteCollaboratorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CollaboratorService_DeleteCollaborator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collaboratorServiceClient) ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollaboratorsResponse)
+	err := c.cc.Invoke(ctx, CollaboratorService_ListCollaborators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collaboratorServiceClient) DeleteStreamCollaborators(ctx context.Context, in *DeleteStreamCollaboratorsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CollaboratorService_DeleteStreamCollaborators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CollaboratorServiceServer is the server API for CollaboratorService service.
+// All implementations must embed UnimplementedCollaboratorServiceServer
+// for forward compatibility.
+//
+// Roles streamers grant other users on a stream, or on every stream of their
+// channel when stream_id is 0
+type CollaboratorServiceServer interface {
+	SetCollaborator(context.Context, *SetCollaboratorRequest) (*CollaboratorResponse, error)
+	DeleteCollaborator(context.Context, *DeleteCollaboratorRequest) (*emptypb.Empty, error)
+	ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error)
+	DeleteStreamCollaborators(context.Context, *DeleteStreamCollaboratorsRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedCollaboratorServiceServer()
+}
+
+// UnimplementedCollaboratorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCollaboratorServiceServer struct{}
+
+func (UnimplementedCollaboratorServiceServer) SetCollaborator(context.Context, *SetCollaboratorRequest) (*CollaboratorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCollaborator not implemented")
+}
+func (UnimplementedCollaboratorServiceServer) DeleteCollaborator(context.Context, *DeleteCollaboratorRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollaborator not implemented")
+}
+func (UnimplementedCollaboratorServiceServer) ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollaborators not implemented")
+}
+func (UnimplementedCollaboratorServiceServer) DeleteStreamCollaborators(context.Context, *DeleteStreamCollaboratorsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStreamCollaborators not implemented")
+}
+func (UnimplementedCollaboratorServiceServer) mustEmbedUnimplementedCollaboratorServiceServer() {}
+func (UnimplementedCollaboratorServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeCollaboratorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CollaboratorServiceServer will
+// result in compilation errors.
+type UnsafeCollaboratorServiceServer interface {
+	mustEmbedUnimplementedCollaboratorServiceServer()
+}
+
+func RegisterCollaboratorServiceServer(s grpc.ServiceRegistrar, srv CollaboratorServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCollaboratorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CollaboratorService_ServiceDesc, srv)
+}
+
+func _CollaboratorService_SetCollaborator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCollaboratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollaboratorServiceServer).SetCollaborator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollaboratorService_SetCollaborator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollaboratorServiceServer).SetCollaborator(ctx, req.(*SetCollaboratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollaboratorService_DeleteCollaborator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollaboratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollaboratorServiceServer).DeleteCollaborator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollaboratorService_DeleteCollaborator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollaboratorServiceServer).DeleteCollaborator(ctx, req.(*DeleteCollaboratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollaboratorService_ListCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollaboratorServiceServer).ListCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollaboratorService_ListCollaborators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollaboratorServiceServer).ListCollaborators(ctx, req.(*ListCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollaboratorService_DeleteStreamCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStreamCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollaboratorServiceServer).DeleteStreamCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollaboratorService_DeleteStreamCollaborators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollaboratorServiceServer).DeleteStreamCollaborators(ctx, req.(*DeleteStreamCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CollaboratorService_ServiceDesc is the grpc.ServiceDesc for CollaboratorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CollaboratorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "streamdb.collaborator.CollaboratorService",
+	HandlerType: (*CollaboratorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetCollaborator",
+			Handler:    _CollaboratorService_SetCollaborator_Handler,
+		},
+		{
+			MethodName: "DeleteCollaborator",
+			Handler:    _CollaboratorService_DeleteCollaborator_Handler,
+		},
+		{
+			MethodName: "ListCollaborators",
+			Handler:    _CollaboratorService_ListCollaborators_Handler,
+		},
+		{
+			MethodName: "DeleteStreamCollaborators",
+			Handler:    _CollaboratorService_DeleteStreamCollaborators_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "streamdb/collaborator.proto",
+}
@@ -0,0 +1,409 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.1
+// 	protoc        v5.29.2
+// source: streamdb/rendition.proto
+
+// Copy of StreamDb/Protos/rendition.proto. The package is prefixed with
+// streamdb so that it can be linked next to the Go services, see wire.go.
+
+package streamdb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Replaces the ladder of a stream. renditions holds the specs of custom
+// ladders, such as "720p30@2500", and is empty for presets.
+type SetLadderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StreamId      int32                  `protobuf:"varint,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	Preset        string                 `protobuf:"bytes,2,opt,name=preset,proto3" json:"preset,omitempty"`
+	Renditions    []string               `protobuf:"bytes,3,rep,name=renditions,proto3" json:"renditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLadderRequest) Reset() {
+	*x = SetLadderRequest{}
+	mi := &file_streamdb_rendition_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLadderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLadderRequest) ProtoMessage() {}
+
+func (x *SetLadderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdb_rendition_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLadderRequest.ProtoReflect.Descriptor instead.
+func (*SetLadderRequest) Descriptor() ([]byte, []int) {
+	return file_streamdb_rendition_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetLadderRequest) GetStreamId() int32 {
+	if x != nil {
+		return x.StreamId
+	}
+	return 0
+}
+
+func (x *SetLadderRequest) GetPreset() string {
+	if x != nil {
+		return x.Preset
+	}
+	return ""
+}
+
+func (x *SetLadderRequest) GetRenditions() []string {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+// Streams without a ladder are left out of the response
+type GetLaddersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StreamIds     []int32                `protobuf:"varint,1,rep,packed,name=stream_ids,json=streamIds,proto3" json:"stream_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLaddersRequest) Reset() {
+	*x = GetLaddersRequest{}
+	mi := &file_streamdb_rendition_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLaddersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLaddersRequest) ProtoMessage() {}
+
+func (x *GetLaddersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdb_rendition_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLaddersRequest.ProtoReflect.Descriptor instead.
+func (*GetLaddersRequest) Descriptor() ([]byte, []int) {
+	return file_streamdb_rendition_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetLaddersRequest) GetStreamIds() []int32 {
+	if x != nil {
+		return x.StreamIds
+	}
+	return nil
+}
+
+type DeleteLadderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StreamId      int32                  `protobuf:"varint,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLadderRequest) Reset() {
+	*x = DeleteLadderRequest{}
+	mi := &file_streamdb_rendition_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLadderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLadderRequest) ProtoMessage() {}
+
+func (x *DeleteLadderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdb_rendition_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLadderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLadderRequest) Descriptor() ([]byte, []int) {
+	return file_streamdb_rendition_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeleteLadderRequest) GetStreamId() int32 {
+	if x != nil {
+		return x.StreamId
+	}
+	return 0
+}
+
+type LadderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StreamId      int32                  `protobuf:"varint,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	Preset        string                 `protobuf:"bytes,2,opt,name=preset,proto3" json:"preset,omitempty"`
+	Renditions    []string               `protobuf:"bytes,3,rep,name=renditions,proto3" json:"renditions,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LadderResponse) Reset() {
+	*x = LadderResponse{}
+	mi := &file_streamdb_rendition_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LadderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LadderResponse) ProtoMessage() {}
+
+func (x *LadderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdb_rendition_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LadderResponse.ProtoReflect.Descriptor instead.
+func (*LadderResponse) Descriptor() ([]byte, []int) {
+	return file_streamdb_rendition_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LadderResponse) GetStreamId() int32 {
+	if x != nil {
+		return x.StreamId
+	}
+	return 0
+}
+
+func (x *LadderResponse) GetPreset() string {
+	if x != nil {
+		return x.Preset
+	}
+	return ""
+}
+
+func (x *LadderResponse) GetRenditions() []string {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+func (x *LadderResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type GetLaddersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ladders       []*LadderResponse      `protobuf:"bytes,1,rep,name=ladders,proto3" json:"ladders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLaddersResponse) Reset() {
+	*x = GetLaddersResponse{}
+	mi := &file_streamdb_rendition_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLaddersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLaddersResponse) ProtoMessage() {}
+
+func (x *GetLaddersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdb_rendition_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLaddersResponse.ProtoReflect.Descriptor instead.
+func (*GetLaddersResponse) Descriptor() ([]byte, []int) {
+	return file_streamdb_rendition_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetLaddersResponse) GetLadders() []*LadderResponse {
+	if x != nil {
+		return x.Ladders
+	}
+	return nil
+}
+
+var File_streamdb_rendition_proto protoreflect.FileDescriptor
+
+var file_streamdb_rendition_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x64, 0x62, 0x2f, 0x72, 0x65, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x64, 0x62, 0x2e, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x1b,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x67, 0x0a, 0x10, 0x53,
+	0x65, 0x74, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x65, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x65, 0x73, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0x32, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x64, 0x64, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x73, 0x22, 0x32, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x22, 0x84, 0x01, 0x0a,
+	0x0e, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x65, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x65, 0x73, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x22, 0x52, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x6c, 0x61, 0x64,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x64, 0x62, 0x2e, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x4c, 0x61, 0x64, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07,
+	0x6c, 0x61, 0x64, 0x64, 0x65, 0x72, 0x73, 0x32, 0x97, 0x02, 0x0a, 0x10, 0x52, 0x65, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x09,
+	0x53, 0x65, 0x74, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72, 0x12, 0x24, 0x2e, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x64, 0x62, 0x2e, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53,
+	0x65, 0x74, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x64, 0x62, 0x2e, 0x72, 0x65, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x25, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x64, 0x62, 0x2e, 0x72, 0x65, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x64, 0x62, 0x2e, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4f, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x64, 0x64, 0x65, 0x72,
+	0x12, 0x27, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x64, 0x62, 0x2e, 0x72, 0x65, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x64, 0x64,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x42, 0x4a, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x63, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x75, 0x73, 0x33, 0x36, 0x30, 0x2f, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x64, 0x62, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x64, 0x62, 0x3b, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x64, 0x62, 0xaa, 0x02, 0x0f, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x44, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_streamdb_rendition_proto_rawDescOnce sync.Once
+	file_streamdb_rendition_proto_rawDescData = file_streamdb_rendition_proto_rawDesc
+)
+
+func file_streamdb_rendition_proto_rawDescGZIP() []byte {
+	file_streamdb_rendition_proto_rawDescOnce.Do(func() {
+		file_streamdb_rendition_proto_rawDescData = protoimpl.X.CompressGZIP(file_streamdb_rendition_proto_rawDescData)
+	})
+	return file_streamdb_rendition_proto_rawDescData
+}
+
+var file_streamdb_rendition_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_streamdb_rendition_proto_goTypes = []any{
+	(*SetLadderRequest)(nil),    // 0: streamdb.rendition.SetLadderRequest
+	(*GetLaddersRequest)(nil),   // 1: streamdb.rendition.GetLaddersRequest
+	(*DeleteLadderRequest)(nil), // 2: streamdb.rendition.DeleteLadderRequest
+	(*LadderResponse)(nil),      // 3: streamdb.rendition.LadderResponse
+	(*GetLaddersResponse)(nil),  // 4: streamdb.rendition.GetLaddersResponse
+	(*emptypb.Empty)(nil),       // 5: google.protobuf.Empty
+}
+var file_streamdb_rendition_proto_depIdxs = []int32{
+	3, // 0: streamdb.rendition.GetLaddersResponse.ladders:type_name -> streamdb.rendition.LadderResponse
+	0, // 1: streamdb.rendition.RenditionService.SetLadder:input_type -> streamdb.rendition.SetLadderRequest
+	1, // 2: streamdb.rendition.RenditionService.GetLadders:input_type -> streamdb.rendition.GetLaddersRequest
+	2, // 3: streamdb.rendition.RenditionService.DeleteLadder:input_type -> streamdb.rendition.DeleteLadderRequest
+	3, // 4: streamdb.rendition.RenditionService.SetLadder:output_type -> streamdb.rendition.LadderResponse
+	4, // 5: streamdb.rendition.RenditionService.GetLadders:output_type -> streamdb.rendition.GetLaddersResponse
+	5, // 6: streamdb.rendition.RenditionService.DeleteLadder:output_type -> google.protobuf.Empty
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_streamdb_rendition_proto_init() }
+func file_streamdb_rendition_proto_init() {
+	if File_streamdb_rendition_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_streamdb_rendition_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_streamdb_rendition_proto_goTypes,
+		DependencyIndexes: file_streamdb_rendition_proto_depIdxs,
+		MessageInfos:      file_streamdb_rendition_proto_msgTypes,
+	}.Build()
+	File_streamdb_rendition_proto = out.File
+	file_streamdb_rendition_proto_rawDesc = nil
+	file_streamdb_rendition_proto_goTypes = nil
+	file_streamdb_rendition_proto_depIdxs = nil
+}
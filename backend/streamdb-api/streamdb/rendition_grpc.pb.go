@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: streamdb/rendition.proto
+
+// Copy of StreamDb/Protos/rendition.proto. The package is prefixed with
+// streamdb so that it can be linked next to the Go services, see wire.go.
+
+package streamdb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RenditionService_SetLadder_FullMethodName    = "/streamdb.rendition.RenditionService/SetLadder"
+	RenditionService_GetLadders_FullMethodName   = "/streamdb.rendition.RenditionService/GetLadders"
+	RenditionService_DeleteLadder_FullMethodName = "/streamdb.rendition.RenditionService/DeleteLadder"
+)
+
+// RenditionServiceClient is the client API for RenditionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// The rendition ladders chosen for streams. Streams without one use the
+// default preset of stream-service, which also holds the presets.
+type RenditionServiceClient interface {
+	SetLadder(ctx context.Context, in *SetLadderRequest, opts ...grpc.CallOption) (*LadderResponse, error)
+	GetLadders(ctx context.Context, in *GetLaddersRequest, opts ...grpc.CallOption) (*GetLaddersResponse, error)
+	DeleteLadder(ctx context.Context, in *DeleteLadderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type renditionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRenditionServiceClient(cc grpc.ClientConnInterface) RenditionServiceClient {
+	return &renditionServiceClient{cc}
+}
+
+func (c *renditionServiceClient) SetLadder(ctx context.Context, in *SetLadderRequest, opts ...grpc.CallOption) (*LadderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LadderResponse)
+	err := c.cc.Invoke(ctx, RenditionService_SetLadder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *renditionServiceClient) GetLadders(ctx context.Context, in *GetLaddersRequest, opts ...grpc.CallOption) (*GetLaddersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLaddersResponse)
+	err := c.cc.Invoke(ctx, RenditionService_GetLadders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *renditionServiceClient) DeleteLadder(ctx context.Context, in *DeleteLadderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RenditionService_DeleteLadder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RenditionServiceServer is the server API for RenditionService service.
+// All implementations must embed UnimplementedRenditionServiceServer
+// for forward compatibility.
+//
+// The rendition ladders chosen for streams. Streams without one use the
+// default preset of stream-service, which also holds the presets.
+type RenditionServiceServer interface {
+	SetLadder(context.Context, *SetLadderRequest) (*LadderResponse, error)
+	GetLadders(context.Context, *GetLaddersRequest) (*GetLaddersResponse, error)
+	DeleteLadder(context.Context, *DeleteLadderRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedRenditionServiceServer()
+}
+
+// UnimplementedRenditionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRenditionServiceServer struct{}
+
+func (UnimplementedRenditionServiceServer) SetLadder(context.Context, *SetLadderRequest) (*LadderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLadder not implemented")
+}
+func (UnimplementedRenditionServiceServer) GetLadders(context.Context, *GetLaddersRequest) (*GetLaddersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLadders not implemented")
+}
+func (UnimplementedRenditionServiceServer) DeleteLadder(context.Context, *DeleteLadderRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLadder not implemented")
+}
+func (UnimplementedRenditionServiceServer) mustEmbedUnimplementedRenditionServiceServer() {}
+func (UnimplementedRenditionServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeRenditionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RenditionServiceServer will
+// result in compilation errors.
+type UnsafeRenditionServiceServer interface {
+	mustEmbedUnimplementedRenditionServiceServer()
+}
+
+func RegisterRenditionServiceServer(s grpc.ServiceRegistrar, srv RenditionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRenditionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RenditionService_ServiceDesc, srv)
+}
+
+func _RenditionService_SetLadder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLadderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RenditionServiceServer).SetLadder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RenditionService_SetLadder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RenditionServiceServer).SetLadder(ctx, req.(*SetLadderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RenditionService_GetLadders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLaddersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RenditionServiceServer).GetLadders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RenditionService_GetLadders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RenditionServiceServer).GetLadders(ctx, req.(*GetLaddersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RenditionService_DeleteLadder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLadderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RenditionServiceServer).DeleteLadder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RenditionService_DeleteLadder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RenditionServiceServer).DeleteLadder(ctx, req.(*DeleteLadderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RenditionService_ServiceDesc is the grpc.ServiceDesc for RenditionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RenditionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "streamdb.rendition.RenditionService",
+	HandlerType: (*RenditionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetLadder",
+			Handler:    _RenditionService_SetLadder_Handler,
+		},
+		{
+			MethodName: "GetLadders",
+			Handler:    _RenditionService_GetLadders_Handler,
+		},
+		{
+			MethodName: "DeleteLadder",
+			Handler:    _RenditionService_DeleteLadder_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "streamdb/rendition.proto",
+}
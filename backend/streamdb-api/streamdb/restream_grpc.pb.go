@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: streamdb/restream.proto
+
+// Copy of StreamDb/Protos/restream.proto. The package is prefixed with
+// streamdb so that it can be linked next to the Go services, see wire.go.
+
+package streamdb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RestreamService_CreateDestination_FullMethodName        = "/streamdb.restream.RestreamService/CreateDestination"
+	RestreamService_GetDestination_FullMethodName           = "/streamdb.restream.RestreamService/GetDestination"
+	RestreamService_UpdateDestination_FullMethodName        = "/streamdb.restream.RestreamService/UpdateDestination"
+	RestreamService_DeleteDestination_FullMethodName        = "/streamdb.restream.RestreamService/DeleteDestination"
+	RestreamService_ListDestinations_FullMethodName         = "/streamdb.restream.RestreamService/ListDestinations"
+	RestreamService_DeleteStreamDestinations_FullMethodName = "/streamdb.restream.RestreamService/DeleteStreamDestinations"
+)
+
+// RestreamServiceClient is the client API for RestreamService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Destinations streamers relay a stream to, or every stream of their channel
+// when stream_id is 0. Stream keys are stored as sealed by stream-service and
+// never in the clear.
+type RestreamServiceClient interface {
+	CreateDestination(ctx context.Context, in *CreateDestinationRequest, opts ...grpc.CallOption) (*DestinationResponse, error)
+	GetDestination(ctx context.Context, in *GetDestinationRequest, opts ...grpc.CallOption) (*DestinationResponse, error)
+	UpdateDestination(ctx context.Context, in *UpdateDestinationRequest, opts ...grpc.CallOption) (*DestinationResponse, error)
+	DeleteDestination(ctx context.Context, in *DeleteDestinationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListDestinations(ctx context.Context, in *ListDestinationsRequest, opts ...grpc.CallOption) (*ListDestinationsResponse, error)
+	DeleteStreamDestinations(ctx context.Context, in *DeleteStreamDestinationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type restreamServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRestreamServiceClient(cc grpc.ClientConnInterface) RestreamServiceClient {
+	return &restreamServiceClient{cc}
+}
+
+func (c *restreamServiceClient) CreateDestination(ctx context.Context, in *CreateDestinationRequest, opts ...grpc.CallOption) (*DestinationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DestinationResponse)
+	err := c.cc.Invoke(ctx, RestreamService_CreateDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restreamServiceClient) GetDestination(ctx context.Context, in *GetDestinationRequest, opts ...grpc.CallOption) (*DestinationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DestinationResponse)
+	err := c.cc.Invoke(ctx, RestreamService_GetDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restreamServiceClient) UpdateDestination(ctx context.Context, in *UpdateDestinationRequest, opts ...grpc.CallOption) (*DestinationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DestinationResponse)
+	err := c.cc.Invoke(ctx, RestreamService_UpdateDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restreamServiceClient) DeleteDestination(ctx context.Context, in *DeleteDestinationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RestreamService_DeleteDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restreamServiceClient) ListDestinations(ctx context.Context, in *ListDestinationsRequest, opts ...grpc.CallOption) (*ListDestinationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDestinationsResponse)
+	err := c.cc.Invoke(ctx, RestreamService_ListDestinations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restreamServiceClient) DeleteStreamDestinations(ctx context.Context, in *DeleteStreamDestinationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RestreamService_DeleteStreamDestinations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RestreamServiceServer is the server API for RestreamService service.
+// All implementations must embed UnimplementedRestreamServiceServer
+// for forward compatibility.
+//
+// Destinations streamers relay a stream to, or every stream of their channel
+// when stream_id is 0. Stream keys are stored as sealed by stream-service and
+// never in the clear.
+type RestreamServiceServer interface {
+	CreateDestination(context.Context, *CreateDestinationRequest) (*DestinationResponse, error)
+	GetDestination(context.Context, *GetDestinationRequest) (*DestinationResponse, error)
+	UpdateDestination(context.Context, *UpdateDestinationRequest) (*DestinationResponse, error)
+	DeleteDestination(context.Context, *DeleteDestinationRequest) (*emptypb.Empty, error)
+	ListDestinations(context.Context, *ListDestinationsRequest) (*ListDestinationsResponse, error)
+	DeleteStreamDestinations(context.Context, *DeleteStreamDestinationsRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedRestreamServiceServer()
+}
+
+// UnimplementedRestreamServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRestreamServiceServer struct{}
+
+func (UnimplementedRestreamServiceServer) CreateDestination(context.Context, *CreateDestinationRequest) (*DestinationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDestination not implemented")
+}
+func (UnimplementedRestreamServiceServer) GetDestination(context.Context, *GetDestinationRequest) (*DestinationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDestination not implemented")
+}
+func (UnimplementedRestreamServiceServer) UpdateDestination(context.Context, *UpdateDestinationRequest) (*DestinationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDestination not implemented")
+}
+func (UnimplementedRestreamServiceServer) DeleteDestination(context.Context, *DeleteDestinationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDestination not implemented")
+}
+func (UnimplementedRestreamServiceServer) ListDestinations(context.Context, *ListDestinationsRequest) (*ListDestinationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDestinations not implemented")
+}
+func (UnimplementedRestreamServiceServer) DeleteStreamDestinations(context.Context, *DeleteStreamDestinationsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStreamDestinations not implemented")
+}
+func (UnimplementedRestreamServiceServer) mustEmbedUnimplementedRestreamServiceServer() {}
+func (UnimplementedRestreamServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeRestreamServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RestreamServiceServer will
+// result in compilation errors.
+type UnsafeRestreamServiceServer interface {
+	mustEmbedUnimplementedRestreamServiceServer()
+}
+
+func RegisterRestreamServiceServer(s grpc.ServiceRegistrar, srv RestreamServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRestreamServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RestreamService_ServiceDesc, srv)
+}
+
+func _RestreamService_CreateDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDestinationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestreamServiceServer).CreateDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestreamService_CreateDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestreamServiceServer).CreateDestination(ctx, req.(*CreateDestinationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestreamService_GetDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDestinationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestreamServiceServer).GetDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestreamService_GetDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestreamServiceServer).GetDestination(ctx, req.(*GetDestinationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestreamService_UpdateDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDestinationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestreamServiceServer).UpdateDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestreamService_UpdateDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestreamServiceServer).UpdateDestination(ctx, req.(*UpdateDestinationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestreamService_DeleteDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDestinationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestreamServiceServer).DeleteDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestreamService_DeleteDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestreamServiceServer).DeleteDestination(ctx, req.(*DeleteDestinationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestreamService_ListDestinations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDestinationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestreamServiceServer).ListDestinations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestreamService_ListDestinations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestreamServiceServer).ListDestinations(ctx, req.(*ListDestinationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestreamService_DeleteStreamDestinations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStreamDestinationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestreamServiceServer).DeleteStreamDestinations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestreamService_DeleteStreamDestinations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestreamServiceServer).DeleteStreamDestinations(ctx, req.(*DeleteStreamDestinationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RestreamService_ServiceDesc is the grpc.ServiceDesc for RestreamService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RestreamService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "streamdb.restream.RestreamService",
+	HandlerType: (*RestreamServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateDestination",
+			Handler:    _RestreamService_CreateDestination_Handler,
+		},
+		{
+			MethodName: "GetDestination",
+			Handler:    _RestreamService_GetDestination_Handler,
+		},
+		{
+			MethodName: "UpdateDestination",
+			Handler:    _RestreamService_UpdateDestination_Handler,
+		},
+		{
+			MethodName: "DeleteDestination",
+			Handler:    _RestreamService_DeleteDestination_Handler,
+		},
+		{
+			MethodName: "ListDestinations",
+			Handler:    _RestreamService_ListDestinations_Handler,
+		},
+		{
+			MethodName: "DeleteStreamDestinations",
+			Handler:    _RestreamService_DeleteStreamDestinations_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "streamdb/restream.proto",
+}
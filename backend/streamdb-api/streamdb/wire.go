@@ -0,0 +1,54 @@
+package streamdb
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// StreamDb declares its APIs in the stream, user, comment, collaborator,
+// restream, telemetry, rendition, clip, audit, analytics and common proto
+// packages, and the Go services reuse some of those names for their own
+// APIs. The copies in this package are declared under streamdb instead so
+// that both can be linked in one binary, and the names are translated back
+// on the wire.
+
+// prefix is added to the proto packages of StreamDb
+const prefix = "streamdb."
+
+// WireName returns the name StreamDb serves a service or a method under,
+// such as "stream.StreamService" for "streamdb.stream.StreamService"
+func WireName(name string) string {
+	if method, ok := strings.CutPrefix(name, "/"+prefix); ok {
+		return "/" + method
+	}
+	return strings.TrimPrefix(name, prefix)
+}
+
+// WireServiceDesc returns a copy of desc that registers the service under
+// the name StreamDb serves it under
+func WireServiceDesc(desc *grpc.ServiceDesc) *grpc.ServiceDesc {
+	wire := *desc
+	wire.ServiceName = WireName(desc.ServiceName)
+	return &wire
+}
+
+// WireConn calls the services of StreamDb through conn under their wire
+// names. The clients of this package are created with it, for example
+// NewStreamServiceClient(WireConn(conn)).
+func WireConn(conn grpc.ClientConnInterface) grpc.ClientConnInterface {
+	return wireConn{conn}
+}
+
+type wireConn struct {
+	conn grpc.ClientConnInterface
+}
+
+func (c wireConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return c.conn.Invoke(ctx, WireName(method), args, reply, opts...)
+}
+
+func (c wireConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.conn.NewStream(ctx, desc, WireName(method), opts...)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	platformconfig "github.com/clementus360/platform/config"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/streamdb-memory/internal/config"
+	"github.com/clementus360/streamdb-memory/server"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	// load the configuration from defaults, config file, environment and flags
+	cfg, err := config.LoadConfig(os.Args[1:])
+	if cfg.PrintConfig {
+		platformconfig.Print(os.Stdout, cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
+	if cfg.PrintConfig {
+		return
+	}
+
+	logger := logging.New("streamdb-memory", cfg.Log.Level, cfg.Log.Format)
+	slog.SetDefault(logger)
+
+	st, err := store.New(cfg.Store.SnapshotFile)
+	if err != nil {
+		logger.Error("Failed to load store", "error", err)
+		os.Exit(1)
+	}
+	if cfg.Store.SnapshotFile != "" {
+		logger.Info("Persisting data", "snapshot", cfg.Store.SnapshotFile)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.Server.GRPCPort))
+	if err != nil {
+		logger.Error("Failed to listen", "error", err)
+		os.Exit(1)
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(logger)),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(logger)),
+	)
+
+	server.New(st).Register(grpcServer)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	go func() {
+		logger.Info("gRPC server is listening", "port", cfg.Server.GRPCPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			logger.Error("Failed to serve gRPC", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("Shutting down server...")
+	healthServer.Shutdown()
+	grpcServer.GracefulStop()
+	logger.InfoContext(context.Background(), "Server exiting")
+}
@@ -0,0 +1,29 @@
+module github.com/clementus360/streamdb-memory
+
+go 1.23.5
+
+require (
+	github.com/Josy-coder/db-service v0.0.0-00010101000000-000000000000
+	github.com/clementus360/platform v0.0.0-00010101000000-000000000000
+	github.com/clementus360/streamdb-api v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.70.0
+	google.golang.org/protobuf v1.36.4
+)
+
+require (
+	github.com/joho/godotenv v1.5.1 // indirect
+	go.opentelemetry.io/otel v1.34.0 // indirect
+	go.opentelemetry.io/otel/trace v1.34.0 // indirect
+	golang.org/x/net v0.34.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250115164207-1a7da9e5054f // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The services share the platform module of this repository
+replace github.com/clementus360/platform => ../platform
+
+replace github.com/clementus360/streamdb-api => ../streamdb-api
+
+replace github.com/Josy-coder/db-service => ../db-service
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	platformconfig "github.com/clementus360/platform/config"
+)
+
+// Config is the complete streamdb-memory configuration
+type Config struct {
+	Server ServerConfig `yaml:"server"`
+	Store  StoreConfig  `yaml:"store"`
+	Log    LogConfig    `yaml:"log"`
+
+	// PrintConfig dumps the effective configuration and exits
+	PrintConfig bool `yaml:"-" flag:"print-config"`
+}
+
+type ServerConfig struct {
+	GRPCPort string `yaml:"grpc_port" env:"SERVER_GRPC_PORT" flag:"grpc-port" default:"5001" required:"true"`
+}
+
+type StoreConfig struct {
+	// SnapshotFile persists the data between runs, it is kept in memory only
+	// when empty
+	SnapshotFile string `yaml:"snapshot_file" env:"STORE_SNAPSHOT_FILE" flag:"snapshot"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL" flag:"log-level" default:"info"`
+	Format string `yaml:"format" env:"LOG_FORMAT" flag:"log-format" default:"text"`
+}
+
+// LoadConfig reads the configuration from defaults, an optional YAML file,
+// the environment and the command-line arguments
+func LoadConfig(args []string) (*Config, error) {
+	cfg := &Config{}
+	if err := platformconfig.Load("streamdb-memory", cfg, args); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// Validate checks values that cannot be expressed with struct tags
+func (c *Config) Validate() error {
+	var errs []error
+
+	if n, err := strconv.Atoi(c.Server.GRPCPort); err != nil || n < 1 || n > 65535 {
+		errs = append(errs, fmt.Errorf("SERVER_GRPC_PORT must be a port number, got %q", c.Server.GRPCPort))
+	}
+	switch c.Log.Format {
+	case "json", "text":
+	default:
+		errs = append(errs, fmt.Errorf("LOG_FORMAT must be json or text, got %q", c.Log.Format))
+	}
+
+	return errors.Join(errs...)
+}
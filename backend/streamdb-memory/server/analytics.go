@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var viewerSessionColumns = map[string]int{
+	"public_id":   64,
+	"viewer_id":   100,
+	"client_type": 20,
+}
+
+type AnalyticsServer struct {
+	pb.UnimplementedAnalyticsServiceServer
+	store *store.Store
+}
+
+func (s *AnalyticsServer) AddViewerSession(ctx context.Context, req *pb.AddViewerSessionRequest) (*pb.ViewerSessionResponse, error) {
+	var errs []string
+	if isBlank(req.Id) {
+		errs = append(errs, "Viewer session ID is required")
+	}
+	if req.StreamId <= 0 {
+		errs = append(errs, "Invalid stream ID")
+	}
+	if isBlank(req.ViewerId) {
+		errs = append(errs, "Viewer ID is required")
+	}
+	if isBlank(req.ClientType) {
+		errs = append(errs, "Client type is required")
+	}
+	joinedAt, ok := parseFilterTime(req.JoinedAt)
+	if !ok {
+		errs = append(errs, "Invalid joined at time")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.ViewerSessionResponse
+	err := s.store.Write(func(d *store.Data) error {
+		if stream := d.Stream(req.StreamId); stream == nil || stream.Deleted() {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+		if d.ViewerSession(req.Id) != nil {
+			return status.Error(codes.AlreadyExists, "Viewer session already exists")
+		}
+
+		now := s.store.Now()
+		session := &store.ViewerSession{
+			BaseEntity: store.BaseEntity{ID: d.NextViewerSessionID(), CreatedAt: now, UpdatedAt: now},
+			PublicID:   req.Id,
+			StreamID:   req.StreamId,
+			ViewerID:   req.ViewerId,
+			ClientType: req.ClientType,
+			JoinedAt:   joinedAt.UTC(),
+		}
+		if err := checkLength("add viewer session", viewerSessionColumns, map[string]string{
+			"public_id": session.PublicID, "viewer_id": session.ViewerID, "client_type": session.ClientType,
+		}); err != nil {
+			return err
+		}
+		d.ViewerSessions = append(d.ViewerSessions, session)
+		// the stream is live again, so its rollup is out of date
+		d.DeleteStreamRollup(req.StreamId)
+		resp = toViewerSessionResponse(session)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *AnalyticsServer) GetViewerSession(ctx context.Context, req *pb.GetViewerSessionRequest) (*pb.ViewerSessionResponse, error) {
+	if isBlank(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "Viewer session ID is required")
+	}
+
+	var resp *pb.ViewerSessionResponse
+	err := s.store.Read(func(d *store.Data) error {
+		session := d.ViewerSession(req.Id)
+		if session == nil {
+			return status.Error(codes.NotFound, "Viewer session not found")
+		}
+		resp = toViewerSessionResponse(session)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *AnalyticsServer) EndViewerSession(ctx context.Context, req *pb.EndViewerSessionRequest) (*pb.ViewerSessionResponse, error) {
+	var errs []string
+	if isBlank(req.Id) {
+		errs = append(errs, "Viewer session ID is required")
+	}
+	leftAt, ok := parseFilterTime(req.LeftAt)
+	if !ok {
+		errs = append(errs, "Invalid left at time")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.ViewerSessionResponse
+	err := s.store.Write(func(d *store.Data) error {
+		session := d.ViewerSession(req.Id)
+		if session == nil {
+			return status.Error(codes.NotFound, "Viewer session not found")
+		}
+		if session.LeftAt == nil {
+			closeSession(session, leftAt.UTC(), s.store.Now())
+		}
+		resp = toViewerSessionResponse(session)
+		return nil
+	})
+	return resp, err
+}
+
+// ListViewerSessions mirrors AnalyticsService.ListViewerSessions, which
+// returns the sessions of a stream in the order they were joined
+func (s *AnalyticsServer) ListViewerSessions(ctx context.Context, req *pb.ListViewerSessionsRequest) (*pb.ListViewerSessionsResponse, error) {
+	if req.StreamId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	resp := &pb.ListViewerSessionsResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var sessions []*store.ViewerSession
+		for _, session := range d.ViewerSessions {
+			switch {
+			case session == nil, session.StreamID != req.StreamId,
+				req.ViewerId != "" && session.ViewerID != req.ViewerId,
+				req.Open && session.LeftAt != nil:
+				continue
+			}
+			sessions = append(sessions, session)
+		}
+		slices.SortStableFunc(sessions, func(a, b *store.ViewerSession) int { return a.JoinedAt.Compare(b.JoinedAt) })
+		for _, session := range sessions {
+			resp.Sessions = append(resp.Sessions, toViewerSessionResponse(session))
+		}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *AnalyticsServer) CountOpenViewerSessions(ctx context.Context, req *emptypb.Empty) (*pb.CountOpenViewerSessionsResponse, error) {
+	resp := &pb.CountOpenViewerSessionsResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		for _, session := range d.ViewerSessions {
+			if session != nil && session.LeftAt == nil {
+				resp.Count++
+			}
+		}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *AnalyticsServer) DeleteViewerSessions(ctx context.Context, req *pb.DeleteViewerSessionsRequest) (*emptypb.Empty, error) {
+	rolledUpBefore, ok := parseFilterTime(req.RolledUpBefore)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "Invalid rolled up before time")
+	}
+
+	err := s.store.Write(func(d *store.Data) error {
+		d.DeleteViewerSessions(func(session *store.ViewerSession) bool {
+			rollup := d.Rollup(session.StreamID)
+			return rollup != nil && rollup.ComputedAt.Before(rolledUpBefore)
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AnalyticsServer) SetRollup(ctx context.Context, req *pb.SetRollupRequest) (*pb.RollupResponse, error) {
+	var errs []string
+	if req.StreamId <= 0 {
+		errs = append(errs, "Invalid stream ID")
+	}
+	if isBlank(req.Summary) {
+		errs = append(errs, "Summary is required")
+	}
+	computedAt, ok := parseFilterTime(req.ComputedAt)
+	if !ok {
+		errs = append(errs, "Invalid computed at time")
+	}
+	closeAt, ok := parseFilterTime(req.CloseSessionsAt)
+	if !ok {
+		errs = append(errs, "Invalid close sessions at time")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.RollupResponse
+	err := s.store.Write(func(d *store.Data) error {
+		// streams are rolled up once deleted too, until they are purged
+		if d.Stream(req.StreamId) == nil {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+
+		now := s.store.Now()
+		for _, session := range d.ViewerSessions {
+			if session != nil && session.StreamID == req.StreamId && session.LeftAt == nil {
+				closeSession(session, closeAt.UTC(), now)
+			}
+		}
+
+		rollup := d.Rollup(req.StreamId)
+		if rollup == nil {
+			rollup = &store.AnalyticsRollup{
+				BaseEntity: store.BaseEntity{ID: d.NextRollupID(), CreatedAt: now},
+				StreamID:   req.StreamId,
+			}
+			d.Rollups = append(d.Rollups, rollup)
+		}
+		rollup.Summary = req.Summary
+		rollup.ComputedAt = computedAt.UTC()
+		rollup.UpdatedAt = now
+		resp = toRollupResponse(rollup)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *AnalyticsServer) GetRollup(ctx context.Context, req *pb.GetRollupRequest) (*pb.RollupResponse, error) {
+	if req.StreamId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	var resp *pb.RollupResponse
+	err := s.store.Read(func(d *store.Data) error {
+		rollup := d.Rollup(req.StreamId)
+		if rollup == nil {
+			return status.Error(codes.NotFound, "Rollup not found")
+		}
+		resp = toRollupResponse(rollup)
+		return nil
+	})
+	return resp, err
+}
+
+// ListPendingRollups mirrors AnalyticsService.ListPendingRollups, which
+// returns the ids in ascending order
+func (s *AnalyticsServer) ListPendingRollups(ctx context.Context, req *emptypb.Empty) (*pb.ListPendingRollupsResponse, error) {
+	resp := &pb.ListPendingRollupsResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		for _, session := range d.ViewerSessions {
+			if session != nil && d.Rollup(session.StreamID) == nil && !slices.Contains(resp.StreamIds, session.StreamID) {
+				resp.StreamIds = append(resp.StreamIds, session.StreamID)
+			}
+		}
+		slices.Sort(resp.StreamIds)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *AnalyticsServer) DeleteStreamAnalytics(ctx context.Context, req *pb.DeleteStreamAnalyticsRequest) (*emptypb.Empty, error) {
+	if req.StreamId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	err := s.store.Write(func(d *store.Data) error {
+		d.DeleteViewerSessions(func(session *store.ViewerSession) bool { return session.StreamID == req.StreamId })
+		d.DeleteStreamRollup(req.StreamId)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// closeSession ends an open session at leftAt, or when it was joined if
+// that is later
+func closeSession(session *store.ViewerSession, leftAt, now time.Time) {
+	if leftAt.Before(session.JoinedAt) {
+		leftAt = session.JoinedAt
+	}
+	session.LeftAt = &leftAt
+	session.UpdatedAt = now
+}
+
+func toViewerSessionResponse(session *store.ViewerSession) *pb.ViewerSessionResponse {
+	resp := &pb.ViewerSessionResponse{
+		Id:         session.PublicID,
+		StreamId:   session.StreamID,
+		ViewerId:   session.ViewerID,
+		ClientType: session.ClientType,
+		JoinedAt:   session.JoinedAt.Format(CreatedAtFormat),
+	}
+	if session.LeftAt != nil {
+		resp.LeftAt = session.LeftAt.Format(CreatedAtFormat)
+	}
+	return resp
+}
+
+func toRollupResponse(rollup *store.AnalyticsRollup) *pb.RollupResponse {
+	return &pb.RollupResponse{
+		StreamId:   rollup.StreamID,
+		Summary:    rollup.Summary,
+		ComputedAt: rollup.ComputedAt.Format(CreatedAtFormat),
+	}
+}
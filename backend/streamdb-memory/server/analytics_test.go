@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestAnalytics(t *testing.T) {
+	wire := dial(t)
+	streams, users, analytics := pb.NewStreamServiceClient(wire), pb.NewUserServiceClient(wire), pb.NewAnalyticsServiceClient(wire)
+	ctx := context.Background()
+
+	user, err := users.CreateUser(ctx, &pb.CreateUserRequest{Email: "alice@example.com", FirstName: "alice", LastName: "Tester", ProfileImageUrl: "https://example.com/alice.png", ClerkId: "user_alice"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	start := time.Now().UTC().Add(time.Hour)
+	var ids []int32
+	for _, title := range []string{"first", "second"} {
+		stream, err := streams.CreateStream(ctx, &pb.CreateStreamRequest{
+			Title:      title,
+			StartTime:  start.Format(TimeFormat),
+			EndTime:    start.Add(time.Hour).Format(TimeFormat),
+			StreamKey:  "key-" + title,
+			Resolution: "1920x1080",
+			Bitrate:    6000,
+			Framerate:  30,
+			Status:     pb.StreamStatus_SCHEDULED,
+			UserId:     int64(user.Id),
+		})
+		if err != nil {
+			t.Fatalf("CreateStream(%s): %v", title, err)
+		}
+		ids = append(ids, stream.Id)
+	}
+	first, second := ids[0], ids[1]
+
+	joined := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	join := func(id string, streamID int32, viewer string, minute int) {
+		t.Helper()
+		_, err := analytics.AddViewerSession(ctx, &pb.AddViewerSessionRequest{
+			Id:         id,
+			StreamId:   streamID,
+			ViewerId:   viewer,
+			ClientType: "web",
+			JoinedAt:   joined.Add(time.Duration(minute) * time.Minute).Format(TimeFormat),
+		})
+		if err != nil {
+			t.Fatalf("AddViewerSession(%s): %v", id, err)
+		}
+	}
+	join("b", first, "viewer-2", 5)
+	join("a", first, "viewer-1", 0)
+	join("c", second, "viewer-1", 0)
+
+	for _, req := range []*pb.AddViewerSessionRequest{
+		{StreamId: first, ViewerId: "viewer-1", ClientType: "web", JoinedAt: joined.Format(TimeFormat)},
+		{Id: "d", StreamId: first, ClientType: "web", JoinedAt: joined.Format(TimeFormat)},
+		{Id: "d", StreamId: first, ViewerId: "viewer-1", ClientType: "web", JoinedAt: "yesterday"},
+	} {
+		if _, err := analytics.AddViewerSession(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("AddViewerSession(%v) failed with %v, want InvalidArgument", req, err)
+		}
+	}
+	duplicate := &pb.AddViewerSessionRequest{Id: "a", StreamId: first, ViewerId: "viewer-1", ClientType: "web", JoinedAt: joined.Format(TimeFormat)}
+	if _, err := analytics.AddViewerSession(ctx, duplicate); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("AddViewerSession of a taken id failed with %v, want AlreadyExists", err)
+	}
+	duplicate.Id, duplicate.StreamId = "e", 99
+	if _, err := analytics.AddViewerSession(ctx, duplicate); status.Code(err) != codes.NotFound {
+		t.Errorf("AddViewerSession on a missing stream failed with %v, want NotFound", err)
+	}
+
+	list := func(req *pb.ListViewerSessionsRequest) string {
+		t.Helper()
+		resp, err := analytics.ListViewerSessions(ctx, req)
+		if err != nil {
+			t.Fatalf("ListViewerSessions(%v): %v", req, err)
+		}
+		var ids []string
+		for _, session := range resp.Sessions {
+			ids = append(ids, session.Id)
+		}
+		return fmt.Sprint(ids)
+	}
+	// in the order they were joined
+	if got := list(&pb.ListViewerSessionsRequest{StreamId: first}); got != "[a b]" {
+		t.Errorf("sessions of the first stream are %s, want [a b]", got)
+	}
+	if got := list(&pb.ListViewerSessionsRequest{StreamId: first, ViewerId: "viewer-2"}); got != "[b]" {
+		t.Errorf("sessions of viewer-2 are %s, want [b]", got)
+	}
+
+	// Sessions are closed once, and never before they were joined
+	ended, err := analytics.EndViewerSession(ctx, &pb.EndViewerSessionRequest{Id: "b", LeftAt: joined.Format(TimeFormat)})
+	if err != nil {
+		t.Fatalf("EndViewerSession: %v", err)
+	}
+	if ended.LeftAt != ended.JoinedAt {
+		t.Errorf("session left at %s before it was joined at %s", ended.LeftAt, ended.JoinedAt)
+	}
+	again, err := analytics.EndViewerSession(ctx, &pb.EndViewerSessionRequest{Id: "b", LeftAt: joined.Add(time.Hour).Format(TimeFormat)})
+	if err != nil {
+		t.Fatalf("EndViewerSession again: %v", err)
+	}
+	if again.LeftAt != ended.LeftAt {
+		t.Errorf("ending a closed session moved left_at to %s", again.LeftAt)
+	}
+	if _, err := analytics.GetViewerSession(ctx, &pb.GetViewerSessionRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetViewerSession of a missing session failed with %v, want NotFound", err)
+	}
+	if got := list(&pb.ListViewerSessionsRequest{StreamId: first, Open: true}); got != "[a]" {
+		t.Errorf("open sessions of the first stream are %s, want [a]", got)
+	}
+	count, err := analytics.CountOpenViewerSessions(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("CountOpenViewerSessions: %v", err)
+	}
+	if count.Count != 2 {
+		t.Errorf("%d sessions are open, want 2", count.Count)
+	}
+
+	// Rollups close the sessions left open and leave the stream pending no
+	// more
+	pending := func() string {
+		t.Helper()
+		resp, err := analytics.ListPendingRollups(ctx, &emptypb.Empty{})
+		if err != nil {
+			t.Fatalf("ListPendingRollups: %v", err)
+		}
+		return fmt.Sprint(resp.StreamIds)
+	}
+	if got, want := pending(), fmt.Sprint([]int32{first, second}); got != want {
+		t.Errorf("pending rollups are %s, want %s", got, want)
+	}
+	if _, err := analytics.GetRollup(ctx, &pb.GetRollupRequest{StreamId: first}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetRollup before the rollup failed with %v, want NotFound", err)
+	}
+	computed := joined.Add(2 * time.Hour)
+	_, err = analytics.SetRollup(ctx, &pb.SetRollupRequest{
+		StreamId:        first,
+		Summary:         `{"unique_viewers":2}`,
+		ComputedAt:      computed.Format(TimeFormat),
+		CloseSessionsAt: joined.Add(time.Hour).Format(TimeFormat),
+	})
+	if err != nil {
+		t.Fatalf("SetRollup: %v", err)
+	}
+	rollup, err := analytics.GetRollup(ctx, &pb.GetRollupRequest{StreamId: first})
+	if err != nil {
+		t.Fatalf("GetRollup: %v", err)
+	}
+	if rollup.Summary != `{"unique_viewers":2}` || rollup.ComputedAt != computed.Format(CreatedAtFormat) {
+		t.Errorf("GetRollup returned %v", rollup)
+	}
+	closed, err := analytics.GetViewerSession(ctx, &pb.GetViewerSessionRequest{Id: "a"})
+	if err != nil {
+		t.Fatalf("GetViewerSession: %v", err)
+	}
+	if closed.LeftAt != joined.Add(time.Hour).Format(CreatedAtFormat) {
+		t.Errorf("session left open was closed at %q", closed.LeftAt)
+	}
+	if got, want := pending(), fmt.Sprint([]int32{second}); got != want {
+		t.Errorf("pending rollups are %s after the rollup, want %s", got, want)
+	}
+
+	// Sessions of streams rolled up long enough ago expire, their rollups
+	// stay
+	if _, err := analytics.DeleteViewerSessions(ctx, &pb.DeleteViewerSessionsRequest{RolledUpBefore: computed.Add(time.Second).Format(TimeFormat)}); err != nil {
+		t.Fatalf("DeleteViewerSessions: %v", err)
+	}
+	if got := list(&pb.ListViewerSessionsRequest{StreamId: first}); got != "[]" {
+		t.Errorf("sessions of the first stream are %s after they expired", got)
+	}
+	if got := list(&pb.ListViewerSessionsRequest{StreamId: second}); got != "[c]" {
+		t.Errorf("sessions of the second stream are %s, want [c]", got)
+	}
+	if _, err := analytics.GetRollup(ctx, &pb.GetRollupRequest{StreamId: first}); err != nil {
+		t.Errorf("GetRollup after the sessions expired: %v", err)
+	}
+
+	// A stream going live again drops its rollup
+	join("f", first, "viewer-1", 180)
+	if _, err := analytics.GetRollup(ctx, &pb.GetRollupRequest{StreamId: first}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetRollup of a live stream failed with %v, want NotFound", err)
+	}
+
+	if _, err := analytics.DeleteStreamAnalytics(ctx, &pb.DeleteStreamAnalyticsRequest{StreamId: first}); err != nil {
+		t.Fatalf("DeleteStreamAnalytics: %v", err)
+	}
+	if got := list(&pb.ListViewerSessionsRequest{StreamId: first}); got != "[]" {
+		t.Errorf("sessions of the first stream are %s after DeleteStreamAnalytics", got)
+	}
+
+	// purging a stream cascades to its analytics
+	if _, err := streams.DeleteStream(ctx, &pb.DeleteStreamRequest{Id: second}); err != nil {
+		t.Fatalf("DeleteStream: %v", err)
+	}
+	if _, err := streams.PurgeStream(ctx, &pb.PurgeStreamRequest{Id: second}); err != nil {
+		t.Fatalf("PurgeStream: %v", err)
+	}
+	if _, err := analytics.GetViewerSession(ctx, &pb.GetViewerSessionRequest{Id: "c"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetViewerSession of a purged stream failed with %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+)
+
+// Page sizes of AuditService.QueryAuditLog, which pages further than the
+// other services of StreamDb
+const (
+	auditDefaultPageSize = 20
+	auditMaxPageSize     = 100
+)
+
+// auditColumns are the lengths of the text columns of audit entries
+var auditColumns = map[string]int{
+	"service":       50,
+	"method":        200,
+	"action":        20,
+	"actor_roles":   200,
+	"actor_service": 50,
+	"target_type":   50,
+	"target_id":     100,
+	"request_id":    100,
+}
+
+type AuditServer struct {
+	pb.UnimplementedAuditServiceServer
+	store *store.Store
+}
+
+func (s *AuditServer) AppendAuditEntry(ctx context.Context, req *pb.AuditEntry) (*pb.AuditEntry, error) {
+	var errs []string
+	recordedAt, ok := parseFilterTime(req.RecordedAt)
+	if !ok {
+		errs = append(errs, "Invalid recorded at time")
+	}
+	if isBlank(req.Service) {
+		errs = append(errs, "Service is required")
+	}
+	if isBlank(req.Method) {
+		errs = append(errs, "Method is required")
+	}
+	if isBlank(req.Action) {
+		errs = append(errs, "Action is required")
+	}
+	if isBlank(req.TargetType) {
+		errs = append(errs, "Target type is required")
+	}
+	if req.ActorId < 0 {
+		errs = append(errs, "Invalid actor ID")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.AuditEntry
+	err := s.store.Write(func(d *store.Data) error {
+		now := s.store.Now()
+		entry := &store.AuditEntry{
+			BaseEntity:   store.BaseEntity{ID: d.NextAuditEntryID(), CreatedAt: now, UpdatedAt: now},
+			RecordedAt:   recordedAt.UTC(),
+			Service:      req.Service,
+			Method:       req.Method,
+			Action:       req.Action,
+			ActorID:      req.ActorId,
+			ActorRoles:   req.ActorRoles,
+			ActorService: req.ActorService,
+			TargetType:   req.TargetType,
+			TargetID:     req.TargetId,
+			RequestID:    req.RequestId,
+		}
+		for _, change := range req.Changes {
+			entry.Changes = append(entry.Changes, store.AuditChange{Field: change.Field, Before: change.Before, After: change.After})
+		}
+		if err := checkLength("append audit entry", auditColumns, map[string]string{
+			"service": entry.Service, "method": entry.Method, "action": entry.Action,
+			"actor_roles": strings.Join(entry.ActorRoles, ","), "actor_service": entry.ActorService,
+			"target_type": entry.TargetType, "target_id": entry.TargetID, "request_id": entry.RequestID,
+		}); err != nil {
+			return err
+		}
+		d.AuditEntries = append(d.AuditEntries, entry)
+		resp = toAuditEntryResponse(entry)
+		return nil
+	})
+	return resp, err
+}
+
+// QueryAuditLog mirrors AuditService.QueryAuditLog, which returns the
+// entries of a service newest first
+func (s *AuditServer) QueryAuditLog(ctx context.Context, req *pb.QueryAuditLogRequest) (*pb.QueryAuditLogResponse, error) {
+	var errs []string
+	if isBlank(req.Service) {
+		errs = append(errs, "Service is required")
+	}
+	if req.PageNumber < 0 || req.PageSize < 0 {
+		errs = append(errs, "Page number and size must not be negative")
+	}
+	since, hasSince := parseFilterTime(req.Since)
+	if !hasSince && req.Since != "" {
+		errs = append(errs, "Invalid since time")
+	}
+	until, hasUntil := parseFilterTime(req.Until)
+	if !hasUntil && req.Until != "" {
+		errs = append(errs, "Invalid until time")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.QueryAuditLogResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var entries []*store.AuditEntry
+		for i := len(d.AuditEntries) - 1; i >= 0; i-- {
+			entry := d.AuditEntries[i]
+			switch {
+			case entry.Service != req.Service,
+				req.ActorId != 0 && entry.ActorID != req.ActorId,
+				req.ActorService != "" && entry.ActorService != req.ActorService,
+				req.TargetType != "" && entry.TargetType != req.TargetType,
+				req.TargetId != "" && entry.TargetID != req.TargetId,
+				hasSince && entry.RecordedAt.Before(since),
+				hasUntil && !entry.RecordedAt.Before(until):
+				continue
+			}
+			entries = append(entries, entry)
+		}
+
+		size := int(req.PageSize)
+		if size == 0 {
+			size = auditDefaultPageSize
+		}
+		size = min(size, auditMaxPageSize)
+		start := min((max(int(req.PageNumber), 1)-1)*size, len(entries))
+		for _, entry := range entries[start:min(start+size, len(entries))] {
+			resp.Entries = append(resp.Entries, toAuditEntryResponse(entry))
+		}
+		resp.TotalCount = int32(len(entries))
+		return nil
+	})
+	return resp, err
+}
+
+func toAuditEntryResponse(entry *store.AuditEntry) *pb.AuditEntry {
+	resp := &pb.AuditEntry{
+		Id:           int64(entry.ID),
+		RecordedAt:   entry.RecordedAt.Format(CreatedAtFormat),
+		Service:      entry.Service,
+		Method:       entry.Method,
+		Action:       entry.Action,
+		ActorId:      entry.ActorID,
+		ActorRoles:   entry.ActorRoles,
+		ActorService: entry.ActorService,
+		TargetType:   entry.TargetType,
+		TargetId:     entry.TargetID,
+		RequestId:    entry.RequestID,
+	}
+	for _, change := range entry.Changes {
+		resp.Changes = append(resp.Changes, &pb.AuditChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+	return resp
+}
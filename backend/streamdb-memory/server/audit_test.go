@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuditLog(t *testing.T) {
+	audit := pb.NewAuditServiceClient(dial(t))
+	ctx := context.Background()
+
+	base := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	appendEntry := func(service, targetID string, offset time.Duration, actorID int64) {
+		t.Helper()
+		_, err := audit.AppendAuditEntry(ctx, &pb.AuditEntry{
+			RecordedAt: base.Add(offset).Format(time.RFC3339Nano),
+			Service:    service,
+			Method:     "/stream.StreamService/UpdateStream",
+			Action:     "update",
+			ActorId:    actorID,
+			ActorRoles: []string{"admin", "moderator"},
+			TargetType: "stream",
+			TargetId:   targetID,
+			Changes:    []*pb.AuditChange{{Field: "title", Before: `"old"`, After: `"new"`}},
+			RequestId:  "req-" + targetID,
+		})
+		if err != nil {
+			t.Fatalf("AppendAuditEntry(%s, %s): %v", service, targetID, err)
+		}
+	}
+	appendEntry("stream-service", "1", 0, 7)
+	appendEntry("stream-service", "2", time.Second, 8)
+	appendEntry("comment-service", "3", 2*time.Second, 7)
+	appendEntry("stream-service", "1", 3*time.Second, 0)
+
+	for _, req := range []*pb.AuditEntry{
+		{RecordedAt: "yesterday", Service: "stream-service", Method: "m", Action: "update", TargetType: "stream"},
+		{RecordedAt: base.Format(time.RFC3339), Method: "m", Action: "update", TargetType: "stream"},
+	} {
+		if _, err := audit.AppendAuditEntry(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("AppendAuditEntry(%v) failed with %v, want InvalidArgument", req, err)
+		}
+	}
+
+	targets := func(req *pb.QueryAuditLogRequest) string {
+		t.Helper()
+		resp, err := audit.QueryAuditLog(ctx, req)
+		if err != nil {
+			t.Fatalf("QueryAuditLog(%v): %v", req, err)
+		}
+		var ids []string
+		for _, entry := range resp.Entries {
+			ids = append(ids, entry.TargetId)
+		}
+		return fmt.Sprintf("%v of %d", ids, resp.TotalCount)
+	}
+	for _, tc := range []struct {
+		name string
+		req  *pb.QueryAuditLogRequest
+		want string
+	}{
+		{"service, newest first", &pb.QueryAuditLogRequest{Service: "stream-service"}, "[1 2 1] of 3"},
+		{"target", &pb.QueryAuditLogRequest{Service: "stream-service", TargetType: "stream", TargetId: "1"}, "[1 1] of 2"},
+		{"actor", &pb.QueryAuditLogRequest{Service: "stream-service", ActorId: 8}, "[2] of 1"},
+		{"since inclusive", &pb.QueryAuditLogRequest{Service: "stream-service", Since: base.Add(time.Second).Format(time.RFC3339)}, "[1 2] of 2"},
+		{"until exclusive", &pb.QueryAuditLogRequest{Service: "stream-service", Until: base.Add(time.Second).Format(time.RFC3339)}, "[1] of 1"},
+		{"second page", &pb.QueryAuditLogRequest{Service: "stream-service", PageNumber: 2, PageSize: 2}, "[1] of 3"},
+	} {
+		if got := targets(tc.req); got != tc.want {
+			t.Errorf("%s: entries are %s, want %s", tc.name, got, tc.want)
+		}
+	}
+
+	resp, err := audit.QueryAuditLog(ctx, &pb.QueryAuditLogRequest{Service: "comment-service"})
+	if err != nil {
+		t.Fatalf("QueryAuditLog: %v", err)
+	}
+	entry := resp.Entries[0]
+	if entry.Id != 3 || entry.RecordedAt != "2026-10-18T12:00:02.0000000Z" || fmt.Sprint(entry.ActorRoles) != "[admin moderator]" ||
+		len(entry.Changes) != 1 || entry.Changes[0].After != `"new"` || entry.RequestId != "req-3" {
+		t.Errorf("entry is %v", entry)
+	}
+
+	if _, err := audit.QueryAuditLog(ctx, &pb.QueryAuditLogRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("QueryAuditLog without a service failed with %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,290 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var clipColumns = map[string]int{
+	"public_id":  64,
+	"title":      100,
+	"source":     20,
+	"renditions": 400,
+}
+
+type ClipServer struct {
+	pb.UnimplementedClipServiceServer
+	store *store.Store
+}
+
+func (s *ClipServer) CreateClip(ctx context.Context, req *pb.CreateClipRequest) (*pb.ClipResponse, error) {
+	var errs []string
+	if isBlank(req.Id) {
+		errs = append(errs, "Clip ID is required")
+	}
+	if req.StreamId <= 0 {
+		errs = append(errs, "Invalid stream ID")
+	}
+	if req.CreatorId <= 0 {
+		errs = append(errs, "Invalid creator ID")
+	}
+	if isBlank(req.Source) {
+		errs = append(errs, "Source is required")
+	}
+	if req.FirstSegment < 0 {
+		errs = append(errs, "First segment must not be negative")
+	}
+	if req.Segments <= 0 {
+		errs = append(errs, "Segments must be positive")
+	}
+	if req.SegmentDurationMs <= 0 {
+		errs = append(errs, "Segment duration must be positive")
+	}
+	if len(req.Renditions) == 0 {
+		errs = append(errs, "Renditions are required")
+	} else if slices.ContainsFunc(req.Renditions, isBlank) {
+		errs = append(errs, "Renditions must not be blank")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.ClipResponse
+	err := s.store.Write(func(d *store.Data) error {
+		if stream := d.Stream(req.StreamId); stream == nil || stream.Deleted() {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+		if d.Clip(req.Id) != nil {
+			return status.Error(codes.AlreadyExists, "Clip already exists")
+		}
+
+		specs := make([]string, len(req.Renditions))
+		for i, spec := range req.Renditions {
+			specs[i] = strings.TrimSpace(spec)
+		}
+		now := s.store.Now()
+		clip := &store.Clip{
+			BaseEntity:        store.BaseEntity{ID: d.NextClipID(), CreatedAt: now, UpdatedAt: now},
+			PublicID:          req.Id,
+			StreamID:          req.StreamId,
+			CreatorID:         req.CreatorId,
+			Title:             strings.TrimSpace(req.Title),
+			Source:            req.Source,
+			FirstSegment:      req.FirstSegment,
+			Segments:          req.Segments,
+			SegmentDurationMs: req.SegmentDurationMs,
+			Renditions:        strings.Join(specs, "/"),
+		}
+		if err := checkLength("create clip", clipColumns, map[string]string{"public_id": clip.PublicID, "title": clip.Title, "source": clip.Source, "renditions": clip.Renditions}); err != nil {
+			return err
+		}
+		d.Clips = append(d.Clips, clip)
+		resp = toClipResponse(clip)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *ClipServer) GetClip(ctx context.Context, req *pb.GetClipRequest) (*pb.ClipResponse, error) {
+	if isBlank(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "Clip ID is required")
+	}
+
+	var resp *pb.ClipResponse
+	err := s.store.Read(func(d *store.Data) error {
+		clip := d.Clip(req.Id)
+		if clip == nil {
+			return status.Error(codes.NotFound, "Clip not found")
+		}
+		resp = toClipResponse(clip)
+		return nil
+	})
+	return resp, err
+}
+
+// ListClips mirrors ClipService.ListClips, which returns the newest clips
+// first
+func (s *ClipServer) ListClips(ctx context.Context, req *pb.ListClipsRequest) (*pb.ListClipsResponse, error) {
+	var errs []string
+	if req.StreamId < 0 {
+		errs = append(errs, "Invalid stream ID")
+	}
+	if req.CreatorId < 0 {
+		errs = append(errs, "Invalid creator ID")
+	}
+	if req.StreamId == 0 && req.CreatorId == 0 {
+		errs = append(errs, "Stream ID or creator ID is required")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListClipsResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var clips []*store.Clip
+		for _, clip := range d.Clips {
+			if clip == nil ||
+				(req.StreamId > 0 && clip.StreamID != req.StreamId) ||
+				(req.CreatorId > 0 && clip.CreatorID != req.CreatorId) {
+				continue
+			}
+			clips = append(clips, clip)
+		}
+		slices.SortStableFunc(clips, func(a, b *store.Clip) int {
+			if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.FirstSegment, a.FirstSegment)
+		})
+		for _, clip := range clips {
+			resp.Clips = append(resp.Clips, toClipResponse(clip))
+		}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *ClipServer) DeleteClip(ctx context.Context, req *pb.DeleteClipRequest) (*emptypb.Empty, error) {
+	if isBlank(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "Clip ID is required")
+	}
+
+	err := s.store.Write(func(d *store.Data) error {
+		for i, clip := range d.Clips {
+			if clip != nil && clip.PublicID == req.Id {
+				d.Clips[i] = nil
+				return nil
+			}
+		}
+		return status.Error(codes.NotFound, "Clip not found")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteStreamClips removes the clips and the broadcast of a stream
+func (s *ClipServer) DeleteStreamClips(ctx context.Context, req *pb.DeleteStreamClipsRequest) (*emptypb.Empty, error) {
+	if req.StreamId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	err := s.store.Write(func(d *store.Data) error {
+		d.DeleteStreamClips(req.StreamId)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// StartBroadcast starts a new broadcast unless the stream is already live
+func (s *ClipServer) StartBroadcast(ctx context.Context, req *pb.BroadcastRequest) (*pb.BroadcastResponse, error) {
+	if req.StreamId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	var resp *pb.BroadcastResponse
+	err := s.store.Write(func(d *store.Data) error {
+		if stream := d.Stream(req.StreamId); stream == nil || stream.Deleted() {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+
+		now := s.store.Now()
+		broadcast := d.Broadcast(req.StreamId)
+		if broadcast != nil && broadcast.EndedAt == nil {
+			resp = toBroadcastResponse(broadcast)
+			return nil
+		}
+		if broadcast == nil {
+			broadcast = &store.Broadcast{
+				BaseEntity: store.BaseEntity{ID: d.NextBroadcastID(), CreatedAt: now},
+				StreamID:   req.StreamId,
+			}
+			d.Broadcasts = append(d.Broadcasts, broadcast)
+		}
+		broadcast.StartedAt = now
+		broadcast.EndedAt = nil
+		broadcast.UpdatedAt = now
+		resp = toBroadcastResponse(broadcast)
+		return nil
+	})
+	return resp, err
+}
+
+// EndBroadcast ends the running broadcast of a stream, and returns
+// broadcasts that already ended as they are
+func (s *ClipServer) EndBroadcast(ctx context.Context, req *pb.BroadcastRequest) (*pb.BroadcastResponse, error) {
+	if req.StreamId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	var resp *pb.BroadcastResponse
+	err := s.store.Write(func(d *store.Data) error {
+		broadcast := d.Broadcast(req.StreamId)
+		if broadcast == nil {
+			return status.Error(codes.NotFound, "Broadcast not found")
+		}
+		if broadcast.EndedAt == nil {
+			now := s.store.Now()
+			broadcast.EndedAt = &now
+			broadcast.UpdatedAt = now
+		}
+		resp = toBroadcastResponse(broadcast)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *ClipServer) GetBroadcast(ctx context.Context, req *pb.BroadcastRequest) (*pb.BroadcastResponse, error) {
+	if req.StreamId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	var resp *pb.BroadcastResponse
+	err := s.store.Read(func(d *store.Data) error {
+		broadcast := d.Broadcast(req.StreamId)
+		if broadcast == nil {
+			return status.Error(codes.NotFound, "Broadcast not found")
+		}
+		resp = toBroadcastResponse(broadcast)
+		return nil
+	})
+	return resp, err
+}
+
+func toClipResponse(clip *store.Clip) *pb.ClipResponse {
+	return &pb.ClipResponse{
+		Id:                clip.PublicID,
+		StreamId:          clip.StreamID,
+		CreatorId:         clip.CreatorID,
+		Title:             clip.Title,
+		Source:            clip.Source,
+		FirstSegment:      clip.FirstSegment,
+		Segments:          clip.Segments,
+		SegmentDurationMs: clip.SegmentDurationMs,
+		Renditions:        strings.Split(clip.Renditions, "/"),
+		CreatedAt:         clip.CreatedAt.Format(CreatedAtFormat),
+	}
+}
+
+func toBroadcastResponse(broadcast *store.Broadcast) *pb.BroadcastResponse {
+	resp := &pb.BroadcastResponse{
+		StreamId:  broadcast.StreamID,
+		StartedAt: broadcast.StartedAt.Format(CreatedAtFormat),
+	}
+	if broadcast.EndedAt != nil {
+		resp.EndedAt = broadcast.EndedAt.Format(CreatedAtFormat)
+	}
+	return resp
+}
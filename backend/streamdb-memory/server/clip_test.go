@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClips(t *testing.T) {
+	wire := dial(t)
+	streams, users, clips := pb.NewStreamServiceClient(wire), pb.NewUserServiceClient(wire), pb.NewClipServiceClient(wire)
+	ctx := context.Background()
+
+	user, err := users.CreateUser(ctx, &pb.CreateUserRequest{Email: "alice@example.com", FirstName: "alice", LastName: "Tester", ProfileImageUrl: "https://example.com/alice.png", ClerkId: "user_alice"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	start := time.Now().UTC().Add(time.Hour)
+	var ids []int32
+	for _, title := range []string{"first", "second"} {
+		stream, err := streams.CreateStream(ctx, &pb.CreateStreamRequest{
+			Title:      title,
+			StartTime:  start.Format(TimeFormat),
+			EndTime:    start.Add(time.Hour).Format(TimeFormat),
+			StreamKey:  "key-" + title,
+			Resolution: "1920x1080",
+			Bitrate:    6000,
+			Framerate:  30,
+			Status:     pb.StreamStatus_SCHEDULED,
+			UserId:     int64(user.Id),
+		})
+		if err != nil {
+			t.Fatalf("CreateStream(%s): %v", title, err)
+		}
+		ids = append(ids, stream.Id)
+	}
+	first, second := ids[0], ids[1]
+
+	create := func(id string, streamID, creatorID, firstSegment int32) {
+		t.Helper()
+		_, err := clips.CreateClip(ctx, &pb.CreateClipRequest{
+			Id:                id,
+			StreamId:          streamID,
+			CreatorId:         creatorID,
+			Title:             " " + id,
+			Source:            "live",
+			FirstSegment:      firstSegment,
+			Segments:          15,
+			SegmentDurationMs: 2000,
+			Renditions:        []string{"1280x720p30@2500", "854x480p30@1000"},
+		})
+		if err != nil {
+			t.Fatalf("CreateClip(%s): %v", id, err)
+		}
+	}
+	create("a", first, 1, 10)
+	create("b", first, 2, 20)
+	create("c", second, 1, 0)
+
+	for _, req := range []*pb.CreateClipRequest{
+		{Id: "d", StreamId: first, CreatorId: 1, Source: "live", Segments: 0, SegmentDurationMs: 2000, Renditions: []string{"1280x720p30@2500"}},
+		{Id: "d", StreamId: first, CreatorId: 1, Source: "live", Segments: 1, SegmentDurationMs: 2000, Renditions: []string{" "}},
+		{StreamId: first, CreatorId: 1, Source: "live", Segments: 1, SegmentDurationMs: 2000, Renditions: []string{"1280x720p30@2500"}},
+	} {
+		if _, err := clips.CreateClip(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateClip(%v) failed with %v, want InvalidArgument", req, err)
+		}
+	}
+	duplicate := &pb.CreateClipRequest{Id: "a", StreamId: first, CreatorId: 1, Source: "live", Segments: 1, SegmentDurationMs: 2000, Renditions: []string{"1280x720p30@2500"}}
+	if _, err := clips.CreateClip(ctx, duplicate); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateClip of a taken id failed with %v, want AlreadyExists", err)
+	}
+	duplicate.Id, duplicate.StreamId = "e", 99
+	if _, err := clips.CreateClip(ctx, duplicate); status.Code(err) != codes.NotFound {
+		t.Errorf("CreateClip on a missing stream failed with %v, want NotFound", err)
+	}
+
+	clip, err := clips.GetClip(ctx, &pb.GetClipRequest{Id: "a"})
+	if err != nil {
+		t.Fatalf("GetClip: %v", err)
+	}
+	if clip.Title != "a" || clip.FirstSegment != 10 || clip.SegmentDurationMs != 2000 || fmt.Sprint(clip.Renditions) != "[1280x720p30@2500 854x480p30@1000]" {
+		t.Errorf("GetClip returned %v", clip)
+	}
+
+	list := func(req *pb.ListClipsRequest) string {
+		t.Helper()
+		resp, err := clips.ListClips(ctx, req)
+		if err != nil {
+			t.Fatalf("ListClips(%v): %v", req, err)
+		}
+		var ids []string
+		for _, clip := range resp.Clips {
+			ids = append(ids, clip.Id)
+		}
+		return fmt.Sprint(ids)
+	}
+	// newest first
+	if got := list(&pb.ListClipsRequest{StreamId: first}); got != "[b a]" {
+		t.Errorf("clips of the first stream are %s, want [b a]", got)
+	}
+	if got := list(&pb.ListClipsRequest{CreatorId: 1}); got != "[c a]" {
+		t.Errorf("clips of creator 1 are %s, want [c a]", got)
+	}
+	if _, err := clips.ListClips(ctx, &pb.ListClipsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListClips without a filter failed with %v, want InvalidArgument", err)
+	}
+
+	if _, err := clips.DeleteClip(ctx, &pb.DeleteClipRequest{Id: "b"}); err != nil {
+		t.Fatalf("DeleteClip: %v", err)
+	}
+	if _, err := clips.DeleteClip(ctx, &pb.DeleteClipRequest{Id: "b"}); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteClip of a deleted clip failed with %v, want NotFound", err)
+	}
+
+	// Broadcasts keep their start while live and restart once ended
+	if _, err := clips.GetBroadcast(ctx, &pb.BroadcastRequest{StreamId: first}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetBroadcast before going live failed with %v, want NotFound", err)
+	}
+	live, err := clips.StartBroadcast(ctx, &pb.BroadcastRequest{StreamId: first})
+	if err != nil {
+		t.Fatalf("StartBroadcast: %v", err)
+	}
+	again, err := clips.StartBroadcast(ctx, &pb.BroadcastRequest{StreamId: first})
+	if err != nil {
+		t.Fatalf("StartBroadcast again: %v", err)
+	}
+	if again.StartedAt != live.StartedAt || again.EndedAt != "" {
+		t.Errorf("StartBroadcast of a live stream returned %v, want %v", again, live)
+	}
+	ended, err := clips.EndBroadcast(ctx, &pb.BroadcastRequest{StreamId: first})
+	if err != nil {
+		t.Fatalf("EndBroadcast: %v", err)
+	}
+	if ended.StartedAt != live.StartedAt || ended.EndedAt == "" {
+		t.Errorf("EndBroadcast returned %v", ended)
+	}
+	if _, err := clips.EndBroadcast(ctx, &pb.BroadcastRequest{StreamId: second}); status.Code(err) != codes.NotFound {
+		t.Errorf("EndBroadcast of a stream never broadcast failed with %v, want NotFound", err)
+	}
+
+	if _, err := clips.DeleteStreamClips(ctx, &pb.DeleteStreamClipsRequest{StreamId: first}); err != nil {
+		t.Fatalf("DeleteStreamClips: %v", err)
+	}
+	if got := list(&pb.ListClipsRequest{StreamId: first}); got != "[]" {
+		t.Errorf("clips of the first stream are %s after DeleteStreamClips", got)
+	}
+	if _, err := clips.GetBroadcast(ctx, &pb.BroadcastRequest{StreamId: first}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetBroadcast after DeleteStreamClips failed with %v, want NotFound", err)
+	}
+
+	// purging a stream cascades to its clips and broadcast
+	if _, err := clips.StartBroadcast(ctx, &pb.BroadcastRequest{StreamId: second}); err != nil {
+		t.Fatalf("StartBroadcast: %v", err)
+	}
+	if _, err := streams.DeleteStream(ctx, &pb.DeleteStreamRequest{Id: second}); err != nil {
+		t.Fatalf("DeleteStream: %v", err)
+	}
+	if _, err := streams.PurgeStream(ctx, &pb.PurgeStreamRequest{Id: second}); err != nil {
+		t.Fatalf("PurgeStream: %v", err)
+	}
+	if _, err := clips.GetClip(ctx, &pb.GetClipRequest{Id: "c"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetClip of a purged stream failed with %v, want NotFound", err)
+	}
+	if _, err := clips.GetBroadcast(ctx, &pb.BroadcastRequest{StreamId: second}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetBroadcast of a purged stream failed with %v, want NotFound", err)
+	}
+}
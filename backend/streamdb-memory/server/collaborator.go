@@ -0,0 +1,165 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var collaboratorColumns = map[string]int{
+	"role": 20,
+}
+
+type CollaboratorServer struct {
+	pb.UnimplementedCollaboratorServiceServer
+	store *store.Store
+}
+
+func (s *CollaboratorServer) SetCollaborator(ctx context.Context, req *pb.SetCollaboratorRequest) (*pb.CollaboratorResponse, error) {
+	if err := validateSetCollaborator(req); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.CollaboratorResponse
+	err := s.store.Write(func(d *store.Data) error {
+		if !activeUser(d, req.OwnerId) || !activeUser(d, req.UserId) {
+			return status.Error(codes.NotFound, "User not found")
+		}
+		if req.StreamId != 0 {
+			if stream := d.Stream(req.StreamId); stream == nil || stream.Deleted() || stream.UserID != req.OwnerId {
+				return status.Error(codes.NotFound, "Stream not found")
+			}
+		}
+
+		role := strings.TrimSpace(req.Role)
+		if err := checkLength("set collaborator", collaboratorColumns, map[string]string{"role": role}); err != nil {
+			return err
+		}
+
+		now := s.store.Now()
+		collaborator := d.Collaborator(req.OwnerId, req.StreamId, req.UserId)
+		if collaborator == nil {
+			collaborator = &store.Collaborator{
+				BaseEntity: store.BaseEntity{ID: d.NextCollaboratorID(), CreatedAt: now},
+				OwnerID:    req.OwnerId,
+				StreamID:   req.StreamId,
+				UserID:     req.UserId,
+			}
+			d.Collaborators = append(d.Collaborators, collaborator)
+		}
+		collaborator.Role = role
+		collaborator.UpdatedAt = now
+		resp = toCollaboratorResponse(collaborator)
+		return nil
+	})
+	return resp, err
+}
+
+// DeleteCollaborator removes the row, revoked grants are not kept
+func (s *CollaboratorServer) DeleteCollaborator(ctx context.Context, req *pb.DeleteCollaboratorRequest) (*emptypb.Empty, error) {
+	err := s.store.Write(func(d *store.Data) error {
+		collaborator := d.Collaborator(req.OwnerId, req.StreamId, req.UserId)
+		if collaborator == nil {
+			return status.Error(codes.NotFound, "Collaborator not found")
+		}
+		d.Collaborators[collaborator.ID-1] = nil
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListCollaborators mirrors CollaboratorService.ListCollaborators: channel
+// grants come first, then grants are sorted by user
+func (s *CollaboratorServer) ListCollaborators(ctx context.Context, req *pb.ListCollaboratorsRequest) (*pb.ListCollaboratorsResponse, error) {
+	if req.OwnerId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid owner ID")
+	}
+
+	resp := &pb.ListCollaboratorsResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var collaborators []*store.Collaborator
+		for _, collaborator := range d.Collaborators {
+			if collaborator == nil || collaborator.OwnerID != req.OwnerId {
+				continue
+			}
+			if collaborator.StreamID != 0 && (req.StreamId <= 0 || collaborator.StreamID != req.StreamId) {
+				continue
+			}
+			if req.UserId > 0 && collaborator.UserID != req.UserId {
+				continue
+			}
+			collaborators = append(collaborators, collaborator)
+		}
+		slices.SortStableFunc(collaborators, func(a, b *store.Collaborator) int {
+			if c := cmp.Compare(a.StreamID, b.StreamID); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.UserID, b.UserID)
+		})
+
+		for _, collaborator := range collaborators {
+			resp.Collaborators = append(resp.Collaborators, toCollaboratorResponse(collaborator))
+		}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *CollaboratorServer) DeleteStreamCollaborators(ctx context.Context, req *pb.DeleteStreamCollaboratorsRequest) (*emptypb.Empty, error) {
+	if req.StreamId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	err := s.store.Write(func(d *store.Data) error {
+		d.DeleteStreamCollaborators(req.StreamId)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// validateSetCollaborator mirrors CollaboratorService.ValidateSetRequest
+func validateSetCollaborator(req *pb.SetCollaboratorRequest) error {
+	var errs []string
+
+	if req.OwnerId <= 0 {
+		errs = append(errs, "Invalid owner ID")
+	}
+	if req.UserId <= 0 {
+		errs = append(errs, "Invalid user ID")
+	}
+	if req.StreamId < 0 {
+		errs = append(errs, "Invalid stream ID")
+	}
+	if req.UserId == req.OwnerId {
+		errs = append(errs, "The owner cannot be a collaborator")
+	}
+	if isBlank(req.Role) {
+		errs = append(errs, "Role is required")
+	}
+
+	return validationError(errs)
+}
+
+func toCollaboratorResponse(collaborator *store.Collaborator) *pb.CollaboratorResponse {
+	return &pb.CollaboratorResponse{
+		Id:        collaborator.ID,
+		OwnerId:   collaborator.OwnerID,
+		StreamId:  collaborator.StreamID,
+		UserId:    collaborator.UserID,
+		Role:      collaborator.Role,
+		CreatedAt: collaborator.CreatedAt.Format(CreatedAtFormat),
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCollaborators(t *testing.T) {
+	streams, users, collaborators, _ := serve(t)
+	ctx := context.Background()
+
+	var ids []int32
+	for _, name := range []string{"alice", "bob", "carol"} {
+		user, err := users.CreateUser(ctx, &pb.CreateUserRequest{Email: name + "@example.com", FirstName: name, LastName: "Tester", ProfileImageUrl: "https://example.com/" + name + ".png", ClerkId: "user_" + name})
+		if err != nil {
+			t.Fatalf("CreateUser(%s): %v", name, err)
+		}
+		ids = append(ids, user.Id)
+	}
+	alice, bob, carol := ids[0], ids[1], ids[2]
+
+	start := time.Now().UTC().Add(time.Hour)
+	stream, err := streams.CreateStream(ctx, &pb.CreateStreamRequest{
+		Title:      "show",
+		StartTime:  start.Format(TimeFormat),
+		EndTime:    start.Add(time.Hour).Format(TimeFormat),
+		StreamKey:  "key-show",
+		Resolution: "1920x1080",
+		Bitrate:    6000,
+		Framerate:  30,
+		UserId:     int64(alice),
+	})
+	if err != nil {
+		t.Fatalf("CreateStream: %v", err)
+	}
+
+	set := func(req *pb.SetCollaboratorRequest) *pb.CollaboratorResponse {
+		t.Helper()
+		collaborator, err := collaborators.SetCollaborator(ctx, req)
+		if err != nil {
+			t.Fatalf("SetCollaborator(%v): %v", req, err)
+		}
+		return collaborator
+	}
+	editor := set(&pb.SetCollaboratorRequest{OwnerId: alice, StreamId: stream.Id, UserId: bob, Role: "editor"})
+	set(&pb.SetCollaboratorRequest{OwnerId: alice, UserId: carol, Role: "moderator"})
+
+	// Setting a grant again replaces its role
+	promoted := set(&pb.SetCollaboratorRequest{OwnerId: alice, StreamId: stream.Id, UserId: bob, Role: "co-host"})
+	if promoted.Id != editor.Id || promoted.Role != "co-host" {
+		t.Errorf("grant set again is %v, want grant %d as co-host", promoted, editor.Id)
+	}
+
+	for _, req := range []*pb.SetCollaboratorRequest{
+		{OwnerId: alice, StreamId: stream.Id, UserId: alice, Role: "editor"},
+		{OwnerId: alice, StreamId: stream.Id, UserId: bob},
+	} {
+		if _, err := collaborators.SetCollaborator(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("SetCollaborator(%v) failed with %v, want InvalidArgument", req, err)
+		}
+	}
+	// Streams of other owners cannot be granted
+	if _, err := collaborators.SetCollaborator(ctx, &pb.SetCollaboratorRequest{OwnerId: bob, StreamId: stream.Id, UserId: carol, Role: "editor"}); status.Code(err) != codes.NotFound {
+		t.Errorf("SetCollaborator on another owner's stream failed with %v, want NotFound", err)
+	}
+
+	list := func(req *pb.ListCollaboratorsRequest) []int32 {
+		t.Helper()
+		resp, err := collaborators.ListCollaborators(ctx, req)
+		if err != nil {
+			t.Fatalf("ListCollaborators(%v): %v", req, err)
+		}
+		var users []int32
+		for _, collaborator := range resp.Collaborators {
+			users = append(users, collaborator.UserId)
+		}
+		return users
+	}
+	if got := list(&pb.ListCollaboratorsRequest{OwnerId: alice, StreamId: stream.Id}); len(got) != 2 || got[0] != carol || got[1] != bob {
+		t.Errorf("collaborators of the stream are %v, want the channel moderator %d first, then %d", got, carol, bob)
+	}
+	if got := list(&pb.ListCollaboratorsRequest{OwnerId: alice}); len(got) != 1 || got[0] != carol {
+		t.Errorf("collaborators of the channel are %v, want [%d]", got, carol)
+	}
+	if got := list(&pb.ListCollaboratorsRequest{OwnerId: alice, StreamId: stream.Id, UserId: bob}); len(got) != 1 || got[0] != bob {
+		t.Errorf("grants of %d are %v", bob, got)
+	}
+
+	// Grants on a stream go with it, channel grants stay
+	if _, err := collaborators.DeleteStreamCollaborators(ctx, &pb.DeleteStreamCollaboratorsRequest{StreamId: stream.Id}); err != nil {
+		t.Fatalf("DeleteStreamCollaborators: %v", err)
+	}
+	if got := list(&pb.ListCollaboratorsRequest{OwnerId: alice, StreamId: stream.Id}); len(got) != 1 || got[0] != carol {
+		t.Errorf("collaborators after deleting the stream grants are %v, want [%d]", got, carol)
+	}
+
+	if _, err := collaborators.DeleteCollaborator(ctx, &pb.DeleteCollaboratorRequest{OwnerId: alice, UserId: carol}); err != nil {
+		t.Fatalf("DeleteCollaborator: %v", err)
+	}
+	if _, err := collaborators.DeleteCollaborator(ctx, &pb.DeleteCollaboratorRequest{OwnerId: alice, UserId: carol}); status.Code(err) != codes.NotFound {
+		t.Errorf("deleting a revoked grant failed with %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CreatedAtFormat is the round-trip ("O") format .NET uses for UTC times
+const CreatedAtFormat = "2006-01-02T15:04:05.0000000Z"
+
+// filterTimeFormats approximates the formats DateTime.TryParse accepts
+var filterTimeFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+var commentColumns = map[string]int{
+	"message": 255,
+}
+
+type CommentServer struct {
+	pb.UnimplementedCommentServiceServer
+	store *store.Store
+}
+
+func (s *CommentServer) CreateComment(ctx context.Context, req *pb.CreateCommentRequest) (*pb.CommentResponse, error) {
+	if err := validateCreateComment(req); err != nil {
+		return nil, err
+	}
+
+	var comment *store.Comment
+	err := s.store.Write(func(d *store.Data) error {
+		if !activeUser(d, req.UserId) {
+			return status.Error(codes.NotFound, "User not found")
+		}
+		if stream := d.Stream(req.StreamId); stream == nil || stream.Deleted() {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+
+		now := s.store.Now()
+		comment = &store.Comment{
+			BaseEntity: store.BaseEntity{ID: d.NextCommentID(), CreatedAt: now, UpdatedAt: now},
+			Message:    strings.TrimSpace(req.Message),
+			UserID:     req.UserId,
+			StreamID:   req.StreamId,
+		}
+		if err := checkLength("create comment", commentColumns, map[string]string{"message": comment.Message}); err != nil {
+			return err
+		}
+		d.Comments = append(d.Comments, comment)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCommentResponse(comment), nil
+}
+
+func (s *CommentServer) GetComment(ctx context.Context, req *pb.GetCommentRequest) (*pb.CommentResponse, error) {
+	var resp *pb.CommentResponse
+	err := s.store.Read(func(d *store.Data) error {
+		comment := d.Comment(req.Id)
+		if comment == nil || comment.Deleted() {
+			return status.Error(codes.NotFound, "Comment not found")
+		}
+		resp = toCommentResponse(comment)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *CommentServer) UpdateComment(ctx context.Context, req *pb.UpdateCommentRequest) (*pb.CommentResponse, error) {
+	var errs []string
+	if req.Id <= 0 {
+		errs = append(errs, "Invalid comment ID")
+	}
+	if isBlank(req.Message) {
+		errs = append(errs, "Message is required")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.CommentResponse
+	err := s.store.Write(func(d *store.Data) error {
+		comment := d.Comment(req.Id)
+		if comment == nil || comment.Deleted() {
+			return status.Error(codes.NotFound, "Comment not found")
+		}
+
+		message := strings.TrimSpace(req.Message)
+		if err := checkLength("update comment", commentColumns, map[string]string{"message": message}); err != nil {
+			return err
+		}
+		comment.Message = message
+		comment.UpdatedAt = s.store.Now()
+		resp = toCommentResponse(comment)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *CommentServer) DeleteComment(ctx context.Context, req *pb.DeleteCommentRequest) (*emptypb.Empty, error) {
+	err := s.store.Write(func(d *store.Data) error {
+		comment := d.Comment(req.Id)
+		if comment == nil || comment.Deleted() {
+			return status.Error(codes.NotFound, "Comment not found")
+		}
+
+		now := s.store.Now()
+		comment.DeletedAt = &now
+		comment.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CommentServer) ListComments(ctx context.Context, req *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
+	resp := &pb.ListCommentsResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var comments []*store.Comment
+		for _, comment := range d.Comments {
+			if comment != nil && !comment.Deleted() && matchComment(comment, req.Filter) {
+				comments = append(comments, comment)
+			}
+		}
+		sortComments(comments, req.SortBy, req.Ascending)
+
+		page := store.Paginate(len(comments), req.PageSize, req.PageNumber, true)
+		start, end := page.Bounds()
+		for _, comment := range comments[start:end] {
+			resp.Comments = append(resp.Comments, toCommentResponse(comment))
+		}
+		resp.MetaData = toPaginationMetadata(page)
+		return nil
+	})
+	return resp, err
+}
+
+// validateCreateComment mirrors CommentService.ValidateCreateRequest
+func validateCreateComment(req *pb.CreateCommentRequest) error {
+	var errs []string
+
+	if isBlank(req.Message) {
+		errs = append(errs, "Message is required")
+	}
+	if req.UserId <= 0 {
+		errs = append(errs, "Invalid user ID")
+	}
+	if req.StreamId <= 0 {
+		errs = append(errs, "Invalid stream ID")
+	}
+
+	return validationError(errs)
+}
+
+// matchComment mirrors CommentService.ApplyFilters. Time bounds that fail to
+// parse are ignored.
+func matchComment(comment *store.Comment, filter *pb.CommentFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if !isBlank(filter.MessageContains) && !strings.Contains(comment.Message, filter.MessageContains) {
+		return false
+	}
+	if filter.UserId > 0 && comment.UserID != filter.UserId {
+		return false
+	}
+	if filter.StreamId > 0 && comment.StreamID != filter.StreamId {
+		return false
+	}
+	if after, ok := parseFilterTime(filter.CreatedAfter); ok && comment.CreatedAt.Before(after) {
+		return false
+	}
+	if before, ok := parseFilterTime(filter.CreatedBefore); ok && comment.CreatedAt.After(before) {
+		return false
+	}
+	return true
+}
+
+func parseFilterTime(value string) (time.Time, bool) {
+	if isBlank(value) {
+		return time.Time{}, false
+	}
+	for _, layout := range filterTimeFormats {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// sortComments mirrors CommentService.ApplySorting, where only "message"
+// survives the lowercasing of sort_by
+func sortComments(comments []*store.Comment, sortBy string, ascending bool) {
+	var less func(a, b *store.Comment) int
+	switch strings.ToLower(sortBy) {
+	case "message":
+		less = func(a, b *store.Comment) int { return strings.Compare(a.Message, b.Message) }
+	default:
+		less = func(a, b *store.Comment) int { return int(a.ID) - int(b.ID) }
+	}
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		if ascending {
+			return less(comments[i], comments[j]) < 0
+		}
+		return less(comments[i], comments[j]) > 0
+	})
+}
+
+func toCommentResponse(comment *store.Comment) *pb.CommentResponse {
+	return &pb.CommentResponse{
+		Id:        comment.ID,
+		Message:   comment.Message,
+		UserId:    comment.UserID,
+		StreamId:  comment.StreamID,
+		CreatedAt: comment.CreatedAt.Format(CreatedAtFormat),
+	}
+}
@@ -0,0 +1,306 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	dbpb "github.com/Josy-coder/db-service/proto/db/v1"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DatabaseServer implements db.v1.DatabaseService, the database service of
+// comment-service and user-service, on the users and comments of the store.
+// Users are shared with StreamDb, so streams are checked against the users
+// created through it. The service does not share the column lengths of
+// StreamDb, which are not checked.
+type DatabaseServer struct {
+	dbpb.UnimplementedDatabaseServiceServer
+	store *store.Store
+}
+
+func (s *DatabaseServer) CreateUser(ctx context.Context, req *dbpb.CreateUserRequest) (*dbpb.CreateUserResponse, error) {
+	if req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "user is required")
+	}
+
+	var resp *dbpb.CreateUserResponse
+	err := s.store.Write(func(d *store.Data) error {
+		now := s.store.Now()
+		user := &store.User{
+			BaseEntity:      store.BaseEntity{ID: d.NextUserID(), CreatedAt: now, UpdatedAt: now},
+			FirstName:       req.User.FirstName,
+			LastName:        req.User.LastName,
+			Email:           req.User.Email,
+			ProfileImageURL: req.User.ProfileImageUrl,
+			ClerkID:         req.User.ClerkId,
+			Username:        req.User.Username,
+		}
+		if req.User.LastLogin != nil {
+			lastLogin := req.User.LastLogin.AsTime()
+			user.LastLogin = &lastLogin
+		}
+		d.Users = append(d.Users, user)
+		resp = &dbpb.CreateUserResponse{User: toDBUser(user)}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *DatabaseServer) GetUser(ctx context.Context, req *dbpb.GetUserRequest) (*dbpb.GetUserResponse, error) {
+	user, err := s.findUser(func(u *store.User) bool { return u.ID == req.Id })
+	if err != nil {
+		return nil, err
+	}
+	return &dbpb.GetUserResponse{User: user}, nil
+}
+
+func (s *DatabaseServer) GetUserByClerkID(ctx context.Context, req *dbpb.GetUserByClerkIDRequest) (*dbpb.GetUserByClerkIDResponse, error) {
+	user, err := s.findUser(func(u *store.User) bool { return u.ClerkID == req.ClerkId })
+	if err != nil {
+		return nil, err
+	}
+	return &dbpb.GetUserByClerkIDResponse{User: user}, nil
+}
+
+func (s *DatabaseServer) GetUserByEmail(ctx context.Context, req *dbpb.GetUserByEmailRequest) (*dbpb.GetUserByEmailResponse, error) {
+	user, err := s.findUser(func(u *store.User) bool { return u.Email == req.Email })
+	if err != nil {
+		return nil, err
+	}
+	return &dbpb.GetUserByEmailResponse{User: user}, nil
+}
+
+func (s *DatabaseServer) GetUserByUsername(ctx context.Context, req *dbpb.GetUserByUsernameRequest) (*dbpb.GetUserByUsernameResponse, error) {
+	user, err := s.findUser(func(u *store.User) bool { return u.Username == req.Username })
+	if err != nil {
+		return nil, err
+	}
+	return &dbpb.GetUserByUsernameResponse{User: user}, nil
+}
+
+// findUser returns the first user that was not deleted and matches
+func (s *DatabaseServer) findUser(match func(*store.User) bool) (*dbpb.User, error) {
+	var user *dbpb.User
+	err := s.store.Read(func(d *store.Data) error {
+		for _, u := range d.Users {
+			if !u.Deleted() && match(u) {
+				user = toDBUser(u)
+				return nil
+			}
+		}
+		return status.Error(codes.NotFound, "User not found")
+	})
+	return user, err
+}
+
+func (s *DatabaseServer) UpdateUser(ctx context.Context, req *dbpb.UpdateUserRequest) (*dbpb.UpdateUserResponse, error) {
+	if req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "user is required")
+	}
+
+	var resp *dbpb.UpdateUserResponse
+	err := s.store.Write(func(d *store.Data) error {
+		user := d.User(req.User.Id)
+		if user == nil || user.Deleted() {
+			return status.Error(codes.NotFound, "User not found")
+		}
+
+		// Empty fields keep their stored value
+		if req.User.Email != "" {
+			user.Email = req.User.Email
+		}
+		if req.User.Username != "" {
+			user.Username = req.User.Username
+		}
+		if req.User.FirstName != "" {
+			user.FirstName = req.User.FirstName
+		}
+		if req.User.LastName != "" {
+			user.LastName = req.User.LastName
+		}
+		if req.User.ProfileImageUrl != "" {
+			user.ProfileImageURL = req.User.ProfileImageUrl
+		}
+		if req.User.LastLogin != nil {
+			lastLogin := req.User.LastLogin.AsTime()
+			user.LastLogin = &lastLogin
+		}
+		user.UpdatedAt = s.store.Now()
+		resp = &dbpb.UpdateUserResponse{User: toDBUser(user)}
+		return nil
+	})
+	return resp, err
+}
+
+// DeleteUser deletes a user softly, like StreamDb, so that their deleted
+// streams cannot be restored
+func (s *DatabaseServer) DeleteUser(ctx context.Context, req *dbpb.DeleteUserRequest) (*dbpb.DeleteUserResponse, error) {
+	err := s.store.Write(func(d *store.Data) error {
+		user := d.User(req.Id)
+		if user == nil || user.Deleted() {
+			return status.Error(codes.NotFound, "User not found")
+		}
+		now := s.store.Now()
+		user.DeletedAt = &now
+		user.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dbpb.DeleteUserResponse{}, nil
+}
+
+// ListUsers returns the users that were not deleted, oldest first
+func (s *DatabaseServer) ListUsers(ctx context.Context, req *dbpb.ListUsersRequest) (*dbpb.ListUsersResponse, error) {
+	resp := &dbpb.ListUsersResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var users []*store.User
+		for _, user := range d.Users {
+			if !user.Deleted() {
+				users = append(users, user)
+			}
+		}
+
+		page := store.Paginate(len(users), req.PageSize, req.Page, false)
+		start, end := page.Bounds()
+		for _, user := range users[start:end] {
+			resp.Users = append(resp.Users, toDBUser(user))
+		}
+		resp.TotalCount = int32(len(users))
+		return nil
+	})
+	return resp, err
+}
+
+func (s *DatabaseServer) CreateComment(ctx context.Context, req *dbpb.CreateCommentRequest) (*dbpb.CreateCommentResponse, error) {
+	if req.Comment == nil {
+		return nil, status.Error(codes.InvalidArgument, "comment is required")
+	}
+
+	var resp *dbpb.CreateCommentResponse
+	err := s.store.Write(func(d *store.Data) error {
+		now := s.store.Now()
+		comment := &store.Comment{
+			BaseEntity: store.BaseEntity{ID: d.NextCommentID(), CreatedAt: now, UpdatedAt: now},
+			Message:    req.Comment.Content,
+			UserID:     req.Comment.UserId,
+			StreamID:   req.Comment.StreamId,
+		}
+		d.Comments = append(d.Comments, comment)
+		resp = &dbpb.CreateCommentResponse{Comment: toDBComment(comment)}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *DatabaseServer) GetComment(ctx context.Context, req *dbpb.GetCommentRequest) (*dbpb.GetCommentResponse, error) {
+	var resp *dbpb.GetCommentResponse
+	err := s.store.Read(func(d *store.Data) error {
+		comment := d.Comment(req.Id)
+		if comment == nil || comment.Deleted() {
+			return status.Error(codes.NotFound, "Comment not found")
+		}
+		resp = &dbpb.GetCommentResponse{Comment: toDBComment(comment)}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *DatabaseServer) UpdateComment(ctx context.Context, req *dbpb.UpdateCommentRequest) (*dbpb.UpdateCommentResponse, error) {
+	if req.Comment == nil {
+		return nil, status.Error(codes.InvalidArgument, "comment is required")
+	}
+
+	var resp *dbpb.UpdateCommentResponse
+	err := s.store.Write(func(d *store.Data) error {
+		comment := d.Comment(req.Comment.Id)
+		if comment == nil || comment.Deleted() {
+			return status.Error(codes.NotFound, "Comment not found")
+		}
+		comment.Message = req.Comment.Content
+		comment.UpdatedAt = s.store.Now()
+		resp = &dbpb.UpdateCommentResponse{Comment: toDBComment(comment)}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *DatabaseServer) DeleteComment(ctx context.Context, req *dbpb.DeleteCommentRequest) (*dbpb.DeleteCommentResponse, error) {
+	err := s.store.Write(func(d *store.Data) error {
+		comment := d.Comment(req.Id)
+		if comment == nil || comment.Deleted() {
+			return status.Error(codes.NotFound, "Comment not found")
+		}
+		now := s.store.Now()
+		comment.DeletedAt = &now
+		comment.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dbpb.DeleteCommentResponse{}, nil
+}
+
+// ListComments returns the comments that were not deleted, oldest first
+func (s *DatabaseServer) ListComments(ctx context.Context, req *dbpb.ListCommentsRequest) (*dbpb.ListCommentsResponse, error) {
+	resp := &dbpb.ListCommentsResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var comments []*store.Comment
+		for _, comment := range d.Comments {
+			if comment == nil || comment.Deleted() ||
+				(req.StreamId != nil && comment.StreamID != *req.StreamId) ||
+				(req.UserId != nil && comment.UserID != *req.UserId) {
+				continue
+			}
+			comments = append(comments, comment)
+		}
+
+		page := store.Paginate(len(comments), req.PageSize, req.Page, false)
+		start, end := page.Bounds()
+		for _, comment := range comments[start:end] {
+			resp.Comments = append(resp.Comments, toDBComment(comment))
+		}
+		resp.TotalCount = int32(len(comments))
+		return nil
+	})
+	return resp, err
+}
+
+func toDBUser(user *store.User) *dbpb.User {
+	return &dbpb.User{
+		Id:              user.ID,
+		ClerkId:         user.ClerkID,
+		Email:           user.Email,
+		Username:        user.Username,
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		ProfileImageUrl: user.ProfileImageURL,
+		CreatedAt:       timestamppb.New(user.CreatedAt),
+		UpdatedAt:       timestamppb.New(user.UpdatedAt),
+		LastLogin:       optionalTimestamp(user.LastLogin),
+	}
+}
+
+func toDBComment(comment *store.Comment) *dbpb.Comment {
+	return &dbpb.Comment{
+		Id:        comment.ID,
+		Content:   comment.Message,
+		UserId:    comment.UserID,
+		StreamId:  comment.StreamID,
+		CreatedAt: timestamppb.New(comment.CreatedAt),
+		UpdatedAt: timestamppb.New(comment.UpdatedAt),
+		DeletedAt: optionalTimestamp(comment.DeletedAt),
+	}
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
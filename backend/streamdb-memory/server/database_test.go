@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	dbpb "github.com/Josy-coder/db-service/proto/db/v1"
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDatabaseService(t *testing.T) {
+	wire := dial(t)
+	db, streams := dbpb.NewDatabaseServiceClient(wire), pb.NewStreamServiceClient(wire)
+	ctx := context.Background()
+
+	created, err := db.CreateUser(ctx, &dbpb.CreateUserRequest{User: &dbpb.User{ClerkId: "user_alice", Email: "alice@example.com", Username: "alice"}})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	alice := created.User
+	if _, err := db.CreateUser(ctx, &dbpb.CreateUserRequest{User: &dbpb.User{ClerkId: "user_bob", Email: "bob@example.com", Username: "bob"}}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	// Users are shared with StreamDb, which only accepts streams of users
+	// it knows
+	start := time.Now().UTC().Add(time.Hour)
+	stream, err := streams.CreateStream(ctx, &pb.CreateStreamRequest{
+		Title:      "first",
+		StartTime:  start.Format(TimeFormat),
+		EndTime:    start.Add(time.Hour).Format(TimeFormat),
+		StreamKey:  "key-first",
+		Resolution: "1920x1080",
+		Bitrate:    6000,
+		Framerate:  30,
+		Status:     pb.StreamStatus_SCHEDULED,
+		UserId:     int64(alice.Id),
+	})
+	if err != nil {
+		t.Fatalf("CreateStream for a user of the database service: %v", err)
+	}
+
+	byUsername, err := db.GetUserByUsername(ctx, &dbpb.GetUserByUsernameRequest{Username: "alice"})
+	if err != nil || byUsername.User.Id != alice.Id {
+		t.Errorf("GetUserByUsername returned %v, %v, want user %d", byUsername, err, alice.Id)
+	}
+	updated, err := db.UpdateUser(ctx, &dbpb.UpdateUserRequest{User: &dbpb.User{Id: alice.Id, FirstName: "Alice"}})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.User.FirstName != "Alice" || updated.User.Email != "alice@example.com" {
+		t.Errorf("UpdateUser returned %v, want only the first name changed", updated.User)
+	}
+
+	for _, content := range []string{"one", "two", "three"} {
+		if _, err := db.CreateComment(ctx, &dbpb.CreateCommentRequest{Comment: &dbpb.Comment{Content: content, UserId: alice.Id, StreamId: stream.Id}}); err != nil {
+			t.Fatalf("CreateComment(%s): %v", content, err)
+		}
+	}
+	if _, err := db.DeleteComment(ctx, &dbpb.DeleteCommentRequest{Id: 2}); err != nil {
+		t.Fatalf("DeleteComment: %v", err)
+	}
+	if _, err := db.GetComment(ctx, &dbpb.GetCommentRequest{Id: 2}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetComment of a deleted comment failed with %v, want NotFound", err)
+	}
+	streamID := stream.Id
+	listed, err := db.ListComments(ctx, &dbpb.ListCommentsRequest{StreamId: &streamID})
+	if err != nil {
+		t.Fatalf("ListComments: %v", err)
+	}
+	var contents []string
+	for _, comment := range listed.Comments {
+		contents = append(contents, comment.Content)
+	}
+	if fmt.Sprint(contents) != "[one three]" || listed.TotalCount != 2 {
+		t.Errorf("comments of the stream are %v of %d, want [one three] oldest first", contents, listed.TotalCount)
+	}
+
+	// Deleted users are kept softly, as StreamDb does
+	if _, err := db.DeleteUser(ctx, &dbpb.DeleteUserRequest{Id: alice.Id}); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if _, err := db.GetUser(ctx, &dbpb.GetUserRequest{Id: alice.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetUser of a deleted user failed with %v, want NotFound", err)
+	}
+	users, err := db.ListUsers(ctx, &dbpb.ListUsersRequest{})
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if users.TotalCount != 1 || users.Users[0].Username != "bob" {
+		t.Errorf("ListUsers returned %v, want only bob", users.Users)
+	}
+}
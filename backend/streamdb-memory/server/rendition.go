@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var ladderColumns = map[string]int{
+	"preset":     100,
+	"renditions": 200,
+}
+
+type RenditionServer struct {
+	pb.UnimplementedRenditionServiceServer
+	store *store.Store
+}
+
+// SetLadder creates the ladder of a stream or replaces it
+func (s *RenditionServer) SetLadder(ctx context.Context, req *pb.SetLadderRequest) (*pb.LadderResponse, error) {
+	var errs []string
+	if req.StreamId <= 0 {
+		errs = append(errs, "Invalid stream ID")
+	}
+	if isBlank(req.Preset) {
+		errs = append(errs, "Preset is required")
+	}
+	if slices.ContainsFunc(req.Renditions, isBlank) {
+		errs = append(errs, "Renditions must not be blank")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.LadderResponse
+	err := s.store.Write(func(d *store.Data) error {
+		if stream := d.Stream(req.StreamId); stream == nil || stream.Deleted() {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+
+		now := s.store.Now()
+		ladder := d.Ladder(req.StreamId)
+		if ladder == nil {
+			ladder = &store.RenditionLadder{
+				BaseEntity: store.BaseEntity{ID: d.NextLadderID(), CreatedAt: now},
+				StreamID:   req.StreamId,
+			}
+			d.Ladders = append(d.Ladders, ladder)
+		}
+
+		specs := make([]string, len(req.Renditions))
+		for i, spec := range req.Renditions {
+			specs[i] = strings.TrimSpace(spec)
+		}
+		ladder.Preset = strings.TrimSpace(req.Preset)
+		ladder.Renditions = strings.Join(specs, "/")
+		if err := checkLength("set ladder", ladderColumns, map[string]string{"preset": ladder.Preset, "renditions": ladder.Renditions}); err != nil {
+			return err
+		}
+		ladder.UpdatedAt = now
+		resp = toLadderResponse(ladder)
+		return nil
+	})
+	return resp, err
+}
+
+// GetLadders mirrors RenditionService.GetLadders, which sorts by stream id
+func (s *RenditionServer) GetLadders(ctx context.Context, req *pb.GetLaddersRequest) (*pb.GetLaddersResponse, error) {
+	if slices.ContainsFunc(req.StreamIds, func(id int32) bool { return id <= 0 }) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	resp := &pb.GetLaddersResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		for _, ladder := range d.Ladders {
+			if ladder != nil && slices.Contains(req.StreamIds, ladder.StreamID) {
+				resp.Ladders = append(resp.Ladders, toLadderResponse(ladder))
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(resp.Ladders, func(a, b *pb.LadderResponse) int { return int(a.StreamId - b.StreamId) })
+	return resp, err
+}
+
+func (s *RenditionServer) DeleteLadder(ctx context.Context, req *pb.DeleteLadderRequest) (*emptypb.Empty, error) {
+	if req.StreamId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	err := s.store.Write(func(d *store.Data) error {
+		d.DeleteStreamLadder(req.StreamId)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func toLadderResponse(ladder *store.RenditionLadder) *pb.LadderResponse {
+	resp := &pb.LadderResponse{
+		StreamId:  ladder.StreamID,
+		Preset:    ladder.Preset,
+		UpdatedAt: ladder.UpdatedAt.Format(CreatedAtFormat),
+	}
+	if ladder.Renditions != "" {
+		resp.Renditions = strings.Split(ladder.Renditions, "/")
+	}
+	return resp
+}
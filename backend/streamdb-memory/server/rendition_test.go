@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRenditionLadders(t *testing.T) {
+	wire := dial(t)
+	streams, users, renditions := pb.NewStreamServiceClient(wire), pb.NewUserServiceClient(wire), pb.NewRenditionServiceClient(wire)
+	ctx := context.Background()
+
+	user, err := users.CreateUser(ctx, &pb.CreateUserRequest{Email: "alice@example.com", FirstName: "alice", LastName: "Tester", ProfileImageUrl: "https://example.com/alice.png", ClerkId: "user_alice"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	start := time.Now().UTC().Add(time.Hour)
+	var ids []int32
+	for _, title := range []string{"first", "second", "third"} {
+		stream, err := streams.CreateStream(ctx, &pb.CreateStreamRequest{
+			Title:      title,
+			StartTime:  start.Format(TimeFormat),
+			EndTime:    start.Add(time.Hour).Format(TimeFormat),
+			StreamKey:  "key-" + title,
+			Resolution: "1920x1080",
+			Bitrate:    6000,
+			Framerate:  30,
+			Status:     pb.StreamStatus_SCHEDULED,
+			UserId:     int64(user.Id),
+		})
+		if err != nil {
+			t.Fatalf("CreateStream(%s): %v", title, err)
+		}
+		ids = append(ids, stream.Id)
+	}
+	first, second, third := ids[0], ids[1], ids[2]
+
+	set := func(req *pb.SetLadderRequest) {
+		t.Helper()
+		if _, err := renditions.SetLadder(ctx, req); err != nil {
+			t.Fatalf("SetLadder(%v): %v", req, err)
+		}
+	}
+	set(&pb.SetLadderRequest{StreamId: second, Preset: "custom", Renditions: []string{" 1080p60", "720p30@2500"}})
+	set(&pb.SetLadderRequest{StreamId: first, Preset: "standard"})
+	// setting again replaces the ladder
+	set(&pb.SetLadderRequest{StreamId: first, Preset: "mobile"})
+
+	for _, req := range []*pb.SetLadderRequest{
+		{StreamId: first},
+		{StreamId: first, Preset: "custom", Renditions: []string{"720p", " "}},
+	} {
+		if _, err := renditions.SetLadder(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("SetLadder(%v) failed with %v, want InvalidArgument", req, err)
+		}
+	}
+	if _, err := renditions.SetLadder(ctx, &pb.SetLadderRequest{StreamId: 99, Preset: "standard"}); status.Code(err) != codes.NotFound {
+		t.Errorf("SetLadder of a missing stream failed with %v, want NotFound", err)
+	}
+
+	ladders := func(ids ...int32) string {
+		t.Helper()
+		resp, err := renditions.GetLadders(ctx, &pb.GetLaddersRequest{StreamIds: ids})
+		if err != nil {
+			t.Fatalf("GetLadders(%v): %v", ids, err)
+		}
+		var got []string
+		for _, ladder := range resp.Ladders {
+			got = append(got, fmt.Sprintf("%d:%s%v", ladder.StreamId, ladder.Preset, ladder.Renditions))
+		}
+		return fmt.Sprint(got)
+	}
+	// streams without a ladder are left out, the others come by stream id
+	want := fmt.Sprintf("[%d:mobile[] %d:custom[1080p60 720p30@2500]]", first, second)
+	if got := ladders(third, second, first); got != want {
+		t.Errorf("GetLadders returned %s, want %s", got, want)
+	}
+
+	if _, err := renditions.DeleteLadder(ctx, &pb.DeleteLadderRequest{StreamId: first}); err != nil {
+		t.Fatalf("DeleteLadder: %v", err)
+	}
+	if got, want := ladders(first, second), fmt.Sprintf("[%d:custom[1080p60 720p30@2500]]", second); got != want {
+		t.Errorf("GetLadders after DeleteLadder returned %s, want %s", got, want)
+	}
+
+	// purging a stream cascades to its ladder
+	if _, err := streams.DeleteStream(ctx, &pb.DeleteStreamRequest{Id: second}); err != nil {
+		t.Fatalf("DeleteStream: %v", err)
+	}
+	if _, err := streams.PurgeStream(ctx, &pb.PurgeStreamRequest{Id: second}); err != nil {
+		t.Fatalf("PurgeStream: %v", err)
+	}
+	if got := ladders(second); got != "[]" {
+		t.Errorf("ladder of a purged stream is %s", got)
+	}
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var destinationColumns = map[string]int{
+	"name":       100,
+	"url":        1000,
+	"sealed_key": 1000,
+	"key_hint":   10,
+}
+
+type RestreamServer struct {
+	pb.UnimplementedRestreamServiceServer
+	store *store.Store
+}
+
+func (s *RestreamServer) CreateDestination(ctx context.Context, req *pb.CreateDestinationRequest) (*pb.DestinationResponse, error) {
+	var errs []string
+	if req.OwnerId <= 0 {
+		errs = append(errs, "Invalid owner ID")
+	}
+	if req.StreamId < 0 {
+		errs = append(errs, "Invalid stream ID")
+	}
+	errs = validateDestination(errs, req.Name, req.Url, req.SealedKey)
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.DestinationResponse
+	err := s.store.Write(func(d *store.Data) error {
+		if !activeUser(d, req.OwnerId) {
+			return status.Error(codes.NotFound, "User not found")
+		}
+		if req.StreamId != 0 {
+			if stream := d.Stream(req.StreamId); stream == nil || stream.Deleted() || stream.UserID != req.OwnerId {
+				return status.Error(codes.NotFound, "Stream not found")
+			}
+		}
+
+		now := s.store.Now()
+		destination := &store.RestreamDestination{
+			BaseEntity: store.BaseEntity{ID: d.NextDestinationID(), CreatedAt: now, UpdatedAt: now},
+			OwnerID:    req.OwnerId,
+			StreamID:   req.StreamId,
+			Name:       strings.TrimSpace(req.Name),
+			URL:        strings.TrimSpace(req.Url),
+			SealedKey:  req.SealedKey,
+			KeyHint:    req.KeyHint,
+			Enabled:    req.Enabled,
+		}
+		if err := checkLength("create destination", destinationColumns, destinationValues(destination)); err != nil {
+			return err
+		}
+		d.Destinations = append(d.Destinations, destination)
+		resp = toDestinationResponse(destination)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *RestreamServer) GetDestination(ctx context.Context, req *pb.GetDestinationRequest) (*pb.DestinationResponse, error) {
+	var resp *pb.DestinationResponse
+	err := s.store.Read(func(d *store.Data) error {
+		destination := d.Destination(req.Id)
+		if destination == nil {
+			return status.Error(codes.NotFound, "Destination not found")
+		}
+		resp = toDestinationResponse(destination)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *RestreamServer) UpdateDestination(ctx context.Context, req *pb.UpdateDestinationRequest) (*pb.DestinationResponse, error) {
+	var errs []string
+	if req.Id <= 0 {
+		errs = append(errs, "Invalid destination ID")
+	}
+	errs = validateDestination(errs, req.Name, req.Url, req.SealedKey)
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.DestinationResponse
+	err := s.store.Write(func(d *store.Data) error {
+		destination := d.Destination(req.Id)
+		if destination == nil {
+			return status.Error(codes.NotFound, "Destination not found")
+		}
+
+		destination.Name = strings.TrimSpace(req.Name)
+		destination.URL = strings.TrimSpace(req.Url)
+		destination.SealedKey = req.SealedKey
+		destination.KeyHint = req.KeyHint
+		destination.Enabled = req.Enabled
+		if err := checkLength("update destination", destinationColumns, destinationValues(destination)); err != nil {
+			return err
+		}
+		destination.UpdatedAt = s.store.Now()
+		resp = toDestinationResponse(destination)
+		return nil
+	})
+	return resp, err
+}
+
+// DeleteDestination removes the row, deleted destinations are not kept
+func (s *RestreamServer) DeleteDestination(ctx context.Context, req *pb.DeleteDestinationRequest) (*emptypb.Empty, error) {
+	err := s.store.Write(func(d *store.Data) error {
+		if d.Destination(req.Id) == nil {
+			return status.Error(codes.NotFound, "Destination not found")
+		}
+		d.Destinations[req.Id-1] = nil
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListDestinations mirrors RestreamService.ListDestinations, which sorts by
+// id
+func (s *RestreamServer) ListDestinations(ctx context.Context, req *pb.ListDestinationsRequest) (*pb.ListDestinationsResponse, error) {
+	if req.OwnerId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid owner ID")
+	}
+
+	resp := &pb.ListDestinationsResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		for _, destination := range d.Destinations {
+			if destination == nil || destination.OwnerID != req.OwnerId {
+				continue
+			}
+			if req.StreamId > 0 && destination.StreamID != 0 && destination.StreamID != req.StreamId {
+				continue
+			}
+			resp.Destinations = append(resp.Destinations, toDestinationResponse(destination))
+		}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *RestreamServer) DeleteStreamDestinations(ctx context.Context, req *pb.DeleteStreamDestinationsRequest) (*emptypb.Empty, error) {
+	if req.StreamId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid stream ID")
+	}
+
+	err := s.store.Write(func(d *store.Data) error {
+		d.DeleteStreamDestinations(req.StreamId)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// validateDestination mirrors RestreamService.ValidateFields
+func validateDestination(errs []string, name, url, sealedKey string) []string {
+	if isBlank(name) {
+		errs = append(errs, "Name is required")
+	}
+	if isBlank(url) {
+		errs = append(errs, "URL is required")
+	}
+	if sealedKey == "" {
+		errs = append(errs, "Sealed key is required")
+	}
+	return errs
+}
+
+func destinationValues(destination *store.RestreamDestination) map[string]string {
+	return map[string]string{
+		"name":       destination.Name,
+		"url":        destination.URL,
+		"sealed_key": destination.SealedKey,
+		"key_hint":   destination.KeyHint,
+	}
+}
+
+func toDestinationResponse(destination *store.RestreamDestination) *pb.DestinationResponse {
+	return &pb.DestinationResponse{
+		Id:        destination.ID,
+		OwnerId:   destination.OwnerID,
+		StreamId:  destination.StreamID,
+		Name:      destination.Name,
+		Url:       destination.URL,
+		SealedKey: destination.SealedKey,
+		KeyHint:   destination.KeyHint,
+		Enabled:   destination.Enabled,
+		CreatedAt: destination.CreatedAt.Format(CreatedAtFormat),
+	}
+}
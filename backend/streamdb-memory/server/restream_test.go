@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRestreamDestinations(t *testing.T) {
+	streams, users, _, restreams := serve(t)
+	ctx := context.Background()
+
+	var ids []int32
+	for _, name := range []string{"alice", "bob"} {
+		user, err := users.CreateUser(ctx, &pb.CreateUserRequest{Email: name + "@example.com", FirstName: name, LastName: "Tester", ProfileImageUrl: "https://example.com/" + name + ".png", ClerkId: "user_" + name})
+		if err != nil {
+			t.Fatalf("CreateUser(%s): %v", name, err)
+		}
+		ids = append(ids, user.Id)
+	}
+	alice, bob := ids[0], ids[1]
+
+	start := time.Now().UTC().Add(time.Hour)
+	var shows []int32
+	for _, title := range []string{"first", "second"} {
+		stream, err := streams.CreateStream(ctx, &pb.CreateStreamRequest{
+			Title:      title,
+			StartTime:  start.Format(TimeFormat),
+			EndTime:    start.Add(time.Hour).Format(TimeFormat),
+			StreamKey:  "key-" + title,
+			Resolution: "1920x1080",
+			Bitrate:    6000,
+			Framerate:  30,
+			UserId:     int64(alice),
+		})
+		if err != nil {
+			t.Fatalf("CreateStream(%s): %v", title, err)
+		}
+		shows = append(shows, stream.Id)
+	}
+
+	create := func(req *pb.CreateDestinationRequest) *pb.DestinationResponse {
+		t.Helper()
+		destination, err := restreams.CreateDestination(ctx, req)
+		if err != nil {
+			t.Fatalf("CreateDestination(%v): %v", req, err)
+		}
+		return destination
+	}
+	channel := create(&pb.CreateDestinationRequest{OwnerId: alice, Name: "twitch", Url: "rtmp://live.example.com/app", SealedKey: "sealed-1", KeyHint: "…ey-1", Enabled: true})
+	first := create(&pb.CreateDestinationRequest{OwnerId: alice, StreamId: shows[0], Name: "youtube", Url: "rtmps://a.example.com/live2", SealedKey: "sealed-2", KeyHint: "…ey-2"})
+	create(&pb.CreateDestinationRequest{OwnerId: alice, StreamId: shows[1], Name: "kick", Url: "rtmp://b.example.com/app", SealedKey: "sealed-3"})
+
+	for _, req := range []*pb.CreateDestinationRequest{
+		{OwnerId: alice, Name: " ", Url: "rtmp://live.example.com/app", SealedKey: "sealed"},
+		{OwnerId: alice, Name: "twitch", Url: "rtmp://live.example.com/app"},
+	} {
+		if _, err := restreams.CreateDestination(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateDestination(%v) failed with %v, want InvalidArgument", req, err)
+		}
+	}
+	// Streams of other owners cannot be relayed
+	if _, err := restreams.CreateDestination(ctx, &pb.CreateDestinationRequest{OwnerId: bob, StreamId: shows[0], Name: "mine", Url: "rtmp://c.example.com/app", SealedKey: "sealed"}); status.Code(err) != codes.NotFound {
+		t.Errorf("CreateDestination on another owner's stream failed with %v, want NotFound", err)
+	}
+
+	list := func(req *pb.ListDestinationsRequest) []int32 {
+		t.Helper()
+		resp, err := restreams.ListDestinations(ctx, req)
+		if err != nil {
+			t.Fatalf("ListDestinations(%v): %v", req, err)
+		}
+		var ids []int32
+		for _, destination := range resp.Destinations {
+			ids = append(ids, destination.Id)
+		}
+		return ids
+	}
+	if got := list(&pb.ListDestinationsRequest{OwnerId: alice, StreamId: shows[0]}); len(got) != 2 || got[0] != channel.Id || got[1] != first.Id {
+		t.Errorf("destinations of the first stream are %v, want [%d %d]", got, channel.Id, first.Id)
+	}
+	if got := list(&pb.ListDestinationsRequest{OwnerId: alice}); len(got) != 3 {
+		t.Errorf("destinations of the owner are %v, want all 3", got)
+	}
+
+	// Updates replace every field
+	updated, err := restreams.UpdateDestination(ctx, &pb.UpdateDestinationRequest{Id: first.Id, Name: "youtube", Url: first.Url, SealedKey: "sealed-4", KeyHint: "…ey-4", Enabled: true})
+	if err != nil {
+		t.Fatalf("UpdateDestination: %v", err)
+	}
+	if updated.SealedKey != "sealed-4" || updated.KeyHint != "…ey-4" || !updated.Enabled || updated.StreamId != shows[0] {
+		t.Errorf("updated destination is %v", updated)
+	}
+
+	// Destinations of a stream go with it, those of the channel stay
+	if _, err := restreams.DeleteStreamDestinations(ctx, &pb.DeleteStreamDestinationsRequest{StreamId: shows[0]}); err != nil {
+		t.Fatalf("DeleteStreamDestinations: %v", err)
+	}
+	if got := list(&pb.ListDestinationsRequest{OwnerId: alice, StreamId: shows[0]}); len(got) != 1 || got[0] != channel.Id {
+		t.Errorf("destinations after deleting those of the stream are %v, want [%d]", got, channel.Id)
+	}
+
+	if _, err := restreams.DeleteDestination(ctx, &pb.DeleteDestinationRequest{Id: channel.Id}); err != nil {
+		t.Fatalf("DeleteDestination: %v", err)
+	}
+	if _, err := restreams.GetDestination(ctx, &pb.GetDestinationRequest{Id: channel.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("getting a deleted destination failed with %v, want NotFound", err)
+	}
+}
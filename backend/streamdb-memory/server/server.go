@@ -0,0 +1,125 @@
+package server
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	dbpb "github.com/Josy-coder/db-service/proto/db/v1"
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The servers reproduce the behaviour of the StreamDb C# services, including
+// their validation messages, so that clients can be developed against this
+// stand-in and behave the same against the real database.
+
+// Server implements the StreamService, UserService, CommentService,
+// CollaboratorService, RestreamService, TelemetryService, RenditionService,
+// ClipService, AuditService and AnalyticsService of StreamDb on top of an
+// in-memory store, together with the db.v1.DatabaseService of
+// comment-service and user-service
+type Server struct {
+	store *store.Store
+}
+
+// New returns the servers backed by st
+func New(st *store.Store) *Server {
+	return &Server{store: st}
+}
+
+// Streams returns the StreamService implementation
+func (s *Server) Streams() *StreamServer {
+	return &StreamServer{store: s.store}
+}
+
+// Users returns the UserService implementation
+func (s *Server) Users() *UserServer {
+	return &UserServer{store: s.store}
+}
+
+// Comments returns the CommentService implementation
+func (s *Server) Comments() *CommentServer {
+	return &CommentServer{store: s.store}
+}
+
+// Collaborators returns the CollaboratorService implementation
+func (s *Server) Collaborators() *CollaboratorServer {
+	return &CollaboratorServer{store: s.store}
+}
+
+// Restreams returns the RestreamService implementation
+func (s *Server) Restreams() *RestreamServer {
+	return &RestreamServer{store: s.store}
+}
+
+// Telemetry returns the TelemetryService implementation
+func (s *Server) Telemetry() *TelemetryServer {
+	return &TelemetryServer{store: s.store}
+}
+
+// Renditions returns the RenditionService implementation
+func (s *Server) Renditions() *RenditionServer {
+	return &RenditionServer{store: s.store}
+}
+
+// Clips returns the ClipService implementation
+func (s *Server) Clips() *ClipServer {
+	return &ClipServer{store: s.store}
+}
+
+// Audit returns the AuditService implementation
+func (s *Server) Audit() *AuditServer {
+	return &AuditServer{store: s.store}
+}
+
+// Analytics returns the AnalyticsService implementation
+func (s *Server) Analytics() *AnalyticsServer {
+	return &AnalyticsServer{store: s.store}
+}
+
+// Database returns the db.v1.DatabaseService implementation
+func (s *Server) Database() *DatabaseServer {
+	return &DatabaseServer{store: s.store}
+}
+
+// Register serves the services on registrar under the names StreamDb serves
+// them under, and the db.v1.DatabaseService under its own
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	registrar.RegisterService(pb.WireServiceDesc(&pb.StreamService_ServiceDesc), s.Streams())
+	registrar.RegisterService(pb.WireServiceDesc(&pb.UserService_ServiceDesc), s.Users())
+	registrar.RegisterService(pb.WireServiceDesc(&pb.CommentService_ServiceDesc), s.Comments())
+	registrar.RegisterService(pb.WireServiceDesc(&pb.CollaboratorService_ServiceDesc), s.Collaborators())
+	registrar.RegisterService(pb.WireServiceDesc(&pb.RestreamService_ServiceDesc), s.Restreams())
+	registrar.RegisterService(pb.WireServiceDesc(&pb.TelemetryService_ServiceDesc), s.Telemetry())
+	registrar.RegisterService(pb.WireServiceDesc(&pb.RenditionService_ServiceDesc), s.Renditions())
+	registrar.RegisterService(pb.WireServiceDesc(&pb.ClipService_ServiceDesc), s.Clips())
+	registrar.RegisterService(pb.WireServiceDesc(&pb.AuditService_ServiceDesc), s.Audit())
+	registrar.RegisterService(pb.WireServiceDesc(&pb.AnalyticsService_ServiceDesc), s.Analytics())
+	dbpb.RegisterDatabaseServiceServer(registrar, s.Database())
+}
+
+// validationError reports every failed rule at once, like StreamDb
+func validationError(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return status.Error(codes.InvalidArgument, strings.Join(errs, ", "))
+}
+
+// checkLength enforces the column lengths of the StreamDb schema, which
+// surface as a failed save in the C# services
+func checkLength(action string, limits map[string]int, values map[string]string) error {
+	for column, limit := range limits {
+		if utf8.RuneCountInString(values[column]) > limit {
+			return status.Errorf(codes.Internal, "Failed to %s: value too long for column %s (max %d)", action, column, limit)
+		}
+	}
+	return nil
+}
+
+func isBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
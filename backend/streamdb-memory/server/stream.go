@@ -0,0 +1,480 @@
+package server
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TimeFormat is the layout of stream times, "yyyy-MM-ddTHH:mm:ssZ" in C#
+const TimeFormat = "2006-01-02T15:04:05Z"
+
+var streamColumns = map[string]int{
+	"title":       100,
+	"description": 100,
+	"stream_key":  100,
+	"resolution":  100,
+	"codec":       100,
+	"protocol":    100,
+}
+
+type StreamServer struct {
+	pb.UnimplementedStreamServiceServer
+	store *store.Store
+}
+
+func (s *StreamServer) CreateStream(ctx context.Context, req *pb.CreateStreamRequest) (*pb.StreamResponse, error) {
+	if err := validateCreateStream(req, s.store.Now()); err != nil {
+		return nil, err
+	}
+
+	var stream *store.Stream
+	err := s.store.Write(func(d *store.Data) error {
+		if req.UserId > math.MaxInt32 || !activeUser(d, int32(req.UserId)) {
+			return status.Error(codes.NotFound, "User not found")
+		}
+
+		startTime, _ := parseTimestamp(req.StartTime)
+		endTime, _ := parseTimestamp(req.EndTime)
+		now := s.store.Now()
+		stream = &store.Stream{
+			BaseEntity:  store.BaseEntity{ID: d.NextStreamID(), CreatedAt: now, UpdatedAt: now},
+			Title:       strings.TrimSpace(req.Title),
+			Description: strings.TrimSpace(req.Description),
+			StartTime:   startTime,
+			EndTime:     endTime,
+			StreamKey:   strings.TrimSpace(req.StreamKey),
+			Resolution:  strings.TrimSpace(req.Resolution),
+			Bitrate:     req.Bitrate,
+			Framerate:   req.Framerate,
+			Codec:       strings.TrimSpace(req.Codec),
+			Protocol:    strings.TrimSpace(req.Protocol),
+			Status:      store.StreamStatus(req.Status),
+			Visibility:  store.StreamVisibility(req.Visibility),
+			UserID:      int32(req.UserId),
+		}
+		if err := checkLength("create stream", streamColumns, streamValues(stream)); err != nil {
+			return err
+		}
+		d.Streams = append(d.Streams, stream)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toStreamResponse(stream), nil
+}
+
+func (s *StreamServer) GetStream(ctx context.Context, req *pb.GetStreamRequest) (*pb.StreamResponse, error) {
+	var resp *pb.StreamResponse
+	err := s.store.Read(func(d *store.Data) error {
+		stream := d.Stream(req.Id)
+		if stream == nil || stream.Deleted() {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+		resp = toStreamResponse(stream)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *StreamServer) UpdateStream(ctx context.Context, req *pb.UpdateStreamRequest) (*pb.StreamResponse, error) {
+	if err := validateUpdateStream(req); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.StreamResponse
+	err := s.store.Write(func(d *store.Data) error {
+		stream := d.Stream(req.Id)
+		if stream == nil || stream.Deleted() {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+
+		updateStreamFields(stream, req)
+		if err := checkLength("update stream", streamColumns, streamValues(stream)); err != nil {
+			return err
+		}
+		stream.UpdatedAt = s.store.Now()
+		resp = toStreamResponse(stream)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *StreamServer) DeleteStream(ctx context.Context, req *pb.DeleteStreamRequest) (*emptypb.Empty, error) {
+	err := s.store.Write(func(d *store.Data) error {
+		stream := d.Stream(req.Id)
+		if stream == nil || stream.Deleted() {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+		if stream.Status == store.StatusOnline {
+			return status.Error(codes.FailedPrecondition, "Cannot delete an active stream. Please end the stream first.")
+		}
+
+		now := s.store.Now()
+		stream.DeletedAt = &now
+		stream.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *StreamServer) ListStreams(ctx context.Context, req *pb.ListStreamsRequest) (*pb.ListStreamsResponse, error) {
+	resp := &pb.ListStreamsResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var streams []*store.Stream
+		for _, stream := range d.Streams {
+			if stream != nil && !stream.Deleted() && matchStream(stream, req.Filter) {
+				streams = append(streams, stream)
+			}
+		}
+		sortStreams(streams, req.SortBy, req.Ascending)
+
+		page := store.Paginate(len(streams), req.PageSize, req.PageNumber, true)
+		start, end := page.Bounds()
+		for _, stream := range streams[start:end] {
+			resp.Streams = append(resp.Streams, toStreamResponse(stream))
+		}
+		resp.MetaData = toPaginationMetadata(page)
+		return nil
+	})
+	return resp, err
+}
+
+// ListDeletedStreams returns the most recently deleted streams first
+func (s *StreamServer) ListDeletedStreams(ctx context.Context, req *pb.ListDeletedStreamsRequest) (*pb.ListStreamsResponse, error) {
+	var deletedBefore time.Time
+	if !isBlank(req.DeletedBefore) {
+		t, err := parseTimestamp(req.DeletedBefore)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		deletedBefore = t
+	}
+
+	resp := &pb.ListStreamsResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var streams []*store.Stream
+		for _, stream := range d.Streams {
+			if stream == nil || !stream.Deleted() {
+				continue
+			}
+			if req.UserId > 0 && stream.UserID != req.UserId {
+				continue
+			}
+			if !deletedBefore.IsZero() && !stream.DeletedAt.Before(deletedBefore) {
+				continue
+			}
+			streams = append(streams, stream)
+		}
+		sort.SliceStable(streams, func(i, j int) bool {
+			if !streams[i].DeletedAt.Equal(*streams[j].DeletedAt) {
+				return streams[i].DeletedAt.After(*streams[j].DeletedAt)
+			}
+			return streams[i].ID > streams[j].ID
+		})
+
+		page := store.Paginate(len(streams), req.PageSize, req.PageNumber, true)
+		start, end := page.Bounds()
+		for _, stream := range streams[start:end] {
+			resp.Streams = append(resp.Streams, toStreamResponse(stream))
+		}
+		resp.MetaData = toPaginationMetadata(page)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *StreamServer) RestoreStream(ctx context.Context, req *pb.RestoreStreamRequest) (*pb.StreamResponse, error) {
+	var resp *pb.StreamResponse
+	err := s.store.Write(func(d *store.Data) error {
+		stream := d.Stream(req.Id)
+		if stream == nil || !stream.Deleted() || (req.UserId > 0 && stream.UserID != req.UserId) {
+			return status.Error(codes.NotFound, "Deleted stream not found")
+		}
+		if !activeUser(d, stream.UserID) {
+			return status.Error(codes.FailedPrecondition, "Cannot restore a stream of a deleted user.")
+		}
+
+		stream.DeletedAt = nil
+		stream.UpdatedAt = s.store.Now()
+		resp = toStreamResponse(stream)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *StreamServer) PurgeStream(ctx context.Context, req *pb.PurgeStreamRequest) (*emptypb.Empty, error) {
+	err := s.store.Write(func(d *store.Data) error {
+		stream := d.Stream(req.Id)
+		if stream == nil {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+		if !stream.Deleted() {
+			return status.Error(codes.FailedPrecondition, "Cannot purge a stream that is not deleted. Please delete the stream first.")
+		}
+
+		d.PurgeStream(stream.ID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// validateCreateStream mirrors StreamService.ValidateCreateRequest
+func validateCreateStream(req *pb.CreateStreamRequest, now time.Time) error {
+	var errs []string
+
+	if isBlank(req.Title) {
+		errs = append(errs, "Title is required")
+	}
+	if isBlank(req.StreamKey) {
+		errs = append(errs, "Stream key is required")
+	}
+	errs = append(errs, validateSchedule(req.StartTime, req.EndTime, &now)...)
+	if req.UserId <= 0 {
+		errs = append(errs, "Invalid user ID")
+	}
+	if req.Bitrate <= 0 {
+		errs = append(errs, "Bitrate must be greater than 0")
+	}
+	if req.Framerate <= 0 {
+		errs = append(errs, "Framerate must be greater than 0")
+	}
+	if !isBlank(req.Resolution) && !isValidResolution(req.Resolution) {
+		errs = append(errs, "Invalid resolution format. Expected format: WidthxHeight")
+	}
+
+	return validationError(errs)
+}
+
+// validateUpdateStream mirrors StreamService.ValidateUpdateRequest. Updates
+// must carry the full schedule, which may have started already.
+func validateUpdateStream(req *pb.UpdateStreamRequest) error {
+	var errs []string
+
+	if isBlank(req.Title) {
+		errs = append(errs, "Title is required")
+	}
+	errs = append(errs, validateSchedule(req.StartTime, req.EndTime, nil)...)
+	if req.Bitrate <= 0 {
+		errs = append(errs, "Bitrate must be greater than 0")
+	}
+	if req.Framerate <= 0 {
+		errs = append(errs, "Framerate must be greater than 0")
+	}
+	if !isBlank(req.Resolution) && !isValidResolution(req.Resolution) {
+		errs = append(errs, "Invalid resolution format. Expected format: WidthxHeight")
+	}
+
+	return validationError(errs)
+}
+
+// validateSchedule checks the times of a stream, and that it starts after
+// now unless now is nil
+func validateSchedule(start, end string, now *time.Time) []string {
+	startTime, err := parseTimestamp(start)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	endTime, err := parseTimestamp(end)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var errs []string
+	if now != nil && !startTime.After(*now) {
+		errs = append(errs, "Start time must be in the future")
+	}
+	if !startTime.Before(endTime) {
+		errs = append(errs, "Start time must be before end time")
+	}
+	return errs
+}
+
+type timestampError struct{}
+
+func (timestampError) Error() string {
+	return "Invalid timestamp format. Expected format: yyyy-MM-ddTHH:mm:ssZ"
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	t, err := time.Parse(TimeFormat, value)
+	if err != nil {
+		return time.Time{}, timestampError{}
+	}
+	return t, nil
+}
+
+func isValidResolution(resolution string) bool {
+	parts := strings.Split(resolution, "x")
+	if len(parts) != 2 {
+		return false
+	}
+	width, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	height, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return width > 0 && height > 0
+}
+
+// updateStreamFields mirrors StreamService.UpdateStreamFields. Proto3
+// strings are never null, so description, times, status and view count are
+// always overwritten. Visibility has presence and is only changed when set.
+func updateStreamFields(stream *store.Stream, req *pb.UpdateStreamRequest) {
+	if !isBlank(req.Title) {
+		stream.Title = strings.TrimSpace(req.Title)
+	}
+	stream.Description = strings.TrimSpace(req.Description)
+	stream.StartTime, _ = parseTimestamp(req.StartTime)
+	stream.EndTime, _ = parseTimestamp(req.EndTime)
+	if !isBlank(req.Resolution) {
+		stream.Resolution = strings.TrimSpace(req.Resolution)
+	}
+	if req.Bitrate > 0 {
+		stream.Bitrate = req.Bitrate
+	}
+	if req.Framerate > 0 {
+		stream.Framerate = req.Framerate
+	}
+	if !isBlank(req.Codec) {
+		stream.Codec = strings.TrimSpace(req.Codec)
+	}
+	if !isBlank(req.Protocol) {
+		stream.Protocol = req.Protocol
+	}
+	stream.Status = store.StreamStatus(req.Status)
+	if req.Visibility != nil {
+		stream.Visibility = store.StreamVisibility(*req.Visibility)
+	}
+	if req.ViewCount >= 0 {
+		stream.ViewCount = req.ViewCount
+	}
+}
+
+// matchStream mirrors StreamService.ApplyFilters. The time filters of
+// StreamFilter are ignored by StreamDb and therefore here too. Text matches
+// are case sensitive like the generated SQL.
+func matchStream(stream *store.Stream, filter *pb.StreamFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if !isBlank(filter.TitleContains) && !strings.Contains(stream.Title, filter.TitleContains) {
+		return false
+	}
+	if !isBlank(filter.DescriptionContains) && !strings.Contains(stream.Description, filter.DescriptionContains) {
+		return false
+	}
+	if filter.UserId > 0 && stream.UserID != filter.UserId {
+		return false
+	}
+	if filter.IdAfter > 0 && stream.ID <= filter.IdAfter {
+		return false
+	}
+	if filter.MinViewCount > 0 && stream.ViewCount < filter.MinViewCount {
+		return false
+	}
+	if filter.MaxViewCount > 0 && stream.ViewCount > filter.MaxViewCount {
+		return false
+	}
+	if len(filter.Status) > 0 && !slices.Contains(filter.Status, pb.StreamStatus(stream.Status)) {
+		return false
+	}
+	if len(filter.Visibility) > 0 && !slices.Contains(filter.Visibility, pb.StreamVisibility(stream.Visibility)) {
+		return false
+	}
+	if !isBlank(filter.Codec) && stream.Codec != filter.Codec {
+		return false
+	}
+	if !isBlank(filter.Protocol) && stream.Protocol != filter.Protocol {
+		return false
+	}
+	return true
+}
+
+// sortStreams mirrors StreamService.ApplySorting. StreamDb lowercases
+// sort_by before matching it against "startTime", "endTime" and
+// "viewCount", so those keys never match and sort by id like unknown keys.
+func sortStreams(streams []*store.Stream, sortBy string, ascending bool) {
+	var less func(a, b *store.Stream) int
+	switch strings.ToLower(sortBy) {
+	case "title":
+		less = func(a, b *store.Stream) int { return strings.Compare(a.Title, b.Title) }
+	case "status":
+		less = func(a, b *store.Stream) int { return int(a.Status) - int(b.Status) }
+	case "userid":
+		less = func(a, b *store.Stream) int { return int(a.UserID) - int(b.UserID) }
+	default:
+		less = func(a, b *store.Stream) int { return int(a.ID) - int(b.ID) }
+	}
+
+	sort.SliceStable(streams, func(i, j int) bool {
+		if ascending {
+			return less(streams[i], streams[j]) < 0
+		}
+		return less(streams[i], streams[j]) > 0
+	})
+}
+
+func streamValues(stream *store.Stream) map[string]string {
+	return map[string]string{
+		"title":       stream.Title,
+		"description": stream.Description,
+		"stream_key":  stream.StreamKey,
+		"resolution":  stream.Resolution,
+		"codec":       stream.Codec,
+		"protocol":    stream.Protocol,
+	}
+}
+
+func toStreamResponse(stream *store.Stream) *pb.StreamResponse {
+	resp := &pb.StreamResponse{
+		Id:          stream.ID,
+		Title:       stream.Title,
+		Description: stream.Description,
+		StartTime:   stream.StartTime.Format(TimeFormat),
+		EndTime:     stream.EndTime.Format(TimeFormat),
+		StreamKey:   stream.StreamKey,
+		Resolution:  stream.Resolution,
+		Bitrate:     strconv.Itoa(int(stream.Bitrate)),
+		Framerate:   strconv.Itoa(int(stream.Framerate)),
+		Codec:       stream.Codec,
+		ViewCount:   stream.ViewCount,
+		Protocol:    stream.Protocol,
+		Status:      pb.StreamStatus(stream.Status),
+		UserId:      stream.UserID,
+		Visibility:  pb.StreamVisibility(stream.Visibility),
+	}
+	if stream.Deleted() {
+		resp.DeletedAt = stream.DeletedAt.Format(TimeFormat)
+	}
+	return resp
+}
+
+func toPaginationMetadata(page store.Page) *pb.PaginationMetadata {
+	return &pb.PaginationMetadata{
+		TotalItems:  int32(page.TotalItems),
+		TotalPages:  int32(page.TotalPages),
+		CurrentPage: int32(page.Number),
+		PageSize:    int32(page.Size),
+	}
+}
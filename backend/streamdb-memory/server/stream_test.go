@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// serve starts the stand-in on an in-memory listener and returns clients
+// calling it under the names StreamDb uses
+func serve(t *testing.T) (pb.StreamServiceClient, pb.UserServiceClient, pb.CollaboratorServiceClient, pb.RestreamServiceClient) {
+	t.Helper()
+
+	wire := dial(t)
+	return pb.NewStreamServiceClient(wire), pb.NewUserServiceClient(wire), pb.NewCollaboratorServiceClient(wire), pb.NewRestreamServiceClient(wire)
+}
+
+// dial starts the stand-in on an in-memory listener and returns a
+// connection calling it under the names StreamDb uses, for the services
+// serve has no client of
+func dial(t *testing.T) grpc.ClientConnInterface {
+	t.Helper()
+
+	st, err := store.New("")
+	if err != nil {
+		t.Fatalf("store.New: %v", err)
+	}
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	New(st).Register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///streamdb",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.WireConn(conn)
+}
+
+func TestWireNames(t *testing.T) {
+	streams, _, _, _ := serve(t)
+
+	// StreamDb serves stream.StreamService, not the streamdb copy
+	_, err := streams.GetStream(context.Background(), &pb.GetStreamRequest{Id: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetStream of a missing stream failed with %v, want NotFound", err)
+	}
+	if got := pb.WireName(pb.StreamService_GetStream_FullMethodName); got != "/stream.StreamService/GetStream" {
+		t.Errorf("wire name of GetStream is %q", got)
+	}
+}
+
+func TestStreamFilters(t *testing.T) {
+	streams, users, _, _ := serve(t)
+	ctx := context.Background()
+
+	var owners []int32
+	for _, name := range []string{"alice", "bob"} {
+		user, err := users.CreateUser(ctx, &pb.CreateUserRequest{Email: name + "@example.com", FirstName: name, LastName: "Tester", ProfileImageUrl: "https://example.com/" + name + ".png", ClerkId: "user_" + name})
+		if err != nil {
+			t.Fatalf("CreateUser(%s): %v", name, err)
+		}
+		owners = append(owners, user.Id)
+	}
+
+	start := time.Now().UTC().Add(time.Hour)
+	create := func(owner int32, title string, status pb.StreamStatus, visibility pb.StreamVisibility) *pb.StreamResponse {
+		t.Helper()
+		stream, err := streams.CreateStream(ctx, &pb.CreateStreamRequest{
+			Title:      title,
+			StartTime:  start.Format(TimeFormat),
+			EndTime:    start.Add(time.Hour).Format(TimeFormat),
+			StreamKey:  "key-" + title,
+			Resolution: "1920x1080",
+			Bitrate:    6000,
+			Framerate:  30,
+			Status:     status,
+			UserId:     int64(owner),
+			Visibility: visibility,
+		})
+		if err != nil {
+			t.Fatalf("CreateStream(%s): %v", title, err)
+		}
+		return stream
+	}
+	live := create(owners[0], "b live", pb.StreamStatus_ONLINE, pb.StreamVisibility_PUBLIC)
+	private := create(owners[0], "c private", pb.StreamStatus_SCHEDULED, pb.StreamVisibility_PRIVATE)
+	unlisted := create(owners[1], "a unlisted", pb.StreamStatus_SCHEDULED, pb.StreamVisibility_UNLISTED)
+
+	// Enums come back as they were stored
+	if private.Status != pb.StreamStatus_SCHEDULED || private.Visibility != pb.StreamVisibility_PRIVATE {
+		t.Errorf("created stream is %s and %s, want SCHEDULED and PRIVATE", private.Status, private.Visibility)
+	}
+
+	ids := func(filter *pb.StreamFilter, sortBy string, ascending bool) string {
+		t.Helper()
+		resp, err := streams.ListStreams(ctx, &pb.ListStreamsRequest{Filter: filter, SortBy: sortBy, Ascending: ascending})
+		if err != nil {
+			t.Fatalf("ListStreams(%v): %v", filter, err)
+		}
+		var ids []int32
+		for _, stream := range resp.Streams {
+			ids = append(ids, stream.Id)
+		}
+		return fmt.Sprint(ids)
+	}
+	tests := []struct {
+		name      string
+		filter    *pb.StreamFilter
+		sortBy    string
+		ascending bool
+		want      []int32
+	}{
+		{"everything", nil, "", true, []int32{live.Id, private.Id, unlisted.Id}},
+		{"newest first", nil, "", false, []int32{unlisted.Id, private.Id, live.Id}},
+		{"by title", nil, "title", true, []int32{unlisted.Id, live.Id, private.Id}},
+		{"owner", &pb.StreamFilter{UserId: owners[0]}, "", true, []int32{live.Id, private.Id}},
+		{"status", &pb.StreamFilter{Status: []pb.StreamStatus{pb.StreamStatus_SCHEDULED}}, "", true, []int32{private.Id, unlisted.Id}},
+		{"visibility", &pb.StreamFilter{Visibility: []pb.StreamVisibility{pb.StreamVisibility_PUBLIC, pb.StreamVisibility_UNLISTED}}, "", true, []int32{live.Id, unlisted.Id}},
+		{"title", &pb.StreamFilter{TitleContains: "private"}, "", true, []int32{private.Id}},
+		{"case sensitive title", &pb.StreamFilter{TitleContains: "Private"}, "", true, nil},
+		{"after an id", &pb.StreamFilter{IdAfter: live.Id}, "id", true, []int32{private.Id, unlisted.Id}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := ids(tt.filter, tt.sortBy, tt.ascending), fmt.Sprint(tt.want); got != want {
+				t.Errorf("ListStreams returned %s, want %s", got, want)
+			}
+		})
+	}
+
+	// Visibility only changes when it is set, the status always does
+	visibility := pb.StreamVisibility_PUBLIC
+	updated, err := streams.UpdateStream(ctx, &pb.UpdateStreamRequest{
+		Id:        private.Id,
+		Title:     private.Title,
+		StartTime: private.StartTime,
+		EndTime:   private.EndTime,
+		Bitrate:   6000,
+		Framerate: 30,
+		Status:    pb.StreamStatus_COMPLETE,
+	})
+	if err != nil {
+		t.Fatalf("UpdateStream: %v", err)
+	}
+	if updated.Status != pb.StreamStatus_COMPLETE || updated.Visibility != pb.StreamVisibility_PRIVATE {
+		t.Errorf("updated stream is %s and %s, want COMPLETE and PRIVATE", updated.Status, updated.Visibility)
+	}
+	updated, err = streams.UpdateStream(ctx, &pb.UpdateStreamRequest{
+		Id:         private.Id,
+		Title:      private.Title,
+		StartTime:  private.StartTime,
+		EndTime:    private.EndTime,
+		Bitrate:    6000,
+		Framerate:  30,
+		Visibility: &visibility,
+	})
+	if err != nil {
+		t.Fatalf("UpdateStream: %v", err)
+	}
+	if updated.Status != pb.StreamStatus_ONLINE || updated.Visibility != pb.StreamVisibility_PUBLIC {
+		t.Errorf("updated stream is %s and %s, want ONLINE and PUBLIC", updated.Status, updated.Visibility)
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"slices"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type TelemetryServer struct {
+	pb.UnimplementedTelemetryServiceServer
+	store *store.Store
+}
+
+func (s *TelemetryServer) AddSample(ctx context.Context, req *pb.AddSampleRequest) (*pb.SampleResponse, error) {
+	var errs []string
+	if req.StreamId <= 0 {
+		errs = append(errs, "Invalid stream ID")
+	}
+	reportedAt, ok := parseFilterTime(req.ReportedAt)
+	if !ok {
+		errs = append(errs, "Invalid reported at time")
+	}
+	if req.BitrateKbps < 0 || req.Framerate < 0 || req.DroppedFrames < 0 || req.KeyframeIntervalSeconds < 0 {
+		errs = append(errs, "Telemetry values must not be negative")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.SampleResponse
+	err := s.store.Write(func(d *store.Data) error {
+		if stream := d.Stream(req.StreamId); stream == nil || stream.Deleted() {
+			return status.Error(codes.NotFound, "Stream not found")
+		}
+
+		now := s.store.Now()
+		sample := &store.TelemetrySample{
+			BaseEntity:              store.BaseEntity{ID: d.NextSampleID(), CreatedAt: now, UpdatedAt: now},
+			StreamID:                req.StreamId,
+			ReportedAt:              reportedAt.UTC(),
+			BitrateKbps:             req.BitrateKbps,
+			Framerate:               req.Framerate,
+			DroppedFrames:           req.DroppedFrames,
+			KeyframeIntervalSeconds: req.KeyframeIntervalSeconds,
+			AudioLevelDb:            req.AudioLevelDb,
+		}
+		d.TelemetrySamples = append(d.TelemetrySamples, sample)
+		resp = toSampleResponse(sample)
+		return nil
+	})
+	return resp, err
+}
+
+// ListSamples mirrors TelemetryService.ListSamples, which takes the newest
+// samples up to the limit and returns them oldest first
+func (s *TelemetryServer) ListSamples(ctx context.Context, req *pb.ListSamplesRequest) (*pb.ListSamplesResponse, error) {
+	var errs []string
+	if req.StreamId <= 0 {
+		errs = append(errs, "Invalid stream ID")
+	}
+	if req.Limit < 0 {
+		errs = append(errs, "Limit must not be negative")
+	}
+	reportedAfter, filtered := parseFilterTime(req.ReportedAfter)
+	if !filtered && req.ReportedAfter != "" {
+		errs = append(errs, "Invalid reported after time")
+	}
+	if err := validationError(errs); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListSamplesResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var samples []*store.TelemetrySample
+		for _, sample := range d.TelemetrySamples {
+			if sample == nil || sample.StreamID != req.StreamId {
+				continue
+			}
+			if filtered && !sample.ReportedAt.After(reportedAfter) {
+				continue
+			}
+			samples = append(samples, sample)
+		}
+		slices.SortStableFunc(samples, func(a, b *store.TelemetrySample) int { return a.ReportedAt.Compare(b.ReportedAt) })
+		if req.Limit > 0 && len(samples) > int(req.Limit) {
+			samples = samples[len(samples)-int(req.Limit):]
+		}
+		for _, sample := range samples {
+			resp.Samples = append(resp.Samples, toSampleResponse(sample))
+		}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *TelemetryServer) DeleteSamples(ctx context.Context, req *pb.DeleteSamplesRequest) (*emptypb.Empty, error) {
+	reportedBefore, ok := parseFilterTime(req.ReportedBefore)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "Invalid reported before time")
+	}
+
+	err := s.store.Write(func(d *store.Data) error {
+		d.DeleteSamples(func(sample *store.TelemetrySample) bool { return sample.ReportedAt.Before(reportedBefore) })
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func toSampleResponse(sample *store.TelemetrySample) *pb.SampleResponse {
+	return &pb.SampleResponse{
+		Id:                      sample.ID,
+		StreamId:                sample.StreamID,
+		ReportedAt:              sample.ReportedAt.Format(CreatedAtFormat),
+		BitrateKbps:             sample.BitrateKbps,
+		Framerate:               sample.Framerate,
+		DroppedFrames:           sample.DroppedFrames,
+		KeyframeIntervalSeconds: sample.KeyframeIntervalSeconds,
+		AudioLevelDb:            sample.AudioLevelDb,
+	}
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTelemetrySamples(t *testing.T) {
+	wire := dial(t)
+	streams, users, telemetry := pb.NewStreamServiceClient(wire), pb.NewUserServiceClient(wire), pb.NewTelemetryServiceClient(wire)
+	ctx := context.Background()
+
+	user, err := users.CreateUser(ctx, &pb.CreateUserRequest{Email: "alice@example.com", FirstName: "alice", LastName: "Tester", ProfileImageUrl: "https://example.com/alice.png", ClerkId: "user_alice"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	start := time.Now().UTC().Add(time.Hour)
+	var ids []int32
+	for _, title := range []string{"first", "second"} {
+		stream, err := streams.CreateStream(ctx, &pb.CreateStreamRequest{
+			Title:      title,
+			StartTime:  start.Format(TimeFormat),
+			EndTime:    start.Add(time.Hour).Format(TimeFormat),
+			StreamKey:  "key-" + title,
+			Resolution: "1920x1080",
+			Bitrate:    6000,
+			Framerate:  30,
+			Status:     pb.StreamStatus_SCHEDULED,
+			UserId:     int64(user.Id),
+		})
+		if err != nil {
+			t.Fatalf("CreateStream(%s): %v", title, err)
+		}
+		ids = append(ids, stream.Id)
+	}
+	first, second := ids[0], ids[1]
+
+	base := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	audio := -18.5
+	add := func(stream int32, offset time.Duration, bitrate int32) {
+		t.Helper()
+		_, err := telemetry.AddSample(ctx, &pb.AddSampleRequest{
+			StreamId:                stream,
+			ReportedAt:              base.Add(offset).Format(time.RFC3339Nano),
+			BitrateKbps:             bitrate,
+			Framerate:               29.97,
+			KeyframeIntervalSeconds: 2,
+			AudioLevelDb:            &audio,
+		})
+		if err != nil {
+			t.Fatalf("AddSample(%d, %s): %v", stream, offset, err)
+		}
+	}
+	// reported out of order, listed by the time they were reported
+	add(first, 2*time.Second, 5800)
+	add(first, 500*time.Millisecond, 5700)
+	add(first, 4*time.Second, 5900)
+	add(second, 3*time.Second, 3000)
+
+	for _, req := range []*pb.AddSampleRequest{
+		{StreamId: first, ReportedAt: "yesterday"},
+		{StreamId: first, ReportedAt: base.Format(time.RFC3339), BitrateKbps: -1},
+	} {
+		if _, err := telemetry.AddSample(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("AddSample(%v) failed with %v, want InvalidArgument", req, err)
+		}
+	}
+	if _, err := telemetry.AddSample(ctx, &pb.AddSampleRequest{StreamId: 99, ReportedAt: base.Format(time.RFC3339)}); status.Code(err) != codes.NotFound {
+		t.Errorf("AddSample for a missing stream failed with %v, want NotFound", err)
+	}
+
+	bitrates := func(req *pb.ListSamplesRequest) []int32 {
+		t.Helper()
+		resp, err := telemetry.ListSamples(ctx, req)
+		if err != nil {
+			t.Fatalf("ListSamples(%v): %v", req, err)
+		}
+		var bitrates []int32
+		for _, sample := range resp.Samples {
+			bitrates = append(bitrates, sample.BitrateKbps)
+		}
+		return bitrates
+	}
+	tests := []struct {
+		name string
+		req  *pb.ListSamplesRequest
+		want []int32
+	}{
+		{"everything", &pb.ListSamplesRequest{StreamId: first}, []int32{5700, 5800, 5900}},
+		{"newest", &pb.ListSamplesRequest{StreamId: first, Limit: 2}, []int32{5800, 5900}},
+		{"reported after", &pb.ListSamplesRequest{StreamId: first, ReportedAfter: base.Add(time.Second).Format(time.RFC3339Nano)}, []int32{5800, 5900}},
+		{"other stream", &pb.ListSamplesRequest{StreamId: second}, []int32{3000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bitrates(tt.req); !slices.Equal(got, tt.want) {
+				t.Errorf("ListSamples returned %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// the sub-second time and the audio level survive the round trip
+	resp, err := telemetry.ListSamples(ctx, &pb.ListSamplesRequest{StreamId: first, Limit: 3})
+	if err != nil {
+		t.Fatalf("ListSamples: %v", err)
+	}
+	if got := resp.Samples[0]; got.ReportedAt != base.Add(500*time.Millisecond).Format(CreatedAtFormat) || got.AudioLevelDb == nil || *got.AudioLevelDb != audio {
+		t.Errorf("first sample was reported at %s with audio at %v", got.ReportedAt, got.AudioLevelDb)
+	}
+
+	// expired samples are deleted across streams
+	if _, err := telemetry.DeleteSamples(ctx, &pb.DeleteSamplesRequest{ReportedBefore: base.Add(1500 * time.Millisecond).Format(time.RFC3339Nano)}); err != nil {
+		t.Fatalf("DeleteSamples: %v", err)
+	}
+	if got := bitrates(&pb.ListSamplesRequest{StreamId: first}); !slices.Equal(got, []int32{5800, 5900}) {
+		t.Errorf("samples after expiry are %v, want [5800 5900]", got)
+	}
+	if got := bitrates(&pb.ListSamplesRequest{StreamId: second}); !slices.Equal(got, []int32{3000}) {
+		t.Errorf("samples of the other stream after expiry are %v, want [3000]", got)
+	}
+
+	// purging a stream cascades to its samples
+	if _, err := streams.DeleteStream(ctx, &pb.DeleteStreamRequest{Id: second}); err != nil {
+		t.Fatalf("DeleteStream: %v", err)
+	}
+	if _, err := streams.PurgeStream(ctx, &pb.PurgeStreamRequest{Id: second}); err != nil {
+		t.Fatalf("PurgeStream: %v", err)
+	}
+	if got := bitrates(&pb.ListSamplesRequest{StreamId: second}); len(got) != 0 {
+		t.Errorf("samples of a purged stream are %v", got)
+	}
+}
@@ -0,0 +1,291 @@
+package server
+
+import (
+	"context"
+	"net/mail"
+	"sort"
+	"strings"
+
+	pb "github.com/clementus360/streamdb-api/streamdb"
+	"github.com/clementus360/streamdb-memory/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var userColumns = map[string]int{
+	"first_name":        100,
+	"last_name":         100,
+	"email":             255,
+	"profile_image_url": 1000,
+	"clerk_id":          255,
+}
+
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+	store *store.Store
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.UserResponse, error) {
+	if err := validateCreateUser(req); err != nil {
+		return nil, err
+	}
+
+	var resp *pb.UserResponse
+	err := s.store.Write(func(d *store.Data) error {
+		now := s.store.Now()
+
+		// StreamDb compares the raw email, so only an exact match is a duplicate
+		for _, user := range d.Users {
+			if user.Email != req.Email && user.ClerkID != req.ClerkId {
+				continue
+			}
+			if !user.Deleted() {
+				return status.Error(codes.AlreadyExists, "User with this email or ClerkId already exists")
+			}
+
+			// a returning user gets their old account back
+			restored := *user
+			restored.DeletedAt = nil
+			restored.FirstName = strings.TrimSpace(req.FirstName)
+			restored.LastName = strings.TrimSpace(req.LastName)
+			restored.ProfileImageURL = strings.TrimSpace(req.ProfileImageUrl)
+			restored.UpdatedAt = now
+			if err := checkLength("create user", userColumns, userValues(&restored)); err != nil {
+				return err
+			}
+			*user = restored
+			resp = toUserResponse(user)
+			return nil
+		}
+
+		user := &store.User{
+			BaseEntity:      store.BaseEntity{ID: d.NextUserID(), CreatedAt: now, UpdatedAt: now},
+			FirstName:       strings.TrimSpace(req.FirstName),
+			LastName:        strings.TrimSpace(req.LastName),
+			Email:           strings.ToLower(strings.TrimSpace(req.Email)),
+			ProfileImageURL: strings.TrimSpace(req.ProfileImageUrl),
+			ClerkID:         strings.TrimSpace(req.ClerkId),
+		}
+		if err := checkLength("create user", userColumns, userValues(user)); err != nil {
+			return err
+		}
+		d.Users = append(d.Users, user)
+		resp = toUserResponse(user)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.UserResponse, error) {
+	var resp *pb.UserResponse
+	err := s.store.Read(func(d *store.Data) error {
+		// the first match wins even when it was deleted, like FirstOrDefault
+		for _, user := range d.Users {
+			if user.ID != req.Id && user.ClerkID != req.ClerkId {
+				continue
+			}
+			if user.Deleted() {
+				break
+			}
+			resp = toUserResponse(user)
+			return nil
+		}
+		return status.Error(codes.NotFound, "User not found")
+	})
+	return resp, err
+}
+
+func (s *UserServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UserResponse, error) {
+	if req.Id <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+	if !isBlank(req.Email) && !isValidEmail(req.Email) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid email format")
+	}
+
+	var resp *pb.UserResponse
+	err := s.store.Write(func(d *store.Data) error {
+		user := d.User(req.Id)
+		if user == nil || user.Deleted() {
+			return status.Error(codes.NotFound, "User not found")
+		}
+
+		updated := *user
+		if !isBlank(req.Email) {
+			email := strings.ToLower(strings.TrimSpace(req.Email))
+			for _, other := range d.Users {
+				if other.ID != user.ID && !other.Deleted() && other.Email == email {
+					return status.Error(codes.AlreadyExists, "Email already in use")
+				}
+			}
+			updated.Email = email
+		}
+		if !isBlank(req.FirstName) {
+			updated.FirstName = strings.TrimSpace(req.FirstName)
+		}
+		if !isBlank(req.LastName) {
+			updated.LastName = strings.TrimSpace(req.LastName)
+		}
+		if !isBlank(req.ProfileImageUrl) {
+			updated.ProfileImageURL = strings.TrimSpace(req.ProfileImageUrl)
+		}
+		if err := checkLength("update user", userColumns, userValues(&updated)); err != nil {
+			return err
+		}
+
+		updated.UpdatedAt = s.store.Now()
+		*user = updated
+		resp = toUserResponse(user)
+		return nil
+	})
+	return resp, err
+}
+
+func (s *UserServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	err := s.store.Write(func(d *store.Data) error {
+		user := d.User(req.Id)
+		if user == nil || user.Deleted() {
+			return status.Error(codes.NotFound, "User not found")
+		}
+
+		for _, stream := range d.Streams {
+			if stream == nil || stream.UserID != user.ID || stream.Deleted() {
+				continue
+			}
+			if stream.Status == store.StatusOnline || stream.Status == store.StatusScheduled {
+				return status.Error(codes.FailedPrecondition, "Cannot delete user with active streams. Please end or cancel all streams first.")
+			}
+		}
+
+		now := s.store.Now()
+		user.DeletedAt = &now
+		user.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	resp := &pb.ListUsersResponse{}
+	err := s.store.Read(func(d *store.Data) error {
+		var users []*store.User
+		for _, user := range d.Users {
+			if !user.Deleted() && matchUser(user, req.Filter) {
+				users = append(users, user)
+			}
+		}
+		sortUsers(users, req.SortBy, req.Ascending)
+
+		// unlike streams and comments, StreamDb does not clamp user pages
+		page := store.Paginate(len(users), req.PageSize, req.PageNumber, false)
+		start, end := page.Bounds()
+		for _, user := range users[start:end] {
+			resp.Users = append(resp.Users, toUserResponse(user))
+		}
+		resp.Pagination = toPaginationMetadata(page)
+		return nil
+	})
+	return resp, err
+}
+
+// validateCreateUser mirrors UsersService.ValidateCreateUserRequest
+func validateCreateUser(req *pb.CreateUserRequest) error {
+	var errs []string
+
+	if isBlank(req.Email) {
+		errs = append(errs, "Email is required")
+	} else if !isValidEmail(req.Email) {
+		errs = append(errs, "Invalid email format")
+	}
+	if isBlank(req.FirstName) {
+		errs = append(errs, "First name is required")
+	}
+	if isBlank(req.LastName) {
+		errs = append(errs, "Last name is required")
+	}
+	if isBlank(req.ProfileImageUrl) {
+		errs = append(errs, "Profile image URL is required")
+	}
+	if isBlank(req.ClerkId) {
+		errs = append(errs, "ClerkId is required")
+	}
+
+	return validationError(errs)
+}
+
+// isValidEmail accepts what System.Net.Mail.MailAddress accepts for a bare
+// address
+func isValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+func matchUser(user *store.User, filter *pb.UserFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if !isBlank(filter.EmailContains) && !strings.Contains(user.Email, filter.EmailContains) {
+		return false
+	}
+	if !isBlank(filter.NameContains) &&
+		!strings.Contains(user.FirstName, filter.NameContains) &&
+		!strings.Contains(user.LastName, filter.NameContains) {
+		return false
+	}
+	if filter.IdEquals > 0 && user.ID != filter.IdEquals {
+		return false
+	}
+	return true
+}
+
+func sortUsers(users []*store.User, sortBy string, ascending bool) {
+	var less func(a, b *store.User) int
+	switch strings.ToLower(sortBy) {
+	case "email":
+		less = func(a, b *store.User) int { return strings.Compare(a.Email, b.Email) }
+	case "firstname":
+		less = func(a, b *store.User) int { return strings.Compare(a.FirstName, b.FirstName) }
+	case "lastname":
+		less = func(a, b *store.User) int { return strings.Compare(a.LastName, b.LastName) }
+	default:
+		less = func(a, b *store.User) int { return int(a.ID) - int(b.ID) }
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		if ascending {
+			return less(users[i], users[j]) < 0
+		}
+		return less(users[i], users[j]) > 0
+	})
+}
+
+// activeUser reports whether id is an existing user that was not deleted
+func activeUser(d *store.Data, id int32) bool {
+	user := d.User(id)
+	return user != nil && !user.Deleted()
+}
+
+func userValues(user *store.User) map[string]string {
+	return map[string]string{
+		"first_name":        user.FirstName,
+		"last_name":         user.LastName,
+		"email":             user.Email,
+		"profile_image_url": user.ProfileImageURL,
+		"clerk_id":          user.ClerkID,
+	}
+}
+
+func toUserResponse(user *store.User) *pb.UserResponse {
+	return &pb.UserResponse{
+		Id:              user.ID,
+		Email:           user.Email,
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		ProfileImageUrl: user.ProfileImageURL,
+		ClerkId:         user.ClerkID,
+	}
+}
@@ -0,0 +1,46 @@
+package store
+
+import "math"
+
+// MaxPageSize is the largest page StreamDb returns
+const MaxPageSize = 10
+
+// Page describes the slice of a result set to return
+type Page struct {
+	Size       int
+	Number     int
+	TotalItems int
+	TotalPages int
+}
+
+// Paginate applies the paging rules of StreamDb: sizes outside 1..10 fall
+// back to 10, numbers below 1 become 1 and, when clamp is set, numbers past
+// the end select the last page. UsersService does not clamp and returns an
+// empty page instead.
+func Paginate(totalItems int, size, number int32, clamp bool) Page {
+	p := Page{Size: int(size), Number: int(number), TotalItems: totalItems}
+	if p.Size <= 0 || p.Size > MaxPageSize {
+		p.Size = MaxPageSize
+	}
+	if p.Number <= 0 {
+		p.Number = 1
+	}
+	p.TotalPages = int(math.Ceil(float64(totalItems) / float64(p.Size)))
+	if clamp && p.TotalPages > 0 && p.Number > p.TotalPages {
+		p.Number = p.TotalPages
+	}
+	return p
+}
+
+// Bounds returns the indexes of the page within a result of TotalItems rows
+func (p Page) Bounds() (int, int) {
+	start := (p.Number - 1) * p.Size
+	if start > p.TotalItems {
+		start = p.TotalItems
+	}
+	end := start + p.Size
+	if end > p.TotalItems {
+		end = p.TotalItems
+	}
+	return start, end
+}
@@ -0,0 +1,587 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// StreamStatus mirrors the EStreamStatus enum of StreamDb
+type StreamStatus int32
+
+const (
+	StatusOnline StreamStatus = iota
+	StatusOffline
+	StatusComplete
+	StatusScheduled
+)
+
+// StreamVisibility mirrors the EStreamVisibility enum of StreamDb
+type StreamVisibility int32
+
+const (
+	VisibilityPublic StreamVisibility = iota
+	VisibilityUnlisted
+	VisibilityPrivate
+)
+
+// BaseEntity holds the columns shared by every table
+type BaseEntity struct {
+	ID        int32      `json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Deleted reports whether the row was soft deleted
+func (e *BaseEntity) Deleted() bool {
+	return e.DeletedAt != nil
+}
+
+type User struct {
+	BaseEntity
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	Email           string `json:"email"`
+	ProfileImageURL string `json:"profile_image_url"`
+	ClerkID         string `json:"clerk_id"`
+	// Username and LastLogin are only read and written through the API of
+	// the database service of comment-service and user-service, as StreamDb
+	// has no such columns
+	Username  string     `json:"username,omitempty"`
+	LastLogin *time.Time `json:"last_login,omitempty"`
+}
+
+type Stream struct {
+	BaseEntity
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	StartTime   time.Time        `json:"start_time"`
+	EndTime     time.Time        `json:"end_time"`
+	StreamKey   string           `json:"stream_key"`
+	Resolution  string           `json:"resolution"`
+	Bitrate     int32            `json:"bitrate"`
+	Framerate   int32            `json:"framerate"`
+	Codec       string           `json:"codec"`
+	ViewCount   int32            `json:"view_count"`
+	Protocol    string           `json:"protocol"`
+	Status      StreamStatus     `json:"status"`
+	Visibility  StreamVisibility `json:"visibility"`
+	UserID      int32            `json:"user_id"`
+}
+
+type Comment struct {
+	BaseEntity
+	Message  string `json:"message"`
+	UserID   int32  `json:"user_id"`
+	StreamID int32  `json:"stream_id"`
+}
+
+// Collaborator is a role granted by an owner on one of their streams, or on
+// all of them when StreamID is 0
+type Collaborator struct {
+	BaseEntity
+	OwnerID  int32  `json:"owner_id"`
+	StreamID int32  `json:"stream_id,omitempty"`
+	UserID   int32  `json:"user_id"`
+	Role     string `json:"role"`
+}
+
+// RestreamDestination is where a stream, or every stream of the owner when
+// StreamID is 0, is relayed to. The stream key is kept as sealed by
+// stream-service.
+type RestreamDestination struct {
+	BaseEntity
+	OwnerID   int32  `json:"owner_id"`
+	StreamID  int32  `json:"stream_id,omitempty"`
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	SealedKey string `json:"sealed_key"`
+	KeyHint   string `json:"key_hint"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// TelemetrySample is one report of the encoder of a stream, stamped by
+// stream-service when it was received
+type TelemetrySample struct {
+	BaseEntity
+	StreamID                int32     `json:"stream_id"`
+	ReportedAt              time.Time `json:"reported_at"`
+	BitrateKbps             int32     `json:"bitrate_kbps"`
+	Framerate               float64   `json:"framerate"`
+	DroppedFrames           int32     `json:"dropped_frames"`
+	KeyframeIntervalSeconds float64   `json:"keyframe_interval_seconds"`
+	AudioLevelDb            *float64  `json:"audio_level_db,omitempty"`
+}
+
+// RenditionLadder is the ladder chosen for a stream: a preset of
+// stream-service, or the specs of a custom ladder separated by slashes
+type RenditionLadder struct {
+	BaseEntity
+	StreamID   int32  `json:"stream_id"`
+	Preset     string `json:"preset"`
+	Renditions string `json:"renditions"`
+}
+
+// Clip is a range of the segments of a broadcast, looked up by the public
+// id stream-service gives it. Renditions holds the ladder of the stream when
+// the clip was cut, separated by slashes.
+type Clip struct {
+	BaseEntity
+	PublicID          string `json:"public_id"`
+	StreamID          int32  `json:"stream_id"`
+	CreatorID         int32  `json:"creator_id"`
+	Title             string `json:"title"`
+	Source            string `json:"source"`
+	FirstSegment      int32  `json:"first_segment"`
+	Segments          int32  `json:"segments"`
+	SegmentDurationMs int32  `json:"segment_duration_ms"`
+	Renditions        string `json:"renditions"`
+}
+
+// Broadcast is the last broadcast of a stream. EndedAt is nil while it is
+// live.
+type Broadcast struct {
+	BaseEntity
+	StreamID  int32      `json:"stream_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+// AuditEntry is a change one of the Go services recorded in the audit log.
+// Entries are never changed or removed.
+type AuditEntry struct {
+	BaseEntity
+	RecordedAt   time.Time     `json:"recorded_at"`
+	Service      string        `json:"service"`
+	Method       string        `json:"method"`
+	Action       string        `json:"action"`
+	ActorID      int64         `json:"actor_id,omitempty"`
+	ActorRoles   []string      `json:"actor_roles,omitempty"`
+	ActorService string        `json:"actor_service,omitempty"`
+	TargetType   string        `json:"target_type"`
+	TargetID     string        `json:"target_id"`
+	Changes      []AuditChange `json:"changes,omitempty"`
+	RequestID    string        `json:"request_id,omitempty"`
+}
+
+// AuditChange is a field of the target of an audit entry before and after
+// the change, as JSON values
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// ViewerSession is the time a viewer spent watching a stream, looked up by
+// the id stream-service gives it. LeftAt is nil while the viewer is
+// watching.
+type ViewerSession struct {
+	BaseEntity
+	PublicID   string     `json:"public_id"`
+	StreamID   int32      `json:"stream_id"`
+	ViewerID   string     `json:"viewer_id"`
+	ClientType string     `json:"client_type"`
+	JoinedAt   time.Time  `json:"joined_at"`
+	LeftAt     *time.Time `json:"left_at,omitempty"`
+}
+
+// AnalyticsRollup is the summary stream-service computed of the sessions of
+// an ended stream, kept as the JSON it sent
+type AnalyticsRollup struct {
+	BaseEntity
+	StreamID   int32     `json:"stream_id"`
+	Summary    string    `json:"summary"`
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+// Data is the content of the database. Rows are kept in insertion order,
+// which is also id order. Deletes are soft like in StreamDb; only purged
+// streams and their comments, revoked collaborators, deleted restream
+// destinations, expired telemetry samples, deleted rendition ladders, clips,
+// the broadcasts of deleted streams, expired viewer sessions and outdated
+// rollups are removed, leaving nil in their slots so ids keep matching
+// positions.
+type Data struct {
+	Users            []*User                `json:"users"`
+	Streams          []*Stream              `json:"streams"`
+	Comments         []*Comment             `json:"comments"`
+	Collaborators    []*Collaborator        `json:"collaborators"`
+	Destinations     []*RestreamDestination `json:"restream_destinations"`
+	TelemetrySamples []*TelemetrySample     `json:"telemetry_samples"`
+	Ladders          []*RenditionLadder     `json:"rendition_ladders"`
+	Clips            []*Clip                `json:"clips"`
+	Broadcasts       []*Broadcast           `json:"broadcasts"`
+	AuditEntries     []*AuditEntry          `json:"audit_entries"`
+	ViewerSessions   []*ViewerSession       `json:"viewer_sessions"`
+	Rollups          []*AnalyticsRollup     `json:"analytics_rollups"`
+}
+
+// Store is an in-memory database, optionally persisted to a JSON snapshot
+type Store struct {
+	mu       sync.RWMutex
+	data     Data
+	snapshot string
+	now      func() time.Time
+}
+
+// New returns an empty store. When snapshot is not empty the store is loaded
+// from that file if it exists and saved to it after every change.
+func New(snapshot string) (*Store, error) {
+	s := &Store{snapshot: snapshot, now: func() time.Time { return time.Now().UTC() }}
+	if snapshot == "" {
+		return s, nil
+	}
+
+	raw, err := os.ReadFile(snapshot)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := json.Unmarshal(raw, &s.data); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", snapshot, err)
+	}
+	return s, nil
+}
+
+// Now returns the current time as stored in timestamps
+func (s *Store) Now() time.Time {
+	return s.now()
+}
+
+// Read runs fn with shared access to the data. fn must not keep references
+// to rows after it returns.
+func (s *Store) Read(fn func(d *Data) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(&s.data)
+}
+
+// Write runs fn with exclusive access to a copy of the data, which replaces
+// the data once it is saved to the snapshot. Nothing changes when fn fails
+// or the snapshot cannot be written, like a failed transaction.
+func (s *Store) Write(fn func(d *Data) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	draft := s.data.clone()
+	if err := fn(draft); err != nil {
+		return err
+	}
+	if err := s.save(draft); err != nil {
+		return err
+	}
+	s.data = *draft
+	return nil
+}
+
+// save writes data to the snapshot atomically. It must be called with the
+// lock held.
+func (s *Store) save(data *Data) error {
+	if s.snapshot == "" {
+		return nil
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.snapshot), filepath.Base(s.snapshot)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.snapshot); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// clone copies the rows so that they can be changed in place. Timestamps
+// are shared, callers replace them rather than change them.
+func (d *Data) clone() *Data {
+	return &Data{
+		Users:            cloneRows(d.Users),
+		Streams:          cloneRows(d.Streams),
+		Comments:         cloneRows(d.Comments),
+		Collaborators:    cloneRows(d.Collaborators),
+		Destinations:     cloneRows(d.Destinations),
+		TelemetrySamples: cloneRows(d.TelemetrySamples),
+		Ladders:          cloneRows(d.Ladders),
+		Clips:            cloneRows(d.Clips),
+		Broadcasts:       cloneRows(d.Broadcasts),
+		AuditEntries:     cloneRows(d.AuditEntries),
+		ViewerSessions:   cloneRows(d.ViewerSessions),
+		Rollups:          cloneRows(d.Rollups),
+	}
+}
+
+func cloneRows[T any](rows []*T) []*T {
+	clone := make([]*T, len(rows))
+	for i, row := range rows {
+		if row != nil {
+			copied := *row
+			clone[i] = &copied
+		}
+	}
+	return clone
+}
+
+// NextUserID returns the identity value of the next user, like a Postgres
+// identity column ids are never reused
+func (d *Data) NextUserID() int32 {
+	return int32(len(d.Users)) + 1
+}
+
+func (d *Data) NextStreamID() int32 {
+	return int32(len(d.Streams)) + 1
+}
+
+func (d *Data) NextCommentID() int32 {
+	return int32(len(d.Comments)) + 1
+}
+
+func (d *Data) NextCollaboratorID() int32 {
+	return int32(len(d.Collaborators)) + 1
+}
+
+func (d *Data) NextDestinationID() int32 {
+	return int32(len(d.Destinations)) + 1
+}
+
+func (d *Data) NextSampleID() int32 {
+	return int32(len(d.TelemetrySamples)) + 1
+}
+
+func (d *Data) NextLadderID() int32 {
+	return int32(len(d.Ladders)) + 1
+}
+
+func (d *Data) NextClipID() int32 {
+	return int32(len(d.Clips)) + 1
+}
+
+func (d *Data) NextBroadcastID() int32 {
+	return int32(len(d.Broadcasts)) + 1
+}
+
+func (d *Data) NextAuditEntryID() int32 {
+	return int32(len(d.AuditEntries)) + 1
+}
+
+func (d *Data) NextViewerSessionID() int32 {
+	return int32(len(d.ViewerSessions)) + 1
+}
+
+func (d *Data) NextRollupID() int32 {
+	return int32(len(d.Rollups)) + 1
+}
+
+// User returns the user with id, including soft deleted ones
+func (d *Data) User(id int32) *User {
+	if id < 1 || int(id) > len(d.Users) {
+		return nil
+	}
+	return d.Users[id-1]
+}
+
+// Stream returns the stream with id, including soft deleted ones, or nil
+// when it does not exist or was purged
+func (d *Data) Stream(id int32) *Stream {
+	if id < 1 || int(id) > len(d.Streams) {
+		return nil
+	}
+	return d.Streams[id-1]
+}
+
+// Comment returns the comment with id, including soft deleted ones, or nil
+// when it does not exist or was purged
+func (d *Data) Comment(id int32) *Comment {
+	if id < 1 || int(id) > len(d.Comments) {
+		return nil
+	}
+	return d.Comments[id-1]
+}
+
+// Collaborator returns the grant of userID on a stream or the channel of
+// ownerID, or nil when there is none
+func (d *Data) Collaborator(ownerID, streamID, userID int32) *Collaborator {
+	for _, collaborator := range d.Collaborators {
+		if collaborator != nil && collaborator.OwnerID == ownerID && collaborator.StreamID == streamID && collaborator.UserID == userID {
+			return collaborator
+		}
+	}
+	return nil
+}
+
+// Destination returns the restream destination with id, or nil when it does
+// not exist or was deleted
+func (d *Data) Destination(id int32) *RestreamDestination {
+	if id < 1 || int(id) > len(d.Destinations) {
+		return nil
+	}
+	return d.Destinations[id-1]
+}
+
+// DeleteStreamDestinations removes the restream destinations of the stream
+// with id
+func (d *Data) DeleteStreamDestinations(id int32) {
+	for i, destination := range d.Destinations {
+		if destination != nil && destination.StreamID == id {
+			d.Destinations[i] = nil
+		}
+	}
+}
+
+// Ladder returns the rendition ladder of the stream with id, or nil when it
+// has none
+func (d *Data) Ladder(streamID int32) *RenditionLadder {
+	for _, ladder := range d.Ladders {
+		if ladder != nil && ladder.StreamID == streamID {
+			return ladder
+		}
+	}
+	return nil
+}
+
+// DeleteStreamLadder removes the rendition ladder of the stream with id
+func (d *Data) DeleteStreamLadder(id int32) {
+	for i, ladder := range d.Ladders {
+		if ladder != nil && ladder.StreamID == id {
+			d.Ladders[i] = nil
+		}
+	}
+}
+
+// Clip returns the clip with publicID, or nil when it does not exist or was
+// deleted
+func (d *Data) Clip(publicID string) *Clip {
+	for _, clip := range d.Clips {
+		if clip != nil && clip.PublicID == publicID {
+			return clip
+		}
+	}
+	return nil
+}
+
+// Broadcast returns the last broadcast of the stream with id, or nil when it
+// was never broadcast
+func (d *Data) Broadcast(streamID int32) *Broadcast {
+	for _, broadcast := range d.Broadcasts {
+		if broadcast != nil && broadcast.StreamID == streamID {
+			return broadcast
+		}
+	}
+	return nil
+}
+
+// DeleteStreamClips removes the clips and the broadcast of the stream with
+// id
+func (d *Data) DeleteStreamClips(id int32) {
+	for i, clip := range d.Clips {
+		if clip != nil && clip.StreamID == id {
+			d.Clips[i] = nil
+		}
+	}
+	for i, broadcast := range d.Broadcasts {
+		if broadcast != nil && broadcast.StreamID == id {
+			d.Broadcasts[i] = nil
+		}
+	}
+}
+
+// DeleteSamples removes the telemetry samples for which drop returns true
+func (d *Data) DeleteSamples(drop func(*TelemetrySample) bool) {
+	for i, sample := range d.TelemetrySamples {
+		if sample != nil && drop(sample) {
+			d.TelemetrySamples[i] = nil
+		}
+	}
+}
+
+// ViewerSession returns the viewer session with publicID, or nil when it
+// does not exist or expired
+func (d *Data) ViewerSession(publicID string) *ViewerSession {
+	for _, session := range d.ViewerSessions {
+		if session != nil && session.PublicID == publicID {
+			return session
+		}
+	}
+	return nil
+}
+
+// Rollup returns the analytics rollup of the stream with id, or nil when it
+// has none
+func (d *Data) Rollup(streamID int32) *AnalyticsRollup {
+	for _, rollup := range d.Rollups {
+		if rollup != nil && rollup.StreamID == streamID {
+			return rollup
+		}
+	}
+	return nil
+}
+
+// DeleteViewerSessions removes the viewer sessions for which drop returns
+// true
+func (d *Data) DeleteViewerSessions(drop func(*ViewerSession) bool) {
+	for i, session := range d.ViewerSessions {
+		if session != nil && drop(session) {
+			d.ViewerSessions[i] = nil
+		}
+	}
+}
+
+// DeleteStreamRollup removes the analytics rollup of the stream with id
+func (d *Data) DeleteStreamRollup(id int32) {
+	for i, rollup := range d.Rollups {
+		if rollup != nil && rollup.StreamID == id {
+			d.Rollups[i] = nil
+		}
+	}
+}
+
+// DeleteStreamCollaborators removes the grants on the stream with id
+func (d *Data) DeleteStreamCollaborators(id int32) {
+	for i, collaborator := range d.Collaborators {
+		if collaborator != nil && collaborator.StreamID == id {
+			d.Collaborators[i] = nil
+		}
+	}
+}
+
+// PurgeStream removes the stream with id, its comments, the grants on it,
+// its restream destinations, its telemetry, its rendition ladder, its clips,
+// its broadcast and its analytics, like the cascades on the foreign keys to
+// streams in StreamDb
+func (d *Data) PurgeStream(id int32) {
+	if d.Stream(id) == nil {
+		return
+	}
+	d.Streams[id-1] = nil
+	for i, comment := range d.Comments {
+		if comment != nil && comment.StreamID == id {
+			d.Comments[i] = nil
+		}
+	}
+	d.DeleteStreamCollaborators(id)
+	d.DeleteStreamDestinations(id)
+	d.DeleteSamples(func(sample *TelemetrySample) bool { return sample.StreamID == id })
+	d.DeleteStreamLadder(id)
+	d.DeleteStreamClips(id)
+	d.DeleteViewerSessions(func(session *ViewerSession) bool { return session.StreamID == id })
+	d.DeleteStreamRollup(id)
+}
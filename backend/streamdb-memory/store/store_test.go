@@ -0,0 +1,159 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// addStream stores a stream of user 1 with title
+func addStream(t *testing.T, s *Store, title string) {
+	t.Helper()
+
+	err := s.Write(func(d *Data) error {
+		d.Streams = append(d.Streams, &Stream{BaseEntity: BaseEntity{ID: d.NextStreamID()}, Title: title, UserID: 1})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+}
+
+// titles returns the titles of the streams, with "-" for purged ones
+func titles(s *Store) []string {
+	var titles []string
+	s.Read(func(d *Data) error {
+		for _, stream := range d.Streams {
+			if stream == nil {
+				titles = append(titles, "-")
+				continue
+			}
+			titles = append(titles, stream.Title)
+		}
+		return nil
+	})
+	return titles
+}
+
+func TestWriteDropsChangesOfFailedCalls(t *testing.T) {
+	s, err := New("")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	addStream(t, s, "first")
+
+	failure := errors.New("validation failed")
+	err = s.Write(func(d *Data) error {
+		d.Stream(1).Title = "changed"
+		d.Streams = append(d.Streams, &Stream{BaseEntity: BaseEntity{ID: d.NextStreamID()}, Title: "second"})
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Write returned %v, want the error of fn", err)
+	}
+	if got := titles(s); len(got) != 1 || got[0] != "first" {
+		t.Errorf("streams are %q after a failed write, want [first]", got)
+	}
+}
+
+func TestWriteDropsChangesThatCannotBeSaved(t *testing.T) {
+	dir := t.TempDir()
+	s, err := New(filepath.Join(dir, "data.json"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	addStream(t, s, "first")
+
+	// the snapshot cannot be written once its directory is gone
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	err = s.Write(func(d *Data) error {
+		d.Stream(1).Title = "changed"
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Write succeeded without a snapshot directory")
+	}
+	if got := titles(s); len(got) != 1 || got[0] != "first" {
+		t.Errorf("streams are %q after a failed save, want [first]", got)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "data.json")
+	s, err := New(snapshot)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for _, title := range []string{"first", "second", "third"} {
+		addStream(t, s, title)
+	}
+
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	err = s.Write(func(d *Data) error {
+		d.Stream(2).DeletedAt = &deletedAt
+		d.PurgeStream(3)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	reloaded, err := New(snapshot)
+	if err != nil {
+		t.Fatalf("New with a snapshot: %v", err)
+	}
+	if got := titles(reloaded); len(got) != 3 || got[0] != "first" || got[1] != "second" || got[2] != "-" {
+		t.Errorf("reloaded streams are %q, want [first second -]", got)
+	}
+	reloaded.Read(func(d *Data) error {
+		if stream := d.Stream(2); !stream.Deleted() || !stream.DeletedAt.Equal(deletedAt) {
+			t.Errorf("reloaded stream 2 was deleted at %v, want %v", stream.DeletedAt, deletedAt)
+		}
+		// ids are not reused after a purge
+		if id := d.NextStreamID(); id != 4 {
+			t.Errorf("next stream id is %d after reloading, want 4", id)
+		}
+		return nil
+	})
+
+	if err := os.WriteFile(snapshot, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(snapshot); err == nil {
+		t.Error("New loaded a corrupt snapshot")
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		size, number int32
+		clamp        bool
+		want         Page
+		start, end   int
+	}{
+		{"first page", 25, 10, 1, true, Page{Size: 10, Number: 1, TotalItems: 25, TotalPages: 3}, 0, 10},
+		{"last page", 25, 10, 3, true, Page{Size: 10, Number: 3, TotalItems: 25, TotalPages: 3}, 20, 25},
+		{"size too large", 25, 50, 1, true, Page{Size: 10, Number: 1, TotalItems: 25, TotalPages: 3}, 0, 10},
+		{"no size", 5, 0, 0, true, Page{Size: 10, Number: 1, TotalItems: 5, TotalPages: 1}, 0, 5},
+		{"clamped past the end", 25, 10, 9, true, Page{Size: 10, Number: 3, TotalItems: 25, TotalPages: 3}, 20, 25},
+		{"empty past the end", 25, 10, 9, false, Page{Size: 10, Number: 9, TotalItems: 25, TotalPages: 3}, 25, 25},
+		{"nothing", 0, 10, 2, true, Page{Size: 10, Number: 2, TotalItems: 0, TotalPages: 0}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := Paginate(tt.total, tt.size, tt.number, tt.clamp)
+			if page != tt.want {
+				t.Errorf("Paginate(%d, %d, %d, %t) = %+v, want %+v", tt.total, tt.size, tt.number, tt.clamp, page, tt.want)
+			}
+			if start, end := page.Bounds(); start != tt.start || end != tt.end {
+				t.Errorf("Bounds() = %d, %d, want %d, %d", start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
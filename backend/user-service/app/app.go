@@ -0,0 +1,285 @@
+// Package app assembles the user service from its configuration. It is
+// shared by cmd/server and the integration tests, which run the service
+// in-process.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/Josy-coder/user-service/internal/clients"
+	"github.com/Josy-coder/user-service/internal/config"
+	"github.com/Josy-coder/user-service/internal/metrics"
+	"github.com/Josy-coder/user-service/internal/ports"
+	"github.com/Josy-coder/user-service/internal/service"
+	pb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/audit"
+	"github.com/clementus360/platform/auth"
+	platformconfig "github.com/clementus360/platform/config"
+	"github.com/clementus360/platform/dial"
+	"github.com/clementus360/platform/health"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/tlsconfig"
+	"github.com/clementus360/platform/tracing"
+	"github.com/clementus360/streamdb-api/streamdb"
+)
+
+// LoadConfig reads the configuration from defaults, config file, environment
+// and flags
+func LoadConfig(args []string) (*config.Config, error) {
+	return config.LoadConfig(args)
+}
+
+// Option customizes an App
+type Option func(*options)
+
+type options struct {
+	logger      *slog.Logger
+	dialOptions []grpc.DialOption
+}
+
+// WithLogger replaces the logger built from the configuration
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithDialOptions adds options to the connections to other services
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// App is a user service connected to its dependencies
+type App struct {
+	tlsManager    *tlsconfig.Manager
+	checker       *health.Checker
+	grpcServer    *grpc.Server
+	handler       http.Handler
+	dbClient      *clients.DBServiceClient
+	commentClient *clients.CommentServiceClient
+	streamClient  *clients.StreamServiceClient
+	auditConn     *grpc.ClientConn
+}
+
+// New connects to the dependencies and registers the user service on a
+// gRPC server. Nothing is served until the server is handed a listener.
+func New(ctx context.Context, cfg *config.Config, opts ...Option) (*App, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	logger := o.logger
+	if logger == nil {
+		logger = logging.New("user-service", cfg.Log.Level, cfg.Log.Format)
+	}
+
+	// Load the certificates shared by the gRPC server and clients
+	tlsManager, err := tlsconfig.New(ctx, "user-service", tlsconfig.Config{
+		Enabled:           cfg.TLS.Enabled,
+		CertFile:          cfg.TLS.CertFile,
+		KeyFile:           cfg.TLS.KeyFile,
+		CAFile:            cfg.TLS.CAFile,
+		ClientAuth:        cfg.TLS.ClientAuth,
+		AllowedIdentities: cfg.TLS.AllowedIdentities,
+		ReloadInterval:    cfg.TLS.ReloadInterval,
+		DevMode:           cfg.TLS.DevMode,
+		DevDir:            cfg.TLS.DevDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize TLS: %w", err)
+	}
+
+	// Sign outgoing calls and verify incoming ones with the shared service key
+	authenticator := auth.New("user-service", auth.Config{
+		SigningKey:     cfg.Auth.SigningKey,
+		Enforce:        cfg.Auth.Enforce,
+		AllowedCallers: cfg.Auth.AllowedCallers,
+		TrustedCallers: cfg.Auth.TrustedCallers,
+		TokenTTL:       cfg.Auth.TokenTTL,
+	})
+
+	// Register the Prometheus collectors exposed on /metrics
+	serviceMetrics := metrics.New("user_service")
+
+	// The Backend API URL is only overridden to talk to a fake identity
+	// provider
+	var clerkOptions []clerk.ClerkOption
+	if cfg.ClerkAPIURL != "" {
+		clerkOptions = append(clerkOptions, clerk.WithBaseURL(cfg.ClerkAPIURL))
+	}
+	clerkClient, err := clerk.NewClient(cfg.ClerkSecretKey, clerkOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Clerk client: %w", err)
+	}
+
+	// Every outgoing connection shares the same deadlines, retries and
+	// circuit breaker settings
+	methodTimeouts, _ := cfg.Client.MethodTimeoutMap()
+	dialer := dial.NewDialer(dial.Config{
+		Timeout:        cfg.Client.Timeout,
+		MethodTimeouts: methodTimeouts,
+		Retry: dial.RetryConfig{
+			MaxAttempts:    cfg.Client.RetryMaxAttempts,
+			InitialBackoff: cfg.Client.RetryInitialBackoff,
+			MaxBackoff:     cfg.Client.RetryMaxBackoff,
+		},
+		Breaker: dial.BreakerConfig{
+			Failures:    cfg.Client.BreakerFailures,
+			OpenTimeout: cfg.Client.BreakerOpenTimeout,
+		},
+		KeepaliveTime:     cfg.Client.KeepaliveTime,
+		KeepaliveTimeout:  cfg.Client.KeepaliveTimeout,
+		MaxConnectBackoff: cfg.Client.MaxConnectBackoff,
+		DialOptions:       o.dialOptions,
+	}, tlsManager, authenticator, serviceMetrics.Metrics)
+
+	a := &App{tlsManager: tlsManager}
+
+	// Initialize service clients
+	a.dbClient, err = clients.NewDBServiceClient(cfg.Services.DBServiceURL, dialer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database service client: %w", err)
+	}
+
+	a.commentClient, err = clients.NewCommentServiceClient(cfg.Services.CommentServiceURL, dialer)
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to create comment service client: %w", err)
+	}
+
+	a.streamClient, err = clients.NewStreamServiceClient(cfg.Services.StreamServiceURL, dialer)
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to create stream service client: %w", err)
+	}
+
+	authClient := clients.NewAuthClient(clerkClient)
+	userService := service.NewUserService(a.dbClient, clerkClient, a.commentClient, a.streamClient)
+
+	// Mutations are recorded in the audit log StreamDb keeps for every
+	// service
+	a.auditConn, err = dialer.Dial(dial.Target{
+		Name:       "audit_log",
+		Audience:   "database-service",
+		Address:    cfg.Audit.Address,
+		Service:    streamdb.WireName(streamdb.AuditService_ServiceDesc.ServiceName),
+		Idempotent: []string{"QueryAuditLog"},
+	})
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to connect to the audit log: %w", err)
+	}
+	auditLog := audit.NewLog("user-service", streamdb.NewAuditServiceClient(streamdb.WireConn(a.auditConn)))
+	auditRecorder := audit.NewRecorder(auditLog, ports.AuditedMethods(userService))
+
+	a.grpcServer = grpc.NewServer(
+		tlsManager.ServerOption(),
+		tracing.ServerOption(),
+		// Accept the keepalive pings of other services' clients
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             platformconfig.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(logger),
+			serviceMetrics.UnaryServerInterceptor(),
+			// End users calling with a Clerk token are authenticated before
+			// the service token is checked
+			authClient.UnaryServerInterceptor(func(ctx context.Context, clerkID string) (int32, error) {
+				user, err := userService.GetUserByClerkID(ctx, clerkID)
+				if err != nil {
+					return 0, err
+				}
+				return user.ID, nil
+			}),
+			authenticator.UnaryServerInterceptor(),
+			auditRecorder.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			logging.StreamServerInterceptor(logger),
+			serviceMetrics.StreamServerInterceptor(),
+			authenticator.StreamServerInterceptor(),
+		),
+	)
+	pb.RegisterUserServiceServer(a.grpcServer, ports.NewGRPCServer(userService, serviceMetrics, auditLog))
+
+	// Probe dependencies for readiness and expose grpc.health.v1
+	a.checker = health.NewChecker(2*time.Second, pb.UserService_ServiceDesc.ServiceName)
+	a.checker.AddProbe("database_service", health.ConnProbe(a.dbClient.Conn()))
+	a.checker.AddProbe("comment_service", health.ConnProbe(a.commentClient.Conn()))
+	a.checker.AddProbe("stream_service", health.ConnProbe(a.streamClient.Conn()))
+	a.checker.AddProbe("audit_log", health.ConnProbe(a.auditConn))
+	a.checker.Register(a.grpcServer)
+
+	// Enable reflection for development purposes
+	if cfg.Server.Env == "development" {
+		reflection.Register(a.grpcServer)
+	}
+
+	// Operational endpoints
+	router := http.NewServeMux()
+	router.Handle("GET /metrics", serviceMetrics.Handler())
+	router.HandleFunc("GET /healthz", a.checker.LivenessHandler())
+	router.HandleFunc("GET /readyz", a.checker.ReadinessHandler())
+	a.handler = tracing.Middleware(logging.Middleware(logger, serviceMetrics.Middleware(router)))
+
+	return a, nil
+}
+
+// GRPCServer returns the server the user service is registered on
+func (a *App) GRPCServer() *grpc.Server {
+	return a.grpcServer
+}
+
+// Handler serves the metrics and health endpoints
+func (a *App) Handler() http.Handler {
+	return a.handler
+}
+
+// Start runs the loops that keep the readiness status and certificates up to
+// date in the background until ctx is cancelled
+func (a *App) Start(ctx context.Context) {
+	go a.checker.Run(ctx, 10*time.Second)
+	go a.tlsManager.Watch(ctx)
+}
+
+// Drain reports NOT_SERVING so that load balancers stop sending traffic
+// before the server stops
+func (a *App) Drain() {
+	a.checker.Shutdown()
+}
+
+// Close stops the gRPC server gracefully and closes the connections to the
+// dependencies and the audit log
+func (a *App) Close() error {
+	if a.grpcServer != nil {
+		a.grpcServer.GracefulStop()
+	}
+
+	var errs []error
+	if a.dbClient != nil {
+		errs = append(errs, a.dbClient.Close())
+	}
+	if a.commentClient != nil {
+		errs = append(errs, a.commentClient.Close())
+	}
+	if a.streamClient != nil {
+		errs = append(errs, a.streamClient.Close())
+	}
+	if a.auditConn != nil {
+		errs = append(errs, a.auditConn.Close())
+	}
+	return errors.Join(errs...)
+}
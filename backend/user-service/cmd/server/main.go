@@ -1,82 +1,83 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"github.com/clerkinc/clerk-sdk-go/clerk"
-	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
-	"github.com/clerkinc/clerk-sdk-go"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
-
-	"github.com/Josy-coder/user-service/internal/clients"
-	"github.com/Josy-coder/user-service/internal/config"
-	"github.com/Josy-coder/user-service/internal/ports"
-	"github.com/Josy-coder/user-service/internal/service"
-	pb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/Josy-coder/user-service/app"
+	"github.com/clementus360/platform/config"
+	"github.com/clementus360/platform/logging"
+	"github.com/clementus360/platform/tracing"
 )
 
 func main() {
-	// Load configuration
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+	// Load configuration from defaults, config file, environment and flags
+	cfg, err := app.LoadConfig(os.Args[1:])
+	if cfg.PrintConfig {
+		config.Print(os.Stdout, cfg)
 	}
-
-	// Initialize Clerk client
-	clerkClient, err := clerk.NewClient(cfg.ClerkSecretKey)
 	if err != nil {
-		log.Fatalf("Failed to create Clerk client: %v", err)
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		os.Exit(2)
 	}
-
-	// Initialize database client
-	dbClient, err := clients.NewDBServiceClient(cfg.Services.DBServiceURL)
-	if err != nil {
-		log.Fatalf("Failed to create database service client: %v", err)
+	if cfg.PrintConfig {
+		return
 	}
-	defer dbClient.Close()
 
-	// Initialize comment client
-	commentClient, err := clients.NewCommentServiceClient(cfg.Services.CommentServiceURL)
+	logger := logging.New("user-service", cfg.Log.Level, cfg.Log.Format)
+	slog.SetDefault(logger)
+
+	// Set up tracing before any client is created so every connection is
+	// instrumented
+	shutdownTracing, err := tracing.Setup(context.Background(), "user-service", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.OTLPInsecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
 	if err != nil {
-		log.Fatalf("Failed to create comment service client: %v", err)
+		logger.Error("Failed to initialize tracing", "error", err)
+		os.Exit(1)
 	}
-	defer commentClient.Close()
+	defer shutdownTracing(context.Background())
 
-	// Initialize stream client
-	streamClient, err := clients.NewStreamServiceClient(cfg.Services.StreamServiceURL)
+	// Connect to the dependencies and register the user service
+	userApp, err := app.New(context.Background(), cfg, app.WithLogger(logger))
 	if err != nil {
-		log.Fatalf("Failed to create stream service client: %v", err)
+		logger.Error("Failed to initialize user service", "error", err)
+		os.Exit(1)
 	}
-	defer streamClient.Close()
-
-	// Initialize auth client
-	authClient := clients.NewAuthClient(clerkClient)
 
-	// Initialize metrics client
-	metricsClient := clients.NewMetricsClient("user_service")
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	userApp.Start(backgroundCtx)
 
-	// Initialize user service
-	userService := service.NewUserService(dbClient, clerkClient, commentClient, streamClient)
-
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
-	userServer := ports.NewGRPCServer(userService)
-	pb.RegisterUserServiceServer(grpcServer, userServer)
-
-	// Enable reflection for development purposes
-	if cfg.Server.Env == "development" {
-		reflection.Register(grpcServer)
+	// Start HTTP server for operational endpoints
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler: userApp.Handler(),
 	}
 
+	go func() {
+		logger.Info("Starting HTTP server", "port", cfg.Server.Port)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("Failed to serve HTTP", "error", err)
+			os.Exit(1)
+		}
+	}()
+
 	// Start gRPC server
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.GRPCPort))
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		logger.Error("Failed to listen", "error", err)
+		os.Exit(1)
 	}
 
 	// Handle graceful shutdown
@@ -84,12 +85,23 @@ func main() {
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 		<-sigChan
-		log.Println("Received shutdown signal")
-		grpcServer.GracefulStop()
+		logger.Info("Received shutdown signal", "drain_period", cfg.Server.DrainPeriod.String())
+
+		// Report NOT_SERVING first so load balancers drain before we stop
+		userApp.Drain()
+		time.Sleep(cfg.Server.DrainPeriod)
+
+		if err := httpServer.Shutdown(context.Background()); err != nil {
+			logger.Error("Failed to shut down HTTP server", "error", err)
+		}
+		if err := userApp.Close(); err != nil {
+			logger.Error("Failed to close service clients", "error", err)
+		}
 	}()
 
-	log.Printf("Starting gRPC server on port %d", cfg.Server.GRPCPort)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	logger.Info("Starting gRPC server", "port", cfg.Server.GRPCPort)
+	if err := userApp.GRPCServer().Serve(lis); err != nil {
+		logger.Error("Failed to serve", "error", err)
+		os.Exit(1)
 	}
 }
@@ -3,11 +3,15 @@ package clients
 import (
 	"context"
 	"errors"
-	"github.com/clerkinc/clerk-sdk-go/clerk"
 	"strings"
 
-	"github.com/clerkinc/clerk-sdk-go"
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/clementus360/platform/auth"
 )
 
 var (
@@ -83,3 +87,50 @@ func (c *AuthClient) ValidateToken(token string) error {
 	}
 	return nil
 }
+
+// UnaryServerInterceptor authenticates end users calling with a Clerk session
+// token. The Clerk user is mapped to the platform user with resolve and
+// attached to the context together with the roles stored in the
+// "roles" entry of its public metadata, so that calls to other services
+// carry the identity without verifying the Clerk token again. Calls without
+// a Clerk token are left to the service token interceptor.
+func (c *AuthClient) UnaryServerInterceptor(resolve func(ctx context.Context, clerkID string) (int32, error)) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, err := c.extractToken(ctx); errors.Is(err, ErrNoToken) {
+			return handler(ctx, req)
+		}
+
+		clerkUser, err := c.GetUserFromContext(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		ctx = auth.WithCaller(ctx, auth.Caller{})
+
+		// A user who has not been synced yet is authenticated but has no
+		// platform identity
+		if id, err := resolve(ctx, clerkUser.ID); err == nil {
+			ctx = auth.WithUser(ctx, auth.User{ID: int64(id), Roles: roles(clerkUser)})
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func roles(user *clerk.User) []string {
+	metadata, ok := user.PublicMetadata.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	values, ok := metadata["roles"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var roles []string
+	for _, v := range values {
+		if role, ok := v.(string); ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
@@ -5,8 +5,8 @@ import (
 	"fmt"
 
 	pb "github.com/Josy-coder/comment-service/proto/comment/v1"
+	"github.com/clementus360/platform/dial"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type CommentServiceClient struct {
@@ -14,8 +14,15 @@ type CommentServiceClient struct {
 	conn   *grpc.ClientConn
 }
 
-func NewCommentServiceClient(address string) (*CommentServiceClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewCommentServiceClient(address string, dialer *dial.Dialer) (*CommentServiceClient, error) {
+	conn, err := dialer.Dial(dial.Target{
+		Name:       "comment_service",
+		Audience:   "comment-service",
+		Address:    address,
+		Service:    pb.CommentService_ServiceDesc.ServiceName,
+		Idempotent: []string{"ListComments"},
+		Streaming:  dial.StreamingMethods(pb.CommentService_ServiceDesc),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to comment service: %w", err)
 	}
@@ -31,15 +38,30 @@ func (c *CommentServiceClient) Close() error {
 	return c.conn.Close()
 }
 
+// Conn exposes the underlying connection for health probes
+func (c *CommentServiceClient) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
 // GetUserComments gets all comments for a user
 func (c *CommentServiceClient) GetUserComments(ctx context.Context, userID int32) ([]*pb.Comment, error) {
-	resp, err := c.client.ListComments(ctx, &pb.ListCommentsRequest{
-		UserId: &userID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user comments: %w", err)
+	var comments []*pb.Comment
+	pageSize := int32(listPageSize)
+	for page := int32(1); ; page++ {
+		resp, err := c.client.ListComments(ctx, &pb.ListCommentsRequest{
+			UserId:   &userID,
+			Page:     &page,
+			PageSize: &pageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user comments: %w", err)
+		}
+		comments = append(comments, resp.Comments...)
+
+		if len(resp.Comments) == 0 || int32(len(comments)) >= resp.TotalCount {
+			return comments, nil
+		}
 	}
-	return resp.Comments, nil
 }
 
 // DeleteUserComments deletes all comments for a user
@@ -6,8 +6,10 @@ import (
 
 	pb "github.com/Josy-coder/db-service/proto/db/v1"
 	"github.com/Josy-coder/user-service/internal/domain"
+	"github.com/clementus360/platform/dial"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -16,8 +18,14 @@ type DBServiceClient struct {
 	conn   *grpc.ClientConn
 }
 
-func NewDBServiceClient(address string) (*DBServiceClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewDBServiceClient(address string, dialer *dial.Dialer) (*DBServiceClient, error) {
+	conn, err := dialer.Dial(dial.Target{
+		Name:       "database_service",
+		Audience:   "database-service",
+		Address:    address,
+		Service:    pb.DatabaseService_ServiceDesc.ServiceName,
+		Idempotent: []string{"GetUser", "GetUserByClerkID", "GetUserByEmail", "GetUserByUsername", "ListUsers"},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database service: %w", err)
 	}
@@ -33,13 +41,21 @@ func (c *DBServiceClient) Close() error {
 	return c.conn.Close()
 }
 
+// Conn exposes the underlying connection for health probes
+func (c *DBServiceClient) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
 func (c *DBServiceClient) CreateUser(ctx context.Context, user *domain.User) error {
-	_, err := c.client.CreateUser(ctx, &pb.CreateUserRequest{
+	resp, err := c.client.CreateUser(ctx, &pb.CreateUserRequest{
 		User: toProtoUser(user),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
+
+	// The database assigns the id
+	user.ID = resp.User.GetId()
 	return nil
 }
 
@@ -48,7 +64,7 @@ func (c *DBServiceClient) GetUser(ctx context.Context, id int32) (*domain.User,
 		Id: id,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, mapError("get user", err)
 	}
 	return toDomainUser(resp.User), nil
 }
@@ -58,7 +74,7 @@ func (c *DBServiceClient) GetUserByClerkID(ctx context.Context, clerkID string)
 		ClerkId: clerkID,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user by clerk ID: %w", err)
+		return nil, mapError("get user by clerk ID", err)
 	}
 	return toDomainUser(resp.User), nil
 }
@@ -68,7 +84,7 @@ func (c *DBServiceClient) GetUserByEmail(ctx context.Context, email string) (*do
 		Email: email,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user by email: %w", err)
+		return nil, mapError("get user by email", err)
 	}
 	return toDomainUser(resp.User), nil
 }
@@ -78,7 +94,7 @@ func (c *DBServiceClient) GetUserByUsername(ctx context.Context, username string
 		Username: username,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user by username: %w", err)
+		return nil, mapError("get user by username", err)
 	}
 	return toDomainUser(resp.User), nil
 }
@@ -88,7 +104,7 @@ func (c *DBServiceClient) UpdateUser(ctx context.Context, user *domain.User) err
 		User: toProtoUser(user),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+		return mapError("update user", err)
 	}
 	return nil
 }
@@ -98,7 +114,7 @@ func (c *DBServiceClient) DeleteUser(ctx context.Context, id int32) error {
 		Id: id,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+		return mapError("delete user", err)
 	}
 	return nil
 }
@@ -120,6 +136,14 @@ func (c *DBServiceClient) ListUsers(ctx context.Context, filter domain.UserFilte
 	return users, resp.TotalCount, nil
 }
 
+// mapError translates the status of a missing user into the domain error
+func mapError(action string, err error) error {
+	if status.Code(err) == codes.NotFound {
+		return domain.ErrUserNotFound
+	}
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
+
 func toProtoUser(u *domain.User) *pb.User {
 	if u == nil {
 		return nil
@@ -4,18 +4,30 @@ import (
 	"context"
 	"fmt"
 
-	pb "github.com/Josy-coder/stream-service/proto/stream/v1"
+	"github.com/Josy-coder/user-service/internal/domain"
+	"github.com/clementus360/platform/dial"
+	pb "github.com/clementus360/stream-service/proto"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// listPageSize is the largest page the database service returns
+const listPageSize = 10
+
 type StreamServiceClient struct {
 	client pb.StreamServiceClient
 	conn   *grpc.ClientConn
 }
 
-func NewStreamServiceClient(address string) (*StreamServiceClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewStreamServiceClient(address string, dialer *dial.Dialer) (*StreamServiceClient, error) {
+	conn, err := dialer.Dial(dial.Target{
+		Name:       "stream_service",
+		Audience:   "stream-service",
+		Address:    address,
+		Service:    pb.StreamService_ServiceDesc.ServiceName,
+		Idempotent: []string{"ListStreams"},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to stream service: %w", err)
 	}
@@ -31,24 +43,52 @@ func (c *StreamServiceClient) Close() error {
 	return c.conn.Close()
 }
 
+// Conn exposes the underlying connection for health probes
+func (c *StreamServiceClient) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
 // GetUserStreams gets all streams for a user
-func (c *StreamServiceClient) GetUserStreams(ctx context.Context, userID int32) ([]*pb.Stream, error) {
-	resp, err := c.client.ListStreams(ctx, &pb.ListStreamsRequest{
-		UserId: &userID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user streams: %w", err)
+func (c *StreamServiceClient) GetUserStreams(ctx context.Context, userID int32) ([]*pb.StreamResponse, error) {
+	var streams []*pb.StreamResponse
+	for page := int32(1); ; page++ {
+		resp, err := c.client.ListStreams(ctx, &pb.ListStreamsRequest{
+			PageSize:   listPageSize,
+			PageNumber: page,
+			Filter:     &pb.StreamFilter{UserId: userID},
+			SortBy:     "id",
+			Ascending:  true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user streams: %w", err)
+		}
+		streams = append(streams, resp.Streams...)
+
+		// The database service clamps pages past the end to the last one
+		if page >= resp.GetMetaData().GetTotalPages() {
+			return streams, nil
+		}
 	}
-	return resp.Streams, nil
 }
 
 // DeleteUserStreams deletes all streams for a user
 func (c *StreamServiceClient) DeleteUserStreams(ctx context.Context, userID int32) error {
-	_, err := c.client.DeleteUserStreams(ctx, &pb.DeleteUserStreamsRequest{
-		UserId: userID,
-	})
+	streams, err := c.GetUserStreams(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("failed to delete user streams: %w", err)
+		return err
+	}
+
+	for _, stream := range streams {
+		_, err := c.client.DeleteStream(ctx, &pb.DeleteStreamRequest{
+			Id: stream.Id,
+		})
+		// The database service refuses to delete a live stream
+		if status.Code(err) == codes.FailedPrecondition {
+			return fmt.Errorf("failed to delete stream %d: %w", stream.Id, domain.ErrActiveStreams)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete stream %d: %w", stream.Id, err)
+		}
 	}
 	return nil
 }
@@ -5,8 +5,8 @@ import (
 	"fmt"
 
 	pb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/dial"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type UserServiceClient struct {
@@ -14,8 +14,14 @@ type UserServiceClient struct {
 	conn   *grpc.ClientConn
 }
 
-func NewUserServiceClient(address string) (*UserServiceClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewUserServiceClient(address string, dialer *dial.Dialer) (*UserServiceClient, error) {
+	conn, err := dialer.Dial(dial.Target{
+		Name:       "user_service",
+		Audience:   "user-service",
+		Address:    address,
+		Service:    pb.UserService_ServiceDesc.ServiceName,
+		Idempotent: []string{"GetUser"},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to user service: %w", err)
 	}
@@ -31,6 +37,11 @@ func (c *UserServiceClient) Close() error {
 	return c.conn.Close()
 }
 
+// Conn exposes the underlying connection for health probes
+func (c *UserServiceClient) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
 func (c *UserServiceClient) GetUser(ctx context.Context, userID int32) error {
 	_, err := c.client.GetUser(ctx, &pb.GetUserRequest{
 		Id: userID,
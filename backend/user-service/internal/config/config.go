@@ -1,48 +1,82 @@
 package config
 
 import (
-	"github.com/joho/godotenv"
-	"os"
-	"strconv"
+	"errors"
+	"fmt"
+	"time"
+
+	platformconfig "github.com/clementus360/platform/config"
 )
 
 type Config struct {
-	Server         ServerConfig
-	Services       ServicesConfig
-	ClerkSecretKey string
+	Server         ServerConfig                 `yaml:"server"`
+	Services       ServicesConfig               `yaml:"services"`
+	Log            platformconfig.LogConfig     `yaml:"log"`
+	Tracing        platformconfig.TracingConfig `yaml:"tracing"`
+	TLS            platformconfig.TLSConfig     `yaml:"tls"`
+	Auth           platformconfig.AuthConfig    `yaml:"auth"`
+	Client         platformconfig.ClientConfig  `yaml:"client"`
+	Audit          AuditConfig                  `yaml:"audit"`
+	ClerkSecretKey string                       `yaml:"clerk_secret_key" env:"CLERK_SECRET_KEY" required:"true" secret:"true"`
+	// ClerkAPIURL overrides the Clerk Backend API, e.g. to point at a fake
+	// identity provider in tests
+	ClerkAPIURL string `yaml:"clerk_api_url" env:"CLERK_API_URL"`
+
+	// PrintConfig dumps the effective configuration and exits
+	PrintConfig bool `yaml:"-" flag:"print-config"`
 }
 
 type ServerConfig struct {
-	Port     int
-	GRPCPort int
-	Env      string
+	Port        int           `yaml:"port" env:"SERVER_PORT" flag:"port" default:"9092" required:"true"`
+	GRPCPort    int           `yaml:"grpc_port" env:"SERVER_GRPC_PORT" flag:"grpc-port" default:"50052" required:"true"`
+	Env         string        `yaml:"env" env:"SERVER_ENV" flag:"env" default:"production"`
+	DrainPeriod time.Duration `yaml:"drain_period" env:"SERVER_DRAIN_PERIOD" flag:"drain-period" default:"5s"`
 }
 
 type ServicesConfig struct {
-	DBServiceURL      string
-	CommentServiceURL string
-	StreamServiceURL  string
+	DBServiceURL      string `yaml:"db_service_url" env:"DB_SERVICE_URL" flag:"db-service-url" required:"true"`
+	CommentServiceURL string `yaml:"comment_service_url" env:"COMMENT_SERVICE_URL" flag:"comment-service-url" required:"true"`
+	StreamServiceURL  string `yaml:"stream_service_url" env:"STREAM_SERVICE_URL" flag:"stream-service-url" required:"true"`
+}
+
+// AuditConfig controls where the audit log of mutations is kept
+type AuditConfig struct {
+	// Address of StreamDb, which keeps the audit log of every service
+	Address string `yaml:"address" env:"AUDIT_DB_ADDRESS" flag:"audit-db-address" required:"true"`
 }
 
-func LoadConfig() (*Config, error) {
-	if err := godotenv.Load(); err != nil {
-		return nil, err
+// LoadConfig reads the configuration from defaults, an optional YAML file,
+// the environment and the command-line arguments
+func LoadConfig(args []string) (*Config, error) {
+	cfg := &Config{}
+	if err := platformconfig.Load("user-service", cfg, args); err != nil {
+		return cfg, err
 	}
+	return cfg, nil
+}
+
+// Validate checks values that cannot be expressed with struct tags
+func (c *Config) Validate() error {
+	var errs []error
 
-	grpcPort, _ := strconv.Atoi(os.Getenv("SERVER_GRPC_PORT"))
-	port, _ := strconv.Atoi(os.Getenv("SERVER_PORT"))
-
-	return &Config{
-		Server: ServerConfig{
-			Port:     port,
-			GRPCPort: grpcPort,
-			Env:      os.Getenv("SERVER_ENV"),
-		},
-		Services: ServicesConfig{
-			DBServiceURL:      os.Getenv("DB_SERVICE_URL"),
-			CommentServiceURL: os.Getenv("COMMENT_SERVICE_URL"),
-			StreamServiceURL:  os.Getenv("STREAM_SERVICE_URL"),
-		},
-		ClerkSecretKey: os.Getenv("CLERK_SECRET_KEY"),
-	}, nil
+	if err := validatePort("SERVER_PORT", c.Server.Port); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validatePort("SERVER_GRPC_PORT", c.Server.GRPCPort); err != nil {
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, c.Tracing.Validate()...)
+	errs = append(errs, c.TLS.Validate()...)
+	errs = append(errs, c.Auth.Validate()...)
+	errs = append(errs, c.Client.Validate()...)
+
+	return errors.Join(errs...)
+}
+
+func validatePort(name string, port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("%s must be a port number between 1 and 65535, got %d", name, port)
+	}
+	return nil
 }
@@ -13,6 +13,9 @@ var (
 	ErrClerkIDRequired = errors.New("clerk ID is required")
 	ErrUsernameTaken   = errors.New("username already taken")
 	ErrEmailTaken      = errors.New("email already taken")
+	ErrForbidden       = errors.New("not allowed to act on behalf of another user")
+	ErrUnauthenticated = errors.New("the call is not made on behalf of a user")
+	ErrActiveStreams   = errors.New("user has live or scheduled streams")
 )
 
 type User struct {
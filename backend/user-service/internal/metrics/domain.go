@@ -0,0 +1,29 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// domain holds the user-specific business metrics
+type domain struct {
+	userSyncs *prometheus.CounterVec
+}
+
+func newDomain(namespace string, registry *prometheus.Registry) domain {
+	d := domain{
+		userSyncs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_syncs_total",
+			Help:      "Total user synchronisations with Clerk, by result.",
+		}, []string{"result"}),
+	}
+	registry.MustRegister(d.userSyncs)
+	return d
+}
+
+// UserSynced counts a synchronisation with Clerk and whether it succeeded
+func (m *Metrics) UserSynced(err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.userSyncs.WithLabelValues(result).Inc()
+}
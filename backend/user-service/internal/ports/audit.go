@@ -0,0 +1,105 @@
+package ports
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Josy-coder/user-service/internal/domain"
+	"github.com/Josy-coder/user-service/internal/service"
+	pb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/audit"
+)
+
+const auditTargetUser = "user"
+
+// AuditedMethods describes how the mutations of the user service are
+// audited. Syncing a user that is not stored yet is recorded as an update
+// with no previous values.
+func AuditedMethods(svc *service.UserService) map[string]audit.Method {
+	before := func(user *domain.User, err error) proto.Message {
+		if err != nil {
+			return nil
+		}
+		return toProtoUser(user)
+	}
+	after := func(resp any) proto.Message { return resp.(*pb.UserResponse).User }
+	userID := func(req, resp any) string {
+		return strconv.Itoa(int(resp.(*pb.UserResponse).User.GetId()))
+	}
+
+	return map[string]audit.Method{
+		pb.UserService_CreateUser_FullMethodName: {
+			Action: audit.ActionCreate, TargetType: auditTargetUser,
+			Target: userID, After: after,
+		},
+		pb.UserService_UpdateUser_FullMethodName: {
+			Action: audit.ActionUpdate, TargetType: auditTargetUser,
+			Target: userID, After: after,
+			Before: func(ctx context.Context, req any) proto.Message {
+				return before(svc.GetUser(ctx, req.(*pb.UpdateUserRequest).Id))
+			},
+		},
+		pb.UserService_DeleteUser_FullMethodName: {
+			Action: audit.ActionDelete, TargetType: auditTargetUser,
+			Target: func(req, resp any) string { return strconv.Itoa(int(req.(*pb.DeleteUserRequest).Id)) },
+			Before: func(ctx context.Context, req any) proto.Message {
+				return before(svc.GetUser(ctx, req.(*pb.DeleteUserRequest).Id))
+			},
+		},
+		pb.UserService_SyncUserWithClerk_FullMethodName: {
+			Action: audit.ActionUpdate, TargetType: auditTargetUser,
+			Target: userID, After: after,
+			Before: func(ctx context.Context, req any) proto.Message {
+				return before(svc.GetUserByClerkID(ctx, req.(*pb.SyncUserWithClerkRequest).ClerkId))
+			},
+		},
+	}
+}
+
+func (s *GRPCServer) QueryAuditLog(ctx context.Context, req *pb.QueryAuditLogRequest) (*pb.QueryAuditLogResponse, error) {
+	page, err := audit.Serve(ctx, s.audit, audit.Query{
+		ActorID:      req.ActorId,
+		ActorService: req.ActorService,
+		TargetType:   req.TargetType,
+		TargetID:     req.TargetId,
+		Since:        audit.Timestamp(req.Since),
+		Until:        audit.Timestamp(req.Until),
+		Page:         int(req.Page),
+		PageSize:     int(req.PageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.QueryAuditLogResponse{TotalCount: int32(page.Total)}
+	for _, entry := range page.Entries {
+		resp.Entries = append(resp.Entries, toProtoAuditEntry(entry))
+	}
+	return resp, nil
+}
+
+func toProtoAuditEntry(entry audit.Entry) *pb.AuditEntry {
+	protoEntry := &pb.AuditEntry{
+		Id:           entry.ID,
+		Time:         timestamppb.New(entry.Time),
+		Service:      entry.Service,
+		Method:       entry.Method,
+		Action:       entry.Action,
+		ActorId:      entry.ActorID,
+		ActorRoles:   entry.ActorRoles,
+		ActorService: entry.ActorService,
+		TargetType:   entry.TargetType,
+		TargetId:     entry.TargetID,
+		RequestId:    entry.RequestID,
+	}
+	for _, change := range entry.Changes {
+		protoEntry.Changes = append(protoEntry.Changes, &pb.AuditChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return protoEntry
+}
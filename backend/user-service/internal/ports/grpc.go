@@ -5,8 +5,10 @@ import (
 	"errors"
 
 	"github.com/Josy-coder/user-service/internal/domain"
+	"github.com/Josy-coder/user-service/internal/metrics"
 	"github.com/Josy-coder/user-service/internal/service"
 	pb "github.com/Josy-coder/user-service/proto/user/v1"
+	"github.com/clementus360/platform/audit"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -15,12 +17,16 @@ import (
 
 type GRPCServer struct {
 	pb.UnimplementedUserServiceServer
-	svc *service.UserService
+	svc     *service.UserService
+	metrics *metrics.Metrics
+	audit   *audit.Log
 }
 
-func NewGRPCServer(svc *service.UserService) pb.UserServiceServer {
+func NewGRPCServer(svc *service.UserService, m *metrics.Metrics, auditLog *audit.Log) pb.UserServiceServer {
 	return &GRPCServer{
-		svc: svc,
+		svc:     svc,
+		metrics: m,
+		audit:   auditLog,
 	}
 }
 
@@ -41,6 +47,10 @@ func (s *GRPCServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 			return nil, status.Error(codes.AlreadyExists, "email already taken")
 		case errors.Is(err, domain.ErrUsernameTaken):
 			return nil, status.Error(codes.AlreadyExists, "username already taken")
+		case errors.Is(err, domain.ErrForbidden):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, domain.ErrUnauthenticated):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
 		default:
 			return nil, status.Error(codes.Internal, err.Error())
 		}
@@ -95,6 +105,10 @@ func (s *GRPCServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 			return nil, status.Error(codes.NotFound, "user not found")
 		case errors.Is(err, domain.ErrUsernameTaken):
 			return nil, status.Error(codes.AlreadyExists, "username already taken")
+		case errors.Is(err, domain.ErrForbidden):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, domain.ErrUnauthenticated):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
 		default:
 			return nil, status.Error(codes.Internal, err.Error())
 		}
@@ -111,6 +125,15 @@ func (s *GRPCServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest)
 		if errors.Is(err, domain.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if errors.Is(err, domain.ErrActiveStreams) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -141,6 +164,7 @@ func (s *GRPCServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*
 
 func (s *GRPCServer) SyncUserWithClerk(ctx context.Context, req *pb.SyncUserWithClerkRequest) (*pb.UserResponse, error) {
 	user, err := s.svc.SyncUserWithClerk(ctx, req.ClerkId)
+	s.metrics.UserSynced(err)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -6,7 +6,8 @@ import (
 	"time"
 
 	"github.com/Josy-coder/user-service/internal/domain"
-	"github.com/clerkinc/clerk-sdk-go"
+	"github.com/clementus360/platform/auth"
+	"github.com/clerkinc/clerk-sdk-go/clerk"
 )
 
 var (
@@ -19,15 +20,29 @@ const (
 	MaxPageSize     = 100
 )
 
+// CommentClient removes the comments of a deleted user
+type CommentClient interface {
+	DeleteUserComments(ctx context.Context, userID int32) error
+}
+
+// StreamClient removes the streams of a deleted user
+type StreamClient interface {
+	DeleteUserStreams(ctx context.Context, userID int32) error
+}
+
 type UserService struct {
-	repo        domain.UserRepository
-	clerkClient clerk.Client
+	repo          domain.UserRepository
+	clerkClient   clerk.Client
+	commentClient CommentClient
+	streamClient  StreamClient
 }
 
-func NewUserService(repo domain.UserRepository, clerkClient clerk.Client) *UserService {
+func NewUserService(repo domain.UserRepository, clerkClient clerk.Client, commentClient CommentClient, streamClient StreamClient) *UserService {
 	return &UserService{
-		repo:        repo,
-		clerkClient: clerkClient,
+		repo:          repo,
+		clerkClient:   clerkClient,
+		commentClient: commentClient,
+		streamClient:  streamClient,
 	}
 }
 
@@ -67,6 +82,10 @@ func (s *UserService) GetUserByClerkID(ctx context.Context, clerkID string) (*do
 }
 
 func (s *UserService) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	if err := authorize(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
 	existingUser, err := s.repo.GetUser(ctx, user.ID)
 	if err != nil {
 		return nil, err
@@ -88,11 +107,29 @@ func (s *UserService) UpdateUser(ctx context.Context, user *domain.User) (*domai
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, id int32) error {
+	if err := authorize(ctx, id); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.GetUser(ctx, id); err != nil {
+		return err
+	}
+
+	// Remove the user's content before the account so that a failure, such
+	// as a stream that is still live, leaves the account in place and the
+	// deletion can be retried
+	if err := s.streamClient.DeleteUserStreams(ctx, id); err != nil {
+		return err
+	}
+	if err := s.commentClient.DeleteUserComments(ctx, id); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteUser(ctx, id)
 }
 
 func (s *UserService) ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int32, error) {
-	if err := validateFilter(filter); err != nil {
+	if err := validateFilter(&filter); err != nil {
 		return nil, 0, err
 	}
 
@@ -117,9 +154,9 @@ func (s *UserService) SyncUserWithClerk(ctx context.Context, clerkID string) (*d
 		user = &domain.User{
 			ClerkID:         clerkID,
 			Email:           clerkUser.EmailAddresses[0].EmailAddress,
-			Username:        clerkUser.Username,
-			FirstName:       clerkUser.FirstName,
-			LastName:        clerkUser.LastName,
+			Username:        stringValue(clerkUser.Username),
+			FirstName:       stringValue(clerkUser.FirstName),
+			LastName:        stringValue(clerkUser.LastName),
 			ProfileImageURL: clerkUser.ProfileImageURL,
 		}
 		return s.CreateUser(ctx, user)
@@ -127,16 +164,37 @@ func (s *UserService) SyncUserWithClerk(ctx context.Context, clerkID string) (*d
 
 	// Update existing user with Clerk data
 	user.Email = clerkUser.EmailAddresses[0].EmailAddress
-	user.Username = clerkUser.Username
-	user.FirstName = clerkUser.FirstName
-	user.LastName = clerkUser.LastName
+	user.Username = stringValue(clerkUser.Username)
+	user.FirstName = stringValue(clerkUser.FirstName)
+	user.LastName = stringValue(clerkUser.LastName)
 	user.ProfileImageURL = clerkUser.ProfileImageURL
 	user.LastLogin = time.Now()
 
 	return s.UpdateUser(ctx, user)
 }
 
-func validateFilter(filter domain.UserFilter) error {
+// authorize checks that the end user behind the call may manage the account
+// userID. Calls without a user are only allowed for the services listed in
+// AUTH_TRUSTED_CALLERS.
+func authorize(ctx context.Context, userID int32) error {
+	switch err := auth.Authorize(ctx, int64(userID)); {
+	case errors.Is(err, auth.ErrNoUser):
+		return domain.ErrUnauthenticated
+	case err != nil:
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// stringValue dereferences the optional fields of Clerk users
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func validateFilter(filter *domain.UserFilter) error {
 	if filter.Page < 1 {
 		return ErrInvalidPage
 	}
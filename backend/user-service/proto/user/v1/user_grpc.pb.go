@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v5.29.3
+// - protoc             v5.29.2
 // source: proto/user/v1/user.proto
 
 package userv1
@@ -27,6 +27,7 @@ const (
 	UserService_ListUsers_FullMethodName         = "/user.v1.UserService/ListUsers"
 	UserService_GetUserByClerkID_FullMethodName  = "/user.v1.UserService/GetUserByClerkID"
 	UserService_SyncUserWithClerk_FullMethodName = "/user.v1.UserService/SyncUserWithClerk"
+	UserService_QueryAuditLog_FullMethodName     = "/user.v1.UserService/QueryAuditLog"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -40,6 +41,7 @@ type UserServiceClient interface {
 	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
 	GetUserByClerkID(ctx context.Context, in *GetUserByClerkIDRequest, opts ...grpc.CallOption) (*UserResponse, error)
 	SyncUserWithClerk(ctx context.Context, in *SyncUserWithClerkRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
 }
 
 type userServiceClient struct {
@@ -120,6 +122,16 @@ func (c *userServiceClient) SyncUserWithClerk(ctx context.Context, in *SyncUserW
 	return out, nil
 }
 
+func (c *userServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, UserService_QueryAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -131,6 +143,7 @@ type UserServiceServer interface {
 	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
 	GetUserByClerkID(context.Context, *GetUserByClerkIDRequest) (*UserResponse, error)
 	SyncUserWithClerk(context.Context, *SyncUserWithClerkRequest) (*UserResponse, error)
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -162,6 +175,9 @@ func (UnimplementedUserServiceServer) GetUserByClerkID(context.Context, *GetUser
 func (UnimplementedUserServiceServer) SyncUserWithClerk(context.Context, *SyncUserWithClerkRequest) (*UserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SyncUserWithClerk not implemented")
 }
+func (UnimplementedUserServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -309,6 +325,24 @@ func _UserService_SyncUserWithClerk_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).QueryAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_QueryAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -344,6 +378,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SyncUserWithClerk",
 			Handler:    _UserService_SyncUserWithClerk_Handler,
 		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _UserService_QueryAuditLog_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/user/v1/user.proto",